@@ -0,0 +1,186 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+	"sigs.k8s.io/yaml"
+)
+
+// crdSchemaValidator returns a validator for the openAPIV3Schema of the DNSRecord CRD manifest,
+// the same kind of structural validation the API server performs on creation.
+func crdSchemaValidator(t *testing.T) *validate.SchemaValidator {
+	t.Helper()
+	data, err := os.ReadFile("../../../config/crd/standard/dnsrecord.yaml")
+	require.NoError(t, err)
+
+	var crd struct {
+		Spec struct {
+			Versions []struct {
+				Name   string `json:"name"`
+				Schema struct {
+					OpenAPIV3Schema json.RawMessage `json:"openAPIV3Schema"`
+				} `json:"schema"`
+			} `json:"versions"`
+		} `json:"spec"`
+	}
+	require.NoError(t, yaml.Unmarshal(data, &crd))
+	require.Len(t, crd.Spec.Versions, 1)
+	require.Equal(t, GroupVersion.Version, crd.Spec.Versions[0].Name)
+
+	schema := &spec.Schema{}
+	require.NoError(t, json.Unmarshal(crd.Spec.Versions[0].Schema.OpenAPIV3Schema, schema))
+	return validate.NewSchemaValidator(schema, nil, "", strfmt.Default)
+}
+
+func TestDNSRecordValidation(t *testing.T) {
+	validator := crdSchemaValidator(t)
+
+	tests := []struct {
+		name  string
+		spec  string
+		error string
+	}{
+		{
+			name: "valid record",
+			spec: `{"name": "www.example.com", "recordType": "A", "targets": ["1.2.3.4"], "ttl": 300}`,
+		},
+		{
+			name: "valid wildcard record with trailing dot",
+			spec: `{"name": "*.apps.example.com.", "recordType": "CNAME", "targets": ["lb.example.org"]}`,
+		},
+		{
+			name: "valid SRV record",
+			spec: `{"name": "_sip._tcp.example.com", "recordType": "SRV", "targets": ["10 5 5060 sip.example.com"], "setIdentifier": "eu"}`,
+		},
+		{
+			name:  "missing name",
+			spec:  `{"recordType": "A", "targets": ["1.2.3.4"]}`,
+			error: "spec.name in body is required",
+		},
+		{
+			name:  "missing record type",
+			spec:  `{"name": "www.example.com", "targets": ["1.2.3.4"]}`,
+			error: "spec.recordType in body is required",
+		},
+		{
+			name:  "missing targets",
+			spec:  `{"name": "www.example.com", "recordType": "A"}`,
+			error: "spec.targets in body is required",
+		},
+		{
+			name:  "empty targets",
+			spec:  `{"name": "www.example.com", "recordType": "A", "targets": []}`,
+			error: "spec.targets in body should have at least 1 items",
+		},
+		{
+			name:  "empty target",
+			spec:  `{"name": "www.example.com", "recordType": "A", "targets": [""]}`,
+			error: "spec.targets[0] in body should be at least 1 chars long",
+		},
+		{
+			name:  "unsupported record type",
+			spec:  `{"name": "www.example.com", "recordType": "SOA", "targets": ["ns.example.com"]}`,
+			error: "spec.recordType in body should be one of",
+		},
+		{
+			name:  "lowercase record type",
+			spec:  `{"name": "www.example.com", "recordType": "a", "targets": ["1.2.3.4"]}`,
+			error: "spec.recordType in body should be one of",
+		},
+		{
+			name:  "name is not a FQDN",
+			spec:  `{"name": "localhost", "recordType": "A", "targets": ["1.2.3.4"]}`,
+			error: "spec.name in body should match",
+		},
+		{
+			name:  "name with invalid characters",
+			spec:  `{"name": "www.exa mple.com", "recordType": "A", "targets": ["1.2.3.4"]}`,
+			error: "spec.name in body should match",
+		},
+		{
+			name:  "name with a label exceeding 63 characters",
+			spec:  `{"name": "` + strings.Repeat("a", 64) + `.example.com", "recordType": "A", "targets": ["1.2.3.4"]}`,
+			error: "spec.name in body should match",
+		},
+		{
+			name:  "name exceeding 253 characters",
+			spec:  `{"name": "` + strings.Repeat("a.", 127) + `com", "recordType": "A", "targets": ["1.2.3.4"]}`,
+			error: "spec.name in body should be at most 253 chars long",
+		},
+		{
+			name:  "wildcard in the middle of the name",
+			spec:  `{"name": "www.*.example.com", "recordType": "A", "targets": ["1.2.3.4"]}`,
+			error: "spec.name in body should match",
+		},
+		{
+			name:  "zero ttl",
+			spec:  `{"name": "www.example.com", "recordType": "A", "targets": ["1.2.3.4"], "ttl": 0}`,
+			error: "spec.ttl in body should be greater than or equal to 1",
+		},
+		{
+			name:  "ttl out of range",
+			spec:  `{"name": "www.example.com", "recordType": "A", "targets": ["1.2.3.4"], "ttl": 2147483648}`,
+			error: "spec.ttl in body should be less than or equal to",
+		},
+		{
+			name:  "provider specific property without name",
+			spec:  `{"name": "www.example.com", "recordType": "A", "targets": ["1.2.3.4"], "providerSpecific": [{"value": "10"}]}`,
+			error: "spec.providerSpecific[0].name in body is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			object := map[string]any{
+				"apiVersion": GroupVersion.String(),
+				"kind":       "DNSRecord",
+				"metadata":   map[string]any{"name": "test", "namespace": "default"},
+			}
+			var recordSpec map[string]any
+			require.NoError(t, json.Unmarshal([]byte(tt.spec), &recordSpec))
+			object["spec"] = recordSpec
+
+			result := validator.Validate(object)
+			if tt.error == "" {
+				assert.True(t, result.IsValid(), "%v", result.Errors)
+				return
+			}
+			require.False(t, result.IsValid())
+			require.NotEmpty(t, result.Errors)
+			assert.ErrorContains(t, result.AsError(), tt.error)
+		})
+	}
+}
+
+func TestDNSRecordValidationRequiresSpec(t *testing.T) {
+	result := crdSchemaValidator(t).Validate(map[string]any{
+		"apiVersion": GroupVersion.String(),
+		"kind":       "DNSRecord",
+		"metadata":   map[string]any{"name": "test"},
+	})
+	assert.ErrorContains(t, result.AsError(), "spec in body is required")
+}
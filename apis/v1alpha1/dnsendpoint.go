@@ -54,9 +54,27 @@ type DNSEndpointSpec struct {
 	Endpoints []*endpoint.Endpoint `json:"endpoints,omitempty"`
 }
 
+// DNSEndpointFinalizer is added to DNSEndpoints so that their records are removed before they are deleted.
+const DNSEndpointFinalizer = "external-dns.alpha.kubernetes.io/cleanup"
+
+// Condition types reported in the status of a DNSEndpoint.
+const (
+	// DNSEndpointReady indicates that the records of the latest generation are synchronized.
+	DNSEndpointReady = "Ready"
+	// DNSEndpointSynced indicates whether the last synchronization with the DNS provider succeeded.
+	DNSEndpointSynced = "Synced"
+	// DNSEndpointError indicates that the last synchronization failed, the message holds the error.
+	DNSEndpointError = "Error"
+)
+
 // DNSEndpointStatus defines the observed state of DNSEndpoint
 type DNSEndpointStatus struct {
 	// The generation observed by the external-dns controller.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions describe the state of the synchronization of the endpoints.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
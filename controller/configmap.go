@@ -0,0 +1,295 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/registry"
+	"sigs.k8s.io/external-dns/source"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+// configMapSetting is a setting of the ConfigMap which is applied without restarting.
+type configMapSetting struct {
+	// rebuildsSource is true when the sources are rebuilt on a change, the registry is rebuilt otherwise
+	rebuildsSource bool
+	apply          func(cfg *externaldns.Config, value string) error
+}
+
+// configMapSettings are the settings of the ConfigMap applied without restarting, keyed by their flag names. The
+// other settings require a restart and are ignored.
+var configMapSettings = map[string]configMapSetting{
+	"annotation-filter": {rebuildsSource: true, apply: func(cfg *externaldns.Config, value string) error {
+		if _, err := labels.Parse(value); err != nil {
+			return err
+		}
+		cfg.AnnotationFilter = value
+		return nil
+	}},
+	"label-filter": {rebuildsSource: true, apply: func(cfg *externaldns.Config, value string) error {
+		if _, err := labels.Parse(value); err != nil {
+			return err
+		}
+		cfg.LabelFilter = value
+		return nil
+	}},
+	"domain-filter": {apply: func(cfg *externaldns.Config, value string) error {
+		cfg.DomainFilter = splitConfigMapList(value)
+		return nil
+	}},
+	"exclude-domains": {apply: func(cfg *externaldns.Config, value string) error {
+		cfg.ExcludeDomains = splitConfigMapList(value)
+		return nil
+	}},
+	"regex-domain-filter": {apply: func(cfg *externaldns.Config, value string) (err error) {
+		cfg.RegexDomainFilter, err = regexp.Compile(value)
+		return err
+	}},
+	"regex-domain-exclusion": {apply: func(cfg *externaldns.Config, value string) (err error) {
+		cfg.RegexDomainExclusion, err = regexp.Compile(value)
+		return err
+	}},
+	"zone-name-filter": {apply: func(cfg *externaldns.Config, value string) error {
+		cfg.ZoneNameFilter = splitConfigMapList(value)
+		return nil
+	}},
+	"zone-id-filter": {apply: func(cfg *externaldns.Config, value string) error {
+		cfg.ZoneIDFilter = splitConfigMapList(value)
+		return nil
+	}},
+	"godaddy-api-ttl": {apply: func(cfg *externaldns.Config, value string) (err error) {
+		cfg.GoDaddyTTL, err = strconv.ParseInt(value, 10, 64)
+		return err
+	}},
+	"ns1-min-ttl": {apply: func(cfg *externaldns.Config, value string) (err error) {
+		cfg.NS1MinTTLSeconds, err = strconv.Atoi(value)
+		return err
+	}},
+	"rfc2136-min-ttl": {apply: func(cfg *externaldns.Config, value string) (err error) {
+		cfg.RFC2136MinTTL, err = time.ParseDuration(value)
+		return err
+	}},
+}
+
+// splitConfigMapList splits a list of the ConfigMap, whose values are separated by line breaks as in the
+// environment variables.
+func splitConfigMapList(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, "\n") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// configMapBuilders rebuild the parts of the controller which depend on the settings of the ConfigMap.
+type configMapBuilders struct {
+	// source builds the source, whose informers run until ctx is done
+	source   func(ctx context.Context, cfg *externaldns.Config) (source.Source, []source.SyncReporter, error)
+	registry func(cfg *externaldns.Config) (registry.Registry, error)
+}
+
+// configMapReloader applies the settings of a ConfigMap on top of the configuration given by the flags.
+type configMapReloader struct {
+	namespace string
+	name      string
+	// flags is the configuration given by the flags
+	flags *externaldns.Config
+
+	mutex sync.Mutex
+	// data is the data of the applied ConfigMap
+	data map[string]string
+	// cfg is the configuration with the settings of the applied ConfigMap
+	cfg *externaldns.Config
+	// cancelSource stops the informers of the current source
+	cancelSource context.CancelFunc
+}
+
+// newConfigMapReloader reads the ConfigMap referenced by the flags and applies its settings, the configuration of the
+// flags is used as is while the ConfigMap doesn't exist.
+func newConfigMapReloader(ctx context.Context, client kubernetes.Interface, flags *externaldns.Config) (*configMapReloader, error) {
+	namespace, name, _ := strings.Cut(flags.ConfigFromConfigMap, "/")
+	r := &configMapReloader{namespace: namespace, name: name, flags: flags, cfg: flags}
+
+	configMap, err := client.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		log.Warnf("ConfigMap %s not found, using the settings of the flags until it is created", flags.ConfigFromConfigMap)
+		return r, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to get ConfigMap %s: %w", flags.ConfigFromConfigMap, err)
+	}
+
+	cfg, _, _, err := r.update(configMap.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid settings in ConfigMap %s: %w", flags.ConfigFromConfigMap, err)
+	}
+	r.data, r.cfg = configMap.Data, cfg
+	return r, nil
+}
+
+// config returns the configuration with the settings of the applied ConfigMap.
+func (r *configMapReloader) config() *externaldns.Config {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.cfg
+}
+
+// update returns the configuration with the settings of the data, and whether the source and the registry have to be
+// rebuilt for the settings changed since the applied ConfigMap. The changes of the settings which require a restart
+// are logged and ignored.
+func (r *configMapReloader) update(data map[string]string) (cfg *externaldns.Config, rebuildSource, rebuildRegistry bool, err error) {
+	next := *r.flags
+	cfg = &next
+	for _, key := range slices.Sorted(maps.Keys(data)) {
+		setting, ok := configMapSettings[key]
+		if !ok {
+			if value, applied := r.data[key]; !applied || value != data[key] {
+				log.Warnf("Setting %q of ConfigMap %s/%s requires a restart, ignoring it", key, r.namespace, r.name)
+			}
+			continue
+		}
+		if err := setting.apply(cfg, data[key]); err != nil {
+			return nil, false, false, fmt.Errorf("invalid value of setting %q: %w", key, err)
+		}
+	}
+
+	for key, setting := range configMapSettings {
+		value, ok := data[key]
+		applied, wasApplied := r.data[key]
+		if ok == wasApplied && value == applied {
+			continue
+		}
+		if setting.rebuildsSource {
+			rebuildSource = true
+		} else {
+			rebuildRegistry = true
+		}
+	}
+	return cfg, rebuildSource, rebuildRegistry, nil
+}
+
+// reload applies the settings of the ConfigMap data to the controller, rebuilding its source or its registry. The
+// current settings are kept when they're invalid or the rebuild fails.
+func (r *configMapReloader) reload(ctx context.Context, ctrl *Controller, build configMapBuilders, data map[string]string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	cfg, rebuildSource, rebuildRegistry, err := r.update(data)
+	if err != nil {
+		log.Errorf("Invalid settings in ConfigMap %s/%s, keeping the current ones: %v", r.namespace, r.name, err)
+		return
+	}
+	if !rebuildSource && !rebuildRegistry {
+		r.data = data
+		return
+	}
+
+	// the informers of the new source run until it is replaced
+	sourceCtx, cancelSource := context.WithCancel(ctx)
+	var src source.Source
+	var reporters []source.SyncReporter
+	if rebuildSource {
+		if src, reporters, err = build.source(sourceCtx, cfg); err != nil {
+			cancelSource()
+			log.Errorf("Failed to reload the sources after a change of ConfigMap %s/%s, keeping the current settings: %v", r.namespace, r.name, err)
+			return
+		}
+	}
+	var reg registry.Registry
+	if rebuildRegistry {
+		if reg, err = build.registry(cfg); err != nil {
+			cancelSource()
+			log.Errorf("Failed to reload the provider after a change of ConfigMap %s/%s, keeping the current settings: %v", r.namespace, r.name, err)
+			return
+		}
+	}
+
+	if rebuildRegistry {
+		ctrl.SetRegistry(reg)
+		ctrl.SetDomainFilter(createDomainFilter(cfg))
+	}
+	if rebuildSource {
+		ctrl.SetSource(src, reporters)
+		if r.cancelSource != nil {
+			r.cancelSource()
+		}
+		r.cancelSource = cancelSource
+	} else {
+		cancelSource()
+	}
+	r.data, r.cfg = data, cfg
+	log.Infof("Reloaded the settings of ConfigMap %s/%s", r.namespace, r.name)
+	ctrl.ScheduleRunOnce(time.Now())
+}
+
+// watch starts watching the ConfigMap, its settings are applied to the controller on each change.
+func (r *configMapReloader) watch(ctx context.Context, client kubernetes.Interface, ctrl *Controller, build configMapBuilders) error {
+	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(client, r.flags.ResyncPeriod,
+		kubeinformers.WithNamespace(r.namespace),
+		kubeinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", r.name).String()
+		}))
+	configMapInformer := informerFactory.Core().V1().ConfigMaps()
+
+	onChange := func(obj interface{}) {
+		if configMap, ok := obj.(*corev1.ConfigMap); ok && configMap.Name == r.name {
+			r.reload(ctx, ctrl, build, configMap.Data)
+		}
+	}
+	_, err := configMapInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    onChange,
+		UpdateFunc: func(_, obj interface{}) { onChange(obj) },
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if configMap, ok := obj.(*corev1.ConfigMap); ok && configMap.Name == r.name {
+				log.Warnf("ConfigMap %s/%s deleted, using the settings of the flags", r.namespace, r.name)
+				r.reload(ctx, ctrl, build, nil)
+			}
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch ConfigMap %s/%s: %w", r.namespace, r.name, err)
+	}
+
+	informers.SetWatchBackoff(r.flags.WatchBackoffMaxDelay, configMapInformer.Informer())
+	informerFactory.Start(ctx.Done())
+	return informers.WaitForCacheSync(ctx, informerFactory)
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/registry"
+	"sigs.k8s.io/external-dns/source"
+)
+
+func newTestConfigMap(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "external-dns", Name: "settings"},
+		Data:       data,
+	}
+}
+
+func newConfigMapFlags() *externaldns.Config {
+	cfg := *externaldns.NewConfig()
+	cfg.ConfigFromConfigMap = "external-dns/settings"
+	cfg.DomainFilter = []string{"example.com"}
+	cfg.AnnotationFilter = "team=a"
+	cfg.GoDaddyTTL = 600
+	return &cfg
+}
+
+func TestNewConfigMapReloader(t *testing.T) {
+	for _, tc := range []struct {
+		title                string
+		objects              []*corev1.ConfigMap
+		expectedDomainFilter []string
+		expectedAnnotation   string
+		expectedGoDaddyTTL   int64
+		expectedError        string
+	}{
+		{
+			title:                "missing ConfigMap uses the flags",
+			expectedDomainFilter: []string{"example.com"},
+			expectedAnnotation:   "team=a",
+			expectedGoDaddyTTL:   600,
+		},
+		{
+			title: "settings of the ConfigMap override the flags",
+			objects: []*corev1.ConfigMap{newTestConfigMap(map[string]string{
+				"domain-filter":     "example.org\n example.net\n",
+				"annotation-filter": "team=b",
+				"godaddy-api-ttl":   "1200",
+			})},
+			expectedDomainFilter: []string{"example.org", "example.net"},
+			expectedAnnotation:   "team=b",
+			expectedGoDaddyTTL:   1200,
+		},
+		{
+			title: "settings requiring a restart are ignored",
+			objects: []*corev1.ConfigMap{newTestConfigMap(map[string]string{
+				"provider": "google",
+			})},
+			expectedDomainFilter: []string{"example.com"},
+			expectedAnnotation:   "team=a",
+			expectedGoDaddyTTL:   600,
+		},
+		{
+			title: "invalid setting",
+			objects: []*corev1.ConfigMap{newTestConfigMap(map[string]string{
+				"ns1-min-ttl": "soon",
+			})},
+			expectedError: `invalid value of setting "ns1-min-ttl"`,
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			client := fake.NewClientset()
+			for _, configMap := range tc.objects {
+				_, err := client.CoreV1().ConfigMaps(configMap.Namespace).Create(t.Context(), configMap, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+			flags := newConfigMapFlags()
+
+			r, err := newConfigMapReloader(t.Context(), client, flags)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			cfg := r.config()
+			assert.Equal(t, tc.expectedDomainFilter, cfg.DomainFilter)
+			assert.Equal(t, tc.expectedAnnotation, cfg.AnnotationFilter)
+			assert.Equal(t, tc.expectedGoDaddyTTL, cfg.GoDaddyTTL)
+			assert.Equal(t, []string{"example.com"}, flags.DomainFilter, "the flags should not be modified")
+		})
+	}
+}
+
+func TestConfigMapReloaderWatch(t *testing.T) {
+	client := fake.NewClientset()
+	r, err := newConfigMapReloader(t.Context(), client, newConfigMapFlags())
+	require.NoError(t, err)
+
+	p := &filteredMockProvider{}
+	reg, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+	src := new(testutils.MockSource)
+	src.On("Endpoints").Return([]*endpoint.Endpoint{}, nil)
+	ctrl := &Controller{
+		Source:             src,
+		Registry:           reg,
+		Policy:             &plan.SyncPolicy{},
+		DomainFilter:       createDomainFilter(r.config()),
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+	}
+
+	var sourceBuilds, registryBuilds atomic.Int32
+	var failRegistry atomic.Bool
+	var sourceCtx atomic.Pointer[context.Context]
+	build := configMapBuilders{
+		source: func(ctx context.Context, _ *externaldns.Config) (source.Source, []source.SyncReporter, error) {
+			sourceBuilds.Add(1)
+			sourceCtx.Store(&ctx)
+			s := new(testutils.MockSource)
+			s.On("Endpoints").Return([]*endpoint.Endpoint{}, nil)
+			return s, nil, nil
+		},
+		registry: func(_ *externaldns.Config) (registry.Registry, error) {
+			registryBuilds.Add(1)
+			if failRegistry.Load() {
+				return nil, errors.New("failed")
+			}
+			return registry.NewNoopRegistry(&filteredMockProvider{})
+		},
+	}
+	require.NoError(t, r.watch(t.Context(), client, ctrl, build))
+
+	// a zone filter change rebuilds the registry only
+	configMaps := client.CoreV1().ConfigMaps("external-dns")
+	_, err = configMaps.Create(t.Context(), newTestConfigMap(map[string]string{"domain-filter": "example.org"}), metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return ctrl.currentRegistry() != reg }, 5*time.Second, 10*time.Millisecond, "the registry should be replaced")
+	assert.True(t, ctrl.currentDomainFilter().Match("www.example.org"))
+	assert.False(t, ctrl.currentDomainFilter().Match("www.example.com"))
+	assert.Equal(t, int32(0), sourceBuilds.Load())
+	assert.Equal(t, []string{"example.org"}, r.config().DomainFilter)
+
+	// an annotation filter change rebuilds the source only
+	_, err = configMaps.Update(t.Context(), newTestConfigMap(map[string]string{"domain-filter": "example.org", "annotation-filter": "team=b"}), metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return sourceBuilds.Load() == 1 }, 5*time.Second, 10*time.Millisecond, "the source should be rebuilt")
+	assert.Eventually(t, func() bool { s, _ := ctrl.currentSource(); return s != src }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(1), registryBuilds.Load())
+	assert.Equal(t, "team=b", r.config().AnnotationFilter)
+	firstSourceCtx := *sourceCtx.Load()
+
+	// a setting requiring a restart is ignored
+	_, err = configMaps.Update(t.Context(), newTestConfigMap(map[string]string{"domain-filter": "example.org", "annotation-filter": "team=b", "provider": "google"}), metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Never(t, func() bool { return sourceBuilds.Load() != 1 || registryBuilds.Load() != 1 }, 200*time.Millisecond, 10*time.Millisecond)
+	assert.Empty(t, r.config().Provider)
+
+	// an invalid value keeps the current settings
+	current := ctrl.currentRegistry()
+	_, err = configMaps.Update(t.Context(), newTestConfigMap(map[string]string{"domain-filter": "example.org", "annotation-filter": "team in (", "provider": "google"}), metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Never(t, func() bool { return sourceBuilds.Load() != 1 }, 200*time.Millisecond, 10*time.Millisecond)
+	assert.Equal(t, "team=b", r.config().AnnotationFilter)
+
+	// a failed rebuild keeps the current settings
+	failRegistry.Store(true)
+	_, err = configMaps.Update(t.Context(), newTestConfigMap(map[string]string{"domain-filter": "example.net", "annotation-filter": "team=b"}), metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return registryBuilds.Load() == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.Same(t, current, ctrl.currentRegistry())
+	assert.Equal(t, []string{"example.org"}, r.config().DomainFilter)
+	failRegistry.Store(false)
+
+	// the deletion of the ConfigMap reverts to the flags, stopping the previous source
+	require.NoError(t, configMaps.Delete(t.Context(), "settings", metav1.DeleteOptions{}))
+	assert.Eventually(t, func() bool { return sourceBuilds.Load() == 2 }, 5*time.Second, 10*time.Millisecond, "the source should be rebuilt")
+	assert.Eventually(t, func() bool { return r.config().AnnotationFilter == "team=a" }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"example.com"}, r.config().DomainFilter)
+	assert.Error(t, firstSourceCtx.Err(), "the informers of the replaced source should be stopped")
+	assert.True(t, ctrl.currentDomainFilter().Match("www.example.com"))
+}
+
+func TestConfigMapReloadInvalidatesFingerprint(t *testing.T) {
+	r, err := newConfigMapReloader(t.Context(), fake.NewClientset(), newConfigMapFlags())
+	require.NoError(t, err)
+	src := &endpointsSource{endpoints: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+	}}
+	p := &failingMockProvider{}
+	ctrl := newFingerprintController(t, src, p)
+	ctrl.DomainFilter = createDomainFilter(r.config())
+
+	var reloaded *failingMockProvider
+	build := configMapBuilders{
+		source: func(context.Context, *externaldns.Config) (source.Source, []source.SyncReporter, error) {
+			return &endpointsSource{endpoints: src.endpoints}, nil, nil
+		},
+		registry: func(*externaldns.Config) (registry.Registry, error) {
+			reloaded = &failingMockProvider{}
+			return registry.NewNoopRegistry(reloaded)
+		},
+	}
+
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	assert.Equal(t, 1, p.RecordsCallCount, "the synchronization of unchanged endpoints should be skipped")
+
+	// a domain filter change is applied although the source endpoints are unchanged
+	r.reload(t.Context(), ctrl, build, map[string]string{"domain-filter": "example.org"})
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	require.NotNil(t, reloaded)
+	assert.Equal(t, 1, reloaded.RecordsCallCount)
+
+	// so is an annotation filter change, which rebuilds the source only
+	r.reload(t.Context(), ctrl, build, map[string]string{"domain-filter": "example.org", "annotation-filter": "team=b"})
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	assert.Equal(t, 2, reloaded.RecordsCallCount)
+
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	assert.Equal(t, 2, reloaded.RecordsCallCount, "the synchronization of unchanged endpoints should be skipped again")
+}
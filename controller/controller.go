@@ -20,13 +20,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/events"
 	"sigs.k8s.io/external-dns/pkg/metrics"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
@@ -91,6 +94,14 @@ var (
 			Help:      "Number of reconcile loops ending up with no changes on the DNS provider side.",
 		},
 	)
+	controllerSkippedSyncsTotal = metrics.NewCounterWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "skipped_syncs_total",
+			Help:      "Number of reconcile loops skipped because the source endpoints are unchanged since the last synchronization.",
+		},
+	)
 	deprecatedRegistryErrors = metrics.NewCounterWithOpts(
 		prometheus.CounterOpts{
 			Subsystem: "registry",
@@ -161,6 +172,15 @@ var (
 			Help:      "Number of consecutive soft errors in reconciliation loop.",
 		},
 	)
+	syncDuration = metrics.NewHistogramWithOpts(
+		prometheus.HistogramOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "sync_duration_seconds",
+			Help:      "Duration of the synchronizations with the DNS provider, whether they succeed or not.",
+			Buckets:   prometheus.ExponentialBuckets(0.1, 2, 12),
+		},
+	)
 )
 
 func init() {
@@ -173,6 +193,7 @@ func init() {
 	metrics.RegisterMetric.MustRegister(deprecatedRegistryErrors)
 	metrics.RegisterMetric.MustRegister(deprecatedSourceErrors)
 	metrics.RegisterMetric.MustRegister(controllerNoChangesTotal)
+	metrics.RegisterMetric.MustRegister(controllerSkippedSyncsTotal)
 	metrics.RegisterMetric.MustRegister(registryARecords)
 	metrics.RegisterMetric.MustRegister(registryAAAARecords)
 	metrics.RegisterMetric.MustRegister(sourceARecords)
@@ -180,6 +201,7 @@ func init() {
 	metrics.RegisterMetric.MustRegister(verifiedARecords)
 	metrics.RegisterMetric.MustRegister(verifiedAAAARecords)
 	metrics.RegisterMetric.MustRegister(consecutiveSoftErrors)
+	metrics.RegisterMetric.MustRegister(syncDuration)
 }
 
 // Controller is responsible for orchestrating the different components.
@@ -189,13 +211,20 @@ func init() {
 // * Take both lists and calculate a Plan to move current towards desired state.
 // * Tell the DNS provider to apply the changes calculated by the Plan.
 type Controller struct {
-	Source   source.Source
+	// Source is replaced with SetSource once the controller runs
+	Source source.Source
+	// Registry is replaced with SetRegistry once the controller runs
 	Registry registry.Registry
+	// The registryMutex is for atomic replacement of the Source, the Registry and the DomainFilter, it also guards
+	// the fingerprint of the last synchronization which is invalidated by their replacement
+	registryMutex sync.RWMutex
 	// The policy that defines which changes to DNS records are allowed
 	Policy plan.Policy
 	// The interval between individual synchronizations
 	Interval time.Duration
-	// The DomainFilter defines which DNS records to keep or exclude
+	// IntervalJitterFactor multiplies the Interval by a random factor in [1, 1+IntervalJitterFactor] on each synchronization
+	IntervalJitterFactor float64
+	// The DomainFilter defines which DNS records to keep or exclude, it is replaced with SetDomainFilter once the controller runs
 	DomainFilter endpoint.DomainFilterInterface
 	// The nextRunAt used for throttling and batching reconciliation
 	nextRunAt time.Time
@@ -207,22 +236,80 @@ type Controller struct {
 	ManagedRecordTypes []string
 	// ExcludeRecordTypes are DNS record types that will be excluded from management.
 	ExcludeRecordTypes []string
+	// ProtectedZones are the names or IDs of the zones whose records are never changed
+	ProtectedZones []string
 	// MinEventSyncInterval is used as window for batching events
 	MinEventSyncInterval time.Duration
+	// EventEmitter emits Kubernetes events for the applied changes, nil disables the emission
+	EventEmitter *events.Emitter
+	// SyncReporters are notified about the result of every synchronization
+	SyncReporters []source.SyncReporter
+	// FingerprintMaxAge skips the synchronizations while the source endpoints are unchanged, for at most
+	// this duration after the last successful synchronization. Zero disables the fingerprinting.
+	FingerprintMaxAge time.Duration
+	// lastFingerprint is the fingerprint of the source endpoints of the last successful synchronization
+	lastFingerprint string
+	// lastFingerprintAt is the time of the last successful synchronization with lastFingerprint
+	lastFingerprintAt time.Time
+	// settingsGeneration is incremented each time the Source, the Registry or the DomainFilter is replaced
+	settingsGeneration uint64
+	// ReconcileTimeout cancels the synchronizations which take longer, the process exits when they
+	// don't return within three times this duration. Zero disables the watchdog.
+	ReconcileTimeout time.Duration
+	// watchdogExit is called when a synchronization is deadlocked, log.Fatalf when nil
+	watchdogExit func(format string, args ...any)
+	// ForceFullSyncAfterErrors drops the cached records after this number of consecutive registry errors,
+	// so that the next synchronization reads all the records from the provider. Zero disables it.
+	ForceFullSyncAfterErrors int
+	// consecutiveErrors is the number of registry errors since the last successful synchronization
+	consecutiveErrors int
+	// synced is true once a synchronization succeeded
+	synced atomic.Bool
+}
+
+// Ready returns true once the controller completed a successful synchronization.
+func (c *Controller) Ready() bool {
+	return c.synced.Load()
 }
 
 // RunOnce runs a single iteration of a reconciliation loop.
 func (c *Controller) RunOnce(ctx context.Context) error {
 	lastReconcileTimestamp.Gauge.SetToCurrentTime()
+	defer prometheus.NewTimer(syncDuration.Histogram).ObserveDuration()
 
 	c.runAtMutex.Lock()
 	c.lastRunAt = time.Now()
 	c.runAtMutex.Unlock()
 
-	records, err := c.Registry.Records(ctx)
+	var endpoints []*endpoint.Endpoint
+	var fingerprint string
+	var generation uint64
+	if c.FingerprintMaxAge > 0 {
+		var err error
+		generation = c.currentSettingsGeneration()
+		// the source endpoints are fetched first, so that the provider isn't called when they are unchanged.
+		if endpoints, err = c.sourceEndpoints(ctx); err != nil {
+			return err
+		}
+		fingerprint = endpointsFingerprint(endpoints)
+		if c.isUnchanged(fingerprint, time.Now()) {
+			controllerSkippedSyncsTotal.Counter.Inc()
+			log.Info("Source endpoints are unchanged since the last synchronization, skipping it")
+			return nil
+		}
+		c.resetFingerprint()
+	}
+
+	// the registry is kept for the whole synchronization, even if it is replaced meanwhile
+	reg := c.currentRegistry()
+	if c.ForceFullSyncAfterErrors > 0 && c.consecutiveErrors >= c.ForceFullSyncAfterErrors {
+		c.forceFullSync(reg)
+	}
+	records, err := reg.Records(ctx)
 	if err != nil {
 		registryErrorsTotal.Counter.Inc()
 		deprecatedRegistryErrors.Counter.Inc()
+		c.consecutiveErrors++
 		return err
 	}
 
@@ -232,11 +319,10 @@ func (c *Controller) RunOnce(ctx context.Context) error {
 	registryAAAARecords.Gauge.Set(float64(regAAAARecords))
 	ctx = context.WithValue(ctx, provider.RecordsContextKey, records)
 
-	endpoints, err := c.Source.Endpoints(ctx)
-	if err != nil {
-		sourceErrorsTotal.Counter.Inc()
-		deprecatedSourceErrors.Counter.Inc()
-		return err
+	if c.FingerprintMaxAge == 0 {
+		if endpoints, err = c.sourceEndpoints(ctx); err != nil {
+			return err
+		}
 	}
 	sourceEndpointsTotal.Gauge.Set(float64(len(endpoints)))
 	srcARecords, srcAAAARecords := countAddressRecords(endpoints)
@@ -245,29 +331,41 @@ func (c *Controller) RunOnce(ctx context.Context) error {
 	vARecords, vAAAARecords := countMatchingAddressRecords(endpoints, records)
 	verifiedARecords.Gauge.Set(float64(vARecords))
 	verifiedAAAARecords.Gauge.Set(float64(vAAAARecords))
-	endpoints, err = c.Registry.AdjustEndpoints(endpoints)
+	endpoints, err = reg.AdjustEndpoints(endpoints)
 	if err != nil {
 		return fmt.Errorf("adjusting endpoints: %w", err)
 	}
-	registryFilter := c.Registry.GetDomainFilter()
+	registryFilter := reg.GetDomainFilter()
+
+	protectedZones, err := protectedZoneNames(ctx, reg, c.ProtectedZones)
+	if err != nil {
+		return err
+	}
 
 	plan := &plan.Plan{
 		Policies:       []plan.Policy{c.Policy},
 		Current:        records,
 		Desired:        endpoints,
-		DomainFilter:   endpoint.MatchAllDomainFilters{c.DomainFilter, registryFilter},
+		DomainFilter:   endpoint.MatchAllDomainFilters{c.currentDomainFilter(), registryFilter},
 		ManagedRecords: c.ManagedRecordTypes,
 		ExcludeRecords: c.ExcludeRecordTypes,
-		OwnerID:        c.Registry.OwnerID(),
+		OwnerID:        reg.OwnerID(),
+		Zones:          plan.ZoneNames(registryFilter),
+		ProtectedZones: protectedZones,
 	}
 
 	plan = plan.Calculate()
 
 	if plan.Changes.HasChanges() {
-		err = c.Registry.ApplyChanges(ctx, plan.Changes)
+		err = reg.ApplyChanges(ctx, plan.Changes)
+		if c.EventEmitter != nil {
+			c.EventEmitter.Emit(plan.Changes, err)
+		}
 		if err != nil {
 			registryErrorsTotal.Counter.Inc()
 			deprecatedRegistryErrors.Counter.Inc()
+			c.consecutiveErrors++
+			c.reportSync(ctx, err)
 			return err
 		}
 	} else {
@@ -276,10 +374,112 @@ func (c *Controller) RunOnce(ctx context.Context) error {
 	}
 
 	lastSyncTimestamp.Gauge.SetToCurrentTime()
+	c.synced.Store(true)
+	c.consecutiveErrors = 0
+	c.reportSync(ctx, nil)
+	if c.FingerprintMaxAge > 0 {
+		c.storeFingerprint(fingerprint, generation, time.Now())
+	}
 
 	return nil
 }
 
+// forceFullSync drops the records cached by the registry, which may have drifted from the provider
+// as the changes which failed to be applied are cached anyway.
+func (c *Controller) forceFullSync(reg registry.Registry) {
+	log.Infof("Forcing a full synchronization after %d consecutive errors", c.consecutiveErrors)
+	if r, ok := reg.(registry.CacheResetter); ok {
+		r.ResetCache()
+	}
+	c.resetFingerprint()
+	c.consecutiveErrors = 0
+}
+
+// protectedZoneNames returns the names of the protected zones, the zones given by ID are looked up in the zones of
+// the provider of the registry.
+func protectedZoneNames(ctx context.Context, reg registry.Registry, zones []string) ([]string, error) {
+	resolver, ok := reg.(registry.ZoneNameResolver)
+	if len(zones) == 0 || !ok {
+		return zones, nil
+	}
+	namesByID, err := resolver.ZoneNamesByID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("resolving the protected zones: %w", err)
+	}
+
+	names := make([]string, 0, len(zones))
+	for _, zone := range zones {
+		if name, ok := namesByID[zone]; ok {
+			zone = name
+		}
+		names = append(names, zone)
+	}
+	return names, nil
+}
+
+// SetRegistry replaces the registry used by the following synchronizations.
+func (c *Controller) SetRegistry(r registry.Registry) {
+	c.registryMutex.Lock()
+	defer c.registryMutex.Unlock()
+	c.Registry = r
+	c.invalidateFingerprint()
+}
+
+func (c *Controller) currentRegistry() registry.Registry {
+	c.registryMutex.RLock()
+	defer c.registryMutex.RUnlock()
+	return c.Registry
+}
+
+// SetSource replaces the source, and the sync reporters of its sources, used by the following synchronizations.
+func (c *Controller) SetSource(s source.Source, reporters []source.SyncReporter) {
+	c.registryMutex.Lock()
+	defer c.registryMutex.Unlock()
+	c.Source = s
+	c.SyncReporters = reporters
+	c.invalidateFingerprint()
+}
+
+func (c *Controller) currentSource() (source.Source, []source.SyncReporter) {
+	c.registryMutex.RLock()
+	defer c.registryMutex.RUnlock()
+	return c.Source, c.SyncReporters
+}
+
+// SetDomainFilter replaces the domain filter used by the following synchronizations.
+func (c *Controller) SetDomainFilter(f endpoint.DomainFilterInterface) {
+	c.registryMutex.Lock()
+	defer c.registryMutex.Unlock()
+	c.DomainFilter = f
+	c.invalidateFingerprint()
+}
+
+func (c *Controller) currentDomainFilter() endpoint.DomainFilterInterface {
+	c.registryMutex.RLock()
+	defer c.registryMutex.RUnlock()
+	return c.DomainFilter
+}
+
+// sourceEndpoints returns the desired endpoints of the source.
+func (c *Controller) sourceEndpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	src, _ := c.currentSource()
+	endpoints, err := src.Endpoints(ctx)
+	if err != nil {
+		sourceErrorsTotal.Counter.Inc()
+		deprecatedSourceErrors.Counter.Inc()
+		return nil, err
+	}
+	return endpoints, nil
+}
+
+// reportSync notifies the sync reporters about the result of a synchronization.
+func (c *Controller) reportSync(ctx context.Context, err error) {
+	_, reporters := c.currentSource()
+	for _, r := range reporters {
+		r.ReportSync(ctx, err)
+	}
+}
+
 func earliest(r time.Time, times ...time.Time) time.Time {
 	for _, t := range times {
 		if t.Before(r) {
@@ -357,10 +557,19 @@ func (c *Controller) ShouldRunOnce(now time.Time) bool {
 	if now.Before(c.nextRunAt) {
 		return false
 	}
-	c.nextRunAt = now.Add(c.Interval)
+	c.nextRunAt = now.Add(c.jitteredInterval())
 	return true
 }
 
+// jitteredInterval returns the Interval multiplied by a random factor in [1, 1+IntervalJitterFactor],
+// so that the instances which are started at the same time don't call the provider at the same time.
+func (c *Controller) jitteredInterval() time.Duration {
+	if c.IntervalJitterFactor <= 0 {
+		return c.Interval
+	}
+	return c.Interval + time.Duration(rand.Float64()*c.IntervalJitterFactor*float64(c.Interval))
+}
+
 // Run runs RunOnce in a loop with a delay until context is canceled
 func (c *Controller) Run(ctx context.Context) {
 	ticker := time.NewTicker(time.Second)
@@ -368,7 +577,7 @@ func (c *Controller) Run(ctx context.Context) {
 	var softErrorCount int
 	for {
 		if c.ShouldRunOnce(time.Now()) {
-			if err := c.RunOnce(ctx); err != nil {
+			if err := c.runOnceWithWatchdog(ctx); err != nil {
 				if errors.Is(err, provider.SoftError) {
 					softErrorCount++
 					consecutiveSoftErrors.Gauge.Set(float64(softErrorCount))
@@ -19,6 +19,7 @@ package controller
 import (
 	"context"
 	"errors"
+	"io"
 	"math"
 	"reflect"
 	"sort"
@@ -27,13 +28,16 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/tools/record"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/testutils"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/events"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 	"sigs.k8s.io/external-dns/registry"
+	"sigs.k8s.io/external-dns/source"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -239,6 +243,96 @@ func TestRunOnce(t *testing.T) {
 	assert.Equal(t, math.Float64bits(1), valueFromMetric(verifiedAAAARecords.Gauge))
 }
 
+// TestRunOnceEmitsEvents tests that RunOnce emits events for the applied changes.
+func TestRunOnceEmitsEvents(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("create-record", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "service/default/foo"),
+	}, nil)
+	provider := newMockProvider([]*endpoint.Endpoint{}, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			{DNSName: "create-record", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+		},
+	})
+
+	r, err := registry.NewNoopRegistry(provider)
+	require.NoError(t, err)
+
+	recorder := record.NewFakeRecorder(1)
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		EventEmitter:       events.NewEmitter(recorder, "inmemory"),
+	}
+
+	assert.NoError(t, ctrl.RunOnce(context.Background()))
+	require.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Normal RecordCreated Created A record create-record with targets 1.2.3.4 (provider: inmemory)", <-recorder.Events)
+}
+
+type syncReporter struct {
+	results []error
+}
+
+func (r *syncReporter) ReportSync(_ context.Context, err error) {
+	r.results = append(r.results, err)
+}
+
+// TestRunOnceReportsSync tests that RunOnce reports the result of the synchronization.
+func TestRunOnceReportsSync(t *testing.T) {
+	r, err := registry.NewNoopRegistry(getTestProvider())
+	require.NoError(t, err)
+
+	reporter := &syncReporter{}
+	ctrl := &Controller{
+		Source:             getTestSource(),
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: getTestConfig().ManagedDNSRecordTypes,
+		SyncReporters:      []source.SyncReporter{reporter},
+	}
+	assert.NoError(t, ctrl.RunOnce(context.Background()))
+
+	// the mock provider rejects unexpected changes
+	ctrl.Registry, err = registry.NewNoopRegistry(newMockProvider(nil, &plan.Changes{}))
+	require.NoError(t, err)
+	assert.Error(t, ctrl.RunOnce(context.Background()))
+
+	require.Len(t, reporter.results, 2)
+	assert.NoError(t, reporter.results[0])
+	assert.Error(t, reporter.results[1])
+}
+
+// TestRunOnceReady tests that the controller is ready once a synchronization succeeded.
+func TestRunOnceReady(t *testing.T) {
+	// the mock provider rejects unexpected changes
+	r, err := registry.NewNoopRegistry(newMockProvider(nil, &plan.Changes{}))
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             getTestSource(),
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: getTestConfig().ManagedDNSRecordTypes,
+	}
+	assert.False(t, ctrl.Ready())
+	assert.Error(t, ctrl.RunOnce(context.Background()))
+	assert.False(t, ctrl.Ready(), "a failed synchronization should not make the controller ready")
+
+	ctrl.Registry, err = registry.NewNoopRegistry(getTestProvider())
+	require.NoError(t, err)
+	assert.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.True(t, ctrl.Ready())
+
+	// the controller stays ready after a failed synchronization
+	ctrl.Registry, err = registry.NewNoopRegistry(newMockProvider(nil, &plan.Changes{}))
+	require.NoError(t, err)
+	assert.Error(t, ctrl.RunOnce(context.Background()))
+	assert.True(t, ctrl.Ready())
+}
+
 // TestRun tests that Run correctly starts and stops
 func TestRun(t *testing.T) {
 	source := getTestSource()
@@ -335,6 +429,26 @@ func TestShouldRunOnce(t *testing.T) {
 	assert.True(t, ctrl.ShouldRunOnce(now))
 }
 
+func TestShouldRunOnceWithJitter(t *testing.T) {
+	ctrl := &Controller{Interval: 10 * time.Minute, IntervalJitterFactor: 0.5}
+
+	now := time.Now()
+	require.True(t, ctrl.ShouldRunOnce(now))
+
+	var jittered bool
+	for range 100 {
+		interval := ctrl.nextRunAt.Sub(now)
+		assert.GreaterOrEqual(t, interval, 10*time.Minute)
+		assert.LessOrEqual(t, interval, 15*time.Minute)
+		jittered = jittered || interval != 10*time.Minute
+
+		assert.False(t, ctrl.ShouldRunOnce(now.Add(interval-time.Nanosecond)))
+		now = now.Add(interval)
+		require.True(t, ctrl.ShouldRunOnce(now))
+	}
+	assert.True(t, jittered, "the intervals must be jittered")
+}
+
 func testControllerFiltersDomains(t *testing.T, configuredEndpoints []*endpoint.Endpoint, domainFilter endpoint.DomainFilter, providerEndpoints []*endpoint.Endpoint, expectedChanges []*plan.Changes) {
 	t.Helper()
 	cfg := externaldns.NewConfig()
@@ -394,6 +508,132 @@ func TestControllerSkipsEmptyChanges(t *testing.T) {
 	)
 }
 
+func TestControllerExcludesRecordTypes(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("example.com", endpoint.RecordTypeNS, "ns1.other.com"),
+		endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+	}, nil)
+
+	// the provider returns the NS records of the zone
+	p := &filteredMockProvider{
+		RecordsStore: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("example.com", endpoint.RecordTypeNS, "ns1.example.com", "ns2.example.com"),
+			endpoint.NewEndpoint("sub.example.com", endpoint.RecordTypeNS, "ns1.sub.example.com"),
+		},
+	}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeNS},
+		ExcludeRecordTypes: []string{endpoint.RecordTypeNS},
+	}
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+
+	require.Len(t, p.ApplyChangesCalls, 1)
+	changes := p.ApplyChangesCalls[0]
+	assert.Equal(t, []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.4")}, changes.Create)
+	assert.Empty(t, changes.UpdateOld)
+	assert.Empty(t, changes.UpdateNew)
+	assert.Empty(t, changes.Delete)
+}
+
+// TestRunOnceStdinSource tests that the endpoints piped to the stdin source are synchronized.
+func TestRunOnceStdinSource(t *testing.T) {
+	reader, writer := io.Pipe()
+	go func() {
+		_, _ = io.WriteString(writer, `{"dnsName":"create.example.com","targets":["1.2.3.4"],"recordType":"A"}`+"\n")
+		_, _ = io.WriteString(writer, `{"dnsName":"update.example.com","targets":["5.6.7.8"],"recordType":"A"}`+"\n")
+		_ = writer.Close()
+	}()
+	src, err := source.NewStdinSource(reader)
+	require.NoError(t, err)
+
+	p := &filteredMockProvider{
+		RecordsStore: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("update.example.com", endpoint.RecordTypeA, "8.7.6.5"),
+			endpoint.NewEndpoint("delete.example.com", endpoint.RecordTypeA, "4.3.2.1"),
+		},
+	}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             src,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+	}
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+
+	require.Len(t, p.ApplyChangesCalls, 1)
+	changes := p.ApplyChangesCalls[0]
+	assert.Equal(t, []*endpoint.Endpoint{endpoint.NewEndpoint("create.example.com", endpoint.RecordTypeA, "1.2.3.4")}, changes.Create)
+	require.Len(t, changes.UpdateNew, 1)
+	assert.Equal(t, endpoint.Targets{"5.6.7.8"}, changes.UpdateNew[0].Targets)
+	assert.Equal(t, []*endpoint.Endpoint{endpoint.NewEndpoint("delete.example.com", endpoint.RecordTypeA, "4.3.2.1")}, changes.Delete)
+}
+
+// zoneIDMockProvider is a filteredMockProvider identifying its zones by ID.
+type zoneIDMockProvider struct {
+	filteredMockProvider
+	zoneNames map[string]string
+}
+
+func (p *zoneIDMockProvider) ZoneNamesByID(_ context.Context) (map[string]string, error) {
+	return p.zoneNames, nil
+}
+
+func TestRunOnceProtectedZones(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		protectedZones []string
+	}{
+		{name: "zone name", protectedZones: []string{"example.com"}},
+		{name: "zone ID", protectedZones: []string{"Z1234567890"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			src := new(testutils.MockSource)
+			src.On("Endpoints").Return([]*endpoint.Endpoint{
+				endpoint.NewEndpoint("create.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+				endpoint.NewEndpoint("update.example.com", endpoint.RecordTypeA, "5.6.7.8"),
+				endpoint.NewEndpoint("create.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			}, nil)
+
+			p := &zoneIDMockProvider{
+				filteredMockProvider: filteredMockProvider{
+					RecordsStore: []*endpoint.Endpoint{
+						endpoint.NewEndpoint("update.example.com", endpoint.RecordTypeA, "8.7.6.5"),
+						endpoint.NewEndpoint("delete.example.com", endpoint.RecordTypeA, "4.3.2.1"),
+					},
+				},
+				zoneNames: map[string]string{"Z1234567890": "example.com.", "Z0987654321": "example.org."},
+			}
+			r, err := registry.NewNoopRegistry(p)
+			require.NoError(t, err)
+
+			ctrl := &Controller{
+				Source:             src,
+				Registry:           r,
+				Policy:             &plan.SyncPolicy{},
+				ManagedRecordTypes: []string{endpoint.RecordTypeA},
+				ProtectedZones:     tt.protectedZones,
+			}
+			require.NoError(t, ctrl.RunOnce(context.Background()))
+
+			// only the records of the other zones are changed
+			require.Len(t, p.ApplyChangesCalls, 1)
+			assert.Equal(t, []*endpoint.Endpoint{endpoint.NewEndpoint("create.example.org", endpoint.RecordTypeA, "1.2.3.4")}, p.ApplyChangesCalls[0].Create)
+			assert.Empty(t, p.ApplyChangesCalls[0].UpdateNew)
+			assert.Empty(t, p.ApplyChangesCalls[0].Delete)
+		})
+	}
+}
+
 func TestWhenNoFilterControllerConsidersAllComain(t *testing.T) {
 	testControllerFiltersDomains(
 		t,
@@ -805,3 +1045,44 @@ func TestToggleRegistry(t *testing.T) {
 		t.Fatalf("failCount should be at least 3 after waiting up to 2s, got %d", finalCount)
 	}
 }
+
+// resettingRegistry counts the resets of its cache.
+type resettingRegistry struct {
+	registry.Registry
+	resets int
+}
+
+func (r *resettingRegistry) ResetCache() {
+	r.resets++
+}
+
+func TestRunOnceForcesFullSyncAfterErrors(t *testing.T) {
+	src := &endpointsSource{endpoints: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("create-record", endpoint.RecordTypeA, "1.2.3.4"),
+	}}
+	p := &failingMockProvider{fail: true}
+	noop, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+	r := &resettingRegistry{Registry: noop}
+
+	ctrl := &Controller{
+		Source:                   src,
+		Registry:                 r,
+		Policy:                   &plan.SyncPolicy{},
+		ManagedRecordTypes:       getTestConfig().ManagedDNSRecordTypes,
+		ForceFullSyncAfterErrors: 3,
+	}
+
+	for range 3 {
+		require.Error(t, ctrl.RunOnce(context.Background()))
+	}
+	assert.Zero(t, r.resets, "the cache must be kept until the number of errors is reached")
+
+	p.fail = false
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Equal(t, 1, r.resets, "a full synchronization must be forced on the 4th attempt")
+	assert.Len(t, p.ApplyChangesCalls, 4)
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Equal(t, 1, r.resets, "the errors must be counted again after a successful synchronization")
+}
@@ -18,8 +18,12 @@ package controller
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
 	"fmt"
 	"net/http"
+	"net/http/pprof"
 	"os"
 	"os/signal"
 	"syscall"
@@ -31,11 +35,13 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns/validation"
+	"sigs.k8s.io/external-dns/pkg/events"
 	"sigs.k8s.io/external-dns/pkg/metrics"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
@@ -44,26 +50,41 @@ import (
 	"sigs.k8s.io/external-dns/provider/aws"
 	"sigs.k8s.io/external-dns/provider/awssd"
 	"sigs.k8s.io/external-dns/provider/azure"
+	"sigs.k8s.io/external-dns/provider/bluecat"
+	"sigs.k8s.io/external-dns/provider/bunny"
 	"sigs.k8s.io/external-dns/provider/civo"
 	"sigs.k8s.io/external-dns/provider/cloudflare"
+	"sigs.k8s.io/external-dns/provider/cloudns"
+	"sigs.k8s.io/external-dns/provider/constellix"
 	"sigs.k8s.io/external-dns/provider/coredns"
+	"sigs.k8s.io/external-dns/provider/desec"
 	"sigs.k8s.io/external-dns/provider/digitalocean"
 	"sigs.k8s.io/external-dns/provider/dnsimple"
+	"sigs.k8s.io/external-dns/provider/dyn"
+	"sigs.k8s.io/external-dns/provider/efficientip"
 	"sigs.k8s.io/external-dns/provider/exoscale"
 	"sigs.k8s.io/external-dns/provider/gandi"
 	"sigs.k8s.io/external-dns/provider/godaddy"
 	"sigs.k8s.io/external-dns/provider/google"
 	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/provider/inwx"
+	"sigs.k8s.io/external-dns/provider/joker"
 	"sigs.k8s.io/external-dns/provider/linode"
+	"sigs.k8s.io/external-dns/provider/namecheap"
+	"sigs.k8s.io/external-dns/provider/netlify"
 	"sigs.k8s.io/external-dns/provider/ns1"
 	"sigs.k8s.io/external-dns/provider/oci"
 	"sigs.k8s.io/external-dns/provider/ovh"
 	"sigs.k8s.io/external-dns/provider/pdns"
 	"sigs.k8s.io/external-dns/provider/pihole"
+	"sigs.k8s.io/external-dns/provider/plugin"
 	"sigs.k8s.io/external-dns/provider/plural"
+	"sigs.k8s.io/external-dns/provider/porkbun"
 	"sigs.k8s.io/external-dns/provider/rfc2136"
 	"sigs.k8s.io/external-dns/provider/scaleway"
 	"sigs.k8s.io/external-dns/provider/transip"
+	"sigs.k8s.io/external-dns/provider/vercel"
+	"sigs.k8s.io/external-dns/provider/vultr"
 	"sigs.k8s.io/external-dns/provider/webhook"
 	webhookapi "sigs.k8s.io/external-dns/provider/webhook/api"
 	"sigs.k8s.io/external-dns/registry"
@@ -97,16 +118,20 @@ func Execute() {
 
 	ctx, cancel := context.WithCancel(context.Background())
 
-	go serveMetrics(cfg.MetricsAddress)
+	go serveMetrics(cfg.MetricsAddress, cfg.MetricsPath)
+	if cfg.EnablePprof {
+		go servePprof(cfg.PprofListenAddress)
+	}
 	go handleSigterm(cancel)
 
 	// Create a source.Config from the flags passed by the user.
 	sourceCfg := source.NewSourceConfig(cfg)
 
 	// Lookup all the selected sources by names and pass them the desired configuration.
-	sources, err := source.ByNames(ctx, &source.SingletonClientGenerator{
-		KubeConfig:   cfg.KubeConfig,
-		APIServerURL: cfg.APIServerURL,
+	clientGenerator := &source.SingletonClientGenerator{
+		KubeConfig:        cfg.KubeConfig,
+		APIServerURL:      cfg.APIServerURL,
+		RestConfigOptions: sourceCfg.RestConfigOptions,
 		// If update events are enabled, disable timeout.
 		RequestTimeout: func() time.Duration {
 			if cfg.UpdateEvents {
@@ -114,27 +139,269 @@ func Execute() {
 			}
 			return cfg.RequestTimeout
 		}(),
-	}, cfg.Sources, sourceCfg)
+	}
+	if cfg.EmitEvents {
+		if cfg.DryRun {
+			log.Info("Kubernetes events are not emitted in dry-run mode")
+		} else {
+			kubeClient, err := clientGenerator.KubeClient()
+			if err != nil {
+				log.Fatal(err)
+			}
+			sourceCfg.EventRecorder = events.NewEventRecorder(kubeClient)
+		}
+	}
+
+	// The settings of the ConfigMap are applied on top of the flags.
+	var reloader *configMapReloader
+	if cfg.ConfigFromConfigMap != "" {
+		kubeClient, err := clientGenerator.KubeClient()
+		if err != nil {
+			log.Fatal(err)
+		}
+		if reloader, err = newConfigMapReloader(ctx, kubeClient, cfg); err != nil {
+			log.Fatal(err)
+		}
+		cfg = reloader.config()
+	}
+
+	var extraClients map[string]source.ClientGenerator
+	if len(cfg.KubeExtraEndpoints) > 0 {
+		var err error
+		extraClients, err = source.NewExtraEndpointClientGenerators(cfg.KubeExtraEndpoints, clientGenerator.RequestTimeout)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	// the informers of the sources are stopped when the sources are rebuilt with the settings of the ConfigMap
+	sourceCtx, cancelSource := context.WithCancel(ctx)
+	defer cancelSource()
+	endpointsSource, syncReporters, err := buildSource(sourceCtx, cfg, clientGenerator, extraClients, sourceCfg.EventRecorder)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	domainFilter := createDomainFilter(cfg)
+	p, err := buildProvider(ctx, cfg, domainFilter)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if cfg.ProviderReadOnly {
+		p = provider.NewReadOnlyProvider(p)
+	}
+
+	if cfg.WebhookServer {
+		webhookapi.StartHTTPApi(p, nil, cfg.WebhookProviderReadTimeout, cfg.WebhookProviderWriteTimeout, "127.0.0.1:8888")
+		os.Exit(0)
+	}
+
+	if cfg.ProviderCacheTime > 0 {
+		p = provider.NewCachedProvider(
+			p,
+			cfg.ProviderCacheTime,
+		)
+	}
+
+	reg, err := selectRegistry(ctx, cfg, p)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	if cfg.TransferOwnershipFrom != "" {
+		transferrer, ok := reg.(registry.OwnershipTransferrer)
+		if !ok {
+			log.Fatalf("the %s registry does not support transferring the ownership of records", cfg.Registry)
+		}
+		if err := transferrer.TransferOwnership(ctx, cfg.TransferOwnershipFrom); err != nil {
+			log.Fatalf("failed to transfer the ownership of records: %v", err)
+		}
+	}
+
+	policy, exists := plan.Policies[cfg.Policy]
+	if !exists {
+		log.Fatalf("unknown policy: %s", cfg.Policy)
+	}
+
+	ctrl := Controller{
+		Source:                   endpointsSource,
+		SyncReporters:            syncReporters,
+		Registry:                 reg,
+		Policy:                   policy,
+		Interval:                 cfg.Interval,
+		IntervalJitterFactor:     cfg.IntervalJitterFactor,
+		DomainFilter:             domainFilter,
+		ManagedRecordTypes:       cfg.ManagedDNSRecordTypes,
+		ExcludeRecordTypes:       cfg.ExcludeDNSRecordTypes,
+		ProtectedZones:           cfg.ProtectedZones,
+		MinEventSyncInterval:     cfg.MinEventSyncInterval,
+		FingerprintMaxAge:        cfg.FingerprintMaxAge,
+		ReconcileTimeout:         cfg.ReconcileTimeout,
+		ForceFullSyncAfterErrors: cfg.ForceFullSyncAfterErrors,
+	}
+
+	if sourceCfg.EventRecorder != nil {
+		ctrl.EventEmitter = events.NewEmitter(sourceCfg.EventRecorder, cfg.Provider)
+	}
+
+	if cfg.Once {
+		err := ctrl.RunOnce(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		os.Exit(0)
+	}
+
+	go serveHealth(cfg.HealthListenAddress, cfg.MetricsPath, ctrl.Ready)
+
+	// currentConfig returns the configuration with the settings of the ConfigMap applied last.
+	currentConfig := func() *externaldns.Config {
+		if reloader != nil {
+			return reloader.config()
+		}
+		return cfg
+	}
+
+	if cfg.ProviderConfigFile != "" {
+		err := watchProviderConfig(ctx, cfg.ProviderConfigFile, &ctrl, func() (registry.Registry, error) {
+			cfg := currentConfig()
+			return buildRegistry(ctx, cfg, createDomainFilter(cfg))
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if cfg.UpdateEvents {
+		// Add RunOnce as the handler function that will be called when ingress/service sources have changed.
+		// Note that k8s Informers will perform an initial list operation, which results in the handler
+		// function initially being called for every Service/Ingress that exists
+		ctrl.Source.AddEventHandler(sourceCtx, func() { ctrl.ScheduleRunOnce(time.Now()) })
+	}
+
+	if reloader != nil {
+		reloader.cancelSource = cancelSource
+		kubeClient, err := clientGenerator.KubeClient()
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = reloader.watch(ctx, kubeClient, &ctrl, configMapBuilders{
+			source: func(ctx context.Context, cfg *externaldns.Config) (source.Source, []source.SyncReporter, error) {
+				src, reporters, err := buildSource(ctx, cfg, clientGenerator, extraClients, sourceCfg.EventRecorder)
+				if err != nil {
+					return nil, nil, err
+				}
+				if cfg.UpdateEvents {
+					src.AddEventHandler(ctx, func() { ctrl.ScheduleRunOnce(time.Now()) })
+				}
+				return src, reporters, nil
+			},
+			registry: func(cfg *externaldns.Config) (registry.Registry, error) {
+				return buildRegistry(ctx, cfg, createDomainFilter(cfg))
+			},
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	ctrl.ScheduleRunOnce(time.Now())
+	ctrl.Run(ctx)
+}
+
+// buildSource builds the source of the endpoints from the sources selected in cfg, including the ones of the extra
+// API servers, along with the sources reporting the result of the synchronizations.
+func buildSource(ctx context.Context, cfg *externaldns.Config, clientGenerator source.ClientGenerator, extraClients map[string]source.ClientGenerator, eventRecorder record.EventRecorder) (source.Source, []source.SyncReporter, error) {
+	sourceCfg := source.NewSourceConfig(cfg)
+	sourceCfg.EventRecorder = eventRecorder
+
+	sources, err := source.ByNames(ctx, clientGenerator, cfg.Sources, sourceCfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(extraClients) > 0 {
+		extraSources, err := source.ExtraEndpointSources(ctx, extraClients, cfg.Sources, sourceCfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		sources = append(sources, extraSources...)
+	}
+
+	// In dry-run mode nothing is synchronized, so there is nothing to report.
+	var reporters []source.SyncReporter
+	for _, s := range sources {
+		if r, ok := s.(source.SyncReporter); ok && !cfg.DryRun {
+			reporters = append(reporters, r)
+		}
+	}
+
 	// Filter targets
 	targetFilter := endpoint.NewTargetNetFilterWithExclusions(cfg.TargetNetFilter, cfg.ExcludeTargetNets)
 
 	// Combine multiple sources into a single, deduplicated source.
-	endpointsSource := source.NewDedupSource(source.NewMultiSource(sources, sourceCfg.DefaultTargets))
+	endpointsSource := source.NewMultiSource(sources, sourceCfg.DefaultTargets)
+	if cfg.HostnameAliases != "" {
+		// expand the aliases first, so that the expanded hostnames are deduplicated too.
+		aliases, err := source.ParseHostnameAliases(cfg.HostnameAliases)
+		if err != nil {
+			return nil, nil, err
+		}
+		endpointsSource = source.NewHostnameAliasSource(endpointsSource, aliases)
+	}
+	endpointsSource = source.NewDedupSource(endpointsSource)
 	endpointsSource = source.NewNAT64Source(endpointsSource, cfg.NAT64Networks)
 	endpointsSource = source.NewTargetFilterSource(endpointsSource, targetFilter)
+	if cfg.EndpointTransformURL != "" {
+		endpointsSource = source.NewTransformWebhookSource(endpointsSource, cfg.EndpointTransformURL, cfg.EndpointTransformTimeout)
+	}
+	return endpointsSource, reporters, nil
+}
 
-	domainFilter := createDomainFilter(cfg)
+// buildRegistry builds the registry of the providers selected in cfg, as they are rebuilt without restarting.
+func buildRegistry(ctx context.Context, cfg *externaldns.Config, domainFilter endpoint.DomainFilter) (registry.Registry, error) {
+	p, err := buildProvider(ctx, cfg, domainFilter)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ProviderReadOnly {
+		p = provider.NewReadOnlyProvider(p)
+	}
+	if cfg.ProviderCacheTime > 0 {
+		p = provider.NewCachedProvider(p, cfg.ProviderCacheTime)
+	}
+	return selectRegistry(ctx, cfg, p)
+}
+
+// buildProvider builds the providers selected in cfg, managing the records of the domains of domainFilter.
+// When several providers are selected, the changes are applied to all of them and the records are read from the first one.
+func buildProvider(ctx context.Context, cfg *externaldns.Config, domainFilter endpoint.DomainFilter) (provider.Provider, error) {
+	names := cfg.ProviderNames()
+	if len(names) == 1 {
+		return newProvider(ctx, cfg, names[0], domainFilter)
+	}
+
+	providers := make([]provider.NamedProvider, 0, len(names))
+	for _, name := range names {
+		p, err := newProvider(ctx, cfg, name, domainFilter)
+		if err != nil {
+			return nil, fmt.Errorf("provider %s: %w", name, err)
+		}
+		providers = append(providers, provider.NamedProvider{Name: name, Provider: p})
+	}
+	return provider.NewMultiProvider(providers...)
+}
+
+// newProvider builds the provider called name, managing the records of the domains of domainFilter.
+func newProvider(ctx context.Context, cfg *externaldns.Config, name string, domainFilter endpoint.DomainFilter) (provider.Provider, error) {
 	zoneNameFilter := endpoint.NewDomainFilter(cfg.ZoneNameFilter)
 	zoneIDFilter := provider.NewZoneIDFilter(cfg.ZoneIDFilter)
 	zoneTypeFilter := provider.NewZoneTypeFilter(cfg.AWSZoneType)
 	zoneTagFilter := provider.NewZoneTagFilter(cfg.AWSZoneTagFilter)
 
 	var p provider.Provider
-	switch cfg.Provider {
+	var err error
+	switch name {
 	case "akamai":
 		p, err = akamai.NewAkamaiProvider(
 			akamai.AkamaiConfig{
@@ -151,47 +418,95 @@ func Execute() {
 	case "alibabacloud":
 		p, err = alibabacloud.NewAlibabaCloudProvider(cfg.AlibabaCloudConfigFile, domainFilter, zoneIDFilter, cfg.AlibabaCloudZoneType, cfg.DryRun)
 	case "aws":
-		configs := aws.CreateV2Configs(cfg)
+		configs := aws.CreateV2Configs(ctx, cfg)
 		clients := make(map[string]aws.Route53API, len(configs))
 		for profile, config := range configs {
 			clients[profile] = route53.NewFromConfig(config)
 		}
+		var resolverRuleConfig aws.ResolverRuleConfig
+		if cfg.AWSManageResolverRules {
+			resolverRuleConfig = aws.ResolverRuleConfig{
+				RuleName: cfg.AWSResolverRuleName,
+				Client:   aws.NewRoute53ResolverRuleClient(aws.CreateDefaultV2Config(ctx, cfg)),
+			}
+		}
+
+		var arcRoutingControlClient aws.ARCRoutingControlClient
+		if cfg.AWSARCRoutingControlARN != "" {
+			arcRoutingControlClient = aws.NewARCRoutingControlClient(aws.CreateDefaultV2Config(ctx, cfg), cfg.AWSARCClusterEndpoints)
+		}
 
-		p, err = aws.NewAWSProvider(
+		var recoveryReadinessClient aws.RecoveryReadinessClient
+		if cfg.AWSRecoveryReadiness {
+			recoveryReadinessClient = aws.NewRecoveryReadinessClient(aws.CreateDefaultV2Config(ctx, cfg))
+		}
+
+		var awsProvider *aws.AWSProvider
+		awsProvider, err = aws.NewAWSProvider(
 			aws.AWSConfig{
-				DomainFilter:          domainFilter,
-				ZoneIDFilter:          zoneIDFilter,
-				ZoneTypeFilter:        zoneTypeFilter,
-				ZoneTagFilter:         zoneTagFilter,
-				ZoneMatchParent:       cfg.AWSZoneMatchParent,
-				BatchChangeSize:       cfg.AWSBatchChangeSize,
-				BatchChangeSizeBytes:  cfg.AWSBatchChangeSizeBytes,
-				BatchChangeSizeValues: cfg.AWSBatchChangeSizeValues,
-				BatchChangeInterval:   cfg.AWSBatchChangeInterval,
-				EvaluateTargetHealth:  cfg.AWSEvaluateTargetHealth,
-				PreferCNAME:           cfg.AWSPreferCNAME,
-				DryRun:                cfg.DryRun,
-				ZoneCacheDuration:     cfg.AWSZoneCacheDuration,
+				DomainFilter:              domainFilter,
+				ZoneIDFilter:              zoneIDFilter,
+				ZoneTypeFilter:            zoneTypeFilter,
+				ZoneTagFilter:             zoneTagFilter,
+				ZoneMatchParent:           cfg.AWSZoneMatchParent,
+				BatchChangeSize:           cfg.AWSBatchChangeSize,
+				BatchChangeSizeBytes:      cfg.AWSBatchChangeSizeBytes,
+				BatchChangeSizeValues:     cfg.AWSBatchChangeSizeValues,
+				BatchChangeInterval:       cfg.AWSBatchChangeInterval,
+				EvaluateTargetHealth:      cfg.AWSEvaluateTargetHealth,
+				PreferCNAME:               cfg.AWSPreferCNAME,
+				DryRun:                    cfg.DryRun,
+				ZoneCacheDuration:         cfg.AWSZoneCacheDuration,
+				ManageResolverRules:       cfg.AWSManageResolverRules,
+				ResolverRuleConfig:        resolverRuleConfig,
+				ValidateDNSSEC:            cfg.AWSValidateDNSSEC,
+				DNSSECConfig:              aws.DNSSECConfig{Address: cfg.AWSDNSSECResolver},
+				ManageTrafficPolicies:     cfg.AWSManageTrafficPolicies,
+				ARCRoutingControlARN:      cfg.AWSARCRoutingControlARN,
+				ARCRoutingControlClient:   arcRoutingControlClient,
+				RecoveryReadinessEnabled:  cfg.AWSRecoveryReadiness,
+				RecoveryReadinessCellARNs: cfg.AWSRecoveryReadinessCellARNs,
+				RecoveryReadinessClient:   recoveryReadinessClient,
+				MaxChangesPerBatch:        cfg.AWSMaxChangesPerBatch,
+				TransactionalZoneUpdates:  cfg.AWSTransactionalZoneUpdates,
+				DomainZoneFilter:          aws.NewDomainZoneFilter(cfg.AWSDomainRoles),
+				CreateZones:               cfg.AWSCreateZones,
+				AutoDelegate:              cfg.AWSZoneAutoDelegate,
+				ParentZoneID:              cfg.AWSParentZoneID,
 			},
 			clients,
 		)
+		if err != nil {
+			return nil, err
+		}
+		if err := ensureAWSZones(ctx, cfg, awsProvider); err != nil {
+			return nil, err
+		}
+		p = awsProvider
 	case "aws-sd":
 		// Check that only compatible Registry is used with AWS-SD
 		if cfg.Registry != "noop" && cfg.Registry != "aws-sd" {
 			log.Infof("Registry \"%s\" cannot be used with AWS Cloud Map. Switching to \"aws-sd\".", cfg.Registry)
 			cfg.Registry = "aws-sd"
 		}
-		p, err = awssd.NewAWSSDProvider(domainFilter, cfg.AWSZoneType, cfg.DryRun, cfg.AWSSDServiceCleanup, cfg.TXTOwnerID, cfg.AWSSDCreateTag, sd.NewFromConfig(aws.CreateDefaultV2Config(cfg)))
+		p, err = awssd.NewAWSSDProvider(domainFilter, cfg.AWSZoneType, cfg.DryRun, cfg.AWSSDServiceCleanup, cfg.TXTOwnerID, cfg.AWSSDCreateTag, sd.NewFromConfig(aws.CreateDefaultV2Config(ctx, cfg)))
 	case "azure-dns", "azure":
-		p, err = azure.NewAzureProvider(cfg.AzureConfigFile, domainFilter, zoneNameFilter, zoneIDFilter, cfg.AzureSubscriptionID, cfg.AzureResourceGroup, cfg.AzureUserAssignedIdentityClientID, cfg.AzureActiveDirectoryAuthorityHost, cfg.AzureZonesCacheDuration, cfg.AzureMaxRetriesCount, cfg.DryRun)
+		p, err = azure.NewAzureProvider(cfg.AzureConfigFile, domainFilter, zoneNameFilter, zoneIDFilter, cfg.AzureSubscriptionID, cfg.AzureResourceGroup, cfg.AzureUserAssignedIdentityClientID, cfg.AzureActiveDirectoryAuthorityHost, cfg.AzureZonesCacheDuration, cfg.AzureMaxRetriesCount, cfg.AzureTrafficManagerProfile, cfg.DryRun)
 	case "azure-private-dns":
 		p, err = azure.NewAzurePrivateDNSProvider(cfg.AzureConfigFile, domainFilter, zoneNameFilter, zoneIDFilter, cfg.AzureSubscriptionID, cfg.AzureResourceGroup, cfg.AzureUserAssignedIdentityClientID, cfg.AzureActiveDirectoryAuthorityHost, cfg.AzureZonesCacheDuration, cfg.AzureMaxRetriesCount, cfg.DryRun)
+	case "bluecat":
+		p, err = bluecat.NewBlueCatProvider(domainFilter, cfg.DryRun)
+	case "bunny":
+		p, err = bunny.NewBunnyProvider(domainFilter, cfg.DryRun)
 	case "civo":
 		p, err = civo.NewCivoProvider(domainFilter, cfg.DryRun)
+	case "cloudns":
+		p, err = cloudns.NewClouDNSProvider(domainFilter, cfg.DryRun)
 	case "cloudflare":
 		p, err = cloudflare.NewCloudFlareProvider(
 			domainFilter,
 			zoneIDFilter,
+			cfg.CloudflareZoneFilterRegex,
 			cfg.CloudflareProxied,
 			cfg.DryRun,
 			cfg.CloudflareRegionKey,
@@ -203,9 +518,33 @@ func Execute() {
 			cloudflare.DNSRecordsConfig{
 				PerPage: cfg.CloudflareDNSRecordsPerPage,
 				Comment: cfg.CloudflareDNSRecordsComment,
-			})
+			},
+			cloudflare.LoadBalancersConfig{
+				Enabled: cfg.CloudflareLoadBalancers,
+			},
+			cloudflare.WorkerRoutesConfig{
+				Enabled: cfg.CloudflareWorkerRoutes,
+			},
+			cloudflare.PropagationConfig{
+				Enabled: cfg.CloudflareVerifyPropagation,
+				Timeout: cfg.CloudflareVerifyTimeout,
+			},
+			cfg.CloudflareZoneTokens)
 	case "google":
-		p, err = google.NewGoogleProvider(ctx, cfg.GoogleProject, domainFilter, zoneIDFilter, cfg.GoogleBatchChangeSize, cfg.GoogleBatchChangeInterval, cfg.GoogleZoneVisibility, cfg.DryRun)
+		forwardingConfig := google.ForwardingConfig{
+			Zones:    cfg.GoogleForwardingZones,
+			Networks: cfg.GoogleForwardingNetworks,
+		}
+		for _, value := range cfg.GoogleForwardingTargets {
+			target, parseErr := google.ParseForwardingTarget(value)
+			if parseErr != nil {
+				return nil, parseErr
+			}
+			forwardingConfig.Targets = append(forwardingConfig.Targets, target)
+		}
+		p, err = google.NewGoogleProvider(ctx, cfg.GoogleProject, domainFilter, zoneIDFilter, cfg.GoogleBatchChangeSize, cfg.GoogleBatchChangeInterval, cfg.GoogleZoneVisibility, cfg.GoogleResponsePolicy, forwardingConfig, cfg.DryRun)
+	case "desec":
+		p, err = desec.NewDesecProvider(domainFilter, cfg.DryRun)
 	case "digitalocean":
 		p, err = digitalocean.NewDigitalOceanProvider(ctx, domainFilter, cfg.DryRun, cfg.DigitalOceanAPIPageSize)
 	case "ovh":
@@ -214,8 +553,14 @@ func Execute() {
 		p, err = linode.NewLinodeProvider(domainFilter, cfg.DryRun)
 	case "dnsimple":
 		p, err = dnsimple.NewDnsimpleProvider(domainFilter, zoneIDFilter, cfg.DryRun)
+	case "constellix":
+		p, err = constellix.NewConstellixProvider(domainFilter, cfg.DryRun)
 	case "coredns", "skydns":
 		p, err = coredns.NewCoreDNSProvider(domainFilter, cfg.CoreDNSPrefix, cfg.DryRun)
+	case "dyn":
+		p, err = dyn.NewDynProvider(domainFilter, cfg.DryRun)
+	case "efficientip":
+		p, err = efficientip.NewEfficientIPProvider(domainFilter, cfg.DryRun)
 	case "exoscale":
 		p, err = exoscale.NewExoscaleProvider(
 			cfg.ExoscaleAPIEnvironment,
@@ -272,6 +617,10 @@ func Execute() {
 			ClientCertKeyFilePath: cfg.TLSClientCertKey,
 		}
 		p, err = rfc2136.NewRfc2136Provider(cfg.RFC2136Host, cfg.RFC2136Port, cfg.RFC2136Zone, cfg.RFC2136Insecure, cfg.RFC2136TSIGKeyName, cfg.RFC2136TSIGSecret, cfg.RFC2136TSIGSecretAlg, cfg.RFC2136TAXFR, domainFilter, cfg.DryRun, cfg.RFC2136MinTTL, cfg.RFC2136CreatePTR, cfg.RFC2136GSSTSIG, cfg.RFC2136KerberosUsername, cfg.RFC2136KerberosPassword, cfg.RFC2136KerberosRealm, cfg.RFC2136BatchChangeSize, tlsConfig, cfg.RFC2136LoadBalancingStrategy, nil)
+	case "namecheap":
+		p, err = namecheap.NewNamecheapProvider(domainFilter, cfg.DryRun)
+	case "netlify":
+		p, err = netlify.NewNetlifyProvider(domainFilter, cfg.DryRun)
 	case "ns1":
 		p, err = ns1.NewNS1Provider(
 			ns1.NS1Config{
@@ -304,66 +653,49 @@ func Execute() {
 		)
 	case "plural":
 		p, err = plural.NewPluralProvider(cfg.PluralCluster, cfg.PluralProvider)
+	case "porkbun":
+		p, err = porkbun.NewPorkbunProvider(domainFilter, cfg.DryRun)
+	case "inwx":
+		p, err = inwx.NewInwxProvider(
+			inwx.InwxConfig{
+				Username:     cfg.InwxUsername,
+				Password:     cfg.InwxPassword,
+				OTPSecret:    cfg.InwxOTPSecret,
+				Sandbox:      cfg.InwxSandbox,
+				DomainFilter: domainFilter,
+				DryRun:       cfg.DryRun,
+			},
+		)
+	case "joker":
+		p, err = joker.NewJokerProvider(domainFilter, cfg.DryRun)
+	case "vercel":
+		p, err = vercel.NewVercelProvider(domainFilter, cfg.DryRun)
+	case "vultr":
+		p, err = vultr.NewVultrProvider(domainFilter, cfg.DryRun)
 	case "webhook":
 		p, err = webhook.NewWebhookProvider(cfg.WebhookProviderURL)
+	case "plugin":
+		p, err = plugin.NewGRPCProvider(cfg.ProviderPluginURL, cfg.ProviderPluginTimeout, domainFilter)
 	default:
-		log.Fatalf("unknown dns provider: %s", cfg.Provider)
-	}
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	if cfg.WebhookServer {
-		webhookapi.StartHTTPApi(p, nil, cfg.WebhookProviderReadTimeout, cfg.WebhookProviderWriteTimeout, "127.0.0.1:8888")
-		os.Exit(0)
-	}
-
-	if cfg.ProviderCacheTime > 0 {
-		p = provider.NewCachedProvider(
-			p,
-			cfg.ProviderCacheTime,
-		)
+		return nil, fmt.Errorf("unknown dns provider: %s", name)
 	}
+	return p, err
+}
 
-	reg, err := selectRegistry(cfg, p)
-	if err != nil {
-		log.Fatal(err)
+// ensureAWSZones creates the hosted zones of --aws-create-zone and delegates them from their parent zone, unless
+// the provider is read-only.
+func ensureAWSZones(ctx context.Context, cfg *externaldns.Config, p *aws.AWSProvider) error {
+	if len(cfg.AWSCreateZones) == 0 {
+		return nil
 	}
-
-	policy, exists := plan.Policies[cfg.Policy]
-	if !exists {
-		log.Fatalf("unknown policy: %s", cfg.Policy)
+	if cfg.ProviderReadOnly {
+		log.Infof("Read-only provider: not creating hosted zones %v", cfg.AWSCreateZones)
+		return nil
 	}
-
-	ctrl := Controller{
-		Source:               endpointsSource,
-		Registry:             reg,
-		Policy:               policy,
-		Interval:             cfg.Interval,
-		DomainFilter:         domainFilter,
-		ManagedRecordTypes:   cfg.ManagedDNSRecordTypes,
-		ExcludeRecordTypes:   cfg.ExcludeDNSRecordTypes,
-		MinEventSyncInterval: cfg.MinEventSyncInterval,
+	if err := p.EnsureZones(ctx); err != nil {
+		return fmt.Errorf("failed to create hosted zones: %w", err)
 	}
-
-	if cfg.Once {
-		err := ctrl.RunOnce(ctx)
-		if err != nil {
-			log.Fatal(err)
-		}
-
-		os.Exit(0)
-	}
-
-	if cfg.UpdateEvents {
-		// Add RunOnce as the handler function that will be called when ingress/service sources have changed.
-		// Note that k8s Informers will perform an initial list operation, which results in the handler
-		// function initially being called for every Service/Ingress that exists
-		ctrl.Source.AddEventHandler(ctx, func() { ctrl.ScheduleRunOnce(time.Now()) })
-	}
-
-	ctrl.ScheduleRunOnce(time.Now())
-	ctrl.Run(ctx)
+	return nil
 }
 
 // This function configures the logger format and level based on the provided configuration.
@@ -381,7 +713,7 @@ func configureLogger(cfg *externaldns.Config) {
 // selectRegistry selects the appropriate registry implementation based on the configuration in cfg.
 // It initializes and returns a registry along with any error encountered during setup.
 // Supported registry types include: dynamodb, noop, txt, and aws-sd.
-func selectRegistry(cfg *externaldns.Config, p provider.Provider) (registry.Registry, error) {
+func selectRegistry(ctx context.Context, cfg *externaldns.Config, p provider.Provider) (registry.Registry, error) {
 	var r registry.Registry
 	var err error
 	switch cfg.Registry {
@@ -394,7 +726,7 @@ func selectRegistry(cfg *externaldns.Config, p provider.Provider) (registry.Regi
 				},
 			}
 		}
-		r, err = registry.NewDynamoDBRegistry(p, cfg.TXTOwnerID, dynamodb.NewFromConfig(aws.CreateDefaultV2Config(cfg), dynamodbOpts...), cfg.AWSDynamoDBTable, cfg.TXTPrefix, cfg.TXTSuffix, cfg.TXTWildcardReplacement, cfg.ManagedDNSRecordTypes, cfg.ExcludeDNSRecordTypes, []byte(cfg.TXTEncryptAESKey), cfg.TXTCacheInterval)
+		r, err = registry.NewDynamoDBRegistry(p, cfg.TXTOwnerID, dynamodb.NewFromConfig(aws.CreateDefaultV2Config(ctx, cfg), dynamodbOpts...), cfg.AWSDynamoDBTable, cfg.TXTPrefix, cfg.TXTSuffix, cfg.TXTWildcardReplacement, cfg.ManagedDNSRecordTypes, cfg.ExcludeDNSRecordTypes, []byte(cfg.TXTEncryptAESKey), cfg.TXTCacheInterval)
 	case "noop":
 		r, err = registry.NewNoopRegistry(p)
 	case "txt":
@@ -426,21 +758,100 @@ func handleSigterm(cancel func()) {
 	cancel()
 }
 
-// serveMetrics starts an HTTP server that serves health and metrics endpoints.
+// metricsHandler returns the handler of the health and metrics endpoints.
 // The /healthz endpoint returns a 200 OK status to indicate the service is healthy.
-// The /metrics endpoint serves Prometheus metrics.
-// The server listens on the specified address and logs debug information about the endpoints.
-func serveMetrics(address string) {
-	http.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+// The metrics path serves Prometheus metrics.
+// A dedicated mux is used rather than http.DefaultServeMux, on which net/http/pprof registers its unauthenticated
+// profiling endpoints.
+func metricsHandler(metricsPath string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("OK"))
 	})
+	mux.Handle(metricsPath, promhttp.Handler())
+	return mux
+}
 
+// serveMetrics starts an HTTP server that serves health and metrics endpoints.
+// The server listens on the specified address and logs debug information about the endpoints.
+func serveMetrics(address, metricsPath string) {
 	log.Debugf("serving 'healthz' on 'localhost:%s/healthz'", address)
-	log.Debugf("serving 'metrics' on 'localhost:%s/metrics'", address)
+	log.Debugf("serving 'metrics' on 'localhost:%s%s'", address, metricsPath)
 	log.Debugf("registered '%d' metrics", len(metrics.RegisterMetric.Metrics))
 
-	http.Handle("/metrics", promhttp.Handler())
+	log.Fatal(http.ListenAndServe(address, metricsHandler(metricsPath)))
+}
+
+// healthHandler returns the handler of the probes and the metrics of the controller.
+// The /healthz endpoint returns a 200 OK status as long as the process is running.
+// The /readyz endpoint returns a 200 OK status once ready returns true, a 503 Service Unavailable status before.
+// The metrics path serves Prometheus metrics, so that a single port is needed.
+func healthHandler(metricsPath string, ready func() bool) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, promhttp.Handler())
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("OK"))
+	})
+	mux.HandleFunc("GET /readyz", func(w http.ResponseWriter, _ *http.Request) {
+		if !ready() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("no successful synchronization yet"))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("OK"))
+	})
+	return mux
+}
+
+// serveHealth starts an HTTP server that serves the liveness and readiness probes of the controller, which is ready
+// once it completed a successful synchronization, along with the metrics.
+func serveHealth(address, metricsPath string, ready func() bool) {
+	log.Debugf("serving 'healthz' on 'localhost:%s/healthz'", address)
+	log.Debugf("serving 'readyz' on 'localhost:%s/readyz'", address)
+	log.Debugf("serving 'metrics' on 'localhost:%s%s'", address, metricsPath)
+
+	log.Fatal(http.ListenAndServe(address, healthHandler(metricsPath, ready)))
+}
+
+// pprofUsername is the user name of the HTTP basic authentication of the profiling endpoints.
+const pprofUsername = "pprof"
+
+// pprofHandler returns the handler of the profiling endpoints of net/http/pprof under /debug/pprof/, which requires
+// the HTTP basic authentication of the given user name and password.
+func pprofHandler(username, password string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok ||
+			subtle.ConstantTimeCompare([]byte(user), []byte(username)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(pass), []byte(password)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="pprof", charset="UTF-8"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// servePprof starts an HTTP server that serves the profiling endpoints, protected by a random password which is
+// logged at startup.
+func servePprof(address string) {
+	secret := make([]byte, 16)
+	if _, err := rand.Read(secret); err != nil {
+		log.Fatalf("failed to generate the password of the profiling endpoints: %v", err)
+	}
+	password := hex.EncodeToString(secret)
+
+	log.Infof("serving 'pprof' on 'localhost:%s/debug/pprof/' with user %q and password %q", address, pprofUsername, password)
 
-	log.Fatal(http.ListenAndServe(address, nil))
+	log.Fatal(http.ListenAndServe(address, pprofHandler(pprofUsername, password)))
 }
@@ -20,8 +20,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/signal"
 	"reflect"
@@ -37,6 +39,7 @@ import (
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/provider/inmemory"
 )
 
 func TestSelectRegistry(t *testing.T) {
@@ -117,11 +120,11 @@ func TestSelectRegistry(t *testing.T) {
 				log.StandardLogger().ExitFunc = func(int) {}
 				log.StandardLogger().SetOutput(b)
 
-				_, err := selectRegistry(tt.cfg, tt.provider)
+				_, err := selectRegistry(t.Context(), tt.cfg, tt.provider)
 				assert.NoError(t, err)
 				assert.Contains(t, b.String(), "unknown registry: unknown")
 			} else {
-				reg, err := selectRegistry(tt.cfg, tt.provider)
+				reg, err := selectRegistry(t.Context(), tt.cfg, tt.provider)
 				assert.NoError(t, err)
 				assert.Contains(t, reflect.TypeOf(reg).String(), tt.wantType)
 			}
@@ -190,6 +193,21 @@ func TestCreateDomainFilter(t *testing.T) {
 	}
 }
 
+func TestBuildProvider(t *testing.T) {
+	domainFilter := endpoint.NewDomainFilter([]string{"example.com"})
+
+	p, err := buildProvider(context.Background(), &externaldns.Config{Providers: []string{"inmemory"}}, domainFilter)
+	require.NoError(t, err)
+	assert.IsType(t, &inmemory.InMemoryProvider{}, p)
+
+	p, err = buildProvider(context.Background(), &externaldns.Config{Providers: []string{"inmemory", "inmemory"}}, domainFilter)
+	require.NoError(t, err)
+	assert.IsType(t, &provider.MultiProvider{}, p)
+
+	_, err = buildProvider(context.Background(), &externaldns.Config{Providers: []string{"inmemory", "unknown"}}, domainFilter)
+	assert.EqualError(t, err, "provider unknown: unknown dns provider: unknown")
+}
+
 func TestHandleSigterm(t *testing.T) {
 	cancelCalled := make(chan bool, 1)
 	cancel := func() {
@@ -240,7 +258,7 @@ func TestServeMetrics(t *testing.T) {
 	require.NoError(t, err)
 	addresse := fmt.Sprintf("localhost:%d", port)
 
-	go serveMetrics(fmt.Sprintf(":%d", port))
+	go serveMetrics(fmt.Sprintf(":%d", port), "/metrics")
 
 	// Wait for the TCP socket to be ready
 	require.Eventually(t, func() bool {
@@ -261,6 +279,215 @@ func TestServeMetrics(t *testing.T) {
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 }
 
+func TestMetricsHandler(t *testing.T) {
+	for _, tt := range []struct {
+		title          string
+		path           string
+		expectedStatus int
+	}{
+		{
+			title:          "health check",
+			path:           "/healthz",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			title:          "metrics",
+			path:           "/metrics",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			title:          "profiling endpoints are not served",
+			path:           "/debug/pprof/",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title:          "profile endpoint is not served",
+			path:           "/debug/pprof/cmdline",
+			expectedStatus: http.StatusNotFound,
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			server := httptest.NewServer(metricsHandler("/metrics"))
+			defer server.Close()
+
+			req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL+tt.path, nil)
+			require.NoError(t, err)
+			resp, err := server.Client().Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestHealthHandler(t *testing.T) {
+	for _, tt := range []struct {
+		title          string
+		method         string
+		path           string
+		ready          bool
+		expectedStatus int
+	}{
+		{
+			title:          "liveness before the first synchronization",
+			method:         http.MethodGet,
+			path:           "/healthz",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			title:          "liveness after the first synchronization",
+			method:         http.MethodGet,
+			path:           "/healthz",
+			ready:          true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			title:          "readiness before the first synchronization",
+			method:         http.MethodGet,
+			path:           "/readyz",
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			title:          "readiness after the first synchronization",
+			method:         http.MethodGet,
+			path:           "/readyz",
+			ready:          true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			title:          "unsupported method",
+			method:         http.MethodPost,
+			path:           "/readyz",
+			ready:          true,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			title:          "unknown path",
+			method:         http.MethodGet,
+			path:           "/unknown",
+			ready:          true,
+			expectedStatus: http.StatusNotFound,
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			server := httptest.NewServer(healthHandler("/metrics", func() bool { return tt.ready }))
+			defer server.Close()
+
+			req, err := http.NewRequestWithContext(t.Context(), tt.method, server.URL+tt.path, nil)
+			require.NoError(t, err)
+			resp, err := server.Client().Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestPprofHandler(t *testing.T) {
+	for _, tt := range []struct {
+		title          string
+		path           string
+		credentials    bool
+		username       string
+		password       string
+		expectedStatus int
+	}{
+		{
+			title:          "index without credentials",
+			path:           "/debug/pprof/",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			title:          "profile without credentials",
+			path:           "/debug/pprof/cmdline",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			title:          "wrong password",
+			path:           "/debug/pprof/",
+			credentials:    true,
+			username:       "pprof",
+			password:       "wrong",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			title:          "wrong user name",
+			path:           "/debug/pprof/",
+			credentials:    true,
+			username:       "admin",
+			password:       "secret",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			title:          "index with credentials",
+			path:           "/debug/pprof/",
+			credentials:    true,
+			username:       "pprof",
+			password:       "secret",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			title:          "profile with credentials",
+			path:           "/debug/pprof/cmdline",
+			credentials:    true,
+			username:       "pprof",
+			password:       "secret",
+			expectedStatus: http.StatusOK,
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			server := httptest.NewServer(pprofHandler("pprof", "secret"))
+			defer server.Close()
+
+			req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL+tt.path, nil)
+			require.NoError(t, err)
+			if tt.credentials {
+				req.SetBasicAuth(tt.username, tt.password)
+			}
+			resp, err := server.Client().Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedStatus == http.StatusUnauthorized {
+				assert.Contains(t, resp.Header.Get("WWW-Authenticate"), "Basic")
+			}
+		})
+	}
+}
+
+func TestServeHealthMetrics(t *testing.T) {
+	t.Parallel()
+
+	port, err := getRandomPort()
+	require.NoError(t, err)
+	address := fmt.Sprintf("localhost:%d", port)
+
+	go serveHealth(fmt.Sprintf(":%d", port), "/custom/metrics", func() bool { return true })
+
+	// Wait for the TCP socket to be ready
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", address)
+		if err != nil {
+			return false
+		}
+		_ = conn.Close()
+		return true
+	}, 1*time.Second, 5*time.Millisecond, "server not ready with port open in time")
+
+	resp, err := http.Get(fmt.Sprintf("http://%s/custom/metrics", address))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "external_dns_controller_sync_duration_seconds")
+
+	resp, err = http.Get(fmt.Sprintf("http://%s/readyz", address))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
 func TestConfigureLogger(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// endpointsFingerprint returns a hash of the endpoints which doesn't depend on their order,
+// nor on the order of their targets, labels and provider specific properties.
+func endpointsFingerprint(endpoints []*endpoint.Endpoint) string {
+	lines := make([]string, 0, len(endpoints))
+	for _, ep := range endpoints {
+		targets := slices.Clone(ep.Targets)
+		sort.Strings(targets)
+
+		labels := make([]string, 0, len(ep.Labels))
+		for k, v := range ep.Labels {
+			labels = append(labels, k+"="+v)
+		}
+		sort.Strings(labels)
+
+		properties := make([]string, 0, len(ep.ProviderSpecific))
+		for _, p := range ep.ProviderSpecific {
+			properties = append(properties, p.Name+"="+p.Value)
+		}
+		sort.Strings(properties)
+
+		lines = append(lines, fmt.Sprintf("%q %q %q %d %q %q %q", ep.DNSName, ep.RecordType, ep.SetIdentifier, ep.RecordTTL, targets, labels, properties))
+	}
+	sort.Strings(lines)
+
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// isUnchanged returns true when the fingerprint is the one of the last successful synchronization,
+// which happened less than FingerprintMaxAge ago.
+func (c *Controller) isUnchanged(fingerprint string, now time.Time) bool {
+	c.registryMutex.RLock()
+	defer c.registryMutex.RUnlock()
+	return c.lastFingerprint != "" && c.lastFingerprint == fingerprint && now.Sub(c.lastFingerprintAt) < c.FingerprintMaxAge
+}
+
+// storeFingerprint records the fingerprint of a successful synchronization, unless the Source, the Registry or the
+// DomainFilter were replaced since the synchronization started with the given settings generation.
+func (c *Controller) storeFingerprint(fingerprint string, generation uint64, now time.Time) {
+	c.registryMutex.Lock()
+	defer c.registryMutex.Unlock()
+	if generation != c.settingsGeneration {
+		return
+	}
+	c.lastFingerprint, c.lastFingerprintAt = fingerprint, now
+}
+
+// resetFingerprint forgets the fingerprint of the last synchronization, so that the next one isn't skipped.
+func (c *Controller) resetFingerprint() {
+	c.registryMutex.Lock()
+	defer c.registryMutex.Unlock()
+	c.lastFingerprint = ""
+}
+
+// invalidateFingerprint forgets the fingerprint of the last synchronization after a replacement of the Source, the
+// Registry or the DomainFilter, as the same source endpoints may lead to different changes. The caller holds
+// registryMutex.
+func (c *Controller) invalidateFingerprint() {
+	c.lastFingerprint = ""
+	c.settingsGeneration++
+}
+
+func (c *Controller) currentSettingsGeneration() uint64 {
+	c.registryMutex.RLock()
+	defer c.registryMutex.RUnlock()
+	return c.settingsGeneration
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+// endpointsSource returns its endpoints, which can be changed between the synchronizations.
+type endpointsSource struct {
+	endpoints []*endpoint.Endpoint
+}
+
+func (s *endpointsSource) Endpoints(context.Context) ([]*endpoint.Endpoint, error) {
+	return s.endpoints, nil
+}
+
+func (s *endpointsSource) AddEventHandler(context.Context, func()) {}
+
+// failingMockProvider fails to apply the changes while fail is set.
+type failingMockProvider struct {
+	filteredMockProvider
+	fail bool
+}
+
+func (p *failingMockProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	_ = p.filteredMockProvider.ApplyChanges(ctx, changes)
+	if p.fail {
+		return errors.New("error for testing")
+	}
+	return nil
+}
+
+func newFingerprintController(t *testing.T, src *endpointsSource, p *failingMockProvider) *Controller {
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+
+	return &Controller{
+		Source:             src,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: getTestConfig().ManagedDNSRecordTypes,
+		FingerprintMaxAge:  time.Hour,
+	}
+}
+
+func TestRunOnceSkipsUnchangedEndpoints(t *testing.T) {
+	src := &endpointsSource{endpoints: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("create-record", endpoint.RecordTypeA, "1.2.3.4"),
+	}}
+	p := &failingMockProvider{}
+	ctrl := newFingerprintController(t, src, p)
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Len(t, p.ApplyChangesCalls, 1)
+	assert.Equal(t, 1, p.RecordsCallCount)
+
+	// the provider is not called at all while the endpoints are unchanged
+	src.endpoints = []*endpoint.Endpoint{
+		endpoint.NewEndpoint("create-record", endpoint.RecordTypeA, "1.2.3.4"),
+	}
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Len(t, p.ApplyChangesCalls, 1)
+	assert.Equal(t, 1, p.RecordsCallCount)
+
+	src.endpoints = []*endpoint.Endpoint{
+		endpoint.NewEndpoint("create-record", endpoint.RecordTypeA, "1.2.3.4", "5.6.7.8"),
+	}
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Len(t, p.ApplyChangesCalls, 2)
+	assert.Equal(t, 2, p.RecordsCallCount)
+}
+
+func TestRunOnceRefreshesExpiredFingerprint(t *testing.T) {
+	src := &endpointsSource{endpoints: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("create-record", endpoint.RecordTypeA, "1.2.3.4"),
+	}}
+	p := &failingMockProvider{}
+	ctrl := newFingerprintController(t, src, p)
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	ctrl.lastFingerprintAt = time.Now().Add(-2 * time.Hour)
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Len(t, p.ApplyChangesCalls, 2)
+}
+
+func TestRunOnceDoesNotSkipAfterFailure(t *testing.T) {
+	src := &endpointsSource{endpoints: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("create-record", endpoint.RecordTypeA, "1.2.3.4"),
+	}}
+	p := &failingMockProvider{fail: true}
+	ctrl := newFingerprintController(t, src, p)
+
+	require.Error(t, ctrl.RunOnce(context.Background()))
+	require.Error(t, ctrl.RunOnce(context.Background()))
+	assert.Len(t, p.ApplyChangesCalls, 2)
+
+	p.fail = false
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Len(t, p.ApplyChangesCalls, 3)
+}
+
+func TestRunOnceWithoutFingerprint(t *testing.T) {
+	src := &endpointsSource{endpoints: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("create-record", endpoint.RecordTypeA, "1.2.3.4"),
+	}}
+	p := &failingMockProvider{}
+	ctrl := newFingerprintController(t, src, p)
+	ctrl.FingerprintMaxAge = 0
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Len(t, p.ApplyChangesCalls, 2)
+}
+
+func TestEndpointsFingerprint(t *testing.T) {
+	newEndpoints := func() []*endpoint.Endpoint {
+		return []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("a.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "5.6.7.8").
+				WithLabel(endpoint.ResourceLabelKey, "service/default/a").
+				WithProviderSpecific("alias", "false"),
+			endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeCNAME, "a.example.com").WithSetIdentifier("b"),
+		}
+	}
+	fingerprint := endpointsFingerprint(newEndpoints())
+
+	reordered := newEndpoints()
+	reordered[0], reordered[1] = reordered[1], reordered[0]
+	reordered[1].Targets = endpoint.Targets{"5.6.7.8", "1.2.3.4"}
+	assert.Equal(t, fingerprint, endpointsFingerprint(reordered))
+
+	for name, change := range map[string]func(*endpoint.Endpoint){
+		"dns name":          func(ep *endpoint.Endpoint) { ep.DNSName = "c.example.com" },
+		"record type":       func(ep *endpoint.Endpoint) { ep.RecordType = endpoint.RecordTypeAAAA },
+		"set identifier":    func(ep *endpoint.Endpoint) { ep.SetIdentifier = "a" },
+		"ttl":               func(ep *endpoint.Endpoint) { ep.RecordTTL = 60 },
+		"targets":           func(ep *endpoint.Endpoint) { ep.Targets = endpoint.Targets{"1.2.3.4"} },
+		"labels":            func(ep *endpoint.Endpoint) { ep.Labels[endpoint.ResourceLabelKey] = "service/default/b" },
+		"provider specific": func(ep *endpoint.Endpoint) { ep.ProviderSpecific[0].Value = "true" },
+	} {
+		t.Run(name, func(t *testing.T) {
+			changed := newEndpoints()
+			change(changed[0])
+			assert.NotEqual(t, fingerprint, endpointsFingerprint(changed))
+		})
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/pkg/filewatch"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+// watchProviderConfig starts watching the provider configuration file. On each change the registry is rebuilt
+// with build and replaces the one of the controller, the current registry is kept when the build fails.
+// The directory is watched rather than the file, as the file is replaced when it is mounted from a Secret.
+func watchProviderConfig(ctx context.Context, path string, ctrl *Controller, build func() (registry.Registry, error)) error {
+	err := filewatch.Watch(ctx, path,
+		func(fsnotify.Event) { reloadRegistry(ctrl, path, build) },
+		func(err error) { log.Warnf("Failed to watch the provider configuration file %s: %v", path, err) })
+	if err != nil {
+		return fmt.Errorf("failed to watch the provider configuration file %s: %w", path, err)
+	}
+	return nil
+}
+
+func reloadRegistry(ctrl *Controller, path string, build func() (registry.Registry, error)) {
+	reg, err := build()
+	if err != nil {
+		log.Errorf("Failed to reload the provider after a change of %s, keeping the current one: %v", path, err)
+		return
+	}
+	ctrl.SetRegistry(reg)
+	log.Infof("Reloaded the provider after a change of %s", path)
+	ctrl.ScheduleRunOnce(time.Now())
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+// newConfigFileProvider returns a provider with a record targeting the content of the file.
+func newConfigFileProvider(path string) (*filteredMockProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	target := strings.TrimSpace(string(data))
+	if target == "" {
+		return nil, errors.New("empty configuration")
+	}
+	return &filteredMockProvider{
+		RecordsStore: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, target)},
+	}, nil
+}
+
+// replaceFile replaces the file at once, as the kubelet does for the files of Secret volumes.
+func replaceFile(t *testing.T, path, content string) {
+	t.Helper()
+	tmp := path + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, []byte(content), 0o600))
+	require.NoError(t, os.Rename(tmp, path))
+}
+
+func TestWatchProviderConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "provider.conf")
+	replaceFile(t, path, "1.2.3.4")
+
+	p, err := newConfigFileProvider(path)
+	require.NoError(t, err)
+	reg, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+	src := new(testutils.MockSource)
+	src.On("Endpoints").Return([]*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.4")}, nil)
+	ctrl := &Controller{
+		Source:             src,
+		Registry:           reg,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+	}
+
+	var builds atomic.Int32
+	var reloaded atomic.Pointer[filteredMockProvider]
+	require.NoError(t, watchProviderConfig(t.Context(), path, ctrl, func() (registry.Registry, error) {
+		builds.Add(1)
+		p, err := newConfigFileProvider(path)
+		if err != nil {
+			return nil, err
+		}
+		reloaded.Store(p)
+		return registry.NewNoopRegistry(p)
+	}))
+
+	// a change of another file of the directory is ignored
+	replaceFile(t, filepath.Join(filepath.Dir(path), "other.conf"), "ignored")
+
+	// the file is replaced while the controller runs
+	replaceFile(t, path, "5.6.7.8")
+	assert.Eventually(t, func() bool { return ctrl.currentRegistry() != reg }, 5*time.Second, 10*time.Millisecond, "the registry should be replaced")
+	assert.Equal(t, int32(1), builds.Load())
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Empty(t, p.ApplyChangesCalls, "the previous provider should not be used anymore")
+	require.Len(t, reloaded.Load().ApplyChangesCalls, 1)
+	assert.Equal(t, endpoint.Targets{"1.2.3.4"}, reloaded.Load().ApplyChangesCalls[0].UpdateNew[0].Targets)
+
+	// an invalid configuration keeps the current registry
+	current := ctrl.currentRegistry()
+	replaceFile(t, path, "")
+	assert.Eventually(t, func() bool { return builds.Load() == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.Same(t, current, ctrl.currentRegistry())
+}
+
+func TestReloadRegistryInvalidatesFingerprint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "provider.conf")
+	replaceFile(t, path, "1.2.3.4")
+
+	p, err := newConfigFileProvider(path)
+	require.NoError(t, err)
+	reg, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+	ctrl := &Controller{
+		Source: &endpointsSource{endpoints: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		}},
+		Registry:           reg,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		FingerprintMaxAge:  time.Hour,
+	}
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	assert.Empty(t, p.ApplyChangesCalls)
+
+	// the rebuilt provider is synchronized although the source endpoints are unchanged
+	replaceFile(t, path, "5.6.7.8")
+	reloaded, err := newConfigFileProvider(path)
+	require.NoError(t, err)
+	reloadRegistry(ctrl, path, func() (registry.Registry, error) { return registry.NewNoopRegistry(reloaded) })
+	require.NoError(t, ctrl.RunOnce(t.Context()))
+	require.Len(t, reloaded.ApplyChangesCalls, 1)
+	assert.Equal(t, endpoint.Targets{"1.2.3.4"}, reloaded.ApplyChangesCalls[0].UpdateNew[0].Targets)
+}
+
+func TestWatchProviderConfigMissingDirectory(t *testing.T) {
+	err := watchProviderConfig(t.Context(), filepath.Join(t.TempDir(), "missing", "provider.conf"), &Controller{}, nil)
+	assert.ErrorContains(t, err, "failed to watch the provider configuration file")
+}
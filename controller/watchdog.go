@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// watchdogExitFactor is the multiple of the reconcile timeout after which a synchronization which
+// ignores the cancellation of its context is considered deadlocked.
+const watchdogExitFactor = 3
+
+var reconcileTimeoutsTotal = metrics.NewCounterWithOpts(
+	prometheus.CounterOpts{
+		Namespace: "external_dns",
+		Subsystem: "controller",
+		Name:      "reconcile_timeouts_total",
+		Help:      "Number of reconcile loops canceled because they exceeded the reconcile timeout.",
+	},
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(reconcileTimeoutsTotal)
+}
+
+// runOnceWithWatchdog runs RunOnce with a context which is canceled when the synchronization doesn't complete
+// within ReconcileTimeout. When RunOnce still doesn't return, the process exits so that it is restarted.
+func (c *Controller) runOnceWithWatchdog(ctx context.Context) error {
+	if c.ReconcileTimeout <= 0 {
+		return c.RunOnce(ctx)
+	}
+
+	syncCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var timedOut atomic.Bool
+	done := make(chan struct{})
+	go c.watch(cancel, done, &timedOut)
+
+	err := c.RunOnce(syncCtx)
+	close(done)
+
+	if err != nil && timedOut.Load() {
+		// The next synchronization may well complete in time, so the loop must go on.
+		return provider.NewSoftErrorf("synchronization canceled after %s: %w", c.ReconcileTimeout, err)
+	}
+	return err
+}
+
+// watch cancels the synchronization when it exceeds ReconcileTimeout and exits the process when
+// it doesn't return within watchdogExitFactor times ReconcileTimeout.
+func (c *Controller) watch(cancel context.CancelFunc, done <-chan struct{}, timedOut *atomic.Bool) {
+	timer := time.NewTimer(c.ReconcileTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return
+	case <-timer.C:
+	}
+
+	log.Errorf("Synchronization did not complete within %s, canceling it", c.ReconcileTimeout)
+	reconcileTimeoutsTotal.Counter.Inc()
+	timedOut.Store(true)
+	cancel()
+
+	timer.Reset((watchdogExitFactor - 1) * c.ReconcileTimeout)
+	select {
+	case <-done:
+		return
+	case <-timer.C:
+	}
+
+	exit := c.watchdogExit
+	if exit == nil {
+		exit = log.Fatalf
+	}
+	exit("Synchronization did not recover within %s of its cancellation, exiting", (watchdogExitFactor-1)*c.ReconcileTimeout)
+}
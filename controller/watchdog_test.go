@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+// blockingSource blocks in Endpoints until released, or until its context is canceled when honorContext is set.
+type blockingSource struct {
+	honorContext bool
+	release      chan struct{}
+}
+
+func (s *blockingSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	if s.honorContext {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-s.release:
+			return nil, nil
+		}
+	}
+	<-s.release
+	return nil, ctx.Err()
+}
+
+func (s *blockingSource) AddEventHandler(context.Context, func()) {}
+
+func newWatchdogController(t *testing.T, src *blockingSource) *Controller {
+	r, err := registry.NewNoopRegistry(&filteredMockProvider{})
+	require.NoError(t, err)
+
+	return &Controller{
+		Source:             src,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: getTestConfig().ManagedDNSRecordTypes,
+		ReconcileTimeout:   50 * time.Millisecond,
+		watchdogExit: func(format string, args ...any) {
+			t.Errorf("unexpected exit: "+format, args...)
+		},
+	}
+}
+
+func TestWatchdogCancelsBlockedSync(t *testing.T) {
+	src := &blockingSource{honorContext: true, release: make(chan struct{})}
+	ctrl := newWatchdogController(t, src)
+	timeouts := testutil.ToFloat64(reconcileTimeoutsTotal.Counter)
+
+	err := ctrl.runOnceWithWatchdog(context.Background())
+	require.ErrorIs(t, err, provider.SoftError)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.ErrorContains(t, err, "synchronization canceled after 50ms")
+	assert.InDelta(t, timeouts+1, testutil.ToFloat64(reconcileTimeoutsTotal.Counter), 0)
+}
+
+func TestWatchdogDoesNotCancelCompletedSync(t *testing.T) {
+	src := &blockingSource{honorContext: true, release: make(chan struct{})}
+	close(src.release)
+	ctrl := newWatchdogController(t, src)
+	timeouts := testutil.ToFloat64(reconcileTimeoutsTotal.Counter)
+
+	require.NoError(t, ctrl.runOnceWithWatchdog(context.Background()))
+	time.Sleep(2 * ctrl.ReconcileTimeout)
+	assert.InDelta(t, timeouts, testutil.ToFloat64(reconcileTimeoutsTotal.Counter), 0)
+}
+
+func TestWatchdogExitsOnDeadlock(t *testing.T) {
+	src := &blockingSource{release: make(chan struct{})}
+	ctrl := newWatchdogController(t, src)
+	exited := make(chan string, 1)
+	ctrl.watchdogExit = func(format string, args ...any) {
+		exited <- fmt.Sprintf(format, args...)
+		close(src.release)
+	}
+
+	start := time.Now()
+	err := ctrl.runOnceWithWatchdog(context.Background())
+	require.ErrorIs(t, err, provider.SoftError)
+
+	select {
+	case msg := <-exited:
+		assert.Equal(t, "Synchronization did not recover within 100ms of its cancellation, exiting", msg)
+	default:
+		t.Fatal("the watchdog must exit when the synchronization ignores its cancellation")
+	}
+	assert.GreaterOrEqual(t, time.Since(start), watchdogExitFactor*ctrl.ReconcileTimeout)
+}
+
+func TestWatchdogDisabled(t *testing.T) {
+	src := &blockingSource{honorContext: true, release: make(chan struct{})}
+	ctrl := newWatchdogController(t, src)
+	ctrl.ReconcileTimeout = 0
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := ctrl.runOnceWithWatchdog(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NotErrorIs(t, err, provider.SoftError)
+}
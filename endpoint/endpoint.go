@@ -45,6 +45,8 @@ const (
 	RecordTypeMX = "MX"
 	// RecordTypeNAPTR is a RecordType enum value
 	RecordTypeNAPTR = "NAPTR"
+	// RecordTypeCAA is a RecordType enum value
+	RecordTypeCAA = "CAA"
 )
 
 // TTL is a structure defining the TTL of a DNS record
@@ -91,43 +93,34 @@ func (t Targets) Swap(i, j int) {
 	t[i], t[j] = t[j], t[i]
 }
 
-// Same compares to Targets and returns true if they are identical (case-insensitive)
+// Same compares two Targets as sets and returns true if they hold the same targets (case-insensitive),
+// regardless of their order and duplicates. IPv6 can be shortened, so IP addresses are compared by value.
 func (t Targets) Same(o Targets) bool {
-	if len(t) != len(o) {
+	ts, os := t.set(), o.set()
+	if len(ts) != len(os) {
 		return false
 	}
-	sort.Stable(t)
-	sort.Stable(o)
-
-	for i, e := range t {
-		if !strings.EqualFold(e, o[i]) {
-			// IPv6 can be shortened, so it should be parsed for equality checking
-			ipA, err := netip.ParseAddr(e)
-			if err != nil {
-				log.WithFields(log.Fields{
-					"targets":           t,
-					"comparisonTargets": o,
-				}).Debugf("Couldn't parse %s as an IP address: %v", e, err)
-			}
-
-			ipB, err := netip.ParseAddr(o[i])
-			if err != nil {
-				log.WithFields(log.Fields{
-					"targets":           t,
-					"comparisonTargets": o,
-				}).Debugf("Couldn't parse %s as an IP address: %v", e, err)
-			}
-
-			// IPv6 Address Shortener == IPv6 Address Expander
-			if ipA.IsValid() && ipB.IsValid() {
-				return ipA.String() == ipB.String()
-			}
+	for e := range ts {
+		if _, ok := os[e]; !ok {
 			return false
 		}
 	}
 	return true
 }
 
+// set returns the normalized targets, IP addresses in their canonical form and other targets in lower case.
+func (t Targets) set() map[string]struct{} {
+	set := make(map[string]struct{}, len(t))
+	for _, e := range t {
+		if ip, err := netip.ParseAddr(e); err == nil {
+			set[ip.String()] = struct{}{}
+		} else {
+			set[strings.ToLower(e)] = struct{}{}
+		}
+	}
+	return set
+}
+
 // IsLess should fulfill the requirement to compare two targets and choose the 'lesser' one.
 // In the past target was a simple string so simple string comparison could be used. Now we define 'less'
 // as either being the shorter list of targets or where the first entry is less.
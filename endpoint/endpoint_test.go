@@ -110,6 +110,14 @@ func TestSameSuccess(t *testing.T) {
 			[]string{"::1", "1.1.1.1", "2600.com", "3.3.3.3"},
 			[]string{"2600.com", "::0001", "3.3.3.3", "1.1.1.1"},
 		},
+		{
+			[]string{"1.2.3.4", "5.6.7.8"},
+			[]string{"5.6.7.8", "1.2.3.4"},
+		},
+		{
+			[]string{"1.2.3.4", "5.6.7.8", "1.2.3.4"},
+			[]string{"5.6.7.8", "1.2.3.4"},
+		},
 	}
 
 	for _, d := range tests {
@@ -141,6 +149,10 @@ func TestSameFailures(t *testing.T) {
 			[]string{"::1", "2600.com", "3.3.3.3"},
 			[]string{"2600.com", "3.3.3.3", "1.1.1.1"},
 		},
+		{
+			[]string{"::1", "a.example.com"},
+			[]string{"::0001", "b.example.com"},
+		},
 	}
 
 	for _, d := range tests {
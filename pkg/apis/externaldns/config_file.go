@@ -0,0 +1,152 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldns
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/alecthomas/kingpin/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// configEnvar is the environment variable of the --config flag, named as the default environment variables of the
+// flags.
+const configEnvar = "EXTERNAL_DNS_CONFIG"
+
+// configFileArgs returns the flags set by the configuration file given with --config, in YAML or JSON, whose keys are
+// the names of the flags. The values of the repeatable flags are lists, and the ones of the key=value flags are maps.
+// The flags given on the command line are skipped, for them to override the file.
+func configFileArgs(app *kingpin.Application, args []string) ([]string, error) {
+	context, err := app.ParseContext(args)
+	if err != nil {
+		return nil, err
+	}
+	path := os.Getenv(configEnvar)
+	commandLine := map[string]bool{}
+	for _, element := range context.Elements {
+		flag, ok := element.Clause.(*kingpin.FlagClause)
+		if !ok {
+			continue
+		}
+		name := flag.Model().Name
+		if name == "config" && element.Value != nil {
+			path = *element.Value
+		}
+		commandLine[name] = true
+	}
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	var values map[string]any
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var fileArgs []string
+	for _, name := range names {
+		flag := app.GetFlag(name)
+		if flag == nil || name == "config" {
+			return nil, fmt.Errorf("unknown flag %q in config file %s", name, path)
+		}
+		if commandLine[name] {
+			continue
+		}
+		flagArgs, err := configFileFlagArgs(name, flag.Model().IsBoolFlag(), values[name])
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of flag %q in config file %s: %w", name, path, err)
+		}
+		fileArgs = append(fileArgs, flagArgs...)
+	}
+	return fileArgs, nil
+}
+
+// configFileFlagArgs returns the command line arguments of a flag set in a configuration file.
+func configFileFlagArgs(name string, isBool bool, value any) ([]string, error) {
+	if isBool {
+		enabled, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected a boolean, got %v", value)
+		}
+		if !enabled {
+			return []string{"--no-" + name}, nil
+		}
+		return []string{"--" + name}, nil
+	}
+
+	switch value := value.(type) {
+	case nil:
+		return nil, nil
+	case []any:
+		args := make([]string, 0, len(value))
+		for _, item := range value {
+			s, err := configFileScalar(item)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, "--"+name+"="+s)
+		}
+		return args, nil
+	case map[string]any:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		args := make([]string, 0, len(value))
+		for _, key := range keys {
+			s, err := configFileScalar(value[key])
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, "--"+name+"="+key+"="+s)
+		}
+		return args, nil
+	}
+	s, err := configFileScalar(value)
+	if err != nil {
+		return nil, err
+	}
+	return []string{"--" + name + "=" + s}, nil
+}
+
+// configFileScalar returns a scalar value of a configuration file as a flag value. The numbers are parsed as floats,
+// they're formatted without exponent.
+func configFileScalar(value any) (string, error) {
+	switch value := value.(type) {
+	case string:
+		return value, nil
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	}
+	return "", fmt.Errorf("unsupported value %v", value)
+}
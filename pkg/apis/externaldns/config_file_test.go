@@ -0,0 +1,360 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldns
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfigFile writes a configuration file, the tabs indenting its lines being removed.
+func writeConfigFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		lines = append(lines, strings.TrimLeft(line, "\t"))
+	}
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o600))
+	return path
+}
+
+func TestParseFlagsConfigFile(t *testing.T) {
+	// the configuration file equivalent to the flags overriding everything
+	path := writeConfigFile(t, "config.yaml", `
+		server: "http://127.0.0.1:8080"
+		kubeconfig: "/some/path"
+		kube-context: "some-context"
+		kube-impersonate-user: "system:serviceaccount:default:external-dns"
+		kube-impersonate-group:
+		  - "system:serviceaccounts"
+		  - "dns-admins"
+		kube-extra-endpoints:
+		  - "https://api.a.example.com"
+		  - "https://api.b.example.com=/path/to/b"
+		kube-skip-tls-verify: true
+		kube-ca-file: "/some/ca.crt"
+		request-timeout: "77s"
+		resync-period: "1m"
+		watch-backoff-max-delay: "2m"
+		gloo-namespace:
+		  - "gloo-not-system"
+		  - "gloo-second-system"
+		skipper-routegroup-groupversion: "zalando.org/v2"
+		source:
+		  - "service"
+		  - "ingress"
+		  - "connector"
+		namespace: "namespace"
+		fqdn-template: "{{.Name}}.service.example.com"
+		ignore-non-host-network-pods: true
+		ignore-hostname-annotation: true
+		hostname-aliases: "web=web.example.com,api=api.example.com"
+		ignore-ingress-tls-spec: true
+		include-cluster-ip: true
+		ignore-ingress-rules-spec: true
+		inherit-service-annotations: true
+		update-ingress-status: true
+		compatibility: "mate"
+		provider: "google"
+		google-project: "project"
+		google-batch-change-size: 100
+		google-batch-change-interval: "2s"
+		google-zone-visibility: "private"
+		google-response-policy: "cluster-policy"
+		google-forwarding-zone: "corp.example.com"
+		google-forwarding-target:
+		  - "10.0.0.53"
+		  - "10.0.1.53,private"
+		google-forwarding-network: "https://www.googleapis.com/compute/v1/projects/project/global/networks/default"
+		azure-config-file: "azure.json"
+		azure-resource-group: "arg"
+		azure-subscription-id: "arg"
+		azure-maxretries-count: 4
+		azure-traffic-manager-profile: "global"
+		cloudflare-proxied: true
+		cloudflare-custom-hostnames: true
+		cloudflare-custom-hostnames-min-tls-version: "1.3"
+		cloudflare-custom-hostnames-certificate-authority: "google"
+		cloudflare-dns-records-per-page: 5000
+		cloudflare-load-balancers: true
+		cloudflare-worker-routes: true
+		cloudflare-verify-propagation: true
+		cloudflare-verify-timeout: "2m"
+		cloudflare-region-key: "us"
+		cloudflare-zone-token:
+		  "example.org": "token1"
+		  "example.com": "file:/etc/cloudflare/example.com"
+		cloudflare-zone-filter-regex: "^(team-a|team-b)\\."
+		coredns-prefix: "/coredns/"
+		akamai-serviceconsumerdomain: "oooo-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net"
+		akamai-client-token: "o184671d5307a388180fbf7f11dbdf46"
+		akamai-client-secret: "o184671d5307a388180fbf7f11dbdf46"
+		akamai-access-token: "o184671d5307a388180fbf7f11dbdf46"
+		akamai-edgerc-path: "/home/test/.edgerc"
+		akamai-edgerc-section: "default"
+		inmemory-zone:
+		  - "example.org"
+		  - "company.com"
+		ovh-endpoint: "ovh-ca"
+		ovh-api-rate-limit: 42
+		pdns-server: "http://ns.example.com:8081"
+		pdns-server-id: "localhost"
+		pdns-api-key: "some-secret-key"
+		pdns-skip-tls-verify: true
+		oci-config-file: "oci.yaml"
+		oci-zone-scope: "PRIVATE"
+		oci-zones-cache-duration: "30s"
+		tls-ca: "/path/to/ca.crt"
+		tls-client-cert: "/path/to/cert.pem"
+		tls-client-cert-key: "/path/to/key.pem"
+		pod-source-domain: "example.org"
+		domain-filter:
+		  - "example.org"
+		  - "company.com"
+		exclude-domains:
+		  - "xapi.example.org"
+		  - "xapi.company.com"
+		regex-domain-filter: "(example\\.org|company\\.com)$"
+		regex-domain-exclusion: "xapi\\.(example\\.org|company\\.com)$"
+		zone-name-filter:
+		  - "yapi.example.org"
+		  - "yapi.company.com"
+		zone-id-filter:
+		  - "/hostedzone/ZTST1"
+		  - "/hostedzone/ZTST2"
+		protected-zones:
+		  - "example.net"
+		  - "example.info"
+		target-net-filter:
+		  - "10.0.0.0/9"
+		  - "10.1.0.0/9"
+		exclude-target-net:
+		  - "1.0.0.0/9"
+		  - "1.1.0.0/9"
+		aws-zone-type: "private"
+		aws-zone-tags: "tag=foo"
+		aws-zone-match-parent: true
+		aws-validate-dnssec: true
+		aws-manage-traffic-policies: true
+		aws-arc-routing-control-arn: "arn:aws:route53-recovery-control::123456789012:controlpanel/abc/routingcontrol/def"
+		aws-arc-cluster-endpoint:
+		  - "https://a.route53-recovery-cluster.us-west-2.amazonaws.com/v1"
+		  - "https://b.route53-recovery-cluster.eu-west-1.amazonaws.com/v1"
+		aws-recovery-readiness: true
+		aws-recovery-readiness-cell-arn: "arn:aws:route53-recovery-readiness::123456789012:cell/us-west-2"
+		aws-create-zone:
+		  - "a.example.com"
+		  - "b.example.com"
+		aws-zone-auto-delegate: true
+		aws-parent-zone-id: "/hostedzone/Z1234567890"
+		aws-dnssec-resolver: "127.0.0.1:53"
+		aws-assume-role: "some-other-role"
+		aws-assume-role-external-id: "pg2000"
+		aws-batch-change-size: 100
+		aws-batch-change-size-bytes: 16000
+		aws-batch-change-size-values: 100
+		aws-batch-change-interval: "2s"
+		aws-max-changes-per-batch: 50
+		aws-transactional-zone-updates: true
+		aws-api-retries: 13
+		aws-ec2-metadata-v2-only: true
+		aws-prefer-cname: true
+		aws-profile:
+		  - "profile1"
+		  - "profile2"
+		aws-zones-cache-duration: "10s"
+		aws-sd-service-cleanup: true
+		aws-sd-create-tag:
+		  "key1": "value1"
+		  "key2": "value2"
+		aws-domain-role:
+		  "example.org": "arn:aws:iam::123456789012:role/example-org"
+		aws-assume-role-session-tag:
+		  "team": "dns"
+		  "cost-center": 1234
+		aws-assume-role-transitive-tag-key: "team"
+		aws-request-tag: "cluster-1"
+		aws-endpoint-url: "http://localhost:4566"
+		aws-sso-start-url: "https://example.awsapps.com/start"
+		aws-sso-account-id: 123456789012
+		aws-sso-role-name: "external-dns"
+		aws-sso-region: "eu-west-1"
+		aws-evaluate-target-health: false
+		pihole-api-version: 6
+		policy: "upsert-only"
+		registry: "noop"
+		txt-owner-id: "owner-1"
+		txt-prefix: "associated-txt-record"
+		txt-cache-interval: "12h"
+		txt-new-format-only: true
+		transfer-ownership-from: "previous"
+		dynamodb-table: "custom-table"
+		interval: "10m"
+		min-event-sync-interval: "50s"
+		interval-jitter-factor: "0.2"
+		fingerprint-max-age: "15m"
+		reconcile-timeout: "5m"
+		force-full-sync-after-errors: 5
+		once: true
+		dry-run: true
+		events: true
+		log-format: "json"
+		metrics-address: "127.0.0.1:9099"
+		health-listen-address: "127.0.0.1:9098"
+		metrics-path: "/external-dns/metrics"
+		enable-pprof: true
+		pprof-listen-address: "127.0.0.1:6061"
+		log-level: "debug"
+		connector-source-server: "localhost:8081"
+		webhook-source-url: "http://localhost:8082/endpoints"
+		source-file: "/etc/external-dns/endpoints.yaml"
+		provider-config-file: "/etc/kubernetes/azure.json"
+		provider-read-only: true
+		federation-kubeconfig: "/path/to/cluster-a,/path/to/cluster-b"
+		federation-source: "ingress"
+		exoscale-apienv: "api1"
+		exoscale-apizone: "zone1"
+		exoscale-apikey: 1
+		exoscale-apisecret: 2
+		crd-source-apiversion: "test.k8s.io/v1alpha1"
+		crd-source-kind: "Endpoint"
+		crd-source-finalizer: true
+		ns1-endpoint: "https://api.example.com/v1"
+		ns1-ignoressl: true
+		transip-account: "transip"
+		transip-keyfile: "/path/to/transip.key"
+		inwx-username: "inwx-user"
+		inwx-password: "inwx-pass"
+		inwx-otp-secret: "GEZDGNBVGY3TQOJQ"
+		inwx-sandbox: true
+		digitalocean-api-page-size: 100
+		managed-record-types:
+		  - "A"
+		  - "AAAA"
+		  - "CNAME"
+		  - "NS"
+		exclude-unschedulable: false
+		endpoint-transform-webhook: "http://localhost:8080/transform"
+		endpoint-transform-webhook-timeout: "10s"
+		provider-plugin-url: "localhost:8889"
+		provider-plugin-timeout: "20s"
+		rfc2136-batch-change-size: 100
+		rfc2136-load-balancing-strategy: "round-robin"
+		rfc2136-host:
+		  - "rfc2136-host1"
+		  - "rfc2136-host2"
+`)
+
+	cfg := NewConfig()
+	require.NoError(t, cfg.ParseFlags([]string{"--config=" + path}))
+
+	expected := *overriddenConfig
+	expected.ConfigFile = path
+	assert.Equal(t, &expected, cfg)
+}
+
+func TestParseFlagsConfigFileOverriddenByFlags(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", `
+		provider: aws
+		source:
+		  - service
+		  - ingress
+		domain-filter:
+		  - example.com
+		  - example.org
+		dry-run: true
+		interval: 5m
+		log-level: warning
+		aws-sd-create-tag:
+		  team: dns
+	`)
+	// the file overrides the environment variables
+	t.Setenv("EXTERNAL_DNS_LOG_LEVEL", "debug")
+	t.Setenv("EXTERNAL_DNS_TXT_OWNER_ID", "owner")
+
+	cfg := NewConfig()
+	require.NoError(t, cfg.ParseFlags([]string{"--config", path, "--provider=google", "--source=crd", "--no-dry-run"}))
+	assert.Equal(t, path, cfg.ConfigFile)
+	assert.Equal(t, "google", cfg.Provider)
+	assert.Equal(t, []string{"crd"}, cfg.Sources)
+	assert.False(t, cfg.DryRun)
+	assert.Equal(t, []string{"example.com", "example.org"}, cfg.DomainFilter)
+	assert.Equal(t, 5*time.Minute, cfg.Interval)
+	assert.Equal(t, "warning", cfg.LogLevel)
+	assert.Equal(t, "owner", cfg.TXTOwnerID)
+	assert.Equal(t, map[string]string{"team": "dns"}, cfg.AWSSDCreateTag)
+}
+
+func TestParseFlagsConfigFileJSON(t *testing.T) {
+	path := writeConfigFile(t, "config.json", `{"provider": "aws", "source": ["service"], "aws-batch-change-size": 2000000, "once": true}`)
+
+	// the file is given by the environment variable of the flag
+	t.Setenv("EXTERNAL_DNS_CONFIG", path)
+
+	cfg := NewConfig()
+	require.NoError(t, cfg.ParseFlags(nil))
+	assert.Equal(t, path, cfg.ConfigFile)
+	assert.Equal(t, "aws", cfg.Provider)
+	assert.Equal(t, []string{"service"}, cfg.Sources)
+	assert.Equal(t, 2000000, cfg.AWSBatchChangeSize)
+	assert.True(t, cfg.Once)
+}
+
+func TestParseFlagsConfigFileErrors(t *testing.T) {
+	for _, tt := range []struct {
+		title   string
+		content string
+		err     string
+	}{
+		{
+			title:   "unknown flag",
+			content: "provider: aws\nunknown-flag: 1\n",
+			err:     `unknown flag "unknown-flag" in config file %s`,
+		},
+		{
+			title:   "nested config file",
+			content: "config: other.yaml\n",
+			err:     `unknown flag "config" in config file %s`,
+		},
+		{
+			title:   "not a boolean",
+			content: "dry-run: yes please\n",
+			err:     `invalid value of flag "dry-run" in config file %s: expected a boolean, got yes please`,
+		},
+		{
+			title:   "invalid value",
+			content: "interval: soon\n",
+			err:     `time: invalid duration "soon"`,
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			path := writeConfigFile(t, "config.yaml", tt.content)
+			cfg := NewConfig()
+			err := cfg.ParseFlags([]string{"--config=" + path, "--source=service"})
+			require.EqualError(t, err, strings.ReplaceAll(tt.err, "%s", path))
+		})
+	}
+
+	cfg := NewConfig()
+	require.ErrorContains(t, cfg.ParseFlags([]string{"--config=/does/not/exist.yaml"}), "failed to read config file")
+}
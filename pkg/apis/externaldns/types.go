@@ -38,9 +38,19 @@ const (
 
 // Config is a project-wide configuration
 type Config struct {
+	ConfigFile                                    string
+	ConfigFromConfigMap                           string
 	APIServerURL                                  string
 	KubeConfig                                    string
+	KubeContext                                   string
+	KubeImpersonateUser                           string
+	KubeImpersonateGroups                         []string
+	KubeExtraEndpoints                            []string
+	KubeSkipTLSVerify                             bool
+	KubeCAFile                                    string
 	RequestTimeout                                time.Duration
+	ResyncPeriod                                  time.Duration
+	WatchBackoffMaxDelay                          time.Duration
 	DefaultTargets                                []string
 	GlooNamespaces                                []string
 	SkipperRouteGroupVersion                      string
@@ -52,9 +62,12 @@ type Config struct {
 	FQDNTemplate                                  string
 	CombineFQDNAndAnnotation                      bool
 	IgnoreHostnameAnnotation                      bool
+	HostnameAliases                               string
 	IgnoreNonHostNetworkPods                      bool
 	IgnoreIngressTLSSpec                          bool
 	IgnoreIngressRulesSpec                        bool
+	InheritServiceAnnotations                     bool
+	UpdateIngressStatus                           bool
 	ListenEndpointEvents                          bool
 	ExposeInternalIPV6                            bool
 	GatewayName                                   string
@@ -63,21 +76,34 @@ type Config struct {
 	Compatibility                                 string
 	PodSourceDomain                               string
 	PublishInternal                               bool
+	IncludeClusterIP                              bool
 	PublishHostIP                                 bool
 	AlwaysPublishNotReadyAddresses                bool
 	ConnectorSourceServer                         string
+	WebhookSourceURL                              string
+	SourceFile                                    string
+	FederationKubeConfig                          string
+	FederationSource                              string
 	Provider                                      string
+	Providers                                     []string
 	ProviderCacheTime                             time.Duration
+	ProviderConfigFile                            string
+	ProviderReadOnly                              bool
 	GoogleProject                                 string
 	GoogleBatchChangeSize                         int
 	GoogleBatchChangeInterval                     time.Duration
 	GoogleZoneVisibility                          string
+	GoogleResponsePolicy                          string
+	GoogleForwardingZones                         []string
+	GoogleForwardingTargets                       []string
+	GoogleForwardingNetworks                      []string
 	DomainFilter                                  []string
 	ExcludeDomains                                []string
 	RegexDomainFilter                             *regexp.Regexp
 	RegexDomainExclusion                          *regexp.Regexp
 	ZoneNameFilter                                []string
 	ZoneIDFilter                                  []string
+	ProtectedZones                                []string
 	TargetNetFilter                               []string
 	ExcludeTargetNets                             []string
 	AlibabaCloudConfigFile                        string
@@ -87,17 +113,41 @@ type Config struct {
 	AWSAssumeRole                                 string
 	AWSProfiles                                   []string
 	AWSAssumeRoleExternalID                       string `secure:"yes"`
+	AWSDomainRoles                                map[string]string
+	AWSAssumeRoleSessionTags                      map[string]string
+	AWSAssumeRoleTransitiveTagKeys                []string
+	AWSRequestTag                                 string
+	AWSEndpointURL                                string
+	AWSSSOStartURL                                string
+	AWSSSOAccountID                               string
+	AWSSSORoleName                                string
+	AWSSSORegion                                  string
 	AWSBatchChangeSize                            int
 	AWSBatchChangeSizeBytes                       int
 	AWSBatchChangeSizeValues                      int
 	AWSBatchChangeInterval                        time.Duration
+	AWSMaxChangesPerBatch                         int
+	AWSTransactionalZoneUpdates                   bool
 	AWSEvaluateTargetHealth                       bool
 	AWSAPIRetries                                 int
+	AWSEC2MetadataV2Only                          bool
 	AWSPreferCNAME                                bool
 	AWSZoneCacheDuration                          time.Duration
 	AWSSDServiceCleanup                           bool
 	AWSSDCreateTag                                map[string]string
 	AWSZoneMatchParent                            bool
+	AWSManageResolverRules                        bool
+	AWSResolverRuleName                           string
+	AWSValidateDNSSEC                             bool
+	AWSManageTrafficPolicies                      bool
+	AWSARCRoutingControlARN                       string
+	AWSARCClusterEndpoints                        []string
+	AWSRecoveryReadiness                          bool
+	AWSRecoveryReadinessCellARNs                  []string
+	AWSCreateZones                                []string
+	AWSZoneAutoDelegate                           bool
+	AWSParentZoneID                               string
+	AWSDNSSECResolver                             string
 	AWSDynamoDBRegion                             string
 	AWSDynamoDBTable                              string
 	AzureConfigFile                               string
@@ -107,14 +157,21 @@ type Config struct {
 	AzureActiveDirectoryAuthorityHost             string
 	AzureZonesCacheDuration                       time.Duration
 	AzureMaxRetriesCount                          int
+	AzureTrafficManagerProfile                    string
 	CloudflareProxied                             bool
 	CloudflareCustomHostnames                     bool
 	CloudflareDNSRecordsPerPage                   int
 	CloudflareDNSRecordsComment                   string
 	CloudflareCustomHostnamesMinTLSVersion        string
 	CloudflareCustomHostnamesCertificateAuthority string
+	CloudflareLoadBalancers                       bool
+	CloudflareWorkerRoutes                        bool
+	CloudflareVerifyPropagation                   bool
+	CloudflareVerifyTimeout                       time.Duration
 	CloudflareRegionKey                           string
 	CloudflareRecordComment                       string
+	CloudflareZoneTokens                          map[string]string `secure:"yes"`
+	CloudflareZoneFilterRegex                     *regexp.Regexp
 	CoreDNSPrefix                                 string
 	AkamaiServiceConsumerDomain                   string
 	AkamaiClientToken                             string
@@ -146,13 +203,23 @@ type Config struct {
 	TXTEncryptEnabled                             bool
 	TXTEncryptAESKey                              string `secure:"yes"`
 	TXTNewFormatOnly                              bool
+	TransferOwnershipFrom                         string
 	Interval                                      time.Duration
 	MinEventSyncInterval                          time.Duration
+	IntervalJitterFactor                          float64
+	FingerprintMaxAge                             time.Duration
+	ReconcileTimeout                              time.Duration
+	ForceFullSyncAfterErrors                      int
 	Once                                          bool
 	DryRun                                        bool
 	UpdateEvents                                  bool
+	EmitEvents                                    bool
 	LogFormat                                     string
 	MetricsAddress                                string
+	HealthListenAddress                           string
+	MetricsPath                                   string
+	EnablePprof                                   bool
+	PprofListenAddress                            string
 	LogLevel                                      string
 	TXTCacheInterval                              time.Duration
 	TXTWildcardReplacement                        string
@@ -163,7 +230,9 @@ type Config struct {
 	ExoscaleAPIZone                               string
 	CRDSourceAPIVersion                           string
 	CRDSourceKind                                 string
+	CRDSourceFinalizer                            bool
 	ServiceTypeFilter                             []string
+	LoadBalancerClassFilter                       []string
 	CFAPIEndpoint                                 string
 	CFUsername                                    string
 	CFPassword                                    string
@@ -191,6 +260,10 @@ type Config struct {
 	NS1MinTTLSeconds                              int
 	TransIPAccountName                            string
 	TransIPPrivateKeyFile                         string
+	InwxUsername                                  string
+	InwxPassword                                  string `secure:"yes"`
+	InwxOTPSecret                                 string `secure:"yes"`
+	InwxSandbox                                   bool
 	DigitalOceanAPIPageSize                       int
 	ManagedDNSRecordTypes                         []string
 	ExcludeDNSRecordTypes                         []string
@@ -213,49 +286,85 @@ type Config struct {
 	TraefikDisableNew                             bool
 	NAT64Networks                                 []string
 	ExcludeUnschedulable                          bool
+	EndpointTransformURL                          string
+	EndpointTransformTimeout                      time.Duration
+	ProviderPluginURL                             string
+	ProviderPluginTimeout                         time.Duration
 }
 
 var defaultConfig = &Config{
-	AkamaiAccessToken:           "",
-	AkamaiClientSecret:          "",
-	AkamaiClientToken:           "",
-	AkamaiEdgercPath:            "",
-	AkamaiEdgercSection:         "",
-	AkamaiServiceConsumerDomain: "",
-	AlibabaCloudConfigFile:      "/etc/kubernetes/alibaba-cloud.json",
-	AnnotationFilter:            "",
-	APIServerURL:                "",
-	AWSAPIRetries:               3,
-	AWSAssumeRole:               "",
-	AWSAssumeRoleExternalID:     "",
-	AWSBatchChangeInterval:      time.Second,
-	AWSBatchChangeSize:          1000,
-	AWSBatchChangeSizeBytes:     32000,
-	AWSBatchChangeSizeValues:    1000,
-	AWSDynamoDBRegion:           "",
-	AWSDynamoDBTable:            "external-dns",
-	AWSEvaluateTargetHealth:     true,
-	AWSPreferCNAME:              false,
-	AWSSDCreateTag:              map[string]string{},
-	AWSSDServiceCleanup:         false,
-	AWSZoneCacheDuration:        0 * time.Second,
-	AWSZoneMatchParent:          false,
-	AWSZoneTagFilter:            []string{},
-	AWSZoneType:                 "",
-	AzureConfigFile:             "/etc/kubernetes/azure.json",
-	AzureResourceGroup:          "",
-	AzureSubscriptionID:         "",
-	AzureZonesCacheDuration:     0 * time.Second,
-	AzureMaxRetriesCount:        3,
-	CFAPIEndpoint:               "",
-	CFPassword:                  "",
-	CFUsername:                  "",
+	ConfigFile:                   "",
+	ConfigFromConfigMap:          "",
+	AkamaiAccessToken:            "",
+	AkamaiClientSecret:           "",
+	AkamaiClientToken:            "",
+	AkamaiEdgercPath:             "",
+	AkamaiEdgercSection:          "",
+	AkamaiServiceConsumerDomain:  "",
+	AlibabaCloudConfigFile:       "/etc/kubernetes/alibaba-cloud.json",
+	AnnotationFilter:             "",
+	APIServerURL:                 "",
+	AWSAPIRetries:                3,
+	AWSEC2MetadataV2Only:         false,
+	AWSARCClusterEndpoints:       []string{},
+	AWSARCRoutingControlARN:      "",
+	AWSRecoveryReadiness:         false,
+	AWSRecoveryReadinessCellARNs: []string{},
+	AWSCreateZones:               []string{},
+	AWSZoneAutoDelegate:          false,
+	AWSParentZoneID:              "",
+	AWSAssumeRole:                "",
+	AWSAssumeRoleExternalID:      "",
+	AWSDomainRoles:               map[string]string{},
+	AWSAssumeRoleSessionTags:     map[string]string{},
+	AWSRequestTag:                "",
+	AWSEndpointURL:               "",
+	AWSSSOStartURL:               "",
+	AWSSSOAccountID:              "",
+	AWSSSORoleName:               "",
+	AWSSSORegion:                 "",
+	AWSBatchChangeInterval:       time.Second,
+	AWSBatchChangeSize:           1000,
+	AWSBatchChangeSizeBytes:      32000,
+	AWSBatchChangeSizeValues:     1000,
+	AWSDNSSECResolver:            "",
+	AWSDynamoDBRegion:            "",
+	AWSDynamoDBTable:             "external-dns",
+	AWSEvaluateTargetHealth:      true,
+	AWSMaxChangesPerBatch:        0,
+	AWSTransactionalZoneUpdates:  false,
+	AWSPreferCNAME:               false,
+	AWSSDCreateTag:               map[string]string{},
+	AWSSDServiceCleanup:          false,
+	AWSManageResolverRules:       false,
+	AWSManageTrafficPolicies:     false,
+	AWSResolverRuleName:          "",
+	AWSValidateDNSSEC:            false,
+	AWSZoneCacheDuration:         0 * time.Second,
+	AWSZoneMatchParent:           false,
+	AWSZoneTagFilter:             []string{},
+	AWSZoneType:                  "",
+	AzureConfigFile:              "/etc/kubernetes/azure.json",
+	AzureResourceGroup:           "",
+	AzureSubscriptionID:          "",
+	AzureZonesCacheDuration:      0 * time.Second,
+	AzureMaxRetriesCount:         3,
+	AzureTrafficManagerProfile:   "",
+	CFAPIEndpoint:                "",
+	CFPassword:                   "",
+	CFUsername:                   "",
 	CloudflareCustomHostnamesCertificateAuthority: "none",
 	CloudflareCustomHostnames:                     false,
 	CloudflareCustomHostnamesMinTLSVersion:        "1.0",
 	CloudflareDNSRecordsPerPage:                   100,
+	CloudflareLoadBalancers:                       false,
+	CloudflareWorkerRoutes:                        false,
+	CloudflareVerifyPropagation:                   false,
+	CloudflareVerifyTimeout:                       time.Minute,
 	CloudflareProxied:                             false,
 	CloudflareRegionKey:                           "earth",
+	CloudflareZoneTokens:                          map[string]string{},
+	CloudflareZoneFilterRegex:                     regexp.MustCompile(""),
 
 	CombineFQDNAndAnnotation:     false,
 	Compatibility:                "",
@@ -263,10 +372,13 @@ var defaultConfig = &Config{
 	CoreDNSPrefix:                "/skydns/",
 	CRDSourceAPIVersion:          "externaldns.k8s.io/v1alpha1",
 	CRDSourceKind:                "DNSEndpoint",
+	CRDSourceFinalizer:           false,
 	DefaultTargets:               []string{},
 	DigitalOceanAPIPageSize:      50,
 	DomainFilter:                 []string{},
 	DryRun:                       false,
+	EndpointTransformTimeout:     5 * time.Second,
+	EndpointTransformURL:         "",
 	ExcludeDNSRecordTypes:        []string{},
 	ExcludeDomains:               []string{},
 	ExcludeTargetNets:            []string{},
@@ -276,12 +388,18 @@ var defaultConfig = &Config{
 	ExoscaleAPISecret:            "",
 	ExoscaleAPIZone:              "ch-gva-2",
 	ExposeInternalIPV6:           true,
+	FederationKubeConfig:         "",
+	FederationSource:             "service",
+	FingerprintMaxAge:            0,
+	ReconcileTimeout:             0,
+	ForceFullSyncAfterErrors:     3,
 	FQDNTemplate:                 "",
 	GatewayLabelFilter:           "",
 	GatewayName:                  "",
 	GatewayNamespace:             "",
 	GlooNamespaces:               []string{"gloo-system"},
 	GoDaddyAPIKey:                "",
+	HostnameAliases:              "",
 	GoDaddyOTE:                   false,
 	GoDaddySecretKey:             "",
 	GoDaddyTTL:                   600,
@@ -289,19 +407,42 @@ var defaultConfig = &Config{
 	GoogleBatchChangeSize:        1000,
 	GoogleProject:                "",
 	GoogleZoneVisibility:         "",
+	GoogleResponsePolicy:         "",
+	GoogleForwardingZones:        []string{},
+	GoogleForwardingTargets:      []string{},
+	GoogleForwardingNetworks:     []string{},
 	IgnoreHostnameAnnotation:     false,
 	IgnoreIngressRulesSpec:       false,
+	InheritServiceAnnotations:    false,
+	UpdateIngressStatus:          false,
 	IgnoreIngressTLSSpec:         false,
+	IncludeClusterIP:             false,
 	IngressClassNames:            nil,
 	InMemoryZones:                []string{},
+	InwxOTPSecret:                "",
+	InwxPassword:                 "",
+	InwxSandbox:                  false,
+	InwxUsername:                 "",
 	Interval:                     time.Minute,
+	KubeCAFile:                   "",
 	KubeConfig:                   "",
+	KubeContext:                  "",
+	KubeExtraEndpoints:           []string{},
+	KubeImpersonateGroups:        []string{},
+	KubeImpersonateUser:          "",
+	KubeSkipTLSVerify:            false,
 	LabelFilter:                  labels.Everything().String(),
+	LoadBalancerClassFilter:      nil,
 	LogFormat:                    "text",
 	LogLevel:                     logrus.InfoLevel.String(),
 	ManagedDNSRecordTypes:        []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
 	MetricsAddress:               ":7979",
+	HealthListenAddress:          ":8080",
+	MetricsPath:                  "/metrics",
+	EnablePprof:                  false,
+	PprofListenAddress:           ":6060",
 	MinEventSyncInterval:         5 * time.Second,
+	IntervalJitterFactor:         0,
 	Namespace:                    "",
 	NAT64Networks:                []string{},
 	NS1Endpoint:                  "",
@@ -326,13 +467,20 @@ var defaultConfig = &Config{
 	PodSourceDomain:              "",
 	Policy:                       "sync",
 	Provider:                     "",
+	Providers:                    []string{},
 	ProviderCacheTime:            0,
+	ProviderConfigFile:           "",
+	ProviderReadOnly:             false,
+	ProviderPluginTimeout:        10 * time.Second,
+	ProviderPluginURL:            "",
 	PublishHostIP:                false,
 	PublishInternal:              false,
 	RegexDomainExclusion:         regexp.MustCompile(""),
 	RegexDomainFilter:            regexp.MustCompile(""),
 	Registry:                     "txt",
 	RequestTimeout:               time.Second * 30,
+	ResyncPeriod:                 10 * time.Minute,
+	WatchBackoffMaxDelay:         30 * time.Second,
 	RFC2136BatchChangeSize:       50,
 	RFC2136GSSTSIG:               false,
 	RFC2136Host:                  []string{""},
@@ -359,6 +507,7 @@ var defaultConfig = &Config{
 	TLSClientCertKey:             "",
 	TraefikDisableLegacy:         false,
 	TraefikDisableNew:            false,
+	TransferOwnershipFrom:        "",
 	TransIPAccountName:           "",
 	TransIPPrivateKeyFile:        "",
 	TXTCacheInterval:             0,
@@ -370,17 +519,23 @@ var defaultConfig = &Config{
 	TXTSuffix:                    "",
 	TXTWildcardReplacement:       "",
 	UpdateEvents:                 false,
+	EmitEvents:                   false,
 	WebhookProviderReadTimeout:   5 * time.Second,
 	WebhookProviderURL:           "http://localhost:8888",
 	WebhookProviderWriteTimeout:  10 * time.Second,
 	WebhookServer:                false,
+	WebhookSourceURL:             "",
+	SourceFile:                   "",
 	ZoneIDFilter:                 []string{},
 }
 
 // NewConfig returns new Config object
 func NewConfig() *Config {
 	return &Config{
-		AWSSDCreateTag: map[string]string{},
+		AWSSDCreateTag:           map[string]string{},
+		AWSDomainRoles:           map[string]string{},
+		AWSAssumeRoleSessionTags: map[string]string{},
+		CloudflareZoneTokens:     map[string]string{},
 	}
 }
 
@@ -392,12 +547,19 @@ func (cfg *Config) String() string {
 	for i := 0; i < t.NumField(); i++ {
 		f := t.Field(i)
 		if val, ok := f.Tag.Lookup("secure"); ok && val == "yes" {
-			if f.Type.Kind() != reflect.String {
-				continue
-			}
 			v := reflect.ValueOf(&temp).Elem().Field(i)
-			if v.String() != "" {
-				v.SetString(passwordMask)
+			switch {
+			case f.Type.Kind() == reflect.String:
+				if v.String() != "" {
+					v.SetString(passwordMask)
+				}
+			case f.Type == reflect.TypeOf(map[string]string{}):
+				// the keys are kept, the map is copied not to mask the values of the configuration
+				masked := make(map[string]string, v.Len())
+				for key := range v.Interface().(map[string]string) {
+					masked[key] = passwordMask
+				}
+				v.Set(reflect.ValueOf(masked))
 			}
 		}
 	}
@@ -405,6 +567,18 @@ func (cfg *Config) String() string {
 	return fmt.Sprintf("%+v", temp)
 }
 
+// ProviderNames returns the names of the providers the records are written to, the first one is the
+// provider the records are read from.
+func (cfg *Config) ProviderNames() []string {
+	if len(cfg.Providers) > 0 {
+		return cfg.Providers
+	}
+	if cfg.Provider != "" {
+		return []string{cfg.Provider}
+	}
+	return nil
+}
+
 // allLogLevelsAsStrings returns all logrus levels as a list of strings
 func allLogLevelsAsStrings() []string {
 	var levels []string
@@ -418,23 +592,68 @@ func allLogLevelsAsStrings() []string {
 func (cfg *Config) ParseFlags(args []string) error {
 	app := App(cfg)
 
-	_, err := app.Parse(args)
+	fileArgs, err := configFileArgs(app, args)
 	if err != nil {
 		return err
 	}
 
+	_, err = app.Parse(append(fileArgs, args...))
+	if err != nil {
+		return err
+	}
+
+	if len(cfg.Providers) > 0 {
+		cfg.Provider = cfg.Providers[0]
+	}
+	cfg.ManagedDNSRecordTypes = splitRecordTypes(cfg.ManagedDNSRecordTypes)
+	cfg.ExcludeDNSRecordTypes = splitRecordTypes(cfg.ExcludeDNSRecordTypes)
+
 	return nil
 }
 
+// SplitCommaSeparated returns the trimmed elements of the comma-separated lists given to a flag. The empty elements
+// are dropped, unless keepEmpty is set for the flags where an empty element has a meaning.
+func SplitCommaSeparated(values []string, keepEmpty bool) []string {
+	var elements []string
+	for _, value := range values {
+		for _, element := range strings.Split(value, ",") {
+			if element = strings.TrimSpace(element); element != "" || keepEmpty {
+				elements = append(elements, element)
+			}
+		}
+	}
+	return elements
+}
+
+// splitRecordTypes splits the comma-separated record types given to a flag and upper cases them.
+func splitRecordTypes(values []string) []string {
+	recordTypes := SplitCommaSeparated(values, false)
+	for i, recordType := range recordTypes {
+		recordTypes[i] = strings.ToUpper(recordType)
+	}
+	return recordTypes
+}
+
 func App(cfg *Config) *kingpin.Application {
 	app := kingpin.New("external-dns", "ExternalDNS synchronizes exposed Kubernetes Services and Ingresses with DNS providers.\n\nNote that all flags may be replaced with env vars - `--flag` -> `EXTERNAL_DNS_FLAG=1` or `--flag value` -> `EXTERNAL_DNS_FLAG=value`")
 	app.Version(Version)
 	app.DefaultEnvars()
 
+	app.Flag("config", "A YAML or JSON file setting flags, whose keys are the flag names, e.g. `provider: aws`; the repeatable flags are set with lists and the key=value flags with maps. The flags given on the command line override the file, which overrides the environment variables (optional)").Default(defaultConfig.ConfigFile).StringVar(&cfg.ConfigFile)
+	app.Flag("config-from-configmap", "A ConfigMap, given as namespace/name, whose data sets settings keyed by their flag names; they're applied at startup and again without restarting when it changes. Only the filters (annotation-filter, label-filter, domain-filter, exclude-domains, regex-domain-filter, regex-domain-exclusion, zone-name-filter, zone-id-filter) and the record TTLs of the providers (godaddy-api-ttl, ns1-min-ttl, rfc2136-min-ttl) are applied, the other settings require a restart and are ignored (optional)").Default(defaultConfig.ConfigFromConfigMap).StringVar(&cfg.ConfigFromConfigMap)
+
 	// Flags related to Kubernetes
 	app.Flag("server", "The Kubernetes API server to connect to (default: auto-detect)").Default(defaultConfig.APIServerURL).StringVar(&cfg.APIServerURL)
 	app.Flag("kubeconfig", "Retrieve target cluster configuration from a Kubernetes configuration file (default: auto-detect)").Default(defaultConfig.KubeConfig).StringVar(&cfg.KubeConfig)
+	app.Flag("kube-context", "The context of the Kubernetes configuration file to use instead of its current context (default: current context)").Default(defaultConfig.KubeContext).StringVar(&cfg.KubeContext)
+	app.Flag("kube-impersonate-user", "Make the requests to the Kubernetes API server as this user or service account, e.g. system:serviceaccount:default:external-dns, to test its RBAC permissions (optional)").Default(defaultConfig.KubeImpersonateUser).StringVar(&cfg.KubeImpersonateUser)
+	app.Flag("kube-impersonate-group", "Make the requests to the Kubernetes API server as a member of this group, requires --kube-impersonate-user; specify multiple times for multiple groups (optional)").StringsVar(&cfg.KubeImpersonateGroups)
+	app.Flag("kube-extra-endpoints", "An additional Kubernetes API server watched by the sources, given as url or url=kubeconfig, its endpoints are merged with the ones of the default API server; specify multiple times for multiple API servers (optional)").StringsVar(&cfg.KubeExtraEndpoints)
+	app.Flag("kube-skip-tls-verify", "Skip the verification of the Kubernetes API server certificate, insecure (default: disabled)").BoolVar(&cfg.KubeSkipTLSVerify)
+	app.Flag("kube-ca-file", "A PEM bundle of CAs trusted for the Kubernetes API server certificate, in addition to the CA of the kubeconfig or service account (optional)").Default(defaultConfig.KubeCAFile).StringVar(&cfg.KubeCAFile)
 	app.Flag("request-timeout", "Request timeout when calling Kubernetes APIs. 0s means no timeout").Default(defaultConfig.RequestTimeout.String()).DurationVar(&cfg.RequestTimeout)
+	app.Flag("resync-period", "The interval at which the informers of the sources re-deliver all the watched objects to the event handlers, to correct any drift from missed events. 0s disables the resync (default: 10m)").Default(defaultConfig.ResyncPeriod.String()).DurationVar(&cfg.ResyncPeriod)
+	app.Flag("watch-backoff-max-delay", "The maximum delay the informers of the sources wait before reconnecting a failed watch, the delay doubles with each consecutive failure starting from 1s. 0s disables the backoff (default: 30s)").Default(defaultConfig.WatchBackoffMaxDelay.String()).DurationVar(&cfg.WatchBackoffMaxDelay)
 	app.Flag("resolve-service-load-balancer-hostname", "Resolve the hostname of LoadBalancer-type Service object to IP addresses in order to create DNS A/AAAA records instead of CNAMEs").BoolVar(&cfg.ResolveServiceLoadBalancerHostname)
 	app.Flag("listen-endpoint-events", "Trigger a reconcile on changes to Endpoints, for Service source (default: false)").BoolVar(&cfg.ListenEndpointEvents)
 
@@ -457,22 +676,30 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("connector-source-server", "The server to connect for connector source, valid only when using connector source").Default(defaultConfig.ConnectorSourceServer).StringVar(&cfg.ConnectorSourceServer)
 	app.Flag("crd-source-apiversion", "API version of the CRD for crd source, e.g. `externaldns.k8s.io/v1alpha1`, valid only when using crd source").Default(defaultConfig.CRDSourceAPIVersion).StringVar(&cfg.CRDSourceAPIVersion)
 	app.Flag("crd-source-kind", "Kind of the CRD for the crd source in API group and version specified by crd-source-apiversion").Default(defaultConfig.CRDSourceKind).StringVar(&cfg.CRDSourceKind)
+	app.Flag("crd-source-finalizer", "Add a cleanup finalizer to the DNSEndpoints of the crd source, so that they are only deleted once their records are removed (default: disabled)").BoolVar(&cfg.CRDSourceFinalizer)
 	app.Flag("default-targets", "Set globally default host/IP that will apply as a target instead of source addresses. Specify multiple times for multiple targets (optional)").StringsVar(&cfg.DefaultTargets)
-	app.Flag("exclude-record-types", "Record types to exclude from management; specify multiple times to exclude many; (optional)").Default().StringsVar(&cfg.ExcludeDNSRecordTypes)
+	app.Flag("endpoint-transform-webhook", "When set, the endpoints collected from the sources are POSTed as JSON to this URL and replaced by the endpoints of the response (optional)").Default(defaultConfig.EndpointTransformURL).StringVar(&cfg.EndpointTransformURL)
+	app.Flag("endpoint-transform-webhook-timeout", "The timeout of the endpoint transform webhook, the endpoints are used unchanged when it is exceeded (default: 5s)").Default(defaultConfig.EndpointTransformTimeout.String()).DurationVar(&cfg.EndpointTransformTimeout)
+	app.Flag("exclude-record-types", "Record types to exclude from management, e.g. NS,SOA; the records of these types are ignored both in the sources and the provider; accepts a comma separated list or specify multiple times to exclude many; (optional)").Default().StringsVar(&cfg.ExcludeDNSRecordTypes)
 	app.Flag("exclude-target-net", "Exclude target nets (optional)").StringsVar(&cfg.ExcludeTargetNets)
 	app.Flag("exclude-unschedulable", "Exclude nodes that are considered unschedulable (default: true)").Default(strconv.FormatBool(defaultConfig.ExcludeUnschedulable)).BoolVar(&cfg.ExcludeUnschedulable)
 	app.Flag("expose-internal-ipv6", "When using the node source, expose internal IPv6 addresses (optional). Default is true.").BoolVar(&cfg.ExposeInternalIPV6)
+	app.Flag("federation-kubeconfig", "Comma-separated list of kubeconfig files of the remote clusters, valid only when using federation source").Default(defaultConfig.FederationKubeConfig).StringVar(&cfg.FederationKubeConfig)
+	app.Flag("federation-source", "The source type queried on each remote cluster, valid only when using federation source (default: service)").Default(defaultConfig.FederationSource).StringVar(&cfg.FederationSource)
 	app.Flag("fqdn-template", "A templated string that's used to generate DNS names from sources that don't define a hostname themselves, or to add a hostname suffix when paired with the fake source (optional). Accepts comma separated list for multiple global FQDN.").Default(defaultConfig.FQDNTemplate).StringVar(&cfg.FQDNTemplate)
 	app.Flag("gateway-label-filter", "Filter Gateways of Route endpoints via label selector (default: all gateways)").StringVar(&cfg.GatewayLabelFilter)
 	app.Flag("gateway-name", "Limit Gateways of Route endpoints to a specific name (default: all names)").StringVar(&cfg.GatewayName)
 	app.Flag("gateway-namespace", "Limit Gateways of Route endpoints to a specific namespace (default: all namespaces)").StringVar(&cfg.GatewayNamespace)
+	app.Flag("hostname-aliases", "Expand the short hostnames of the endpoints to FQDNs, given as a comma separated list of alias=fqdn, e.g. web=web.example.com,api=api.example.com (optional)").Default(defaultConfig.HostnameAliases).StringVar(&cfg.HostnameAliases)
 	app.Flag("ignore-hostname-annotation", "Ignore hostname annotation when generating DNS names, valid only when --fqdn-template is set (default: false)").BoolVar(&cfg.IgnoreHostnameAnnotation)
 	app.Flag("ignore-ingress-rules-spec", "Ignore the spec.rules section in Ingress resources (default: false)").BoolVar(&cfg.IgnoreIngressRulesSpec)
+	app.Flag("inherit-service-annotations", "Inherit the TTL and weight annotations of the backend services of the Ingress resources, the annotations of the Ingress take precedence (default: false)").BoolVar(&cfg.InheritServiceAnnotations)
+	app.Flag("update-ingress-status", "Update the load balancer status of the Ingress resources with the targets of their target annotation, for the ingress controllers which don't report it (default: false)").BoolVar(&cfg.UpdateIngressStatus)
 	app.Flag("ignore-ingress-tls-spec", "Ignore the spec.tls section in Ingress resources (default: false)").BoolVar(&cfg.IgnoreIngressTLSSpec)
 	app.Flag("ignore-non-host-network-pods", "Ignore pods not running on host network when using pod source (default: false)").BoolVar(&cfg.IgnoreNonHostNetworkPods)
 	app.Flag("ingress-class", "Require an Ingress to have this class name (defaults to any class; specify multiple times to allow more than one class)").StringsVar(&cfg.IngressClassNames)
-	app.Flag("label-filter", "Filter resources queried for endpoints by label selector; currently supported by source types crd, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, ingress, node, openshift-route, service and ambassador-host").Default(defaultConfig.LabelFilter).StringVar(&cfg.LabelFilter)
-	managedRecordTypesHelp := fmt.Sprintf("Record types to manage; specify multiple times to include many; (default: %s) (supported records: A, AAAA, CNAME, NS, SRV, TXT)", strings.Join(defaultConfig.ManagedDNSRecordTypes, ","))
+	app.Flag("label-filter", "Filter resources queried for endpoints by label selector; currently supported by source types argocd-application, crd, dnsrecord, flux-helmrelease, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, ingress, namespace, node, openshift-route, service and ambassador-host").Default(defaultConfig.LabelFilter).StringVar(&cfg.LabelFilter)
+	managedRecordTypesHelp := fmt.Sprintf("Record types to manage, the records of other types are neither created nor updated; accepts a comma separated list or specify multiple times to include many; (default: %s) (supported records: A, AAAA, CNAME, NS, SRV, TXT)", strings.Join(defaultConfig.ManagedDNSRecordTypes, ","))
 	app.Flag("managed-record-types", managedRecordTypesHelp).Default(defaultConfig.ManagedDNSRecordTypes...).StringsVar(&cfg.ManagedDNSRecordTypes)
 	app.Flag("namespace", "Limit resources queried for endpoints to a specific namespace (default: all namespaces)").Default(defaultConfig.Namespace).StringVar(&cfg.Namespace)
 	app.Flag("nat64-networks", "Adding an A record for each AAAA record in NAT64-enabled networks; specify multiple times for multiple possible nets (optional)").StringsVar(&cfg.NAT64Networks)
@@ -480,26 +707,37 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("pod-source-domain", "Domain to use for pods records (optional)").Default(defaultConfig.PodSourceDomain).StringVar(&cfg.PodSourceDomain)
 	app.Flag("publish-host-ip", "Allow external-dns to publish host-ip for headless services (optional)").BoolVar(&cfg.PublishHostIP)
 	app.Flag("publish-internal-services", "Allow external-dns to publish DNS records for ClusterIP services (optional)").BoolVar(&cfg.PublishInternal)
+	app.Flag("include-cluster-ip", "Also process ClusterIP services, publishing A and AAAA records of all their cluster IPs, unlike --publish-internal-services which publishes the primary one only (default: false)").BoolVar(&cfg.IncludeClusterIP)
 	app.Flag("service-type-filter", "The service types to filter by. Specify multiple times for multiple filters to be applied. (optional, default: all, expected: ClusterIP, NodePort, LoadBalancer or ExternalName)").Default(defaultConfig.ServiceTypeFilter...).StringsVar(&cfg.ServiceTypeFilter)
-	app.Flag("source", "The resource types that are queried for endpoints; specify multiple times for multiple sources (required, options: service, ingress, node, pod, fake, connector, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, istio-gateway, istio-virtualservice, cloudfoundry, contour-httpproxy, gloo-proxy, crd, empty, skipper-routegroup, openshift-route, ambassador-host, kong-tcpingress, f5-virtualserver, f5-transportserver, traefik-proxy)").Required().PlaceHolder("source").EnumsVar(&cfg.Sources, "service", "ingress", "node", "pod", "gateway-httproute", "gateway-grpcroute", "gateway-tlsroute", "gateway-tcproute", "gateway-udproute", "istio-gateway", "istio-virtualservice", "cloudfoundry", "contour-httpproxy", "gloo-proxy", "fake", "connector", "crd", "empty", "skipper-routegroup", "openshift-route", "ambassador-host", "kong-tcpingress", "f5-virtualserver", "f5-transportserver", "traefik-proxy")
+	app.Flag("load-balancer-class-filter", "Only process LoadBalancer services with one of these comma-separated spec.loadBalancerClass values, an empty value matches the services without class; specify multiple times for multiple filters (optional, default: all)").StringsVar(&cfg.LoadBalancerClassFilter)
+	app.Flag("source", "The resource types that are queried for endpoints; specify multiple times for multiple sources (required, options: service, ingress, node, pod, fake, connector, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, istio-gateway, istio-virtualservice, cloudfoundry, contour-httpproxy, gloo-proxy, crd, dnsrecord, empty, skipper-routegroup, openshift-route, ambassador-host, kong-tcpingress, f5-virtualserver, f5-transportserver, traefik-proxy, webhook, file, stdin, federation, argocd-application, flux-helmrelease, namespace)").Required().PlaceHolder("source").EnumsVar(&cfg.Sources, "service", "ingress", "node", "pod", "gateway-httproute", "gateway-grpcroute", "gateway-tlsroute", "gateway-tcproute", "gateway-udproute", "istio-gateway", "istio-virtualservice", "cloudfoundry", "contour-httpproxy", "gloo-proxy", "fake", "connector", "crd", "dnsrecord", "empty", "skipper-routegroup", "openshift-route", "ambassador-host", "kong-tcpingress", "f5-virtualserver", "f5-transportserver", "traefik-proxy", "webhook", "file", "stdin", "federation", "argocd-application", "flux-helmrelease", "namespace")
 	app.Flag("target-net-filter", "Limit possible targets by a net filter; specify multiple times for multiple possible nets (optional)").StringsVar(&cfg.TargetNetFilter)
 	app.Flag("traefik-disable-legacy", "Disable listeners on Resources under the traefik.containo.us API Group").Default(strconv.FormatBool(defaultConfig.TraefikDisableLegacy)).BoolVar(&cfg.TraefikDisableLegacy)
 	app.Flag("traefik-disable-new", "Disable listeners on Resources under the traefik.io API Group").Default(strconv.FormatBool(defaultConfig.TraefikDisableNew)).BoolVar(&cfg.TraefikDisableNew)
+	app.Flag("webhook-source-url", "The URL queried with a GET request for a JSON array of endpoints, valid only when using webhook source").Default(defaultConfig.WebhookSourceURL).StringVar(&cfg.WebhookSourceURL)
+	app.Flag("source-file", "The YAML or JSON file containing a list of endpoints, read again when it changes; valid only when using file source").Default(defaultConfig.SourceFile).StringVar(&cfg.SourceFile)
 
 	// Flags related to providers
-	providers := []string{"akamai", "alibabacloud", "aws", "aws-sd", "azure", "azure-dns", "azure-private-dns", "civo", "cloudflare", "coredns", "digitalocean", "dnsimple", "exoscale", "gandi", "godaddy", "google", "inmemory", "linode", "ns1", "oci", "ovh", "pdns", "pihole", "plural", "rfc2136", "scaleway", "skydns", "transip", "webhook"}
-	app.Flag("provider", "The DNS provider where the DNS records will be created (required, options: "+strings.Join(providers, ", ")+")").Required().PlaceHolder("provider").EnumVar(&cfg.Provider, providers...)
+	providers := []string{"akamai", "alibabacloud", "aws", "aws-sd", "azure", "azure-dns", "azure-private-dns", "bluecat", "bunny", "civo", "cloudflare", "cloudns", "constellix", "coredns", "desec", "digitalocean", "dnsimple", "dyn", "efficientip", "exoscale", "gandi", "godaddy", "google", "inmemory", "inwx", "joker", "linode", "namecheap", "netlify", "ns1", "oci", "ovh", "pdns", "pihole", "plural", "porkbun", "rfc2136", "scaleway", "skydns", "plugin", "transip", "vercel", "vultr", "webhook"}
+	app.Flag("provider", "The DNS provider where the DNS records will be created; specify multiple times to write the records to multiple providers, which are read from the first one (required, options: "+strings.Join(providers, ", ")+")").Required().PlaceHolder("provider").EnumsVar(&cfg.Providers, providers...)
 	app.Flag("provider-cache-time", "The time to cache the DNS provider record list requests.").Default(defaultConfig.ProviderCacheTime.String()).DurationVar(&cfg.ProviderCacheTime)
+	app.Flag("provider-config-file", "A configuration or credentials file of the provider, e.g. the --azure-config-file, whose changes rebuild the provider and the registry without restarting (optional)").Default(defaultConfig.ProviderConfigFile).StringVar(&cfg.ProviderConfigFile)
+	app.Flag("provider-read-only", "When enabled, the DNS provider is only read and the changes are logged instead of being applied, unlike --dry-run this is enforced by the provider itself; AWS hosted zones are not created and the DynamoDB registry cannot be used (default: disabled)").BoolVar(&cfg.ProviderReadOnly)
 	app.Flag("domain-filter", "Limit possible target zones by a domain suffix; specify multiple times for multiple domains (optional)").Default("").StringsVar(&cfg.DomainFilter)
 	app.Flag("exclude-domains", "Exclude subdomains (optional)").Default("").StringsVar(&cfg.ExcludeDomains)
 	app.Flag("regex-domain-filter", "Limit possible domains and target zones by a Regex filter; Overrides domain-filter (optional)").Default(defaultConfig.RegexDomainFilter.String()).RegexpVar(&cfg.RegexDomainFilter)
 	app.Flag("regex-domain-exclusion", "Regex filter that excludes domains and target zones matched by regex-domain-filter (optional); Require 'regex-domain-filter' ").Default(defaultConfig.RegexDomainExclusion.String()).RegexpVar(&cfg.RegexDomainExclusion)
 	app.Flag("zone-name-filter", "Filter target zones by zone domain (For now, only AzureDNS provider is using this flag); specify multiple times for multiple zones (optional)").Default("").StringsVar(&cfg.ZoneNameFilter)
 	app.Flag("zone-id-filter", "Filter target zones by hosted zone id; specify multiple times for multiple zones (optional)").Default("").StringsVar(&cfg.ZoneIDFilter)
+	app.Flag("protected-zones", "Never change the records of this zone, given by its domain name or its zone ID, even when the sources emit endpoints for them; specify multiple times for multiple zones (optional)").StringsVar(&cfg.ProtectedZones)
 	app.Flag("google-project", "When using the Google provider, current project is auto-detected, when running on GCP. Specify other project with this. Must be specified when running outside GCP.").Default(defaultConfig.GoogleProject).StringVar(&cfg.GoogleProject)
 	app.Flag("google-batch-change-size", "When using the Google provider, set the maximum number of changes that will be applied in each batch.").Default(strconv.Itoa(defaultConfig.GoogleBatchChangeSize)).IntVar(&cfg.GoogleBatchChangeSize)
 	app.Flag("google-batch-change-interval", "When using the Google provider, set the interval between batch changes.").Default(defaultConfig.GoogleBatchChangeInterval.String()).DurationVar(&cfg.GoogleBatchChangeInterval)
 	app.Flag("google-zone-visibility", "When using the Google provider, filter for zones with this visibility (optional, options: public, private)").Default(defaultConfig.GoogleZoneVisibility).EnumVar(&cfg.GoogleZoneVisibility, "", "public", "private")
+	app.Flag("google-response-policy", "When using the Google provider, manage the records as the rules of this response policy instead of in the managed zones (optional)").Default(defaultConfig.GoogleResponsePolicy).StringVar(&cfg.GoogleResponsePolicy)
+	app.Flag("google-forwarding-zone", "When using the Google provider, keep a private forwarding zone for this domain in sync with the --google-forwarding-target name servers; specify multiple times for multiple domains (optional)").StringsVar(&cfg.GoogleForwardingZones)
+	app.Flag("google-forwarding-target", "When using the Google provider with --google-forwarding-zone, name server the queries are forwarded to, in the form ip or ip,path where path is default or private; specify multiple times for multiple name servers").StringsVar(&cfg.GoogleForwardingTargets)
+	app.Flag("google-forwarding-network", "When using the Google provider with --google-forwarding-zone, URL of a VPC network the forwarding zones are visible to; specify multiple times for multiple networks (optional)").StringsVar(&cfg.GoogleForwardingNetworks)
 	app.Flag("alibaba-cloud-config-file", "When using the Alibaba Cloud provider, specify the Alibaba Cloud configuration file (required when --provider=alibabacloud)").Default(defaultConfig.AlibabaCloudConfigFile).StringVar(&cfg.AlibabaCloudConfigFile)
 	app.Flag("alibaba-cloud-zone-type", "When using the Alibaba Cloud provider, filter for zones of this type (optional, options: public, private)").Default(defaultConfig.AlibabaCloudZoneType).EnumVar(&cfg.AlibabaCloudZoneType, "", "public", "private")
 	app.Flag("aws-zone-type", "When using the AWS provider, filter for zones of this type (optional, options: public, private)").Default(defaultConfig.AWSZoneType).EnumVar(&cfg.AWSZoneType, "", "public", "private")
@@ -507,15 +745,39 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("aws-profile", "When using the AWS provider, name of the profile to use").Default("").StringsVar(&cfg.AWSProfiles)
 	app.Flag("aws-assume-role", "When using the AWS API, assume this IAM role. Useful for hosted zones in another AWS account. Specify the full ARN, e.g. `arn:aws:iam::123455567:role/external-dns` (optional)").Default(defaultConfig.AWSAssumeRole).StringVar(&cfg.AWSAssumeRole)
 	app.Flag("aws-assume-role-external-id", "When using the AWS API and assuming a role then specify this external ID` (optional)").Default(defaultConfig.AWSAssumeRoleExternalID).StringVar(&cfg.AWSAssumeRoleExternalID)
+	app.Flag("aws-domain-role", "When using the AWS API, assume this IAM role to manage the hosted zones of the domain, in the form domain=role-arn, the zones of other domains are not managed with the role. Specify multiple times for multiple domains (optional)").StringMapVar(&cfg.AWSDomainRoles)
+	app.Flag("aws-assume-role-session-tag", "When using the AWS API and assuming a role, tag the session with this tag in the form key=value. Specify multiple times for multiple tags (optional)").StringMapVar(&cfg.AWSAssumeRoleSessionTags)
+	app.Flag("aws-assume-role-transitive-tag-key", "When using the AWS API and assuming a role, pass the session tag with this key to the roles assumed from the session. Specify multiple times for multiple keys (optional)").StringsVar(&cfg.AWSAssumeRoleTransitiveTagKeys)
+	app.Flag("aws-request-tag", "When using the AWS API, add this tag to the user agent of the requests, so that they can be told apart in CloudTrail (optional)").Default(defaultConfig.AWSRequestTag).StringVar(&cfg.AWSRequestTag)
+	app.Flag("aws-endpoint-url", "When using the AWS API, send the requests of Route53, STS and the other AWS services to this URL instead of the AWS endpoints, e.g. for LocalStack or a proxy (optional)").Default(defaultConfig.AWSEndpointURL).StringVar(&cfg.AWSEndpointURL)
+	app.Flag("aws-sso-start-url", "When using the AWS API, get the credentials of a role from the AWS SSO user portal at this URL, with the token cached by 'aws sso login'; requires --aws-sso-account-id and --aws-sso-role-name (optional)").Default(defaultConfig.AWSSSOStartURL).StringVar(&cfg.AWSSSOStartURL)
+	app.Flag("aws-sso-account-id", "When using AWS SSO, the id of the account of the role (optional)").Default(defaultConfig.AWSSSOAccountID).StringVar(&cfg.AWSSSOAccountID)
+	app.Flag("aws-sso-role-name", "When using AWS SSO, the name of the role (optional)").Default(defaultConfig.AWSSSORoleName).StringVar(&cfg.AWSSSORoleName)
+	app.Flag("aws-sso-region", "When using AWS SSO, the region of the user portal (default: the region of the AWS configuration)").Default(defaultConfig.AWSSSORegion).StringVar(&cfg.AWSSSORegion)
 	app.Flag("aws-batch-change-size", "When using the AWS provider, set the maximum number of changes that will be applied in each batch.").Default(strconv.Itoa(defaultConfig.AWSBatchChangeSize)).IntVar(&cfg.AWSBatchChangeSize)
 	app.Flag("aws-batch-change-size-bytes", "When using the AWS provider, set the maximum byte size that will be applied in each batch.").Default(strconv.Itoa(defaultConfig.AWSBatchChangeSizeBytes)).IntVar(&cfg.AWSBatchChangeSizeBytes)
 	app.Flag("aws-batch-change-size-values", "When using the AWS provider, set the maximum total record values that will be applied in each batch.").Default(strconv.Itoa(defaultConfig.AWSBatchChangeSizeValues)).IntVar(&cfg.AWSBatchChangeSizeValues)
 	app.Flag("aws-batch-change-interval", "When using the AWS provider, set the interval between batch changes.").Default(defaultConfig.AWSBatchChangeInterval.String()).DurationVar(&cfg.AWSBatchChangeInterval)
+	app.Flag("aws-max-changes-per-batch", "When using the AWS provider, submit the changes in batches of at most this many changes and roll back the batches already submitted when one fails (default: disabled)").Default(strconv.Itoa(defaultConfig.AWSMaxChangesPerBatch)).IntVar(&cfg.AWSMaxChangesPerBatch)
+	app.Flag("aws-transactional-zone-updates", "When using the AWS provider, submit all the changes of a zone in a single batch and don't submit the changes of the following zones when it fails (default: false)").BoolVar(&cfg.AWSTransactionalZoneUpdates)
 	app.Flag("aws-evaluate-target-health", "When using the AWS provider, set whether to evaluate the health of a DNS target (default: enabled, disable with --no-aws-evaluate-target-health)").Default(strconv.FormatBool(defaultConfig.AWSEvaluateTargetHealth)).BoolVar(&cfg.AWSEvaluateTargetHealth)
 	app.Flag("aws-api-retries", "When using the AWS API, set the maximum number of retries before giving up.").Default(strconv.Itoa(defaultConfig.AWSAPIRetries)).IntVar(&cfg.AWSAPIRetries)
+	app.Flag("aws-ec2-metadata-v2-only", "When using the AWS API on EC2, get the instance profile credentials with IMDSv2 only, without falling back to IMDSv1 (default: false)").BoolVar(&cfg.AWSEC2MetadataV2Only)
 	app.Flag("aws-prefer-cname", "When using the AWS provider, prefer using CNAME instead of ALIAS (default: disabled)").BoolVar(&cfg.AWSPreferCNAME)
 	app.Flag("aws-zones-cache-duration", "When using the AWS provider, set the zones list cache TTL (0s to disable).").Default(defaultConfig.AWSZoneCacheDuration.String()).DurationVar(&cfg.AWSZoneCacheDuration)
 	app.Flag("aws-zone-match-parent", "Expand limit possible target by sub-domains (default: disabled)").BoolVar(&cfg.AWSZoneMatchParent)
+	app.Flag("aws-manage-resolver-rules", "When using the AWS provider, associate the Route53 Resolver rule given by --aws-resolver-rule-name with the VPCs of the managed private zones (default: disabled)").BoolVar(&cfg.AWSManageResolverRules)
+	app.Flag("aws-resolver-rule-name", "When using the AWS provider with --aws-manage-resolver-rules, name of the Route53 Resolver rule to associate, e.g. a forwarding rule to on-premises DNS servers").Default(defaultConfig.AWSResolverRuleName).StringVar(&cfg.AWSResolverRuleName)
+	app.Flag("aws-validate-dnssec", "When using the AWS provider, skip the updates and deletions of the records of public zones which fail DNSSEC validation (default: disabled)").BoolVar(&cfg.AWSValidateDNSSEC)
+	app.Flag("aws-dnssec-resolver", "When using the AWS provider with --aws-validate-dnssec, host:port of the validating resolver used to query the existing records (default: first nameserver of /etc/resolv.conf)").Default(defaultConfig.AWSDNSSECResolver).StringVar(&cfg.AWSDNSSECResolver)
+	app.Flag("aws-manage-traffic-policies", "When using the AWS provider, publish the endpoints with the aws-traffic-policy-id annotation as Route53 traffic policy instances (default: disabled)").BoolVar(&cfg.AWSManageTrafficPolicies)
+	app.Flag("aws-arc-routing-control-arn", "When using the AWS provider, ARN of a Route53 Application Recovery Controller routing control which must be on for the changes to be applied (optional)").Default(defaultConfig.AWSARCRoutingControlARN).StringVar(&cfg.AWSARCRoutingControlARN)
+	app.Flag("aws-arc-cluster-endpoint", "When using the AWS provider with --aws-arc-routing-control-arn, endpoint of the Application Recovery Controller cluster; specify multiple times for the endpoints to try in turn").StringsVar(&cfg.AWSARCClusterEndpoints)
+	app.Flag("aws-recovery-readiness", "When using the AWS provider, register the failover records as resource sets in Route53 Recovery Readiness, and deregister them when they are deleted (default: disabled)").BoolVar(&cfg.AWSRecoveryReadiness)
+	app.Flag("aws-recovery-readiness-cell-arn", "When using the AWS provider with --aws-recovery-readiness, ARN of a Recovery Readiness cell in which the readiness of the resource sets is checked; specify multiple times for multiple cells (optional)").StringsVar(&cfg.AWSRecoveryReadinessCellARNs)
+	app.Flag("aws-create-zone", "When using the AWS provider, create this public hosted zone when it doesn't exist; specify multiple times for multiple zones (optional)").StringsVar(&cfg.AWSCreateZones)
+	app.Flag("aws-zone-auto-delegate", "When using the AWS provider with --aws-create-zone, upsert the NS records delegating the zones to their name servers in their parent zone (default: disabled)").BoolVar(&cfg.AWSZoneAutoDelegate)
+	app.Flag("aws-parent-zone-id", "When using the AWS provider with --aws-zone-auto-delegate, ID of the hosted zone the zones are delegated from (default: the most specific existing parent zone of each zone)").Default(defaultConfig.AWSParentZoneID).StringVar(&cfg.AWSParentZoneID)
 	app.Flag("aws-sd-service-cleanup", "When using the AWS CloudMap provider, delete empty Services without endpoints (default: disabled)").BoolVar(&cfg.AWSSDServiceCleanup)
 	app.Flag("aws-sd-create-tag", "When using the AWS CloudMap provider, add tag to created services. The flag can be used multiple times").StringMapVar(&cfg.AWSSDCreateTag)
 	app.Flag("azure-config-file", "When using the Azure provider, specify the Azure configuration file (required when --provider=azure)").Default(defaultConfig.AzureConfigFile).StringVar(&cfg.AzureConfigFile)
@@ -524,14 +786,21 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("azure-user-assigned-identity-client-id", "When using the Azure provider, override the client id of user assigned identity in config file (optional)").Default("").StringVar(&cfg.AzureUserAssignedIdentityClientID)
 	app.Flag("azure-zones-cache-duration", "When using the Azure provider, set the zones list cache TTL (0s to disable).").Default(defaultConfig.AzureZonesCacheDuration.String()).DurationVar(&cfg.AzureZonesCacheDuration)
 	app.Flag("azure-maxretries-count", "When using the Azure provider, set the number of retries for API calls (When less than 0, it disables retries). (optional)").Default(strconv.Itoa(defaultConfig.AzureMaxRetriesCount)).IntVar(&cfg.AzureMaxRetriesCount)
+	app.Flag("azure-traffic-manager-profile", "When using the Azure provider, manage the geo mapping of the endpoints of this Traffic Manager profile in the resource group from the azure-geo-location annotation of the records they target (optional)").Default(defaultConfig.AzureTrafficManagerProfile).StringVar(&cfg.AzureTrafficManagerProfile)
 
 	app.Flag("cloudflare-proxied", "When using the Cloudflare provider, specify if the proxy mode must be enabled (default: disabled)").BoolVar(&cfg.CloudflareProxied)
 	app.Flag("cloudflare-custom-hostnames", "When using the Cloudflare provider, specify if the Custom Hostnames feature will be used. Requires \"Cloudflare for SaaS\" enabled. (default: disabled)").BoolVar(&cfg.CloudflareCustomHostnames)
 	app.Flag("cloudflare-custom-hostnames-min-tls-version", "When using the Cloudflare provider with the Custom Hostnames, specify which Minimum TLS Version will be used by default. (default: 1.0, options: 1.0, 1.1, 1.2, 1.3)").Default("1.0").EnumVar(&cfg.CloudflareCustomHostnamesMinTLSVersion, "1.0", "1.1", "1.2", "1.3")
 	app.Flag("cloudflare-custom-hostnames-certificate-authority", "When using the Cloudflare provider with the Custom Hostnames, specify which Certificate Authority will be used. A value of none indicates no Certificate Authority will be sent to the Cloudflare API (default: none, options: google, ssl_com, lets_encrypt, none)").Default("none").EnumVar(&cfg.CloudflareCustomHostnamesCertificateAuthority, "google", "ssl_com", "lets_encrypt", "none")
+	app.Flag("cloudflare-load-balancers", "When using the Cloudflare provider, specify if endpoints annotated with cloudflare-load-balancer will be published as Cloudflare Load Balancers. Requires \"Load Balancing\" enabled on the account. (default: disabled)").BoolVar(&cfg.CloudflareLoadBalancers)
+	app.Flag("cloudflare-worker-routes", "When using the Cloudflare provider, specify if a Workers Route to the script named by the cloudflare-worker-route annotation will be managed for the annotated hostnames. (default: disabled)").BoolVar(&cfg.CloudflareWorkerRoutes)
+	app.Flag("cloudflare-verify-propagation", "When using the Cloudflare provider, wait until the created and updated records are answered by the Cloudflare DNS over HTTPS resolver before completing the synchronization (default: disabled)").BoolVar(&cfg.CloudflareVerifyPropagation)
+	app.Flag("cloudflare-verify-timeout", "When using the Cloudflare provider, the maximum time to wait for the propagation of the records, the synchronization fails with the records which are still not visible once exceeded (default: 1m)").Default(defaultConfig.CloudflareVerifyTimeout.String()).DurationVar(&cfg.CloudflareVerifyTimeout)
 	app.Flag("cloudflare-dns-records-per-page", "When using the Cloudflare provider, specify how many DNS records listed per page, max possible 5,000 (default: 100)").Default(strconv.Itoa(defaultConfig.CloudflareDNSRecordsPerPage)).IntVar(&cfg.CloudflareDNSRecordsPerPage)
 	app.Flag("cloudflare-region-key", "When using the Cloudflare provider, specify the region (default: earth)").StringVar(&cfg.CloudflareRegionKey)
 	app.Flag("cloudflare-record-comment", "When using the Cloudflare provider, specify the comment for the DNS records (default: '')").Default("").StringVar(&cfg.CloudflareRecordComment)
+	app.Flag("cloudflare-zone-token", "When using the Cloudflare provider, manage the zone with this API token instead of the global one, in the form zone=token or zone=file:path; specify multiple times for multiple zones (optional)").StringMapVar(&cfg.CloudflareZoneTokens)
+	app.Flag("cloudflare-zone-filter-regex", "When using the Cloudflare provider, only manage the zones with a name matching this regex, applied after listing the zones (optional)").Default(defaultConfig.CloudflareZoneFilterRegex.String()).RegexpVar(&cfg.CloudflareZoneFilterRegex)
 
 	app.Flag("coredns-prefix", "When using the CoreDNS provider, specify the prefix name").Default(defaultConfig.CoreDNSPrefix).StringVar(&cfg.CoreDNSPrefix)
 	app.Flag("akamai-serviceconsumerdomain", "When using the Akamai provider, specify the base URL (required when --provider=akamai and edgerc-path not specified)").Default(defaultConfig.AkamaiServiceConsumerDomain).StringVar(&cfg.AkamaiServiceConsumerDomain)
@@ -558,8 +827,8 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("ns1-min-ttl", "Minimal TTL (in seconds) for records. This value will be used if the provided TTL for a service/ingress is lower than this.").IntVar(&cfg.NS1MinTTLSeconds)
 	app.Flag("digitalocean-api-page-size", "Configure the page size used when querying the DigitalOcean API.").Default(strconv.Itoa(defaultConfig.DigitalOceanAPIPageSize)).IntVar(&cfg.DigitalOceanAPIPageSize)
 	// GoDaddy flags
-	app.Flag("godaddy-api-key", "When using the GoDaddy provider, specify the API Key (required when --provider=godaddy)").Default(defaultConfig.GoDaddyAPIKey).StringVar(&cfg.GoDaddyAPIKey)
-	app.Flag("godaddy-api-secret", "When using the GoDaddy provider, specify the API secret (required when --provider=godaddy)").Default(defaultConfig.GoDaddySecretKey).StringVar(&cfg.GoDaddySecretKey)
+	app.Flag("godaddy-api-key", "When using the GoDaddy provider, specify the API Key (required when --provider=godaddy, unless GODADDY_API_KEY is set)").Default(defaultConfig.GoDaddyAPIKey).StringVar(&cfg.GoDaddyAPIKey)
+	app.Flag("godaddy-api-secret", "When using the GoDaddy provider, specify the API secret (required when --provider=godaddy, unless GODADDY_API_SECRET is set)").Default(defaultConfig.GoDaddySecretKey).StringVar(&cfg.GoDaddySecretKey)
 	app.Flag("godaddy-api-ttl", "TTL (in seconds) for records. This value will be used if the provided TTL for a service/ingress is not provided.").Int64Var(&cfg.GoDaddyTTL)
 	app.Flag("godaddy-api-ote", "When using the GoDaddy provider, use OTE api (optional, default: false, when --provider=godaddy)").BoolVar(&cfg.GoDaddyOTE)
 
@@ -598,6 +867,12 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("transip-account", "When using the TransIP provider, specify the account name (required when --provider=transip)").Default(defaultConfig.TransIPAccountName).StringVar(&cfg.TransIPAccountName)
 	app.Flag("transip-keyfile", "When using the TransIP provider, specify the path to the private key file (required when --provider=transip)").Default(defaultConfig.TransIPPrivateKeyFile).StringVar(&cfg.TransIPPrivateKeyFile)
 
+	// Flags related to INWX provider
+	app.Flag("inwx-username", "When using the INWX provider, specify the account username (required when --provider=inwx)").Default(defaultConfig.InwxUsername).StringVar(&cfg.InwxUsername)
+	app.Flag("inwx-password", "When using the INWX provider, specify the account password (required when --provider=inwx)").Default(defaultConfig.InwxPassword).StringVar(&cfg.InwxPassword)
+	app.Flag("inwx-otp-secret", "When using the INWX provider, specify the shared secret of the two factor authentication the one-time PINs are generated from (required when the account has two factor authentication enabled)").Default(defaultConfig.InwxOTPSecret).StringVar(&cfg.InwxOTPSecret)
+	app.Flag("inwx-sandbox", "When using the INWX provider, use the test environment (OTE) API").BoolVar(&cfg.InwxSandbox)
+
 	// Flags related to Pihole provider
 	app.Flag("pihole-server", "When using the Pihole provider, the base URL of the Pihole web server (required when --provider=pihole)").Default(defaultConfig.PiholeServer).StringVar(&cfg.PiholeServer)
 	app.Flag("pihole-password", "When using the Pihole provider, the password to the server if it is protected").Default(defaultConfig.PiholePassword).StringVar(&cfg.PiholePassword)
@@ -620,6 +895,7 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("txt-encrypt-enabled", "When using the TXT registry, set if TXT records should be encrypted before stored (default: disabled)").BoolVar(&cfg.TXTEncryptEnabled)
 	app.Flag("txt-encrypt-aes-key", "When using the TXT registry, set TXT record decryption and encryption 32 byte aes key (required when --txt-encrypt=true)").Default(defaultConfig.TXTEncryptAESKey).StringVar(&cfg.TXTEncryptAESKey)
 	app.Flag("txt-new-format-only", "When using the TXT registry, only use new format records which include record type information (e.g., prefix: 'a-'). Reduces number of TXT records (default: disabled)").BoolVar(&cfg.TXTNewFormatOnly)
+	app.Flag("transfer-ownership-from", "When using the TXT registry, take over the records owned by this owner id on startup, e.g. after renaming --txt-owner-id or when the previous instance is gone for good (default: disabled)").Default(defaultConfig.TransferOwnershipFrom).StringVar(&cfg.TransferOwnershipFrom)
 	app.Flag("dynamodb-region", "When using the DynamoDB registry, the AWS region of the DynamoDB table (optional)").Default(cfg.AWSDynamoDBRegion).StringVar(&cfg.AWSDynamoDBRegion)
 	app.Flag("dynamodb-table", "When using the DynamoDB registry, the name of the DynamoDB table (default: \"external-dns\")").Default(defaultConfig.AWSDynamoDBTable).StringVar(&cfg.AWSDynamoDBTable)
 
@@ -627,13 +903,22 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("txt-cache-interval", "The interval between cache synchronizations in duration format (default: disabled)").Default(defaultConfig.TXTCacheInterval.String()).DurationVar(&cfg.TXTCacheInterval)
 	app.Flag("interval", "The interval between two consecutive synchronizations in duration format (default: 1m)").Default(defaultConfig.Interval.String()).DurationVar(&cfg.Interval)
 	app.Flag("min-event-sync-interval", "The minimum interval between two consecutive synchronizations triggered from kubernetes events in duration format (default: 5s)").Default(defaultConfig.MinEventSyncInterval.String()).DurationVar(&cfg.MinEventSyncInterval)
+	app.Flag("interval-jitter-factor", "Multiplies the interval between two synchronizations by a random factor between 1 and 1 + this factor, so that the instances started at the same time spread their calls to the provider; must be between 0 and 1 (default: 0)").Default("0").Float64Var(&cfg.IntervalJitterFactor)
+	app.Flag("fingerprint-max-age", "When set, skips the synchronizations while the source endpoints are unchanged since the last successful one, for at most this duration; the provider is not called in the meantime (default: disabled)").Default(defaultConfig.FingerprintMaxAge.String()).DurationVar(&cfg.FingerprintMaxAge)
+	app.Flag("reconcile-timeout", "When set, cancels the synchronizations which take longer than this duration and exits when they don't return within three times this duration, so that the pod is restarted (default: disabled)").Default(defaultConfig.ReconcileTimeout.String()).DurationVar(&cfg.ReconcileTimeout)
+	app.Flag("force-full-sync-after-errors", "Drops the cached records after this number of consecutive synchronization errors, so that all the records are read from the provider again; 0 disables it (default: 3)").Default(strconv.Itoa(defaultConfig.ForceFullSyncAfterErrors)).IntVar(&cfg.ForceFullSyncAfterErrors)
 	app.Flag("once", "When enabled, exits the synchronization loop after the first iteration (default: disabled)").BoolVar(&cfg.Once)
 	app.Flag("dry-run", "When enabled, prints DNS record changes rather than actually performing them (default: disabled)").BoolVar(&cfg.DryRun)
 	app.Flag("events", "When enabled, in addition to running every interval, the reconciliation loop will get triggered when supported sources change (default: disabled)").BoolVar(&cfg.UpdateEvents)
+	app.Flag("emit-events", "When enabled, emits Kubernetes events on the source objects (Service, Ingress, DNSEndpoint) when their DNS records are created, updated or deleted (default: disabled)").BoolVar(&cfg.EmitEvents)
 
 	// Miscellaneous flags
 	app.Flag("log-format", "The format in which log messages are printed (default: text, options: text, json)").Default(defaultConfig.LogFormat).EnumVar(&cfg.LogFormat, "text", "json")
 	app.Flag("metrics-address", "Specify where to serve the metrics and health check endpoint (default: :7979)").Default(defaultConfig.MetricsAddress).StringVar(&cfg.MetricsAddress)
+	app.Flag("health-listen-address", "Specify where to serve the liveness probe on /healthz, the readiness probe on /readyz, which succeeds once a synchronization succeeded, and the metrics (default: :8080)").Default(defaultConfig.HealthListenAddress).StringVar(&cfg.HealthListenAddress)
+	app.Flag("metrics-path", "The path of the metrics on the metrics and health check endpoints (default: /metrics)").Default(defaultConfig.MetricsPath).StringVar(&cfg.MetricsPath)
+	app.Flag("enable-pprof", "When enabled, serves the profiling endpoints of net/http/pprof on /debug/pprof/ at --pprof-listen-address, protected by HTTP basic authentication with a random password logged at startup (default: disabled)").BoolVar(&cfg.EnablePprof)
+	app.Flag("pprof-listen-address", "Specify where to serve the profiling endpoints when --enable-pprof is set (default: :6060)").Default(defaultConfig.PprofListenAddress).StringVar(&cfg.PprofListenAddress)
 	app.Flag("log-level", "Set the level of logging. (default: info, options: panic, debug, info, warning, error, fatal)").Default(defaultConfig.LogLevel).EnumVar(&cfg.LogLevel, allLogLevelsAsStrings()...)
 
 	// Webhook provider
@@ -643,5 +928,9 @@ func App(cfg *Config) *kingpin.Application {
 
 	app.Flag("webhook-server", "When enabled, runs as a webhook server instead of a controller. (default: false).").BoolVar(&cfg.WebhookServer)
 
+	// Plugin provider
+	app.Flag("provider-plugin-url", "The address of the gRPC plugin server for the plugin provider, e.g. localhost:8889 or unix:///var/run/plugin.sock").Default(defaultConfig.ProviderPluginURL).StringVar(&cfg.ProviderPluginURL)
+	app.Flag("provider-plugin-timeout", "The timeout of the calls to the plugin provider in duration format (default: 10s)").Default(defaultConfig.ProviderPluginTimeout.String()).DurationVar(&cfg.ProviderPluginTimeout)
+
 	return app
 }
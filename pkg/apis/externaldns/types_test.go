@@ -19,6 +19,7 @@ package externaldns
 import (
 	"os"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -34,6 +35,8 @@ var (
 		APIServerURL:                           "",
 		KubeConfig:                             "",
 		RequestTimeout:                         time.Second * 30,
+		ResyncPeriod:                           10 * time.Minute,
+		WatchBackoffMaxDelay:                   30 * time.Second,
 		GlooNamespaces:                         []string{"gloo-system"},
 		SkipperRouteGroupVersion:               "zalando.org/v1",
 		Sources:                                []string{"service"},
@@ -41,6 +44,7 @@ var (
 		FQDNTemplate:                           "",
 		Compatibility:                          "",
 		Provider:                               "google",
+		Providers:                              []string{"google"},
 		GoogleProject:                          "",
 		GoogleBatchChangeSize:                  1000,
 		GoogleBatchChangeInterval:              time.Second,
@@ -68,6 +72,14 @@ var (
 		AWSZoneCacheDuration:                   0 * time.Second,
 		AWSSDServiceCleanup:                    false,
 		AWSSDCreateTag:                         map[string]string{},
+		AWSDomainRoles:                         map[string]string{},
+		AWSAssumeRoleSessionTags:               map[string]string{},
+		AWSRequestTag:                          "",
+		AWSEndpointURL:                         "",
+		AWSSSOStartURL:                         "",
+		AWSSSOAccountID:                        "",
+		AWSSSORoleName:                         "",
+		AWSSSORegion:                           "",
 		AWSDynamoDBTable:                       "external-dns",
 		AzureConfigFile:                        "/etc/kubernetes/azure.json",
 		AzureResourceGroup:                     "",
@@ -78,8 +90,11 @@ var (
 		CloudflareCustomHostnamesMinTLSVersion: "1.0",
 		CloudflareCustomHostnamesCertificateAuthority: "none",
 		CloudflareDNSRecordsPerPage:                   100,
+		CloudflareVerifyTimeout:                       time.Minute,
 		CloudflareDNSRecordsComment:                   "",
 		CloudflareRegionKey:                           "",
+		CloudflareZoneTokens:                          map[string]string{},
+		CloudflareZoneFilterRegex:                     regexp.MustCompile(""),
 		CoreDNSPrefix:                                 "/skydns/",
 		AkamaiServiceConsumerDomain:                   "",
 		AkamaiClientToken:                             "",
@@ -104,13 +119,19 @@ var (
 		TXTNewFormatOnly:                              false,
 		Interval:                                      time.Minute,
 		MinEventSyncInterval:                          5 * time.Second,
+		ForceFullSyncAfterErrors:                      3,
 		Once:                                          false,
 		DryRun:                                        false,
 		UpdateEvents:                                  false,
 		LogFormat:                                     "text",
 		MetricsAddress:                                ":7979",
+		HealthListenAddress:                           ":8080",
+		MetricsPath:                                   "/metrics",
+		EnablePprof:                                   false,
+		PprofListenAddress:                            ":6060",
 		LogLevel:                                      logrus.InfoLevel.String(),
 		ConnectorSourceServer:                         "localhost:8080",
+		FederationSource:                              "service",
 		ExoscaleAPIEnvironment:                        "api",
 		ExoscaleAPIZone:                               "ch-gva-2",
 		ExoscaleAPIKey:                                "",
@@ -119,6 +140,10 @@ var (
 		CRDSourceKind:                                 "DNSEndpoint",
 		TransIPAccountName:                            "",
 		TransIPPrivateKeyFile:                         "",
+		InwxUsername:                                  "",
+		InwxPassword:                                  "",
+		InwxOTPSecret:                                 "",
+		InwxSandbox:                                   false,
 		DigitalOceanAPIPageSize:                       50,
 		ManagedDNSRecordTypes:                         []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
 		RFC2136BatchChangeSize:                        50,
@@ -130,63 +155,112 @@ var (
 		WebhookProviderReadTimeout:                    5 * time.Second,
 		WebhookProviderWriteTimeout:                   10 * time.Second,
 		ExcludeUnschedulable:                          true,
+		EndpointTransformTimeout:                      5 * time.Second,
+		ProviderPluginTimeout:                         10 * time.Second,
 	}
 
 	overriddenConfig = &Config{
 		APIServerURL:                           "http://127.0.0.1:8080",
 		KubeConfig:                             "/some/path",
+		KubeContext:                            "some-context",
+		KubeImpersonateUser:                    "system:serviceaccount:default:external-dns",
+		KubeImpersonateGroups:                  []string{"system:serviceaccounts", "dns-admins"},
+		KubeExtraEndpoints:                     []string{"https://api.a.example.com", "https://api.b.example.com=/path/to/b"},
+		KubeSkipTLSVerify:                      true,
+		KubeCAFile:                             "/some/ca.crt",
 		RequestTimeout:                         time.Second * 77,
+		ResyncPeriod:                           time.Minute,
+		WatchBackoffMaxDelay:                   2 * time.Minute,
 		GlooNamespaces:                         []string{"gloo-not-system", "gloo-second-system"},
 		SkipperRouteGroupVersion:               "zalando.org/v2",
 		Sources:                                []string{"service", "ingress", "connector"},
 		Namespace:                              "namespace",
 		IgnoreHostnameAnnotation:               true,
+		HostnameAliases:                        "web=web.example.com,api=api.example.com",
 		IgnoreNonHostNetworkPods:               true,
 		IgnoreIngressTLSSpec:                   true,
+		IncludeClusterIP:                       true,
 		IgnoreIngressRulesSpec:                 true,
+		InheritServiceAnnotations:              true,
+		UpdateIngressStatus:                    true,
 		FQDNTemplate:                           "{{.Name}}.service.example.com",
 		Compatibility:                          "mate",
 		Provider:                               "google",
+		Providers:                              []string{"google"},
 		GoogleProject:                          "project",
 		GoogleBatchChangeSize:                  100,
 		GoogleBatchChangeInterval:              time.Second * 2,
 		GoogleZoneVisibility:                   "private",
+		GoogleResponsePolicy:                   "cluster-policy",
+		GoogleForwardingZones:                  []string{"corp.example.com"},
+		GoogleForwardingTargets:                []string{"10.0.0.53", "10.0.1.53,private"},
+		GoogleForwardingNetworks:               []string{"https://www.googleapis.com/compute/v1/projects/project/global/networks/default"},
 		DomainFilter:                           []string{"example.org", "company.com"},
 		ExcludeDomains:                         []string{"xapi.example.org", "xapi.company.com"},
 		RegexDomainFilter:                      regexp.MustCompile("(example\\.org|company\\.com)$"),
 		RegexDomainExclusion:                   regexp.MustCompile("xapi\\.(example\\.org|company\\.com)$"),
 		ZoneNameFilter:                         []string{"yapi.example.org", "yapi.company.com"},
 		ZoneIDFilter:                           []string{"/hostedzone/ZTST1", "/hostedzone/ZTST2"},
+		ProtectedZones:                         []string{"example.net", "example.info"},
 		TargetNetFilter:                        []string{"10.0.0.0/9", "10.1.0.0/9"},
 		ExcludeTargetNets:                      []string{"1.0.0.0/9", "1.1.0.0/9"},
 		AlibabaCloudConfigFile:                 "/etc/kubernetes/alibaba-cloud.json",
 		AWSZoneType:                            "private",
 		AWSZoneTagFilter:                       []string{"tag=foo"},
 		AWSZoneMatchParent:                     true,
+		AWSValidateDNSSEC:                      true,
+		AWSManageTrafficPolicies:               true,
+		AWSARCRoutingControlARN:                "arn:aws:route53-recovery-control::123456789012:controlpanel/abc/routingcontrol/def",
+		AWSARCClusterEndpoints:                 []string{"https://a.route53-recovery-cluster.us-west-2.amazonaws.com/v1", "https://b.route53-recovery-cluster.eu-west-1.amazonaws.com/v1"},
+		AWSRecoveryReadiness:                   true,
+		AWSRecoveryReadinessCellARNs:           []string{"arn:aws:route53-recovery-readiness::123456789012:cell/us-west-2"},
+		AWSCreateZones:                         []string{"a.example.com", "b.example.com"},
+		AWSZoneAutoDelegate:                    true,
+		AWSParentZoneID:                        "/hostedzone/Z1234567890",
+		AWSDNSSECResolver:                      "127.0.0.1:53",
 		AWSAssumeRole:                          "some-other-role",
 		AWSAssumeRoleExternalID:                "pg2000",
 		AWSBatchChangeSize:                     100,
 		AWSBatchChangeSizeBytes:                16000,
 		AWSBatchChangeSizeValues:               100,
 		AWSBatchChangeInterval:                 time.Second * 2,
+		AWSMaxChangesPerBatch:                  50,
+		AWSTransactionalZoneUpdates:            true,
 		AWSEvaluateTargetHealth:                false,
 		AWSAPIRetries:                          13,
+		AWSEC2MetadataV2Only:                   true,
 		AWSPreferCNAME:                         true,
 		AWSProfiles:                            []string{"profile1", "profile2"},
 		AWSZoneCacheDuration:                   10 * time.Second,
 		AWSSDServiceCleanup:                    true,
 		AWSSDCreateTag:                         map[string]string{"key1": "value1", "key2": "value2"},
+		AWSDomainRoles:                         map[string]string{"example.org": "arn:aws:iam::123456789012:role/example-org"},
+		AWSAssumeRoleSessionTags:               map[string]string{"team": "dns", "cost-center": "1234"},
+		AWSAssumeRoleTransitiveTagKeys:         []string{"team"},
+		AWSRequestTag:                          "cluster-1",
+		AWSEndpointURL:                         "http://localhost:4566",
+		AWSSSOStartURL:                         "https://example.awsapps.com/start",
+		AWSSSOAccountID:                        "123456789012",
+		AWSSSORoleName:                         "external-dns",
+		AWSSSORegion:                           "eu-west-1",
 		AWSDynamoDBTable:                       "custom-table",
 		AzureConfigFile:                        "azure.json",
 		AzureResourceGroup:                     "arg",
 		AzureSubscriptionID:                    "arg",
 		AzureMaxRetriesCount:                   4,
+		AzureTrafficManagerProfile:             "global",
 		CloudflareProxied:                      true,
 		CloudflareCustomHostnames:              true,
 		CloudflareCustomHostnamesMinTLSVersion: "1.3",
 		CloudflareCustomHostnamesCertificateAuthority: "google",
 		CloudflareDNSRecordsPerPage:                   5000,
+		CloudflareLoadBalancers:                       true,
+		CloudflareWorkerRoutes:                        true,
+		CloudflareVerifyPropagation:                   true,
+		CloudflareVerifyTimeout:                       2 * time.Minute,
 		CloudflareRegionKey:                           "us",
+		CloudflareZoneTokens:                          map[string]string{"example.org": "token1", "example.com": "file:/etc/cloudflare/example.com"},
+		CloudflareZoneFilterRegex:                     regexp.MustCompile("^(team-a|team-b)\\."),
 		CoreDNSPrefix:                                 "/coredns/",
 		AkamaiServiceConsumerDomain:                   "oooo-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net",
 		AkamaiClientToken:                             "o184671d5307a388180fbf7f11dbdf46",
@@ -214,25 +288,45 @@ var (
 		TXTPrefix:                                     "associated-txt-record",
 		TXTCacheInterval:                              12 * time.Hour,
 		TXTNewFormatOnly:                              true,
+		TransferOwnershipFrom:                         "previous",
 		Interval:                                      10 * time.Minute,
 		MinEventSyncInterval:                          50 * time.Second,
+		IntervalJitterFactor:                          0.2,
+		FingerprintMaxAge:                             15 * time.Minute,
+		ReconcileTimeout:                              5 * time.Minute,
+		ForceFullSyncAfterErrors:                      5,
 		Once:                                          true,
 		DryRun:                                        true,
 		UpdateEvents:                                  true,
 		LogFormat:                                     "json",
 		MetricsAddress:                                "127.0.0.1:9099",
+		HealthListenAddress:                           "127.0.0.1:9098",
+		MetricsPath:                                   "/external-dns/metrics",
+		EnablePprof:                                   true,
+		PprofListenAddress:                            "127.0.0.1:6061",
 		LogLevel:                                      logrus.DebugLevel.String(),
 		ConnectorSourceServer:                         "localhost:8081",
+		WebhookSourceURL:                              "http://localhost:8082/endpoints",
+		SourceFile:                                    "/etc/external-dns/endpoints.yaml",
+		ProviderConfigFile:                            "/etc/kubernetes/azure.json",
+		ProviderReadOnly:                              true,
+		FederationKubeConfig:                          "/path/to/cluster-a,/path/to/cluster-b",
+		FederationSource:                              "ingress",
 		ExoscaleAPIEnvironment:                        "api1",
 		ExoscaleAPIZone:                               "zone1",
 		ExoscaleAPIKey:                                "1",
 		ExoscaleAPISecret:                             "2",
 		CRDSourceAPIVersion:                           "test.k8s.io/v1alpha1",
 		CRDSourceKind:                                 "Endpoint",
+		CRDSourceFinalizer:                            true,
 		NS1Endpoint:                                   "https://api.example.com/v1",
 		NS1IgnoreSSL:                                  true,
 		TransIPAccountName:                            "transip",
 		TransIPPrivateKeyFile:                         "/path/to/transip.key",
+		InwxUsername:                                  "inwx-user",
+		InwxPassword:                                  "inwx-pass",
+		InwxOTPSecret:                                 "GEZDGNBVGY3TQOJQ",
+		InwxSandbox:                                   true,
 		DigitalOceanAPIPageSize:                       100,
 		ManagedDNSRecordTypes:                         []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeNS},
 		RFC2136BatchChangeSize:                        100,
@@ -243,6 +337,10 @@ var (
 		WebhookProviderReadTimeout:                    5 * time.Second,
 		WebhookProviderWriteTimeout:                   10 * time.Second,
 		ExcludeUnschedulable:                          false,
+		EndpointTransformURL:                          "http://localhost:8080/transform",
+		EndpointTransformTimeout:                      10 * time.Second,
+		ProviderPluginURL:                             "localhost:8889",
+		ProviderPluginTimeout:                         20 * time.Second,
 	}
 )
 
@@ -268,7 +366,17 @@ func TestParseFlags(t *testing.T) {
 			args: []string{
 				"--server=http://127.0.0.1:8080",
 				"--kubeconfig=/some/path",
+				"--kube-context=some-context",
+				"--kube-impersonate-user=system:serviceaccount:default:external-dns",
+				"--kube-impersonate-group=system:serviceaccounts",
+				"--kube-impersonate-group=dns-admins",
+				"--kube-extra-endpoints=https://api.a.example.com",
+				"--kube-extra-endpoints=https://api.b.example.com=/path/to/b",
+				"--kube-skip-tls-verify",
+				"--kube-ca-file=/some/ca.crt",
 				"--request-timeout=77s",
+				"--resync-period=1m",
+				"--watch-backoff-max-delay=2m",
 				"--gloo-namespace=gloo-not-system",
 				"--gloo-namespace=gloo-second-system",
 				"--skipper-routegroup-groupversion=zalando.org/v2",
@@ -279,24 +387,41 @@ func TestParseFlags(t *testing.T) {
 				"--fqdn-template={{.Name}}.service.example.com",
 				"--ignore-non-host-network-pods",
 				"--ignore-hostname-annotation",
+				"--hostname-aliases=web=web.example.com,api=api.example.com",
 				"--ignore-ingress-tls-spec",
+				"--include-cluster-ip",
 				"--ignore-ingress-rules-spec",
+				"--inherit-service-annotations",
+				"--update-ingress-status",
 				"--compatibility=mate",
 				"--provider=google",
 				"--google-project=project",
 				"--google-batch-change-size=100",
 				"--google-batch-change-interval=2s",
 				"--google-zone-visibility=private",
+				"--google-response-policy=cluster-policy",
+				"--google-forwarding-zone=corp.example.com",
+				"--google-forwarding-target=10.0.0.53",
+				"--google-forwarding-target=10.0.1.53,private",
+				"--google-forwarding-network=https://www.googleapis.com/compute/v1/projects/project/global/networks/default",
 				"--azure-config-file=azure.json",
 				"--azure-resource-group=arg",
 				"--azure-subscription-id=arg",
 				"--azure-maxretries-count=4",
+				"--azure-traffic-manager-profile=global",
 				"--cloudflare-proxied",
 				"--cloudflare-custom-hostnames",
 				"--cloudflare-custom-hostnames-min-tls-version=1.3",
 				"--cloudflare-custom-hostnames-certificate-authority=google",
 				"--cloudflare-dns-records-per-page=5000",
+				"--cloudflare-load-balancers",
+				"--cloudflare-worker-routes",
+				"--cloudflare-verify-propagation",
+				"--cloudflare-verify-timeout=2m",
 				"--cloudflare-region-key=us",
+				"--cloudflare-zone-token=example.org=token1",
+				"--cloudflare-zone-token=example.com=file:/etc/cloudflare/example.com",
+				"--cloudflare-zone-filter-regex=^(team-a|team-b)\\.",
 				"--coredns-prefix=/coredns/",
 				"--akamai-serviceconsumerdomain=oooo-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net",
 				"--akamai-client-token=o184671d5307a388180fbf7f11dbdf46",
@@ -329,6 +454,8 @@ func TestParseFlags(t *testing.T) {
 				"--zone-name-filter=yapi.company.com",
 				"--zone-id-filter=/hostedzone/ZTST1",
 				"--zone-id-filter=/hostedzone/ZTST2",
+				"--protected-zones=example.net",
+				"--protected-zones=example.info",
 				"--target-net-filter=10.0.0.0/9",
 				"--target-net-filter=10.1.0.0/9",
 				"--exclude-target-net=1.0.0.0/9",
@@ -336,13 +463,28 @@ func TestParseFlags(t *testing.T) {
 				"--aws-zone-type=private",
 				"--aws-zone-tags=tag=foo",
 				"--aws-zone-match-parent",
+				"--aws-validate-dnssec",
+				"--aws-manage-traffic-policies",
+				"--aws-arc-routing-control-arn=arn:aws:route53-recovery-control::123456789012:controlpanel/abc/routingcontrol/def",
+				"--aws-arc-cluster-endpoint=https://a.route53-recovery-cluster.us-west-2.amazonaws.com/v1",
+				"--aws-arc-cluster-endpoint=https://b.route53-recovery-cluster.eu-west-1.amazonaws.com/v1",
+				"--aws-recovery-readiness",
+				"--aws-recovery-readiness-cell-arn=arn:aws:route53-recovery-readiness::123456789012:cell/us-west-2",
+				"--aws-create-zone=a.example.com",
+				"--aws-create-zone=b.example.com",
+				"--aws-zone-auto-delegate",
+				"--aws-parent-zone-id=/hostedzone/Z1234567890",
+				"--aws-dnssec-resolver=127.0.0.1:53",
 				"--aws-assume-role=some-other-role",
 				"--aws-assume-role-external-id=pg2000",
 				"--aws-batch-change-size=100",
 				"--aws-batch-change-size-bytes=16000",
 				"--aws-batch-change-size-values=100",
 				"--aws-batch-change-interval=2s",
+				"--aws-max-changes-per-batch=50",
+				"--aws-transactional-zone-updates",
 				"--aws-api-retries=13",
+				"--aws-ec2-metadata-v2-only",
 				"--aws-prefer-cname",
 				"--aws-profile=profile1",
 				"--aws-profile=profile2",
@@ -350,6 +492,16 @@ func TestParseFlags(t *testing.T) {
 				"--aws-sd-service-cleanup",
 				"--aws-sd-create-tag=key1=value1",
 				"--aws-sd-create-tag=key2=value2",
+				"--aws-domain-role=example.org=arn:aws:iam::123456789012:role/example-org",
+				"--aws-assume-role-session-tag=team=dns",
+				"--aws-assume-role-session-tag=cost-center=1234",
+				"--aws-assume-role-transitive-tag-key=team",
+				"--aws-request-tag=cluster-1",
+				"--aws-endpoint-url=http://localhost:4566",
+				"--aws-sso-start-url=https://example.awsapps.com/start",
+				"--aws-sso-account-id=123456789012",
+				"--aws-sso-role-name=external-dns",
+				"--aws-sso-region=eu-west-1",
 				"--no-aws-evaluate-target-health",
 				"--pihole-api-version=6",
 				"--policy=upsert-only",
@@ -358,32 +510,56 @@ func TestParseFlags(t *testing.T) {
 				"--txt-prefix=associated-txt-record",
 				"--txt-cache-interval=12h",
 				"--txt-new-format-only",
+				"--transfer-ownership-from=previous",
 				"--dynamodb-table=custom-table",
 				"--interval=10m",
 				"--min-event-sync-interval=50s",
+				"--interval-jitter-factor=0.2",
+				"--fingerprint-max-age=15m",
+				"--reconcile-timeout=5m",
+				"--force-full-sync-after-errors=5",
 				"--once",
 				"--dry-run",
 				"--events",
 				"--log-format=json",
 				"--metrics-address=127.0.0.1:9099",
+				"--health-listen-address=127.0.0.1:9098",
+				"--metrics-path=/external-dns/metrics",
+				"--enable-pprof",
+				"--pprof-listen-address=127.0.0.1:6061",
 				"--log-level=debug",
 				"--connector-source-server=localhost:8081",
+				"--webhook-source-url=http://localhost:8082/endpoints",
+				"--source-file=/etc/external-dns/endpoints.yaml",
+				"--provider-config-file=/etc/kubernetes/azure.json",
+				"--provider-read-only",
+				"--federation-kubeconfig=/path/to/cluster-a,/path/to/cluster-b",
+				"--federation-source=ingress",
 				"--exoscale-apienv=api1",
 				"--exoscale-apizone=zone1",
 				"--exoscale-apikey=1",
 				"--exoscale-apisecret=2",
 				"--crd-source-apiversion=test.k8s.io/v1alpha1",
 				"--crd-source-kind=Endpoint",
+				"--crd-source-finalizer",
 				"--ns1-endpoint=https://api.example.com/v1",
 				"--ns1-ignoressl",
 				"--transip-account=transip",
 				"--transip-keyfile=/path/to/transip.key",
+				"--inwx-username=inwx-user",
+				"--inwx-password=inwx-pass",
+				"--inwx-otp-secret=GEZDGNBVGY3TQOJQ",
+				"--inwx-sandbox",
 				"--digitalocean-api-page-size=100",
 				"--managed-record-types=A",
 				"--managed-record-types=AAAA",
 				"--managed-record-types=CNAME",
 				"--managed-record-types=NS",
 				"--no-exclude-unschedulable",
+				"--endpoint-transform-webhook=http://localhost:8080/transform",
+				"--endpoint-transform-webhook-timeout=10s",
+				"--provider-plugin-url=localhost:8889",
+				"--provider-plugin-timeout=20s",
 				"--rfc2136-batch-change-size=100",
 				"--rfc2136-load-balancing-strategy=round-robin",
 				"--rfc2136-host=rfc2136-host1",
@@ -398,7 +574,15 @@ func TestParseFlags(t *testing.T) {
 			envVars: map[string]string{
 				"EXTERNAL_DNS_SERVER":                                            "http://127.0.0.1:8080",
 				"EXTERNAL_DNS_KUBECONFIG":                                        "/some/path",
+				"EXTERNAL_DNS_KUBE_CONTEXT":                                      "some-context",
+				"EXTERNAL_DNS_KUBE_IMPERSONATE_USER":                             "system:serviceaccount:default:external-dns",
+				"EXTERNAL_DNS_KUBE_IMPERSONATE_GROUP":                            "system:serviceaccounts\ndns-admins",
+				"EXTERNAL_DNS_KUBE_EXTRA_ENDPOINTS":                              "https://api.a.example.com\nhttps://api.b.example.com=/path/to/b",
+				"EXTERNAL_DNS_KUBE_SKIP_TLS_VERIFY":                              "1",
+				"EXTERNAL_DNS_KUBE_CA_FILE":                                      "/some/ca.crt",
 				"EXTERNAL_DNS_REQUEST_TIMEOUT":                                   "77s",
+				"EXTERNAL_DNS_RESYNC_PERIOD":                                     "1m",
+				"EXTERNAL_DNS_WATCH_BACKOFF_MAX_DELAY":                           "2m",
 				"EXTERNAL_DNS_CONTOUR_LOAD_BALANCER":                             "heptio-contour-other/contour-other",
 				"EXTERNAL_DNS_GLOO_NAMESPACE":                                    "gloo-not-system\ngloo-second-system",
 				"EXTERNAL_DNS_SKIPPER_ROUTEGROUP_GROUPVERSION":                   "zalando.org/v2",
@@ -407,24 +591,39 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_FQDN_TEMPLATE":                                     "{{.Name}}.service.example.com",
 				"EXTERNAL_DNS_IGNORE_NON_HOST_NETWORK_PODS":                      "1",
 				"EXTERNAL_DNS_IGNORE_HOSTNAME_ANNOTATION":                        "1",
+				"EXTERNAL_DNS_HOSTNAME_ALIASES":                                  "web=web.example.com,api=api.example.com",
 				"EXTERNAL_DNS_IGNORE_INGRESS_TLS_SPEC":                           "1",
+				"EXTERNAL_DNS_INCLUDE_CLUSTER_IP":                                "1",
 				"EXTERNAL_DNS_IGNORE_INGRESS_RULES_SPEC":                         "1",
+				"EXTERNAL_DNS_INHERIT_SERVICE_ANNOTATIONS":                       "1",
+				"EXTERNAL_DNS_UPDATE_INGRESS_STATUS":                             "1",
 				"EXTERNAL_DNS_COMPATIBILITY":                                     "mate",
 				"EXTERNAL_DNS_PROVIDER":                                          "google",
 				"EXTERNAL_DNS_GOOGLE_PROJECT":                                    "project",
 				"EXTERNAL_DNS_GOOGLE_BATCH_CHANGE_SIZE":                          "100",
 				"EXTERNAL_DNS_GOOGLE_BATCH_CHANGE_INTERVAL":                      "2s",
 				"EXTERNAL_DNS_GOOGLE_ZONE_VISIBILITY":                            "private",
+				"EXTERNAL_DNS_GOOGLE_RESPONSE_POLICY":                            "cluster-policy",
+				"EXTERNAL_DNS_GOOGLE_FORWARDING_ZONE":                            "corp.example.com",
+				"EXTERNAL_DNS_GOOGLE_FORWARDING_TARGET":                          "10.0.0.53\n10.0.1.53,private",
+				"EXTERNAL_DNS_GOOGLE_FORWARDING_NETWORK":                         "https://www.googleapis.com/compute/v1/projects/project/global/networks/default",
 				"EXTERNAL_DNS_AZURE_CONFIG_FILE":                                 "azure.json",
 				"EXTERNAL_DNS_AZURE_RESOURCE_GROUP":                              "arg",
 				"EXTERNAL_DNS_AZURE_SUBSCRIPTION_ID":                             "arg",
 				"EXTERNAL_DNS_AZURE_MAXRETRIES_COUNT":                            "4",
+				"EXTERNAL_DNS_AZURE_TRAFFIC_MANAGER_PROFILE":                     "global",
 				"EXTERNAL_DNS_CLOUDFLARE_PROXIED":                                "1",
 				"EXTERNAL_DNS_CLOUDFLARE_CUSTOM_HOSTNAMES":                       "1",
 				"EXTERNAL_DNS_CLOUDFLARE_CUSTOM_HOSTNAMES_MIN_TLS_VERSION":       "1.3",
 				"EXTERNAL_DNS_CLOUDFLARE_CUSTOM_HOSTNAMES_CERTIFICATE_AUTHORITY": "google",
 				"EXTERNAL_DNS_CLOUDFLARE_DNS_RECORDS_PER_PAGE":                   "5000",
+				"EXTERNAL_DNS_CLOUDFLARE_LOAD_BALANCERS":                         "1",
+				"EXTERNAL_DNS_CLOUDFLARE_WORKER_ROUTES":                          "1",
+				"EXTERNAL_DNS_CLOUDFLARE_VERIFY_PROPAGATION":                     "1",
+				"EXTERNAL_DNS_CLOUDFLARE_VERIFY_TIMEOUT":                         "2m",
 				"EXTERNAL_DNS_CLOUDFLARE_REGION_KEY":                             "us",
+				"EXTERNAL_DNS_CLOUDFLARE_ZONE_TOKEN":                             "example.org=token1\nexample.com=file:/etc/cloudflare/example.com",
+				"EXTERNAL_DNS_CLOUDFLARE_ZONE_FILTER_REGEX":                      "^(team-a|team-b)\\.",
 				"EXTERNAL_DNS_COREDNS_PREFIX":                                    "/coredns/",
 				"EXTERNAL_DNS_AKAMAI_SERVICECONSUMERDOMAIN":                      "oooo-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net",
 				"EXTERNAL_DNS_AKAMAI_CLIENT_TOKEN":                               "o184671d5307a388180fbf7f11dbdf46",
@@ -455,22 +654,45 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_TLS_CLIENT_CERT_KEY":                               "/path/to/key.pem",
 				"EXTERNAL_DNS_ZONE_NAME_FILTER":                                  "yapi.example.org\nyapi.company.com",
 				"EXTERNAL_DNS_ZONE_ID_FILTER":                                    "/hostedzone/ZTST1\n/hostedzone/ZTST2",
+				"EXTERNAL_DNS_PROTECTED_ZONES":                                   "example.net\nexample.info",
 				"EXTERNAL_DNS_AWS_ZONE_TYPE":                                     "private",
 				"EXTERNAL_DNS_AWS_ZONE_TAGS":                                     "tag=foo",
 				"EXTERNAL_DNS_AWS_ZONE_MATCH_PARENT":                             "true",
+				"EXTERNAL_DNS_AWS_VALIDATE_DNSSEC":                               "1",
+				"EXTERNAL_DNS_AWS_MANAGE_TRAFFIC_POLICIES":                       "1",
+				"EXTERNAL_DNS_AWS_ARC_ROUTING_CONTROL_ARN":                       "arn:aws:route53-recovery-control::123456789012:controlpanel/abc/routingcontrol/def",
+				"EXTERNAL_DNS_AWS_ARC_CLUSTER_ENDPOINT":                          "https://a.route53-recovery-cluster.us-west-2.amazonaws.com/v1\nhttps://b.route53-recovery-cluster.eu-west-1.amazonaws.com/v1",
+				"EXTERNAL_DNS_AWS_RECOVERY_READINESS":                            "1",
+				"EXTERNAL_DNS_AWS_RECOVERY_READINESS_CELL_ARN":                   "arn:aws:route53-recovery-readiness::123456789012:cell/us-west-2",
+				"EXTERNAL_DNS_AWS_CREATE_ZONE":                                   "a.example.com\nb.example.com",
+				"EXTERNAL_DNS_AWS_ZONE_AUTO_DELEGATE":                            "1",
+				"EXTERNAL_DNS_AWS_PARENT_ZONE_ID":                                "/hostedzone/Z1234567890",
+				"EXTERNAL_DNS_AWS_DNSSEC_RESOLVER":                               "127.0.0.1:53",
 				"EXTERNAL_DNS_AWS_ASSUME_ROLE":                                   "some-other-role",
 				"EXTERNAL_DNS_AWS_ASSUME_ROLE_EXTERNAL_ID":                       "pg2000",
 				"EXTERNAL_DNS_AWS_BATCH_CHANGE_SIZE":                             "100",
 				"EXTERNAL_DNS_AWS_BATCH_CHANGE_SIZE_BYTES":                       "16000",
 				"EXTERNAL_DNS_AWS_BATCH_CHANGE_SIZE_VALUES":                      "100",
 				"EXTERNAL_DNS_AWS_BATCH_CHANGE_INTERVAL":                         "2s",
+				"EXTERNAL_DNS_AWS_MAX_CHANGES_PER_BATCH":                         "50",
+				"EXTERNAL_DNS_AWS_TRANSACTIONAL_ZONE_UPDATES":                    "1",
 				"EXTERNAL_DNS_AWS_EVALUATE_TARGET_HEALTH":                        "0",
 				"EXTERNAL_DNS_AWS_API_RETRIES":                                   "13",
+				"EXTERNAL_DNS_AWS_EC2_METADATA_V2_ONLY":                          "1",
 				"EXTERNAL_DNS_AWS_PREFER_CNAME":                                  "true",
 				"EXTERNAL_DNS_AWS_PROFILE":                                       "profile1\nprofile2",
 				"EXTERNAL_DNS_AWS_ZONES_CACHE_DURATION":                          "10s",
 				"EXTERNAL_DNS_AWS_SD_SERVICE_CLEANUP":                            "true",
 				"EXTERNAL_DNS_AWS_SD_CREATE_TAG":                                 "key1=value1\nkey2=value2",
+				"EXTERNAL_DNS_AWS_DOMAIN_ROLE":                                   "example.org=arn:aws:iam::123456789012:role/example-org",
+				"EXTERNAL_DNS_AWS_ASSUME_ROLE_SESSION_TAG":                       "team=dns\ncost-center=1234",
+				"EXTERNAL_DNS_AWS_ASSUME_ROLE_TRANSITIVE_TAG_KEY":                "team",
+				"EXTERNAL_DNS_AWS_REQUEST_TAG":                                   "cluster-1",
+				"EXTERNAL_DNS_AWS_ENDPOINT_URL":                                  "http://localhost:4566",
+				"EXTERNAL_DNS_AWS_SSO_START_URL":                                 "https://example.awsapps.com/start",
+				"EXTERNAL_DNS_AWS_SSO_ACCOUNT_ID":                                "123456789012",
+				"EXTERNAL_DNS_AWS_SSO_ROLE_NAME":                                 "external-dns",
+				"EXTERNAL_DNS_AWS_SSO_REGION":                                    "eu-west-1",
 				"EXTERNAL_DNS_DYNAMODB_TABLE":                                    "custom-table",
 				"EXTERNAL_DNS_PIHOLE_API_VERSION":                                "6",
 				"EXTERNAL_DNS_POLICY":                                            "upsert-only",
@@ -479,28 +701,52 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_TXT_PREFIX":                                        "associated-txt-record",
 				"EXTERNAL_DNS_TXT_CACHE_INTERVAL":                                "12h",
 				"EXTERNAL_DNS_TXT_NEW_FORMAT_ONLY":                               "1",
+				"EXTERNAL_DNS_TRANSFER_OWNERSHIP_FROM":                           "previous",
 				"EXTERNAL_DNS_INTERVAL":                                          "10m",
 				"EXTERNAL_DNS_MIN_EVENT_SYNC_INTERVAL":                           "50s",
+				"EXTERNAL_DNS_INTERVAL_JITTER_FACTOR":                            "0.2",
+				"EXTERNAL_DNS_FINGERPRINT_MAX_AGE":                               "15m",
+				"EXTERNAL_DNS_RECONCILE_TIMEOUT":                                 "5m",
+				"EXTERNAL_DNS_FORCE_FULL_SYNC_AFTER_ERRORS":                      "5",
 				"EXTERNAL_DNS_ONCE":                                              "1",
 				"EXTERNAL_DNS_DRY_RUN":                                           "1",
 				"EXTERNAL_DNS_EVENTS":                                            "1",
 				"EXTERNAL_DNS_LOG_FORMAT":                                        "json",
 				"EXTERNAL_DNS_METRICS_ADDRESS":                                   "127.0.0.1:9099",
+				"EXTERNAL_DNS_HEALTH_LISTEN_ADDRESS":                             "127.0.0.1:9098",
+				"EXTERNAL_DNS_METRICS_PATH":                                      "/external-dns/metrics",
+				"EXTERNAL_DNS_ENABLE_PPROF":                                      "1",
+				"EXTERNAL_DNS_PPROF_LISTEN_ADDRESS":                              "127.0.0.1:6061",
 				"EXTERNAL_DNS_LOG_LEVEL":                                         "debug",
 				"EXTERNAL_DNS_CONNECTOR_SOURCE_SERVER":                           "localhost:8081",
+				"EXTERNAL_DNS_WEBHOOK_SOURCE_URL":                                "http://localhost:8082/endpoints",
+				"EXTERNAL_DNS_SOURCE_FILE":                                       "/etc/external-dns/endpoints.yaml",
+				"EXTERNAL_DNS_PROVIDER_CONFIG_FILE":                              "/etc/kubernetes/azure.json",
+				"EXTERNAL_DNS_PROVIDER_READ_ONLY":                                "1",
+				"EXTERNAL_DNS_FEDERATION_KUBECONFIG":                             "/path/to/cluster-a,/path/to/cluster-b",
+				"EXTERNAL_DNS_FEDERATION_SOURCE":                                 "ingress",
 				"EXTERNAL_DNS_EXOSCALE_APIENV":                                   "api1",
 				"EXTERNAL_DNS_EXOSCALE_APIZONE":                                  "zone1",
 				"EXTERNAL_DNS_EXOSCALE_APIKEY":                                   "1",
 				"EXTERNAL_DNS_EXOSCALE_APISECRET":                                "2",
 				"EXTERNAL_DNS_CRD_SOURCE_APIVERSION":                             "test.k8s.io/v1alpha1",
 				"EXTERNAL_DNS_CRD_SOURCE_KIND":                                   "Endpoint",
+				"EXTERNAL_DNS_CRD_SOURCE_FINALIZER":                              "1",
 				"EXTERNAL_DNS_NS1_ENDPOINT":                                      "https://api.example.com/v1",
 				"EXTERNAL_DNS_NS1_IGNORESSL":                                     "1",
 				"EXTERNAL_DNS_TRANSIP_ACCOUNT":                                   "transip",
 				"EXTERNAL_DNS_TRANSIP_KEYFILE":                                   "/path/to/transip.key",
+				"EXTERNAL_DNS_INWX_USERNAME":                                     "inwx-user",
+				"EXTERNAL_DNS_INWX_PASSWORD":                                     "inwx-pass",
+				"EXTERNAL_DNS_INWX_OTP_SECRET":                                   "GEZDGNBVGY3TQOJQ",
+				"EXTERNAL_DNS_INWX_SANDBOX":                                      "1",
 				"EXTERNAL_DNS_DIGITALOCEAN_API_PAGE_SIZE":                        "100",
 				"EXTERNAL_DNS_MANAGED_RECORD_TYPES":                              "A\nAAAA\nCNAME\nNS",
 				"EXTERNAL_DNS_EXCLUDE_UNSCHEDULABLE":                             "false",
+				"EXTERNAL_DNS_ENDPOINT_TRANSFORM_WEBHOOK":                        "http://localhost:8080/transform",
+				"EXTERNAL_DNS_ENDPOINT_TRANSFORM_WEBHOOK_TIMEOUT":                "10s",
+				"EXTERNAL_DNS_PROVIDER_PLUGIN_URL":                               "localhost:8889",
+				"EXTERNAL_DNS_PROVIDER_PLUGIN_TIMEOUT":                           "20s",
 				"EXTERNAL_DNS_RFC2136_BATCH_CHANGE_SIZE":                         "100",
 				"EXTERNAL_DNS_RFC2136_LOAD_BALANCING_STRATEGY":                   "round-robin",
 				"EXTERNAL_DNS_RFC2136_HOST":                                      "rfc2136-host1\nrfc2136-host2",
@@ -519,6 +765,169 @@ func TestParseFlags(t *testing.T) {
 	}
 }
 
+func TestParseFlagsDefaultEnvars(t *testing.T) {
+	app := App(NewConfig())
+	// the environment variables of the flags are set on parsing
+	_, err := app.ParseContext(nil)
+	require.NoError(t, err)
+
+	for _, flag := range app.Model().Flags {
+		expected := "EXTERNAL_DNS_" + strings.ToUpper(strings.ReplaceAll(flag.Name, "-", "_"))
+		assert.Equal(t, expected, flag.Envar, "flag --%s", flag.Name)
+	}
+}
+
+func TestParseFlagsEnvars(t *testing.T) {
+	for _, tt := range []struct {
+		envar    string
+		value    string
+		field    func(cfg *Config) any
+		expected any
+	}{
+		{
+			envar:    "EXTERNAL_DNS_PROVIDER",
+			value:    "cloudflare",
+			field:    func(cfg *Config) any { return cfg.Provider },
+			expected: "cloudflare",
+		},
+		{
+			envar:    "EXTERNAL_DNS_SOURCE",
+			value:    "service\ningress",
+			field:    func(cfg *Config) any { return cfg.Sources },
+			expected: []string{"service", "ingress"},
+		},
+		{
+			envar:    "EXTERNAL_DNS_DOMAIN_FILTER",
+			value:    "example.com\nexample.org",
+			field:    func(cfg *Config) any { return cfg.DomainFilter },
+			expected: []string{"example.com", "example.org"},
+		},
+		{
+			envar:    "EXTERNAL_DNS_ANNOTATION_FILTER",
+			value:    "kubernetes.io/ingress.class=public",
+			field:    func(cfg *Config) any { return cfg.AnnotationFilter },
+			expected: "kubernetes.io/ingress.class=public",
+		},
+		{
+			envar:    "EXTERNAL_DNS_LABEL_FILTER",
+			value:    "team=dns",
+			field:    func(cfg *Config) any { return cfg.LabelFilter },
+			expected: "team=dns",
+		},
+		{
+			envar:    "EXTERNAL_DNS_POLICY",
+			value:    "upsert-only",
+			field:    func(cfg *Config) any { return cfg.Policy },
+			expected: "upsert-only",
+		},
+		{
+			envar:    "EXTERNAL_DNS_REGISTRY",
+			value:    "noop",
+			field:    func(cfg *Config) any { return cfg.Registry },
+			expected: "noop",
+		},
+		{
+			envar:    "EXTERNAL_DNS_TXT_OWNER_ID",
+			value:    "my-cluster",
+			field:    func(cfg *Config) any { return cfg.TXTOwnerID },
+			expected: "my-cluster",
+		},
+		{
+			envar:    "EXTERNAL_DNS_TXT_SUFFIX",
+			value:    "-owner",
+			field:    func(cfg *Config) any { return cfg.TXTSuffix },
+			expected: "-owner",
+		},
+		{
+			envar:    "EXTERNAL_DNS_INTERVAL",
+			value:    "5m",
+			field:    func(cfg *Config) any { return cfg.Interval },
+			expected: 5 * time.Minute,
+		},
+		{
+			envar:    "EXTERNAL_DNS_LOG_LEVEL",
+			value:    "debug",
+			field:    func(cfg *Config) any { return cfg.LogLevel },
+			expected: "debug",
+		},
+		{
+			envar:    "EXTERNAL_DNS_DRY_RUN",
+			value:    "true",
+			field:    func(cfg *Config) any { return cfg.DryRun },
+			expected: true,
+		},
+		{
+			envar:    "EXTERNAL_DNS_ONCE",
+			value:    "1",
+			field:    func(cfg *Config) any { return cfg.Once },
+			expected: true,
+		},
+		{
+			envar:    "EXTERNAL_DNS_EXCLUDE_UNSCHEDULABLE",
+			value:    "false",
+			field:    func(cfg *Config) any { return cfg.ExcludeUnschedulable },
+			expected: false,
+		},
+		{
+			envar:    "EXTERNAL_DNS_EMIT_EVENTS",
+			value:    "true",
+			field:    func(cfg *Config) any { return cfg.EmitEvents },
+			expected: true,
+		},
+		{
+			envar:    "EXTERNAL_DNS_MANAGED_RECORD_TYPES",
+			value:    "A\nMX",
+			field:    func(cfg *Config) any { return cfg.ManagedDNSRecordTypes },
+			expected: []string{"A", "MX"},
+		},
+		{
+			envar:    "EXTERNAL_DNS_WEBHOOK_PROVIDER_URL",
+			value:    "http://localhost:9999",
+			field:    func(cfg *Config) any { return cfg.WebhookProviderURL },
+			expected: "http://localhost:9999",
+		},
+		{
+			envar:    "EXTERNAL_DNS_GODADDY_API_TTL",
+			value:    "1200",
+			field:    func(cfg *Config) any { return cfg.GoDaddyTTL },
+			expected: int64(1200),
+		},
+		{
+			envar:    "EXTERNAL_DNS_RFC2136_MIN_TTL",
+			value:    "30s",
+			field:    func(cfg *Config) any { return cfg.RFC2136MinTTL },
+			expected: 30 * time.Second,
+		},
+		{
+			envar:    "EXTERNAL_DNS_CONFIG_FROM_CONFIGMAP",
+			value:    "external-dns/settings",
+			field:    func(cfg *Config) any { return cfg.ConfigFromConfigMap },
+			expected: "external-dns/settings",
+		},
+	} {
+		t.Run(tt.envar, func(t *testing.T) {
+			// the required flags, unless they're the tested ones
+			t.Setenv("EXTERNAL_DNS_PROVIDER", "aws")
+			t.Setenv("EXTERNAL_DNS_SOURCE", "service")
+			t.Setenv(tt.envar, tt.value)
+
+			cfg := NewConfig()
+			require.NoError(t, cfg.ParseFlags(nil))
+			assert.Equal(t, tt.expected, tt.field(cfg))
+		})
+	}
+}
+
+func TestParseFlagsEnvarsOverriddenByFlags(t *testing.T) {
+	t.Setenv("EXTERNAL_DNS_PROVIDER", "aws")
+	t.Setenv("EXTERNAL_DNS_TXT_OWNER_ID", "my-cluster")
+
+	cfg := NewConfig()
+	require.NoError(t, cfg.ParseFlags([]string{"--source=service", "--txt-owner-id=other-cluster"}))
+	assert.Equal(t, "aws", cfg.Provider)
+	assert.Equal(t, "other-cluster", cfg.TXTOwnerID)
+}
+
 // helper functions
 
 func setEnv(t *testing.T, env map[string]string) map[string]string {
@@ -540,12 +949,88 @@ func restoreEnv(t *testing.T, originalEnv map[string]string) {
 
 func TestPasswordsNotLogged(t *testing.T) {
 	cfg := Config{
-		PDNSAPIKey:        "pdns-api-key",
-		RFC2136TSIGSecret: "tsig-secret",
+		PDNSAPIKey:           "pdns-api-key",
+		RFC2136TSIGSecret:    "tsig-secret",
+		CloudflareZoneTokens: map[string]string{"example.org": "zone-token"},
 	}
 
 	s := cfg.String()
 
 	assert.NotContains(t, s, "pdns-api-key")
 	assert.NotContains(t, s, "tsig-secret")
+	assert.NotContains(t, s, "zone-token")
+	assert.Contains(t, s, "example.org")
+	assert.Equal(t, "zone-token", cfg.CloudflareZoneTokens["example.org"], "the configuration must not be masked")
+}
+
+func TestSplitCommaSeparated(t *testing.T) {
+	assert.Equal(t, []string{"aws", "metallb", "nginx"}, SplitCommaSeparated([]string{"aws, metallb", "nginx,"}, false))
+	assert.Equal(t, []string{"aws", "", "metallb", ""}, SplitCommaSeparated([]string{"aws,", "metallb", ""}, true))
+	assert.Nil(t, SplitCommaSeparated([]string{""}, false))
+	assert.Nil(t, SplitCommaSeparated(nil, true))
+}
+
+func TestParseFlagsExcludeRecordTypes(t *testing.T) {
+	for _, tt := range []struct {
+		title    string
+		args     []string
+		expected []string
+	}{
+		{
+			title: "default",
+		},
+		{
+			title:    "repeated flag",
+			args:     []string{"--exclude-record-types=NS", "--exclude-record-types=SOA"},
+			expected: []string{"NS", "SOA"},
+		},
+		{
+			title:    "comma separated list",
+			args:     []string{"--exclude-record-types=ns, SOA,", "--exclude-record-types=TXT"},
+			expected: []string{"NS", "SOA", "TXT"},
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			cfg := NewConfig()
+			require.NoError(t, cfg.ParseFlags(append([]string{"--provider=aws", "--source=service"}, tt.args...)))
+			assert.Equal(t, tt.expected, cfg.ExcludeDNSRecordTypes)
+		})
+	}
+}
+
+func TestParseFlagsManagedRecordTypes(t *testing.T) {
+	for _, tt := range []struct {
+		title    string
+		args     []string
+		expected []string
+	}{
+		{
+			title:    "default",
+			expected: []string{"A", "AAAA", "CNAME"},
+		},
+		{
+			title:    "repeated flag",
+			args:     []string{"--managed-record-types=A", "--managed-record-types=TXT"},
+			expected: []string{"A", "TXT"},
+		},
+		{
+			title:    "comma separated list",
+			args:     []string{"--managed-record-types=a,AAAA, CNAME", "--managed-record-types=MX"},
+			expected: []string{"A", "AAAA", "CNAME", "MX"},
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			cfg := NewConfig()
+			require.NoError(t, cfg.ParseFlags(append([]string{"--provider=aws", "--source=service"}, tt.args...)))
+			assert.Equal(t, tt.expected, cfg.ManagedDNSRecordTypes)
+		})
+	}
+}
+
+func TestParseFlagsMultipleProviders(t *testing.T) {
+	cfg := NewConfig()
+	require.NoError(t, cfg.ParseFlags([]string{"--provider=aws", "--provider=google", "--source=service"}))
+	assert.Equal(t, "aws", cfg.Provider)
+	assert.Equal(t, []string{"aws", "google"}, cfg.Providers)
+	assert.Equal(t, []string{"aws", "google"}, cfg.ProviderNames())
 }
@@ -19,6 +19,9 @@ package validation
 import (
 	"errors"
 	"fmt"
+	"net/url"
+	"slices"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/labels"
 
@@ -33,8 +36,10 @@ func ValidateConfig(cfg *externaldns.Config) error {
 		return err
 	}
 
-	if err := validateConfigForProvider(cfg); err != nil {
-		return err
+	for _, name := range cfg.ProviderNames() {
+		if err := validateConfigForProvider(cfg, name); err != nil {
+			return err
+		}
 	}
 
 	if cfg.IgnoreHostnameAnnotation && cfg.FQDNTemplate == "" {
@@ -45,6 +50,40 @@ func ValidateConfig(cfg *externaldns.Config) error {
 		return errors.New("txt-prefix and txt-suffix are mutual exclusive")
 	}
 
+	if cfg.KubeSkipTLSVerify && cfg.KubeCAFile != "" {
+		return errors.New("--kube-skip-tls-verify and --kube-ca-file are mutually exclusive")
+	}
+
+	if len(cfg.KubeImpersonateGroups) > 0 && cfg.KubeImpersonateUser == "" {
+		return errors.New("--kube-impersonate-user is required when specifying --kube-impersonate-group option")
+	}
+
+	if cfg.IntervalJitterFactor < 0 || cfg.IntervalJitterFactor > 1 {
+		return errors.New("--interval-jitter-factor must be between 0 and 1")
+	}
+
+	if slices.Contains(cfg.Sources, "stdin") && !cfg.Once {
+		return errors.New("--once is required when using the stdin source, as the standard input can only be read once")
+	}
+
+	if cfg.ConfigFromConfigMap != "" {
+		if namespace, name, ok := strings.Cut(cfg.ConfigFromConfigMap, "/"); !ok || namespace == "" || name == "" {
+			return errors.New("--config-from-configmap must be given as namespace/name")
+		}
+	}
+
+	if cfg.MetricsPath != "" && (!strings.HasPrefix(cfg.MetricsPath, "/") || cfg.MetricsPath == "/healthz" || cfg.MetricsPath == "/readyz") {
+		return errors.New("--metrics-path must be an absolute path other than /healthz and /readyz")
+	}
+
+	if cfg.TransferOwnershipFrom != "" && cfg.TransferOwnershipFrom == cfg.TXTOwnerID {
+		return errors.New("--transfer-ownership-from must be another owner id than --txt-owner-id")
+	}
+
+	if cfg.ProviderReadOnly && cfg.Registry == "dynamodb" {
+		return errors.New("--provider-read-only cannot be used with --registry=dynamodb, as the DynamoDB registry writes its table directly")
+	}
+
 	_, err := labels.Parse(cfg.LabelFilter)
 	if err != nil {
 		return errors.New("--label-filter does not specify a valid label selector")
@@ -59,20 +98,28 @@ func preValidateConfig(cfg *externaldns.Config) error {
 	if len(cfg.Sources) == 0 {
 		return errors.New("no sources specified")
 	}
-	if cfg.Provider == "" {
+	if len(cfg.ProviderNames()) == 0 {
 		return errors.New("no provider specified")
 	}
 	return nil
 }
 
-func validateConfigForProvider(cfg *externaldns.Config) error {
-	switch cfg.Provider {
+func validateConfigForProvider(cfg *externaldns.Config, name string) error {
+	switch name {
 	case "azure":
 		return validateConfigForAzure(cfg)
 	case "akamai":
 		return validateConfigForAkamai(cfg)
 	case "rfc2136":
 		return validateConfigForRfc2136(cfg)
+	case "aws":
+		return validateConfigForAWS(cfg)
+	case "plugin":
+		return validateConfigForPlugin(cfg)
+	case "google":
+		return validateConfigForGoogle(cfg)
+	case "inwx":
+		return validateConfigForInwx(cfg)
 	default:
 		return nil
 	}
@@ -101,6 +148,69 @@ func validateConfigForAkamai(cfg *externaldns.Config) error {
 	return nil
 }
 
+func validateConfigForAWS(cfg *externaldns.Config) error {
+	if cfg.AWSManageResolverRules && cfg.AWSResolverRuleName == "" {
+		return errors.New("--aws-resolver-rule-name is required when specifying --aws-manage-resolver-rules option")
+	}
+	if cfg.AWSARCRoutingControlARN != "" && len(cfg.AWSARCClusterEndpoints) == 0 {
+		return errors.New("--aws-arc-cluster-endpoint is required when specifying --aws-arc-routing-control-arn option")
+	}
+	if len(cfg.AWSRecoveryReadinessCellARNs) > 0 && !cfg.AWSRecoveryReadiness {
+		return errors.New("--aws-recovery-readiness is required when specifying --aws-recovery-readiness-cell-arn option")
+	}
+	if len(cfg.AWSDomainRoles) > 0 && (cfg.AWSAssumeRole != "" || slices.ContainsFunc(cfg.AWSProfiles, func(profile string) bool { return profile != "" })) {
+		return errors.New("--aws-domain-role is mutually exclusive with --aws-assume-role and --aws-profile")
+	}
+	if len(cfg.AWSAssumeRoleSessionTags) > 0 && cfg.AWSAssumeRole == "" && len(cfg.AWSDomainRoles) == 0 {
+		return errors.New("--aws-assume-role or --aws-domain-role is required when specifying --aws-assume-role-session-tag option")
+	}
+	for _, key := range cfg.AWSAssumeRoleTransitiveTagKeys {
+		if _, ok := cfg.AWSAssumeRoleSessionTags[key]; !ok {
+			return fmt.Errorf("--aws-assume-role-transitive-tag-key %s is not the key of a session tag", key)
+		}
+	}
+	if cfg.AWSEndpointURL != "" {
+		if u, err := url.Parse(cfg.AWSEndpointURL); err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("--aws-endpoint-url %s is not an absolute URL", cfg.AWSEndpointURL)
+		}
+	}
+	if (cfg.AWSSSOStartURL != "" || cfg.AWSSSOAccountID != "" || cfg.AWSSSORoleName != "") &&
+		(cfg.AWSSSOStartURL == "" || cfg.AWSSSOAccountID == "" || cfg.AWSSSORoleName == "") {
+		return errors.New("--aws-sso-start-url, --aws-sso-account-id and --aws-sso-role-name must be specified together")
+	}
+	if cfg.AWSMaxChangesPerBatch < 0 {
+		return errors.New("--aws-max-changes-per-batch must not be negative")
+	}
+	if cfg.AWSTransactionalZoneUpdates && cfg.AWSMaxChangesPerBatch > 0 {
+		return errors.New("--aws-transactional-zone-updates and --aws-max-changes-per-batch are mutually exclusive")
+	}
+	return nil
+}
+
+func validateConfigForGoogle(cfg *externaldns.Config) error {
+	if len(cfg.GoogleForwardingZones) > 0 && len(cfg.GoogleForwardingTargets) == 0 {
+		return errors.New("--google-forwarding-target is required when specifying --google-forwarding-zone option")
+	}
+	if len(cfg.GoogleForwardingZones) > 0 && cfg.GoogleResponsePolicy != "" {
+		return errors.New("--google-forwarding-zone and --google-response-policy are mutually exclusive")
+	}
+	return nil
+}
+
+func validateConfigForInwx(cfg *externaldns.Config) error {
+	if cfg.InwxUsername == "" || cfg.InwxPassword == "" {
+		return errors.New("--inwx-username and --inwx-password are required when using the INWX provider")
+	}
+	return nil
+}
+
+func validateConfigForPlugin(cfg *externaldns.Config) error {
+	if cfg.ProviderPluginURL == "" {
+		return errors.New("--provider-plugin-url is required when using the plugin provider")
+	}
+	return nil
+}
+
 func validateConfigForRfc2136(cfg *externaldns.Config) error {
 	if cfg.RFC2136MinTTL < 0 {
 		return errors.New("TTL specified for rfc2136 is negative")
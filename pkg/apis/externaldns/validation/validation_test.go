@@ -137,6 +137,305 @@ func TestValidateGoodRfc2136Config(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestValidateAWSResolverRuleConfig(t *testing.T) {
+	cfg := externaldns.NewConfig()
+
+	cfg.LogFormat = "json"
+	cfg.Sources = []string{"test-source"}
+	cfg.Provider = "aws"
+	cfg.AWSManageResolverRules = true
+
+	assert.Error(t, ValidateConfig(cfg))
+
+	cfg.AWSResolverRuleName = "on-prem"
+
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateGoogleForwardingConfig(t *testing.T) {
+	cfg := externaldns.NewConfig()
+
+	cfg.LogFormat = "json"
+	cfg.Sources = []string{"test-source"}
+	cfg.Provider = "google"
+	cfg.GoogleForwardingZones = []string{"corp.example.com"}
+
+	assert.Error(t, ValidateConfig(cfg))
+
+	cfg.GoogleForwardingTargets = []string{"10.0.0.53"}
+
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.GoogleResponsePolicy = "cluster-policy"
+
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestValidateAWSARCConfig(t *testing.T) {
+	cfg := externaldns.NewConfig()
+
+	cfg.LogFormat = "json"
+	cfg.Sources = []string{"test-source"}
+	cfg.Provider = "aws"
+	cfg.AWSARCRoutingControlARN = "arn:aws:route53-recovery-control::123456789012:controlpanel/abc/routingcontrol/def"
+
+	assert.Error(t, ValidateConfig(cfg))
+
+	cfg.AWSARCClusterEndpoints = []string{"https://a.route53-recovery-cluster.us-west-2.amazonaws.com/v1"}
+
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateAWSRecoveryReadinessConfig(t *testing.T) {
+	cfg := externaldns.NewConfig()
+
+	cfg.LogFormat = "json"
+	cfg.Sources = []string{"test-source"}
+	cfg.Provider = "aws"
+	cfg.AWSRecoveryReadinessCellARNs = []string{"arn:aws:route53-recovery-readiness::123456789012:cell/us-west-2"}
+
+	assert.EqualError(t, ValidateConfig(cfg), "--aws-recovery-readiness is required when specifying --aws-recovery-readiness-cell-arn option")
+
+	cfg.AWSRecoveryReadiness = true
+
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateAWSDomainRolesConfig(t *testing.T) {
+	cfg := externaldns.NewConfig()
+
+	cfg.LogFormat = "json"
+	cfg.Sources = []string{"test-source"}
+	cfg.Provider = "aws"
+	cfg.AWSDomainRoles = map[string]string{"example.com": "arn:aws:iam::123456789012:role/external-dns"}
+	cfg.AWSProfiles = []string{""}
+
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.AWSAssumeRole = "arn:aws:iam::123456789012:role/other"
+
+	assert.EqualError(t, ValidateConfig(cfg), "--aws-domain-role is mutually exclusive with --aws-assume-role and --aws-profile")
+
+	cfg.AWSAssumeRole = ""
+	cfg.AWSProfiles = []string{"profile1"}
+
+	assert.Error(t, ValidateConfig(cfg))
+}
+
+func TestValidateAWSAssumeRoleSessionTags(t *testing.T) {
+	cfg := externaldns.NewConfig()
+
+	cfg.LogFormat = "json"
+	cfg.Sources = []string{"test-source"}
+	cfg.Provider = "aws"
+	cfg.AWSAssumeRoleSessionTags = map[string]string{"team": "dns"}
+
+	assert.EqualError(t, ValidateConfig(cfg), "--aws-assume-role or --aws-domain-role is required when specifying --aws-assume-role-session-tag option")
+
+	cfg.AWSAssumeRole = "arn:aws:iam::123456789012:role/external-dns"
+	cfg.AWSAssumeRoleTransitiveTagKeys = []string{"team", "project"}
+
+	assert.EqualError(t, ValidateConfig(cfg), "--aws-assume-role-transitive-tag-key project is not the key of a session tag")
+
+	cfg.AWSAssumeRoleTransitiveTagKeys = []string{"team"}
+
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateAWSEndpointURL(t *testing.T) {
+	cfg := externaldns.NewConfig()
+
+	cfg.LogFormat = "json"
+	cfg.Sources = []string{"test-source"}
+	cfg.Provider = "aws"
+	cfg.AWSEndpointURL = "localhost:4566"
+
+	assert.EqualError(t, ValidateConfig(cfg), "--aws-endpoint-url localhost:4566 is not an absolute URL")
+
+	cfg.AWSEndpointURL = "http://localhost:4566"
+
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateAWSSSO(t *testing.T) {
+	cfg := externaldns.NewConfig()
+
+	cfg.LogFormat = "json"
+	cfg.Sources = []string{"test-source"}
+	cfg.Provider = "aws"
+	cfg.AWSSSOStartURL = "https://example.awsapps.com/start"
+	cfg.AWSSSOAccountID = "123456789012"
+
+	assert.EqualError(t, ValidateConfig(cfg), "--aws-sso-start-url, --aws-sso-account-id and --aws-sso-role-name must be specified together")
+
+	cfg.AWSSSORoleName = "external-dns"
+
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateAWSMaxChangesPerBatch(t *testing.T) {
+	cfg := externaldns.NewConfig()
+
+	cfg.LogFormat = "json"
+	cfg.Sources = []string{"test-source"}
+	cfg.Provider = "aws"
+	cfg.AWSMaxChangesPerBatch = -1
+
+	assert.Error(t, ValidateConfig(cfg))
+
+	cfg.AWSMaxChangesPerBatch = 10
+
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.AWSTransactionalZoneUpdates = true
+
+	assert.EqualError(t, ValidateConfig(cfg), "--aws-transactional-zone-updates and --aws-max-changes-per-batch are mutually exclusive")
+}
+
+func TestValidateKubeTLSConfig(t *testing.T) {
+	cfg := externaldns.NewConfig()
+
+	cfg.LogFormat = "json"
+	cfg.Sources = []string{"test-source"}
+	cfg.Provider = "test-provider"
+	cfg.KubeSkipTLSVerify = true
+	cfg.KubeCAFile = "/some/ca.crt"
+
+	assert.Error(t, ValidateConfig(cfg))
+
+	cfg.KubeSkipTLSVerify = false
+
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateKubeImpersonationConfig(t *testing.T) {
+	cfg := externaldns.NewConfig()
+
+	cfg.LogFormat = "json"
+	cfg.Sources = []string{"test-source"}
+	cfg.Provider = "test-provider"
+	cfg.KubeImpersonateGroups = []string{"dns-admins"}
+
+	assert.Error(t, ValidateConfig(cfg))
+
+	cfg.KubeImpersonateUser = "external-dns"
+
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateStdinSourceConfig(t *testing.T) {
+	cfg := externaldns.NewConfig()
+
+	cfg.LogFormat = "json"
+	cfg.Sources = []string{"stdin"}
+	cfg.Provider = "test-provider"
+
+	assert.EqualError(t, ValidateConfig(cfg), "--once is required when using the stdin source, as the standard input can only be read once")
+
+	cfg.Once = true
+
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateIntervalJitterFactor(t *testing.T) {
+	cfg := externaldns.NewConfig()
+
+	cfg.LogFormat = "json"
+	cfg.Sources = []string{"test-source"}
+	cfg.Provider = "test-provider"
+
+	for _, factor := range []float64{-0.1, 1.5} {
+		cfg.IntervalJitterFactor = factor
+		assert.EqualError(t, ValidateConfig(cfg), "--interval-jitter-factor must be between 0 and 1")
+	}
+
+	for _, factor := range []float64{0, 0.5, 1} {
+		cfg.IntervalJitterFactor = factor
+		assert.NoError(t, ValidateConfig(cfg))
+	}
+}
+
+func TestValidateConfigFromConfigMap(t *testing.T) {
+	cfg := externaldns.NewConfig()
+
+	cfg.LogFormat = "json"
+	cfg.Sources = []string{"test-source"}
+	cfg.Provider = "test-provider"
+
+	for _, ref := range []string{"external-dns", "/external-dns", "default/"} {
+		cfg.ConfigFromConfigMap = ref
+		assert.EqualError(t, ValidateConfig(cfg), "--config-from-configmap must be given as namespace/name")
+	}
+
+	cfg.ConfigFromConfigMap = "default/external-dns"
+
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateMetricsPath(t *testing.T) {
+	cfg := newValidConfig(t)
+
+	for _, path := range []string{"metrics", "/healthz", "/readyz"} {
+		cfg.MetricsPath = path
+		assert.EqualError(t, ValidateConfig(cfg), "--metrics-path must be an absolute path other than /healthz and /readyz")
+	}
+
+	cfg.MetricsPath = "/external-dns/metrics"
+
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateProviderReadOnly(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.ProviderReadOnly = true
+
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.Registry = "dynamodb"
+
+	assert.EqualError(t, ValidateConfig(cfg), "--provider-read-only cannot be used with --registry=dynamodb, as the DynamoDB registry writes its table directly")
+}
+
+func TestValidateTransferOwnershipFrom(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.TXTOwnerID = "current"
+	cfg.TransferOwnershipFrom = "previous"
+
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.TransferOwnershipFrom = "current"
+
+	assert.EqualError(t, ValidateConfig(cfg), "--transfer-ownership-from must be another owner id than --txt-owner-id")
+}
+
+func TestValidateMultipleProvidersConfig(t *testing.T) {
+	cfg := externaldns.NewConfig()
+
+	cfg.LogFormat = "json"
+	cfg.Sources = []string{"test-source"}
+	cfg.Providers = []string{"google", "plugin"}
+
+	assert.Error(t, ValidateConfig(cfg), "the configuration of every provider must be validated")
+
+	cfg.ProviderPluginURL = "localhost:8889"
+
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidatePluginConfig(t *testing.T) {
+	cfg := externaldns.NewConfig()
+
+	cfg.LogFormat = "json"
+	cfg.Sources = []string{"test-source"}
+	cfg.Provider = "plugin"
+
+	assert.Error(t, ValidateConfig(cfg))
+
+	cfg.ProviderPluginURL = "localhost:8889"
+
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
 func TestValidateBadRfc2136GssTsigConfig(t *testing.T) {
 	invalidRfc2136GssTsigConfigs := []*externaldns.Config{
 		{
@@ -351,3 +650,17 @@ func TestValidateGoodAzureConfig(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func TestValidateInwxConfig(t *testing.T) {
+	cfg := externaldns.NewConfig()
+
+	cfg.LogFormat = "json"
+	cfg.Sources = []string{"test-source"}
+	cfg.Provider = "inwx"
+	cfg.InwxUsername = "user"
+
+	assert.EqualError(t, ValidateConfig(cfg), "--inwx-username and --inwx-password are required when using the INWX provider")
+
+	cfg.InwxPassword = "pass"
+	assert.NoError(t, ValidateConfig(cfg))
+}
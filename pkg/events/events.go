@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+const (
+	// ComponentName is reported as the source of the emitted events.
+	ComponentName = "external-dns"
+
+	ReasonRecordCreated = "RecordCreated"
+	ReasonRecordUpdated = "RecordUpdated"
+	ReasonRecordDeleted = "RecordDeleted"
+	ReasonRecordError   = "RecordError"
+)
+
+// kinds maps the resource label prefix set by the sources to the kind of the source object.
+var kinds = map[string]struct{ kind, apiVersion string }{
+	"service":            {kind: "Service", apiVersion: "v1"},
+	"ingress":            {kind: "Ingress", apiVersion: "networking.k8s.io/v1"},
+	"crd":                {kind: "DNSEndpoint", apiVersion: "externaldns.k8s.io/v1alpha1"},
+	"dnsrecord":          {kind: "DNSRecord", apiVersion: "externaldns.io/v1alpha1"},
+	"argocd-application": {kind: "Application", apiVersion: "argoproj.io/v1alpha1"},
+	"flux-helmrelease":   {kind: "HelmRelease", apiVersion: "helm.toolkit.fluxcd.io/v2beta1"},
+}
+
+// Emitter emits Kubernetes events on the source objects of the applied changes.
+type Emitter struct {
+	recorder record.EventRecorder
+	provider string
+}
+
+// NewEmitter returns an Emitter recording events with the given recorder.
+// The provider name is included in the message of every event.
+func NewEmitter(recorder record.EventRecorder, provider string) *Emitter {
+	return &Emitter{
+		recorder: recorder,
+		provider: provider,
+	}
+}
+
+// NewEventRecorder returns an event recorder which sends the events to the Kubernetes API.
+func NewEventRecorder(client kubernetes.Interface) record.EventRecorder {
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: client.CoreV1().Events("")})
+	return broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: ComponentName})
+}
+
+// Emit emits an event for every change on the object it originates from.
+// If err is not nil the changes are reported as failed with Warning events.
+func (e *Emitter) Emit(changes *plan.Changes, err error) {
+	for _, ep := range changes.Create {
+		if err != nil {
+			e.warn(ep, "create", err)
+			continue
+		}
+		e.event(ep, corev1.EventTypeNormal, ReasonRecordCreated, "Created %s record %s with targets %s (provider: %s)", ep.RecordType, ep.DNSName, ep.Targets, e.provider)
+	}
+
+	old := make(map[endpoint.EndpointKey]*endpoint.Endpoint, len(changes.UpdateOld))
+	for _, ep := range changes.UpdateOld {
+		old[ep.Key()] = ep
+	}
+	for _, ep := range changes.UpdateNew {
+		if err != nil {
+			e.warn(ep, "update", err)
+			continue
+		}
+		var oldTargets endpoint.Targets
+		if o, ok := old[ep.Key()]; ok {
+			oldTargets = o.Targets
+		}
+		e.event(ep, corev1.EventTypeNormal, ReasonRecordUpdated, "Updated %s record %s targets from %s to %s (provider: %s)", ep.RecordType, ep.DNSName, oldTargets, ep.Targets, e.provider)
+	}
+
+	for _, ep := range changes.Delete {
+		if err != nil {
+			e.warn(ep, "delete", err)
+			continue
+		}
+		e.event(ep, corev1.EventTypeNormal, ReasonRecordDeleted, "Deleted %s record %s with targets %s (provider: %s)", ep.RecordType, ep.DNSName, ep.Targets, e.provider)
+	}
+}
+
+func (e *Emitter) warn(ep *endpoint.Endpoint, action string, err error) {
+	e.event(ep, corev1.EventTypeWarning, ReasonRecordError, "Failed to %s %s record %s with targets %s (provider: %s): %v", action, ep.RecordType, ep.DNSName, ep.Targets, e.provider, err)
+}
+
+func (e *Emitter) event(ep *endpoint.Endpoint, eventType, reason, messageFmt string, args ...any) {
+	ref := objectReference(ep)
+	if ref == nil {
+		log.Debugf("Not emitting %s event for %s, unsupported resource %q", reason, ep.DNSName, ep.Labels[endpoint.ResourceLabelKey])
+		return
+	}
+	e.recorder.Eventf(ref, eventType, reason, messageFmt, args...)
+}
+
+// objectReference returns a reference to the object the endpoint originates from,
+// based on the resource label in the form of <kind>/<namespace>/<name>.
+func objectReference(ep *endpoint.Endpoint) *corev1.ObjectReference {
+	parts := strings.Split(ep.Labels[endpoint.ResourceLabelKey], "/")
+	if len(parts) != 3 {
+		return nil
+	}
+	k, ok := kinds[parts[0]]
+	if !ok {
+		return nil
+	}
+	return &corev1.ObjectReference{
+		Kind:       k.kind,
+		APIVersion: k.apiVersion,
+		Namespace:  parts[1],
+		Name:       parts[2],
+	}
+}
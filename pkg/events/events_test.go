@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func newEndpoint(dnsName, recordType, resource string, targets ...string) *endpoint.Endpoint {
+	return endpoint.NewEndpoint(dnsName, recordType, targets...).WithLabel(endpoint.ResourceLabelKey, resource)
+}
+
+func recordedEvents(recorder *record.FakeRecorder) []string {
+	close(recorder.Events)
+	var events []string
+	for e := range recorder.Events {
+		events = append(events, e)
+	}
+	return events
+}
+
+func TestEmit(t *testing.T) {
+	changes := &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpoint("create.example.com", endpoint.RecordTypeA, "service/default/foo", "1.2.3.4"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			newEndpoint("update.example.com", endpoint.RecordTypeCNAME, "ingress/kube-system/bar", "old.example.org"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			newEndpoint("update.example.com", endpoint.RecordTypeCNAME, "ingress/kube-system/bar", "new.example.org"),
+		},
+		Delete: []*endpoint.Endpoint{
+			newEndpoint("delete.example.com", endpoint.RecordTypeAAAA, "crd/default/baz", "2001:db8::1"),
+			// records of unsupported sources don't emit events
+			newEndpoint("node.example.com", endpoint.RecordTypeA, "node/worker-1", "10.0.0.1"),
+			endpoint.NewEndpoint("unknown.example.com", endpoint.RecordTypeA, "10.0.0.2"),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected []string
+	}{
+		{
+			name: "successful changes",
+			expected: []string{
+				"Normal RecordCreated Created A record create.example.com with targets 1.2.3.4 (provider: aws) involvedObject{kind=Service,apiVersion=v1}",
+				"Normal RecordUpdated Updated CNAME record update.example.com targets from old.example.org to new.example.org (provider: aws) involvedObject{kind=Ingress,apiVersion=networking.k8s.io/v1}",
+				"Normal RecordDeleted Deleted AAAA record delete.example.com with targets 2001:db8::1 (provider: aws) involvedObject{kind=DNSEndpoint,apiVersion=externaldns.k8s.io/v1alpha1}",
+			},
+		},
+		{
+			name: "failed changes",
+			err:  errors.New("throttled"),
+			expected: []string{
+				"Warning RecordError Failed to create A record create.example.com with targets 1.2.3.4 (provider: aws): throttled involvedObject{kind=Service,apiVersion=v1}",
+				"Warning RecordError Failed to update CNAME record update.example.com with targets new.example.org (provider: aws): throttled involvedObject{kind=Ingress,apiVersion=networking.k8s.io/v1}",
+				"Warning RecordError Failed to delete AAAA record delete.example.com with targets 2001:db8::1 (provider: aws): throttled involvedObject{kind=DNSEndpoint,apiVersion=externaldns.k8s.io/v1alpha1}",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			recorder.IncludeObject = true
+
+			NewEmitter(recorder, "aws").Emit(changes, tt.err)
+
+			assert.Equal(t, tt.expected, recordedEvents(recorder))
+		})
+	}
+}
+
+func TestObjectReference(t *testing.T) {
+	assert.Equal(t, &corev1.ObjectReference{
+		Kind:       "Service",
+		APIVersion: "v1",
+		Namespace:  "default",
+		Name:       "foo",
+	}, objectReference(newEndpoint("example.com", endpoint.RecordTypeA, "service/default/foo")))
+
+	assert.Nil(t, objectReference(newEndpoint("example.com", endpoint.RecordTypeA, "service/foo")))
+	assert.Nil(t, objectReference(newEndpoint("example.com", endpoint.RecordTypeA, "route/default/foo")))
+	assert.Nil(t, objectReference(endpoint.NewEndpoint("example.com", endpoint.RecordTypeA)))
+}
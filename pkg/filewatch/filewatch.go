@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filewatch
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// dataDir is the symlink through which the kubelet atomically replaces the files of a ConfigMap or Secret volume.
+const dataDir = "..data"
+
+// Watch calls onChange with each event about the file and onError with each error of the watch, until the context
+// is done. The directory is watched rather than the file, as the file is replaced by most editors and by the
+// kubelet when it is mounted from a ConfigMap or a Secret.
+func Watch(ctx context.Context, path string, onChange func(fsnotify.Event), onError func(error)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		_ = watcher.Close()
+		return err
+	}
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if isFileEvent(path, event) {
+					onChange(event)
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				onError(err)
+			}
+		}
+	}()
+	return nil
+}
+
+// isFileEvent returns whether the event is about the file, or about the data directory of the
+// ConfigMap or Secret volume the file is a symlink into.
+func isFileEvent(path string, event fsnotify.Event) bool {
+	return filepath.Clean(event.Name) == filepath.Clean(path) || filepath.Base(event.Name) == dataDir
+}
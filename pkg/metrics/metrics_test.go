@@ -60,8 +60,9 @@ func TestMustRegister(t *testing.T) {
 				NewGaugeWithOpts(prometheus.GaugeOpts{Name: "test_gauge_3"}),
 				NewCounterWithOpts(prometheus.CounterOpts{Name: "test_counter_3"}),
 				NewCounterVecWithOpts(prometheus.CounterOpts{Name: "test_counter_vec_3"}, []string{"label"}),
+				NewHistogramWithOpts(prometheus.HistogramOpts{Name: "test_histogram_3"}),
 			},
-			expected: 3,
+			expected: 4,
 		},
 		{
 			name: "unsupported metric",
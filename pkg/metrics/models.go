@@ -68,6 +68,15 @@ func (g CounterVecMetric) Get() *Metric {
 	return &g.Metric
 }
 
+type HistogramMetric struct {
+	Metric
+	Histogram prometheus.Histogram
+}
+
+func (g HistogramMetric) Get() *Metric {
+	return &g.Metric
+}
+
 func NewGaugeWithOpts(opts prometheus.GaugeOpts) GaugeMetric {
 	return GaugeMetric{
 		Metric: Metric{
@@ -109,3 +118,17 @@ func NewCounterVecWithOpts(opts prometheus.CounterOpts, labelNames []string) Cou
 		CounterVec: prometheus.NewCounterVec(opts, labelNames),
 	}
 }
+
+func NewHistogramWithOpts(opts prometheus.HistogramOpts) HistogramMetric {
+	return HistogramMetric{
+		Metric: Metric{
+			Type:      "histogram",
+			Name:      opts.Name,
+			FQDN:      fmt.Sprintf("%s_%s", opts.Subsystem, opts.Name),
+			Namespace: opts.Namespace,
+			Subsystem: opts.Subsystem,
+			Help:      opts.Help,
+		},
+		Histogram: prometheus.NewHistogram(opts),
+	}
+}
@@ -81,3 +81,22 @@ func TestNewCounterVecWithOpts(t *testing.T) {
 	assert.Equal(t, "test_subsystem_test_counter_vec", counterVecMetric.FQDN)
 	assert.NotNil(t, counterVecMetric.CounterVec)
 }
+
+func TestNewHistogramWithOpts(t *testing.T) {
+	opts := prometheus.HistogramOpts{
+		Name:      "test_histogram",
+		Namespace: "test_namespace",
+		Subsystem: "test_subsystem",
+		Help:      "This is a test histogram",
+	}
+
+	histogramMetric := NewHistogramWithOpts(opts)
+
+	assert.Equal(t, "histogram", histogramMetric.Type)
+	assert.Equal(t, "test_histogram", histogramMetric.Name)
+	assert.Equal(t, "test_namespace", histogramMetric.Namespace)
+	assert.Equal(t, "test_subsystem", histogramMetric.Subsystem)
+	assert.Equal(t, "This is a test histogram", histogramMetric.Help)
+	assert.Equal(t, "test_subsystem_test_histogram", histogramMetric.FQDN)
+	assert.NotNil(t, histogramMetric.Histogram)
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"sort"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// orderChanges sorts the creations so that the records are created before the CNAMEs which reference them,
+// and the deletions so that the CNAMEs are deleted before the records they reference. The order of the
+// independent changes is kept.
+func orderChanges(changes *Changes) *Changes {
+	changes.Create = orderByDependency(changes.Create, false)
+	changes.Delete = orderByDependency(changes.Delete, true)
+	return changes
+}
+
+// orderByDependency sorts the endpoints by the length of the chain of CNAMEs of the list which lead to them,
+// the referenced records come first unless reverse is set.
+func orderByDependency(endpoints []*endpoint.Endpoint, reverse bool) []*endpoint.Endpoint {
+	if len(endpoints) < 2 {
+		return endpoints
+	}
+
+	byName := make(map[string][]*endpoint.Endpoint, len(endpoints))
+	for _, ep := range endpoints {
+		name := normalizeDNSName(ep.DNSName)
+		byName[name] = append(byName[name], ep)
+	}
+
+	depths := make(map[*endpoint.Endpoint]int, len(endpoints))
+	visiting := make(map[*endpoint.Endpoint]bool)
+	var depth func(ep *endpoint.Endpoint) int
+	depth = func(ep *endpoint.Endpoint) int {
+		if d, ok := depths[ep]; ok {
+			return d
+		}
+		// a CNAME loop can't be ordered, the records of the loop keep their order
+		if visiting[ep] || ep.RecordType != endpoint.RecordTypeCNAME {
+			return 0
+		}
+		visiting[ep] = true
+		d := 0
+		for _, target := range ep.Targets {
+			for _, dep := range byName[normalizeDNSName(target)] {
+				d = max(d, depth(dep)+1)
+			}
+		}
+		delete(visiting, ep)
+		depths[ep] = d
+		return d
+	}
+
+	ordered := make([]*endpoint.Endpoint, len(endpoints))
+	copy(ordered, endpoints)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if reverse {
+			return depth(ordered[i]) > depth(ordered[j])
+		}
+		return depth(ordered[i]) < depth(ordered[j])
+	})
+	return ordered
+}
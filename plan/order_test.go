@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func dnsNames(endpoints []*endpoint.Endpoint) []string {
+	names := make([]string, 0, len(endpoints))
+	for _, ep := range endpoints {
+		names = append(names, ep.DNSName)
+	}
+	return names
+}
+
+func TestPlanOrdersChangesByDependency(t *testing.T) {
+	// www.example.org -> app.example.org -> lb.example.org
+	chain := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeCNAME, "app.example.org"),
+		endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeCNAME, "LB.example.org."),
+		endpoint.NewEndpoint("lb.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	}
+
+	created := (&Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Desired:        chain,
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+	}).Calculate()
+	assert.Equal(t, []string{"lb.example.org", "app.example.org", "www.example.org"}, dnsNames(created.Changes.Create))
+
+	deleted := (&Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        []*endpoint.Endpoint{chain[2], chain[1], chain[0]},
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+	}).Calculate()
+	assert.Equal(t, []string{"www.example.org", "app.example.org", "lb.example.org"}, dnsNames(deleted.Changes.Delete))
+}
+
+func TestOrderByDependency(t *testing.T) {
+	independent := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeCNAME, "external.example.com"),
+		endpoint.NewEndpoint("c.example.org", endpoint.RecordTypeAAAA, "2001:db8::1"),
+	}
+	assert.Equal(t, independent, orderByDependency(independent, false), "the order of independent changes must be kept")
+
+	loop := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeCNAME, "b.example.org"),
+		endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeCNAME, "a.example.org"),
+	}
+	assert.ElementsMatch(t, loop, orderByDependency(loop, false), "a loop of CNAMEs must not be lost")
+}
@@ -50,6 +50,10 @@ type Plan struct {
 	ExcludeRecords []string
 	// OwnerID of records to manage
 	OwnerID string
+	// Zones are the names of the provider zones, hostnames matching more than one of them are reported
+	Zones []string
+	// ProtectedZones are the names of the zones whose records are never changed
+	ProtectedZones []string
 }
 
 // Changes holds lists of actions to be executed by dns providers
@@ -262,6 +266,10 @@ func (p *Plan) Calculate() *Plan {
 		changes.UpdateNew = endpoint.FilterEndpointsByOwnerID(p.OwnerID, changes.UpdateNew)
 	}
 
+	changes = p.filterProtectedZones(changes)
+	p.detectZoneConflicts(changes)
+	changes = orderChanges(changes)
+
 	plan := &Plan{
 		Current: p.Current,
 		Desired: p.Desired,
@@ -331,9 +339,11 @@ func filterRecordsForPlan(records []*endpoint.Endpoint, domainFilter endpoint.Ma
 			log.Debugf("ignoring record %s that does not match domain filter", record.DNSName)
 			continue
 		}
-		if IsManagedRecord(record.RecordType, managedRecords, excludeRecords) {
-			filtered = append(filtered, record)
+		if !IsManagedRecord(record.RecordType, managedRecords, excludeRecords) {
+			log.Debugf("ignoring record %s of unmanaged type %s", record.DNSName, record.RecordType)
+			continue
 		}
+		filtered = append(filtered, record)
 	}
 
 	return filtered
@@ -801,6 +801,33 @@ func (suite *PlanTestSuite) TestIgnoreTargetCase() {
 	validateEntries(suite.T(), changes.Delete, expectedDelete)
 }
 
+func (suite *PlanTestSuite) TestIgnoreTargetOrder() {
+	for _, recordType := range []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA} {
+		targets := []string{"1.2.3.4", "5.6.7.8"}
+		reordered := []string{"5.6.7.8", "1.2.3.4"}
+		if recordType == endpoint.RecordTypeAAAA {
+			targets = []string{"2001:db8::1", "2001:db8::2"}
+			reordered = []string{"2001:DB8:0::2", "2001:db8::1"}
+		}
+
+		for _, orderings := range [][2][]string{{targets, reordered}, {reordered, targets}} {
+			current := []*endpoint.Endpoint{endpoint.NewEndpoint("foo", recordType, orderings[0]...)}
+			desired := []*endpoint.Endpoint{endpoint.NewEndpoint("foo", recordType, orderings[1]...)}
+
+			p := &Plan{
+				Policies:       []Policy{&SyncPolicy{}},
+				Current:        current,
+				Desired:        desired,
+				ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA},
+			}
+
+			changes := p.Calculate().Changes
+			suite.False(changes.HasChanges(), "%s records with targets %v and %v must not be updated", recordType, orderings[0], orderings[1])
+			suite.Equal(orderings[1], []string(desired[0].Targets), "the desired targets must not be reordered")
+		}
+	}
+}
+
 func (suite *PlanTestSuite) TestRemoveEndpoint() {
 	current := []*endpoint.Endpoint{suite.fooV1Cname, suite.bar192A}
 	desired := []*endpoint.Endpoint{suite.fooV1Cname}
@@ -1181,3 +1208,94 @@ func TestShouldUpdateProviderSpecific(tt *testing.T) {
 		})
 	}
 }
+
+func TestManagedRecordTypes(t *testing.T) {
+	sourceTypes := []string{
+		endpoint.RecordTypeA,
+		endpoint.RecordTypeAAAA,
+		endpoint.RecordTypeCNAME,
+		endpoint.RecordTypeTXT,
+		endpoint.RecordTypeMX,
+		endpoint.RecordTypeNS,
+		endpoint.RecordTypeSRV,
+	}
+	targets := map[string][2]string{
+		endpoint.RecordTypeA:     {"1.2.3.4", "5.6.7.8"},
+		endpoint.RecordTypeAAAA:  {"2001:db8::1", "2001:db8::2"},
+		endpoint.RecordTypeCNAME: {"old.example.com", "new.example.com"},
+		endpoint.RecordTypeTXT:   {"\"old\"", "\"new\""},
+		endpoint.RecordTypeMX:    {"10 old.example.com", "10 new.example.com"},
+		endpoint.RecordTypeNS:    {"ns1.example.com", "ns2.example.com"},
+		endpoint.RecordTypeSRV:   {"0 50 443 old.example.com", "0 50 443 new.example.com"},
+	}
+
+	for _, tt := range []struct {
+		name           string
+		managedRecords []string
+		excludeRecords []string
+		expected       []string
+	}{
+		{
+			name:     "nothing is managed",
+			expected: []string{},
+		},
+		{
+			name:           "default managed record types",
+			managedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
+			expected:       []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
+		},
+		{
+			name:           "single managed record type",
+			managedRecords: []string{endpoint.RecordTypeMX},
+			expected:       []string{endpoint.RecordTypeMX},
+		},
+		{
+			name:           "managed record type not emitted by the source",
+			managedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypePTR},
+			expected:       []string{endpoint.RecordTypeA},
+		},
+		{
+			name:           "all the emitted record types are managed",
+			managedRecords: sourceTypes,
+			expected:       sourceTypes,
+		},
+		{
+			name:           "excluded record types take precedence",
+			managedRecords: sourceTypes,
+			excludeRecords: []string{endpoint.RecordTypeNS, endpoint.RecordTypeTXT},
+			expected:       []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeMX, endpoint.RecordTypeSRV},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var current, desired []*endpoint.Endpoint
+			// the names differ per type since CNAME records can't coexist with other types
+			for _, recordType := range sourceTypes {
+				name := strings.ToLower(recordType) + ".example.com"
+				current = append(current, endpoint.NewEndpoint("update-"+name, recordType, targets[recordType][0]))
+				desired = append(desired,
+					endpoint.NewEndpoint("update-"+name, recordType, targets[recordType][1]),
+					endpoint.NewEndpoint("create-"+name, recordType, targets[recordType][1]),
+				)
+			}
+
+			changes := (&Plan{
+				Policies:       []Policy{&SyncPolicy{}},
+				Current:        current,
+				Desired:        desired,
+				ManagedRecords: tt.managedRecords,
+				ExcludeRecords: tt.excludeRecords,
+			}).Calculate().Changes
+
+			recordTypes := func(endpoints []*endpoint.Endpoint) []string {
+				types := []string{}
+				for _, ep := range endpoints {
+					types = append(types, ep.RecordType)
+				}
+				return types
+			}
+			assert.ElementsMatch(t, tt.expected, recordTypes(changes.Create), "created record types")
+			assert.ElementsMatch(t, tt.expected, recordTypes(changes.UpdateNew), "updated record types")
+			assert.Empty(t, changes.Delete)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// MostSpecificZone returns the zone with the longest name matching the hostname, and all the zones
+// matching it ordered from the most to the least specific. The zone is empty when none matches.
+func MostSpecificZone(hostname string, zones []string) (string, []string) {
+	name := strings.TrimSuffix(normalizeDNSName(hostname), ".")
+
+	var matching []string
+	for _, zone := range zones {
+		zone = strings.TrimSuffix(strings.ToLower(zone), ".")
+		if zone == "" {
+			continue
+		}
+		if name == zone || strings.HasSuffix(name, "."+zone) {
+			matching = append(matching, zone)
+		}
+	}
+	if len(matching) == 0 {
+		return "", nil
+	}
+
+	sort.SliceStable(matching, func(i, j int) bool {
+		return len(matching[i]) > len(matching[j])
+	})
+	return matching[0], matching
+}
+
+// ZoneNames returns the zone names of a domain filter built from the zones of a provider,
+// the filters prefixed by a dot which match the subdomains of a zone are skipped.
+func ZoneNames(filter endpoint.DomainFilterInterface) []string {
+	var df endpoint.DomainFilter
+	switch f := filter.(type) {
+	case endpoint.DomainFilter:
+		df = f
+	case *endpoint.DomainFilter:
+		if f == nil {
+			return nil
+		}
+		df = *f
+	default:
+		return nil
+	}
+
+	var zones []string
+	for _, name := range df.Filters {
+		if !strings.HasPrefix(name, ".") {
+			zones = append(zones, name)
+		}
+	}
+	return zones
+}
+
+// detectZoneConflicts warns about the changed records whose hostname matches more than one zone,
+// providers place such records in the most specific zone.
+func (p *Plan) detectZoneConflicts(changes *Changes) {
+	if len(p.Zones) < 2 {
+		return
+	}
+
+	seen := map[string]bool{}
+	for _, records := range [][]*endpoint.Endpoint{changes.Create, changes.UpdateNew, changes.Delete} {
+		for _, ep := range records {
+			name := normalizeDNSName(ep.DNSName)
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			zone, matching := MostSpecificZone(ep.DNSName, p.Zones)
+			if len(matching) > 1 {
+				log.Warnf("Hostname %s matches the zones %v, using the most specific zone %s", ep.DNSName, matching, zone)
+			}
+		}
+	}
+}
+
+// filterProtectedZones drops, with a warning, the changes of the records whose most specific zone is protected.
+func (p *Plan) filterProtectedZones(changes *Changes) *Changes {
+	if len(p.ProtectedZones) == 0 {
+		return changes
+	}
+
+	zones := append(append([]string{}, p.Zones...), p.ProtectedZones...)
+	protected := make(map[string]bool, len(p.ProtectedZones))
+	for _, zone := range p.ProtectedZones {
+		protected[strings.TrimSuffix(strings.ToLower(zone), ".")] = true
+	}
+	isProtected := func(action string, ep *endpoint.Endpoint) bool {
+		zone, _ := MostSpecificZone(ep.DNSName, zones)
+		if !protected[zone] {
+			return false
+		}
+		log.Warnf("Skipping %s of %s record %s, the zone %s is protected", action, ep.RecordType, ep.DNSName, zone)
+		return true
+	}
+
+	filtered := &Changes{}
+	for _, ep := range changes.Create {
+		if !isProtected("creation", ep) {
+			filtered.Create = append(filtered.Create, ep)
+		}
+	}
+	for i, ep := range changes.UpdateNew {
+		if !isProtected("update", ep) {
+			filtered.UpdateOld = append(filtered.UpdateOld, changes.UpdateOld[i])
+			filtered.UpdateNew = append(filtered.UpdateNew, ep)
+		}
+	}
+	for _, ep := range changes.Delete {
+		if !isProtected("deletion", ep) {
+			filtered.Delete = append(filtered.Delete, ep)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+)
+
+func TestMostSpecificZone(t *testing.T) {
+	zones := []string{"example.com", "sub.example.com.", "other.org"}
+
+	for _, tt := range []struct {
+		hostname string
+		zone     string
+		matching []string
+	}{
+		{hostname: "www.sub.example.com", zone: "sub.example.com", matching: []string{"sub.example.com", "example.com"}},
+		{hostname: "sub.example.com", zone: "sub.example.com", matching: []string{"sub.example.com", "example.com"}},
+		{hostname: "WWW.Sub.Example.com.", zone: "sub.example.com", matching: []string{"sub.example.com", "example.com"}},
+		{hostname: "www.example.com", zone: "example.com", matching: []string{"example.com"}},
+		{hostname: "www.notsub.example.com", zone: "example.com", matching: []string{"example.com"}},
+		{hostname: "www.example.net", zone: ""},
+	} {
+		t.Run(tt.hostname, func(t *testing.T) {
+			zone, matching := MostSpecificZone(tt.hostname, zones)
+			assert.Equal(t, tt.zone, zone)
+			assert.Equal(t, tt.matching, matching)
+		})
+	}
+}
+
+func TestZoneNames(t *testing.T) {
+	assert.Equal(t, []string{"example.com", "sub.example.com"},
+		ZoneNames(endpoint.NewDomainFilter([]string{"example.com", ".example.com", "sub.example.com.", ".sub.example.com."})))
+	filter := endpoint.NewDomainFilter([]string{"example.com"})
+	assert.Equal(t, []string{"example.com"}, ZoneNames(&filter))
+	assert.Nil(t, ZoneNames(endpoint.MatchAllDomainFilters{&filter}))
+}
+
+func TestPlanZoneConflicts(t *testing.T) {
+	hook := testutils.LogsUnderTestWithLogLevel(log.WarnLevel, t)
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Desired:        []*endpoint.Endpoint{endpoint.NewEndpoint("www.sub.example.com", endpoint.RecordTypeA, "1.2.3.4"), endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.5")},
+		ManagedRecords: []string{endpoint.RecordTypeA},
+		Zones:          []string{"example.com", "sub.example.com"},
+	}
+	changes := p.Calculate().Changes
+
+	assert.Len(t, changes.Create, 2)
+	testutils.TestHelperLogContains("Hostname www.sub.example.com matches the zones [sub.example.com example.com], using the most specific zone sub.example.com", hook, t)
+	testutils.TestHelperLogNotContains("Hostname www.example.com matches", hook, t)
+}
+
+func TestPlanProtectedZones(t *testing.T) {
+	hook := testutils.LogsUnderTestWithLogLevel(log.WarnLevel, t)
+
+	p := &Plan{
+		Policies: []Policy{&SyncPolicy{}},
+		Current: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("app.sub.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+		Desired: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "5.6.7.8"),
+			endpoint.NewEndpoint("app.sub.example.com", endpoint.RecordTypeA, "5.6.7.8"),
+			endpoint.NewEndpoint("new.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+		ManagedRecords: []string{endpoint.RecordTypeA},
+		Zones:          []string{"example.com", "sub.example.com", "example.org"},
+		ProtectedZones: []string{"example.com."},
+	}
+	changes := p.Calculate().Changes
+
+	assert.Equal(t, []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.org", endpoint.RecordTypeA, "1.2.3.4")}, changes.Create)
+	require.Len(t, changes.UpdateNew, 1)
+	assert.Equal(t, "app.sub.example.com", changes.UpdateNew[0].DNSName)
+	require.Len(t, changes.UpdateOld, 1)
+	assert.Equal(t, "app.sub.example.com", changes.UpdateOld[0].DNSName)
+	assert.Empty(t, changes.Delete)
+
+	testutils.TestHelperLogContains("Skipping creation of A record new.example.com, the zone example.com is protected", hook, t)
+	testutils.TestHelperLogContains("Skipping update of A record app.example.com, the zone example.com is protected", hook, t)
+	testutils.TestHelperLogContains("Skipping deletion of A record old.example.com, the zone example.com is protected", hook, t)
+	testutils.TestHelperLogNotContains("record app.sub.example.com", hook, t)
+}
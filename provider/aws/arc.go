@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	arcServiceName  = "route53-recovery-cluster"
+	arcTargetPrefix = "ToggleCustomerAPI."
+	// ARCRoutingControlStateOn is the state of a routing control which lets the traffic flow.
+	ARCRoutingControlStateOn = "On"
+	// ARCRoutingControlStateOff is the state of a routing control which stops the traffic.
+	ARCRoutingControlStateOff = "Off"
+)
+
+// ARCRoutingControlClient is the subset of the Route53 Application Recovery Controller cluster API that we actually use.
+// https://docs.aws.amazon.com/routing-control/latest/APIReference/API_Operations_Routing_Control.html
+type ARCRoutingControlClient interface {
+	GetRoutingControlState(ctx context.Context, arn string) (string, error)
+}
+
+type getRoutingControlStateInput struct {
+	RoutingControlArn string `json:"RoutingControlArn"`
+}
+
+type getRoutingControlStateOutput struct {
+	RoutingControlArn   string `json:"RoutingControlArn"`
+	RoutingControlState string `json:"RoutingControlState"`
+	RoutingControlName  string `json:"RoutingControlName,omitempty"`
+}
+
+// arcEndpoint is a cluster endpoint and the region its requests are signed for.
+type arcEndpoint struct {
+	url    string
+	region string
+}
+
+// arcClient talks to the Route53 Application Recovery Controller cluster JSON API, the endpoints of
+// the cluster are tried in turn as any of them can answer.
+type arcClient struct {
+	api       jsonAPI
+	endpoints []arcEndpoint
+}
+
+// NewARCRoutingControlClient returns an ARCRoutingControlClient using the given AWS configuration and cluster endpoints,
+// e.g. https://abcd1234.route53-recovery-cluster.us-west-2.amazonaws.com/v1.
+func NewARCRoutingControlClient(cfg awsv2.Config, clusterEndpoints []string) ARCRoutingControlClient {
+	endpoints := make([]arcEndpoint, 0, len(clusterEndpoints))
+	for _, endpoint := range clusterEndpoints {
+		endpoints = append(endpoints, arcEndpoint{url: endpoint, region: arcEndpointRegion(endpoint, cfg.Region)})
+	}
+	return &arcClient{
+		api: jsonAPI{
+			cfg:          cfg,
+			signer:       v4.NewSigner(),
+			service:      arcServiceName,
+			contentType:  "application/x-amz-json-1.0",
+			targetPrefix: arcTargetPrefix,
+		},
+		endpoints: endpoints,
+	}
+}
+
+// arcEndpointRegion returns the region of a cluster endpoint, which follows the service name in its host.
+func arcEndpointRegion(endpoint, defaultRegion string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return defaultRegion
+	}
+	labels := strings.Split(u.Hostname(), ".")
+	for i := 0; i < len(labels)-1; i++ {
+		if labels[i] == arcServiceName {
+			return labels[i+1]
+		}
+	}
+	return defaultRegion
+}
+
+func (c *arcClient) GetRoutingControlState(ctx context.Context, arn string) (string, error) {
+	var errs []error
+	for _, endpoint := range c.endpoints {
+		var output getRoutingControlStateOutput
+		err := c.api.call(ctx, endpoint.url, endpoint.region, "GetRoutingControlState", getRoutingControlStateInput{RoutingControlArn: arn}, &output)
+		if err == nil {
+			return output.RoutingControlState, nil
+		}
+		log.Debugf("Failed to get the state of routing control %s from cluster endpoint %s: %v", arn, endpoint.url, err)
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return "", errors.New("no cluster endpoint configured")
+	}
+	return "", errors.Join(errs...)
+}
+
+// checkRoutingControl returns an error unless the configured routing control is on, so that the
+// records are not changed while the traffic is stopped by the Application Recovery Controller.
+func (p *AWSProvider) checkRoutingControl(ctx context.Context) error {
+	state, err := p.arcRoutingControlClient.GetRoutingControlState(ctx, p.arcRoutingControlARN)
+	if err != nil {
+		return provider.NewSoftErrorf("failed to get the state of routing control %s, not applying changes: %w", p.arcRoutingControlARN, err)
+	}
+	if state != ARCRoutingControlStateOn {
+		return provider.NewSoftErrorf("routing control %s is in state %q, not applying changes", p.arcRoutingControlARN, state)
+	}
+	return nil
+}
+
+// validateARCConfig returns an error when a routing control is given without a client to check its state.
+func validateARCConfig(awsConfig AWSConfig) error {
+	if awsConfig.ARCRoutingControlARN != "" && awsConfig.ARCRoutingControlClient == nil {
+		return fmt.Errorf("a client is required to check the state of routing control %s", awsConfig.ARCRoutingControlARN)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const testRoutingControlARN = "arn:aws:route53-recovery-control::123456789012:controlpanel/abc/routingcontrol/def"
+
+// Compile time check for interface conformance
+var _ ARCRoutingControlClient = &ARCRoutingControlClientStub{}
+
+type ARCRoutingControlClientStub struct {
+	state string
+	err   error
+	arns  []string
+}
+
+func (c *ARCRoutingControlClientStub) GetRoutingControlState(_ context.Context, arn string) (string, error) {
+	c.arns = append(c.arns, arn)
+	return c.state, c.err
+}
+
+func newAWSProviderWithRoutingControl(t *testing.T, client *ARCRoutingControlClientStub) *AWSProvider {
+	p, _ := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, false, nil)
+	p.arcRoutingControlARN = testRoutingControlARN
+	p.arcRoutingControlClient = client
+	return p
+}
+
+func TestAWSApplyChangesRoutingControlOn(t *testing.T) {
+	client := &ARCRoutingControlClientStub{state: ARCRoutingControlStateOn}
+	p := newAWSProviderWithRoutingControl(t, client)
+
+	require.NoError(t, p.ApplyChanges(context.Background(), createChange()))
+
+	assert.Equal(t, []string{testRoutingControlARN}, client.arns)
+	assert.Len(t, listAWSRecords(t, p.clients[defaultAWSProfile], "/hostedzone/zone-3.ext-dns-test-2.teapot.zalan.do."), 1)
+}
+
+func TestAWSApplyChangesRoutingControlOff(t *testing.T) {
+	p := newAWSProviderWithRoutingControl(t, &ARCRoutingControlClientStub{state: ARCRoutingControlStateOff})
+
+	err := p.ApplyChanges(context.Background(), createChange())
+	require.ErrorIs(t, err, provider.SoftError)
+	assert.ErrorContains(t, err, `routing control `+testRoutingControlARN+` is in state "Off", not applying changes`)
+
+	assert.Empty(t, listAWSRecords(t, p.clients[defaultAWSProfile], "/hostedzone/zone-3.ext-dns-test-2.teapot.zalan.do."))
+}
+
+func TestAWSApplyChangesRoutingControlError(t *testing.T) {
+	p := newAWSProviderWithRoutingControl(t, &ARCRoutingControlClientStub{err: errors.New("cluster unreachable")})
+
+	err := p.ApplyChanges(context.Background(), createChange())
+	require.ErrorIs(t, err, provider.SoftError)
+	assert.ErrorContains(t, err, "cluster unreachable")
+
+	assert.Empty(t, listAWSRecords(t, p.clients[defaultAWSProfile], "/hostedzone/zone-3.ext-dns-test-2.teapot.zalan.do."))
+}
+
+func TestNewAWSProviderRoutingControlWithoutClient(t *testing.T) {
+	_, err := NewAWSProvider(AWSConfig{ARCRoutingControlARN: testRoutingControlARN}, nil)
+	require.EqualError(t, err, "a client is required to check the state of routing control "+testRoutingControlARN)
+}
+
+func TestARCClient(t *testing.T) {
+	var requests int
+	unavailable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unavailable.Close()
+	available := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "ToggleCustomerAPI.GetRoutingControlState", r.Header.Get("X-Amz-Target"))
+		assert.Equal(t, "application/x-amz-json-1.0", r.Header.Get("Content-Type"))
+		assert.Contains(t, r.Header.Get("Authorization"), "/us-east-1/route53-recovery-cluster/aws4_request")
+
+		var input map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+		assert.Equal(t, testRoutingControlARN, input["RoutingControlArn"])
+		_, _ = w.Write([]byte(`{"RoutingControlArn":"` + testRoutingControlARN + `","RoutingControlState":"Off"}`))
+	}))
+	defer available.Close()
+
+	client := NewARCRoutingControlClient(aws.Config{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}, []string{unavailable.URL, available.URL}).(*arcClient)
+
+	state, err := client.GetRoutingControlState(context.Background(), testRoutingControlARN)
+	require.NoError(t, err)
+	assert.Equal(t, ARCRoutingControlStateOff, state)
+	assert.Equal(t, 2, requests, "the next cluster endpoint must be tried when one fails")
+
+	client.endpoints = client.endpoints[:1]
+	_, err = client.GetRoutingControlState(context.Background(), testRoutingControlARN)
+	assert.ErrorContains(t, err, "unexpected status 503")
+
+	client.endpoints = nil
+	_, err = client.GetRoutingControlState(context.Background(), testRoutingControlARN)
+	assert.EqualError(t, err, "no cluster endpoint configured")
+}
+
+func TestARCEndpointRegion(t *testing.T) {
+	assert.Equal(t, "ap-southeast-2", arcEndpointRegion("https://abcd1234.route53-recovery-cluster.ap-southeast-2.amazonaws.com/v1", "us-east-1"))
+	assert.Equal(t, "us-east-1", arcEndpointRegion("https://recovery.example.com/v1", "us-east-1"))
+	assert.Equal(t, "us-east-1", arcEndpointRegion("://invalid", "us-east-1"))
+}
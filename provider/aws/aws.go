@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/netip"
 	"regexp"
 	"slices"
 	"sort"
@@ -66,6 +67,8 @@ const (
 	// Currently supported up to 10 health checks or hosted zones.
 	// https://docs.aws.amazon.com/Route53/latest/APIReference/API_ListTagsForResources.html#API_ListTagsForResources_RequestSyntax
 	batchSize = 10
+	// maxSetIdentifierLength is the maximum length of the set identifier of a Route53 record.
+	maxSetIdentifierLength = 128
 )
 
 // see elb: https://docs.aws.amazon.com/general/latest/gr/elb.html
@@ -212,6 +215,7 @@ type Route53API interface {
 	ListResourceRecordSets(ctx context.Context, input *route53.ListResourceRecordSetsInput, optFns ...func(options *route53.Options)) (*route53.ListResourceRecordSetsOutput, error)
 	ChangeResourceRecordSets(ctx context.Context, input *route53.ChangeResourceRecordSetsInput, optFns ...func(options *route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error)
 	CreateHostedZone(ctx context.Context, input *route53.CreateHostedZoneInput, optFns ...func(*route53.Options)) (*route53.CreateHostedZoneOutput, error)
+	GetHostedZone(ctx context.Context, input *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error)
 	ListHostedZones(ctx context.Context, input *route53.ListHostedZonesInput, optFns ...func(options *route53.Options)) (*route53.ListHostedZonesOutput, error)
 	ListTagsForResources(ctx context.Context, input *route53.ListTagsForResourcesInput, optFns ...func(options *route53.Options)) (*route53.ListTagsForResourcesOutput, error)
 }
@@ -222,6 +226,10 @@ type Route53Change struct {
 	OwnedRecord string
 	sizeBytes   int
 	sizeValues  int
+	// previous is the record set replaced by an UPSERT, it is only kept to roll the change back
+	previous *route53types.ResourceRecordSet
+	// private is the change submitted to the private zones instead, using the internal targets of the endpoint
+	private *Route53Change
 }
 
 type Route53Changes []*Route53Change
@@ -292,43 +300,127 @@ type AWSProvider struct {
 	zonesCache      *zonesListCache
 	// queue for collecting changes to submit them in the next iteration, but after all other changes
 	failedChangesQueue map[string]Route53Changes
+	// associate a Route53 Resolver rule with the VPCs of managed private zones
+	manageResolverRules bool
+	resolverRuleConfig  ResolverRuleConfig
+	resolverRuleID      string
+	resolverRuleVPCs    map[string]struct{}
+	// skip the changes overwriting records which fail DNSSEC validation
+	validateDNSSEC bool
+	dnssecConfig   DNSSECConfig
+	// publish the endpoints with a traffic policy as traffic policy instances
+	manageTrafficPolicies bool
+	trafficPolicyClients  map[string]Route53TrafficPolicyAPI
+	// skip the changes while the Application Recovery Controller routing control is not on
+	arcRoutingControlARN    string
+	arcRoutingControlClient ARCRoutingControlClient
+	// register the failover records as resource sets in Route53 Recovery Readiness, checked in the given cells
+	recoveryReadinessEnabled  bool
+	recoveryReadinessCellARNs []string
+	recoveryReadinessClient   RecoveryReadinessClient
+	// submit the changes in batches of at most this many changes and roll them back when one fails
+	maxChangesPerBatch int
+	// submit the changes of a zone in a single batch and stop at the first zone which fails
+	transactionalZoneUpdates bool
+	// only manage the zones of a domain with the profile of the role assumed for it
+	domainZoneFilter DomainZoneFilter
+	// create the missing public hosted zones, and delegate them from their parent zone
+	createZones  []string
+	autoDelegate bool
+	parentZoneID string
 }
 
 // AWSConfig contains configuration to create a new AWS provider.
 type AWSConfig struct {
-	DomainFilter          endpoint.DomainFilter
-	ZoneIDFilter          provider.ZoneIDFilter
-	ZoneTypeFilter        provider.ZoneTypeFilter
-	ZoneTagFilter         provider.ZoneTagFilter
-	ZoneMatchParent       bool
-	BatchChangeSize       int
-	BatchChangeSizeBytes  int
-	BatchChangeSizeValues int
-	BatchChangeInterval   time.Duration
-	EvaluateTargetHealth  bool
-	PreferCNAME           bool
-	DryRun                bool
-	ZoneCacheDuration     time.Duration
+	DomainFilter              endpoint.DomainFilter
+	ZoneIDFilter              provider.ZoneIDFilter
+	ZoneTypeFilter            provider.ZoneTypeFilter
+	ZoneTagFilter             provider.ZoneTagFilter
+	ZoneMatchParent           bool
+	BatchChangeSize           int
+	BatchChangeSizeBytes      int
+	BatchChangeSizeValues     int
+	BatchChangeInterval       time.Duration
+	EvaluateTargetHealth      bool
+	PreferCNAME               bool
+	DryRun                    bool
+	ZoneCacheDuration         time.Duration
+	ManageResolverRules       bool
+	ResolverRuleConfig        ResolverRuleConfig
+	ValidateDNSSEC            bool
+	DNSSECConfig              DNSSECConfig
+	ManageTrafficPolicies     bool
+	ARCRoutingControlARN      string
+	ARCRoutingControlClient   ARCRoutingControlClient
+	RecoveryReadinessEnabled  bool
+	RecoveryReadinessCellARNs []string
+	RecoveryReadinessClient   RecoveryReadinessClient
+	MaxChangesPerBatch        int
+	DomainZoneFilter          DomainZoneFilter
+	TransactionalZoneUpdates  bool
+	CreateZones               []string
+	AutoDelegate              bool
+	ParentZoneID              string
 }
 
 // NewAWSProvider initializes a new AWS Route53 based Provider.
 func NewAWSProvider(awsConfig AWSConfig, clients map[string]Route53API) (*AWSProvider, error) {
 	pr := &AWSProvider{
-		clients:               clients,
-		domainFilter:          awsConfig.DomainFilter,
-		zoneIDFilter:          awsConfig.ZoneIDFilter,
-		zoneTypeFilter:        awsConfig.ZoneTypeFilter,
-		zoneTagFilter:         awsConfig.ZoneTagFilter,
-		zoneMatchParent:       awsConfig.ZoneMatchParent,
-		batchChangeSize:       awsConfig.BatchChangeSize,
-		batchChangeSizeBytes:  awsConfig.BatchChangeSizeBytes,
-		batchChangeSizeValues: awsConfig.BatchChangeSizeValues,
-		batchChangeInterval:   awsConfig.BatchChangeInterval,
-		evaluateTargetHealth:  awsConfig.EvaluateTargetHealth,
-		preferCNAME:           awsConfig.PreferCNAME,
-		dryRun:                awsConfig.DryRun,
-		zonesCache:            &zonesListCache{duration: awsConfig.ZoneCacheDuration},
-		failedChangesQueue:    make(map[string]Route53Changes),
+		clients:                   clients,
+		domainFilter:              awsConfig.DomainFilter,
+		zoneIDFilter:              awsConfig.ZoneIDFilter,
+		zoneTypeFilter:            awsConfig.ZoneTypeFilter,
+		zoneTagFilter:             awsConfig.ZoneTagFilter,
+		zoneMatchParent:           awsConfig.ZoneMatchParent,
+		batchChangeSize:           awsConfig.BatchChangeSize,
+		batchChangeSizeBytes:      awsConfig.BatchChangeSizeBytes,
+		batchChangeSizeValues:     awsConfig.BatchChangeSizeValues,
+		batchChangeInterval:       awsConfig.BatchChangeInterval,
+		maxChangesPerBatch:        awsConfig.MaxChangesPerBatch,
+		transactionalZoneUpdates:  awsConfig.TransactionalZoneUpdates,
+		domainZoneFilter:          awsConfig.DomainZoneFilter,
+		evaluateTargetHealth:      awsConfig.EvaluateTargetHealth,
+		preferCNAME:               awsConfig.PreferCNAME,
+		dryRun:                    awsConfig.DryRun,
+		zonesCache:                &zonesListCache{duration: awsConfig.ZoneCacheDuration},
+		failedChangesQueue:        make(map[string]Route53Changes),
+		manageResolverRules:       awsConfig.ManageResolverRules,
+		resolverRuleConfig:        awsConfig.ResolverRuleConfig,
+		resolverRuleVPCs:          make(map[string]struct{}),
+		validateDNSSEC:            awsConfig.ValidateDNSSEC,
+		manageTrafficPolicies:     awsConfig.ManageTrafficPolicies,
+		arcRoutingControlARN:      awsConfig.ARCRoutingControlARN,
+		arcRoutingControlClient:   awsConfig.ARCRoutingControlClient,
+		recoveryReadinessEnabled:  awsConfig.RecoveryReadinessEnabled,
+		recoveryReadinessCellARNs: awsConfig.RecoveryReadinessCellARNs,
+		recoveryReadinessClient:   awsConfig.RecoveryReadinessClient,
+		createZones:               awsConfig.CreateZones,
+		autoDelegate:              awsConfig.AutoDelegate,
+		parentZoneID:              awsConfig.ParentZoneID,
+	}
+
+	if err := validateARCConfig(awsConfig); err != nil {
+		return nil, err
+	}
+
+	if err := validateRecoveryReadinessConfig(awsConfig); err != nil {
+		return nil, err
+	}
+
+	if awsConfig.ValidateDNSSEC {
+		dnssecConfig, err := awsConfig.DNSSECConfig.withDefaults()
+		if err != nil {
+			return nil, err
+		}
+		pr.dnssecConfig = dnssecConfig
+	}
+
+	if awsConfig.ManageTrafficPolicies {
+		trafficPolicyClients, err := trafficPolicyAPIs(clients)
+		if err != nil {
+			return nil, err
+		}
+		pr.trafficPolicyClients = trafficPolicyClients
 	}
 
 	return pr, nil
@@ -348,6 +440,21 @@ func (p *AWSProvider) Zones(ctx context.Context) (map[string]*route53types.Hoste
 	return result, nil
 }
 
+// ZoneNamesByID returns the names of the hosted zones by ID, with and without the "/hostedzone/" prefix.
+func (p *AWSProvider) ZoneNamesByID(ctx context.Context) (map[string]string, error) {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string, 2*len(zones))
+	for id, zone := range zones {
+		names[id] = *zone.zone.Name
+		names[cleanZoneID(id)] = *zone.zone.Name
+	}
+	return names, nil
+}
+
 // zones returns the list of zones per AWS profile
 func (p *AWSProvider) zones(ctx context.Context) (map[string]*profiledZone, error) {
 	if p.zonesCache.zones != nil && time.Since(p.zonesCache.age) < p.zonesCache.duration {
@@ -391,6 +498,10 @@ func (p *AWSProvider) zones(ctx context.Context) (map[string]*profiledZone, erro
 					}
 				}
 
+				if !p.domainZoneFilter.Match(profile, *zone.Name) {
+					continue
+				}
+
 				if !p.zoneTagFilter.IsEmpty() {
 					zonesToTagFilter = append(zonesToTagFilter, cleanZoneID(*zone.Id))
 				}
@@ -465,6 +576,7 @@ func (p *AWSProvider) Records(ctx context.Context) (endpoints []*endpoint.Endpoi
 
 func (p *AWSProvider) records(ctx context.Context, zones map[string]*profiledZone) ([]*endpoint.Endpoint, error) {
 	endpoints := make([]*endpoint.Endpoint, 0)
+	var privateEndpoints []*endpoint.Endpoint
 
 	for _, z := range zones {
 		client := p.clients[z.profile]
@@ -487,6 +599,11 @@ func (p *AWSProvider) records(ctx context.Context, zones map[string]*profiledZon
 					continue
 				}
 
+				// the records of traffic policy instances are represented by the endpoints of the instances
+				if p.manageTrafficPolicies && r.TrafficPolicyInstanceId != nil {
+					continue
+				}
+
 				name := convertOctalToAscii(wildcardUnescape(*r.Name))
 
 				var ttl endpoint.TTL
@@ -551,12 +668,26 @@ func (p *AWSProvider) records(ctx context.Context, zones map[string]*profiledZon
 						ep.WithProviderSpecific(providerSpecificHealthCheckID, *r.HealthCheckId)
 					}
 
+					if isPrivateZone(z.zone) {
+						privateEndpoints = append(privateEndpoints, ep)
+						continue
+					}
 					endpoints = append(endpoints, ep)
 				}
 			}
 		}
 	}
 
+	endpoints = withInternalTargets(endpoints, privateEndpoints)
+
+	if p.manageTrafficPolicies {
+		trafficPolicyEndpoints, err := p.trafficPolicyRecords(ctx, zones)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, trafficPolicyEndpoints...)
+	}
+
 	return endpoints, nil
 }
 
@@ -600,6 +731,7 @@ func (p *AWSProvider) createUpdateChanges(newEndpoints, oldEndpoints []*endpoint
 	var deletes []*endpoint.Endpoint
 	var creates []*endpoint.Endpoint
 	var updates []*endpoint.Endpoint
+	var updatesOld []*endpoint.Endpoint
 
 	for i, newE := range newEndpoints {
 		oldE := oldEndpoints[i]
@@ -609,12 +741,27 @@ func (p *AWSProvider) createUpdateChanges(newEndpoints, oldEndpoints []*endpoint
 		} else {
 			// Safe to perform an UPSERT.
 			updates = append(updates, newE)
+			updatesOld = append(updatesOld, oldE)
+		}
+	}
+
+	upserts := p.newChanges(route53types.ChangeActionUpsert, updates)
+	if p.maxChangesPerBatch > 0 {
+		for i, c := range upserts {
+			previous := p.newChange(route53types.ChangeActionUpsert, updatesOld[i])
+			c.previous = previous.ResourceRecordSet
+			if c.private != nil {
+				c.private.previous = previous.ResourceRecordSet
+				if previous.private != nil {
+					c.private.previous = previous.private.ResourceRecordSet
+				}
+			}
 		}
 	}
 
 	combined := make(Route53Changes, 0, len(deletes)+len(creates)+len(updates))
 	combined = append(combined, p.newChanges(route53types.ChangeActionCreate, creates)...)
-	combined = append(combined, p.newChanges(route53types.ChangeActionUpsert, updates)...)
+	combined = append(combined, upserts...)
 	combined = append(combined, p.newChanges(route53types.ChangeActionDelete, deletes)...)
 	return combined
 }
@@ -636,11 +783,30 @@ func (p *AWSProvider) GetDomainFilter() endpoint.DomainFilterInterface {
 
 // ApplyChanges applies a given set of changes in a given zone.
 func (p *AWSProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	if p.arcRoutingControlARN != "" {
+		if err := p.checkRoutingControl(ctx); err != nil {
+			return err
+		}
+	}
+
 	zones, err := p.zones(ctx)
 	if err != nil {
 		return provider.NewSoftErrorf("failed to list zones, not applying changes: %w", err)
 	}
 
+	if p.validateDNSSEC {
+		changes = p.dnssecValidatedChanges(ctx, changes, zones)
+	}
+
+	var trafficPolicyChanges *plan.Changes
+	if p.manageTrafficPolicies {
+		// traffic policy instances are deleted first so that records can replace them
+		trafficPolicyChanges, changes = splitTrafficPolicyChanges(changes)
+		if err := p.deleteTrafficPolicyInstances(ctx, trafficPolicyChanges.Delete, zones); err != nil {
+			return err
+		}
+	}
+
 	updateChanges := p.createUpdateChanges(changes.UpdateNew, changes.UpdateOld)
 
 	combinedChanges := make(Route53Changes, 0, len(changes.Delete)+len(changes.Create)+len(updateChanges))
@@ -648,7 +814,32 @@ func (p *AWSProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) e
 	combinedChanges = append(combinedChanges, p.newChanges(route53types.ChangeActionDelete, changes.Delete)...)
 	combinedChanges = append(combinedChanges, updateChanges...)
 
-	return p.submitChanges(ctx, combinedChanges, zones)
+	if err := p.submitChanges(ctx, combinedChanges, zones); err != nil {
+		return err
+	}
+
+	if p.manageTrafficPolicies {
+		upserts := make([]*endpoint.Endpoint, 0, len(trafficPolicyChanges.Create)+len(trafficPolicyChanges.UpdateNew))
+		upserts = append(upserts, trafficPolicyChanges.Create...)
+		upserts = append(upserts, trafficPolicyChanges.UpdateNew...)
+		if err := p.upsertTrafficPolicyInstances(ctx, upserts, zones); err != nil {
+			return err
+		}
+	}
+
+	if p.recoveryReadinessEnabled {
+		if err := p.syncRecoveryReadinessResourceSets(ctx, changes, zones); err != nil {
+			return provider.NewSoftErrorf("failed to manage recovery readiness resource sets: %w", err)
+		}
+	}
+
+	if p.manageResolverRules {
+		if err := p.associateResolverRules(ctx, zones); err != nil {
+			return provider.NewSoftErrorf("failed to manage resolver rules: %w", err)
+		}
+	}
+
+	return nil
 }
 
 // submitChanges takes a zone and a collection of Changes and sends them as a single transaction.
@@ -665,6 +856,14 @@ func (p *AWSProvider) submitChanges(ctx context.Context, changes Route53Changes,
 		log.Info("All records are already up to date, there are no changes for the matching hosted zones")
 	}
 
+	if p.transactionalZoneUpdates {
+		return p.submitChangesTransactionally(ctx, changesByZone, zones)
+	}
+
+	if p.maxChangesPerBatch > 0 {
+		return p.submitChangesWithRollback(ctx, changesByZone, zones)
+	}
+
 	var failedZones []string
 	debugLevel := log.DebugLevel
 	for z, cs := range changesByZone {
@@ -778,9 +977,28 @@ func (p *AWSProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoi
 	// hard coded to 'A' type aliases but we also need their 'AAAA' counterparts.
 	var aliasCnameAaaaEndpoints []*endpoint.Endpoint
 
+	if err := p.adjustTrafficPolicyEndpoints(endpoints); err != nil {
+		return nil, err
+	}
+
+	if err := p.adjustInternalTargets(endpoints); err != nil {
+		return nil, err
+	}
+
 	for _, ep := range endpoints {
 		alias := false
 
+		if isTrafficPolicy(ep) {
+			continue
+		}
+
+		normalizeTargets(ep)
+
+		if weight, ok := ep.GetProviderSpecificProperty(providerSpecificWeight); ok && ep.SetIdentifier == "" {
+			ep.SetIdentifier = weightedSetIdentifier(ep, weight)
+			log.Debugf("Modifying endpoint: %v, setting set identifier of weighted record to %q", ep, ep.SetIdentifier)
+		}
+
 		if aliasString, ok := ep.GetProviderSpecificProperty(providerSpecificAlias); ok {
 			alias = aliasString == "true"
 			if alias {
@@ -803,6 +1021,8 @@ func (p *AWSProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoi
 		}
 
 		if alias {
+			// the records of the private zones are the same aliases
+			ep.DeleteProviderSpecificProperty(providerSpecificInternalTarget)
 			if ep.RecordTTL.IsConfigured() {
 				log.Debugf("Modifying endpoint: %v, setting ttl=%v", ep, defaultTTL)
 				ep.RecordTTL = defaultTTL
@@ -838,6 +1058,54 @@ func (p *AWSProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoi
 	return endpoints, nil
 }
 
+// normalizeTargets converts the targets to the form Route53 returns them in, so that targets which only
+// differ in their notation are equal: hostnames are lower case without trailing dot and IPv4-mapped IPv6
+// addresses are IPv4 addresses. An AAAA record whose targets are all IPv4 addresses becomes an A record.
+func normalizeTargets(ep *endpoint.Endpoint) {
+	switch ep.RecordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA:
+		allIPv4 := len(ep.Targets) > 0
+		for i, target := range ep.Targets {
+			addr, err := netip.ParseAddr(target)
+			if err != nil {
+				// the target of an alias record
+				ep.Targets[i] = normalizeHostnameTarget(target)
+				allIPv4 = false
+				continue
+			}
+			addr = addr.Unmap()
+			ep.Targets[i] = addr.String()
+			allIPv4 = allIPv4 && addr.Is4()
+		}
+		if ep.RecordType == endpoint.RecordTypeAAAA && allIPv4 {
+			log.Debugf("Modifying endpoint: %v, setting record type %s of IPv4-mapped IPv6 addresses", ep, endpoint.RecordTypeA)
+			ep.RecordType = endpoint.RecordTypeA
+		}
+	case endpoint.RecordTypeCNAME:
+		for i, target := range ep.Targets {
+			ep.Targets[i] = normalizeHostnameTarget(target)
+		}
+	}
+}
+
+func normalizeHostnameTarget(target string) string {
+	return strings.ToLower(strings.TrimSuffix(target, "."))
+}
+
+// weightedSetIdentifier returns the set identifier of a weighted record without one, so that
+// the resources sharing a hostname with different weights are published as separate records.
+// The resource of the record is used as it is stable when the weight changes.
+func weightedSetIdentifier(ep *endpoint.Endpoint, weight string) string {
+	identifier := ep.Labels[endpoint.ResourceLabelKey]
+	if identifier == "" {
+		identifier = "weight-" + weight
+	}
+	if len(identifier) > maxSetIdentifierLength {
+		identifier = identifier[:maxSetIdentifierLength]
+	}
+	return identifier
+}
+
 // newChange returns a route53 Change
 // returned Change is based on the given record by the given action, e.g.
 // action=ChangeActionCreate returns a change for creation of the record and
@@ -936,6 +1204,8 @@ func (p *AWSProvider) newChange(action route53types.ChangeAction, ep *endpoint.E
 		change.OwnedRecord = ownedRecord
 	}
 
+	change.private = p.internalTargetsChange(action, ep)
+
 	return change
 }
 
@@ -1111,6 +1381,11 @@ func changesByZone(zones map[string]*profiledZone, changeSet Route53Changes) map
 			continue
 		}
 		for _, z := range zones {
+			if isPrivateZone(z.zone) && c.private != nil {
+				changes[*z.zone.Id] = append(changes[*z.zone.Id], c.private)
+				log.Debugf("Adding %s with its internal targets to zone %s [Id: %s]", hostname, *z.zone.Name, *z.zone.Id)
+				continue
+			}
 			if c.ResourceRecordSet.AliasTarget != nil && *c.ResourceRecordSet.AliasTarget.HostedZoneId == sameZoneAlias {
 				// alias record is to be created; target needs to be in the same zone as endpoint
 				// if it's not, this will fail
@@ -1206,6 +1481,9 @@ func isAWSAlias(ep *endpoint.Endpoint) string {
 
 // canonicalHostedZone returns the matching canonical zone for a given hostname.
 func canonicalHostedZone(hostname string) string {
+	// load balancer hostnames may be reported as absolute or with upper case letters
+	hostname = strings.ToLower(strings.TrimSuffix(hostname, "."))
+
 	// strings.HasSuffix is optimized for this specific task and avoids the overhead associated with compiling and executing a regular expression.
 	if strings.HasSuffix(hostname, "aws.com") || strings.HasSuffix(hostname, "aws.com.cn") || strings.HasSuffix(hostname, "tor.com") || strings.HasSuffix(hostname, "ont.com") || strings.HasSuffix(hostname, "ont.net") {
 		parts := strings.Split(hostname, ".")
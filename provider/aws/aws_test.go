@@ -60,6 +60,7 @@ type Route53APIStub struct {
 	zones      map[string]*route53types.HostedZone
 	recordSets map[string]map[string][]route53types.ResourceRecordSet
 	zoneTags   map[string][]route53types.Tag
+	zoneVPCs   map[string][]route53types.VPC
 	m          dynamicMock
 	t          *testing.T
 }
@@ -78,6 +79,7 @@ func NewRoute53APIStub(t *testing.T) *Route53APIStub {
 		zones:      make(map[string]*route53types.HostedZone),
 		recordSets: make(map[string]map[string][]route53types.ResourceRecordSet),
 		zoneTags:   make(map[string][]route53types.Tag),
+		zoneVPCs:   make(map[string][]route53types.VPC),
 		t:          t,
 	}
 }
@@ -129,6 +131,11 @@ func (c *Route53APICounter) CreateHostedZone(ctx context.Context, input *route53
 	return c.wrapped.CreateHostedZone(ctx, input, optFns...)
 }
 
+func (c *Route53APICounter) GetHostedZone(ctx context.Context, input *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error) {
+	c.calls["GetHostedZone"]++
+	return c.wrapped.GetHostedZone(ctx, input, optFns...)
+}
+
 func (c *Route53APICounter) ListHostedZones(ctx context.Context, input *route53.ListHostedZonesInput, optFns ...func(options *route53.Options)) (*route53.ListHostedZonesOutput, error) {
 	c.calls["ListHostedZonesPages"]++
 	return c.wrapped.ListHostedZones(ctx, input, optFns...)
@@ -261,7 +268,27 @@ func (r *Route53APIStub) CreateHostedZone(ctx context.Context, input *route53.Cr
 		Name:   aws.String(name),
 		Config: input.HostedZoneConfig,
 	}
-	return &route53.CreateHostedZoneOutput{HostedZone: r.zones[id]}, nil
+	if input.VPC != nil {
+		r.zoneVPCs[id] = append(r.zoneVPCs[id], *input.VPC)
+	}
+	return &route53.CreateHostedZoneOutput{HostedZone: r.zones[id], DelegationSet: stubDelegationSet(name)}, nil
+}
+
+func (r *Route53APIStub) GetHostedZone(ctx context.Context, input *route53.GetHostedZoneInput, optFns ...func(options *route53.Options)) (*route53.GetHostedZoneOutput, error) {
+	zone, ok := r.zones[*input.Id]
+	if !ok {
+		return nil, fmt.Errorf("hosted zone doesn't exist: %s", *input.Id)
+	}
+	output := &route53.GetHostedZoneOutput{HostedZone: zone, VPCs: r.zoneVPCs[*input.Id]}
+	if zone.Config == nil || !zone.Config.PrivateZone {
+		output.DelegationSet = stubDelegationSet(*zone.Name)
+	}
+	return output, nil
+}
+
+// stubDelegationSet returns the name servers of a public hosted zone of the stub.
+func stubDelegationSet(name string) *route53types.DelegationSet {
+	return &route53types.DelegationSet{NameServers: []string{"ns-1.awsdns." + name, "ns-2.awsdns." + name}}
 }
 
 type dynamicMock struct {
@@ -349,6 +376,17 @@ func TestAWSZones(t *testing.T) {
 	}
 }
 
+func TestAWSZoneNamesByID(t *testing.T) {
+	provider, _ := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter("private"), defaultEvaluateTargetHealth, false, nil)
+
+	names, err := provider.ZoneNamesByID(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"/hostedzone/zone-3.ext-dns-test-2.teapot.zalan.do.": "zone-3.ext-dns-test-2.teapot.zalan.do.",
+		"zone-3.ext-dns-test-2.teapot.zalan.do.":             "zone-3.ext-dns-test-2.teapot.zalan.do.",
+	}, names)
+}
+
 func TestAWSZonesWithTagFilterError(t *testing.T) {
 	client := NewRoute53APIStub(t)
 	provider := &AWSProvider{
@@ -690,6 +728,62 @@ func TestAWSAdjustEndpoints(t *testing.T) {
 	})
 }
 
+func TestAWSNormalizeTargets(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		recordType string
+		targets    endpoint.Targets
+		wantType   string
+		want       endpoint.Targets
+	}{
+		{name: "ELB hostname with trailing dot", recordType: endpoint.RecordTypeCNAME, targets: endpoint.Targets{"foo.eu-central-1.elb.amazonaws.com."}, wantType: endpoint.RecordTypeCNAME, want: endpoint.Targets{"foo.eu-central-1.elb.amazonaws.com"}},
+		{name: "upper case hostname", recordType: endpoint.RecordTypeCNAME, targets: endpoint.Targets{"Foo.Example.COM"}, wantType: endpoint.RecordTypeCNAME, want: endpoint.Targets{"foo.example.com"}},
+		{name: "alias target", recordType: endpoint.RecordTypeA, targets: endpoint.Targets{"Foo.EU-Central-1.elb.amazonaws.com."}, wantType: endpoint.RecordTypeA, want: endpoint.Targets{"foo.eu-central-1.elb.amazonaws.com"}},
+		{name: "IPv4-mapped IPv6 address of A record", recordType: endpoint.RecordTypeA, targets: endpoint.Targets{"::ffff:192.0.2.1", "192.0.2.2"}, wantType: endpoint.RecordTypeA, want: endpoint.Targets{"192.0.2.1", "192.0.2.2"}},
+		{name: "IPv4-mapped IPv6 addresses of AAAA record", recordType: endpoint.RecordTypeAAAA, targets: endpoint.Targets{"::FFFF:192.0.2.1"}, wantType: endpoint.RecordTypeA, want: endpoint.Targets{"192.0.2.1"}},
+		{name: "IPv6 addresses", recordType: endpoint.RecordTypeAAAA, targets: endpoint.Targets{"2001:DB8:0:0:0:0:0:1", "::ffff:192.0.2.1"}, wantType: endpoint.RecordTypeAAAA, want: endpoint.Targets{"2001:db8::1", "192.0.2.1"}},
+		{name: "TXT targets are kept", recordType: endpoint.RecordTypeTXT, targets: endpoint.Targets{"Some Text."}, wantType: endpoint.RecordTypeTXT, want: endpoint.Targets{"Some Text."}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ep := &endpoint.Endpoint{DNSName: "test.zone-1.ext-dns-test-2.teapot.zalan.do", RecordType: tt.recordType, Targets: tt.targets}
+			normalizeTargets(ep)
+			assert.Equal(t, tt.wantType, ep.RecordType)
+			assert.Equal(t, tt.want, ep.Targets)
+		})
+	}
+}
+
+func TestAWSNormalizedTargetsHaveNoChanges(t *testing.T) {
+	provider, _ := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, false, nil)
+	ctx := context.Background()
+
+	desired := func() []*endpoint.Endpoint {
+		return []*endpoint.Endpoint{
+			{DNSName: "cname.zone-1.ext-dns-test-2.teapot.zalan.do", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"Foo.Example.COM."}},
+			{DNSName: "elb.zone-1.ext-dns-test-2.teapot.zalan.do", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"Foo.EU-Central-1.elb.amazonaws.com."}},
+			{DNSName: "mapped.zone-1.ext-dns-test-2.teapot.zalan.do", RecordType: endpoint.RecordTypeAAAA, Targets: endpoint.Targets{"::ffff:192.0.2.1"}},
+		}
+	}
+
+	adjusted, err := provider.AdjustEndpoints(desired())
+	require.NoError(t, err)
+	require.NoError(t, provider.ApplyChanges(ctx, &plan.Changes{Create: adjusted}))
+
+	current, err := provider.Records(ctx)
+	require.NoError(t, err)
+	adjusted, err = provider.AdjustEndpoints(desired())
+	require.NoError(t, err)
+
+	p := &plan.Plan{
+		Policies:       []plan.Policy{&plan.SyncPolicy{}},
+		Current:        current,
+		Desired:        adjusted,
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
+	}
+	changes := p.Calculate().Changes
+	assert.False(t, changes.HasChanges(), "normalized targets must not be changed: %+v", changes)
+}
+
 func TestAWSApplyChanges(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -1961,6 +2055,115 @@ func TestAWSCreateRecordsWithCNAME(t *testing.T) {
 	})
 }
 
+func TestAWSWildcardRecords(t *testing.T) {
+	provider, _ := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, false, nil)
+	ctx := context.Background()
+
+	created := endpoint.NewEndpoint("*.apps.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "1.2.3.4")
+	adjusted, err := provider.AdjustEndpoints([]*endpoint.Endpoint{created})
+	require.NoError(t, err)
+	require.NoError(t, provider.ApplyChanges(ctx, &plan.Changes{Create: adjusted}))
+
+	validateRecords(t, listAWSRecords(t, provider.clients[defaultAWSProfile], "/hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do."), []route53types.ResourceRecordSet{
+		{
+			Name:            aws.String("\\052.apps.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type:            route53types.RRTypeA,
+			TTL:             aws.Int64(300),
+			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String("1.2.3.4")}},
+		},
+	})
+	records, err := provider.Records(ctx)
+	require.NoError(t, err)
+	validateEndpoints(t, provider, records, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("*.apps.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "1.2.3.4"),
+	})
+
+	updated := endpoint.NewEndpoint("*.apps.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "4.3.2.1")
+	adjusted, err = provider.AdjustEndpoints([]*endpoint.Endpoint{updated})
+	require.NoError(t, err)
+	require.NoError(t, provider.ApplyChanges(ctx, &plan.Changes{UpdateOld: records, UpdateNew: adjusted}))
+
+	records, err = provider.Records(ctx)
+	require.NoError(t, err)
+	validateEndpoints(t, provider, records, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("*.apps.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "4.3.2.1"),
+	})
+
+	require.NoError(t, provider.ApplyChanges(ctx, &plan.Changes{Delete: records}))
+
+	assert.Empty(t, listAWSRecords(t, provider.clients[defaultAWSProfile], "/hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do."))
+}
+
+func TestAWSCreateWeightedRecordsWithoutSetIdentifier(t *testing.T) {
+	provider, _ := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, false, nil)
+
+	// two services sharing a hostname with different weights
+	records := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("weighted.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "1.2.3.4").
+			WithProviderSpecific(providerSpecificWeight, "10").
+			WithLabel(endpoint.ResourceLabelKey, "service/default/blue"),
+		endpoint.NewEndpoint("weighted.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "4.3.2.1").
+			WithProviderSpecific(providerSpecificWeight, "90").
+			WithLabel(endpoint.ResourceLabelKey, "service/default/green"),
+		endpoint.NewEndpoint("weighted-no-resource.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "1.2.3.4").
+			WithProviderSpecific(providerSpecificWeight, "5"),
+		// the set identifier of the endpoint is kept
+		endpoint.NewEndpoint("weighted-set-identifier.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "1.2.3.4").
+			WithSetIdentifier("primary").
+			WithProviderSpecific(providerSpecificWeight, "20").
+			WithLabel(endpoint.ResourceLabelKey, "service/default/primary"),
+	}
+
+	adjusted, err := provider.AdjustEndpoints(records)
+	require.NoError(t, err)
+	require.NoError(t, provider.ApplyChanges(context.Background(), &plan.Changes{
+		Create: adjusted,
+	}))
+
+	recordSets := listAWSRecords(t, provider.clients[defaultAWSProfile], "/hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do.")
+
+	validateRecords(t, recordSets, []route53types.ResourceRecordSet{
+		{
+			Name:            aws.String("weighted.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type:            route53types.RRTypeA,
+			TTL:             aws.Int64(300),
+			SetIdentifier:   aws.String("service/default/blue"),
+			Weight:          aws.Int64(10),
+			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String("1.2.3.4")}},
+		},
+		{
+			Name:            aws.String("weighted.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type:            route53types.RRTypeA,
+			TTL:             aws.Int64(300),
+			SetIdentifier:   aws.String("service/default/green"),
+			Weight:          aws.Int64(90),
+			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String("4.3.2.1")}},
+		},
+		{
+			Name:            aws.String("weighted-no-resource.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type:            route53types.RRTypeA,
+			TTL:             aws.Int64(300),
+			SetIdentifier:   aws.String("weight-5"),
+			Weight:          aws.Int64(5),
+			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String("1.2.3.4")}},
+		},
+		{
+			Name:            aws.String("weighted-set-identifier.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type:            route53types.RRTypeA,
+			TTL:             aws.Int64(300),
+			SetIdentifier:   aws.String("primary"),
+			Weight:          aws.Int64(20),
+			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String("1.2.3.4")}},
+		},
+	})
+}
+
+func TestWeightedSetIdentifierLength(t *testing.T) {
+	ep := endpoint.NewEndpoint("weighted.example.com", endpoint.RecordTypeA, "1.2.3.4").
+		WithLabel(endpoint.ResourceLabelKey, "service/default/"+strings.Repeat("a", 200))
+	assert.Len(t, weightedSetIdentifier(ep, "10"), maxSetIdentifierLength)
+}
+
 func TestAWSCreateRecordsWithALIAS(t *testing.T) {
 	for key, evaluateTargetHealth := range map[string]bool{
 		"true":  true,
@@ -2097,6 +2300,76 @@ func TestAWSCanonicalHostedZoneNotExist(t *testing.T) {
 	assert.Containsf(t, buf.String(), "Could not find canonical hosted zone for domain", host)
 }
 
+func TestAWSCanonicalHostedZoneLoadBalancers(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		hostname string
+		expected string
+	}{
+		{name: "network load balancer", hostname: "k8s-default-nginx-0123456789.elb.us-east-1.amazonaws.com", expected: "Z26RNL4JYFTOTI"},
+		{name: "internal network load balancer", hostname: "k8s-default-nginx-0123456789.elb.eu-west-1.amazonaws.com", expected: "Z2IFOLAFXWLO4F"},
+		{name: "application load balancer", hostname: "k8s-default-nginx-0123456789-987654321.us-east-1.elb.amazonaws.com", expected: "Z35SXDOTRQ7X7K"},
+		{name: "internal application load balancer", hostname: "internal-k8s-default-nginx-0123456789-987654321.eu-west-1.elb.amazonaws.com", expected: "Z32O12XQLNTSW2"},
+		{name: "dualstack application load balancer", hostname: "dualstack.k8s-default-nginx-0123456789-987654321.ap-southeast-2.elb.amazonaws.com", expected: "Z1GM3OXH4ZPM65"},
+		{name: "classic load balancer", hostname: "a0123456789abcdef0123456789abcde-987654321.eu-central-1.elb.amazonaws.com", expected: "Z215JYRZR1TBD5"},
+		{name: "network load balancer in china", hostname: "k8s-default-nginx-0123456789.elb.cn-north-1.amazonaws.com.cn", expected: "Z3QFB96KMJ7ED6"},
+		{name: "classic load balancer in govcloud", hostname: "a0123456789abcdef-987654321.us-gov-west-1.elb.amazonaws.com", expected: "Z33AYJ8TM3BH4J"},
+		{name: "global accelerator", hostname: "a0123456789abcdef.awsglobalaccelerator.com", expected: "Z2BJ6XQ5FK7U4H"},
+		{name: "dualstack global accelerator", hostname: "dualstack.a0123456789abcdef.awsglobalaccelerator.com", expected: "Z2BJ6XQ5FK7U4H"},
+		{name: "not a global accelerator", hostname: "a0123456789abcdef.notawsglobalaccelerator.com", expected: ""},
+		{name: "absolute hostname", hostname: "k8s-default-nginx-0123456789.elb.us-east-1.amazonaws.com.", expected: "Z26RNL4JYFTOTI"},
+		{name: "upper case hostname", hostname: "K8S-Default-Nginx-0123456789.ELB.US-EAST-1.amazonaws.com", expected: "Z26RNL4JYFTOTI"},
+		{name: "unknown region", hostname: "k8s-default-nginx-0123456789.elb.eastwest-1.amazonaws.com", expected: ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, canonicalHostedZone(tt.hostname))
+		})
+	}
+}
+
+func TestAWSCreateRecordsWithLoadBalancerALIAS(t *testing.T) {
+	provider, _ := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), false, false, nil)
+
+	loadBalancers := map[string]string{
+		"nlb.zone-1.ext-dns-test-2.teapot.zalan.do":     "k8s-default-nlb-0123456789.elb.us-west-2.amazonaws.com",
+		"alb.zone-1.ext-dns-test-2.teapot.zalan.do":     "k8s-default-alb-0123456789-987654321.us-west-2.elb.amazonaws.com",
+		"classic.zone-1.ext-dns-test-2.teapot.zalan.do": "a0123456789abcdef-987654321.us-west-2.elb.amazonaws.com",
+		"ga.zone-1.ext-dns-test-2.teapot.zalan.do":      "a0123456789abcdef.awsglobalaccelerator.com",
+	}
+	hostedZones := map[string]string{
+		"nlb.zone-1.ext-dns-test-2.teapot.zalan.do":     "Z18D5FSROUN65G",
+		"alb.zone-1.ext-dns-test-2.teapot.zalan.do":     "Z1H1FL5HABSF5",
+		"classic.zone-1.ext-dns-test-2.teapot.zalan.do": "Z1H1FL5HABSF5",
+		"ga.zone-1.ext-dns-test-2.teapot.zalan.do":      "Z2BJ6XQ5FK7U4H",
+	}
+
+	// the endpoints of a service with the hostname of its load balancer in the status
+	var records []*endpoint.Endpoint
+	var expected []route53types.ResourceRecordSet
+	for name, hostname := range loadBalancers {
+		records = append(records, endpoint.NewEndpoint(name, endpoint.RecordTypeCNAME, hostname))
+		for _, recordType := range []route53types.RRType{route53types.RRTypeA, route53types.RRTypeAaaa} {
+			expected = append(expected, route53types.ResourceRecordSet{
+				AliasTarget: &route53types.AliasTarget{
+					DNSName:              aws.String(hostname + "."),
+					EvaluateTargetHealth: false,
+					HostedZoneId:         aws.String(hostedZones[name]),
+				},
+				Name: aws.String(name + "."),
+				Type: recordType,
+			})
+		}
+	}
+
+	adjusted, err := provider.AdjustEndpoints(records)
+	require.NoError(t, err)
+	require.NoError(t, provider.ApplyChanges(context.Background(), &plan.Changes{
+		Create: adjusted,
+	}))
+
+	validateRecords(t, listAWSRecords(t, provider.clients[defaultAWSProfile], "/hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do."), expected)
+}
+
 func BenchmarkTestAWSCanonicalHostedZone(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		for suffix := range canonicalHostedZones {
@@ -115,6 +115,11 @@ func (r Route53APIFixtureStub) CreateHostedZone(ctx context.Context, input *rout
 	panic("implement me")
 }
 
+func (r Route53APIFixtureStub) GetHostedZone(ctx context.Context, input *route53.GetHostedZoneInput, optFns ...func(*route53.Options)) (*route53.GetHostedZoneOutput, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
 func (r Route53APIFixtureStub) ListHostedZones(ctx context.Context, input *route53.ListHostedZonesInput, optFns ...func(options *route53.Options)) (*route53.ListHostedZonesOutput, error) {
 	r.calls["listhostedzones"]++
 	output := &route53.ListHostedZonesOutput{}
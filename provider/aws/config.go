@@ -19,14 +19,23 @@ package aws
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net/http"
+	"os"
+	"slices"
 	"strings"
 
 	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
 	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/ec2rolecreds"
+	"github.com/aws/aws-sdk-go-v2/credentials/ssocreds"
 	stscredsv2 "github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
+	"github.com/aws/aws-sdk-go-v2/service/sso"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
+	ststypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
 	"github.com/linki/instrumented_http"
 	"github.com/sirupsen/logrus"
 
@@ -39,37 +48,75 @@ type AWSSessionConfig struct {
 	AssumeRoleExternalID string
 	APIRetries           int
 	Profile              string
+	// EC2MetadataV2Only gets the instance profile credentials with IMDSv2 only, without falling back to IMDSv1
+	EC2MetadataV2Only bool
+	// AssumeRoleSessionTags are the tags of the session of the assumed role
+	AssumeRoleSessionTags map[string]string
+	// TransitiveTagKeys are the keys of the session tags passed to the roles assumed from the session
+	TransitiveTagKeys []string
+	// RequestTag is added to the user agent of the API requests, which is recorded by CloudTrail
+	RequestTag string
+	// EndpointURL replaces the endpoints of the AWS services, e.g. to use LocalStack
+	EndpointURL string
+	// SSOStartURL, SSOAccountID and SSORoleName select the role of the AWS SSO (IAM Identity Center) user portal whose
+	// credentials are used, with the token cached by `aws sso login`
+	SSOStartURL  string
+	SSOAccountID string
+	SSORoleName  string
+	// SSORegion is the region of the user portal, the region of the configuration is used when it's empty
+	SSORegion string
 }
 
-func CreateDefaultV2Config(cfg *externaldns.Config) awsv2.Config {
-	result, err := newV2Config(
-		AWSSessionConfig{
-			AssumeRole:           cfg.AWSAssumeRole,
-			AssumeRoleExternalID: cfg.AWSAssumeRoleExternalID,
-			APIRetries:           cfg.AWSAPIRetries,
-		},
-	)
+// requestTagUserAgentKey is the key of the request tag in the user agent of the API requests.
+const requestTagUserAgentKey = "external-dns-request-tag"
+
+// newAWSSessionConfig returns the session configuration given by the flags, for the default profile.
+func newAWSSessionConfig(cfg *externaldns.Config) AWSSessionConfig {
+	return AWSSessionConfig{
+		AssumeRole:            cfg.AWSAssumeRole,
+		AssumeRoleExternalID:  cfg.AWSAssumeRoleExternalID,
+		APIRetries:            cfg.AWSAPIRetries,
+		EC2MetadataV2Only:     cfg.AWSEC2MetadataV2Only,
+		AssumeRoleSessionTags: cfg.AWSAssumeRoleSessionTags,
+		TransitiveTagKeys:     cfg.AWSAssumeRoleTransitiveTagKeys,
+		RequestTag:            cfg.AWSRequestTag,
+		EndpointURL:           cfg.AWSEndpointURL,
+		SSOStartURL:           cfg.AWSSSOStartURL,
+		SSOAccountID:          cfg.AWSSSOAccountID,
+		SSORoleName:           cfg.AWSSSORoleName,
+		SSORegion:             cfg.AWSSSORegion,
+	}
+}
+
+func CreateDefaultV2Config(ctx context.Context, cfg *externaldns.Config) awsv2.Config {
+	result, err := newV2Config(ctx, newAWSSessionConfig(cfg))
 	if err != nil {
 		logrus.Fatal(err)
 	}
 	return result
 }
 
-func CreateV2Configs(cfg *externaldns.Config) map[string]awsv2.Config {
+func CreateV2Configs(ctx context.Context, cfg *externaldns.Config) map[string]awsv2.Config {
 	result := make(map[string]awsv2.Config)
-	if len(cfg.AWSProfiles) == 0 || (len(cfg.AWSProfiles) == 1 && cfg.AWSProfiles[0] == "") {
-		cfg := CreateDefaultV2Config(cfg)
+	if len(cfg.AWSDomainRoles) > 0 {
+		// a profile named after the domain is created for each role, see NewDomainZoneFilter
+		for domain, role := range cfg.AWSDomainRoles {
+			sessionConfig := newAWSSessionConfig(cfg)
+			sessionConfig.AssumeRole = role
+			cfg, err := newV2Config(ctx, sessionConfig)
+			if err != nil {
+				logrus.Fatal(err)
+			}
+			result[domain] = cfg
+		}
+	} else if len(cfg.AWSProfiles) == 0 || (len(cfg.AWSProfiles) == 1 && cfg.AWSProfiles[0] == "") {
+		cfg := CreateDefaultV2Config(ctx, cfg)
 		result[defaultAWSProfile] = cfg
 	} else {
 		for _, profile := range cfg.AWSProfiles {
-			cfg, err := newV2Config(
-				AWSSessionConfig{
-					AssumeRole:           cfg.AWSAssumeRole,
-					AssumeRoleExternalID: cfg.AWSAssumeRoleExternalID,
-					APIRetries:           cfg.AWSAPIRetries,
-					Profile:              profile,
-				},
-			)
+			sessionConfig := newAWSSessionConfig(cfg)
+			sessionConfig.Profile = profile
+			cfg, err := newV2Config(ctx, sessionConfig)
 			if err != nil {
 				logrus.Fatal(err)
 			}
@@ -79,7 +126,53 @@ func CreateV2Configs(cfg *externaldns.Config) map[string]awsv2.Config {
 	return result
 }
 
-func newV2Config(awsConfig AWSSessionConfig) (awsv2.Config, error) {
+// newSSOProvider returns the provider of the credentials of the role of the AWS SSO user portal, which exchanges
+// the token cached by `aws sso login` for the start URL.
+func newSSOProvider(client ssocreds.GetRoleCredentialsAPIClient, awsConfig AWSSessionConfig) *ssocreds.Provider {
+	logrus.Infof("Using the AWS SSO role %s of account %s", awsConfig.SSORoleName, awsConfig.SSOAccountID)
+	return ssocreds.New(client, awsConfig.SSOAccountID, awsConfig.SSORoleName, awsConfig.SSOStartURL)
+}
+
+// newAssumeRoleProvider returns the provider of the credentials of the role to assume, with the external id
+// and the session tags of the configuration.
+func newAssumeRoleProvider(client stscredsv2.AssumeRoleAPIClient, awsConfig AWSSessionConfig) *stscredsv2.AssumeRoleProvider {
+	var assumeRoleOpts []func(*stscredsv2.AssumeRoleOptions)
+	if awsConfig.AssumeRoleExternalID != "" {
+		logrus.Infof("Assuming role %s with external id", awsConfig.AssumeRole)
+		logrus.Debugf("External id: %s", awsConfig.AssumeRoleExternalID)
+		assumeRoleOpts = append(assumeRoleOpts, func(opts *stscredsv2.AssumeRoleOptions) {
+			opts.ExternalID = &awsConfig.AssumeRoleExternalID
+		})
+	} else {
+		logrus.Infof("Assuming role: %s", awsConfig.AssumeRole)
+	}
+	if len(awsConfig.AssumeRoleSessionTags) > 0 {
+		keys := slices.Sorted(maps.Keys(awsConfig.AssumeRoleSessionTags))
+		tags := make([]ststypes.Tag, 0, len(keys))
+		for _, key := range keys {
+			tags = append(tags, ststypes.Tag{Key: awsv2.String(key), Value: awsv2.String(awsConfig.AssumeRoleSessionTags[key])})
+		}
+		logrus.Debugf("Session tags: %v, transitive tag keys: %v", keys, awsConfig.TransitiveTagKeys)
+		assumeRoleOpts = append(assumeRoleOpts, func(opts *stscredsv2.AssumeRoleOptions) {
+			opts.Tags = tags
+			opts.TransitiveTagKeys = awsConfig.TransitiveTagKeys
+		})
+	}
+	return stscredsv2.NewAssumeRoleProvider(client, awsConfig.AssumeRole, assumeRoleOpts...)
+}
+
+// imdsOptions returns the options of the EC2 instance metadata client, the token of IMDSv2 is required
+// rather than falling back to IMDSv1 when it can't be obtained.
+func imdsOptions(awsConfig AWSSessionConfig) imds.Options {
+	var opts imds.Options
+	if awsConfig.EC2MetadataV2Only {
+		opts.EnableFallback = awsv2.FalseTernary
+	}
+	return opts
+}
+
+// newV2Config returns the configuration of the AWS clients, the web identity token file is watched until ctx is done.
+func newV2Config(ctx context.Context, awsConfig AWSSessionConfig) (awsv2.Config, error) {
 	defaultOpts := []func(*config.LoadOptions) error{
 		config.WithRetryer(func() awsv2.Retryer {
 			return retry.AddWithMaxAttempts(retry.NewStandard(), awsConfig.APIRetries)
@@ -92,28 +185,48 @@ func newV2Config(awsConfig AWSSessionConfig) (awsv2.Config, error) {
 		})),
 		config.WithSharedConfigProfile(awsConfig.Profile),
 	}
+	if awsConfig.EC2MetadataV2Only {
+		defaultOpts = append(defaultOpts, config.WithEC2RoleCredentialOptions(func(o *ec2rolecreds.Options) {
+			o.Client = imds.New(imdsOptions(awsConfig))
+		}))
+	}
 
-	cfg, err := config.LoadDefaultConfig(context.Background(), defaultOpts...)
+	if awsConfig.EndpointURL != "" {
+		defaultOpts = append(defaultOpts, config.WithBaseEndpoint(awsConfig.EndpointURL))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, defaultOpts...)
 	if err != nil {
 		return awsv2.Config{}, fmt.Errorf("instantiating AWS config: %w", err)
 	}
 
+	// the clients of Route53, STS and the other services are all created from this configuration
+	if awsConfig.RequestTag != "" {
+		cfg.APIOptions = append(cfg.APIOptions, awsmiddleware.AddUserAgentKeyValue(requestTagUserAgentKey, awsConfig.RequestTag))
+	}
+
+	if awsConfig.SSOStartURL != "" {
+		ssoSvc := sso.NewFromConfig(cfg, func(o *sso.Options) {
+			if awsConfig.SSORegion != "" {
+				o.Region = awsConfig.SSORegion
+			}
+		})
+		cfg.Credentials = awsv2.NewCredentialsCache(newSSOProvider(ssoSvc, awsConfig))
+	}
+
+	credentials := []awsv2.CredentialsProvider{cfg.Credentials}
 	if awsConfig.AssumeRole != "" {
 		stsSvc := sts.NewFromConfig(cfg)
-		var assumeRoleOpts []func(*stscredsv2.AssumeRoleOptions)
-		if awsConfig.AssumeRoleExternalID != "" {
-			logrus.Infof("Assuming role %s with external id", awsConfig.AssumeRole)
-			logrus.Debugf("External id: %s", awsConfig.AssumeRoleExternalID)
-			assumeRoleOpts = []func(*stscredsv2.AssumeRoleOptions){
-				func(opts *stscredsv2.AssumeRoleOptions) {
-					opts.ExternalID = &awsConfig.AssumeRoleExternalID
-				},
-			}
-		} else {
-			logrus.Infof("Assuming role: %s", awsConfig.AssumeRole)
-		}
-		creds := stscredsv2.NewAssumeRoleProvider(stsSvc, awsConfig.AssumeRole, assumeRoleOpts...)
+		creds := newAssumeRoleProvider(stsSvc, awsConfig)
+		baseCredentials := cfg.Credentials
 		cfg.Credentials = awsv2.NewCredentialsCache(creds)
+		credentials = []awsv2.CredentialsProvider{baseCredentials, cfg.Credentials}
+	}
+
+	if tokenFile := os.Getenv(webIdentityTokenFileEnv); tokenFile != "" {
+		if err := watchWebIdentityToken(ctx, tokenFile, credentials...); err != nil {
+			logrus.Warnf("The AWS credentials will only be refreshed when they expire: %v", err)
+		}
 	}
 
 	return cfg, nil
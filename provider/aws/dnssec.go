@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	dnssecResolvConf      = "/etc/resolv.conf"
+	dnssecQueryTimeout    = 5 * time.Second
+	dnssecEDNS0UDPSize    = 4096
+	dnssecMetricSubsystem = "aws"
+)
+
+var dnssecValidationFailuresTotal = metrics.NewCounterVecWithOpts(
+	prometheus.CounterOpts{
+		Namespace: "external_dns",
+		Subsystem: dnssecMetricSubsystem,
+		Name:      "dnssec_validation_failures_total",
+		Help:      "Number of changes skipped because the existing record failed DNSSEC validation.",
+	},
+	[]string{"record_type"},
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(dnssecValidationFailuresTotal)
+}
+
+// DNSSECResolver is the subset of the miekg/dns client that we actually use, it is implemented by *dns.Client.
+type DNSSECResolver interface {
+	ExchangeContext(ctx context.Context, m *dns.Msg, address string) (*dns.Msg, time.Duration, error)
+}
+
+// DNSSECConfig contains the configuration used to validate the existing records before they are overwritten.
+type DNSSECConfig struct {
+	// Address is the host:port of the validating resolver, the first nameserver of /etc/resolv.conf is used when empty.
+	Address string
+	// Resolver is used to query the validating resolver.
+	Resolver DNSSECResolver
+}
+
+// withDefaults returns the config with the system resolver and a TCP client, as signed answers can exceed the size of a UDP message.
+func (c DNSSECConfig) withDefaults() (DNSSECConfig, error) {
+	if c.Address == "" {
+		conf, err := dns.ClientConfigFromFile(dnssecResolvConf)
+		if err != nil {
+			return c, fmt.Errorf("failed to read the DNSSEC resolver from %s: %w", dnssecResolvConf, err)
+		}
+		if len(conf.Servers) == 0 {
+			return c, fmt.Errorf("no DNSSEC resolver found in %s", dnssecResolvConf)
+		}
+		c.Address = net.JoinHostPort(conf.Servers[0], conf.Port)
+	}
+	if c.Resolver == nil {
+		c.Resolver = &dns.Client{Net: "tcp", Timeout: dnssecQueryTimeout}
+	}
+	return c, nil
+}
+
+// dnssecValidatedChanges returns the changes without the updates and deletions of the records of public zones
+// which fail DNSSEC validation, records of private zones can't be queried through a public resolver and are kept.
+func (p *AWSProvider) dnssecValidatedChanges(ctx context.Context, changes *plan.Changes, zones map[string]*profiledZone) *plan.Changes {
+	validated := &plan.Changes{Create: changes.Create}
+
+	for i, current := range changes.UpdateOld {
+		if p.isDNSSECValid(ctx, current, zones) {
+			validated.UpdateOld = append(validated.UpdateOld, current)
+			validated.UpdateNew = append(validated.UpdateNew, changes.UpdateNew[i])
+		}
+	}
+
+	for _, current := range changes.Delete {
+		if p.isDNSSECValid(ctx, current, zones) {
+			validated.Delete = append(validated.Delete, current)
+		}
+	}
+
+	return validated
+}
+
+// isDNSSECValid queries the record through the validating resolver, the answer must be authenticated.
+func (p *AWSProvider) isDNSSECValid(ctx context.Context, ep *endpoint.Endpoint, zones map[string]*profiledZone) bool {
+	for _, z := range suitableZones(provider.EnsureTrailingDot(ep.DNSName), zones) {
+		if z.zone.Config != nil && z.zone.Config.PrivateZone {
+			return true
+		}
+	}
+
+	qtype, ok := dns.StringToType[ep.RecordType]
+	if !ok {
+		return true
+	}
+
+	logFields := log.Fields{
+		"record": ep.DNSName,
+		"type":   ep.RecordType,
+	}
+
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(ep.DNSName), qtype)
+	m.SetEdns0(dnssecEDNS0UDPSize, true)
+
+	resp, _, err := p.dnssecConfig.Resolver.ExchangeContext(ctx, m, p.dnssecConfig.Address)
+	switch {
+	case err != nil:
+		log.WithFields(logFields).Warnf("Skipping change, DNSSEC validation of the existing record failed: %v", err)
+	case resp.Rcode != dns.RcodeSuccess && resp.Rcode != dns.RcodeNameError:
+		log.WithFields(logFields).Warnf("Skipping change, DNSSEC validation of the existing record failed: %s", dns.RcodeToString[resp.Rcode])
+	case !resp.AuthenticatedData:
+		log.WithFields(logFields).Warn("Skipping change, the existing record is not authenticated by DNSSEC")
+	default:
+		return true
+	}
+
+	dnssecValidationFailuresTotal.CounterVec.WithLabelValues(ep.RecordType).Inc()
+	return false
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// Compile time check for interface conformance
+var _ DNSSECResolver = &dns.Client{}
+
+// validatingResolverStub answers the queries of a validating resolver, the answer of a name is authenticated unless configured otherwise.
+type validatingResolverStub struct {
+	rcodes        map[string]int
+	unsigned      map[string]bool
+	errors        map[string]error
+	queries       []string
+	lastAddress   string
+	lastDNSSECOK  bool
+	lastQueryType uint16
+}
+
+func (r *validatingResolverStub) ExchangeContext(_ context.Context, m *dns.Msg, address string) (*dns.Msg, time.Duration, error) {
+	name := m.Question[0].Name
+	r.queries = append(r.queries, name)
+	r.lastAddress = address
+	r.lastQueryType = m.Question[0].Qtype
+	r.lastDNSSECOK = m.IsEdns0() != nil && m.IsEdns0().Do()
+
+	if err := r.errors[name]; err != nil {
+		return nil, 0, err
+	}
+	resp := new(dns.Msg)
+	resp.SetReply(m)
+	if rcode, ok := r.rcodes[name]; ok {
+		resp.Rcode = rcode
+	}
+	resp.AuthenticatedData = resp.Rcode == dns.RcodeSuccess && !r.unsigned[name]
+	return resp, 0, nil
+}
+
+func dnssecTestRecord(name, value string) route53types.ResourceRecordSet {
+	return route53types.ResourceRecordSet{
+		Name:            aws.String(name),
+		Type:            route53types.RRTypeA,
+		TTL:             aws.Int64(defaultTTL),
+		ResourceRecords: []route53types.ResourceRecord{{Value: aws.String(value)}},
+	}
+}
+
+func newAWSProviderWithDNSSEC(t *testing.T, resolver *validatingResolverStub) *AWSProvider {
+	p, _ := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, false, []route53types.ResourceRecordSet{
+		dnssecTestRecord("valid.zone-1.ext-dns-test-2.teapot.zalan.do.", "8.8.8.8"),
+		dnssecTestRecord("bogus.zone-1.ext-dns-test-2.teapot.zalan.do.", "8.8.8.8"),
+		dnssecTestRecord("unsigned.zone-1.ext-dns-test-2.teapot.zalan.do.", "8.8.8.8"),
+		dnssecTestRecord("unreachable.zone-1.ext-dns-test-2.teapot.zalan.do.", "8.8.8.8"),
+		dnssecTestRecord("delete-valid.zone-1.ext-dns-test-2.teapot.zalan.do.", "8.8.8.8"),
+		dnssecTestRecord("delete-bogus.zone-1.ext-dns-test-2.teapot.zalan.do.", "8.8.8.8"),
+		dnssecTestRecord("private.zone-3.ext-dns-test-2.teapot.zalan.do.", "8.8.8.8"),
+	})
+	p.validateDNSSEC = true
+	p.dnssecConfig = DNSSECConfig{Address: "192.0.2.53:53", Resolver: resolver}
+	return p
+}
+
+func TestAWSApplyChangesValidateDNSSEC(t *testing.T) {
+	resolver := &validatingResolverStub{
+		rcodes: map[string]int{
+			"bogus.zone-1.ext-dns-test-2.teapot.zalan.do.":        dns.RcodeServerFailure,
+			"delete-bogus.zone-1.ext-dns-test-2.teapot.zalan.do.": dns.RcodeServerFailure,
+		},
+		unsigned: map[string]bool{"unsigned.zone-1.ext-dns-test-2.teapot.zalan.do.": true},
+		errors:   map[string]error{"unreachable.zone-1.ext-dns-test-2.teapot.zalan.do.": errors.New("i/o timeout")},
+	}
+	p := newAWSProviderWithDNSSEC(t, resolver)
+	failures := testutil.ToFloat64(dnssecValidationFailuresTotal.CounterVec.WithLabelValues(endpoint.RecordTypeA))
+
+	updateOld := func(name string) *endpoint.Endpoint {
+		return endpoint.NewEndpointWithTTL(name, endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "8.8.8.8")
+	}
+	updateNew := func(name string) *endpoint.Endpoint {
+		return endpoint.NewEndpointWithTTL(name, endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "1.2.3.4")
+	}
+	names := []string{
+		"valid.zone-1.ext-dns-test-2.teapot.zalan.do",
+		"bogus.zone-1.ext-dns-test-2.teapot.zalan.do",
+		"unsigned.zone-1.ext-dns-test-2.teapot.zalan.do",
+		"unreachable.zone-1.ext-dns-test-2.teapot.zalan.do",
+		"private.zone-3.ext-dns-test-2.teapot.zalan.do",
+	}
+	changes := &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("create.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "1.2.3.4"),
+		},
+		Delete: []*endpoint.Endpoint{
+			updateOld("delete-valid.zone-1.ext-dns-test-2.teapot.zalan.do"),
+			updateOld("delete-bogus.zone-1.ext-dns-test-2.teapot.zalan.do"),
+		},
+	}
+	for _, name := range names {
+		changes.UpdateOld = append(changes.UpdateOld, updateOld(name))
+		changes.UpdateNew = append(changes.UpdateNew, updateNew(name))
+	}
+
+	require.NoError(t, p.ApplyChanges(context.Background(), changes))
+
+	assert.Equal(t, "192.0.2.53:53", resolver.lastAddress)
+	assert.True(t, resolver.lastDNSSECOK, "the DNSSEC OK bit must be set")
+	assert.Equal(t, dns.TypeA, resolver.lastQueryType)
+	assert.NotContains(t, resolver.queries, "create.zone-1.ext-dns-test-2.teapot.zalan.do.", "created records must not be validated")
+	assert.NotContains(t, resolver.queries, "private.zone-3.ext-dns-test-2.teapot.zalan.do.", "records of private zones must not be validated")
+	assert.InDelta(t, failures+4, testutil.ToFloat64(dnssecValidationFailuresTotal.CounterVec.WithLabelValues(endpoint.RecordTypeA)), 0)
+
+	validateRecords(t, listAWSRecords(t, p.clients[defaultAWSProfile], "/hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do."), []route53types.ResourceRecordSet{
+		dnssecTestRecord("create.zone-1.ext-dns-test-2.teapot.zalan.do.", "1.2.3.4"),
+		dnssecTestRecord("valid.zone-1.ext-dns-test-2.teapot.zalan.do.", "1.2.3.4"),
+		dnssecTestRecord("bogus.zone-1.ext-dns-test-2.teapot.zalan.do.", "8.8.8.8"),
+		dnssecTestRecord("unsigned.zone-1.ext-dns-test-2.teapot.zalan.do.", "8.8.8.8"),
+		dnssecTestRecord("unreachable.zone-1.ext-dns-test-2.teapot.zalan.do.", "8.8.8.8"),
+		dnssecTestRecord("delete-bogus.zone-1.ext-dns-test-2.teapot.zalan.do.", "8.8.8.8"),
+	})
+	validateRecords(t, listAWSRecords(t, p.clients[defaultAWSProfile], "/hostedzone/zone-3.ext-dns-test-2.teapot.zalan.do."), []route53types.ResourceRecordSet{
+		dnssecTestRecord("private.zone-3.ext-dns-test-2.teapot.zalan.do.", "1.2.3.4"),
+	})
+}
+
+func TestAWSApplyChangesValidateDNSSECNameError(t *testing.T) {
+	// a validating resolver authenticates the denial of existence of a name
+	resolver := &validatingResolverStub{}
+	p := newAWSProviderWithDNSSEC(t, resolver)
+
+	changes := p.dnssecValidatedChanges(context.Background(), &plan.Changes{
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("missing.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeTXT, "\"heritage=external-dns\"")},
+	}, nil)
+	assert.Len(t, changes.Delete, 1)
+	assert.Equal(t, dns.TypeTXT, resolver.lastQueryType)
+}
+
+func TestDNSSECConfigWithDefaults(t *testing.T) {
+	resolver := &validatingResolverStub{}
+	config, err := DNSSECConfig{Address: "192.0.2.53:53", Resolver: resolver}.withDefaults()
+	require.NoError(t, err)
+	assert.Equal(t, "192.0.2.53:53", config.Address)
+	assert.Same(t, resolver, config.Resolver)
+
+	config, err = DNSSECConfig{Address: "192.0.2.53:53"}.withDefaults()
+	require.NoError(t, err)
+	assert.Equal(t, &dns.Client{Net: "tcp", Timeout: dnssecQueryTimeout}, config.Resolver)
+}
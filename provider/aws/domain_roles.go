@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// DomainZoneFilter maps the profiles of the provider to the domain each of them is configured for, so that
+// the zones of a domain are only managed with the role assumed for it. The profiles which aren't in the
+// filter manage every zone.
+type DomainZoneFilter map[string]string
+
+// Match reports whether the zone can be managed with the profile, which is the case when the profile is
+// configured for the most specific domain of the filter the zone belongs to.
+func (f DomainZoneFilter) Match(profile, zoneName string) bool {
+	domain, ok := f[profile]
+	if !ok {
+		return true
+	}
+	if !endpoint.NewDomainFilter([]string{domain}).Match(zoneName) {
+		return false
+	}
+	for other, otherDomain := range f {
+		if other == profile || !endpoint.NewDomainFilter([]string{otherDomain}).Match(zoneName) {
+			continue
+		}
+		if len(normalizeDomain(otherDomain)) > len(normalizeDomain(domain)) || (otherDomain == domain && other < profile) {
+			return false
+		}
+	}
+	return true
+}
+
+// NewDomainZoneFilter returns the filter of the profiles created by CreateV2Configs for the roles of domainRoles,
+// which are named after their domain.
+func NewDomainZoneFilter(domainRoles map[string]string) DomainZoneFilter {
+	filter := make(DomainZoneFilter, len(domainRoles))
+	for domain := range domainRoles {
+		filter[domain] = domain
+	}
+	return filter
+}
+
+func normalizeDomain(domain string) string {
+	return strings.ToLower(strings.Trim(domain, "."))
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainZoneFilterMatch(t *testing.T) {
+	filter := NewDomainZoneFilter(map[string]string{
+		"example.com":     "arn:aws:iam::123456789012:role/example-com",
+		"sub.example.com": "arn:aws:iam::123456789012:role/sub-example-com",
+		"example.org":     "arn:aws:iam::123456789012:role/example-org",
+	})
+
+	for _, tt := range []struct {
+		profile  string
+		zone     string
+		expected bool
+	}{
+		{"example.com", "example.com.", true},
+		{"example.com", "other.example.com.", true},
+		{"example.com", "sub.example.com.", false},
+		{"sub.example.com", "sub.example.com.", true},
+		{"sub.example.com", "deep.sub.example.com.", true},
+		{"sub.example.com", "example.com.", false},
+		{"example.org", "example.com.", false},
+		{"example.org", "example.org.", true},
+		{defaultAWSProfile, "example.com.", true},
+	} {
+		assert.Equal(t, tt.expected, filter.Match(tt.profile, tt.zone), "profile %s, zone %s", tt.profile, tt.zone)
+	}
+
+	assert.True(t, DomainZoneFilter(nil).Match(defaultAWSProfile, "example.com."))
+}
+
+func TestAWSZonesWithDomainZoneFilter(t *testing.T) {
+	comClient := NewRoute53APIStub(t)
+	orgClient := NewRoute53APIStub(t)
+	p := &AWSProvider{
+		clients: map[string]Route53API{
+			"example.com": comClient,
+			"example.org": orgClient,
+		},
+		domainZoneFilter: NewDomainZoneFilter(map[string]string{
+			"example.com": "arn:aws:iam::123456789012:role/example-com",
+			"example.org": "arn:aws:iam::123456789012:role/example-org",
+		}),
+		zonesCache: &zonesListCache{duration: 1 * time.Minute},
+	}
+
+	// both roles can list every zone of the account
+	for _, client := range []*Route53APIStub{comClient, orgClient} {
+		for _, name := range []string{"example.com.", "example.org.", "example.net."} {
+			_, err := client.CreateHostedZone(context.Background(), &route53.CreateHostedZoneInput{
+				CallerReference:  aws.String("external-dns.alpha.kubernetes.io/test-zone"),
+				Name:             aws.String(name),
+				HostedZoneConfig: &route53types.HostedZoneConfig{},
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	zones, err := p.zones(context.Background())
+	require.NoError(t, err)
+
+	profiles := make(map[string]string, len(zones))
+	for id, z := range zones {
+		profiles[id] = z.profile
+	}
+	assert.Equal(t, map[string]string{
+		"/hostedzone/example.com.": "example.com",
+		"/hostedzone/example.org.": "example.org",
+	}, profiles)
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"net/netip"
+	"slices"
+	"strings"
+
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// providerSpecificInternalTarget holds the comma separated targets of an endpoint in the private zones,
+// for split-horizon setups where a hostname resolves to different targets internally.
+const providerSpecificInternalTarget = "aws/internal-target"
+
+// adjustInternalTargets checks the internal targets of the endpoints, which replace their targets in the records of
+// the private zones at change time. The endpoints of a hostname without public zone use their internal targets right
+// away, and the internal targets of a hostname without private zone are dropped.
+func (p *AWSProvider) adjustInternalTargets(endpoints []*endpoint.Endpoint) error {
+	var zones map[string]*profiledZone
+	for _, ep := range endpoints {
+		value, ok := ep.GetProviderSpecificProperty(providerSpecificInternalTarget)
+		if !ok || isTrafficPolicy(ep) {
+			continue
+		}
+		ep.DeleteProviderSpecificProperty(providerSpecificInternalTarget)
+
+		normalizeTargets(ep)
+		internal := &endpoint.Endpoint{RecordType: ep.RecordType, Targets: endpoint.NewTargets(strings.Split(value, ",")...)}
+		if !internalTargetsMatchType(internal.RecordType, internal.Targets) {
+			log.Warnf("Ignoring the internal targets %s of %s record %s, they don't match the record type", internal.Targets, ep.RecordType, ep.DNSName)
+			continue
+		}
+		normalizeTargets(internal)
+		if internal.Targets.Same(ep.Targets) {
+			continue
+		}
+
+		if zones == nil {
+			var err error
+			if zones, err = p.zones(context.Background()); err != nil {
+				return provider.NewSoftErrorf("failed to list zones, not adjusting the internal targets: %w", err)
+			}
+		}
+		var private, public bool
+		for _, z := range suitableZones(provider.EnsureTrailingDot(ep.DNSName), zones) {
+			if isPrivateZone(z.zone) {
+				private = true
+			} else {
+				public = true
+			}
+		}
+		switch {
+		case !private:
+		case !public:
+			log.Debugf("Modifying endpoint: %v, using the internal targets %s as it only has private zones", ep, internal.Targets)
+			ep.Targets = internal.Targets
+		default:
+			ep.SetProviderSpecificProperty(providerSpecificInternalTarget, strings.Join(internal.Targets, ","))
+		}
+	}
+	return nil
+}
+
+// internalTargetsChange returns the change of the records of the private zones, using the internal targets of the
+// endpoint, or nil when it has none.
+func (p *AWSProvider) internalTargetsChange(action route53types.ChangeAction, ep *endpoint.Endpoint) *Route53Change {
+	value, ok := ep.GetProviderSpecificProperty(providerSpecificInternalTarget)
+	if !ok {
+		return nil
+	}
+	internal := ep.DeepCopy()
+	internal.Targets = endpoint.NewTargets(strings.Split(value, ",")...)
+	internal.DeleteProviderSpecificProperty(providerSpecificInternalTarget)
+	return p.newChange(action, internal)
+}
+
+// withInternalTargets returns the endpoints of the public and the private zones. The records of the private zones
+// differing from the records of the public zones are represented as the endpoints they were changed from, with the
+// targets of the latter and the internal targets property.
+func withInternalTargets(public, private []*endpoint.Endpoint) []*endpoint.Endpoint {
+	byKey := map[endpoint.EndpointKey]*endpoint.Endpoint{}
+	for _, ep := range public {
+		if _, ok := byKey[ep.Key()]; !ok {
+			byKey[ep.Key()] = ep
+		}
+	}
+	for _, ep := range private {
+		counterpart, ok := byKey[ep.Key()]
+		if !ok || ep.Targets.Same(counterpart.Targets) || isAlias(ep) || isAlias(counterpart) {
+			continue
+		}
+		counterpart.SetProviderSpecificProperty(providerSpecificInternalTarget, strings.Join(ep.Targets, ","))
+		ep.SetProviderSpecificProperty(providerSpecificInternalTarget, strings.Join(ep.Targets, ","))
+		ep.Targets = slices.Clone(counterpart.Targets)
+	}
+	return append(public, private...)
+}
+
+// isAlias reports whether the endpoint read from a zone is an alias record.
+func isAlias(ep *endpoint.Endpoint) bool {
+	alias, _ := ep.GetProviderSpecificProperty(providerSpecificAlias)
+	return alias == "true"
+}
+
+// internalTargetsMatchType reports whether the targets can replace the targets of a record of the given type.
+func internalTargetsMatchType(recordType string, targets endpoint.Targets) bool {
+	if len(targets) == 0 {
+		return false
+	}
+	for _, target := range targets {
+		addr, err := netip.ParseAddr(target)
+		switch recordType {
+		case endpoint.RecordTypeA:
+			if err != nil || !addr.Unmap().Is4() {
+				return false
+			}
+		case endpoint.RecordTypeAAAA:
+			if err != nil || addr.Unmap().Is4() {
+				return false
+			}
+		case endpoint.RecordTypeCNAME:
+			if err == nil || target == "" {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
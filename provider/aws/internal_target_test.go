@@ -0,0 +1,180 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	splitHorizonHostname      = "app.split.ext-dns-test-2.teapot.zalan.do"
+	splitHorizonPublicZoneID  = "/hostedzone/split-public"
+	splitHorizonPrivateZoneID = "/hostedzone/split-private"
+)
+
+// newSplitHorizonAWSProvider returns a provider of the given zone type, managing a public and a private zone of the same domain.
+func newSplitHorizonAWSProvider(t *testing.T, zoneType string) (*AWSProvider, *Route53APIStub) {
+	p, client := newAWSProvider(t, endpoint.NewDomainFilter([]string{"split.ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(zoneType), defaultEvaluateTargetHealth, false, nil)
+	client.zones[splitHorizonPublicZoneID] = &route53types.HostedZone{
+		Id:     aws.String(splitHorizonPublicZoneID),
+		Name:   aws.String("split.ext-dns-test-2.teapot.zalan.do."),
+		Config: &route53types.HostedZoneConfig{PrivateZone: false},
+	}
+	client.zones[splitHorizonPrivateZoneID] = &route53types.HostedZone{
+		Id:     aws.String(splitHorizonPrivateZoneID),
+		Name:   aws.String("split.ext-dns-test-2.teapot.zalan.do."),
+		Config: &route53types.HostedZoneConfig{PrivateZone: true},
+	}
+	p.zonesCache = &zonesListCache{}
+	return p, client
+}
+
+func TestAWSApplyChangesInternalTarget(t *testing.T) {
+	for _, tt := range []struct {
+		zoneType string
+		expected map[string]string
+		property bool
+	}{
+		{zoneType: "public", expected: map[string]string{splitHorizonPublicZoneID: "1.2.3.4"}},
+		{zoneType: "private", expected: map[string]string{splitHorizonPrivateZoneID: "10.0.0.1"}},
+		{zoneType: "", expected: map[string]string{splitHorizonPublicZoneID: "1.2.3.4", splitHorizonPrivateZoneID: "10.0.0.1"}, property: true},
+	} {
+		t.Run(tt.zoneType, func(t *testing.T) {
+			p, client := newSplitHorizonAWSProvider(t, tt.zoneType)
+
+			endpoints, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+				endpoint.NewEndpoint(splitHorizonHostname, endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific(providerSpecificInternalTarget, "10.0.0.1"),
+			})
+			require.NoError(t, err)
+			require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{Create: endpoints}))
+
+			for zoneID, expected := range tt.expected {
+				records := listAWSRecords(t, client, zoneID)
+				require.Len(t, records, 1)
+				assert.Equal(t, []route53types.ResourceRecord{{Value: aws.String(expected)}}, records[0].ResourceRecords, zoneID)
+			}
+
+			current, err := p.Records(context.Background())
+			require.NoError(t, err)
+			require.Len(t, current, len(tt.expected))
+			for _, ep := range current {
+				assert.Equal(t, endpoints[0].Targets, ep.Targets, "the records read back must match the adjusted endpoint")
+				internal, ok := ep.GetProviderSpecificProperty(providerSpecificInternalTarget)
+				assert.Equal(t, tt.property, ok)
+				if ok {
+					assert.Equal(t, "10.0.0.1", internal)
+				}
+			}
+		})
+	}
+}
+
+func TestAWSApplyChangesInternalTargetUpdate(t *testing.T) {
+	p, client := newSplitHorizonAWSProvider(t, "")
+
+	old, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint(splitHorizonHostname, endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific(providerSpecificInternalTarget, "10.0.0.1"),
+	})
+	require.NoError(t, err)
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{Create: old}))
+
+	current, err := p.Records(context.Background())
+	require.NoError(t, err)
+	updated, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint(splitHorizonHostname, endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific(providerSpecificInternalTarget, "10.0.0.2"),
+	})
+	require.NoError(t, err)
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{UpdateOld: current[:1], UpdateNew: updated}))
+
+	assert.Equal(t, []route53types.ResourceRecord{{Value: aws.String("1.2.3.4")}}, listAWSRecords(t, client, splitHorizonPublicZoneID)[0].ResourceRecords)
+	assert.Equal(t, []route53types.ResourceRecord{{Value: aws.String("10.0.0.2")}}, listAWSRecords(t, client, splitHorizonPrivateZoneID)[0].ResourceRecords)
+
+	current, err = p.Records(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{Delete: current[:1]}))
+	assert.Empty(t, listAWSRecords(t, client, splitHorizonPublicZoneID))
+	assert.Empty(t, listAWSRecords(t, client, splitHorizonPrivateZoneID))
+}
+
+func TestAWSAdjustEndpointsInternalTarget(t *testing.T) {
+	for _, tt := range []struct {
+		zoneType string
+		// expected are the targets of the endpoints, and their internal targets when they are kept
+		expected         []endpoint.Targets
+		expectedInternal []string
+	}{
+		{
+			zoneType:         "private",
+			expected:         []endpoint.Targets{{"fd00::1", "fd00::2"}, {"internal.example.com"}, {"1.2.3.4"}, {"1.2.3.4"}},
+			expectedInternal: []string{"", "", "", ""},
+		},
+		{
+			zoneType:         "public",
+			expected:         []endpoint.Targets{{"2001:db8::1"}, {"public.example.com"}, {"1.2.3.4"}, {"1.2.3.4"}},
+			expectedInternal: []string{"", "", "", ""},
+		},
+		{
+			zoneType:         "",
+			expected:         []endpoint.Targets{{"2001:db8::1"}, {"public.example.com"}, {"1.2.3.4"}, {"1.2.3.4"}},
+			expectedInternal: []string{"fd00::1,fd00::2", "internal.example.com", "", ""},
+		},
+	} {
+		t.Run(tt.zoneType, func(t *testing.T) {
+			p, _ := newSplitHorizonAWSProvider(t, tt.zoneType)
+
+			endpoints, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+				endpoint.NewEndpoint("ipv6.split.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeAAAA, "2001:db8::1").WithProviderSpecific(providerSpecificInternalTarget, "fd00::1,fd00::2"),
+				endpoint.NewEndpoint("cname.split.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeCNAME, "public.example.com").WithProviderSpecific(providerSpecificAlias, "false").WithProviderSpecific(providerSpecificInternalTarget, "Internal.example.com."),
+				// internal targets of another record type are ignored
+				endpoint.NewEndpoint("mismatch.split.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific(providerSpecificInternalTarget, "internal.example.com"),
+				// internal targets equal to the targets are dropped
+				endpoint.NewEndpoint("same.split.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific(providerSpecificInternalTarget, "1.2.3.4"),
+			})
+			require.NoError(t, err)
+
+			require.Len(t, endpoints, len(tt.expected))
+			for i, ep := range endpoints {
+				assert.Equal(t, tt.expected[i], ep.Targets, ep.DNSName)
+				internal, _ := ep.GetProviderSpecificProperty(providerSpecificInternalTarget)
+				assert.Equal(t, tt.expectedInternal[i], internal, ep.DNSName)
+			}
+		})
+	}
+}
+
+func TestAWSAdjustEndpointsInternalTargetAlias(t *testing.T) {
+	p, _ := newSplitHorizonAWSProvider(t, "")
+
+	endpoints, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint(splitHorizonHostname, endpoint.RecordTypeCNAME, "my-lb.eu-central-1.elb.amazonaws.com").WithProviderSpecific(providerSpecificInternalTarget, "internal.example.com"),
+	})
+	require.NoError(t, err)
+
+	// the records of the private zones are the same aliases
+	_, ok := endpoints[0].GetProviderSpecificProperty(providerSpecificInternalTarget)
+	assert.False(t, ok)
+}
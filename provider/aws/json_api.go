@@ -0,0 +1,164 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// jsonAPI calls the operations of an AWS JSON API using SigV4 signed requests. It is used by the Route53 Resolver,
+// Application Recovery Controller and Recovery Readiness clients, as their SDK service modules aren't dependencies of
+// the project yet. The requests are retried with the retryer of the configuration, like the SDK clients do.
+type jsonAPI struct {
+	cfg          awsv2.Config
+	signer       *v4.Signer
+	service      string
+	contentType  string
+	targetPrefix string
+}
+
+// apiError is returned when an API answers a request with an error status. It exposes its status and error code the
+// way the SDK errors do, so that the retryers of the SDK can tell whether it is retryable.
+type apiError struct {
+	operation  string
+	statusCode int
+	code       string
+	body       string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("calling %s: unexpected status %d: %s", e.operation, e.statusCode, e.body)
+}
+
+// HTTPStatusCode returns the status of the response.
+func (e *apiError) HTTPStatusCode() int {
+	return e.statusCode
+}
+
+// ErrorCode returns the error code of the response, e.g. ThrottlingException.
+func (e *apiError) ErrorCode() string {
+	return e.code
+}
+
+// call sends the input of the operation to the endpoint, the request is signed for the given region.
+func (a jsonAPI) call(ctx context.Context, endpoint, region, operation string, input, output any) error {
+	return a.send(ctx, http.MethodPost, endpoint, region, operation, input, output)
+}
+
+// send sends the input of the operation with the given method, which lets the REST APIs be called too: they
+// have no target prefix and take no input in some requests.
+func (a jsonAPI) send(ctx context.Context, method, endpoint, region, operation string, input, output any) error {
+	var body []byte
+	if input != nil {
+		var err error
+		if body, err = json.Marshal(input); err != nil {
+			return err
+		}
+	}
+
+	var retryer awsv2.Retryer
+	if a.cfg.Retryer != nil {
+		retryer = a.cfg.Retryer()
+	}
+	for attempt := 1; ; attempt++ {
+		err := a.attempt(ctx, method, endpoint, region, operation, body, output)
+		if err == nil || retryer == nil || attempt >= retryer.MaxAttempts() || !retryer.IsErrorRetryable(err) {
+			return err
+		}
+		delay, delayErr := retryer.RetryDelay(attempt, err)
+		if delayErr != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (a jsonAPI) attempt(ctx context.Context, method, endpoint, region, operation string, body []byte, output any) error {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", a.contentType)
+	if a.targetPrefix != "" {
+		req.Header.Set("X-Amz-Target", a.targetPrefix+operation)
+	}
+
+	creds, err := a.cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("retrieving credentials: %w", err)
+	}
+	hash := sha256.Sum256(body)
+	if err := a.signer.SignHTTP(ctx, creds, req, hex.EncodeToString(hash[:]), a.service, region, time.Now()); err != nil {
+		return fmt.Errorf("signing %s request: %w", operation, err)
+	}
+
+	httpClient := a.cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling %s: %w", operation, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return &apiError{operation: operation, statusCode: resp.StatusCode, code: errorCode(resp.Header, data), body: string(data)}
+	}
+	if output == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, output)
+}
+
+// errorCode returns the error code of an error response, from its X-Amzn-ErrorType header or its __type field,
+// without the namespace and the documentation link they may have.
+func errorCode(header http.Header, body []byte) string {
+	code := header.Get("X-Amzn-ErrorType")
+	if code == "" {
+		var payload struct {
+			Type string `json:"__type"`
+		}
+		_ = json.Unmarshal(body, &payload)
+		code = payload.Type
+	}
+	code, _, _ = strings.Cut(code, ":")
+	if i := strings.LastIndex(code, "#"); i >= 0 {
+		code = code[i+1:]
+	}
+	return code
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/ratelimit"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONAPIRetries(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		status   int
+		header   string
+		body     string
+		attempts int
+		code     string
+	}{
+		{name: "server error", status: http.StatusServiceUnavailable, attempts: 3},
+		{name: "throttling", status: http.StatusBadRequest, header: "ThrottlingException:http://internal.amazon.com/", attempts: 3, code: "ThrottlingException"},
+		{name: "client error", status: http.StatusBadRequest, body: `{"__type":"com.amazonaws.route53resolver#InvalidRequestException"}`, attempts: 1, code: "InvalidRequestException"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				attempts++
+				if tt.header != "" {
+					w.Header().Set("X-Amzn-ErrorType", tt.header)
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			api := jsonAPI{
+				cfg: aws.Config{
+					Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+					Retryer: func() aws.Retryer {
+						return retry.NewStandard(func(o *retry.StandardOptions) {
+							o.MaxAttempts = 3
+							o.Backoff = retry.BackoffDelayerFunc(func(int, error) (time.Duration, error) { return 0, nil })
+							o.RateLimiter = ratelimit.None
+						})
+					},
+				},
+				signer:      v4.NewSigner(),
+				service:     "route53resolver",
+				contentType: "application/x-amz-json-1.1",
+			}
+			err := api.call(context.Background(), server.URL, "us-east-1", "ListResolverRules", struct{}{}, nil)
+
+			var apiErr *apiError
+			require.True(t, errors.As(err, &apiErr))
+			assert.Equal(t, tt.status, apiErr.HTTPStatusCode())
+			assert.Equal(t, tt.code, apiErr.ErrorCode())
+			assert.Equal(t, tt.attempts, attempts)
+		})
+	}
+
+	t.Run("success after a retry", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			attempts++
+			if attempts == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_, _ = w.Write([]byte(`{"NextToken":"page-2"}`))
+		}))
+		defer server.Close()
+
+		api := jsonAPI{
+			cfg: aws.Config{
+				Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+				Retryer: func() aws.Retryer {
+					return retry.NewStandard(func(o *retry.StandardOptions) {
+						o.Backoff = retry.BackoffDelayerFunc(func(int, error) (time.Duration, error) { return 0, nil })
+						o.RateLimiter = ratelimit.None
+					})
+				},
+			},
+			signer:  v4.NewSigner(),
+			service: "route53resolver",
+		}
+		var output struct{ NextToken string }
+		require.NoError(t, api.call(context.Background(), server.URL, "us-east-1", "ListResolverRules", struct{}{}, &output))
+		assert.Equal(t, "page-2", output.NextToken)
+		assert.Equal(t, 2, attempts)
+	})
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"strings"
+)
+
+// partition is a group of AWS regions, the China regions have their own DNS suffix.
+type partition struct {
+	name      string
+	dnsSuffix string
+}
+
+var (
+	awsPartition = partition{
+		name:      "aws",
+		dnsSuffix: "amazonaws.com",
+	}
+	chinaPartition = partition{
+		name:      "aws-cn",
+		dnsSuffix: "amazonaws.com.cn",
+	}
+	govCloudPartition = partition{
+		name:      "aws-us-gov",
+		dnsSuffix: "amazonaws.com",
+	}
+)
+
+// regionPartition returns the partition of the region, e.g. cn-north-1 and cn-northwest-1 belong to the China partition.
+func regionPartition(region string) partition {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return chinaPartition
+	case strings.HasPrefix(region, "us-gov-"):
+		return govCloudPartition
+	default:
+		return awsPartition
+	}
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+
+	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	readinessServiceName = "route53-recovery-readiness"
+	// readinessRegion is the only region the Recovery Readiness API is available in.
+	readinessRegion          = "us-west-2"
+	readinessResourceSetType = "AWS::Route53RecoveryReadiness::DNSTargetResource"
+	// readinessResourceSetPrefix is the prefix of the names of the resource sets registered by external-dns.
+	readinessResourceSetPrefix = "external-dns-"
+)
+
+// RecoveryReadinessResourceSet is a resource set of a single failover record, checked for readiness in the given cells.
+type RecoveryReadinessResourceSet struct {
+	Name            string
+	DomainName      string
+	HostedZoneARN   string
+	RecordSetID     string
+	RecordType      string
+	ReadinessScopes []string
+}
+
+// RecoveryReadinessClient is the subset of the Route53 Recovery Readiness API that we actually use.
+// https://docs.aws.amazon.com/recovery-readiness/latest/api/resourcesets.html
+type RecoveryReadinessClient interface {
+	// PutResourceSet creates the resource set, or updates it when it exists.
+	PutResourceSet(ctx context.Context, set RecoveryReadinessResourceSet) error
+	// DeleteResourceSet deletes the resource set, it is not an error when it doesn't exist.
+	DeleteResourceSet(ctx context.Context, name string) error
+}
+
+type readinessResourceSetInput struct {
+	ResourceSetName string              `json:"resourceSetName,omitempty"`
+	ResourceSetType string              `json:"resourceSetType"`
+	Resources       []readinessResource `json:"resources"`
+}
+
+type readinessResource struct {
+	DNSTargetResource readinessDNSTargetResource `json:"dnsTargetResource"`
+	ReadinessScopes   []string                   `json:"readinessScopes,omitempty"`
+}
+
+type readinessDNSTargetResource struct {
+	DomainName    string `json:"domainName"`
+	HostedZoneArn string `json:"hostedZoneArn"`
+	RecordSetId   string `json:"recordSetId,omitempty"`
+	RecordType    string `json:"recordType"`
+}
+
+// readinessClient talks to the Route53 Recovery Readiness REST API.
+type readinessClient struct {
+	api      jsonAPI
+	endpoint string
+}
+
+// NewRecoveryReadinessClient returns a RecoveryReadinessClient using the given AWS configuration.
+func NewRecoveryReadinessClient(cfg awsv2.Config) RecoveryReadinessClient {
+	return &readinessClient{
+		api: jsonAPI{
+			cfg:         cfg,
+			signer:      v4.NewSigner(),
+			service:     readinessServiceName,
+			contentType: "application/json",
+		},
+		endpoint: fmt.Sprintf("https://%s.%s.amazonaws.com", readinessServiceName, readinessRegion),
+	}
+}
+
+func (c *readinessClient) PutResourceSet(ctx context.Context, set RecoveryReadinessResourceSet) error {
+	input := readinessResourceSetInput{
+		ResourceSetName: set.Name,
+		ResourceSetType: readinessResourceSetType,
+		Resources: []readinessResource{{
+			DNSTargetResource: readinessDNSTargetResource{
+				DomainName:    set.DomainName,
+				HostedZoneArn: set.HostedZoneARN,
+				RecordSetId:   set.RecordSetID,
+				RecordType:    set.RecordType,
+			},
+			ReadinessScopes: set.ReadinessScopes,
+		}},
+	}
+	err := c.api.send(ctx, http.MethodPost, c.endpoint+"/resourcesets", readinessRegion, "CreateResourceSet", input, nil)
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) || apiErr.statusCode != http.StatusConflict {
+		return err
+	}
+	// the name is part of the path of the updated resource set
+	input.ResourceSetName = ""
+	return c.api.send(ctx, http.MethodPut, c.resourceSetURL(set.Name), readinessRegion, "UpdateResourceSet", input, nil)
+}
+
+func (c *readinessClient) DeleteResourceSet(ctx context.Context, name string) error {
+	err := c.api.send(ctx, http.MethodDelete, c.resourceSetURL(name), readinessRegion, "DeleteResourceSet", nil, nil)
+	var apiErr *apiError
+	if errors.As(err, &apiErr) && apiErr.statusCode == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+func (c *readinessClient) resourceSetURL(name string) string {
+	return c.endpoint + "/resourcesets/" + url.PathEscape(name)
+}
+
+// isFailoverRecord returns true when the endpoint is published with a failover routing policy.
+func isFailoverRecord(ep *endpoint.Endpoint) bool {
+	_, ok := ep.GetProviderSpecificProperty(providerSpecificFailover)
+	return ok
+}
+
+// readinessResourceSet returns the resource set of the failover record of the endpoint in the zone, its name is
+// derived from the record so that it is found again when the record is deleted.
+func (p *AWSProvider) readinessResourceSet(ep *endpoint.Endpoint, z *profiledZone) RecoveryReadinessResourceSet {
+	zoneID := cleanZoneID(*z.zone.Id)
+	domainName := provider.EnsureTrailingDot(ep.DNSName)
+	hash := sha256.Sum256([]byte(zoneID + "/" + domainName + "/" + ep.RecordType + "/" + ep.SetIdentifier))
+	return RecoveryReadinessResourceSet{
+		Name:            readinessResourceSetPrefix + hex.EncodeToString(hash[:])[:32],
+		DomainName:      domainName,
+		HostedZoneARN:   "arn:aws:route53:::hostedzone/" + zoneID,
+		RecordSetID:     ep.SetIdentifier,
+		RecordType:      ep.RecordType,
+		ReadinessScopes: p.recoveryReadinessCellARNs,
+	}
+}
+
+// syncRecoveryReadinessResourceSets registers the failover records which were created or updated as resource
+// sets in Recovery Readiness, and deregisters the ones which were deleted or are no longer failover records.
+func (p *AWSProvider) syncRecoveryReadinessResourceSets(ctx context.Context, changes *plan.Changes, zones map[string]*profiledZone) error {
+	upserts := make(map[string]RecoveryReadinessResourceSet)
+	for _, eps := range [][]*endpoint.Endpoint{changes.Create, changes.UpdateNew} {
+		for _, ep := range eps {
+			if !isFailoverRecord(ep) {
+				continue
+			}
+			for _, z := range suitableZones(provider.EnsureTrailingDot(ep.DNSName), zones) {
+				set := p.readinessResourceSet(ep, z)
+				upserts[set.Name] = set
+			}
+		}
+	}
+
+	var deletes []RecoveryReadinessResourceSet
+	for _, eps := range [][]*endpoint.Endpoint{changes.Delete, changes.UpdateOld} {
+		for _, ep := range eps {
+			if !isFailoverRecord(ep) {
+				continue
+			}
+			for _, z := range suitableZones(provider.EnsureTrailingDot(ep.DNSName), zones) {
+				set := p.readinessResourceSet(ep, z)
+				if _, ok := upserts[set.Name]; !ok {
+					deletes = append(deletes, set)
+				}
+			}
+		}
+	}
+
+	for _, set := range deletes {
+		log.Infof("Desired change: DELETE recovery readiness resource set %s for %s %s %s", set.Name, set.DomainName, set.RecordType, set.RecordSetID)
+		if p.dryRun {
+			continue
+		}
+		if err := p.recoveryReadinessClient.DeleteResourceSet(ctx, set.Name); err != nil {
+			return fmt.Errorf("failed to delete recovery readiness resource set %s: %w", set.Name, err)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(upserts)) {
+		set := upserts[name]
+		log.Infof("Desired change: UPSERT recovery readiness resource set %s for %s %s %s", set.Name, set.DomainName, set.RecordType, set.RecordSetID)
+		if p.dryRun {
+			continue
+		}
+		if err := p.recoveryReadinessClient.PutResourceSet(ctx, set); err != nil {
+			return fmt.Errorf("failed to put recovery readiness resource set %s: %w", set.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateRecoveryReadinessConfig returns an error when Recovery Readiness is enabled without a client.
+func validateRecoveryReadinessConfig(awsConfig AWSConfig) error {
+	if awsConfig.RecoveryReadinessEnabled && awsConfig.RecoveryReadinessClient == nil {
+		return errors.New("a client is required to register the resource sets in recovery readiness")
+	}
+	return nil
+}
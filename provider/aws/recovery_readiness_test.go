@@ -0,0 +1,217 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const testReadinessCellARN = "arn:aws:route53-recovery-readiness::123456789012:cell/us-west-2"
+
+// Compile time check for interface conformance
+var _ RecoveryReadinessClient = &RecoveryReadinessClientStub{}
+
+type RecoveryReadinessClientStub struct {
+	sets    map[string]RecoveryReadinessResourceSet
+	deleted []string
+	err     error
+}
+
+func (c *RecoveryReadinessClientStub) PutResourceSet(_ context.Context, set RecoveryReadinessResourceSet) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.sets[set.Name] = set
+	return nil
+}
+
+func (c *RecoveryReadinessClientStub) DeleteResourceSet(_ context.Context, name string) error {
+	if c.err != nil {
+		return c.err
+	}
+	delete(c.sets, name)
+	c.deleted = append(c.deleted, name)
+	return nil
+}
+
+func newAWSProviderWithRecoveryReadiness(t *testing.T, client *RecoveryReadinessClientStub) *AWSProvider {
+	p, _ := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, false, nil)
+	p.recoveryReadinessEnabled = true
+	p.recoveryReadinessCellARNs = []string{testReadinessCellARN}
+	p.recoveryReadinessClient = client
+	return p
+}
+
+func failoverEndpoint(setIdentifier, failover, target string) *endpoint.Endpoint {
+	return endpoint.NewEndpoint("failover.zone-3.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, target).
+		WithSetIdentifier(setIdentifier).
+		WithProviderSpecific(providerSpecificFailover, failover)
+}
+
+func TestAWSApplyChangesRecoveryReadiness(t *testing.T) {
+	client := &RecoveryReadinessClientStub{sets: map[string]RecoveryReadinessResourceSet{}}
+	p := newAWSProviderWithRecoveryReadiness(t, client)
+
+	primary := failoverEndpoint("primary", "PRIMARY", "1.2.3.4")
+	secondary := failoverEndpoint("secondary", "SECONDARY", "5.6.7.8")
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			primary,
+			secondary,
+			endpoint.NewEndpoint("simple.zone-3.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "8.8.8.8"),
+		},
+	}))
+
+	require.Len(t, client.sets, 2, "only the failover records must be registered")
+	for _, set := range client.sets {
+		assert.Regexp(t, "^external-dns-[0-9a-f]{32}$", set.Name)
+		assert.Equal(t, "failover.zone-3.ext-dns-test-2.teapot.zalan.do.", set.DomainName)
+		assert.Equal(t, "arn:aws:route53:::hostedzone/zone-3.ext-dns-test-2.teapot.zalan.do.", set.HostedZoneARN)
+		assert.Equal(t, endpoint.RecordTypeA, set.RecordType)
+		assert.Contains(t, []string{"primary", "secondary"}, set.RecordSetID)
+		assert.Equal(t, []string{testReadinessCellARN}, set.ReadinessScopes)
+	}
+
+	// an update keeps the resource set of the record, a deletion deregisters it
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{primary},
+		UpdateNew: []*endpoint.Endpoint{failoverEndpoint("primary", "PRIMARY", "4.3.2.1")},
+		Delete:    []*endpoint.Endpoint{secondary},
+	}))
+
+	require.Len(t, client.sets, 1)
+	require.Len(t, client.deleted, 1)
+	for _, set := range client.sets {
+		assert.Equal(t, "primary", set.RecordSetID)
+	}
+}
+
+func TestAWSApplyChangesRecoveryReadinessDryRun(t *testing.T) {
+	client := &RecoveryReadinessClientStub{sets: map[string]RecoveryReadinessResourceSet{}}
+	p := newAWSProviderWithRecoveryReadiness(t, client)
+	p.dryRun = true
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{failoverEndpoint("primary", "PRIMARY", "1.2.3.4")},
+	}))
+
+	assert.Empty(t, client.sets)
+}
+
+func TestAWSApplyChangesRecoveryReadinessError(t *testing.T) {
+	p := newAWSProviderWithRecoveryReadiness(t, &RecoveryReadinessClientStub{err: errors.New("access denied")})
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{failoverEndpoint("primary", "PRIMARY", "1.2.3.4")},
+	})
+	require.ErrorIs(t, err, provider.SoftError)
+	assert.ErrorContains(t, err, "access denied")
+
+	assert.Len(t, listAWSRecords(t, p.clients[defaultAWSProfile], "/hostedzone/zone-3.ext-dns-test-2.teapot.zalan.do."), 1, "the records must be changed regardless")
+}
+
+func TestNewAWSProviderRecoveryReadinessWithoutClient(t *testing.T) {
+	_, err := NewAWSProvider(AWSConfig{RecoveryReadinessEnabled: true}, nil)
+	require.EqualError(t, err, "a client is required to register the resource sets in recovery readiness")
+}
+
+func TestRecoveryReadinessClient(t *testing.T) {
+	var requests []string
+	exists := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		assert.Empty(t, r.Header.Get("X-Amz-Target"))
+		assert.Contains(t, r.Header.Get("Authorization"), "/us-west-2/route53-recovery-readiness/aws4_request")
+
+		switch r.Method {
+		case http.MethodPost:
+			var input map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+			assert.Equal(t, "external-dns-set", input["resourceSetName"])
+			assert.Equal(t, "AWS::Route53RecoveryReadiness::DNSTargetResource", input["resourceSetType"])
+			assert.Equal(t, []any{map[string]any{
+				"dnsTargetResource": map[string]any{
+					"domainName":    "failover.example.org.",
+					"hostedZoneArn": "arn:aws:route53:::hostedzone/Z123",
+					"recordSetId":   "primary",
+					"recordType":    "A",
+				},
+				"readinessScopes": []any{testReadinessCellARN},
+			}}, input["resources"])
+			if exists {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			_, _ = w.Write([]byte(`{"resourceSetName":"external-dns-set"}`))
+		case http.MethodPut:
+			var input map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+			assert.NotContains(t, input, "resourceSetName")
+			_, _ = w.Write([]byte(`{"resourceSetName":"external-dns-set"}`))
+		case http.MethodDelete:
+			if !exists {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := NewRecoveryReadinessClient(aws.Config{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}).(*readinessClient)
+	client.endpoint = server.URL
+
+	set := RecoveryReadinessResourceSet{
+		Name:            "external-dns-set",
+		DomainName:      "failover.example.org.",
+		HostedZoneARN:   "arn:aws:route53:::hostedzone/Z123",
+		RecordSetID:     "primary",
+		RecordType:      endpoint.RecordTypeA,
+		ReadinessScopes: []string{testReadinessCellARN},
+	}
+	require.NoError(t, client.PutResourceSet(context.Background(), set))
+	require.NoError(t, client.DeleteResourceSet(context.Background(), set.Name), "a missing resource set must not be an error")
+
+	exists = true
+	require.NoError(t, client.PutResourceSet(context.Background(), set))
+	require.NoError(t, client.DeleteResourceSet(context.Background(), set.Name))
+
+	assert.Equal(t, []string{
+		"POST /resourcesets",
+		"DELETE /resourcesets/external-dns-set",
+		"POST /resourcesets",
+		"PUT /resourcesets/external-dns-set",
+		"DELETE /resourcesets/external-dns-set",
+	}, requests)
+}
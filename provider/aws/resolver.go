@@ -0,0 +1,207 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	resolverServiceName  = "route53resolver"
+	resolverTargetPrefix = "Route53Resolver."
+)
+
+// ResolverRuleConfig contains the configuration used to associate Route53 Resolver rules
+// with the VPCs of the managed private hosted zones.
+type ResolverRuleConfig struct {
+	// RuleName is the name of an existing resolver rule, e.g. a forwarding rule to on-premises DNS servers.
+	RuleName string
+	// Client is used to interact with the Route53 Resolver API.
+	Client Route53ResolverRuleClient
+}
+
+// ResolverRule is the subset of a Route53 Resolver rule that we actually use.
+type ResolverRule struct {
+	Id   string `json:"Id"`
+	Name string `json:"Name"`
+	Arn  string `json:"Arn,omitempty"`
+}
+
+// ResolverRuleAssociation is the subset of a Route53 Resolver rule association that we actually use.
+type ResolverRuleAssociation struct {
+	Id             string `json:"Id,omitempty"`
+	ResolverRuleId string `json:"ResolverRuleId"`
+	VPCId          string `json:"VPCId"`
+	Name           string `json:"Name,omitempty"`
+	Status         string `json:"Status,omitempty"`
+}
+
+// Route53ResolverRuleClient is the subset of the AWS Route53 Resolver API that we actually use.
+// https://docs.aws.amazon.com/Route53/latest/APIReference/API_Operations_Amazon_Route_53_Resolver.html
+type Route53ResolverRuleClient interface {
+	ListResolverRules(ctx context.Context, name string) ([]ResolverRule, error)
+	ListResolverRuleAssociations(ctx context.Context, ruleID string) ([]ResolverRuleAssociation, error)
+	AssociateResolverRule(ctx context.Context, association ResolverRuleAssociation) error
+}
+
+type resolverFilter struct {
+	Name   string   `json:"Name"`
+	Values []string `json:"Values"`
+}
+
+type listResolverRulesInput struct {
+	Filters   []resolverFilter `json:"Filters,omitempty"`
+	NextToken string           `json:"NextToken,omitempty"`
+}
+
+type listResolverRulesOutput struct {
+	ResolverRules []ResolverRule `json:"ResolverRules"`
+	NextToken     string         `json:"NextToken,omitempty"`
+}
+
+type listResolverRuleAssociationsOutput struct {
+	ResolverRuleAssociations []ResolverRuleAssociation `json:"ResolverRuleAssociations"`
+	NextToken                string                    `json:"NextToken,omitempty"`
+}
+
+// resolverClient talks to the Route53 Resolver JSON API.
+type resolverClient struct {
+	api      jsonAPI
+	endpoint string
+}
+
+// NewRoute53ResolverRuleClient returns a Route53ResolverRuleClient using the given AWS configuration.
+func NewRoute53ResolverRuleClient(cfg awsv2.Config) Route53ResolverRuleClient {
+	endpoint := fmt.Sprintf("https://%s.%s.%s", resolverServiceName, cfg.Region, regionPartition(cfg.Region).dnsSuffix)
+	return &resolverClient{
+		api: jsonAPI{
+			cfg:          cfg,
+			signer:       v4.NewSigner(),
+			service:      resolverServiceName,
+			contentType:  "application/x-amz-json-1.1",
+			targetPrefix: resolverTargetPrefix,
+		},
+		endpoint: endpoint,
+	}
+}
+
+func (c *resolverClient) ListResolverRules(ctx context.Context, name string) ([]ResolverRule, error) {
+	var rules []ResolverRule
+	input := listResolverRulesInput{Filters: []resolverFilter{{Name: "Name", Values: []string{name}}}}
+	for {
+		var output listResolverRulesOutput
+		if err := c.call(ctx, "ListResolverRules", input, &output); err != nil {
+			return nil, err
+		}
+		rules = append(rules, output.ResolverRules...)
+		if output.NextToken == "" {
+			return rules, nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+func (c *resolverClient) ListResolverRuleAssociations(ctx context.Context, ruleID string) ([]ResolverRuleAssociation, error) {
+	var associations []ResolverRuleAssociation
+	input := listResolverRulesInput{Filters: []resolverFilter{{Name: "ResolverRuleId", Values: []string{ruleID}}}}
+	for {
+		var output listResolverRuleAssociationsOutput
+		if err := c.call(ctx, "ListResolverRuleAssociations", input, &output); err != nil {
+			return nil, err
+		}
+		associations = append(associations, output.ResolverRuleAssociations...)
+		if output.NextToken == "" {
+			return associations, nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+func (c *resolverClient) AssociateResolverRule(ctx context.Context, association ResolverRuleAssociation) error {
+	return c.call(ctx, "AssociateResolverRule", association, nil)
+}
+
+func (c *resolverClient) call(ctx context.Context, operation string, input, output any) error {
+	return c.api.call(ctx, c.endpoint, c.api.cfg.Region, operation, input, output)
+}
+
+// associateResolverRules associates the configured resolver rule with the VPCs of all managed private zones.
+// VPCs that already have the rule associated are remembered, so the Resolver API is only queried once per VPC.
+func (p *AWSProvider) associateResolverRules(ctx context.Context, zones map[string]*profiledZone) error {
+	client := p.resolverRuleConfig.Client
+	if p.resolverRuleID == "" {
+		rules, err := client.ListResolverRules(ctx, p.resolverRuleConfig.RuleName)
+		if err != nil {
+			return fmt.Errorf("failed to list resolver rules: %w", err)
+		}
+		for _, rule := range rules {
+			if rule.Name == p.resolverRuleConfig.RuleName {
+				p.resolverRuleID = rule.Id
+				break
+			}
+		}
+		if p.resolverRuleID == "" {
+			return fmt.Errorf("resolver rule %q not found", p.resolverRuleConfig.RuleName)
+		}
+
+		associations, err := client.ListResolverRuleAssociations(ctx, p.resolverRuleID)
+		if err != nil {
+			return fmt.Errorf("failed to list resolver rule associations: %w", err)
+		}
+		for _, a := range associations {
+			p.resolverRuleVPCs[a.VPCId] = struct{}{}
+		}
+	}
+
+	for id, z := range zones {
+		if z.zone.Config == nil || !z.zone.Config.PrivateZone {
+			continue
+		}
+		resp, err := p.clients[z.profile].GetHostedZone(ctx, &route53.GetHostedZoneInput{Id: awsv2.String(id)})
+		if err != nil {
+			return fmt.Errorf("failed to get hosted zone %s: %w", id, err)
+		}
+		for _, vpc := range resp.VPCs {
+			if vpc.VPCId == nil {
+				continue
+			}
+			if _, ok := p.resolverRuleVPCs[*vpc.VPCId]; ok {
+				continue
+			}
+			log.Infof("Associating resolver rule %s with VPC %s of zone %s", p.resolverRuleConfig.RuleName, *vpc.VPCId, *z.zone.Name)
+			if p.dryRun {
+				continue
+			}
+			err := client.AssociateResolverRule(ctx, ResolverRuleAssociation{
+				ResolverRuleId: p.resolverRuleID,
+				VPCId:          *vpc.VPCId,
+				Name:           fmt.Sprintf("external-dns-%s", *vpc.VPCId),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to associate resolver rule %s with VPC %s: %w", p.resolverRuleConfig.RuleName, *vpc.VPCId, err)
+			}
+			p.resolverRuleVPCs[*vpc.VPCId] = struct{}{}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// Compile time check for interface conformance
+var _ Route53ResolverRuleClient = &Route53ResolverRuleClientStub{}
+
+type Route53ResolverRuleClientStub struct {
+	rules        []ResolverRule
+	associations []ResolverRuleAssociation
+	listCalls    int
+	associateErr error
+}
+
+func (r *Route53ResolverRuleClientStub) ListResolverRules(_ context.Context, name string) ([]ResolverRule, error) {
+	r.listCalls++
+	var rules []ResolverRule
+	for _, rule := range r.rules {
+		if rule.Name == name {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func (r *Route53ResolverRuleClientStub) ListResolverRuleAssociations(_ context.Context, ruleID string) ([]ResolverRuleAssociation, error) {
+	var associations []ResolverRuleAssociation
+	for _, a := range r.associations {
+		if a.ResolverRuleId == ruleID {
+			associations = append(associations, a)
+		}
+	}
+	return associations, nil
+}
+
+func (r *Route53ResolverRuleClientStub) AssociateResolverRule(_ context.Context, association ResolverRuleAssociation) error {
+	if r.associateErr != nil {
+		return r.associateErr
+	}
+	r.associations = append(r.associations, association)
+	return nil
+}
+
+func newAWSProviderWithResolver(t *testing.T, resolver *Route53ResolverRuleClientStub, dryRun bool) (*AWSProvider, *Route53APIStub) {
+	p, stub := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, dryRun, nil)
+	p.manageResolverRules = true
+	p.resolverRuleConfig = ResolverRuleConfig{RuleName: "on-prem", Client: resolver}
+	p.resolverRuleVPCs = make(map[string]struct{})
+	stub.zoneVPCs["/hostedzone/zone-3.ext-dns-test-2.teapot.zalan.do."] = []route53types.VPC{
+		{VPCId: aws.String("vpc-1"), VPCRegion: route53types.VPCRegionUsEast1},
+		{VPCId: aws.String("vpc-2"), VPCRegion: route53types.VPCRegionUsEast1},
+	}
+	// public zones must never be associated
+	stub.zoneVPCs["/hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do."] = []route53types.VPC{
+		{VPCId: aws.String("vpc-public"), VPCRegion: route53types.VPCRegionUsEast1},
+	}
+	return p, stub
+}
+
+func createChange() *plan.Changes {
+	return &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("create-test.zone-3.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "8.8.8.8"),
+		},
+	}
+}
+
+func TestAWSApplyChangesAssociatesResolverRule(t *testing.T) {
+	resolver := &Route53ResolverRuleClientStub{
+		rules: []ResolverRule{{Id: "rslvr-rr-1", Name: "on-prem"}, {Id: "rslvr-rr-2", Name: "other"}},
+		associations: []ResolverRuleAssociation{
+			{ResolverRuleId: "rslvr-rr-1", VPCId: "vpc-2"},
+		},
+	}
+	p, _ := newAWSProviderWithResolver(t, resolver, false)
+
+	require.NoError(t, p.ApplyChanges(context.Background(), createChange()))
+
+	assert.Equal(t, "rslvr-rr-1", p.resolverRuleID)
+	require.Len(t, resolver.associations, 2)
+	assert.Equal(t, ResolverRuleAssociation{ResolverRuleId: "rslvr-rr-1", VPCId: "vpc-1", Name: "external-dns-vpc-1"}, resolver.associations[1])
+
+	// a second run must not look up the rule nor associate the VPCs again
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{}))
+	assert.Equal(t, 1, resolver.listCalls)
+	assert.Len(t, resolver.associations, 2)
+}
+
+func TestAWSApplyChangesResolverRuleDryRun(t *testing.T) {
+	resolver := &Route53ResolverRuleClientStub{
+		rules: []ResolverRule{{Id: "rslvr-rr-1", Name: "on-prem"}},
+	}
+	p, _ := newAWSProviderWithResolver(t, resolver, true)
+
+	require.NoError(t, p.ApplyChanges(context.Background(), createChange()))
+	assert.Empty(t, resolver.associations)
+}
+
+func TestAWSApplyChangesResolverRuleNotFound(t *testing.T) {
+	resolver := &Route53ResolverRuleClientStub{
+		rules: []ResolverRule{{Id: "rslvr-rr-2", Name: "other"}},
+	}
+	p, _ := newAWSProviderWithResolver(t, resolver, false)
+
+	err := p.ApplyChanges(context.Background(), createChange())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, provider.SoftError)
+	assert.ErrorContains(t, err, `resolver rule "on-prem" not found`)
+}
+
+func TestAWSApplyChangesResolverRuleAssociationError(t *testing.T) {
+	resolver := &Route53ResolverRuleClientStub{
+		rules:        []ResolverRule{{Id: "rslvr-rr-1", Name: "on-prem"}},
+		associateErr: fmt.Errorf("access denied"),
+	}
+	p, _ := newAWSProviderWithResolver(t, resolver, false)
+
+	err := p.ApplyChanges(context.Background(), createChange())
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "access denied")
+	assert.Empty(t, p.resolverRuleVPCs["vpc-1"])
+}
+
+func TestResolverClient(t *testing.T) {
+	var targets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		targets = append(targets, r.Header.Get("X-Amz-Target"))
+		assert.Equal(t, "application/x-amz-json-1.1", r.Header.Get("Content-Type"))
+		assert.Contains(t, r.Header.Get("Authorization"), "/route53resolver/aws4_request")
+
+		var input map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+
+		switch r.Header.Get("X-Amz-Target") {
+		case "Route53Resolver.ListResolverRules":
+			if input["NextToken"] == nil {
+				_, _ = w.Write([]byte(`{"ResolverRules":[{"Id":"rslvr-rr-1","Name":"on-prem"}],"NextToken":"page-2"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"ResolverRules":[{"Id":"rslvr-rr-2","Name":"on-prem"}]}`))
+		case "Route53Resolver.ListResolverRuleAssociations":
+			_, _ = w.Write([]byte(`{"ResolverRuleAssociations":[{"ResolverRuleId":"rslvr-rr-1","VPCId":"vpc-1"}]}`))
+		case "Route53Resolver.AssociateResolverRule":
+			assert.Equal(t, "vpc-2", input["VPCId"])
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"InvalidRequestException"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewRoute53ResolverRuleClient(aws.Config{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}).(*resolverClient)
+	assert.Equal(t, "https://route53resolver.us-east-1.amazonaws.com", client.endpoint)
+	client.endpoint = server.URL
+
+	rules, err := client.ListResolverRules(context.Background(), "on-prem")
+	require.NoError(t, err)
+	assert.Equal(t, []ResolverRule{{Id: "rslvr-rr-1", Name: "on-prem"}, {Id: "rslvr-rr-2", Name: "on-prem"}}, rules)
+
+	associations, err := client.ListResolverRuleAssociations(context.Background(), "rslvr-rr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []ResolverRuleAssociation{{ResolverRuleId: "rslvr-rr-1", VPCId: "vpc-1"}}, associations)
+
+	err = client.AssociateResolverRule(context.Background(), ResolverRuleAssociation{ResolverRuleId: "rslvr-rr-1", VPCId: "vpc-2"})
+	assert.ErrorContains(t, err, "unexpected status 400")
+
+	assert.Equal(t, []string{
+		"Route53Resolver.ListResolverRules",
+		"Route53Resolver.ListResolverRules",
+		"Route53Resolver.ListResolverRuleAssociations",
+		"Route53Resolver.AssociateResolverRule",
+	}, targets)
+}
+
+func TestNewRoute53ResolverRuleClientChinaRegion(t *testing.T) {
+	client := NewRoute53ResolverRuleClient(aws.Config{Region: "cn-north-1"}).(*resolverClient)
+	assert.Equal(t, "https://route53resolver.cn-north-1.amazonaws.com.cn", client.endpoint)
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// submittedBatch is a change batch which was successfully submitted to a hosted zone.
+type submittedBatch struct {
+	zoneID  string
+	changes Route53Changes
+}
+
+// submitChangesWithRollback submits the changes in batches of at most maxChangesPerBatch changes. When a batch fails,
+// the batches submitted before it are rolled back in reverse order, so that the records are left in their prior state.
+func (p *AWSProvider) submitChangesWithRollback(ctx context.Context, changesByZone map[string]Route53Changes, zones map[string]*profiledZone) error {
+	zoneIDs := make([]string, 0, len(changesByZone))
+	for z := range changesByZone {
+		zoneIDs = append(zoneIDs, z)
+	}
+	sort.Strings(zoneIDs)
+
+	batchSize := min(p.batchChangeSize, p.maxChangesPerBatch)
+
+	var submitted []submittedBatch
+	for _, z := range zoneIDs {
+		log := log.WithFields(log.Fields{
+			"zoneName": *zones[z].zone.Name,
+			"zoneID":   z,
+			"profile":  zones[z].profile,
+		})
+
+		for _, b := range batchChangeSet(changesByZone[z], batchSize, p.batchChangeSizeBytes, p.batchChangeSizeValues) {
+			if len(b) == 0 {
+				continue
+			}
+
+			for _, c := range b {
+				log.Infof("Desired change: %s %s %s", c.Action, *c.ResourceRecordSet.Name, c.ResourceRecordSet.Type)
+			}
+
+			if p.dryRun {
+				continue
+			}
+
+			if len(submitted) > 0 {
+				time.Sleep(p.batchChangeInterval)
+			}
+
+			if err := p.changeResourceRecordSets(ctx, z, zones[z], b); err != nil {
+				log.Errorf("Failure in zone %s when submitting change batch: %v", *zones[z].zone.Name, err)
+				p.rollbackBatches(ctx, submitted, zones)
+				return provider.NewSoftErrorf("failed to submit change batch for zone %s, %d previously submitted batch(es) were rolled back: %w", z, len(submitted), err)
+			}
+			log.Infof("%d record(s) were successfully updated", len(b))
+			submitted = append(submitted, submittedBatch{zoneID: z, changes: b})
+		}
+	}
+
+	return nil
+}
+
+// rollbackBatches submits the inverse of the given batches, starting with the last one.
+func (p *AWSProvider) rollbackBatches(ctx context.Context, submitted []submittedBatch, zones map[string]*profiledZone) {
+	for i := len(submitted) - 1; i >= 0; i-- {
+		z := submitted[i].zoneID
+		log := log.WithFields(log.Fields{
+			"zoneName": *zones[z].zone.Name,
+			"zoneID":   z,
+			"profile":  zones[z].profile,
+		})
+
+		if err := p.changeResourceRecordSets(ctx, z, zones[z], rollbackChanges(submitted[i].changes)); err != nil {
+			log.Errorf("Failed to roll back change batch, the records may be left partially updated: %v", err)
+			continue
+		}
+		log.Infof("%d record(s) were rolled back", len(submitted[i].changes))
+	}
+}
+
+func (p *AWSProvider) changeResourceRecordSets(ctx context.Context, zoneID string, zone *profiledZone, changes Route53Changes) error {
+	_, err := p.clients[zone.profile].ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(zoneID),
+		ChangeBatch: &route53types.ChangeBatch{
+			Changes: changes.Route53Changes(),
+		},
+	})
+	return err
+}
+
+// rollbackChanges returns the changes restoring the record sets as they were before the given changes:
+// created record sets are deleted, deleted ones are created again and upserted ones get their previous value.
+func rollbackChanges(changes Route53Changes) Route53Changes {
+	rollback := make(Route53Changes, 0, len(changes))
+	for _, c := range changes {
+		inverse := *c
+		inverse.previous = nil
+		switch c.Action {
+		case route53types.ChangeActionCreate:
+			inverse.Action = route53types.ChangeActionDelete
+		case route53types.ChangeActionDelete:
+			inverse.Action = route53types.ChangeActionCreate
+		case route53types.ChangeActionUpsert:
+			if c.previous == nil {
+				inverse.Action = route53types.ChangeActionDelete
+			} else {
+				inverse.ResourceRecordSet = c.previous
+			}
+		}
+		rollback = append(rollback, &inverse)
+	}
+	return sortChangesByActionNameType(rollback)
+}
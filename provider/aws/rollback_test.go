@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// failingBatchRoute53API fails the failOn-th change batch and records all the submitted batches.
+type failingBatchRoute53API struct {
+	*Route53APIStub
+	failOn  int
+	batches [][]route53types.Change
+}
+
+func (r *failingBatchRoute53API) ChangeResourceRecordSets(ctx context.Context, input *route53.ChangeResourceRecordSetsInput, optFns ...func(options *route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+	r.batches = append(r.batches, input.ChangeBatch.Changes)
+	if len(r.batches) == r.failOn {
+		return nil, errors.New("Mock route53 failure")
+	}
+	return r.Route53APIStub.ChangeResourceRecordSets(ctx, input, optFns...)
+}
+
+func newAWSProviderWithFailingBatch(t *testing.T, failOn int, records []route53types.ResourceRecordSet) (*AWSProvider, *failingBatchRoute53API) {
+	p, stub := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, false, records)
+	client := &failingBatchRoute53API{Route53APIStub: stub, failOn: failOn}
+	p.clients[defaultAWSProfile] = client
+	p.maxChangesPerBatch = 1
+	p.batchChangeInterval = 0
+	return p, client
+}
+
+func TestAWSApplyChangesRollsBackOnBatchFailure(t *testing.T) {
+	existing := []route53types.ResourceRecordSet{
+		{
+			Name:            aws.String("b-update.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type:            route53types.RRTypeA,
+			TTL:             aws.Int64(defaultTTL),
+			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String("1.1.1.1")}},
+		},
+		{
+			Name:            aws.String("d-delete.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type:            route53types.RRTypeA,
+			TTL:             aws.Int64(defaultTTL),
+			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String("4.4.4.4")}},
+		},
+	}
+	p, client := newAWSProviderWithFailingBatch(t, 4, existing)
+
+	changes := &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("a-create.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, defaultTTL, "1.2.3.4"),
+			endpoint.NewEndpointWithTTL("e-create.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, defaultTTL, "5.5.5.5"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("b-update.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, defaultTTL, "1.1.1.1"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("b-update.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, defaultTTL, "2.2.2.2"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("d-delete.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, defaultTTL, "4.4.4.4"),
+		},
+	}
+
+	err := p.ApplyChanges(context.Background(), changes)
+	require.ErrorIs(t, err, provider.SoftError)
+	assert.ErrorContains(t, err, "3 previously submitted batch(es) were rolled back")
+
+	// the three first batches succeeded, the fourth one failed and the three first ones are rolled back in reverse order
+	require.Len(t, client.batches, 7)
+	for i, expected := range []struct {
+		action route53types.ChangeAction
+		name   string
+		value  string
+	}{
+		{route53types.ChangeActionCreate, "a-create", "1.2.3.4"},
+		{route53types.ChangeActionUpsert, "b-update", "2.2.2.2"},
+		{route53types.ChangeActionDelete, "d-delete", "4.4.4.4"},
+		{route53types.ChangeActionCreate, "e-create", "5.5.5.5"},
+		{route53types.ChangeActionCreate, "d-delete", "4.4.4.4"},
+		{route53types.ChangeActionUpsert, "b-update", "1.1.1.1"},
+		{route53types.ChangeActionDelete, "a-create", "1.2.3.4"},
+	} {
+		require.Len(t, client.batches[i], 1)
+		c := client.batches[i][0]
+		assert.Equal(t, expected.action, c.Action, "batch %d", i)
+		assert.Equal(t, expected.name+".zone-1.ext-dns-test-2.teapot.zalan.do.", provider.EnsureTrailingDot(*c.ResourceRecordSet.Name), "batch %d", i)
+		assert.Equal(t, expected.value, *c.ResourceRecordSet.ResourceRecords[0].Value, "batch %d", i)
+	}
+
+	validateRecords(t, listAWSRecords(t, p.clients[defaultAWSProfile], "/hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do."), existing)
+}
+
+func TestAWSApplyChangesRollsBackFirstBatch(t *testing.T) {
+	p, client := newAWSProviderWithFailingBatch(t, 2, nil)
+
+	changes := &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("a.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, defaultTTL, "1.2.3.4"),
+			endpoint.NewEndpointWithTTL("b.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, defaultTTL, "5.6.7.8"),
+		},
+	}
+
+	require.ErrorIs(t, p.ApplyChanges(context.Background(), changes), provider.SoftError)
+	require.Len(t, client.batches, 3, "the first batch must be rolled back")
+	assert.Equal(t, route53types.ChangeActionDelete, client.batches[2][0].Action)
+	assert.Empty(t, listAWSRecords(t, p.clients[defaultAWSProfile], "/hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do."))
+}
+
+func TestAWSApplyChangesMaxChangesPerBatch(t *testing.T) {
+	p, client := newAWSProviderWithFailingBatch(t, 0, nil)
+	p.maxChangesPerBatch = 2
+
+	changes := &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("a.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, defaultTTL, "1.2.3.4"),
+			endpoint.NewEndpointWithTTL("b.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, defaultTTL, "1.2.3.4"),
+			endpoint.NewEndpointWithTTL("c.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, defaultTTL, "1.2.3.4"),
+		},
+	}
+
+	require.NoError(t, p.ApplyChanges(context.Background(), changes))
+	require.Len(t, client.batches, 2)
+	assert.Len(t, client.batches[0], 2)
+	assert.Len(t, client.batches[1], 1)
+	assert.Len(t, listAWSRecords(t, p.clients[defaultAWSProfile], "/hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do."), 3)
+}
@@ -0,0 +1,316 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	// providerSpecificTrafficPolicyID specifies that the endpoint is published as a traffic policy instance of the given traffic policy.
+	providerSpecificTrafficPolicyID = "aws/traffic-policy-id"
+	// providerSpecificTrafficPolicyVersion specifies the version of the traffic policy, the latest version is used when absent.
+	providerSpecificTrafficPolicyVersion = "aws/traffic-policy-version"
+)
+
+// Route53TrafficPolicyAPI is the subset of the AWS Route53 traffic policy API that we actually use, it is implemented by *route53.Client.
+// https://docs.aws.amazon.com/Route53/latest/DeveloperGuide/traffic-policies.html
+type Route53TrafficPolicyAPI interface {
+	ListTrafficPolicies(ctx context.Context, input *route53.ListTrafficPoliciesInput, optFns ...func(*route53.Options)) (*route53.ListTrafficPoliciesOutput, error)
+	ListTrafficPolicyInstancesByHostedZone(ctx context.Context, input *route53.ListTrafficPolicyInstancesByHostedZoneInput, optFns ...func(*route53.Options)) (*route53.ListTrafficPolicyInstancesByHostedZoneOutput, error)
+	CreateTrafficPolicyInstance(ctx context.Context, input *route53.CreateTrafficPolicyInstanceInput, optFns ...func(*route53.Options)) (*route53.CreateTrafficPolicyInstanceOutput, error)
+	UpdateTrafficPolicyInstance(ctx context.Context, input *route53.UpdateTrafficPolicyInstanceInput, optFns ...func(*route53.Options)) (*route53.UpdateTrafficPolicyInstanceOutput, error)
+	DeleteTrafficPolicyInstance(ctx context.Context, input *route53.DeleteTrafficPolicyInstanceInput, optFns ...func(*route53.Options)) (*route53.DeleteTrafficPolicyInstanceOutput, error)
+}
+
+// trafficPolicyAPIs returns the traffic policy API of the client of every profile.
+func trafficPolicyAPIs(clients map[string]Route53API) (map[string]Route53TrafficPolicyAPI, error) {
+	apis := make(map[string]Route53TrafficPolicyAPI, len(clients))
+	for profile, client := range clients {
+		api, ok := client.(Route53TrafficPolicyAPI)
+		if !ok {
+			return nil, fmt.Errorf("the Route53 client of aws profile %q does not support traffic policies", profile)
+		}
+		apis[profile] = api
+	}
+	return apis, nil
+}
+
+// isTrafficPolicy returns whether the endpoint is published as a traffic policy instance.
+func isTrafficPolicy(ep *endpoint.Endpoint) bool {
+	_, ok := ep.GetProviderSpecificProperty(providerSpecificTrafficPolicyID)
+	return ok
+}
+
+// trafficPolicies returns the traffic policies of every profile by ID.
+func (p *AWSProvider) trafficPolicies(ctx context.Context) (map[string]route53types.TrafficPolicySummary, error) {
+	policies := make(map[string]route53types.TrafficPolicySummary)
+	for profile, client := range p.trafficPolicyClients {
+		input := &route53.ListTrafficPoliciesInput{}
+		for {
+			resp, err := client.ListTrafficPolicies(ctx, input)
+			if err != nil {
+				return nil, provider.NewSoftErrorf("failed to list traffic policies using aws profile %q: %w", profile, err)
+			}
+			for _, policy := range resp.TrafficPolicySummaries {
+				policies[*policy.Id] = policy
+			}
+			if !resp.IsTruncated {
+				break
+			}
+			input.TrafficPolicyIdMarker = resp.TrafficPolicyIdMarker
+		}
+	}
+	return policies, nil
+}
+
+// adjustTrafficPolicyEndpoints modifies the endpoints of traffic policies to match the endpoints of their
+// traffic policy instances, the record type is the one of the traffic policy and the target is its ID.
+func (p *AWSProvider) adjustTrafficPolicyEndpoints(endpoints []*endpoint.Endpoint) error {
+	var policies map[string]route53types.TrafficPolicySummary
+
+	for _, ep := range endpoints {
+		id, ok := ep.GetProviderSpecificProperty(providerSpecificTrafficPolicyID)
+		if !ok {
+			continue
+		}
+		if !p.manageTrafficPolicies {
+			ep.DeleteProviderSpecificProperty(providerSpecificTrafficPolicyID)
+			ep.DeleteProviderSpecificProperty(providerSpecificTrafficPolicyVersion)
+			continue
+		}
+
+		if policies == nil {
+			var err error
+			if policies, err = p.trafficPolicies(context.Background()); err != nil {
+				return err
+			}
+		}
+
+		policy, ok := policies[id]
+		if !ok {
+			log.Warnf("Ignoring the unknown traffic policy %q of endpoint %v", id, ep)
+			ep.DeleteProviderSpecificProperty(providerSpecificTrafficPolicyID)
+			ep.DeleteProviderSpecificProperty(providerSpecificTrafficPolicyVersion)
+			continue
+		}
+
+		version := strconv.Itoa(int(*policy.LatestVersion))
+		if prop, ok := ep.GetProviderSpecificProperty(providerSpecificTrafficPolicyVersion); ok {
+			if v, err := strconv.ParseInt(prop, 10, 32); err == nil && v > 0 && v <= int64(*policy.LatestVersion) {
+				version = strconv.FormatInt(v, 10)
+			} else {
+				log.Warnf("Ignoring invalid version %q of traffic policy %q, using the latest version %s", prop, id, version)
+			}
+		}
+
+		log.Debugf("Modifying endpoint: %v, publishing it as an instance of traffic policy %s version %s", ep, id, version)
+		ep.RecordType = string(policy.Type)
+		ep.Targets = endpoint.Targets{id}
+		ep.SetProviderSpecificProperty(providerSpecificTrafficPolicyVersion, version)
+	}
+
+	return nil
+}
+
+// trafficPolicyInstances returns the traffic policy instances of a zone.
+func (p *AWSProvider) trafficPolicyInstances(ctx context.Context, z *profiledZone) ([]route53types.TrafficPolicyInstance, error) {
+	var instances []route53types.TrafficPolicyInstance
+	input := &route53.ListTrafficPolicyInstancesByHostedZoneInput{HostedZoneId: z.zone.Id}
+	for {
+		resp, err := p.trafficPolicyClients[z.profile].ListTrafficPolicyInstancesByHostedZone(ctx, input)
+		if err != nil {
+			return nil, provider.NewSoftErrorf("failed to list traffic policy instances for zone %s using aws profile %q: %w", *z.zone.Id, z.profile, err)
+		}
+		instances = append(instances, resp.TrafficPolicyInstances...)
+		if !resp.IsTruncated {
+			return instances, nil
+		}
+		input.TrafficPolicyInstanceNameMarker = resp.TrafficPolicyInstanceNameMarker
+		input.TrafficPolicyInstanceTypeMarker = resp.TrafficPolicyInstanceTypeMarker
+	}
+}
+
+// trafficPolicyRecords returns an endpoint for every traffic policy instance of the zones.
+func (p *AWSProvider) trafficPolicyRecords(ctx context.Context, zones map[string]*profiledZone) ([]*endpoint.Endpoint, error) {
+	var endpoints []*endpoint.Endpoint
+	for _, z := range zones {
+		instances, err := p.trafficPolicyInstances(ctx, z)
+		if err != nil {
+			return nil, err
+		}
+		for _, instance := range instances {
+			ep := endpoint.NewEndpointWithTTL(convertOctalToAscii(wildcardUnescape(*instance.Name)), string(instance.TrafficPolicyType), endpoint.TTL(aws.ToInt64(instance.TTL)), *instance.TrafficPolicyId).
+				WithProviderSpecific(providerSpecificTrafficPolicyID, *instance.TrafficPolicyId).
+				WithProviderSpecific(providerSpecificTrafficPolicyVersion, strconv.Itoa(int(aws.ToInt32(instance.TrafficPolicyVersion))))
+			endpoints = append(endpoints, ep)
+		}
+	}
+	return endpoints, nil
+}
+
+// splitTrafficPolicyChanges separates the changes of traffic policy instances from the changes of the records,
+// an update replacing a record by a traffic policy instance or the other way round becomes a deletion and a creation.
+func splitTrafficPolicyChanges(changes *plan.Changes) (trafficPolicyChanges, recordChanges *plan.Changes) {
+	trafficPolicyChanges = &plan.Changes{}
+	recordChanges = &plan.Changes{}
+
+	for _, ep := range changes.Create {
+		if isTrafficPolicy(ep) {
+			trafficPolicyChanges.Create = append(trafficPolicyChanges.Create, ep)
+		} else {
+			recordChanges.Create = append(recordChanges.Create, ep)
+		}
+	}
+
+	for i, current := range changes.UpdateOld {
+		desired := changes.UpdateNew[i]
+		switch {
+		case isTrafficPolicy(current) && isTrafficPolicy(desired):
+			trafficPolicyChanges.UpdateOld = append(trafficPolicyChanges.UpdateOld, current)
+			trafficPolicyChanges.UpdateNew = append(trafficPolicyChanges.UpdateNew, desired)
+		case isTrafficPolicy(current):
+			trafficPolicyChanges.Delete = append(trafficPolicyChanges.Delete, current)
+			recordChanges.Create = append(recordChanges.Create, desired)
+		case isTrafficPolicy(desired):
+			recordChanges.Delete = append(recordChanges.Delete, current)
+			trafficPolicyChanges.Create = append(trafficPolicyChanges.Create, desired)
+		default:
+			recordChanges.UpdateOld = append(recordChanges.UpdateOld, current)
+			recordChanges.UpdateNew = append(recordChanges.UpdateNew, desired)
+		}
+	}
+
+	for _, ep := range changes.Delete {
+		if isTrafficPolicy(ep) {
+			trafficPolicyChanges.Delete = append(trafficPolicyChanges.Delete, ep)
+		} else {
+			recordChanges.Delete = append(recordChanges.Delete, ep)
+		}
+	}
+
+	return trafficPolicyChanges, recordChanges
+}
+
+// deleteTrafficPolicyInstances deletes the traffic policy instances of the endpoints.
+func (p *AWSProvider) deleteTrafficPolicyInstances(ctx context.Context, endpoints []*endpoint.Endpoint, zones map[string]*profiledZone) error {
+	instances := make(map[string][]route53types.TrafficPolicyInstance)
+	for _, ep := range endpoints {
+		for _, z := range suitableZones(provider.EnsureTrailingDot(ep.DNSName), zones) {
+			instance, err := p.findTrafficPolicyInstance(ctx, ep, z, instances)
+			if err != nil {
+				return err
+			}
+			if instance == nil {
+				continue
+			}
+			log.Infof("Desired change: DELETE traffic policy instance %s %s [Id: %s]", ep.DNSName, ep.RecordType, *z.zone.Id)
+			if p.dryRun {
+				continue
+			}
+			if _, err := p.trafficPolicyClients[z.profile].DeleteTrafficPolicyInstance(ctx, &route53.DeleteTrafficPolicyInstanceInput{Id: instance.Id}); err != nil {
+				return provider.NewSoftErrorf("failed to delete traffic policy instance %s in zone %s: %w", ep.DNSName, *z.zone.Id, err)
+			}
+		}
+	}
+	return nil
+}
+
+// upsertTrafficPolicyInstances creates the traffic policy instances of the endpoints, or updates them when they exist.
+func (p *AWSProvider) upsertTrafficPolicyInstances(ctx context.Context, endpoints []*endpoint.Endpoint, zones map[string]*profiledZone) error {
+	instances := make(map[string][]route53types.TrafficPolicyInstance)
+	for _, ep := range endpoints {
+		id, _ := ep.GetProviderSpecificProperty(providerSpecificTrafficPolicyID)
+		prop, _ := ep.GetProviderSpecificProperty(providerSpecificTrafficPolicyVersion)
+		version, err := strconv.ParseInt(prop, 10, 32)
+		if err != nil {
+			log.Errorf("Skipping traffic policy instance %s, failed parsing value of %s: %s: %v", ep.DNSName, providerSpecificTrafficPolicyVersion, prop, err)
+			continue
+		}
+		ttl := int64(defaultTTL)
+		if ep.RecordTTL.IsConfigured() {
+			ttl = int64(ep.RecordTTL)
+		}
+
+		for _, z := range suitableZones(provider.EnsureTrailingDot(ep.DNSName), zones) {
+			instance, err := p.findTrafficPolicyInstance(ctx, ep, z, instances)
+			if err != nil {
+				return err
+			}
+			if instance == nil {
+				log.Infof("Desired change: CREATE traffic policy instance %s %s [Id: %s]", ep.DNSName, ep.RecordType, *z.zone.Id)
+				if p.dryRun {
+					continue
+				}
+				_, err = p.trafficPolicyClients[z.profile].CreateTrafficPolicyInstance(ctx, &route53.CreateTrafficPolicyInstanceInput{
+					HostedZoneId:         z.zone.Id,
+					Name:                 aws.String(provider.EnsureTrailingDot(ep.DNSName)),
+					TTL:                  aws.Int64(ttl),
+					TrafficPolicyId:      aws.String(id),
+					TrafficPolicyVersion: aws.Int32(int32(version)),
+				})
+			} else {
+				log.Infof("Desired change: UPDATE traffic policy instance %s %s [Id: %s]", ep.DNSName, ep.RecordType, *z.zone.Id)
+				if p.dryRun {
+					continue
+				}
+				_, err = p.trafficPolicyClients[z.profile].UpdateTrafficPolicyInstance(ctx, &route53.UpdateTrafficPolicyInstanceInput{
+					Id:                   instance.Id,
+					TTL:                  aws.Int64(ttl),
+					TrafficPolicyId:      aws.String(id),
+					TrafficPolicyVersion: aws.Int32(int32(version)),
+				})
+			}
+			if err != nil {
+				return provider.NewSoftErrorf("failed to submit traffic policy instance %s in zone %s: %w", ep.DNSName, *z.zone.Id, err)
+			}
+		}
+	}
+	return nil
+}
+
+// findTrafficPolicyInstance returns the traffic policy instance of the endpoint in the zone, the instances
+// of the zone are listed once and kept in the given map.
+func (p *AWSProvider) findTrafficPolicyInstance(ctx context.Context, ep *endpoint.Endpoint, z *profiledZone, instances map[string][]route53types.TrafficPolicyInstance) (*route53types.TrafficPolicyInstance, error) {
+	zoneInstances, ok := instances[*z.zone.Id]
+	if !ok {
+		var err error
+		if zoneInstances, err = p.trafficPolicyInstances(ctx, z); err != nil {
+			return nil, err
+		}
+		instances[*z.zone.Id] = zoneInstances
+	}
+
+	name := provider.EnsureTrailingDot(ep.DNSName)
+	for i, instance := range zoneInstances {
+		if convertOctalToAscii(wildcardUnescape(*instance.Name)) == name && string(instance.TrafficPolicyType) == ep.RecordType {
+			return &zoneInstances[i], nil
+		}
+	}
+	return nil, nil
+}
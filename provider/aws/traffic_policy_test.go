@@ -0,0 +1,289 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// Compile time check for interface conformance
+var _ Route53TrafficPolicyAPI = &route53.Client{}
+
+const (
+	testTrafficPolicyID     = "11111111-2222-3333-4444-555555555555"
+	testTrafficPolicyRecord = "policy.zone-1.ext-dns-test-2.teapot.zalan.do"
+)
+
+// trafficPolicyStub keeps the traffic policy instances of every zone in memory.
+type trafficPolicyStub struct {
+	*Route53APIStub
+	policies  []route53types.TrafficPolicySummary
+	instances map[string][]route53types.TrafficPolicyInstance
+	listErr   error
+	nextID    int
+}
+
+func newTrafficPolicyStub(t *testing.T) *trafficPolicyStub {
+	return &trafficPolicyStub{
+		Route53APIStub: NewRoute53APIStub(t),
+		policies: []route53types.TrafficPolicySummary{{
+			Id:            aws.String(testTrafficPolicyID),
+			LatestVersion: aws.Int32(3),
+			Name:          aws.String("latency"),
+			Type:          route53types.RRTypeA,
+		}},
+		instances: make(map[string][]route53types.TrafficPolicyInstance),
+	}
+}
+
+func (s *trafficPolicyStub) ListTrafficPolicies(_ context.Context, _ *route53.ListTrafficPoliciesInput, _ ...func(*route53.Options)) (*route53.ListTrafficPoliciesOutput, error) {
+	if s.listErr != nil {
+		return nil, s.listErr
+	}
+	return &route53.ListTrafficPoliciesOutput{TrafficPolicySummaries: s.policies}, nil
+}
+
+func (s *trafficPolicyStub) ListTrafficPolicyInstancesByHostedZone(_ context.Context, input *route53.ListTrafficPolicyInstancesByHostedZoneInput, _ ...func(*route53.Options)) (*route53.ListTrafficPolicyInstancesByHostedZoneOutput, error) {
+	return &route53.ListTrafficPolicyInstancesByHostedZoneOutput{TrafficPolicyInstances: s.instances[*input.HostedZoneId]}, nil
+}
+
+func (s *trafficPolicyStub) CreateTrafficPolicyInstance(_ context.Context, input *route53.CreateTrafficPolicyInstanceInput, _ ...func(*route53.Options)) (*route53.CreateTrafficPolicyInstanceOutput, error) {
+	s.nextID++
+	instance := route53types.TrafficPolicyInstance{
+		Id:                   aws.String(fmt.Sprintf("instance-%d", s.nextID)),
+		HostedZoneId:         input.HostedZoneId,
+		Name:                 input.Name,
+		TTL:                  input.TTL,
+		TrafficPolicyId:      input.TrafficPolicyId,
+		TrafficPolicyVersion: input.TrafficPolicyVersion,
+		TrafficPolicyType:    route53types.RRTypeA,
+		State:                aws.String("Applied"),
+	}
+	s.instances[*input.HostedZoneId] = append(s.instances[*input.HostedZoneId], instance)
+	return &route53.CreateTrafficPolicyInstanceOutput{TrafficPolicyInstance: &instance}, nil
+}
+
+func (s *trafficPolicyStub) UpdateTrafficPolicyInstance(_ context.Context, input *route53.UpdateTrafficPolicyInstanceInput, _ ...func(*route53.Options)) (*route53.UpdateTrafficPolicyInstanceOutput, error) {
+	for _, instances := range s.instances {
+		for i := range instances {
+			if *instances[i].Id == *input.Id {
+				instances[i].TTL = input.TTL
+				instances[i].TrafficPolicyId = input.TrafficPolicyId
+				instances[i].TrafficPolicyVersion = input.TrafficPolicyVersion
+				return &route53.UpdateTrafficPolicyInstanceOutput{TrafficPolicyInstance: &instances[i]}, nil
+			}
+		}
+	}
+	return nil, errors.New("no such traffic policy instance")
+}
+
+func (s *trafficPolicyStub) DeleteTrafficPolicyInstance(_ context.Context, input *route53.DeleteTrafficPolicyInstanceInput, _ ...func(*route53.Options)) (*route53.DeleteTrafficPolicyInstanceOutput, error) {
+	for zone, instances := range s.instances {
+		for i := range instances {
+			if *instances[i].Id == *input.Id {
+				s.instances[zone] = append(instances[:i], instances[i+1:]...)
+				return &route53.DeleteTrafficPolicyInstanceOutput{}, nil
+			}
+		}
+	}
+	return nil, errors.New("no such traffic policy instance")
+}
+
+func newAWSProviderWithTrafficPolicies(t *testing.T, dryRun bool, records []route53types.ResourceRecordSet) (*AWSProvider, *trafficPolicyStub) {
+	p, _ := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter("public"), defaultEvaluateTargetHealth, dryRun, records)
+	stub := newTrafficPolicyStub(t)
+	p.manageTrafficPolicies = true
+	p.trafficPolicyClients = map[string]Route53TrafficPolicyAPI{defaultAWSProfile: stub}
+	return p, stub
+}
+
+func trafficPolicyEndpoint(version string) *endpoint.Endpoint {
+	ep := endpoint.NewEndpoint(testTrafficPolicyRecord, endpoint.RecordTypeCNAME, "lb.example.com").
+		WithProviderSpecific(providerSpecificTrafficPolicyID, testTrafficPolicyID)
+	if version != "" {
+		ep = ep.WithProviderSpecific(providerSpecificTrafficPolicyVersion, version)
+	}
+	return ep
+}
+
+// syncTrafficPolicies applies the changes between the records of the provider and the desired endpoints.
+func syncTrafficPolicies(t *testing.T, p *AWSProvider, desired []*endpoint.Endpoint) {
+	t.Helper()
+	ctx := context.Background()
+
+	desired, err := p.AdjustEndpoints(desired)
+	require.NoError(t, err)
+	current, err := p.Records(ctx)
+	require.NoError(t, err)
+
+	changes := (&plan.Plan{
+		Current:        current,
+		Desired:        desired,
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+		DomainFilter:   endpoint.MatchAllDomainFilters{p.domainFilter},
+	}).Calculate().Changes
+	require.NoError(t, p.ApplyChanges(ctx, changes))
+}
+
+func TestNewAWSProviderTrafficPolicies(t *testing.T) {
+	_, err := NewAWSProvider(AWSConfig{ManageTrafficPolicies: true}, map[string]Route53API{defaultAWSProfile: NewRoute53APIStub(t)})
+	require.EqualError(t, err, `the Route53 client of aws profile "default" does not support traffic policies`)
+
+	stub := newTrafficPolicyStub(t)
+	p, err := NewAWSProvider(AWSConfig{ManageTrafficPolicies: true}, map[string]Route53API{defaultAWSProfile: stub})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Route53TrafficPolicyAPI{defaultAWSProfile: stub}, p.trafficPolicyClients)
+}
+
+func TestAWSAdjustEndpointsTrafficPolicy(t *testing.T) {
+	p, _ := newAWSProviderWithTrafficPolicies(t, false, nil)
+
+	unknown := endpoint.NewEndpoint("unknown.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "1.2.3.4").
+		WithProviderSpecific(providerSpecificTrafficPolicyID, "unknown")
+	endpoints, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		trafficPolicyEndpoint(""),
+		trafficPolicyEndpoint("2"),
+		trafficPolicyEndpoint("4"),
+		unknown,
+	})
+	require.NoError(t, err)
+	require.Len(t, endpoints, 4)
+
+	for i, version := range []string{"3", "2", "3"} {
+		assert.Equal(t, endpoint.RecordTypeA, endpoints[i].RecordType)
+		assert.Equal(t, endpoint.Targets{testTrafficPolicyID}, endpoints[i].Targets)
+		assert.Equal(t, endpoint.ProviderSpecific{
+			{Name: providerSpecificTrafficPolicyID, Value: testTrafficPolicyID},
+			{Name: providerSpecificTrafficPolicyVersion, Value: version},
+		}, endpoints[i].ProviderSpecific)
+	}
+	assert.Empty(t, endpoints[3].ProviderSpecific, "the properties of an unknown traffic policy must be removed")
+	assert.Equal(t, endpoint.Targets{"1.2.3.4"}, endpoints[3].Targets)
+
+	p.manageTrafficPolicies = false
+	endpoints, err = p.AdjustEndpoints([]*endpoint.Endpoint{trafficPolicyEndpoint("2")})
+	require.NoError(t, err)
+	assert.Equal(t, endpoint.RecordTypeCNAME, endpoints[0].RecordType)
+	assert.Equal(t, endpoint.Targets{"lb.example.com"}, endpoints[0].Targets)
+	_, ok := endpoints[0].GetProviderSpecificProperty(providerSpecificTrafficPolicyID)
+	assert.False(t, ok)
+}
+
+func TestAWSAdjustEndpointsTrafficPolicyError(t *testing.T) {
+	p, stub := newAWSProviderWithTrafficPolicies(t, false, nil)
+	stub.listErr = errors.New("throttled")
+
+	_, err := p.AdjustEndpoints([]*endpoint.Endpoint{trafficPolicyEndpoint("")})
+	require.ErrorIs(t, err, provider.SoftError)
+}
+
+func TestAWSTrafficPolicyLifecycle(t *testing.T) {
+	p, stub := newAWSProviderWithTrafficPolicies(t, false, []route53types.ResourceRecordSet{
+		{
+			Name:            aws.String("other.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type:            route53types.RRTypeA,
+			TTL:             aws.Int64(defaultTTL),
+			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String("8.8.8.8")}},
+		},
+		{
+			// a record of an instance that is not managed by external-dns
+			Name:                    aws.String("unmanaged.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type:                    route53types.RRTypeA,
+			TTL:                     aws.Int64(defaultTTL),
+			ResourceRecords:         []route53types.ResourceRecord{{Value: aws.String("8.8.4.4")}},
+			TrafficPolicyInstanceId: aws.String("unmanaged"),
+		},
+	})
+	zone := "/hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do."
+	other := endpoint.NewEndpoint("other.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, "8.8.8.8")
+
+	syncTrafficPolicies(t, p, []*endpoint.Endpoint{other, trafficPolicyEndpoint("2")})
+	require.Len(t, stub.instances[zone], 1)
+	instance := stub.instances[zone][0]
+	assert.Equal(t, "policy.zone-1.ext-dns-test-2.teapot.zalan.do.", *instance.Name)
+	assert.Equal(t, int64(defaultTTL), *instance.TTL)
+	assert.Equal(t, testTrafficPolicyID, *instance.TrafficPolicyId)
+	assert.Equal(t, int32(2), *instance.TrafficPolicyVersion)
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("other.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, defaultTTL, "8.8.8.8"),
+		endpoint.NewEndpointWithTTL(testTrafficPolicyRecord, endpoint.RecordTypeA, defaultTTL, testTrafficPolicyID).
+			WithProviderSpecific(providerSpecificTrafficPolicyID, testTrafficPolicyID).
+			WithProviderSpecific(providerSpecificTrafficPolicyVersion, "2"),
+	}, records)
+
+	// without a version the instance is updated to the latest version of the traffic policy
+	syncTrafficPolicies(t, p, []*endpoint.Endpoint{other, trafficPolicyEndpoint("")})
+	require.Len(t, stub.instances[zone], 1)
+	assert.Equal(t, *instance.Id, *stub.instances[zone][0].Id)
+	assert.Equal(t, int32(3), *stub.instances[zone][0].TrafficPolicyVersion)
+
+	// the instance is deleted before the record replacing it is created
+	syncTrafficPolicies(t, p, []*endpoint.Endpoint{other, endpoint.NewEndpoint(testTrafficPolicyRecord, endpoint.RecordTypeA, "1.2.3.4")})
+	assert.Empty(t, stub.instances[zone])
+	validateRecords(t, listAWSRecords(t, p.clients[defaultAWSProfile], zone), []route53types.ResourceRecordSet{
+		{
+			Name:            aws.String("other.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type:            route53types.RRTypeA,
+			TTL:             aws.Int64(defaultTTL),
+			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String("8.8.8.8")}},
+		},
+		{
+			Name:                    aws.String("unmanaged.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type:                    route53types.RRTypeA,
+			TTL:                     aws.Int64(defaultTTL),
+			ResourceRecords:         []route53types.ResourceRecord{{Value: aws.String("8.8.4.4")}},
+			TrafficPolicyInstanceId: aws.String("unmanaged"),
+		},
+		{
+			Name:            aws.String("policy.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type:            route53types.RRTypeA,
+			TTL:             aws.Int64(defaultTTL),
+			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String("1.2.3.4")}},
+		},
+	})
+
+	// the record is deleted before the instance replacing it is created
+	syncTrafficPolicies(t, p, []*endpoint.Endpoint{other, trafficPolicyEndpoint("")})
+	require.Len(t, stub.instances[zone], 1)
+	assert.Len(t, listAWSRecords(t, p.clients[defaultAWSProfile], zone), 2)
+
+	syncTrafficPolicies(t, p, []*endpoint.Endpoint{other})
+	assert.Empty(t, stub.instances[zone])
+}
+
+func TestAWSTrafficPolicyDryRun(t *testing.T) {
+	p, stub := newAWSProviderWithTrafficPolicies(t, true, nil)
+
+	syncTrafficPolicies(t, p, []*endpoint.Endpoint{trafficPolicyEndpoint("")})
+	assert.Empty(t, stub.instances)
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// submitChangesTransactionally submits all the changes of a zone in a single change batch, which Route53 applies
+// atomically, and stops at the first zone whose batch fails so that the following zones are left unchanged. No zone
+// is updated when the changes of one of them don't fit in a single batch.
+func (p *AWSProvider) submitChangesTransactionally(ctx context.Context, changesByZone map[string]Route53Changes, zones map[string]*profiledZone) error {
+	zoneIDs := make([]string, 0, len(changesByZone))
+	for z := range changesByZone {
+		zoneIDs = append(zoneIDs, z)
+	}
+	sort.Strings(zoneIDs)
+
+	for _, z := range zoneIDs {
+		if err := p.validateTransactionalBatch(changesByZone[z]); err != nil {
+			return fmt.Errorf("the changes of zone %s can't be submitted in a single batch: %w", z, err)
+		}
+	}
+
+	for i, z := range zoneIDs {
+		log := log.WithFields(log.Fields{
+			"zoneName": *zones[z].zone.Name,
+			"zoneID":   z,
+			"profile":  zones[z].profile,
+		})
+
+		changes := changesByZone[z]
+		if len(changes) == 0 {
+			continue
+		}
+
+		for _, c := range changes {
+			log.Infof("Desired change: %s %s %s", c.Action, *c.ResourceRecordSet.Name, c.ResourceRecordSet.Type)
+		}
+
+		if p.dryRun {
+			continue
+		}
+
+		if err := p.changeResourceRecordSets(ctx, z, zones[z], changes); err != nil {
+			log.Errorf("Failure in zone %s when submitting change batch: %v", *zones[z].zone.Name, err)
+			return provider.NewSoftErrorf("failed to submit the changes of zone %s, the changes of the following zones were not submitted: %v: %w", z, zoneIDs[i+1:], err)
+		}
+		log.Infof("%d record(s) were successfully updated", len(changes))
+	}
+
+	return nil
+}
+
+// validateTransactionalBatch checks that the changes of a zone fit in a single change batch.
+func (p *AWSProvider) validateTransactionalBatch(changes Route53Changes) error {
+	if len(changes) > p.batchChangeSize {
+		return fmt.Errorf("%d changes exceed the batch size of %d", len(changes), p.batchChangeSize)
+	}
+	if bytes := countChangeBytes(changes); bytes > p.batchChangeSizeBytes {
+		return fmt.Errorf("%d bytes exceed the batch size bytes of %d", bytes, p.batchChangeSizeBytes)
+	}
+	if values := countChangeValues(changes); values > p.batchChangeSizeValues {
+		return fmt.Errorf("%d values exceed the batch size values of %d", values, p.batchChangeSizeValues)
+	}
+	return nil
+}
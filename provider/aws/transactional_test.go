@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+func newAWSProviderWithTransactionalZoneUpdates(t *testing.T, failOn int) (*AWSProvider, *failingBatchRoute53API) {
+	p, client := newAWSProviderWithFailingBatch(t, failOn, nil)
+	p.maxChangesPerBatch = 0
+	p.batchChangeSize = 2
+	p.transactionalZoneUpdates = true
+	return p, client
+}
+
+func transactionalChanges() *plan.Changes {
+	return &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("a.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, defaultTTL, "1.2.3.4"),
+			endpoint.NewEndpointWithTTL("b.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, defaultTTL, "1.2.3.4"),
+			endpoint.NewEndpointWithTTL("a.zone-2.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, defaultTTL, "1.2.3.4"),
+			endpoint.NewEndpointWithTTL("b.zone-2.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, defaultTTL, "1.2.3.4"),
+			endpoint.NewEndpointWithTTL("a.zone-3.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, defaultTTL, "1.2.3.4"),
+		},
+	}
+}
+
+func TestAWSApplyChangesTransactionalZoneUpdates(t *testing.T) {
+	p, client := newAWSProviderWithTransactionalZoneUpdates(t, 0)
+
+	require.NoError(t, p.ApplyChanges(context.Background(), transactionalChanges()))
+
+	// the changes of every zone are submitted in a single batch
+	require.Len(t, client.batches, 3)
+	assert.Len(t, client.batches[0], 2)
+	assert.Len(t, client.batches[1], 2)
+	assert.Len(t, client.batches[2], 1)
+	assert.Len(t, listAWSRecords(t, p.clients[defaultAWSProfile], "/hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do."), 2)
+	assert.Len(t, listAWSRecords(t, p.clients[defaultAWSProfile], "/hostedzone/zone-2.ext-dns-test-2.teapot.zalan.do."), 2)
+	assert.Len(t, listAWSRecords(t, p.clients[defaultAWSProfile], "/hostedzone/zone-3.ext-dns-test-2.teapot.zalan.do."), 1)
+}
+
+func TestAWSApplyChangesTransactionalZoneUpdatesStopsAtFailedZone(t *testing.T) {
+	p, client := newAWSProviderWithTransactionalZoneUpdates(t, 2)
+
+	err := p.ApplyChanges(context.Background(), transactionalChanges())
+	require.ErrorIs(t, err, provider.SoftError)
+	assert.ErrorContains(t, err, "failed to submit the changes of zone /hostedzone/zone-2.ext-dns-test-2.teapot.zalan.do., the changes of the following zones were not submitted: [/hostedzone/zone-3.ext-dns-test-2.teapot.zalan.do.]")
+
+	require.Len(t, client.batches, 2, "the failed batch must neither be retried change by change nor be followed by the next zone")
+	assert.Len(t, listAWSRecords(t, p.clients[defaultAWSProfile], "/hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do."), 2)
+	assert.Empty(t, listAWSRecords(t, p.clients[defaultAWSProfile], "/hostedzone/zone-2.ext-dns-test-2.teapot.zalan.do."))
+	assert.Empty(t, listAWSRecords(t, p.clients[defaultAWSProfile], "/hostedzone/zone-3.ext-dns-test-2.teapot.zalan.do."))
+	assert.Empty(t, p.failedChangesQueue, "the changes of the failed zone must not be queued")
+}
+
+func TestAWSApplyChangesTransactionalZoneUpdatesBatchTooLarge(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		setup func(p *AWSProvider)
+		err   string
+	}{
+		{
+			name:  "batch size",
+			setup: func(p *AWSProvider) { p.batchChangeSize = 1 },
+			err:   "2 changes exceed the batch size of 1",
+		},
+		{
+			name:  "batch size bytes",
+			setup: func(p *AWSProvider) { p.batchChangeSizeBytes = 10 },
+			err:   "14 bytes exceed the batch size bytes of 10",
+		},
+		{
+			name:  "batch size values",
+			setup: func(p *AWSProvider) { p.batchChangeSizeValues = 1 },
+			err:   "2 values exceed the batch size values of 1",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p, client := newAWSProviderWithTransactionalZoneUpdates(t, 0)
+			tt.setup(p)
+
+			err := p.ApplyChanges(context.Background(), transactionalChanges())
+			require.EqualError(t, err, "the changes of zone /hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do. can't be submitted in a single batch: "+tt.err)
+
+			assert.Empty(t, client.batches, "no zone must be updated")
+		})
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"weak"
+
+	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/pkg/filewatch"
+)
+
+// webIdentityTokenFileEnv is the environment variable of the web identity token file, which is set by
+// the EKS pod identity webhook and rotated by the Kubernetes token projector.
+const webIdentityTokenFileEnv = "AWS_WEB_IDENTITY_TOKEN_FILE"
+
+// tokenWatchers are the watchers of the web identity token files by file, so that each file is watched once
+// for all the configurations created with it, however many times the provider is rebuilt.
+var tokenWatchers = struct {
+	sync.Mutex
+	byFile map[string]*tokenWatcher
+}{byFile: make(map[string]*tokenWatcher)}
+
+// tokenWatcher refreshes the cached credentials of the configurations created with a web identity token file.
+type tokenWatcher struct {
+	tokenFile string
+	ctx       context.Context
+	mu        sync.Mutex
+	// chains are the cached credentials of each configuration, in the order they are assumed. They are held
+	// weakly, so that the credentials of the configurations which are no longer used are dropped.
+	chains [][]weak.Pointer[awsv2.CredentialsCache]
+}
+
+// watchWebIdentityToken refreshes the cached credentials whenever the web identity token file changes, rather than
+// when they expire, so that the credentials are always obtained with a valid token. The credentials which aren't
+// cached are ignored. The file is watched until ctx is done by a watcher shared by all the credentials of the file.
+func watchWebIdentityToken(ctx context.Context, tokenFile string, credentials ...awsv2.CredentialsProvider) error {
+	var chain []weak.Pointer[awsv2.CredentialsCache]
+	for _, c := range credentials {
+		if cache, ok := c.(*awsv2.CredentialsCache); ok {
+			chain = append(chain, weak.Make(cache))
+		}
+	}
+	if len(chain) == 0 {
+		return nil
+	}
+
+	tokenWatchers.Lock()
+	defer tokenWatchers.Unlock()
+	watcher, ok := tokenWatchers.byFile[tokenFile]
+	if !ok || watcher.ctx.Err() != nil {
+		watcher = &tokenWatcher{tokenFile: tokenFile, ctx: ctx}
+		if err := watcher.start(); err != nil {
+			return err
+		}
+		tokenWatchers.byFile[tokenFile] = watcher
+	}
+	watcher.mu.Lock()
+	watcher.chains = append(watcher.chains, chain)
+	watcher.mu.Unlock()
+	return nil
+}
+
+// start watches the token file, which is replaced through a symlink.
+func (w *tokenWatcher) start() error {
+	err := filewatch.Watch(w.ctx, w.tokenFile,
+		func(event fsnotify.Event) {
+			if event.Op&(fsnotify.Create|fsnotify.Write|fsnotify.Rename) != 0 {
+				w.refreshCredentials()
+			}
+		},
+		func(err error) { logrus.Warnf("Failed to watch the web identity token file %s: %v", w.tokenFile, err) })
+	if err != nil {
+		return fmt.Errorf("failed to watch the web identity token file %s: %w", w.tokenFile, err)
+	}
+	return nil
+}
+
+// refreshCredentials invalidates and retrieves the credentials of each configuration in order, the credentials
+// assumed with other credentials follow them. The configurations whose credentials were dropped are forgotten.
+func (w *tokenWatcher) refreshCredentials() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	chains := w.chains[:0]
+	refreshed := true
+	for _, chain := range w.chains {
+		caches := make([]*awsv2.CredentialsCache, 0, len(chain))
+		for _, pointer := range chain {
+			if cache := pointer.Value(); cache != nil {
+				caches = append(caches, cache)
+			}
+		}
+		if len(caches) < len(chain) {
+			continue
+		}
+		chains = append(chains, chain)
+		refreshed = refreshChain(w.ctx, w.tokenFile, caches) && refreshed
+	}
+	clear(w.chains[len(chains):])
+	w.chains = chains
+	if refreshed {
+		logrus.Infof("Refreshed the AWS credentials after a change of the web identity token file %s", w.tokenFile)
+	}
+}
+
+// refreshChain invalidates and retrieves the credentials in order, it stops at the first failure.
+func refreshChain(ctx context.Context, tokenFile string, caches []*awsv2.CredentialsCache) bool {
+	for _, cache := range caches {
+		cache.Invalidate()
+		if _, err := cache.Retrieve(ctx); err != nil {
+			logrus.Warnf("Failed to refresh the AWS credentials after a change of the web identity token file %s: %v", tokenFile, err)
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	ststypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// webIdentitySTSStub returns credentials named after the web identity token they are assumed with.
+type webIdentitySTSStub struct {
+	mu     sync.Mutex
+	tokens []string
+}
+
+func (s *webIdentitySTSStub) AssumeRoleWithWebIdentity(_ context.Context, params *sts.AssumeRoleWithWebIdentityInput, _ ...func(*sts.Options)) (*sts.AssumeRoleWithWebIdentityOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = append(s.tokens, *params.WebIdentityToken)
+	return &sts.AssumeRoleWithWebIdentityOutput{
+		Credentials: &ststypes.Credentials{
+			AccessKeyId:     aws.String("AKID-" + *params.WebIdentityToken),
+			SecretAccessKey: aws.String("SECRET"),
+			SessionToken:    aws.String("SESSION"),
+			Expiration:      aws.Time(time.Now().Add(time.Hour)),
+		},
+	}, nil
+}
+
+func (s *webIdentitySTSStub) assumedTokens() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.tokens...)
+}
+
+// replaceFile replaces the file atomically like the Kubernetes token projector.
+func replaceFile(t *testing.T, path, content string) {
+	tmp := path + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, []byte(content), 0o600))
+	require.NoError(t, os.Rename(tmp, path))
+}
+
+func TestWatchWebIdentityToken(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token-1"), 0o600))
+
+	stub := &webIdentitySTSStub{}
+	creds := aws.NewCredentialsCache(stscreds.NewWebIdentityRoleProvider(stub, "arn:aws:iam::123456789012:role/external-dns", stscreds.IdentityTokenFile(tokenFile)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, watchWebIdentityToken(ctx, tokenFile, creds))
+
+	value, err := creds.Retrieve(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "AKID-token-1", value.AccessKeyID)
+
+	replaceFile(t, tokenFile, "token-2")
+
+	assert.Eventually(t, func() bool {
+		tokens := stub.assumedTokens()
+		return len(tokens) > 1 && tokens[len(tokens)-1] == "token-2"
+	}, 5*time.Second, 10*time.Millisecond, "the credentials must be refreshed when the token is replaced")
+
+	value, err = creds.Retrieve(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "AKID-token-2", value.AccessKeyID)
+}
+
+func TestWatchWebIdentityTokenShared(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token-1"), 0o600))
+
+	stub := &webIdentitySTSStub{}
+	newCredentials := func() *aws.CredentialsCache {
+		return aws.NewCredentialsCache(stscreds.NewWebIdentityRoleProvider(stub, "arn:aws:iam::123456789012:role/external-dns", stscreds.IdentityTokenFile(tokenFile)))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	creds := newCredentials()
+	require.NoError(t, watchWebIdentityToken(ctx, tokenFile, creds))
+	// the credentials of a rebuilt provider share the watcher, those which are no longer used are dropped
+	func() {
+		require.NoError(t, watchWebIdentityToken(ctx, tokenFile, newCredentials()))
+	}()
+	tokenWatchers.Lock()
+	watcher := tokenWatchers.byFile[tokenFile]
+	tokenWatchers.Unlock()
+	require.NotNil(t, watcher)
+	watcher.mu.Lock()
+	require.Len(t, watcher.chains, 2)
+	watcher.mu.Unlock()
+	runtime.GC()
+
+	_, err := creds.Retrieve(ctx)
+	require.NoError(t, err)
+	replaceFile(t, tokenFile, "token-2")
+
+	assert.Eventually(t, func() bool {
+		tokens := stub.assumedTokens()
+		return len(tokens) > 1 && tokens[len(tokens)-1] == "token-2"
+	}, 5*time.Second, 10*time.Millisecond, "the credentials must be refreshed when the token is replaced")
+	watcher.mu.Lock()
+	assert.Len(t, watcher.chains, 1)
+	watcher.mu.Unlock()
+	assert.Equal(t, []string{"token-1", "token-2"}, stub.assumedTokens())
+}
+
+func TestWatchWebIdentityTokenWithoutCache(t *testing.T) {
+	require.NoError(t, watchWebIdentityToken(context.Background(), filepath.Join(t.TempDir(), "missing", "token"), aws.AnonymousCredentials{}),
+		"credentials which aren't cached must not be watched")
+}
+
+func TestWatchWebIdentityTokenMissingDirectory(t *testing.T) {
+	err := watchWebIdentityToken(context.Background(), filepath.Join(t.TempDir(), "missing", "token"), aws.NewCredentialsCache(aws.AnonymousCredentials{}))
+	assert.ErrorContains(t, err, "failed to watch the web identity token file")
+}
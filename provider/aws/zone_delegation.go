@@ -0,0 +1,216 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// delegationTTL is the TTL of the NS records delegating a zone, the one Route53 sets on the NS records of new zones.
+const delegationTTL = 172800
+
+// EnsureZones creates the missing zones of the create-zones mode and delegates them from their parent zone when
+// autoDelegate is set. A zone is created with the client of the profile managing its domain, and delegated with
+// the client of the profile of its parent zone.
+func (p *AWSProvider) EnsureZones(ctx context.Context) error {
+	if len(p.createZones) == 0 {
+		return nil
+	}
+
+	existing, err := p.listAllZones(ctx)
+	if err != nil {
+		return err
+	}
+	for _, name := range p.createZones {
+		name = provider.EnsureTrailingDot(strings.ToLower(name))
+		zone, ok := existing[name]
+		if !ok {
+			profile, ok := p.zoneProfile(name)
+			if !ok {
+				return fmt.Errorf("no aws profile to create hosted zone %s with", name)
+			}
+			if p.dryRun {
+				log.Infof("Would create hosted zone %s using aws profile %q", name, profile)
+				continue
+			}
+			created, err := createZone(ctx, p.clients[profile], name)
+			if err != nil {
+				return err
+			}
+			zone = &profiledZone{profile: profile, zone: created}
+			existing[name] = zone
+			p.zonesCache.zones = nil
+			log.Infof("Created hosted zone %s (%s) using aws profile %q", name, *created.Id, profile)
+		}
+		if p.autoDelegate {
+			if err := p.delegateZone(ctx, name, zone, existing); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// zoneProfile returns the profile to create the zone name with: the profile configured for its domain, or else
+// the first of the profiles managing every zone.
+func (p *AWSProvider) zoneProfile(name string) (string, bool) {
+	profiles := make([]string, 0, len(p.clients))
+	for profile := range p.clients {
+		profiles = append(profiles, profile)
+	}
+	sort.Strings(profiles)
+
+	fallback, found := "", false
+	for _, profile := range profiles {
+		if !p.domainZoneFilter.Match(profile, name) {
+			continue
+		}
+		if _, ok := p.domainZoneFilter[profile]; ok {
+			return profile, true
+		}
+		if !found {
+			fallback, found = profile, true
+		}
+	}
+	return fallback, found
+}
+
+// listAllZones returns the hosted zones of all the profiles by name, regardless of the zone filters but with the
+// profile managing their domain. The public zone is kept of a public and a private zone of the same name.
+func (p *AWSProvider) listAllZones(ctx context.Context) (map[string]*profiledZone, error) {
+	zones := make(map[string]*profiledZone)
+	for profile, client := range p.clients {
+		paginator := route53.NewListHostedZonesPaginator(client, &route53.ListHostedZonesInput{})
+		for paginator.HasMorePages() {
+			resp, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list hosted zones using aws profile %q: %w", profile, err)
+			}
+			for _, zone := range resp.HostedZones {
+				name := provider.EnsureTrailingDot(strings.ToLower(*zone.Name))
+				if !p.domainZoneFilter.Match(profile, name) {
+					continue
+				}
+				if other, ok := zones[name]; ok && (isPrivateZone(&zone) || !isPrivateZone(other.zone)) {
+					continue
+				}
+				zones[name] = &profiledZone{profile: profile, zone: &zone}
+			}
+		}
+	}
+	return zones, nil
+}
+
+func isPrivateZone(zone *route53types.HostedZone) bool {
+	return zone.Config != nil && zone.Config.PrivateZone
+}
+
+func createZone(ctx context.Context, client Route53API, name string) (*route53types.HostedZone, error) {
+	resp, err := client.CreateHostedZone(ctx, &route53.CreateHostedZoneInput{
+		Name:            aws.String(name),
+		CallerReference: aws.String(fmt.Sprintf("external-dns-%s-%d", strings.TrimSuffix(name, "."), time.Now().UnixNano())),
+		HostedZoneConfig: &route53types.HostedZoneConfig{
+			Comment: aws.String("Created by ExternalDNS"),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create hosted zone %s: %w", name, err)
+	}
+	return resp.HostedZone, nil
+}
+
+// parentZone returns the zone to delegate the zone name from, which is the zone given by parentZoneID or the most
+// specific of the public zones whose name is a parent of the zone name.
+func (p *AWSProvider) parentZone(name string, zones map[string]*profiledZone) (*profiledZone, error) {
+	if p.parentZoneID != "" {
+		for _, z := range zones {
+			if cleanZoneID(*z.zone.Id) == cleanZoneID(p.parentZoneID) {
+				return z, nil
+			}
+		}
+		return nil, fmt.Errorf("parent hosted zone %s of hosted zone %s not found", p.parentZoneID, name)
+	}
+
+	parentName := ""
+	for zoneName, z := range zones {
+		// the private zones can't delegate public zones
+		if strings.HasSuffix(name, "."+zoneName) && !isPrivateZone(z.zone) && len(zoneName) > len(parentName) {
+			parentName = zoneName
+		}
+	}
+	if parentName == "" {
+		return nil, fmt.Errorf("no parent zone to delegate hosted zone %s from", name)
+	}
+	return zones[parentName], nil
+}
+
+// delegateZone upserts the NS records of the zone in its parent zone.
+func (p *AWSProvider) delegateZone(ctx context.Context, name string, zone *profiledZone, zones map[string]*profiledZone) error {
+	parent, err := p.parentZone(name, zones)
+	if err != nil {
+		return err
+	}
+	parentID := *parent.zone.Id
+
+	resp, err := p.clients[zone.profile].GetHostedZone(ctx, &route53.GetHostedZoneInput{Id: zone.zone.Id})
+	if err != nil {
+		return fmt.Errorf("failed to get the name servers of hosted zone %s: %w", name, err)
+	}
+	if resp.DelegationSet == nil || len(resp.DelegationSet.NameServers) == 0 {
+		return fmt.Errorf("hosted zone %s has no name servers to delegate it to", name)
+	}
+	records := make([]route53types.ResourceRecord, 0, len(resp.DelegationSet.NameServers))
+	for _, nameServer := range resp.DelegationSet.NameServers {
+		records = append(records, route53types.ResourceRecord{Value: aws.String(nameServer)})
+	}
+
+	if p.dryRun {
+		log.Infof("Would delegate hosted zone %s from hosted zone %s", name, parentID)
+		return nil
+	}
+	_, err = p.clients[parent.profile].ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(parentID),
+		ChangeBatch: &route53types.ChangeBatch{
+			Comment: aws.String(fmt.Sprintf("Delegation of %s by ExternalDNS", name)),
+			Changes: []route53types.Change{{
+				Action: route53types.ChangeActionUpsert,
+				ResourceRecordSet: &route53types.ResourceRecordSet{
+					Name:            aws.String(name),
+					Type:            route53types.RRTypeNs,
+					TTL:             aws.Int64(delegationTTL),
+					ResourceRecords: records,
+				},
+			}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delegate hosted zone %s from hosted zone %s using aws profile %q: %w", name, parentID, parent.profile, err)
+	}
+	log.Infof("Delegated hosted zone %s from hosted zone %s", name, parentID)
+	return nil
+}
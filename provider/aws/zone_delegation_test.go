@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+func newAWSProviderWithCreateZones(t *testing.T, dryRun bool, zones ...string) (*AWSProvider, *Route53APIStub) {
+	p, client := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, dryRun, nil)
+	p.createZones = zones
+	return p, client
+}
+
+// delegationRecords returns the NS records of the zone name in the zone.
+func delegationRecords(t *testing.T, client Route53API, zoneID, name string) []route53types.ResourceRecordSet {
+	var records []route53types.ResourceRecordSet
+	for _, rrs := range listAWSRecords(t, client, zoneID) {
+		if rrs.Type == route53types.RRTypeNs && *rrs.Name == name {
+			records = append(records, rrs)
+		}
+	}
+	return records
+}
+
+func TestAWSCreateZones(t *testing.T) {
+	p, client := newAWSProviderWithCreateZones(t, false, "New.Zone-1.ext-dns-test-2.teapot.zalan.do", "zone-2.ext-dns-test-2.teapot.zalan.do.")
+
+	err := p.EnsureZones(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, client.zones, "/hostedzone/new.zone-1.ext-dns-test-2.teapot.zalan.do.")
+	assert.Len(t, client.zones, 5, "the existing zone should not be created again")
+	assert.Empty(t, delegationRecords(t, client, "/hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do.", "new.zone-1.ext-dns-test-2.teapot.zalan.do."),
+		"the zone should not be delegated without auto delegation")
+
+	// the zones are only created when missing
+	err = p.EnsureZones(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, client.zones, 5)
+}
+
+func TestAWSCreateZonesDryRun(t *testing.T) {
+	p, client := newAWSProviderWithCreateZones(t, true, "new.zone-1.ext-dns-test-2.teapot.zalan.do.")
+	p.autoDelegate = true
+
+	err := p.EnsureZones(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, client.zones, 4)
+}
+
+func TestAWSCreateZonesAutoDelegate(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		zone           string
+		parentZoneID   string
+		expectedParent string
+		expectedError  string
+	}{
+		{
+			name:           "most specific public parent zone",
+			zone:           "new.zone-1.ext-dns-test-2.teapot.zalan.do.",
+			expectedParent: "/hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do.",
+		},
+		{
+			name:           "given parent zone",
+			zone:           "new.zone-1.ext-dns-test-2.teapot.zalan.do.",
+			parentZoneID:   "/hostedzone/zone-2.ext-dns-test-2.teapot.zalan.do.",
+			expectedParent: "/hostedzone/zone-2.ext-dns-test-2.teapot.zalan.do.",
+		},
+		{
+			name:          "unknown parent zone",
+			zone:          "new.zone-1.ext-dns-test-2.teapot.zalan.do.",
+			parentZoneID:  "/hostedzone/unknown.",
+			expectedError: "parent hosted zone /hostedzone/unknown. of hosted zone new.zone-1.ext-dns-test-2.teapot.zalan.do. not found",
+		},
+		{
+			name:          "private parent zone",
+			zone:          "new.zone-3.ext-dns-test-2.teapot.zalan.do.",
+			expectedError: "no parent zone to delegate hosted zone new.zone-3.ext-dns-test-2.teapot.zalan.do. from",
+		},
+		{
+			name:          "no parent zone",
+			zone:          "example.org.",
+			expectedError: "no parent zone to delegate hosted zone example.org. from",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p, client := newAWSProviderWithCreateZones(t, false, tt.zone)
+			p.autoDelegate = true
+			p.parentZoneID = tt.parentZoneID
+
+			err := p.EnsureZones(context.Background())
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			records := delegationRecords(t, client, tt.expectedParent, tt.zone)
+			require.Len(t, records, 1)
+			assert.Equal(t, int64(delegationTTL), aws.ToInt64(records[0].TTL))
+			assert.Equal(t, []route53types.ResourceRecord{
+				{Value: aws.String("ns-1.awsdns." + tt.zone)},
+				{Value: aws.String("ns-2.awsdns." + tt.zone)},
+			}, records[0].ResourceRecords)
+		})
+	}
+}
+
+func TestAWSCreateZonesAutoDelegateExistingZone(t *testing.T) {
+	p, client := newAWSProviderWithCreateZones(t, false, "new.zone-1.ext-dns-test-2.teapot.zalan.do.")
+	err := p.EnsureZones(context.Background())
+	require.NoError(t, err)
+
+	// the zones created before the auto delegation was enabled are delegated as well
+	p.autoDelegate = true
+	err = p.EnsureZones(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, delegationRecords(t, client, "/hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do.", "new.zone-1.ext-dns-test-2.teapot.zalan.do."), 1)
+}
+
+func TestAWSCreateZonesWithDomainProfiles(t *testing.T) {
+	comClient := NewRoute53APIStub(t)
+	orgClient := NewRoute53APIStub(t)
+	p := &AWSProvider{
+		clients: map[string]Route53API{
+			"example.com": comClient,
+			"example.org": orgClient,
+		},
+		domainZoneFilter: NewDomainZoneFilter(map[string]string{
+			"example.com": "arn:aws:iam::123456789012:role/example-com",
+			"example.org": "arn:aws:iam::123456789012:role/example-org",
+		}),
+		zonesCache:   &zonesListCache{duration: 1 * time.Minute},
+		createZones:  []string{"team.example.com", "team.example.org"},
+		autoDelegate: true,
+	}
+	for _, client := range []*Route53APIStub{comClient, orgClient} {
+		for _, name := range []string{"example.com.", "example.org."} {
+			_, err := client.CreateHostedZone(context.Background(), &route53.CreateHostedZoneInput{
+				CallerReference:  aws.String("external-dns.alpha.kubernetes.io/test-zone"),
+				Name:             aws.String(name),
+				HostedZoneConfig: &route53types.HostedZoneConfig{},
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	require.NoError(t, p.EnsureZones(context.Background()))
+
+	// each zone is created and delegated with the profile of its domain
+	assert.Contains(t, comClient.zones, "/hostedzone/team.example.com.")
+	assert.NotContains(t, comClient.zones, "/hostedzone/team.example.org.")
+	assert.Contains(t, orgClient.zones, "/hostedzone/team.example.org.")
+	assert.NotContains(t, orgClient.zones, "/hostedzone/team.example.com.")
+	assert.Len(t, delegationRecords(t, comClient, "/hostedzone/example.com.", "team.example.com."), 1)
+	assert.Len(t, delegationRecords(t, orgClient, "/hostedzone/example.org.", "team.example.org."), 1)
+	assert.Empty(t, delegationRecords(t, comClient, "/hostedzone/example.org.", "team.example.org."))
+}
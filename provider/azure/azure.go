@@ -64,12 +64,18 @@ type AzureProvider struct {
 	zonesCache                   *zonesCache[dns.Zone]
 	recordSetsClient             RecordSetsClient
 	maxRetriesCount              int
+	// the Traffic Manager profile whose endpoints' geo mappings are managed, when set
+	trafficManagerProfile string
+	trafficManagerClient  TrafficManagerClient
+	// the resources alias record sets can reference, looked up by the host names targeted by CNAME endpoints
+	aliasTargetResourcesClient AliasTargetResourcesClient
+	aliasTargetResourcesCache  *zonesCache[AliasTargetResource]
 }
 
 // NewAzureProvider creates a new Azure provider.
 //
 // Returns the provider or an error if a provider could not be created.
-func NewAzureProvider(configFile string, domainFilter endpoint.DomainFilter, zoneNameFilter endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, subscriptionID string, resourceGroup string, userAssignedIdentityClientID string, activeDirectoryAuthorityHost string, zonesCacheDuration time.Duration, maxRetriesCount int, dryRun bool) (*AzureProvider, error) {
+func NewAzureProvider(configFile string, domainFilter endpoint.DomainFilter, zoneNameFilter endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, subscriptionID string, resourceGroup string, userAssignedIdentityClientID string, activeDirectoryAuthorityHost string, zonesCacheDuration time.Duration, maxRetriesCount int, trafficManagerProfile string, dryRun bool) (*AzureProvider, error) {
 	cfg, err := getConfig(configFile, subscriptionID, resourceGroup, userAssignedIdentityClientID, activeDirectoryAuthorityHost)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read Azure config file '%s': %w", configFile, err)
@@ -88,6 +94,14 @@ func NewAzureProvider(configFile string, domainFilter endpoint.DomainFilter, zon
 	if err != nil {
 		return nil, err
 	}
+	trafficManagerClient, err := newTrafficManagerClient(cfg.SubscriptionID, cred, clientOpts)
+	if err != nil {
+		return nil, err
+	}
+	aliasTargetResourcesClient, err := newAliasTargetResourcesClient(cfg.SubscriptionID, cred, clientOpts)
+	if err != nil {
+		return nil, err
+	}
 	return &AzureProvider{
 		domainFilter:                 domainFilter,
 		zoneNameFilter:               zoneNameFilter,
@@ -100,6 +114,10 @@ func NewAzureProvider(configFile string, domainFilter endpoint.DomainFilter, zon
 		zonesCache:                   &zonesCache[dns.Zone]{duration: zonesCacheDuration},
 		recordSetsClient:             recordSetsClient,
 		maxRetriesCount:              maxRetriesCount,
+		trafficManagerProfile:        trafficManagerProfile,
+		trafficManagerClient:         trafficManagerClient,
+		aliasTargetResourcesClient:   aliasTargetResourcesClient,
+		aliasTargetResourcesCache:    &zonesCache[AliasTargetResource]{duration: zonesCacheDuration},
 	}, nil
 }
 
@@ -153,6 +171,11 @@ func (p *AzureProvider) Records(ctx context.Context) (endpoints []*endpoint.Endp
 			}
 		}
 	}
+	if p.trafficManagerProfile != "" {
+		if endpoints, err = p.withGeoLocations(ctx, endpoints); err != nil {
+			return nil, provider.NewSoftError(err)
+		}
+	}
 	return endpoints, nil
 }
 
@@ -168,9 +191,25 @@ func (p *AzureProvider) ApplyChanges(ctx context.Context, changes *plan.Changes)
 	deleted, updated := p.mapChanges(zones, changes)
 	p.deleteRecords(ctx, deleted)
 	p.updateRecords(ctx, updated)
+	if p.trafficManagerProfile != "" {
+		p.updateGeoMappings(ctx, changes)
+	}
 	return nil
 }
 
+// AdjustEndpoints turns the endpoints targeting Azure resources, by their ID or their host name, into alias endpoints,
+// and keeps the geo location of the endpoints only when a Traffic Manager profile is managed.
+func (p *AzureProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	if err := p.resolveAliasTargetHosts(context.Background(), endpoints); err != nil {
+		return nil, provider.NewSoftError(err)
+	}
+	for _, ep := range endpoints {
+		adjustAlias(ep)
+		p.adjustGeoLocation(ep)
+	}
+	return endpoints, nil
+}
+
 func (p *AzureProvider) zones(ctx context.Context) ([]dns.Zone, error) {
 	log.Debugf("Retrieving Azure DNS zones for resource group: %s.", p.resourceGroup)
 	if !p.zonesCache.Expired() {
@@ -344,6 +383,17 @@ func (p *AzureProvider) newRecordSet(endpoint *endpoint.Endpoint) (dns.RecordSet
 	if endpoint.RecordTTL.IsConfigured() {
 		ttl = int64(endpoint.RecordTTL)
 	}
+	if resourceID, ok := aliasTargetResource(endpoint); ok {
+		switch dns.RecordType(endpoint.RecordType) {
+		case dns.RecordTypeA, dns.RecordTypeAAAA:
+			return dns.RecordSet{
+				Properties: &dns.RecordSetProperties{
+					TTL:            to.Ptr(ttl),
+					TargetResource: &dns.SubResource{ID: to.Ptr(resourceID)},
+				},
+			}, nil
+		}
+	}
 	switch dns.RecordType(endpoint.RecordType) {
 	case dns.RecordTypeA:
 		aRecords := make([]*dns.ARecord, len(endpoint.Targets))
@@ -440,6 +490,12 @@ func extractAzureTargets(recordSet *dns.RecordSet) []string {
 		return []string{}
 	}
 
+	// Check for alias record sets
+	targetResource := properties.TargetResource
+	if targetResource != nil && targetResource.ID != nil {
+		return []string{*targetResource.ID}
+	}
+
 	// Check for A records
 	aRecords := properties.ARecords
 	if len(aRecords) > 0 && (aRecords)[0].IPv4Address != nil {
@@ -0,0 +1,244 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+const (
+	publicIPAddressesAPIVersion = "2023-09-01"
+	cdnAPIVersion               = "2024-02-01"
+)
+
+// aliasTargetResourceRegex matches the IDs of the Azure resources an alias record set can reference: public IP
+// addresses, Traffic Manager profiles, CDN endpoints and Front Door endpoints.
+var aliasTargetResourceRegex = regexp.MustCompile(`(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/(` +
+	`Microsoft\.Network/publicIPAddresses|` +
+	`Microsoft\.Network/trafficManagerProfiles|` +
+	`Microsoft\.Cdn/profiles/[^/]+/endpoints|` +
+	`Microsoft\.Cdn/profiles/[^/]+/afdEndpoints` +
+	`)/[^/]+$`)
+
+// aliasTargetHostRegex matches the host names Azure assigns to the resources an alias record set can reference: the
+// DNS names of public IP addresses, and the host names of Traffic Manager profiles, CDN endpoints and Front Door
+// endpoints.
+var aliasTargetHostRegex = regexp.MustCompile(`(?i)\.(cloudapp\.azure\.com|trafficmanager\.net|azureedge\.net|azurefd\.net)\.?$`)
+
+// AliasTargetResource is an Azure resource an alias record set can reference, with the host name Azure assigned to it.
+type AliasTargetResource struct {
+	ID       string
+	HostName string
+}
+
+// AliasTargetResourcesClient is an interface of the Azure Resource Manager API listing the resources an alias record
+// set can reference, that can be stubbed for testing.
+type AliasTargetResourcesClient interface {
+	ListAliasTargetResources(ctx context.Context) ([]AliasTargetResource, error)
+}
+
+// aliasTargetResourcesClient lists the resources of the subscription through the Azure Resource Manager pipeline.
+type aliasTargetResourcesClient struct {
+	client         *arm.Client
+	subscriptionID string
+}
+
+func newAliasTargetResourcesClient(subscriptionID string, cred azcore.TokenCredential, options *arm.ClientOptions) (*aliasTargetResourcesClient, error) {
+	client, err := arm.NewClient("external-dns/aliastargets", "v1.0.0", cred, options)
+	if err != nil {
+		return nil, err
+	}
+	return &aliasTargetResourcesClient{client: client, subscriptionID: subscriptionID}, nil
+}
+
+// armResource holds the properties of the Azure resources read to find their host name.
+type armResource struct {
+	ID  string `json:"id"`
+	SKU struct {
+		Name string `json:"name"`
+	} `json:"sku"`
+	Properties struct {
+		// DNSSettings is set on public IP addresses.
+		DNSSettings struct {
+			FQDN string `json:"fqdn"`
+		} `json:"dnsSettings"`
+		// DNSConfig is set on Traffic Manager profiles.
+		DNSConfig struct {
+			FQDN string `json:"fqdn"`
+		} `json:"dnsConfig"`
+		// HostName is set on CDN and Front Door endpoints.
+		HostName string `json:"hostName"`
+	} `json:"properties"`
+}
+
+// list returns all the resources of a collection, following the links to its next pages.
+func (c *aliasTargetResourcesClient) list(ctx context.Context, path string, apiVersion string) ([]armResource, error) {
+	var resources []armResource
+	for next := armURL(c.client, path, apiVersion); next != ""; {
+		var page struct {
+			Value    []armResource `json:"value"`
+			NextLink string        `json:"nextLink"`
+		}
+		if err := doARMRequest(ctx, c.client, http.MethodGet, next, nil, &page); err != nil {
+			return nil, err
+		}
+		resources = append(resources, page.Value...)
+		next = page.NextLink
+	}
+	return resources, nil
+}
+
+func (c *aliasTargetResourcesClient) ListAliasTargetResources(ctx context.Context) ([]AliasTargetResource, error) {
+	subscription := "/subscriptions/" + url.PathEscape(c.subscriptionID)
+	var targets []AliasTargetResource
+
+	publicIPAddresses, err := c.list(ctx, subscription+"/providers/Microsoft.Network/publicIPAddresses", publicIPAddressesAPIVersion)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range publicIPAddresses {
+		targets = append(targets, AliasTargetResource{ID: r.ID, HostName: r.Properties.DNSSettings.FQDN})
+	}
+
+	profiles, err := c.list(ctx, subscription+"/providers/Microsoft.Network/trafficmanagerprofiles", trafficManagerAPIVersion)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range profiles {
+		targets = append(targets, AliasTargetResource{ID: r.ID, HostName: r.Properties.DNSConfig.FQDN})
+	}
+
+	cdnProfiles, err := c.list(ctx, subscription+"/providers/Microsoft.Cdn/profiles", cdnAPIVersion)
+	if err != nil {
+		return nil, err
+	}
+	for _, profile := range cdnProfiles {
+		// the Front Door profiles have Front Door endpoints, the other CDN profiles have CDN endpoints
+		endpointsPath := profile.ID + "/endpoints"
+		if strings.HasSuffix(profile.SKU.Name, "_AzureFrontDoor") {
+			endpointsPath = profile.ID + "/afdEndpoints"
+		}
+		endpoints, err := c.list(ctx, endpointsPath, cdnAPIVersion)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range endpoints {
+			targets = append(targets, AliasTargetResource{ID: r.ID, HostName: r.Properties.HostName})
+		}
+	}
+
+	return targets, nil
+}
+
+// isAliasTargetHost reports whether the target is a host name Azure assigns to the resources an alias record set can
+// reference.
+func isAliasTargetHost(target string) bool {
+	return aliasTargetHostRegex.MatchString(target)
+}
+
+// normalizeHost returns the lowercased host name without its trailing dot.
+func normalizeHost(host string) string {
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
+
+// aliasTargetResourceIDs returns the IDs of the resources an alias record set can reference, by their host name. The
+// resources are left out when the identity isn't allowed to list them, the host names then giving CNAME records.
+func (p *AzureProvider) aliasTargetResourceIDs(ctx context.Context) (map[string]string, error) {
+	resources := p.aliasTargetResourcesCache.Get()
+	if p.aliasTargetResourcesCache.Expired() {
+		var err error
+		resources, err = p.aliasTargetResourcesClient.ListAliasTargetResources(ctx)
+		var respErr *azcore.ResponseError
+		if errors.As(err, &respErr) && respErr.StatusCode == http.StatusForbidden {
+			log.Warnf("Not allowed to list the Azure resources alias record sets can reference, keeping the CNAME records targeting their host names: %v", err)
+			return map[string]string{}, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the Azure resources alias record sets can reference: %w", err)
+		}
+		p.aliasTargetResourcesCache.Reset(resources)
+	}
+
+	ids := map[string]string{}
+	for _, r := range resources {
+		if r.HostName != "" {
+			ids[normalizeHost(r.HostName)] = r.ID
+		}
+	}
+	return ids, nil
+}
+
+// resolveAliasTargetHosts replaces the host name targeted by the CNAME endpoints with the ID of the Azure resource it
+// was assigned to, for the endpoints to be written as alias record sets. The resources are listed only when an
+// endpoint targets such a host name.
+func (p *AzureProvider) resolveAliasTargetHosts(ctx context.Context, endpoints []*endpoint.Endpoint) error {
+	var ids map[string]string
+	for _, ep := range endpoints {
+		if ep.RecordType != endpoint.RecordTypeCNAME || len(ep.Targets) != 1 || !isAliasTargetHost(ep.Targets[0]) {
+			continue
+		}
+		if ids == nil {
+			var err error
+			if ids, err = p.aliasTargetResourceIDs(ctx); err != nil {
+				return err
+			}
+		}
+		if id, ok := ids[normalizeHost(ep.Targets[0])]; ok {
+			log.Debugf("Resolved the host name '%s' targeted by '%s' to the Azure resource '%s'.", ep.Targets[0], ep.DNSName, id)
+			ep.Targets = endpoint.Targets{id}
+		}
+	}
+	return nil
+}
+
+// isAliasTargetResource reports whether the target is the ID of an Azure resource an alias record set can reference.
+func isAliasTargetResource(target string) bool {
+	return aliasTargetResourceRegex.MatchString(target)
+}
+
+// aliasTargetResource returns the ID of the Azure resource the endpoint is an alias of, if any. An alias record set
+// references a single resource.
+func aliasTargetResource(ep *endpoint.Endpoint) (string, bool) {
+	if len(ep.Targets) != 1 || !isAliasTargetResource(ep.Targets[0]) {
+		return "", false
+	}
+	return ep.Targets[0], true
+}
+
+// adjustAlias turns a CNAME endpoint targeting an Azure resource into an A endpoint, which is written as an alias
+// record set. Unlike CNAME records, the A alias record sets are also allowed at the zone apex.
+func adjustAlias(ep *endpoint.Endpoint) {
+	if ep.RecordType != endpoint.RecordTypeCNAME {
+		return
+	}
+	if resourceID, ok := aliasTargetResource(ep); ok {
+		log.Debugf("Using an alias record set for '%s' targeting the Azure resource '%s'.", ep.DNSName, resourceID)
+		ep.RecordType = endpoint.RecordTypeA
+	}
+}
@@ -0,0 +1,312 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	dns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/dns/armdns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	testPublicIPAddressID      = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group/providers/Microsoft.Network/publicIPAddresses/ingress"
+	testTrafficManagerID       = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group/providers/Microsoft.Network/trafficManagerProfiles/global"
+	testCDNEndpointID          = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group/providers/Microsoft.Cdn/profiles/cdn/endpoints/static"
+	testFrontDoorEndpointID    = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group/providers/Microsoft.Cdn/profiles/afd/afdEndpoints/web"
+	testUnsupportedResourceID  = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group/providers/Microsoft.Network/loadBalancers/internal"
+	testLowercasePublicIPAddID = "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/group/providers/microsoft.network/publicipaddresses/ingress"
+)
+
+type mockAliasTargetResourcesClient struct {
+	resources []AliasTargetResource
+	err       error
+	calls     int
+}
+
+func (m *mockAliasTargetResourcesClient) ListAliasTargetResources(ctx context.Context) ([]AliasTargetResource, error) {
+	m.calls++
+	return m.resources, m.err
+}
+
+func testAliasTargetResources() []AliasTargetResource {
+	return []AliasTargetResource{
+		{ID: testPublicIPAddressID, HostName: "ingress.westeurope.cloudapp.azure.com"},
+		{ID: testTrafficManagerID, HostName: "global.trafficmanager.net"},
+		{ID: testCDNEndpointID, HostName: "static.azureedge.net"},
+		{ID: testFrontDoorEndpointID, HostName: "web-abcdef.z01.azurefd.net"},
+		// a public IP address without a DNS name
+		{ID: testPublicIPAddressID + "-2"},
+	}
+}
+
+func TestIsAliasTargetResource(t *testing.T) {
+	for _, target := range []string{testPublicIPAddressID, testTrafficManagerID, testCDNEndpointID, testFrontDoorEndpointID, testLowercasePublicIPAddID} {
+		assert.True(t, isAliasTargetResource(target), target)
+	}
+	for _, target := range []string{
+		testUnsupportedResourceID,
+		testPublicIPAddressID + "/extra",
+		"ingress.westeurope.cloudapp.azure.com",
+		"web.azurefd.net",
+		"1.2.3.4",
+	} {
+		assert.False(t, isAliasTargetResource(target), target)
+	}
+}
+
+func TestAzureAdjustEndpointsAlias(t *testing.T) {
+	p, _ := newMockedAzureProvider(endpoint.NewDomainFilter([]string{"example.com"}), endpoint.NewDomainFilter([]string{""}), provider.NewZoneIDFilter([]string{""}), false, "group", "", "", nil, nil, 3)
+
+	endpoints, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("example.com", endpoint.RecordTypeCNAME, testPublicIPAddressID),
+		endpoint.NewEndpoint("global.example.com", endpoint.RecordTypeCNAME, testTrafficManagerID),
+		endpoint.NewEndpoint("static.example.com", endpoint.RecordTypeCNAME, testCDNEndpointID),
+		endpoint.NewEndpoint("web.example.com", endpoint.RecordTypeCNAME, testFrontDoorEndpointID),
+		endpoint.NewEndpoint("lb.example.com", endpoint.RecordTypeCNAME, testUnsupportedResourceID),
+		endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, "example.org"),
+	})
+	require.NoError(t, err)
+
+	recordTypes := map[string]string{}
+	for _, ep := range endpoints {
+		recordTypes[ep.DNSName] = ep.RecordType
+	}
+	assert.Equal(t, map[string]string{
+		"example.com":        endpoint.RecordTypeA,
+		"global.example.com": endpoint.RecordTypeA,
+		"static.example.com": endpoint.RecordTypeA,
+		"web.example.com":    endpoint.RecordTypeA,
+		"lb.example.com":     endpoint.RecordTypeCNAME,
+		"www.example.com":    endpoint.RecordTypeCNAME,
+	}, recordTypes)
+}
+
+func TestAzureNewRecordSetAlias(t *testing.T) {
+	p, _ := newMockedAzureProvider(endpoint.NewDomainFilter([]string{"example.com"}), endpoint.NewDomainFilter([]string{""}), provider.NewZoneIDFilter([]string{""}), false, "group", "", "", nil, nil, 3)
+
+	for _, tc := range []struct {
+		name       string
+		recordType string
+		resourceID string
+	}{
+		{name: "public IP address", recordType: endpoint.RecordTypeA, resourceID: testPublicIPAddressID},
+		{name: "public IPv6 address", recordType: endpoint.RecordTypeAAAA, resourceID: testPublicIPAddressID},
+		{name: "Traffic Manager profile", recordType: endpoint.RecordTypeA, resourceID: testTrafficManagerID},
+		{name: "CDN endpoint", recordType: endpoint.RecordTypeA, resourceID: testCDNEndpointID},
+		{name: "Front Door endpoint", recordType: endpoint.RecordTypeA, resourceID: testFrontDoorEndpointID},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			recordSet, err := p.newRecordSet(endpoint.NewEndpointWithTTL("example.com", tc.recordType, 60, tc.resourceID))
+			require.NoError(t, err)
+			require.NotNil(t, recordSet.Properties.TargetResource)
+			assert.Equal(t, tc.resourceID, *recordSet.Properties.TargetResource.ID)
+			assert.Equal(t, int64(60), *recordSet.Properties.TTL)
+			assert.Empty(t, recordSet.Properties.ARecords)
+			assert.Empty(t, recordSet.Properties.AaaaRecords)
+		})
+	}
+}
+
+func TestAzureApplyChangesAlias(t *testing.T) {
+	zonesClient := newMockZonesClient([]*dns.Zone{createMockZone("example.com", "/dnszones/example.com")})
+	recordsClient := mockRecordSetsClient{}
+	p := newAzureProvider(endpoint.NewDomainFilter([]string{""}), endpoint.NewDomainFilter([]string{""}), provider.NewZoneIDFilter([]string{""}), false, "group", "", "", &zonesClient, &recordsClient, 3)
+
+	endpoints, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("example.com", endpoint.RecordTypeCNAME, testFrontDoorEndpointID),
+	})
+	require.NoError(t, err)
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{Create: endpoints}))
+
+	validateAzureEndpoints(t, recordsClient.updatedEndpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, defaultTTL, testFrontDoorEndpointID),
+	})
+}
+
+func TestAzureRecordAlias(t *testing.T) {
+	p, _ := newMockedAzureProvider(endpoint.NewDomainFilter([]string{"example.com"}), endpoint.NewDomainFilter([]string{""}), provider.NewZoneIDFilter([]string{""}), false, "group", "", "",
+		[]*dns.Zone{createMockZone("example.com", "/dnszones/example.com")},
+		[]*dns.RecordSet{
+			{
+				Name: to.Ptr("@"),
+				Type: to.Ptr("Microsoft.Network/dnszones/A"),
+				Properties: &dns.RecordSetProperties{
+					TTL:            to.Ptr(int64(60)),
+					TargetResource: &dns.SubResource{ID: to.Ptr(testPublicIPAddressID)},
+				},
+			},
+			{
+				Name: to.Ptr("global"),
+				Type: to.Ptr("Microsoft.Network/dnszones/AAAA"),
+				Properties: &dns.RecordSetProperties{
+					TTL:            to.Ptr(int64(60)),
+					TargetResource: &dns.SubResource{ID: to.Ptr(testTrafficManagerID)},
+				},
+			},
+		}, 3)
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	validateAzureEndpoints(t, endpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 60, testPublicIPAddressID),
+		endpoint.NewEndpointWithTTL("global.example.com", endpoint.RecordTypeAAAA, 60, testTrafficManagerID),
+	})
+}
+
+func TestIsAliasTargetHost(t *testing.T) {
+	for _, target := range []string{"ingress.westeurope.cloudapp.azure.com", "global.trafficmanager.net", "static.azureedge.net", "web-abcdef.z01.azurefd.net", "Web-Abcdef.Z01.AzureFD.net."} {
+		assert.True(t, isAliasTargetHost(target), target)
+	}
+	for _, target := range []string{"example.com", "azurefd.net", "web.azurefd.net.example.com", testFrontDoorEndpointID} {
+		assert.False(t, isAliasTargetHost(target), target)
+	}
+}
+
+func TestAzureAdjustEndpointsAliasHost(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		target     string
+		recordType string
+		resolved   string
+	}{
+		{name: "public IP address", target: "ingress.westeurope.cloudapp.azure.com", recordType: endpoint.RecordTypeA, resolved: testPublicIPAddressID},
+		{name: "Traffic Manager profile", target: "global.trafficmanager.net.", recordType: endpoint.RecordTypeA, resolved: testTrafficManagerID},
+		{name: "CDN endpoint", target: "Static.AzureEdge.net", recordType: endpoint.RecordTypeA, resolved: testCDNEndpointID},
+		{name: "Front Door endpoint", target: "web-abcdef.z01.azurefd.net", recordType: endpoint.RecordTypeA, resolved: testFrontDoorEndpointID},
+		{name: "unknown resource", target: "other.azurefd.net", recordType: endpoint.RecordTypeCNAME, resolved: "other.azurefd.net"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, _ := newMockedAzureProvider(endpoint.NewDomainFilter([]string{"example.com"}), endpoint.NewDomainFilter([]string{""}), provider.NewZoneIDFilter([]string{""}), false, "group", "", "", nil, nil, 3)
+			p.aliasTargetResourcesClient = &mockAliasTargetResourcesClient{resources: testAliasTargetResources()}
+
+			endpoints, err := p.AdjustEndpoints([]*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, tc.target)})
+			require.NoError(t, err)
+			assert.Equal(t, tc.recordType, endpoints[0].RecordType)
+			assert.Equal(t, endpoint.Targets{tc.resolved}, endpoints[0].Targets)
+		})
+	}
+}
+
+func TestAzureAdjustEndpointsAliasHostListing(t *testing.T) {
+	p, _ := newMockedAzureProvider(endpoint.NewDomainFilter([]string{"example.com"}), endpoint.NewDomainFilter([]string{""}), provider.NewZoneIDFilter([]string{""}), false, "group", "", "", nil, nil, 3)
+	client := &mockAliasTargetResourcesClient{resources: testAliasTargetResources()}
+	p.aliasTargetResourcesClient = client
+	p.aliasTargetResourcesCache = &zonesCache[AliasTargetResource]{duration: time.Hour}
+
+	// the resources aren't listed without an endpoint targeting their host names
+	_, err := p.AdjustEndpoints([]*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, "example.org")})
+	require.NoError(t, err)
+	assert.Zero(t, client.calls)
+
+	// the resources are listed once for all the endpoints, and then cached
+	for range 2 {
+		_, err = p.AdjustEndpoints([]*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, "web-abcdef.z01.azurefd.net"),
+			endpoint.NewEndpoint("static.example.com", endpoint.RecordTypeCNAME, "static.azureedge.net"),
+		})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, client.calls)
+}
+
+func TestAzureAdjustEndpointsAliasHostErrors(t *testing.T) {
+	p, _ := newMockedAzureProvider(endpoint.NewDomainFilter([]string{"example.com"}), endpoint.NewDomainFilter([]string{""}), provider.NewZoneIDFilter([]string{""}), false, "group", "", "", nil, nil, 3)
+
+	// the CNAME records are kept when the identity isn't allowed to list the resources
+	p.aliasTargetResourcesClient = &mockAliasTargetResourcesClient{err: &azcore.ResponseError{StatusCode: http.StatusForbidden}}
+	endpoints, err := p.AdjustEndpoints([]*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, "web-abcdef.z01.azurefd.net")})
+	require.NoError(t, err)
+	assert.Equal(t, endpoint.RecordTypeCNAME, endpoints[0].RecordType)
+
+	// other errors fail the synchronization, rather than turning the alias record sets into CNAME records
+	p.aliasTargetResourcesClient = &mockAliasTargetResourcesClient{err: errors.New("timeout")}
+	_, err = p.AdjustEndpoints([]*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, "web-abcdef.z01.azurefd.net")})
+	require.ErrorIs(t, err, provider.SoftError)
+	assert.ErrorContains(t, err, "failed to list the Azure resources alias record sets can reference: timeout")
+}
+
+func TestAliasTargetResourcesClient(t *testing.T) {
+	const subscription = "/subscriptions/sub"
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		var body string
+		switch r.URL.Path {
+		case subscription + "/providers/Microsoft.Network/publicIPAddresses":
+			assert.Equal(t, publicIPAddressesAPIVersion, r.URL.Query().Get("api-version"))
+			if r.URL.Query().Get("page") == "" {
+				body = `{"value":[{"id":"ip-1","properties":{"dnsSettings":{"fqdn":"ingress.westeurope.cloudapp.azure.com"}}}],"nextLink":"https://` + r.Host + r.URL.Path + `?api-version=` + publicIPAddressesAPIVersion + `&page=2"}`
+			} else {
+				body = `{"value":[{"id":"ip-2","properties":{}}]}`
+			}
+		case subscription + "/providers/Microsoft.Network/trafficmanagerprofiles":
+			assert.Equal(t, trafficManagerAPIVersion, r.URL.Query().Get("api-version"))
+			body = `{"value":[{"id":"tm","properties":{"dnsConfig":{"fqdn":"global.trafficmanager.net"}}}]}`
+		case subscription + "/providers/Microsoft.Cdn/profiles":
+			assert.Equal(t, cdnAPIVersion, r.URL.Query().Get("api-version"))
+			body = `{"value":[{"id":"` + subscription + `/cdn","sku":{"name":"Standard_Microsoft"}},{"id":"` + subscription + `/afd","sku":{"name":"Premium_AzureFrontDoor"}}]}`
+		case subscription + "/cdn/endpoints":
+			body = `{"value":[{"id":"cdn-endpoint","properties":{"hostName":"static.azureedge.net"}}]}`
+		case subscription + "/afd/afdEndpoints":
+			body = `{"value":[{"id":"afd-endpoint","properties":{"hostName":"web-abcdef.z01.azurefd.net"}}]}`
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client, err := newAliasTargetResourcesClient("sub", fakeTokenCredential{}, &arm.ClientOptions{
+		ClientOptions: policy.ClientOptions{
+			Cloud: cloud.Configuration{
+				ActiveDirectoryAuthorityHost: server.URL,
+				Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+					cloud.ResourceManager: {Audience: server.URL, Endpoint: server.URL},
+				},
+			},
+			Transport: server.Client(),
+		},
+	})
+	require.NoError(t, err)
+
+	resources, err := client.ListAliasTargetResources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []AliasTargetResource{
+		{ID: "ip-1", HostName: "ingress.westeurope.cloudapp.azure.com"},
+		{ID: "ip-2"},
+		{ID: "tm", HostName: "global.trafficmanager.net"},
+		{ID: "cdn-endpoint", HostName: "static.azureedge.net"},
+		{ID: "afd-endpoint", HostName: "web-abcdef.z01.azurefd.net"},
+	}, resources)
+}
@@ -256,6 +256,8 @@ func newAzureProvider(domainFilter endpoint.DomainFilter, zoneNameFilter endpoin
 		zonesCache:                   &zonesCache[dns.Zone]{duration: 0},
 		recordSetsClient:             recordsClient,
 		maxRetriesCount:              maxRetriesCount,
+		aliasTargetResourcesClient:   &mockAliasTargetResourcesClient{},
+		aliasTargetResourcesCache:    &zonesCache[AliasTargetResource]{duration: 0},
 	}
 }
 
@@ -0,0 +1,223 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+const (
+	// geoLocationProperty is the provider specific property set from the azure-geo-location annotation, a comma
+	// separated list of the location codes routed to the Traffic Manager endpoints targeting the record.
+	geoLocationProperty = "azure/geo-location"
+
+	trafficManagerAPIVersion = "2022-04-01"
+)
+
+// TrafficManagerEndpoint is an endpoint of a Traffic Manager profile, with the properties used by the provider.
+type TrafficManagerEndpoint struct {
+	ID         string                           `json:"id"`
+	Name       string                           `json:"name"`
+	Properties TrafficManagerEndpointProperties `json:"properties"`
+}
+
+// TrafficManagerEndpointProperties holds the properties of a Traffic Manager endpoint used by the provider.
+type TrafficManagerEndpointProperties struct {
+	Target     string   `json:"target,omitempty"`
+	GeoMapping []string `json:"geoMapping"`
+}
+
+// TrafficManagerClient is an interface of the Traffic Manager API that can be stubbed for testing.
+type TrafficManagerClient interface {
+	ListEndpoints(ctx context.Context, resourceGroupName string, profileName string) ([]TrafficManagerEndpoint, error)
+	UpdateGeoMapping(ctx context.Context, endpointID string, geoMapping []string) error
+}
+
+// trafficManagerClient calls the Traffic Manager REST API through the Azure Resource Manager pipeline.
+type trafficManagerClient struct {
+	client         *arm.Client
+	subscriptionID string
+}
+
+func newTrafficManagerClient(subscriptionID string, cred azcore.TokenCredential, options *arm.ClientOptions) (*trafficManagerClient, error) {
+	client, err := arm.NewClient("external-dns/trafficmanager", "v1.0.0", cred, options)
+	if err != nil {
+		return nil, err
+	}
+	return &trafficManagerClient{client: client, subscriptionID: subscriptionID}, nil
+}
+
+func (c *trafficManagerClient) do(ctx context.Context, method string, path string, body any, result any) error {
+	return doARMRequest(ctx, c.client, method, armURL(c.client, path, trafficManagerAPIVersion), body, result)
+}
+
+func (c *trafficManagerClient) ListEndpoints(ctx context.Context, resourceGroupName string, profileName string) ([]TrafficManagerEndpoint, error) {
+	path := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/trafficmanagerprofiles/%s",
+		url.PathEscape(c.subscriptionID), url.PathEscape(resourceGroupName), url.PathEscape(profileName))
+	var profile struct {
+		Properties struct {
+			Endpoints []TrafficManagerEndpoint `json:"endpoints"`
+		} `json:"properties"`
+	}
+	if err := c.do(ctx, http.MethodGet, path, nil, &profile); err != nil {
+		return nil, err
+	}
+	return profile.Properties.Endpoints, nil
+}
+
+func (c *trafficManagerClient) UpdateGeoMapping(ctx context.Context, endpointID string, geoMapping []string) error {
+	body := TrafficManagerEndpoint{Properties: TrafficManagerEndpointProperties{GeoMapping: geoMapping}}
+	return c.do(ctx, http.MethodPatch, endpointID, body, nil)
+}
+
+// normalizeGeoLocation returns the sorted location codes of a geo location property.
+func normalizeGeoLocation(value string) []string {
+	var codes []string
+	for _, code := range strings.Split(value, ",") {
+		if code = strings.TrimSpace(code); code != "" && !slices.Contains(codes, code) {
+			codes = append(codes, code)
+		}
+	}
+	slices.Sort(codes)
+	return codes
+}
+
+// isGeoLocationRecordType reports whether a Traffic Manager endpoint can target records of the type.
+func isGeoLocationRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME:
+		return true
+	}
+	return false
+}
+
+// adjustGeoLocation drops the geo location property when no Traffic Manager profile is managed, or from the records
+// no Traffic Manager endpoint can target, and normalizes its value otherwise.
+func (p *AzureProvider) adjustGeoLocation(ep *endpoint.Endpoint) {
+	value, ok := ep.GetProviderSpecificProperty(geoLocationProperty)
+	if !ok {
+		return
+	}
+	if p.trafficManagerProfile == "" || !isGeoLocationRecordType(ep.RecordType) {
+		ep.DeleteProviderSpecificProperty(geoLocationProperty)
+		return
+	}
+	codes := normalizeGeoLocation(value)
+	if len(codes) == 0 {
+		ep.DeleteProviderSpecificProperty(geoLocationProperty)
+		return
+	}
+	ep.SetProviderSpecificProperty(geoLocationProperty, strings.Join(codes, ","))
+}
+
+// trafficManagerEndpoints returns the endpoints of the Traffic Manager profile, by the DNS name they target.
+func (p *AzureProvider) trafficManagerEndpoints(ctx context.Context) (map[string][]TrafficManagerEndpoint, error) {
+	endpoints, err := p.trafficManagerClient.ListEndpoints(ctx, p.resourceGroup, p.trafficManagerProfile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the endpoints of Traffic Manager profile '%s': %w", p.trafficManagerProfile, err)
+	}
+	byTarget := map[string][]TrafficManagerEndpoint{}
+	for _, tmEndpoint := range endpoints {
+		target := strings.ToLower(strings.TrimSuffix(tmEndpoint.Properties.Target, "."))
+		if target != "" {
+			byTarget[target] = append(byTarget[target], tmEndpoint)
+		}
+	}
+	return byTarget, nil
+}
+
+// withGeoLocations sets the geo location property of the records targeted by the Traffic Manager endpoints, from
+// their geo mapping.
+func (p *AzureProvider) withGeoLocations(ctx context.Context, endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	tmEndpoints, err := p.trafficManagerEndpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, ep := range endpoints {
+		if !isGeoLocationRecordType(ep.RecordType) {
+			continue
+		}
+		for _, tmEndpoint := range tmEndpoints[strings.ToLower(ep.DNSName)] {
+			codes := normalizeGeoLocation(strings.Join(tmEndpoint.Properties.GeoMapping, ","))
+			if len(codes) > 0 {
+				ep.SetProviderSpecificProperty(geoLocationProperty, strings.Join(codes, ","))
+				break
+			}
+		}
+	}
+	return endpoints, nil
+}
+
+// updateGeoMappings sets the geo mapping of the Traffic Manager endpoints targeting the created and updated records
+// to their geo location property.
+func (p *AzureProvider) updateGeoMappings(ctx context.Context, changes *plan.Changes) {
+	var changed []*endpoint.Endpoint
+	for _, ep := range append(changes.Create, changes.UpdateNew...) {
+		if isGeoLocationRecordType(ep.RecordType) && p.domainFilter.Match(ep.DNSName) {
+			changed = append(changed, ep)
+		}
+	}
+	if len(changed) == 0 {
+		return
+	}
+
+	tmEndpoints, err := p.trafficManagerEndpoints(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	for _, ep := range changed {
+		value, _ := ep.GetProviderSpecificProperty(geoLocationProperty)
+		codes := normalizeGeoLocation(value)
+		targeting := tmEndpoints[strings.ToLower(ep.DNSName)]
+		if len(targeting) == 0 {
+			if len(codes) > 0 {
+				log.Warnf("No endpoint of Traffic Manager profile '%s' targets '%s', ignoring its geo location.", p.trafficManagerProfile, ep.DNSName)
+			}
+			continue
+		}
+		if codes == nil {
+			codes = []string{}
+		}
+		for _, tmEndpoint := range targeting {
+			if slices.Equal(normalizeGeoLocation(strings.Join(tmEndpoint.Properties.GeoMapping, ",")), codes) {
+				continue
+			}
+			if p.dryRun {
+				log.Infof("Would update geo mapping of Traffic Manager endpoint '%s' targeting '%s' to '%s'.", tmEndpoint.Name, ep.DNSName, codes)
+				continue
+			}
+			log.Infof("Updating geo mapping of Traffic Manager endpoint '%s' targeting '%s' to '%s'.", tmEndpoint.Name, ep.DNSName, codes)
+			if err := p.trafficManagerClient.UpdateGeoMapping(ctx, tmEndpoint.ID, codes); err != nil {
+				log.Errorf("Failed to update geo mapping of Traffic Manager endpoint '%s' targeting '%s': %v", tmEndpoint.Name, ep.DNSName, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	dns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/dns/armdns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const testTrafficManagerEndpointPrefix = "/subscriptions/sub/resourceGroups/group/providers/Microsoft.Network/trafficManagerProfiles/global/externalEndpoints/"
+
+// mockTrafficManagerClient holds the endpoints of a Traffic Manager profile and records the geo mapping updates.
+type mockTrafficManagerClient struct {
+	endpoints []TrafficManagerEndpoint
+	updates   map[string][]string
+	listErr   error
+}
+
+func newMockTrafficManagerClient(endpoints ...TrafficManagerEndpoint) *mockTrafficManagerClient {
+	return &mockTrafficManagerClient{endpoints: endpoints, updates: map[string][]string{}}
+}
+
+func (m *mockTrafficManagerClient) ListEndpoints(ctx context.Context, resourceGroupName string, profileName string) ([]TrafficManagerEndpoint, error) {
+	return m.endpoints, m.listErr
+}
+
+func (m *mockTrafficManagerClient) UpdateGeoMapping(ctx context.Context, endpointID string, geoMapping []string) error {
+	m.updates[endpointID] = geoMapping
+	return nil
+}
+
+func newTrafficManagerEndpoint(name, target string, geoMapping ...string) TrafficManagerEndpoint {
+	return TrafficManagerEndpoint{
+		ID:         testTrafficManagerEndpointPrefix + name,
+		Name:       name,
+		Properties: TrafficManagerEndpointProperties{Target: target, GeoMapping: geoMapping},
+	}
+}
+
+func newGeoLocationAzureProvider(t *testing.T, dryRun bool, tmClient TrafficManagerClient, recordSets ...*dns.RecordSet) *AzureProvider {
+	p, err := newMockedAzureProvider(endpoint.NewDomainFilter([]string{"example.com"}), endpoint.NewDomainFilter([]string{""}), provider.NewZoneIDFilter([]string{""}), dryRun, "group", "", "",
+		[]*dns.Zone{createMockZone("example.com", "/dnszones/example.com")}, recordSets, 3)
+	require.NoError(t, err)
+	p.trafficManagerProfile = "global"
+	p.trafficManagerClient = tmClient
+	return p
+}
+
+func TestAzureAdjustEndpointsGeoLocation(t *testing.T) {
+	p := newGeoLocationAzureProvider(t, false, newMockTrafficManagerClient())
+
+	endpoints, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("eu.example.com", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific(geoLocationProperty, "GEO-EU, DE,GEO-EU"),
+		endpoint.NewEndpoint("empty.example.com", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific(geoLocationProperty, " , "),
+		endpoint.NewEndpoint("mail.example.com", endpoint.RecordTypeMX, "10 mx.example.com").WithProviderSpecific(geoLocationProperty, "DE"),
+	})
+	require.NoError(t, err)
+	value, ok := endpoints[0].GetProviderSpecificProperty(geoLocationProperty)
+	assert.True(t, ok)
+	assert.Equal(t, "DE,GEO-EU", value)
+	_, ok = endpoints[1].GetProviderSpecificProperty(geoLocationProperty)
+	assert.False(t, ok)
+	_, ok = endpoints[2].GetProviderSpecificProperty(geoLocationProperty)
+	assert.False(t, ok)
+
+	// without a Traffic Manager profile the property is dropped
+	p.trafficManagerProfile = ""
+	endpoints, err = p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("eu.example.com", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific(geoLocationProperty, "GEO-EU"),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, endpoints[0].ProviderSpecific)
+}
+
+func TestAzureRecordsGeoLocation(t *testing.T) {
+	tmClient := newMockTrafficManagerClient(
+		newTrafficManagerEndpoint("eu", "EU.example.com.", "GEO-EU", "DE"),
+		newTrafficManagerEndpoint("us", "us.example.com"),
+	)
+	p := newGeoLocationAzureProvider(t, false, tmClient,
+		createMockRecordSet("eu", endpoint.RecordTypeA, "1.2.3.4"),
+		createMockRecordSet("eu", endpoint.RecordTypeTXT, "heritage=external-dns"),
+		createMockRecordSet("us", endpoint.RecordTypeCNAME, "us.elb.example.org"),
+	)
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	geoLocations := map[string]string{}
+	for _, ep := range endpoints {
+		if value, ok := ep.GetProviderSpecificProperty(geoLocationProperty); ok {
+			geoLocations[ep.DNSName+" "+ep.RecordType] = value
+		}
+	}
+	assert.Equal(t, map[string]string{"eu.example.com A": "DE,GEO-EU"}, geoLocations)
+
+	tmClient.listErr = errors.New("forbidden")
+	_, err = p.Records(context.Background())
+	require.ErrorIs(t, err, provider.SoftError)
+}
+
+func TestAzureApplyChangesGeoLocation(t *testing.T) {
+	for _, dryRun := range []bool{false, true} {
+		tmClient := newMockTrafficManagerClient(
+			newTrafficManagerEndpoint("eu", "eu.example.com", "GEO-EU"),
+			newTrafficManagerEndpoint("eu-backup", "eu.example.com", "GEO-EU"),
+			newTrafficManagerEndpoint("us", "us.example.com", "GEO-NA"),
+			newTrafficManagerEndpoint("asia", "asia.example.com", "GEO-AS"),
+		)
+		p := newGeoLocationAzureProvider(t, dryRun, tmClient)
+
+		require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+			Create: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("eu.example.com", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific(geoLocationProperty, "DE,GEO-EU"),
+				// no Traffic Manager endpoint targets it
+				endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific(geoLocationProperty, "FR"),
+			},
+			UpdateOld: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("us.example.com", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific(geoLocationProperty, "GEO-NA"),
+				endpoint.NewEndpoint("asia.example.com", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific(geoLocationProperty, "GEO-AS"),
+			},
+			UpdateNew: []*endpoint.Endpoint{
+				// unchanged geo location
+				endpoint.NewEndpoint("us.example.com", endpoint.RecordTypeA, "1.2.3.5").WithProviderSpecific(geoLocationProperty, "GEO-NA"),
+				// removed geo location
+				endpoint.NewEndpoint("asia.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+			},
+		}))
+
+		if dryRun {
+			assert.Empty(t, tmClient.updates)
+			continue
+		}
+		assert.Equal(t, map[string][]string{
+			testTrafficManagerEndpointPrefix + "eu":        {"DE", "GEO-EU"},
+			testTrafficManagerEndpointPrefix + "eu-backup": {"DE", "GEO-EU"},
+			testTrafficManagerEndpointPrefix + "asia":      {},
+		}, tmClient.updates)
+	}
+}
+
+type fakeTokenCredential struct{}
+
+func (fakeTokenCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: "token", ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+func TestTrafficManagerClient(t *testing.T) {
+	var patched TrafficManagerEndpoint
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, trafficManagerAPIVersion, r.URL.Query().Get("api-version"))
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "/subscriptions/sub/resourceGroups/group/providers/Microsoft.Network/trafficmanagerprofiles/global", r.URL.Path)
+			_, _ = w.Write([]byte(`{"properties":{"endpoints":[{"id":"` + testTrafficManagerEndpointPrefix + `eu","name":"eu","properties":{"target":"eu.example.com","geoMapping":["GEO-EU"]}}]}}`))
+		case http.MethodPatch:
+			assert.Equal(t, testTrafficManagerEndpointPrefix+"eu", r.URL.Path)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&patched))
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := newTrafficManagerClient("sub", fakeTokenCredential{}, &arm.ClientOptions{
+		ClientOptions: policy.ClientOptions{
+			Cloud: cloud.Configuration{
+				ActiveDirectoryAuthorityHost: server.URL,
+				Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+					cloud.ResourceManager: {Audience: server.URL, Endpoint: server.URL},
+				},
+			},
+			Transport: server.Client(),
+		},
+	})
+	require.NoError(t, err)
+
+	endpoints, err := client.ListEndpoints(context.Background(), "group", "global")
+	require.NoError(t, err)
+	assert.Equal(t, []TrafficManagerEndpoint{newTrafficManagerEndpoint("eu", "eu.example.com", "GEO-EU")}, endpoints)
+
+	require.NoError(t, client.UpdateGeoMapping(context.Background(), endpoints[0].ID, []string{"DE", "GEO-EU"}))
+	assert.Equal(t, []string{"DE", "GEO-EU"}, patched.Properties.GeoMapping)
+	assert.Empty(t, patched.Properties.Target)
+}
@@ -18,10 +18,15 @@ limitations under the License.
 package azure
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	azcoreruntime "github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	dns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/dns/armdns"
 	privatedns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/privatedns/armprivatedns"
@@ -45,3 +50,34 @@ func parseMxTarget[T dns.MxRecord | privatedns.MxRecord](mxTarget string) (T, er
 		Exchange:   to.Ptr(exchange),
 	}, nil
 }
+
+// armURL returns the URL of a path of the Azure Resource Manager API, in the given API version.
+func armURL(client *arm.Client, path string, apiVersion string) string {
+	return azcoreruntime.JoinPaths(client.Endpoint(), path) + "?api-version=" + url.QueryEscape(apiVersion)
+}
+
+// doARMRequest sends a request to the Azure Resource Manager API through the pipeline of the client, which
+// authenticates it and retries it, and decodes the JSON response into the result.
+func doARMRequest(ctx context.Context, client *arm.Client, method string, rawURL string, body any, result any) error {
+	req, err := azcoreruntime.NewRequest(ctx, method, rawURL)
+	if err != nil {
+		return err
+	}
+	req.Raw().Header["Accept"] = []string{"application/json"}
+	if body != nil {
+		if err := azcoreruntime.MarshalAsJSON(req, body); err != nil {
+			return err
+		}
+	}
+	resp, err := client.Pipeline().Do(req)
+	if err != nil {
+		return err
+	}
+	if !azcoreruntime.HasStatusCode(resp, http.StatusOK) {
+		return azcoreruntime.NewResponseError(resp)
+	}
+	if result == nil {
+		return nil
+	}
+	return azcoreruntime.UnmarshalAsJSON(resp, result)
+}
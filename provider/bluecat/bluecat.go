@@ -0,0 +1,430 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bluecat
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// BlueCatProvider is an implementation of Provider for BlueCat Address Manager.
+type BlueCatProvider struct {
+	provider.BaseProvider
+	Client DNSClient
+	// only consider hosted zones managing domains ending in this suffix
+	domainFilter endpoint.DomainFilter
+	// the configuration and view whose zones are managed
+	configuration string
+	view          string
+	// deploy the changed zones to the DNS servers
+	deploy bool
+	DryRun bool
+}
+
+type blueCatChangeCreate struct {
+	Zone   Zone
+	Record Record
+}
+
+type blueCatChangeUpdate struct {
+	Zone   Zone
+	Record Record
+}
+
+type blueCatChangeDelete struct {
+	Zone   Zone
+	Record Record
+}
+
+// blueCatChanges contains all changes to apply to DNS
+type blueCatChanges struct {
+	Creates []blueCatChangeCreate
+	Updates []blueCatChangeUpdate
+	Deletes []blueCatChangeDelete
+}
+
+// NewBlueCatProvider initializes a new BlueCat Address Manager based Provider.
+func NewBlueCatProvider(domainFilter endpoint.DomainFilter, dryRun bool) (*BlueCatProvider, error) {
+	host := os.Getenv("BLUECAT_HOST")
+	if host == "" {
+		return nil, fmt.Errorf("no Address Manager host found, set BLUECAT_HOST")
+	}
+	token := os.Getenv("BLUECAT_API_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("no API token found, set BLUECAT_API_TOKEN")
+	}
+	configuration := os.Getenv("BLUECAT_CONFIGURATION")
+	view := os.Getenv("BLUECAT_VIEW")
+	if configuration == "" || view == "" {
+		return nil, fmt.Errorf("no configuration or view found, set BLUECAT_CONFIGURATION and BLUECAT_VIEW")
+	}
+
+	skipTLSVerify, err := parseBoolEnv("BLUECAT_SKIP_TLS_VERIFY")
+	if err != nil {
+		return nil, err
+	}
+	deploy, err := parseBoolEnv("BLUECAT_DEPLOY")
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: skipTLSVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	return &BlueCatProvider{
+		Client:        NewClient(host, token, &http.Client{Timeout: 30 * time.Second, Transport: transport}),
+		domainFilter:  domainFilter,
+		configuration: configuration,
+		view:          view,
+		deploy:        deploy,
+		DryRun:        dryRun,
+	}, nil
+}
+
+func parseBoolEnv(name string) (bool, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+	return b, nil
+}
+
+// Zones returns the list of hosted zones of the view, the subzones included.
+func (p *BlueCatProvider) Zones(ctx context.Context) ([]Zone, error) {
+	viewID, err := p.Client.FindView(ctx, p.configuration, p.view)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find view: %w", err)
+	}
+	zones, err := p.Client.ListZones(ctx, viewID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zones: %w", err)
+	}
+
+	var filtered []Zone
+	for _, zone := range zones {
+		if p.domainFilter.Match(zone.AbsoluteName) {
+			filtered = append(filtered, zone)
+		}
+	}
+	return filtered, nil
+}
+
+// SupportedRecordType returns true for the record types external-dns manages in Address Manager: the alias, MX and TXT
+// resource records, and the generic records of the A, AAAA and PTR types. The host records are left alone.
+func (p *BlueCatProvider) SupportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT, endpoint.RecordTypeMX, endpoint.RecordTypePTR:
+		return true
+	default:
+		return false
+	}
+}
+
+// Records returns the list of records in all the zones. Address Manager stores a resource record per target, the
+// records of a name and type are merged into a single endpoint.
+func (p *BlueCatProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, zone := range zones {
+		records, err := p.Client.ListRecords(ctx, zone.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list records of zone %s: %w", zone.AbsoluteName, err)
+		}
+
+		for _, r := range records {
+			recordType := recordType(r)
+			if !p.SupportedRecordType(recordType) {
+				continue
+			}
+			endpoints = append(endpoints, endpoint.NewEndpointWithTTL(r.AbsoluteName, recordType, endpoint.TTL(r.TTL), recordTarget(r)))
+		}
+	}
+	endpoints = provider.MergeEndpointsByNameType(endpoints)
+
+	log.WithFields(log.Fields{
+		"endpoints": endpoints,
+	}).Debug("Endpoints generated from BlueCat Address Manager")
+
+	return endpoints, nil
+}
+
+// ApplyChanges applies the given changes, listing the resource records of a zone once for all its changes.
+func (p *BlueCatProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return err
+	}
+
+	zonesByID := map[string]Zone{}
+	zoneNameIDMapper := provider.ZoneIDName{}
+	for _, zone := range zones {
+		id := strconv.FormatInt(zone.ID, 10)
+		zonesByID[id] = zone
+		zoneNameIDMapper.Add(id, zone.AbsoluteName)
+	}
+
+	recordsByZone := map[int64][]Record{}
+	records := func(zone Zone) ([]Record, error) {
+		if r, ok := recordsByZone[zone.ID]; ok {
+			return r, nil
+		}
+		r, err := p.Client.ListRecords(ctx, zone.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list records of zone %s: %w", zone.AbsoluteName, err)
+		}
+		recordsByZone[zone.ID] = r
+		return r, nil
+	}
+
+	var blueCatChanges blueCatChanges
+	err = provider.ForEachEndpointTargets(changes, func(ep *endpoint.Endpoint, targets []string, prune bool) error {
+		zoneID, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zoneID == "" {
+			log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", ep.DNSName)
+			return nil
+		}
+		zone := zonesByID[zoneID]
+		existing, err := records(zone)
+		if err != nil {
+			return err
+		}
+		return blueCatChanges.add(zone, existing, ep, targets, prune)
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.submitChanges(ctx, blueCatChanges)
+}
+
+// add adds the changes turning the zone's resource records of the endpoint's name and type into a record per target.
+// Address Manager updates a resource record as a whole, an outdated record is sent back with the endpoint's TTL.
+func (c *blueCatChanges) add(zone Zone, existing []Record, ep *endpoint.Endpoint, targets []string, prune bool) error {
+	current := slices.DeleteFunc(slices.Clone(existing), func(r Record) bool {
+		return !strings.EqualFold(r.AbsoluteName, ep.DNSName) || recordType(r) != ep.RecordType
+	})
+	diff := provider.DiffRecords(current, targets, prune, recordTarget, func(r Record) bool {
+		return ep.RecordTTL.IsConfigured() && r.TTL != int(ep.RecordTTL)
+	})
+
+	for _, target := range diff.Create {
+		record, err := newRecord(zone, ep, target)
+		if err != nil {
+			return fmt.Errorf("invalid target %q of %s record %s: %w", target, ep.RecordType, ep.DNSName, err)
+		}
+		c.Creates = append(c.Creates, blueCatChangeCreate{Zone: zone, Record: record})
+	}
+	for _, update := range diff.Update {
+		record := update.Record
+		record.TTL = int(ep.RecordTTL)
+		c.Updates = append(c.Updates, blueCatChangeUpdate{Zone: zone, Record: record})
+	}
+	for _, record := range diff.Delete {
+		c.Deletes = append(c.Deletes, blueCatChangeDelete{Zone: zone, Record: record})
+	}
+	return nil
+}
+
+// submitChanges submits the changes to the Address Manager REST API one resource record at a time, the deletions first
+// for an alias record to take the place of the other records of its name. The changed zones are deployed afterwards
+// when the deployment is enabled.
+func (p *BlueCatProvider) submitChanges(ctx context.Context, changes blueCatChanges) error {
+	var changedZones []Zone
+	changed := func(zone Zone) {
+		if !slices.ContainsFunc(changedZones, func(z Zone) bool { return z.ID == zone.ID }) {
+			changedZones = append(changedZones, zone)
+		}
+	}
+
+	for _, change := range changes.Deletes {
+		logFields := log.Fields{
+			"record":   change.Record.AbsoluteName,
+			"type":     recordType(change.Record),
+			"target":   recordTarget(change.Record),
+			"action":   "Delete",
+			"zoneName": change.Zone.AbsoluteName,
+		}
+		log.WithFields(logFields).Info("Deleting record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.DeleteRecord(ctx, change.Record.ID); err != nil {
+			return fmt.Errorf("failed to delete %s record %s of zone %s: %w", recordType(change.Record), change.Record.AbsoluteName, change.Zone.AbsoluteName, err)
+		}
+		changed(change.Zone)
+	}
+
+	for _, change := range changes.Updates {
+		logFields := log.Fields{
+			"record":   change.Record.AbsoluteName,
+			"type":     recordType(change.Record),
+			"target":   recordTarget(change.Record),
+			"ttl":      change.Record.TTL,
+			"action":   "Update",
+			"zoneName": change.Zone.AbsoluteName,
+		}
+		log.WithFields(logFields).Info("Updating record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.UpdateRecord(ctx, change.Record); err != nil {
+			return fmt.Errorf("failed to update %s record %s of zone %s: %w", recordType(change.Record), change.Record.AbsoluteName, change.Zone.AbsoluteName, err)
+		}
+		changed(change.Zone)
+	}
+
+	for _, change := range changes.Creates {
+		logFields := log.Fields{
+			"record":   recordDNSName(change.Zone.AbsoluteName, change.Record.Name),
+			"type":     recordType(change.Record),
+			"target":   recordTarget(change.Record),
+			"action":   "Create",
+			"zoneName": change.Zone.AbsoluteName,
+		}
+		log.WithFields(logFields).Info("Creating record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.CreateRecord(ctx, change.Zone.ID, change.Record); err != nil {
+			return fmt.Errorf("failed to create %s record %s of zone %s: %w", recordType(change.Record), recordDNSName(change.Zone.AbsoluteName, change.Record.Name), change.Zone.AbsoluteName, err)
+		}
+		changed(change.Zone)
+	}
+
+	if !p.deploy {
+		return nil
+	}
+	for _, zone := range changedZones {
+		log.WithFields(log.Fields{"zoneName": zone.AbsoluteName}).Info("Deploying zone.")
+		if err := p.Client.DeployZone(ctx, zone.ID); err != nil {
+			return fmt.Errorf("failed to deploy zone %s: %w", zone.AbsoluteName, err)
+		}
+	}
+	return nil
+}
+
+// recordName returns the name of a record relative to its zone, the apex being the empty name.
+func recordName(zone, dnsName string) string {
+	if dnsName == zone {
+		return ""
+	}
+	return strings.TrimSuffix(dnsName, "."+zone)
+}
+
+// recordDNSName returns the DNS name of a record of a zone.
+func recordDNSName(zone, name string) string {
+	if name == "" {
+		return zone
+	}
+	return name + "." + zone
+}
+
+// recordType returns the endpoint record type of a record. The host records, which are linked to the IP addresses of
+// the IPAM, aren't managed by the provider and have no record type.
+func recordType(r Record) string {
+	switch r.Type {
+	case typeGenericRecord:
+		return r.RecordType
+	case typeAliasRecord:
+		return endpoint.RecordTypeCNAME
+	case typeTXTRecord:
+		return endpoint.RecordTypeTXT
+	case typeMXRecord:
+		return endpoint.RecordTypeMX
+	}
+	return ""
+}
+
+// recordTarget returns the endpoint target of a record, the names being returned without a trailing dot.
+func recordTarget(r Record) string {
+	var linkedName string
+	if r.LinkedRecord != nil {
+		linkedName = strings.TrimSuffix(r.LinkedRecord.AbsoluteName, ".")
+	}
+	switch r.Type {
+	case typeGenericRecord:
+		return strings.TrimSuffix(r.RData, ".")
+	case typeAliasRecord:
+		return linkedName
+	case typeTXTRecord:
+		return r.Text
+	case typeMXRecord:
+		var priority int
+		if r.Priority != nil {
+			priority = *r.Priority
+		}
+		return fmt.Sprintf("%d %s", priority, linkedName)
+	}
+	return ""
+}
+
+// newRecord returns the record of an endpoint target. A, AAAA and PTR records are generic records, CNAME records are
+// alias records. MX targets are of the form "10 mail.example.com", the priority coming first.
+func newRecord(zone Zone, ep *endpoint.Endpoint, target string) (Record, error) {
+	record := Record{Name: recordName(zone.AbsoluteName, ep.DNSName)}
+	if ep.RecordTTL.IsConfigured() {
+		record.TTL = int(ep.RecordTTL)
+	}
+	switch ep.RecordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypePTR:
+		record.Type = typeGenericRecord
+		record.RecordType = ep.RecordType
+		record.RData = target
+	case endpoint.RecordTypeCNAME:
+		record.Type = typeAliasRecord
+		record.LinkedRecord = &LinkedRecord{AbsoluteName: target}
+	case endpoint.RecordTypeTXT:
+		record.Type = typeTXTRecord
+		record.Text = target
+	case endpoint.RecordTypeMX:
+		priorityRaw, exchange, ok := strings.Cut(target, " ")
+		if !ok {
+			return Record{}, fmt.Errorf("the priority is missing")
+		}
+		priority, err := strconv.Atoi(priorityRaw)
+		if err != nil {
+			return Record{}, fmt.Errorf("invalid priority %q", priorityRaw)
+		}
+		record.Type = typeMXRecord
+		record.Priority = &priority
+		record.LinkedRecord = &LinkedRecord{AbsoluteName: exchange}
+	}
+	return record, nil
+}
@@ -0,0 +1,331 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bluecat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// fakeAddressManagerAPI is an in-memory Address Manager REST API.
+type fakeAddressManagerAPI struct {
+	mu sync.Mutex
+	// zones by parent, a view or a zone
+	zones       map[string][]Zone
+	records     map[int64][]Record
+	deployments []int64
+	nextID      int64
+	writes      int
+}
+
+func priority(p int) *int {
+	return &p
+}
+
+func newFakeAddressManagerAPI() *fakeAddressManagerAPI {
+	return &fakeAddressManagerAPI{
+		zones: map[string][]Zone{
+			"views/2": {
+				{ID: 9, Name: "com", AbsoluteName: "com"},
+				{ID: 20, Name: "10.in-addr.arpa", AbsoluteName: "10.in-addr.arpa"},
+			},
+			"zones/9":  {{ID: 10, Name: "example", AbsoluteName: "example.com"}},
+			"zones/10": {{ID: 11, Name: "sub", AbsoluteName: "sub.example.com"}},
+		},
+		records: map[int64][]Record{
+			10: {
+				{ID: 100, Type: "HostRecord", Name: "api", AbsoluteName: "api.example.com", TTL: 3600},
+				{ID: 101, Type: typeGenericRecord, Name: "app", AbsoluteName: "app.example.com", TTL: 300, RecordType: "A", RData: "1.2.3.4"},
+				{ID: 102, Type: typeGenericRecord, Name: "app", AbsoluteName: "app.example.com", TTL: 300, RecordType: "A", RData: "1.2.3.5"},
+				{ID: 103, Type: typeTXTRecord, Name: "app", AbsoluteName: "app.example.com", TTL: 300, Text: "heritage=external-dns"},
+				{ID: 104, Type: typeAliasRecord, Name: "www", AbsoluteName: "www.example.com", TTL: 300, LinkedRecord: &LinkedRecord{AbsoluteName: "app.example.com"}},
+				{ID: 105, Type: typeMXRecord, Name: "", AbsoluteName: "example.com", TTL: 300, Priority: priority(10), LinkedRecord: &LinkedRecord{AbsoluteName: "mail.example.com"}},
+			},
+			11: {
+				{ID: 106, Type: typeGenericRecord, Name: "db", AbsoluteName: "db.sub.example.com", RecordType: "A", RData: "10.0.0.5"},
+			},
+			20: {
+				{ID: 107, Type: typeGenericRecord, Name: "5.0.0", AbsoluteName: "5.0.0.10.in-addr.arpa", TTL: 300, RecordType: "PTR", RData: "db.sub.example.com."},
+			},
+		},
+		nextID: 200,
+	}
+}
+
+func (f *fakeAddressManagerAPI) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parts := strings.Split(strings.TrimPrefix(req.URL.Path, "/api/v2/"), "/")
+	respond := func(data any) {
+		_ = json.NewEncoder(w).Encode(data)
+	}
+	fail := func(status int, code, message string) {
+		w.WriteHeader(status)
+		respond(map[string]any{"status": status, "code": code, "message": message})
+	}
+	zoneName := func(zoneID int64) string {
+		for _, zones := range f.zones {
+			for _, z := range zones {
+				if z.ID == zoneID {
+					return z.AbsoluteName
+				}
+			}
+		}
+		return ""
+	}
+	findRecord := func(id string) (int64, int) {
+		for zoneID, records := range f.records {
+			if i := slices.IndexFunc(records, func(r Record) bool { return strconv.FormatInt(r.ID, 10) == id }); i >= 0 {
+				return zoneID, i
+			}
+		}
+		return 0, -1
+	}
+
+	switch {
+	case req.URL.Path == "/api/v2/configurations":
+		respond(map[string]any{"data": []map[string]any{{"id": 1, "name": "production"}}})
+	case req.URL.Path == "/api/v2/configurations/1/views":
+		var views []map[string]any
+		for id, name := range map[int]string{2: "internal", 3: "external"} {
+			if req.URL.Query().Get("filter") == fmt.Sprintf("name:eq('%s')", name) {
+				views = append(views, map[string]any{"id": id, "name": name})
+			}
+		}
+		respond(map[string]any{"data": views})
+	case len(parts) == 3 && parts[2] == "zones":
+		respond(map[string]any{"data": f.zones[parts[0]+"/"+parts[1]]})
+	case len(parts) == 3 && parts[2] == "resourceRecords":
+		zoneID, _ := strconv.ParseInt(parts[1], 10, 64)
+		if req.Method == http.MethodGet {
+			respond(map[string]any{"data": f.records[zoneID]})
+			return
+		}
+		var record Record
+		_ = json.NewDecoder(req.Body).Decode(&record)
+		f.nextID++
+		f.writes++
+		record.ID = f.nextID
+		record.AbsoluteName = zoneName(zoneID)
+		if record.Name != "" {
+			record.AbsoluteName = record.Name + "." + record.AbsoluteName
+		}
+		f.records[zoneID] = append(f.records[zoneID], record)
+		w.WriteHeader(http.StatusCreated)
+		respond(record)
+	case len(parts) == 2 && parts[0] == "resourceRecords":
+		zoneID, i := findRecord(parts[1])
+		if i < 0 {
+			fail(http.StatusNotFound, "ObjectNotFound", "Resource record not found")
+			return
+		}
+		f.writes++
+		if req.Method == http.MethodDelete {
+			f.records[zoneID] = slices.Delete(f.records[zoneID], i, i+1)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		var record Record
+		_ = json.NewDecoder(req.Body).Decode(&record)
+		f.records[zoneID][i] = record
+		respond(record)
+	case len(parts) == 3 && parts[2] == "deployments":
+		zoneID, _ := strconv.ParseInt(parts[1], 10, 64)
+		f.deployments = append(f.deployments, zoneID)
+		w.WriteHeader(http.StatusCreated)
+		respond(map[string]any{"id": 300, "type": "QuickDeployment", "state": "QUEUED"})
+	default:
+		fail(http.StatusNotFound, "NotFound", "Not found")
+	}
+}
+
+func newTestProvider(t *testing.T, api *fakeAddressManagerAPI, domains ...string) *BlueCatProvider {
+	return &BlueCatProvider{
+		Client:        newTestClient(t, api.ServeHTTP),
+		domainFilter:  endpoint.NewDomainFilter(domains),
+		configuration: "production",
+		view:          "internal",
+	}
+}
+
+func TestNewBlueCatProvider(t *testing.T) {
+	t.Setenv("BLUECAT_HOST", "bam.example.com")
+	t.Setenv("BLUECAT_API_TOKEN", "secret-token")
+	t.Setenv("BLUECAT_CONFIGURATION", "production")
+	t.Setenv("BLUECAT_VIEW", "internal")
+	t.Setenv("BLUECAT_DEPLOY", "true")
+	p, err := NewBlueCatProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.NoError(t, err)
+	assert.True(t, p.deploy)
+
+	t.Setenv("BLUECAT_SKIP_TLS_VERIFY", "maybe")
+	_, err = NewBlueCatProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.ErrorContains(t, err, `invalid BLUECAT_SKIP_TLS_VERIFY "maybe"`)
+
+	t.Setenv("BLUECAT_VIEW", "")
+	_, err = NewBlueCatProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.EqualError(t, err, "no configuration or view found, set BLUECAT_CONFIGURATION and BLUECAT_VIEW")
+
+	t.Setenv("BLUECAT_API_TOKEN", "")
+	_, err = NewBlueCatProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.EqualError(t, err, "no API token found, set BLUECAT_API_TOKEN")
+
+	t.Setenv("BLUECAT_HOST", "")
+	_, err = NewBlueCatProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.EqualError(t, err, "no Address Manager host found, set BLUECAT_HOST")
+}
+
+func TestBlueCatProviderZones(t *testing.T) {
+	p := newTestProvider(t, newFakeAddressManagerAPI(), "example.com")
+
+	zones, err := p.Zones(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Zone{
+		{ID: 10, Name: "example", AbsoluteName: "example.com"},
+		{ID: 11, Name: "sub", AbsoluteName: "sub.example.com"},
+	}, zones)
+
+	p.view = "missing"
+	_, err = p.Zones(context.Background())
+	require.EqualError(t, err, "failed to find view: view missing of configuration production: not found")
+}
+
+func TestBlueCatProviderRecords(t *testing.T) {
+	p := newTestProvider(t, newFakeAddressManagerAPI(), "example.com", "10.in-addr.arpa")
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("5.0.0.10.in-addr.arpa", endpoint.RecordTypePTR, 300, "db.sub.example.com"),
+		endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5"),
+		endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeTXT, 300, "heritage=external-dns"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeCNAME, 300, "app.example.com"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 300, "10 mail.example.com"),
+		endpoint.NewEndpoint("db.sub.example.com", endpoint.RecordTypeA, "10.0.0.5"),
+	}, endpoints)
+}
+
+func TestBlueCatProviderApplyChanges(t *testing.T) {
+	api := newFakeAddressManagerAPI()
+	p := newTestProvider(t, api, "example.com", "10.in-addr.arpa")
+	p.deploy = true
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("cache.sub.example.com", endpoint.RecordTypeAAAA, "2001:db8::1"),
+			endpoint.NewEndpointWithTTL("6.0.0.10.in-addr.arpa", endpoint.RecordTypePTR, 600, "cache.sub.example.com"),
+			// not in a managed zone
+			endpoint.NewEndpoint("www.example.net", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5"),
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 300, "10 mail.example.com"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeA, 300, "1.2.3.5", "1.2.3.6"),
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 900, "10 mail.example.com", "20 backup.example.com"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, "app.example.com"),
+		},
+	}))
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("5.0.0.10.in-addr.arpa", endpoint.RecordTypePTR, 300, "db.sub.example.com"),
+		endpoint.NewEndpointWithTTL("6.0.0.10.in-addr.arpa", endpoint.RecordTypePTR, 600, "cache.sub.example.com"),
+		endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeA, 300, "1.2.3.5", "1.2.3.6"),
+		endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeTXT, 300, "heritage=external-dns"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 900, "10 mail.example.com", "20 backup.example.com"),
+		endpoint.NewEndpoint("db.sub.example.com", endpoint.RecordTypeA, "10.0.0.5"),
+		endpoint.NewEndpoint("cache.sub.example.com", endpoint.RecordTypeAAAA, "2001:db8::1"),
+	}, endpoints)
+	// the unchanged MX record is updated in place
+	assert.Contains(t, api.records[10], Record{
+		ID: 105, Type: typeMXRecord, Name: "", AbsoluteName: "example.com", TTL: 900, Priority: priority(10),
+		LinkedRecord: &LinkedRecord{AbsoluteName: "mail.example.com"},
+	})
+	// the host record is left untouched
+	assert.Equal(t, int64(100), api.records[10][0].ID)
+	assert.Equal(t, 7, api.writes)
+	assert.Equal(t, []int64{10, 11, 20}, api.deployments)
+}
+
+func TestBlueCatProviderApplyChangesDryRun(t *testing.T) {
+	api := newFakeAddressManagerAPI()
+	p := newTestProvider(t, api, "example.com")
+	p.DryRun = true
+	p.deploy = true
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4", "1.2.3.5")},
+	}))
+	assert.Zero(t, api.writes)
+	assert.Empty(t, api.deployments)
+}
+
+func TestBlueCatProviderApplyChangesInvalidTarget(t *testing.T) {
+	api := newFakeAddressManagerAPI()
+	p := newTestProvider(t, api, "example.com")
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "mail.example.com")},
+	})
+	require.EqualError(t, err, `invalid target "mail.example.com" of MX record example.com: the priority is missing`)
+	assert.Zero(t, api.writes)
+}
+
+func TestBlueCatProviderApplyChangesError(t *testing.T) {
+	api := newFakeAddressManagerAPI()
+	p := newTestProvider(t, api, "example.com")
+	// the record is gone by the time it's deleted
+	p.Client = &staleRecordsClient{DNSClient: p.Client}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeTXT, "heritage=external-dns")},
+	})
+	require.EqualError(t, err, "failed to delete TXT record app.example.com of zone example.com: bluecat: 404 Not Found: ObjectNotFound: Resource record not found")
+}
+
+// staleRecordsClient returns records whose IDs don't exist anymore.
+type staleRecordsClient struct {
+	DNSClient
+}
+
+func (c *staleRecordsClient) ListRecords(ctx context.Context, zoneID int64) ([]Record, error) {
+	records, err := c.DNSClient.ListRecords(ctx, zoneID)
+	for i := range records {
+		records[i].ID = 404
+	}
+	return records, err
+}
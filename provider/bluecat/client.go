@@ -0,0 +1,240 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bluecat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+// pageSize is the number of objects listed per request.
+const pageSize = 1000
+
+// The types of the resource records of Address Manager managed by the provider.
+const (
+	typeGenericRecord = "GenericRecord"
+	typeAliasRecord   = "AliasRecord"
+	typeTXTRecord     = "TXTRecord"
+	typeMXRecord      = "MXRecord"
+)
+
+// Zone is a DNS zone of a view. The zones of Address Manager are nested, a zone holding the records and the subzones
+// of its name.
+type Zone struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	AbsoluteName string `json:"absoluteName"`
+}
+
+// LinkedRecord is the record an alias or MX record points to.
+type LinkedRecord struct {
+	AbsoluteName string `json:"absoluteName"`
+}
+
+// Record is a resource record of a zone, the fields depending on its type. A generic record holds the data of a record
+// of its record type, e.g. A or PTR.
+type Record struct {
+	ID           int64         `json:"id,omitempty"`
+	Type         string        `json:"type"`
+	Name         string        `json:"name"`
+	AbsoluteName string        `json:"absoluteName,omitempty"`
+	TTL          int           `json:"ttl,omitempty"`
+	RecordType   string        `json:"recordType,omitempty"`
+	RData        string        `json:"rdata,omitempty"`
+	Text         string        `json:"text,omitempty"`
+	Priority     *int          `json:"priority,omitempty"`
+	LinkedRecord *LinkedRecord `json:"linkedRecord,omitempty"`
+}
+
+// APIError is an error returned by the Address Manager API.
+type APIError struct {
+	StatusCode int
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("bluecat: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("bluecat: %d %s: %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Code, e.Message)
+}
+
+// DNSClient is the interface of the Address Manager API used by the provider.
+type DNSClient interface {
+	FindView(ctx context.Context, configuration, view string) (int64, error)
+	ListZones(ctx context.Context, viewID int64) ([]Zone, error)
+	ListRecords(ctx context.Context, zoneID int64) ([]Record, error)
+	CreateRecord(ctx context.Context, zoneID int64, record Record) error
+	UpdateRecord(ctx context.Context, record Record) error
+	DeleteRecord(ctx context.Context, recordID int64) error
+	DeployZone(ctx context.Context, zoneID int64) error
+}
+
+// Client calls the Address Manager REST API v2 with an API token.
+type Client struct {
+	token      string
+	endpoint   string
+	httpClient *http.Client
+}
+
+// NewClient returns a client of the REST API of the Address Manager at host, authenticating with the API token.
+func NewClient(host, token string, httpClient *http.Client) *Client {
+	endpoint := host
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	return &Client{
+		token:      token,
+		endpoint:   strings.TrimSuffix(endpoint, "/") + "/api/v2",
+		httpClient: httpClient,
+	}
+}
+
+// FindView returns the ID of a view of a configuration.
+func (c *Client) FindView(ctx context.Context, configuration, view string) (int64, error) {
+	configurationID, err := c.findByName(ctx, "/configurations", configuration)
+	if err != nil {
+		return 0, fmt.Errorf("configuration %s: %w", configuration, err)
+	}
+	viewID, err := c.findByName(ctx, fmt.Sprintf("/configurations/%d/views", configurationID), view)
+	if err != nil {
+		return 0, fmt.Errorf("view %s of configuration %s: %w", view, configuration, err)
+	}
+	return viewID, nil
+}
+
+func (c *Client) findByName(ctx context.Context, path, name string) (int64, error) {
+	var page struct {
+		Data []struct {
+			ID int64 `json:"id"`
+		} `json:"data"`
+	}
+	query := url.Values{"filter": {fmt.Sprintf("name:eq('%s')", name)}}
+	if err := c.do(ctx, http.MethodGet, path+"?"+query.Encode(), nil, &page); err != nil {
+		return 0, err
+	}
+	if len(page.Data) == 0 {
+		return 0, fmt.Errorf("not found")
+	}
+	return page.Data[0].ID, nil
+}
+
+// ListZones returns the zones of a view, along with their subzones.
+func (c *Client) ListZones(ctx context.Context, viewID int64) ([]Zone, error) {
+	zones, err := list[Zone](ctx, c, fmt.Sprintf("/views/%d/zones", viewID))
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < len(zones); i++ {
+		subzones, err := list[Zone](ctx, c, fmt.Sprintf("/zones/%d/zones", zones[i].ID))
+		if err != nil {
+			return nil, err
+		}
+		zones = append(zones, subzones...)
+	}
+	return zones, nil
+}
+
+// ListRecords returns the resource records of a zone, without the records of its subzones.
+func (c *Client) ListRecords(ctx context.Context, zoneID int64) ([]Record, error) {
+	return list[Record](ctx, c, fmt.Sprintf("/zones/%d/resourceRecords", zoneID))
+}
+
+// CreateRecord creates a resource record in a zone, its name being relative to the zone.
+func (c *Client) CreateRecord(ctx context.Context, zoneID int64, record Record) error {
+	return c.do(ctx, http.MethodPost, fmt.Sprintf("/zones/%d/resourceRecords", zoneID), record, nil)
+}
+
+// UpdateRecord replaces a resource record.
+func (c *Client) UpdateRecord(ctx context.Context, record Record) error {
+	return c.do(ctx, http.MethodPut, fmt.Sprintf("/resourceRecords/%d", record.ID), record, nil)
+}
+
+// DeleteRecord deletes a resource record.
+func (c *Client) DeleteRecord(ctx context.Context, recordID int64) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/resourceRecords/%d", recordID), nil, nil)
+}
+
+// DeployZone pushes the changes of a zone to the DNS servers.
+func (c *Client) DeployZone(ctx context.Context, zoneID int64) error {
+	return c.do(ctx, http.MethodPost, fmt.Sprintf("/zones/%d/deployments", zoneID), map[string]string{"type": "QuickDeployment"}, nil)
+}
+
+// list returns all the objects of a collection, requesting it page by page.
+func list[T any](ctx context.Context, c *Client, path string) ([]T, error) {
+	var objects []T
+	for offset := 0; ; offset += pageSize {
+		var page struct {
+			Data []T `json:"data"`
+		}
+		query := url.Values{"limit": {strconv.Itoa(pageSize)}, "offset": {strconv.Itoa(offset)}}
+		if err := c.do(ctx, http.MethodGet, path+"?"+query.Encode(), nil, &page); err != nil {
+			return nil, err
+		}
+		objects = append(objects, page.Data...)
+		if len(page.Data) < pageSize {
+			return objects, nil
+		}
+	}
+}
+
+// do sends a request to the API, with the body encoded as JSON, and decodes the response into the result.
+func (c *Client) do(ctx context.Context, method, path string, body, result any) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", externaldns.UserAgent())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		_ = json.NewDecoder(resp.Body).Decode(apiErr)
+		return apiErr
+	}
+	if result == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
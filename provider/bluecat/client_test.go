@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bluecat
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a client authenticated with a test token against the Address Manager of a test server.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewClient(server.URL, "secret-token", server.Client())
+}
+
+func TestNewClientEndpoint(t *testing.T) {
+	assert.Equal(t, "https://bam.example.com/api/v2", NewClient("bam.example.com", "", nil).endpoint)
+	assert.Equal(t, "http://10.0.0.1:8080/api/v2", NewClient("http://10.0.0.1:8080/", "", nil).endpoint)
+}
+
+func TestClientFindView(t *testing.T) {
+	var requests []string
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "Bearer secret-token", req.Header.Get("Authorization"))
+		requests = append(requests, req.URL.Path+" "+req.URL.Query().Get("filter"))
+		if req.URL.Path == "/api/v2/configurations" {
+			fmt.Fprint(w, `{"count":1,"data":[{"id":1,"type":"Configuration","name":"production"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"count":0,"data":[]}`)
+	})
+
+	_, err := client.FindView(context.Background(), "production", "internal")
+	require.EqualError(t, err, "view internal of configuration production: not found")
+	assert.Equal(t, []string{
+		"/api/v2/configurations name:eq('production')",
+		"/api/v2/configurations/1/views name:eq('internal')",
+	}, requests)
+}
+
+func TestClientListZonesWalksSubzones(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/api/v2/views/2/zones":
+			fmt.Fprint(w, `{"count":1,"data":[{"id":9,"type":"Zone","name":"com","absoluteName":"com"}]}`)
+		case "/api/v2/zones/9/zones":
+			fmt.Fprint(w, `{"count":1,"data":[{"id":10,"type":"Zone","name":"example","absoluteName":"example.com"}]}`)
+		default:
+			fmt.Fprint(w, `{"count":0,"data":[]}`)
+		}
+	})
+
+	zones, err := client.ListZones(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, []Zone{{ID: 9, Name: "com", AbsoluteName: "com"}, {ID: 10, Name: "example", AbsoluteName: "example.com"}}, zones)
+}
+
+func TestClientCreateRecord(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "/api/v2/zones/10/resourceRecords", req.URL.Path)
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"type":"MXRecord","name":"","ttl":300,"priority":10,"linkedRecord":{"absoluteName":"mail.example.com"}}`, string(body))
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":200,"type":"MXRecord"}`)
+	})
+
+	priority := 10
+	require.NoError(t, client.CreateRecord(context.Background(), 10, Record{
+		Type: typeMXRecord, TTL: 300, Priority: &priority, LinkedRecord: &LinkedRecord{AbsoluteName: "mail.example.com"},
+	}))
+}
+
+func TestClientAPIError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"status":409,"reason":"Conflict","code":"DuplicateObject","message":"Duplicate of another item"}`)
+	})
+
+	err := client.DeleteRecord(context.Background(), 42)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.EqualError(t, err, "bluecat: 409 Conflict: DuplicateObject: Duplicate of another item")
+}
@@ -0,0 +1,308 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bunny
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// defaultTTL is the TTL of the records without a configured TTL.
+const defaultTTL = 300
+
+// BunnyProvider is an implementation of Provider for Bunny DNS.
+type BunnyProvider struct {
+	provider.BaseProvider
+	Client DNSClient
+	// only consider hosted zones managing domains ending in this suffix
+	domainFilter endpoint.DomainFilter
+	DryRun       bool
+}
+
+type bunnyChangeCreate struct {
+	Zone    Zone
+	Options RecordRequest
+}
+
+type bunnyChangeUpdate struct {
+	Zone    Zone
+	Record  Record
+	Options RecordRequest
+}
+
+type bunnyChangeDelete struct {
+	Zone   Zone
+	Record Record
+}
+
+// bunnyChanges contains all changes to apply to DNS
+type bunnyChanges struct {
+	Creates []bunnyChangeCreate
+	Updates []bunnyChangeUpdate
+	Deletes []bunnyChangeDelete
+}
+
+// NewBunnyProvider initializes a new Bunny DNS based Provider.
+func NewBunnyProvider(domainFilter endpoint.DomainFilter, dryRun bool) (*BunnyProvider, error) {
+	apiKey := os.Getenv("BUNNY_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("no API key found, set BUNNY_API_KEY")
+	}
+
+	return &BunnyProvider{
+		Client:       NewClient(apiKey, &http.Client{Timeout: 30 * time.Second}),
+		domainFilter: domainFilter,
+		DryRun:       dryRun,
+	}, nil
+}
+
+// Zones returns the list of hosted zones, with their records.
+func (p *BunnyProvider) Zones(ctx context.Context) ([]Zone, error) {
+	zones, err := p.Client.ListZones(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DNS zones: %w", err)
+	}
+
+	var filtered []Zone
+	for _, zone := range zones {
+		if p.domainFilter.Match(zone.Domain) {
+			filtered = append(filtered, zone)
+		}
+	}
+	return filtered, nil
+}
+
+// SupportedRecordType returns true for the standard record types managed on Bunny DNS, the records specific to Bunny,
+// such as pull zone and redirect records, are left alone.
+func (p *BunnyProvider) SupportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT, endpoint.RecordTypeMX:
+		return true
+	default:
+		return false
+	}
+}
+
+// Records returns the list of records in all the zones. Bunny DNS stores a record per target, the records of a name
+// and type are merged into a single endpoint.
+func (p *BunnyProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, zone := range zones {
+		for _, r := range zone.Records {
+			recordType := r.Type.String()
+			if !p.SupportedRecordType(recordType) {
+				continue
+			}
+			endpoints = append(endpoints, endpoint.NewEndpointWithTTL(recordDNSName(zone.Domain, r.Name), recordType, endpoint.TTL(r.TTL), recordTarget(r)))
+		}
+	}
+	endpoints = provider.MergeEndpointsByNameType(endpoints)
+
+	log.WithFields(log.Fields{
+		"endpoints": endpoints,
+	}).Debug("Endpoints generated from Bunny DNS")
+
+	return endpoints, nil
+}
+
+// ApplyChanges applies the given changes to the records listed with the zones, so that a zone is listed a single time.
+func (p *BunnyProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return err
+	}
+
+	zoneNameIDMapper := provider.ZoneIDName{}
+	zonesByID := map[string]Zone{}
+	for _, zone := range zones {
+		id := strconv.FormatInt(zone.ID, 10)
+		zoneNameIDMapper.Add(id, zone.Domain)
+		zonesByID[id] = zone
+	}
+
+	var bunnyChanges bunnyChanges
+	err = provider.ForEachEndpointTargets(changes, func(ep *endpoint.Endpoint, targets []string, prune bool) error {
+		zoneID, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zoneID == "" {
+			log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", ep.DNSName)
+			return nil
+		}
+		return bunnyChanges.add(zonesByID[zoneID], ep, targets, prune)
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.submitChanges(ctx, bunnyChanges)
+}
+
+// add adds the changes turning the zone's records of the endpoint's name and type into a record per target. A record
+// whose TTL differs from the configured one is updated in place, keeping its ID.
+func (c *bunnyChanges) add(zone Zone, ep *endpoint.Endpoint, targets []string, prune bool) error {
+	name := recordName(zone.Domain, ep.DNSName)
+	recordType, ok := recordTypes[ep.RecordType]
+	if !ok {
+		return fmt.Errorf("unsupported record type %s of record %s", ep.RecordType, ep.DNSName)
+	}
+
+	current := slices.DeleteFunc(slices.Clone(zone.Records), func(r Record) bool {
+		return recordName(zone.Domain, recordDNSName(zone.Domain, r.Name)) != name || r.Type != recordType
+	})
+	diff := provider.DiffRecords(current, targets, prune, recordTarget, func(r Record) bool {
+		return ep.RecordTTL.IsConfigured() && r.TTL != int(ep.RecordTTL)
+	})
+
+	for _, target := range diff.Create {
+		options, err := newRecordRequest(name, recordType, target, ep.RecordTTL)
+		if err != nil {
+			return fmt.Errorf("invalid target %q of %s record %s: %w", target, ep.RecordType, ep.DNSName, err)
+		}
+		c.Creates = append(c.Creates, bunnyChangeCreate{Zone: zone, Options: options})
+	}
+	for _, update := range diff.Update {
+		options, err := newRecordRequest(name, recordType, update.Target, ep.RecordTTL)
+		if err != nil {
+			return fmt.Errorf("invalid target %q of %s record %s: %w", update.Target, ep.RecordType, ep.DNSName, err)
+		}
+		c.Updates = append(c.Updates, bunnyChangeUpdate{Zone: zone, Record: update.Record, Options: options})
+	}
+	for _, record := range diff.Delete {
+		c.Deletes = append(c.Deletes, bunnyChangeDelete{Zone: zone, Record: record})
+	}
+	return nil
+}
+
+// submitChanges submits the changes to the Bunny API one record at a time, the deletions first as a CNAME record
+// can't be created next to the other records of its name.
+func (p *BunnyProvider) submitChanges(ctx context.Context, changes bunnyChanges) error {
+	for _, change := range changes.Deletes {
+		logFields := log.Fields{
+			"record":   change.Record.Name,
+			"type":     change.Record.Type.String(),
+			"target":   change.Record.Value,
+			"action":   "Delete",
+			"zoneName": change.Zone.Domain,
+		}
+		log.WithFields(logFields).Info("Deleting record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.DeleteRecord(ctx, change.Zone.ID, change.Record.ID); err != nil {
+			return fmt.Errorf("failed to delete %s record %s of zone %s: %w", change.Record.Type, change.Record.Name, change.Zone.Domain, err)
+		}
+	}
+
+	for _, change := range changes.Updates {
+		logFields := log.Fields{
+			"record":   change.Record.Name,
+			"type":     change.Record.Type.String(),
+			"target":   change.Options.Value,
+			"ttl":      change.Options.TTL,
+			"action":   "Update",
+			"zoneName": change.Zone.Domain,
+		}
+		log.WithFields(logFields).Info("Updating record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.UpdateRecord(ctx, change.Zone.ID, change.Record.ID, change.Options); err != nil {
+			return fmt.Errorf("failed to update %s record %s of zone %s: %w", change.Record.Type, change.Record.Name, change.Zone.Domain, err)
+		}
+	}
+
+	for _, change := range changes.Creates {
+		logFields := log.Fields{
+			"record":   change.Options.Name,
+			"type":     change.Options.Type.String(),
+			"target":   change.Options.Value,
+			"action":   "Create",
+			"zoneName": change.Zone.Domain,
+		}
+		log.WithFields(logFields).Info("Creating record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.AddRecord(ctx, change.Zone.ID, change.Options); err != nil {
+			return fmt.Errorf("failed to create %s record %s of zone %s: %w", change.Options.Type, change.Options.Name, change.Zone.Domain, err)
+		}
+	}
+
+	return nil
+}
+
+// recordName returns the name of a record relative to its zone, the apex being the empty name.
+func recordName(zone, dnsName string) string {
+	if dnsName == zone {
+		return ""
+	}
+	return strings.TrimSuffix(dnsName, "."+zone)
+}
+
+// recordDNSName returns the DNS name of a record of a zone.
+func recordDNSName(zone, name string) string {
+	if name == "" || name == "@" {
+		return zone
+	}
+	return name + "." + zone
+}
+
+// recordTarget returns the endpoint target of a record, the priority of MX records is stored apart from their value.
+func recordTarget(r Record) string {
+	if r.Type == RecordTypeMX {
+		return fmt.Sprintf("%d %s", r.Priority, r.Value)
+	}
+	return r.Value
+}
+
+// newRecordRequest returns the request creating the record of an endpoint target. MX targets are of the form
+// "10 mail.example.com", the priority coming first.
+func newRecordRequest(name string, recordType RecordType, target string, ttl endpoint.TTL) (RecordRequest, error) {
+	request := RecordRequest{Name: name, Type: recordType, Value: target, TTL: defaultTTL}
+	if ttl.IsConfigured() {
+		request.TTL = int(ttl)
+	}
+	if recordType == RecordTypeMX {
+		priorityRaw, value, ok := strings.Cut(target, " ")
+		if !ok {
+			return RecordRequest{}, fmt.Errorf("the priority is missing")
+		}
+		priority, err := strconv.Atoi(priorityRaw)
+		if err != nil {
+			return RecordRequest{}, fmt.Errorf("invalid priority %q", priorityRaw)
+		}
+		request.Priority = priority
+		request.Value = value
+	}
+	return request, nil
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bunny
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// fakeBunnyAPI is an in-memory Bunny DNS API.
+type fakeBunnyAPI struct {
+	t      *testing.T
+	zones  []Zone
+	nextID int64
+	writes int
+}
+
+// newFakeBunnyAPI returns a fake API serving the test zones.
+func newFakeBunnyAPI(t *testing.T) *fakeBunnyAPI {
+	return &fakeBunnyAPI{t: t, zones: testBunnyZones(), nextID: 100}
+}
+
+func (f *fakeBunnyAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	assert.Equal(f.t, "secret", r.Header.Get("AccessKey"))
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	if len(parts) == 1 && r.Method == http.MethodGet {
+		require.NoError(f.t, json.NewEncoder(w).Encode(map[string]any{"Items": f.zones, "HasMoreItems": false}))
+		return
+	}
+
+	zoneID, _ := strconv.ParseInt(parts[1], 10, 64)
+	z := slices.IndexFunc(f.zones, func(zone Zone) bool { return zone.ID == zoneID })
+	if z < 0 || len(parts) < 3 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	zone := &f.zones[z]
+
+	var body RecordRequest
+	if r.Method != http.MethodDelete {
+		require.NoError(f.t, json.NewDecoder(r.Body).Decode(&body))
+	}
+	if len(parts) == 3 && r.Method == http.MethodPut {
+		f.nextID++
+		f.writes++
+		record := Record{ID: f.nextID, Type: body.Type, Name: body.Name, Value: body.Value, TTL: body.TTL, Priority: body.Priority}
+		zone.Records = append(zone.Records, record)
+		w.WriteHeader(http.StatusCreated)
+		require.NoError(f.t, json.NewEncoder(w).Encode(record))
+		return
+	}
+
+	recordID, _ := strconv.ParseInt(parts[3], 10, 64)
+	i := slices.IndexFunc(zone.Records, func(record Record) bool { return record.ID == recordID })
+	if i < 0 {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"ErrorKey":"dnszone.record.not_found","Message":"The requested DNS record was not found"}`))
+		return
+	}
+	f.writes++
+	switch r.Method {
+	case http.MethodPost:
+		assert.Equal(f.t, recordID, body.ID)
+		zone.Records[i] = Record{ID: recordID, Type: body.Type, Name: body.Name, Value: body.Value, TTL: body.TTL, Priority: body.Priority}
+	case http.MethodDelete:
+		zone.Records = slices.Delete(zone.Records, i, i+1)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func testBunnyZones() []Zone {
+	return []Zone{
+		{ID: 1, Domain: "example.com", Records: []Record{
+			{ID: 1, Type: RecordTypeA, Name: "", Value: "1.2.3.4", TTL: 300},
+			{ID: 2, Type: RecordTypeA, Name: "www", Value: "1.2.3.4", TTL: 300},
+			{ID: 3, Type: RecordTypeA, Name: "www", Value: "1.2.3.5", TTL: 300},
+			{ID: 4, Type: RecordTypeTXT, Name: "www", Value: "heritage=external-dns", TTL: 300},
+			{ID: 5, Type: RecordTypeMX, Name: "", Value: "mail.example.com", Priority: 10, TTL: 3600},
+			// a pull zone record, specific to Bunny
+			{ID: 6, Type: 7, Name: "cdn", Value: "12345", TTL: 300},
+		}},
+		{ID: 2, Domain: "example.org", Records: []Record{
+			{ID: 7, Type: RecordTypeCNAME, Name: "www", Value: "example.com", TTL: 300},
+		}},
+	}
+}
+
+func newTestProvider(t *testing.T, api *fakeBunnyAPI, domains ...string) *BunnyProvider {
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+	client := NewClient("secret", server.Client())
+	client.endpoint = server.URL
+	return &BunnyProvider{
+		Client:       client,
+		domainFilter: endpoint.NewDomainFilter(domains),
+	}
+}
+
+func TestNewBunnyProvider(t *testing.T) {
+	t.Setenv("BUNNY_API_KEY", "secret")
+	_, err := NewBunnyProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.NoError(t, err)
+
+	t.Setenv("BUNNY_API_KEY", "")
+	_, err = NewBunnyProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.EqualError(t, err, "no API key found, set BUNNY_API_KEY")
+}
+
+func TestBunnyProviderRecords(t *testing.T) {
+	p := newTestProvider(t, newFakeBunnyAPI(t), "example.com")
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 300, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeTXT, 300, "heritage=external-dns"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+	}, endpoints)
+}
+
+func TestBunnyProviderApplyChanges(t *testing.T) {
+	api := newFakeBunnyAPI(t)
+	p := newTestProvider(t, api, "example.com", "example.org")
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeAAAA, "2001:db8::1", "2001:db8::2"),
+			endpoint.NewEndpointWithTTL("example.org", endpoint.RecordTypeTXT, 60, "v=spf1 -all"),
+			// not in a managed zone
+			endpoint.NewEndpoint("www.example.net", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5"),
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.5", "1.2.3.6"),
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 600, "10 mail.example.com", "20 backup.example.com"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeTXT, "heritage=external-dns"),
+			endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeCNAME, "example.com"),
+		},
+	}))
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 300, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.5", "1.2.3.6"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 600, "10 mail.example.com", "20 backup.example.com"),
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeAAAA, defaultTTL, "2001:db8::1", "2001:db8::2"),
+		endpoint.NewEndpointWithTTL("example.org", endpoint.RecordTypeTXT, 60, "v=spf1 -all"),
+	}, endpoints)
+	// the unchanged target of www.example.com is kept
+	assert.Contains(t, api.zones[0].Records, Record{ID: 3, Type: RecordTypeA, Name: "www", Value: "1.2.3.5", TTL: 300})
+	// the TTL of the MX record is updated in place
+	assert.Contains(t, api.zones[0].Records, Record{ID: 5, Type: RecordTypeMX, Name: "", Value: "mail.example.com", Priority: 10, TTL: 600})
+	// the records specific to Bunny are left alone
+	assert.Contains(t, api.zones[0].Records, Record{ID: 6, Type: 7, Name: "cdn", Value: "12345", TTL: 300})
+}
+
+func TestBunnyProviderApplyChangesDryRun(t *testing.T) {
+	api := newFakeBunnyAPI(t)
+	p := newTestProvider(t, api, "example.com")
+	p.DryRun = true
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.4", "1.2.3.5")},
+	}))
+	assert.Zero(t, api.writes)
+}
+
+func TestBunnyProviderApplyChangesInvalidTarget(t *testing.T) {
+	api := newFakeBunnyAPI(t)
+	p := newTestProvider(t, api, "example.com")
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "mail.example.com")},
+	})
+	require.EqualError(t, err, `invalid target "mail.example.com" of MX record example.com: the priority is missing`)
+	assert.Zero(t, api.writes)
+}
+
+func TestBunnyProviderApplyChangesError(t *testing.T) {
+	api := newFakeBunnyAPI(t)
+	p := newTestProvider(t, api, "example.com")
+	// the record was deleted behind the provider's back
+	api.zones[0].Records = api.zones[0].Records[:3]
+	p.Client = &staleZonesClient{DNSClient: p.Client, zones: testBunnyZones()}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeTXT, "heritage=external-dns")},
+	})
+	require.EqualError(t, err, "failed to delete TXT record www of zone example.com: bunny: 404 Not Found: dnszone.record.not_found: The requested DNS record was not found")
+}
+
+// staleZonesClient lists zones that are out of date.
+type staleZonesClient struct {
+	DNSClient
+	zones []Zone
+}
+
+func (c *staleZonesClient) ListZones(context.Context) ([]Zone, error) {
+	return c.zones, nil
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bunny
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+const (
+	// defaultEndpoint is the base URL of the Bunny.net API.
+	defaultEndpoint = "https://api.bunny.net"
+	// perPage is the page size of the zone listing, the maximum allowed by the API.
+	perPage = 1000
+)
+
+// RecordType is the numeric type of a Bunny DNS record.
+type RecordType int
+
+// The record types of the Bunny DNS API managed by the provider.
+const (
+	RecordTypeA     RecordType = 0
+	RecordTypeAAAA  RecordType = 1
+	RecordTypeCNAME RecordType = 2
+	RecordTypeTXT   RecordType = 3
+	RecordTypeMX    RecordType = 4
+)
+
+// recordTypes maps the endpoint record types to the Bunny record types.
+var recordTypes = map[string]RecordType{
+	endpoint.RecordTypeA:     RecordTypeA,
+	endpoint.RecordTypeAAAA:  RecordTypeAAAA,
+	endpoint.RecordTypeCNAME: RecordTypeCNAME,
+	endpoint.RecordTypeTXT:   RecordTypeTXT,
+	endpoint.RecordTypeMX:    RecordTypeMX,
+}
+
+// String returns the endpoint record type of a Bunny record type, empty for the types not managed by the provider.
+func (t RecordType) String() string {
+	for name, recordType := range recordTypes {
+		if recordType == t {
+			return name
+		}
+	}
+	return ""
+}
+
+// Zone is a DNS zone of the account, listed with its records.
+type Zone struct {
+	ID      int64    `json:"Id"`
+	Domain  string   `json:"Domain"`
+	Records []Record `json:"Records"`
+}
+
+// Record is a DNS record of a zone. The records are flat within a zone, a record holds a single value.
+type Record struct {
+	ID       int64      `json:"Id"`
+	Type     RecordType `json:"Type"`
+	Name     string     `json:"Name"`
+	Value    string     `json:"Value"`
+	TTL      int        `json:"Ttl"`
+	Priority int        `json:"Priority"`
+}
+
+// RecordRequest is the body of the requests adding or updating a record.
+type RecordRequest struct {
+	ID       int64      `json:"Id,omitempty"`
+	Type     RecordType `json:"Type"`
+	Name     string     `json:"Name"`
+	Value    string     `json:"Value"`
+	TTL      int        `json:"Ttl,omitempty"`
+	Priority int        `json:"Priority,omitempty"`
+}
+
+// APIError is an error returned by the Bunny.net API.
+type APIError struct {
+	StatusCode int    `json:"-"`
+	ErrorKey   string `json:"ErrorKey"`
+	Message    string `json:"Message"`
+}
+
+func (e *APIError) Error() string {
+	if e.ErrorKey == "" {
+		return fmt.Sprintf("bunny: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+	}
+	return fmt.Sprintf("bunny: %d %s: %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.ErrorKey, e.Message)
+}
+
+// DNSClient is the interface of the Bunny DNS API used by the provider.
+type DNSClient interface {
+	ListZones(ctx context.Context) ([]Zone, error)
+	AddRecord(ctx context.Context, zoneID int64, record RecordRequest) error
+	UpdateRecord(ctx context.Context, zoneID int64, recordID int64, record RecordRequest) error
+	DeleteRecord(ctx context.Context, zoneID int64, recordID int64) error
+}
+
+// Client calls the Bunny.net API with the API key of the account.
+type Client struct {
+	apiKey     string
+	endpoint   string
+	httpClient *http.Client
+}
+
+// NewClient returns a client of the Bunny.net API authenticating with the API key.
+func NewClient(apiKey string, httpClient *http.Client) *Client {
+	return &Client{
+		apiKey:     apiKey,
+		endpoint:   defaultEndpoint,
+		httpClient: httpClient,
+	}
+}
+
+func (c *Client) ListZones(ctx context.Context) ([]Zone, error) {
+	var zones []Zone
+	for page := 1; ; page++ {
+		var result struct {
+			Items        []Zone `json:"Items"`
+			HasMoreItems bool   `json:"HasMoreItems"`
+		}
+		query := url.Values{"page": {strconv.Itoa(page)}, "perPage": {strconv.Itoa(perPage)}}
+		if err := c.do(ctx, http.MethodGet, "/dnszone", query, nil, &result); err != nil {
+			return nil, err
+		}
+		zones = append(zones, result.Items...)
+		if !result.HasMoreItems || len(result.Items) == 0 {
+			return zones, nil
+		}
+	}
+}
+
+func (c *Client) AddRecord(ctx context.Context, zoneID int64, record RecordRequest) error {
+	return c.do(ctx, http.MethodPut, fmt.Sprintf("/dnszone/%d/records", zoneID), nil, record, nil)
+}
+
+func (c *Client) UpdateRecord(ctx context.Context, zoneID int64, recordID int64, record RecordRequest) error {
+	record.ID = recordID
+	return c.do(ctx, http.MethodPost, fmt.Sprintf("/dnszone/%d/records/%d", zoneID, recordID), nil, record, nil)
+}
+
+func (c *Client) DeleteRecord(ctx context.Context, zoneID int64, recordID int64) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/dnszone/%d/records/%d", zoneID, recordID), nil, nil, nil)
+}
+
+// do sends a request to the API, with the body encoded as JSON, and decodes the response into the result.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, result any) error {
+	u := c.endpoint + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("AccessKey", c.apiKey)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", externaldns.UserAgent())
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		_ = json.NewDecoder(resp.Body).Decode(apiErr)
+		return apiErr
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bunny
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTripFunc is an http.RoundTripper answering the requests with a function.
+type roundTripFunc func(req *http.Request) *http.Response
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestClientListZonesPagination(t *testing.T) {
+	var pages []string
+	client := NewClient("secret", &http.Client{Transport: roundTripFunc(func(req *http.Request) *http.Response {
+		assert.Equal(t, "secret", req.Header.Get("AccessKey"))
+		assert.Equal(t, "/dnszone", req.URL.Path)
+		assert.Equal(t, "1000", req.URL.Query().Get("perPage"))
+		page := req.URL.Query().Get("page")
+		pages = append(pages, page)
+		if page == "1" {
+			return jsonResponse(http.StatusOK, `{"Items":[{"Id":1,"Domain":"example.com","Records":[{"Id":10,"Type":0,"Name":"www","Value":"1.2.3.4","Ttl":300}]}],"CurrentPage":1,"TotalItems":2,"HasMoreItems":true}`)
+		}
+		return jsonResponse(http.StatusOK, `{"Items":[{"Id":2,"Domain":"example.org","Records":[{"Id":20,"Type":4,"Name":"","Value":"mail.example.org","Ttl":300,"Priority":10}]}],"CurrentPage":2,"TotalItems":2,"HasMoreItems":false}`)
+	})})
+
+	zones, err := client.ListZones(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, pages)
+	assert.Equal(t, []Zone{
+		{ID: 1, Domain: "example.com", Records: []Record{{ID: 10, Type: RecordTypeA, Name: "www", Value: "1.2.3.4", TTL: 300}}},
+		{ID: 2, Domain: "example.org", Records: []Record{{ID: 20, Type: RecordTypeMX, Name: "", Value: "mail.example.org", TTL: 300, Priority: 10}}},
+	}, zones)
+}
+
+func TestClientAddRecord(t *testing.T) {
+	client := NewClient("secret", &http.Client{Transport: roundTripFunc(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodPut, req.Method)
+		assert.Equal(t, "/dnszone/1/records", req.URL.Path)
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"Type":4,"Name":"","Value":"mail.example.com","Ttl":300,"Priority":10}`, string(body))
+		return jsonResponse(http.StatusCreated, `{"Id":11}`)
+	})})
+
+	require.NoError(t, client.AddRecord(context.Background(), 1, RecordRequest{Type: RecordTypeMX, Value: "mail.example.com", TTL: 300, Priority: 10}))
+}
+
+func TestClientUpdateRecord(t *testing.T) {
+	client := NewClient("secret", &http.Client{Transport: roundTripFunc(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "/dnszone/1/records/10", req.URL.Path)
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"Id":10,"Type":0,"Name":"www","Value":"1.2.3.4","Ttl":60}`, string(body))
+		return jsonResponse(http.StatusNoContent, "")
+	})})
+
+	require.NoError(t, client.UpdateRecord(context.Background(), 1, 10, RecordRequest{Type: RecordTypeA, Name: "www", Value: "1.2.3.4", TTL: 60}))
+}
+
+func TestClientAPIError(t *testing.T) {
+	client := NewClient("invalid", &http.Client{Transport: roundTripFunc(func(req *http.Request) *http.Response {
+		return jsonResponse(http.StatusBadRequest, `{"ErrorKey":"dnszone.record.invalid","Field":"Value","Message":"The record value is invalid."}`)
+	})})
+
+	err := client.DeleteRecord(context.Background(), 1, 10)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.EqualError(t, err, "bunny: 400 Bad Request: dnszone.record.invalid: The record value is invalid.")
+
+	client = NewClient("invalid", &http.Client{Transport: roundTripFunc(func(req *http.Request) *http.Response {
+		return jsonResponse(http.StatusUnauthorized, "")
+	})})
+	_, err = client.ListZones(context.Background())
+	assert.EqualError(t, err, "bunny: 401 Unauthorized: ")
+}
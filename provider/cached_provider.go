@@ -96,6 +96,11 @@ func (c *CachedProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 	return c.Provider.ApplyChanges(ctx, changes)
 }
 
+// ZoneNamesByID returns the names of the zones of the cached provider by zone ID.
+func (c *CachedProvider) ZoneNamesByID(ctx context.Context) (map[string]string, error) {
+	return ZoneNamesByID(ctx, c.Provider)
+}
+
 func (c *CachedProvider) Reset() {
 	c.cache = nil
 	c.lastRead = time.Time{}
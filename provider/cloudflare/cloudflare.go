@@ -23,6 +23,7 @@ import (
 	"maps"
 	"net/http"
 	"os"
+	"regexp"
 	"slices"
 	"sort"
 	"strconv"
@@ -53,6 +54,11 @@ const (
 	// Cloudflare tier limitations https://developers.cloudflare.com/dns/manage-dns-records/reference/record-attributes/#availability
 	freeZoneMaxCommentLength = 100
 	paidZoneMaxCommentLength = 500
+
+	// dmarcPrefix is the label of the DMARC policy record of a domain
+	dmarcPrefix = "_dmarc."
+	// dmarcVersion is the tag a DMARC policy must start with
+	dmarcVersion = "v=DMARC1"
 )
 
 var changeActionNames = map[changeAction]string{
@@ -212,7 +218,7 @@ func (p *CloudFlareProvider) ZoneHasPaidPlan(hostname string) bool {
 		return false
 	}
 
-	zoneDetails, err := p.Client.ZoneDetails(context.Background(), zoneID)
+	zoneDetails, err := p.zoneClient(zoneID).ZoneDetails(context.Background(), zoneID)
 	if err != nil {
 		log.Errorf("Failed to get zone %s details %v", zone, err)
 		return false
@@ -226,13 +232,26 @@ type CloudFlareProvider struct {
 	provider.BaseProvider
 	Client cloudFlareDNS
 	// only consider hosted zones managing domains ending in this suffix
-	domainFilter          endpoint.DomainFilter
-	zoneIDFilter          provider.ZoneIDFilter
+	domainFilter endpoint.DomainFilter
+	zoneIDFilter provider.ZoneIDFilter
+	// only consider hosted zones with a name matching this regex, applied after listing the zones
+	zoneNameFilter        *regexp.Regexp
 	proxiedByDefault      bool
 	DryRun                bool
 	CustomHostnamesConfig CustomHostnamesConfig
 	DNSRecordsConfig      DNSRecordsConfig
 	RegionKey             string
+	LoadBalancerClient    cloudFlareLoadBalancer
+	LoadBalancersConfig   LoadBalancersConfig
+	WorkerRoutesClient    cloudFlareWorkerRoutes
+	WorkerRoutesConfig    WorkerRoutesConfig
+	PropagationConfig     PropagationConfig
+	// looks up the changed records when waiting for their propagation
+	resolver *dohResolver
+	// clients of the zones with their own API token, by zone name; the other zones are managed with Client
+	zoneClients map[string]cloudFlareDNS
+	// names of the listed zones, by zone ID
+	zoneNames map[string]string
 }
 
 // cloudFlareChange differentiates between ChangActions
@@ -272,20 +291,17 @@ func getCreateDNSRecordParam(cfc cloudFlareChange) cloudflare.CreateDNSRecordPar
 }
 
 // NewCloudFlareProvider initializes a new CloudFlare DNS based Provider.
-func NewCloudFlareProvider(domainFilter endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, proxiedByDefault bool, dryRun bool, regionKey string, customHostnamesConfig CustomHostnamesConfig, dnsRecordsConfig DNSRecordsConfig) (*CloudFlareProvider, error) {
+// The zones of zoneTokens are managed with their own API token, given by zone name, rather than the global one.
+func NewCloudFlareProvider(domainFilter endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, zoneNameFilter *regexp.Regexp, proxiedByDefault bool, dryRun bool, regionKey string, customHostnamesConfig CustomHostnamesConfig, dnsRecordsConfig DNSRecordsConfig, loadBalancersConfig LoadBalancersConfig, workerRoutesConfig WorkerRoutesConfig, propagationConfig PropagationConfig, zoneTokens map[string]string) (*CloudFlareProvider, error) {
 	// initialize via chosen auth method and returns new API object
 	var (
 		config *cloudflare.API
 		err    error
 	)
 	if os.Getenv("CF_API_TOKEN") != "" {
-		token := os.Getenv("CF_API_TOKEN")
-		if strings.HasPrefix(token, "file:") {
-			tokenBytes, err := os.ReadFile(strings.TrimPrefix(token, "file:"))
-			if err != nil {
-				return nil, fmt.Errorf("failed to read CF_API_TOKEN from file: %w", err)
-			}
-			token = strings.TrimSpace(string(tokenBytes))
+		token, err := readAPIToken(os.Getenv("CF_API_TOKEN"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CF_API_TOKEN from file: %w", err)
 		}
 		config, err = cloudflare.NewWithAPIToken(token)
 	} else {
@@ -295,18 +311,80 @@ func NewCloudFlareProvider(domainFilter endpoint.DomainFilter, zoneIDFilter prov
 		return nil, fmt.Errorf("failed to initialize cloudflare provider: %w", err)
 	}
 
+	zoneClients := make(map[string]cloudFlareDNS, len(zoneTokens))
+	for zone, token := range zoneTokens {
+		token, err := readAPIToken(token)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the API token of zone %s from file: %w", zone, err)
+		}
+		zoneConfig, err := cloudflare.NewWithAPIToken(token)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize cloudflare provider for zone %s: %w", zone, err)
+		}
+		zoneClients[normalizeZoneName(zone)] = zoneService{zoneConfig}
+	}
+
 	return &CloudFlareProvider{
 		Client:                zoneService{config},
 		domainFilter:          domainFilter,
 		zoneIDFilter:          zoneIDFilter,
+		zoneNameFilter:        zoneNameFilter,
 		proxiedByDefault:      proxiedByDefault,
 		CustomHostnamesConfig: customHostnamesConfig,
 		DryRun:                dryRun,
 		RegionKey:             regionKey,
 		DNSRecordsConfig:      dnsRecordsConfig,
+		LoadBalancerClient:    zoneService{config},
+		LoadBalancersConfig:   loadBalancersConfig,
+		WorkerRoutesClient:    zoneService{config},
+		WorkerRoutesConfig:    workerRoutesConfig,
+		PropagationConfig:     propagationConfig,
+		resolver:              newDoHResolver(),
+		zoneClients:           zoneClients,
 	}, nil
 }
 
+// readAPIToken returns the API token, which is read from a file when it is given as file:path.
+func readAPIToken(token string) (string, error) {
+	if !strings.HasPrefix(token, "file:") {
+		return token, nil
+	}
+	tokenBytes, err := os.ReadFile(strings.TrimPrefix(token, "file:"))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(tokenBytes)), nil
+}
+
+// normalizeZoneName returns the zone name the way Cloudflare returns it.
+func normalizeZoneName(zone string) string {
+	return strings.ToLower(strings.TrimSuffix(zone, "."))
+}
+
+// zoneClient returns the client of the zone with its own API token, or the global client for the other zones.
+func (p *CloudFlareProvider) zoneClient(zoneID string) cloudFlareDNS {
+	if client, ok := p.zoneClients[p.zoneNames[zoneID]]; ok {
+		return client
+	}
+	return p.Client
+}
+
+// addZoneName remembers the name of the zone, so that the zone is managed with its own client.
+func (p *CloudFlareProvider) addZoneName(zone cloudflare.Zone) {
+	if p.zoneNames == nil {
+		p.zoneNames = make(map[string]string)
+	}
+	p.zoneNames[zone.ID] = normalizeZoneName(zone.Name)
+}
+
+// matchZoneNameFilter returns true when the zone name matches the zone name filter, or when there is no filter.
+func (p *CloudFlareProvider) matchZoneNameFilter(zoneName string) bool {
+	if p.zoneNameFilter == nil || p.zoneNameFilter.String() == "" {
+		return true
+	}
+	return p.zoneNameFilter.MatchString(zoneName)
+}
+
 // Zones returns the list of hosted zones.
 func (p *CloudFlareProvider) Zones(ctx context.Context) ([]cloudflare.Zone, error) {
 	var result []cloudflare.Zone
@@ -322,10 +400,15 @@ func (p *CloudFlareProvider) Zones(ctx context.Context) ([]cloudflare.Zone, erro
 				log.Errorf("zone %q lookup failed, %v", zoneID, err)
 				return result, err
 			}
+			if !p.matchZoneNameFilter(detailResponse.Name) {
+				log.Debugf("zone %q not in zone name filter", detailResponse.Name)
+				continue
+			}
 			log.WithFields(log.Fields{
 				"zoneName": detailResponse.Name,
 				"zoneID":   detailResponse.ID,
 			}).Debugln("adding zone for consideration")
+			p.addZoneName(detailResponse)
 			result = append(result, detailResponse)
 		}
 		return result, nil
@@ -350,6 +433,11 @@ func (p *CloudFlareProvider) Zones(ctx context.Context) ([]cloudflare.Zone, erro
 			log.Debugf("zone %q not in domain filter", zone.Name)
 			continue
 		}
+		if !p.matchZoneNameFilter(zone.Name) {
+			log.Debugf("zone %q not in zone name filter", zone.Name)
+			continue
+		}
+		p.addZoneName(zone)
 		result = append(result, zone)
 	}
 
@@ -382,16 +470,34 @@ func (p *CloudFlareProvider) Records(ctx context.Context) ([]*endpoint.Endpoint,
 		endpoints = append(endpoints, groupByNameAndTypeWithCustomHostnames(records, chs)...)
 	}
 
+	// nil if load balancers are not enabled
+	loadBalancers, err := p.loadBalancerRecords(ctx, zones)
+	if err != nil {
+		return nil, err
+	}
+	endpoints = append(endpoints, loadBalancers...)
+
+	// no-op if worker routes are not enabled
+	if err := p.withWorkerRoutes(ctx, zones, endpoints); err != nil {
+		return nil, err
+	}
+
 	return endpoints, nil
 }
 
 // ApplyChanges applies a given set of changes in a given zone.
 func (p *CloudFlareProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
 	var cloudflareChanges []*cloudFlareChange
+	// Load Balancers are deleted before the records and created after them,
+	// as a hostname can't be used by both a Load Balancer and a DNS record.
+	var loadBalancerDeletions, loadBalancerChanges []*loadBalancerChange
 
 	// if custom hostnames are enabled, deleting first allows to avoid conflicts with the new ones
 	if p.CustomHostnamesConfig.Enabled {
 		for _, e := range changes.Delete {
+			if isLoadBalancer(e) {
+				continue
+			}
 			for _, target := range e.Targets {
 				cloudflareChanges = append(cloudflareChanges, p.newCloudFlareChange(cloudFlareDelete, e, target, nil))
 			}
@@ -399,6 +505,10 @@ func (p *CloudFlareProvider) ApplyChanges(ctx context.Context, changes *plan.Cha
 	}
 
 	for _, e := range changes.Create {
+		if isLoadBalancer(e) {
+			loadBalancerChanges = append(loadBalancerChanges, p.newLoadBalancerChange(cloudFlareCreate, e))
+			continue
+		}
 		for _, target := range e.Targets {
 			cloudflareChanges = append(cloudflareChanges, p.newCloudFlareChange(cloudFlareCreate, e, target, nil))
 		}
@@ -407,6 +517,26 @@ func (p *CloudFlareProvider) ApplyChanges(ctx context.Context, changes *plan.Cha
 	for i, desired := range changes.UpdateNew {
 		current := changes.UpdateOld[i]
 
+		switch {
+		case isLoadBalancer(current) && isLoadBalancer(desired):
+			loadBalancerChanges = append(loadBalancerChanges, p.newLoadBalancerChange(cloudFlareUpdate, desired))
+			continue
+		case isLoadBalancer(current):
+			// the Load Balancer is replaced by records
+			loadBalancerDeletions = append(loadBalancerDeletions, p.newLoadBalancerChange(cloudFlareDelete, current))
+			for _, a := range desired.Targets {
+				cloudflareChanges = append(cloudflareChanges, p.newCloudFlareChange(cloudFlareCreate, desired, a, current))
+			}
+			continue
+		case isLoadBalancer(desired):
+			// the records are replaced by a Load Balancer
+			for _, a := range current.Targets {
+				cloudflareChanges = append(cloudflareChanges, p.newCloudFlareChange(cloudFlareDelete, current, a, current))
+			}
+			loadBalancerChanges = append(loadBalancerChanges, p.newLoadBalancerChange(cloudFlareCreate, desired))
+			continue
+		}
+
 		add, remove, leave := provider.Difference(current.Targets, desired.Targets)
 
 		for _, a := range remove {
@@ -422,16 +552,40 @@ func (p *CloudFlareProvider) ApplyChanges(ctx context.Context, changes *plan.Cha
 		}
 	}
 
+	for _, e := range changes.Delete {
+		if isLoadBalancer(e) {
+			loadBalancerDeletions = append(loadBalancerDeletions, p.newLoadBalancerChange(cloudFlareDelete, e))
+		}
+	}
+
 	// TODO: consider deleting before creating even if custom hostnames are not in use
 	if !p.CustomHostnamesConfig.Enabled {
 		for _, e := range changes.Delete {
+			if isLoadBalancer(e) {
+				continue
+			}
 			for _, target := range e.Targets {
 				cloudflareChanges = append(cloudflareChanges, p.newCloudFlareChange(cloudFlareDelete, e, target, nil))
 			}
 		}
 	}
 
-	return p.submitChanges(ctx, cloudflareChanges)
+	if err := p.submitLoadBalancerChanges(ctx, loadBalancerDeletions); err != nil {
+		return err
+	}
+	if len(cloudflareChanges) > 0 || len(loadBalancerDeletions)+len(loadBalancerChanges) == 0 {
+		if err := p.submitChanges(ctx, cloudflareChanges); err != nil {
+			return err
+		}
+	}
+	if err := p.submitLoadBalancerChanges(ctx, loadBalancerChanges); err != nil {
+		return err
+	}
+	// the routes are changed once the records of their hostnames are
+	if !p.WorkerRoutesConfig.Enabled {
+		return nil
+	}
+	return p.submitWorkerRouteChanges(ctx, newWorkerRouteChanges(changes))
 }
 
 // submitCustomHostnameChanges implements Custom Hostname functionality for the Change, returns false if it fails
@@ -452,7 +606,7 @@ func (p *CloudFlareProvider) submitCustomHostnameChanges(ctx context.Context, zo
 					prevChID := prevCh.ID
 					if prevChID != "" {
 						log.WithFields(logFields).Infof("Removing previous custom hostname %q/%q", prevChID, changeCH)
-						chErr := p.Client.DeleteCustomHostname(ctx, zoneID, prevChID)
+						chErr := p.zoneClient(zoneID).DeleteCustomHostname(ctx, zoneID, prevChID)
 						if chErr != nil {
 							failedChange = true
 							log.WithFields(logFields).Errorf("failed to remove previous custom hostname %q/%q: %v", prevChID, changeCH, chErr)
@@ -462,7 +616,7 @@ func (p *CloudFlareProvider) submitCustomHostnameChanges(ctx context.Context, zo
 			}
 			for _, changeCH := range add {
 				log.WithFields(logFields).Infof("Adding custom hostname %q", changeCH)
-				_, chErr := p.Client.CreateCustomHostname(ctx, zoneID, change.CustomHostnames[changeCH])
+				_, chErr := p.zoneClient(zoneID).CreateCustomHostname(ctx, zoneID, change.CustomHostnames[changeCH])
 				if chErr != nil {
 					failedChange = true
 					log.WithFields(logFields).Errorf("failed to add custom hostname %q: %v", changeCH, chErr)
@@ -475,7 +629,7 @@ func (p *CloudFlareProvider) submitCustomHostnameChanges(ctx context.Context, zo
 				log.WithFields(logFields).Infof("Deleting custom hostname %q", changeCH.Hostname)
 				if ch, err := getCustomHostname(chs, changeCH.Hostname); err == nil {
 					chID := ch.ID
-					chErr := p.Client.DeleteCustomHostname(ctx, zoneID, chID)
+					chErr := p.zoneClient(zoneID).DeleteCustomHostname(ctx, zoneID, chID)
 					if chErr != nil {
 						failedChange = true
 						log.WithFields(logFields).Errorf("failed to delete custom hostname %q/%q: %v", chID, changeCH.Hostname, chErr)
@@ -497,7 +651,7 @@ func (p *CloudFlareProvider) submitCustomHostnameChanges(ctx context.Context, zo
 						log.WithFields(logFields).Errorf("failed to create custom hostname, %q already exists with origin %q", changeCH.Hostname, ch.CustomOriginServer)
 					}
 				} else {
-					_, chErr := p.Client.CreateCustomHostname(ctx, zoneID, changeCH)
+					_, chErr := p.zoneClient(zoneID).CreateCustomHostname(ctx, zoneID, changeCH)
 					if chErr != nil {
 						failedChange = true
 						log.WithFields(logFields).Errorf("failed to create custom hostname %q: %v", changeCH.Hostname, chErr)
@@ -528,6 +682,7 @@ func (p *CloudFlareProvider) submitChanges(ctx context.Context, changes []*cloud
 	for zoneID, zoneChanges := range changesByZone {
 		var failedChange bool
 		resourceContainer := cloudflare.ZoneIdentifier(zoneID)
+		client := p.zoneClient(zoneID)
 
 		for _, change := range zoneChanges {
 			logFields := log.Fields{
@@ -563,7 +718,7 @@ func (p *CloudFlareProvider) submitChanges(ctx context.Context, changes []*cloud
 				}
 				recordParam := updateDNSRecordParam(*change)
 				recordParam.ID = recordID
-				err := p.Client.UpdateDNSRecord(ctx, resourceContainer, recordParam)
+				err := client.UpdateDNSRecord(ctx, resourceContainer, recordParam)
 				if err != nil {
 					failedChange = true
 					log.WithFields(logFields).Errorf("failed to update record: %v", err)
@@ -574,7 +729,7 @@ func (p *CloudFlareProvider) submitChanges(ctx context.Context, changes []*cloud
 					log.WithFields(logFields).Errorf("failed to find previous record: %v", change.ResourceRecord)
 					continue
 				}
-				err := p.Client.DeleteDNSRecord(ctx, resourceContainer, recordID)
+				err := client.DeleteDNSRecord(ctx, resourceContainer, recordID)
 				if err != nil {
 					failedChange = true
 					log.WithFields(logFields).Errorf("failed to delete record: %v", err)
@@ -584,7 +739,7 @@ func (p *CloudFlareProvider) submitChanges(ctx context.Context, changes []*cloud
 				}
 			} else if change.Action == cloudFlareCreate {
 				recordParam := getCreateDNSRecordParam(*change)
-				_, err := p.Client.CreateDNSRecord(ctx, resourceContainer, recordParam)
+				_, err := client.CreateDNSRecord(ctx, resourceContainer, recordParam)
 				if err != nil {
 					failedChange = true
 					log.WithFields(logFields).Errorf("failed to create record: %v", err)
@@ -616,13 +771,17 @@ func (p *CloudFlareProvider) submitChanges(ctx context.Context, changes []*cloud
 		return fmt.Errorf("failed to submit all changes for the following zones: %q", failedZones)
 	}
 
+	if p.PropagationConfig.Enabled && !p.DryRun {
+		return p.waitForPropagation(ctx, changesByZone)
+	}
+
 	return nil
 }
 
 // AdjustEndpoints modifies the endpoints as needed by the specific provider
 func (p *CloudFlareProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
 	var adjustedEndpoints []*endpoint.Endpoint
-	for _, e := range endpoints {
+	for _, e := range withDMARCEndpoints(endpoints) {
 		proxied := shouldBeProxied(e, p.proxiedByDefault)
 		if proxied {
 			e.RecordTTL = 0
@@ -640,11 +799,74 @@ func (p *CloudFlareProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]
 			e.DeleteProviderSpecificProperty(annotations.CloudflareCustomHostnameKey)
 		}
 
+		if p.LoadBalancersConfig.Enabled && isLoadBalancer(e) {
+			e.SetProviderSpecificProperty(annotations.CloudflareLoadBalancerKey, "true")
+		} else {
+			// ignore load balancer annotations if not enabled
+			e.DeleteProviderSpecificProperty(annotations.CloudflareLoadBalancerKey)
+		}
+
+		if p.WorkerRoutesConfig.Enabled && isWorkerRouteRecordType(e.RecordType) && workerRouteScript(e) != "" {
+			e.SetProviderSpecificProperty(annotations.CloudflareWorkerRouteKey, workerRouteScript(e))
+		} else {
+			// ignore worker route annotations if not enabled, or of records not serving the traffic of the hostname
+			e.DeleteProviderSpecificProperty(annotations.CloudflareWorkerRouteKey)
+		}
+
 		adjustedEndpoints = append(adjustedEndpoints, e)
 	}
 	return adjustedEndpoints, nil
 }
 
+// withDMARCEndpoints returns the endpoints with a "_dmarc." TXT endpoint holding the policy of each endpoint annotated with cloudflare-dmarc.
+// Invalid policies are skipped, as well as the DMARC records already defined by a TXT endpoint.
+func withDMARCEndpoints(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	txtNames := map[string]bool{}
+	for _, e := range endpoints {
+		if e.RecordType == endpoint.RecordTypeTXT {
+			txtNames[e.DNSName] = true
+		}
+	}
+
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	result = append(result, endpoints...)
+	for _, e := range endpoints {
+		policy, ok := e.GetProviderSpecificProperty(annotations.CloudflareDMARCKey)
+		if !ok {
+			continue
+		}
+		// the policy is published as a separate record, it is not a property of the endpoint
+		e.DeleteProviderSpecificProperty(annotations.CloudflareDMARCKey)
+
+		if err := validateDMARCPolicy(policy); err != nil {
+			log.Warnf("Skipping DMARC record of %s: %v", e.DNSName, err)
+			continue
+		}
+
+		name := dmarcPrefix + e.DNSName
+		if txtNames[name] {
+			continue
+		}
+		txtNames[name] = true
+
+		dmarc := endpoint.NewEndpointWithTTL(name, endpoint.RecordTypeTXT, e.RecordTTL, policy)
+		for k, v := range e.Labels {
+			dmarc.Labels[k] = v
+		}
+		result = append(result, dmarc)
+	}
+	return result
+}
+
+// validateDMARCPolicy returns an error if the first tag of the policy is not the DMARC version, see RFC 7489 section 6.4.
+func validateDMARCPolicy(policy string) error {
+	version, _, _ := strings.Cut(policy, ";")
+	if strings.Join(strings.Fields(version), "") != dmarcVersion {
+		return fmt.Errorf("invalid DMARC policy %q, it must start with %q", policy, dmarcVersion)
+	}
+	return nil
+}
+
 // changesByZone separates a multi-zone change into a single change per zone.
 func (p *CloudFlareProvider) changesByZone(zones []cloudflare.Zone, changeSet []*cloudFlareChange) map[string][]*cloudFlareChange {
 	changes := make(map[string][]*cloudFlareChange)
@@ -751,7 +973,7 @@ func (p *CloudFlareProvider) listDNSRecordsWithAutoPagination(ctx context.Contex
 	resultInfo := cloudflare.ResultInfo{PerPage: p.DNSRecordsConfig.PerPage, Page: 1}
 	params := cloudflare.ListDNSRecordsParams{ResultInfo: resultInfo}
 	for {
-		pageRecords, resultInfo, err := p.Client.ListDNSRecords(ctx, cloudflare.ZoneIdentifier(zoneID), params)
+		pageRecords, resultInfo, err := p.zoneClient(zoneID).ListDNSRecords(ctx, cloudflare.ZoneIdentifier(zoneID), params)
 		if err != nil {
 			var apiErr *cloudflare.Error
 			if errors.As(err, &apiErr) {
@@ -786,7 +1008,7 @@ func (p *CloudFlareProvider) listCustomHostnamesWithPagination(ctx context.Conte
 	chs := make(CustomHostnamesMap)
 	resultInfo := cloudflare.ResultInfo{Page: 1}
 	for {
-		pageCustomHostnameListResponse, result, err := p.Client.CustomHostnames(ctx, zoneID, resultInfo.Page, cloudflare.CustomHostname{})
+		pageCustomHostnameListResponse, result, err := p.zoneClient(zoneID).CustomHostnames(ctx, zoneID, resultInfo.Page, cloudflare.CustomHostname{})
 		if err != nil {
 			var apiErr *cloudflare.Error
 			if errors.As(err, &apiErr) {
@@ -0,0 +1,359 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+// loadBalancerPoolPrefix is the prefix of the names of the pools managed by external-dns.
+const loadBalancerPoolPrefix = "external-dns-"
+
+type LoadBalancersConfig struct {
+	Enabled bool
+}
+
+// cloudFlareLoadBalancer is the subset of the CloudFlare Load Balancing API that we actually use. Signatures must match exactly.
+type cloudFlareLoadBalancer interface {
+	ListLoadBalancers(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListLoadBalancerParams) ([]cloudflare.LoadBalancer, error)
+	CreateLoadBalancer(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.CreateLoadBalancerParams) (cloudflare.LoadBalancer, error)
+	UpdateLoadBalancer(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.UpdateLoadBalancerParams) (cloudflare.LoadBalancer, error)
+	DeleteLoadBalancer(ctx context.Context, rc *cloudflare.ResourceContainer, loadBalancerID string) error
+	ListLoadBalancerPools(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListLoadBalancerPoolParams) ([]cloudflare.LoadBalancerPool, error)
+	CreateLoadBalancerPool(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.CreateLoadBalancerPoolParams) (cloudflare.LoadBalancerPool, error)
+	UpdateLoadBalancerPool(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.UpdateLoadBalancerPoolParams) (cloudflare.LoadBalancerPool, error)
+	DeleteLoadBalancerPool(ctx context.Context, rc *cloudflare.ResourceContainer, poolID string) error
+}
+
+func (z zoneService) ListLoadBalancers(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListLoadBalancerParams) ([]cloudflare.LoadBalancer, error) {
+	return z.service.ListLoadBalancers(ctx, rc, params)
+}
+
+func (z zoneService) CreateLoadBalancer(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.CreateLoadBalancerParams) (cloudflare.LoadBalancer, error) {
+	return z.service.CreateLoadBalancer(ctx, rc, params)
+}
+
+func (z zoneService) UpdateLoadBalancer(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.UpdateLoadBalancerParams) (cloudflare.LoadBalancer, error) {
+	return z.service.UpdateLoadBalancer(ctx, rc, params)
+}
+
+func (z zoneService) DeleteLoadBalancer(ctx context.Context, rc *cloudflare.ResourceContainer, loadBalancerID string) error {
+	return z.service.DeleteLoadBalancer(ctx, rc, loadBalancerID)
+}
+
+func (z zoneService) ListLoadBalancerPools(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListLoadBalancerPoolParams) ([]cloudflare.LoadBalancerPool, error) {
+	return z.service.ListLoadBalancerPools(ctx, rc, params)
+}
+
+func (z zoneService) CreateLoadBalancerPool(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.CreateLoadBalancerPoolParams) (cloudflare.LoadBalancerPool, error) {
+	return z.service.CreateLoadBalancerPool(ctx, rc, params)
+}
+
+func (z zoneService) UpdateLoadBalancerPool(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.UpdateLoadBalancerPoolParams) (cloudflare.LoadBalancerPool, error) {
+	return z.service.UpdateLoadBalancerPool(ctx, rc, params)
+}
+
+func (z zoneService) DeleteLoadBalancerPool(ctx context.Context, rc *cloudflare.ResourceContainer, poolID string) error {
+	return z.service.DeleteLoadBalancerPool(ctx, rc, poolID)
+}
+
+// loadBalancerChange is a change of a Load Balancer and of its default pool, the targets are the origins of the pool.
+type loadBalancerChange struct {
+	Action  changeAction
+	Name    string
+	TTL     int
+	Proxied bool
+	Targets endpoint.Targets
+}
+
+// isLoadBalancer returns true when the endpoint must be published as a Cloudflare Load Balancer.
+func isLoadBalancer(ep *endpoint.Endpoint) bool {
+	value, ok := ep.GetProviderSpecificProperty(annotations.CloudflareLoadBalancerKey)
+	if !ok {
+		return false
+	}
+	enabled, err := strconv.ParseBool(value)
+	return err == nil && enabled
+}
+
+func (p *CloudFlareProvider) newLoadBalancerChange(action changeAction, ep *endpoint.Endpoint) *loadBalancerChange {
+	ttl := 0
+	if ep.RecordTTL.IsConfigured() {
+		ttl = int(ep.RecordTTL)
+	}
+	return &loadBalancerChange{
+		Action:  action,
+		Name:    ep.DNSName,
+		TTL:     ttl,
+		Proxied: shouldBeProxied(ep, p.proxiedByDefault),
+		Targets: ep.Targets,
+	}
+}
+
+// loadBalancerPoolName returns the name of the pool of a Load Balancer, pool names only allow alphanumeric characters, hyphens and underscores.
+func loadBalancerPoolName(name string) string {
+	return loadBalancerPoolPrefix + strings.NewReplacer(".", "_", "*", "wildcard").Replace(name)
+}
+
+// loadBalancerOrigins returns an enabled origin of equal weight for each target.
+func loadBalancerOrigins(targets endpoint.Targets) []cloudflare.LoadBalancerOrigin {
+	origins := make([]cloudflare.LoadBalancerOrigin, 0, len(targets))
+	for _, target := range targets {
+		origins = append(origins, cloudflare.LoadBalancerOrigin{
+			Name:    target,
+			Address: target,
+			Enabled: true,
+			Weight:  1,
+		})
+	}
+	return origins
+}
+
+// loadBalancerRecordType returns the record type the source would have used for the origins of a pool.
+func loadBalancerRecordType(targets endpoint.Targets) string {
+	ip := net.ParseIP(targets[0])
+	switch {
+	case ip == nil:
+		return endpoint.RecordTypeCNAME
+	case ip.To4() != nil:
+		return endpoint.RecordTypeA
+	default:
+		return endpoint.RecordTypeAAAA
+	}
+}
+
+// convertCloudflareError returns rate limits and server errors of the Cloudflare API as soft errors.
+func convertCloudflareError(err error) error {
+	var apiErr *cloudflare.Error
+	if errors.As(err, &apiErr) {
+		if apiErr.ClientRateLimited() || apiErr.StatusCode >= http.StatusInternalServerError {
+			return provider.NewSoftError(err)
+		}
+	}
+	return err
+}
+
+// listLoadBalancerPools returns the pools of an account indexed by ID.
+func (p *CloudFlareProvider) listLoadBalancerPools(ctx context.Context, accountID string) (map[string]cloudflare.LoadBalancerPool, error) {
+	pools, err := p.LoadBalancerClient.ListLoadBalancerPools(ctx, cloudflare.AccountIdentifier(accountID), cloudflare.ListLoadBalancerPoolParams{})
+	if err != nil {
+		return nil, convertCloudflareError(err)
+	}
+	poolsByID := make(map[string]cloudflare.LoadBalancerPool, len(pools))
+	for _, pool := range pools {
+		poolsByID[pool.ID] = pool
+	}
+	return poolsByID, nil
+}
+
+// loadBalancerRecords returns an endpoint for each Load Balancer of the zones, the targets are the origins of its first default pool.
+func (p *CloudFlareProvider) loadBalancerRecords(ctx context.Context, zones []cloudflare.Zone) ([]*endpoint.Endpoint, error) {
+	if !p.LoadBalancersConfig.Enabled {
+		return nil, nil
+	}
+
+	var endpoints []*endpoint.Endpoint
+	poolsByAccount := map[string]map[string]cloudflare.LoadBalancerPool{}
+	for _, zone := range zones {
+		loadBalancers, err := p.LoadBalancerClient.ListLoadBalancers(ctx, cloudflare.ZoneIdentifier(zone.ID), cloudflare.ListLoadBalancerParams{})
+		if err != nil {
+			return nil, convertCloudflareError(err)
+		}
+		if len(loadBalancers) == 0 {
+			continue
+		}
+
+		pools, ok := poolsByAccount[zone.Account.ID]
+		if !ok {
+			pools, err = p.listLoadBalancerPools(ctx, zone.Account.ID)
+			if err != nil {
+				return nil, err
+			}
+			poolsByAccount[zone.Account.ID] = pools
+		}
+
+		for _, lb := range loadBalancers {
+			if len(lb.DefaultPools) == 0 {
+				continue
+			}
+			pool, ok := pools[lb.DefaultPools[0]]
+			if !ok || len(pool.Origins) == 0 {
+				log.Debugf("Skipping load balancer %q without origins", lb.Name)
+				continue
+			}
+			targets := make(endpoint.Targets, 0, len(pool.Origins))
+			for _, origin := range pool.Origins {
+				targets = append(targets, origin.Address)
+			}
+			e := endpoint.NewEndpointWithTTL(lb.Name, loadBalancerRecordType(targets), endpoint.TTL(lb.TTL), targets...)
+			if e == nil {
+				continue
+			}
+			e = e.WithProviderSpecific(annotations.CloudflareProxiedKey, strconv.FormatBool(lb.Proxied)).
+				WithProviderSpecific(annotations.CloudflareLoadBalancerKey, "true")
+			endpoints = append(endpoints, e)
+		}
+	}
+	return endpoints, nil
+}
+
+// submitLoadBalancerChanges applies a set of Load Balancer changes, the pools are account scoped
+// so the account of the zone of each Load Balancer is used for its pool.
+func (p *CloudFlareProvider) submitLoadBalancerChanges(ctx context.Context, changes []*loadBalancerChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return err
+	}
+	zonesByID := map[string]cloudflare.Zone{}
+	zoneNameIDMapper := provider.ZoneIDName{}
+	for _, z := range zones {
+		zonesByID[z.ID] = z
+		zoneNameIDMapper.Add(z.ID, z.Name)
+	}
+
+	var failedZones []string
+	for _, change := range changes {
+		zoneID, _ := zoneNameIDMapper.FindZone(change.Name)
+		if zoneID == "" {
+			log.Debugf("Skipping load balancer %q because no hosted zone matching record DNS Name was detected", change.Name)
+			continue
+		}
+
+		logFields := log.Fields{
+			"loadBalancer": change.Name,
+			"origins":      change.Targets,
+			"action":       change.Action,
+			"zone":         zoneID,
+		}
+		log.WithFields(logFields).Info("Changing load balancer.")
+
+		if p.DryRun {
+			continue
+		}
+
+		if err := p.submitLoadBalancerChange(ctx, zonesByID[zoneID], change); err != nil {
+			log.WithFields(logFields).Errorf("failed to %s load balancer: %v", strings.ToLower(change.Action.String()), err)
+			failedZones = append(failedZones, zoneID)
+		}
+	}
+
+	if len(failedZones) > 0 {
+		return fmt.Errorf("failed to submit all load balancer changes for the following zones: %q", failedZones)
+	}
+	return nil
+}
+
+func (p *CloudFlareProvider) submitLoadBalancerChange(ctx context.Context, zone cloudflare.Zone, change *loadBalancerChange) error {
+	zoneContainer := cloudflare.ZoneIdentifier(zone.ID)
+	accountContainer := cloudflare.AccountIdentifier(zone.Account.ID)
+
+	loadBalancers, err := p.LoadBalancerClient.ListLoadBalancers(ctx, zoneContainer, cloudflare.ListLoadBalancerParams{})
+	if err != nil {
+		return err
+	}
+	var current *cloudflare.LoadBalancer
+	for i := range loadBalancers {
+		if loadBalancers[i].Name == change.Name {
+			current = &loadBalancers[i]
+			break
+		}
+	}
+
+	pools, err := p.LoadBalancerClient.ListLoadBalancerPools(ctx, accountContainer, cloudflare.ListLoadBalancerPoolParams{})
+	if err != nil {
+		return err
+	}
+	poolName := loadBalancerPoolName(change.Name)
+	var pool *cloudflare.LoadBalancerPool
+	for i := range pools {
+		if pools[i].Name == poolName {
+			pool = &pools[i]
+			break
+		}
+	}
+
+	if change.Action == cloudFlareDelete {
+		if current != nil {
+			if err := p.LoadBalancerClient.DeleteLoadBalancer(ctx, zoneContainer, current.ID); err != nil {
+				return err
+			}
+		}
+		// only the pool created by external-dns is deleted, pools added by hand to the Load Balancer are kept
+		if pool != nil {
+			return p.LoadBalancerClient.DeleteLoadBalancerPool(ctx, accountContainer, pool.ID)
+		}
+		return nil
+	}
+
+	// the pool is left behind when the Load Balancer could not be created, it is reused on the next synchronization
+	if pool == nil {
+		created, err := p.LoadBalancerClient.CreateLoadBalancerPool(ctx, accountContainer, cloudflare.CreateLoadBalancerPoolParams{
+			LoadBalancerPool: cloudflare.LoadBalancerPool{
+				Name:    poolName,
+				Enabled: true,
+				Origins: loadBalancerOrigins(change.Targets),
+			},
+		})
+		if err != nil {
+			return err
+		}
+		pool = &created
+	} else {
+		pool.Enabled = true
+		pool.Origins = loadBalancerOrigins(change.Targets)
+		if _, err := p.LoadBalancerClient.UpdateLoadBalancerPool(ctx, accountContainer, cloudflare.UpdateLoadBalancerPoolParams{LoadBalancer: *pool}); err != nil {
+			return err
+		}
+	}
+
+	if current == nil {
+		_, err := p.LoadBalancerClient.CreateLoadBalancer(ctx, zoneContainer, cloudflare.CreateLoadBalancerParams{
+			LoadBalancer: cloudflare.LoadBalancer{
+				Name:         change.Name,
+				TTL:          change.TTL,
+				Proxied:      change.Proxied,
+				DefaultPools: []string{pool.ID},
+				FallbackPool: pool.ID,
+			},
+		})
+		return err
+	}
+
+	current.TTL = change.TTL
+	current.Proxied = change.Proxied
+	current.DefaultPools = []string{pool.ID}
+	current.FallbackPool = pool.ID
+	_, err = p.LoadBalancerClient.UpdateLoadBalancer(ctx, zoneContainer, cloudflare.UpdateLoadBalancerParams{LoadBalancer: *current})
+	return err
+}
@@ -0,0 +1,296 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+type mockCloudFlareLoadBalancerClient struct {
+	LoadBalancers map[string][]cloudflare.LoadBalancer
+	Pools         []cloudflare.LoadBalancerPool
+	Actions       []string
+	nextID        int
+	listError     error
+	createError   error
+}
+
+func newMockCloudFlareLoadBalancerClient() *mockCloudFlareLoadBalancerClient {
+	return &mockCloudFlareLoadBalancerClient{
+		LoadBalancers: map[string][]cloudflare.LoadBalancer{},
+	}
+}
+
+func (m *mockCloudFlareLoadBalancerClient) newID() string {
+	m.nextID++
+	return fmt.Sprintf("id-%d", m.nextID)
+}
+
+func (m *mockCloudFlareLoadBalancerClient) ListLoadBalancers(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListLoadBalancerParams) ([]cloudflare.LoadBalancer, error) {
+	if m.listError != nil {
+		return nil, m.listError
+	}
+	return m.LoadBalancers[rc.Identifier], nil
+}
+
+func (m *mockCloudFlareLoadBalancerClient) CreateLoadBalancer(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.CreateLoadBalancerParams) (cloudflare.LoadBalancer, error) {
+	if m.createError != nil {
+		return cloudflare.LoadBalancer{}, m.createError
+	}
+	lb := params.LoadBalancer
+	lb.ID = m.newID()
+	m.LoadBalancers[rc.Identifier] = append(m.LoadBalancers[rc.Identifier], lb)
+	m.Actions = append(m.Actions, "CreateLoadBalancer "+lb.Name)
+	return lb, nil
+}
+
+func (m *mockCloudFlareLoadBalancerClient) UpdateLoadBalancer(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.UpdateLoadBalancerParams) (cloudflare.LoadBalancer, error) {
+	for i, lb := range m.LoadBalancers[rc.Identifier] {
+		if lb.ID == params.LoadBalancer.ID {
+			m.LoadBalancers[rc.Identifier][i] = params.LoadBalancer
+			m.Actions = append(m.Actions, "UpdateLoadBalancer "+lb.Name)
+			return params.LoadBalancer, nil
+		}
+	}
+	return cloudflare.LoadBalancer{}, errors.New("load balancer not found")
+}
+
+func (m *mockCloudFlareLoadBalancerClient) DeleteLoadBalancer(ctx context.Context, rc *cloudflare.ResourceContainer, loadBalancerID string) error {
+	for i, lb := range m.LoadBalancers[rc.Identifier] {
+		if lb.ID == loadBalancerID {
+			m.LoadBalancers[rc.Identifier] = append(m.LoadBalancers[rc.Identifier][:i], m.LoadBalancers[rc.Identifier][i+1:]...)
+			m.Actions = append(m.Actions, "DeleteLoadBalancer "+lb.Name)
+			return nil
+		}
+	}
+	return errors.New("load balancer not found")
+}
+
+func (m *mockCloudFlareLoadBalancerClient) ListLoadBalancerPools(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListLoadBalancerPoolParams) ([]cloudflare.LoadBalancerPool, error) {
+	if m.listError != nil {
+		return nil, m.listError
+	}
+	return m.Pools, nil
+}
+
+func (m *mockCloudFlareLoadBalancerClient) CreateLoadBalancerPool(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.CreateLoadBalancerPoolParams) (cloudflare.LoadBalancerPool, error) {
+	pool := params.LoadBalancerPool
+	pool.ID = m.newID()
+	m.Pools = append(m.Pools, pool)
+	m.Actions = append(m.Actions, "CreateLoadBalancerPool "+pool.Name)
+	return pool, nil
+}
+
+func (m *mockCloudFlareLoadBalancerClient) UpdateLoadBalancerPool(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.UpdateLoadBalancerPoolParams) (cloudflare.LoadBalancerPool, error) {
+	for i, pool := range m.Pools {
+		if pool.ID == params.LoadBalancer.ID {
+			m.Pools[i] = params.LoadBalancer
+			m.Actions = append(m.Actions, "UpdateLoadBalancerPool "+pool.Name)
+			return params.LoadBalancer, nil
+		}
+	}
+	return cloudflare.LoadBalancerPool{}, errors.New("pool not found")
+}
+
+func (m *mockCloudFlareLoadBalancerClient) DeleteLoadBalancerPool(ctx context.Context, rc *cloudflare.ResourceContainer, poolID string) error {
+	for i, pool := range m.Pools {
+		if pool.ID == poolID {
+			m.Pools = append(m.Pools[:i], m.Pools[i+1:]...)
+			m.Actions = append(m.Actions, "DeleteLoadBalancerPool "+pool.Name)
+			return nil
+		}
+	}
+	return errors.New("pool not found")
+}
+
+func newLoadBalancerProvider(enabled bool) (*CloudFlareProvider, *mockCloudFlareClient, *mockCloudFlareLoadBalancerClient) {
+	client := NewMockCloudFlareClient()
+	lbClient := newMockCloudFlareLoadBalancerClient()
+	return &CloudFlareProvider{
+		Client:              client,
+		LoadBalancerClient:  lbClient,
+		LoadBalancersConfig: LoadBalancersConfig{Enabled: enabled},
+		domainFilter:        endpoint.NewDomainFilter([]string{"bar.com"}),
+	}, client, lbClient
+}
+
+// syncLoadBalancers plans and applies the desired endpoints against the records of the provider.
+func syncLoadBalancers(t *testing.T, p *CloudFlareProvider, desired []*endpoint.Endpoint) *plan.Changes {
+	t.Helper()
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+
+	desired, err = p.AdjustEndpoints(desired)
+	require.NoError(t, err)
+
+	changes := (&plan.Plan{
+		Current:        records,
+		Desired:        desired,
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
+	}).Calculate().Changes
+
+	require.NoError(t, p.ApplyChanges(context.Background(), changes))
+	return changes
+}
+
+func loadBalancerEndpoint(name string, targets ...string) *endpoint.Endpoint {
+	return endpoint.NewEndpoint(name, endpoint.RecordTypeA, targets...).
+		WithProviderSpecific(annotations.CloudflareLoadBalancerKey, "true")
+}
+
+func TestCloudflareLoadBalancerLifecycle(t *testing.T) {
+	p, client, lbClient := newLoadBalancerProvider(true)
+
+	syncLoadBalancers(t, p, []*endpoint.Endpoint{loadBalancerEndpoint("lb.bar.com", "1.2.3.4", "1.2.3.5")})
+
+	assert.Empty(t, client.Actions, "no DNS record should be created")
+	assert.Equal(t, []string{
+		"CreateLoadBalancerPool external-dns-lb_bar_com",
+		"CreateLoadBalancer lb.bar.com",
+	}, lbClient.Actions)
+	require.Len(t, lbClient.Pools, 1)
+	pool := lbClient.Pools[0]
+	assert.Equal(t, []cloudflare.LoadBalancerOrigin{
+		{Name: "1.2.3.4", Address: "1.2.3.4", Enabled: true, Weight: 1},
+		{Name: "1.2.3.5", Address: "1.2.3.5", Enabled: true, Weight: 1},
+	}, pool.Origins)
+	require.Len(t, lbClient.LoadBalancers["001"], 1)
+	lb := lbClient.LoadBalancers["001"][0]
+	assert.Equal(t, []string{pool.ID}, lb.DefaultPools)
+	assert.Equal(t, pool.ID, lb.FallbackPool)
+	assert.False(t, lb.Proxied)
+
+	// the load balancer is up to date
+	lbClient.Actions = nil
+	changes := syncLoadBalancers(t, p, []*endpoint.Endpoint{loadBalancerEndpoint("lb.bar.com", "1.2.3.5", "1.2.3.4")})
+	assert.False(t, changes.HasChanges())
+	assert.Empty(t, lbClient.Actions)
+
+	// the origins of the pool follow the targets
+	syncLoadBalancers(t, p, []*endpoint.Endpoint{loadBalancerEndpoint("lb.bar.com", "1.2.3.6")})
+	assert.Equal(t, []string{
+		"UpdateLoadBalancerPool external-dns-lb_bar_com",
+		"UpdateLoadBalancer lb.bar.com",
+	}, lbClient.Actions)
+	assert.Equal(t, []cloudflare.LoadBalancerOrigin{
+		{Name: "1.2.3.6", Address: "1.2.3.6", Enabled: true, Weight: 1},
+	}, lbClient.Pools[0].Origins)
+
+	// the load balancer and its pool are deleted with the endpoint
+	lbClient.Actions = nil
+	syncLoadBalancers(t, p, []*endpoint.Endpoint{})
+	assert.Equal(t, []string{
+		"DeleteLoadBalancer lb.bar.com",
+		"DeleteLoadBalancerPool external-dns-lb_bar_com",
+	}, lbClient.Actions)
+	assert.Empty(t, lbClient.Pools)
+	assert.Empty(t, lbClient.LoadBalancers["001"])
+	assert.Empty(t, client.Actions)
+}
+
+func TestCloudflareLoadBalancerReplacesRecords(t *testing.T) {
+	p, client, lbClient := newLoadBalancerProvider(true)
+
+	syncLoadBalancers(t, p, []*endpoint.Endpoint{endpoint.NewEndpoint("lb.bar.com", endpoint.RecordTypeA, "1.2.3.4")})
+	require.Len(t, client.Actions, 1)
+	assert.Equal(t, "Create", client.Actions[0].Name)
+	assert.Empty(t, lbClient.Actions)
+
+	client.Actions = nil
+	syncLoadBalancers(t, p, []*endpoint.Endpoint{loadBalancerEndpoint("lb.bar.com", "1.2.3.4")})
+	require.Len(t, client.Actions, 1)
+	assert.Equal(t, "Delete", client.Actions[0].Name)
+	assert.Equal(t, []string{
+		"CreateLoadBalancerPool external-dns-lb_bar_com",
+		"CreateLoadBalancer lb.bar.com",
+	}, lbClient.Actions)
+
+	client.Actions = nil
+	lbClient.Actions = nil
+	syncLoadBalancers(t, p, []*endpoint.Endpoint{endpoint.NewEndpoint("lb.bar.com", endpoint.RecordTypeA, "1.2.3.4")})
+	assert.Equal(t, []string{
+		"DeleteLoadBalancer lb.bar.com",
+		"DeleteLoadBalancerPool external-dns-lb_bar_com",
+	}, lbClient.Actions)
+	require.Len(t, client.Actions, 1)
+	assert.Equal(t, "Create", client.Actions[0].Name)
+}
+
+func TestCloudflareLoadBalancerProxied(t *testing.T) {
+	p, _, lbClient := newLoadBalancerProvider(true)
+
+	syncLoadBalancers(t, p, []*endpoint.Endpoint{
+		loadBalancerEndpoint("lb.bar.com", "origin.example.com").WithProviderSpecific(annotations.CloudflareProxiedKey, "true"),
+	})
+
+	require.Len(t, lbClient.LoadBalancers["001"], 1)
+	assert.True(t, lbClient.LoadBalancers["001"][0].Proxied)
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, endpoint.RecordTypeCNAME, records[0].RecordType)
+	assert.Equal(t, endpoint.Targets{"origin.example.com"}, records[0].Targets)
+}
+
+func TestCloudflareLoadBalancerDisabled(t *testing.T) {
+	p, client, lbClient := newLoadBalancerProvider(false)
+	lbClient.listError = errors.New("load balancers should not be listed")
+
+	syncLoadBalancers(t, p, []*endpoint.Endpoint{loadBalancerEndpoint("lb.bar.com", "1.2.3.4")})
+
+	require.Len(t, client.Actions, 1)
+	assert.Equal(t, "Create", client.Actions[0].Name)
+	assert.Empty(t, lbClient.Actions)
+}
+
+func TestCloudflareLoadBalancerDryRun(t *testing.T) {
+	p, _, lbClient := newLoadBalancerProvider(true)
+	p.DryRun = true
+
+	syncLoadBalancers(t, p, []*endpoint.Endpoint{loadBalancerEndpoint("lb.bar.com", "1.2.3.4")})
+
+	assert.Empty(t, lbClient.Actions)
+}
+
+func TestCloudflareLoadBalancerErrors(t *testing.T) {
+	p, _, lbClient := newLoadBalancerProvider(true)
+	lbClient.createError = errors.New("create failed")
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{loadBalancerEndpoint("lb.bar.com", "1.2.3.4")},
+	})
+	assert.ErrorContains(t, err, "failed to submit all load balancer changes")
+
+	lbClient.listError = &cloudflare.Error{StatusCode: http.StatusTooManyRequests, ErrorCodes: []int{10000}, Type: cloudflare.ErrorTypeRateLimit}
+	_, err = p.Records(context.Background())
+	assert.ErrorIs(t, err, provider.SoftError)
+}
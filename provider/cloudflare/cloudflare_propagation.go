@@ -0,0 +1,233 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	// dohEndpoint is the DNS over HTTPS JSON API of the Cloudflare resolver.
+	dohEndpoint = "https://cloudflare-dns.com/dns-query"
+	// defaultPropagationInterval is the delay between two lookups of the records which are not visible yet.
+	defaultPropagationInterval = 2 * time.Second
+)
+
+type PropagationConfig struct {
+	Enabled bool
+	Timeout time.Duration
+}
+
+// dohResolver looks up records with the DNS over HTTPS JSON API.
+// https://developers.cloudflare.com/1.1.1.1/encryption/dns-over-https/make-api-requests/dns-json/
+type dohResolver struct {
+	endpoint string
+	client   *http.Client
+	interval time.Duration
+}
+
+func newDoHResolver() *dohResolver {
+	return &dohResolver{
+		endpoint: dohEndpoint,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		interval: defaultPropagationInterval,
+	}
+}
+
+type dohAnswer struct {
+	Name string `json:"name"`
+	Type uint16 `json:"type"`
+	Data string `json:"data"`
+}
+
+type dohResponse struct {
+	Status int         `json:"Status"`
+	Answer []dohAnswer `json:"Answer"`
+}
+
+// lookup returns the data of the answers of the given type for the name.
+func (r *dohResolver) lookup(ctx context.Context, name, recordType string) ([]string, error) {
+	query := url.Values{"name": {name}, "type": {recordType}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/dns-json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var result dohResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.Status != dns.RcodeSuccess && result.Status != dns.RcodeNameError {
+		return nil, fmt.Errorf("lookup failed with %s", dns.RcodeToString[result.Status])
+	}
+
+	var data []string
+	for _, answer := range result.Answer {
+		if answer.Type == dns.StringToType[recordType] {
+			data = append(data, answer.Data)
+		}
+	}
+	return data, nil
+}
+
+// propagationCheck is a record set expected to be visible, with the targets of its changes.
+type propagationCheck struct {
+	Name     string
+	Type     string
+	Proxied  bool
+	Contents []string
+}
+
+func (c *propagationCheck) String() string {
+	return c.Name + " " + c.Type
+}
+
+// newPropagationChecks returns the record sets created or updated by the changes, deletions are not checked.
+func newPropagationChecks(changesByZone map[string][]*cloudFlareChange) map[string]*propagationCheck {
+	checks := map[string]*propagationCheck{}
+	for _, zoneChanges := range changesByZone {
+		for _, change := range zoneChanges {
+			if change.Action != cloudFlareCreate && change.Action != cloudFlareUpdate {
+				continue
+			}
+			record := change.ResourceRecord
+			proxied := record.Proxied != nil && *record.Proxied
+			key := record.Name + "/" + record.Type
+			check, ok := checks[key]
+			if !ok {
+				check = &propagationCheck{Name: record.Name, Type: record.Type, Proxied: proxied}
+				checks[key] = check
+			}
+			check.Contents = append(check.Contents, record.Content)
+		}
+	}
+	return checks
+}
+
+// normalizeAnswerData returns the record data in the same form as the content of the Cloudflare records.
+func normalizeAnswerData(recordType, data string) string {
+	switch recordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA:
+		if ip := net.ParseIP(data); ip != nil {
+			return ip.String()
+		}
+	case endpoint.RecordTypeTXT:
+		// long values are answered as several quoted strings
+		return strings.Trim(strings.ReplaceAll(data, `" "`, ""), `"`)
+	}
+	return strings.ToLower(strings.TrimSuffix(data, "."))
+}
+
+// visible returns true when the record set is answered by the resolver with all the targets of its changes.
+// The addresses of the Cloudflare proxy are answered for proxied records, so only their presence is checked.
+func (r *dohResolver) visible(ctx context.Context, check *propagationCheck) (bool, error) {
+	recordType := check.Type
+	if check.Proxied && recordType == endpoint.RecordTypeCNAME {
+		// proxied CNAME records are flattened into the addresses of the proxy
+		recordType = endpoint.RecordTypeA
+	}
+	data, err := r.lookup(ctx, check.Name, recordType)
+	if err != nil || len(data) == 0 {
+		return false, err
+	}
+
+	switch {
+	case check.Proxied:
+		return true, nil
+	case recordType != endpoint.RecordTypeA && recordType != endpoint.RecordTypeAAAA &&
+		recordType != endpoint.RecordTypeCNAME && recordType != endpoint.RecordTypeTXT:
+		// the answers of the other types hold more fields than the content of the record
+		return true, nil
+	}
+
+	answered := make([]string, 0, len(data))
+	for _, d := range data {
+		answered = append(answered, normalizeAnswerData(recordType, d))
+	}
+	for _, content := range check.Contents {
+		if !slices.Contains(answered, normalizeAnswerData(recordType, content)) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// waitForPropagation waits until the records created or updated by the changes are answered by the Cloudflare
+// resolver, it returns a soft error when some of them are still not visible once the timeout is exceeded.
+func (p *CloudFlareProvider) waitForPropagation(ctx context.Context, changesByZone map[string][]*cloudFlareChange) error {
+	pending := newPropagationChecks(changesByZone)
+	if len(pending) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.PropagationConfig.Timeout)
+	defer cancel()
+
+	for {
+		for key, check := range pending {
+			visible, err := p.resolver.visible(ctx, check)
+			if err != nil {
+				log.Debugf("Failed to look up %s over DNS over HTTPS: %v", check, err)
+				continue
+			}
+			if visible {
+				log.Debugf("Record %s is visible", check)
+				delete(pending, key)
+			}
+		}
+		if len(pending) == 0 {
+			log.Info("All changed records are visible")
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			var names []string
+			for _, check := range pending {
+				names = append(names, check.String())
+			}
+			sort.Strings(names)
+			return provider.NewSoftError(fmt.Errorf("records not visible after %s: %q", p.PropagationConfig.Timeout, names))
+		case <-time.After(p.resolver.interval):
+		}
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+// mockDoHServer answers the DNS over HTTPS JSON queries with the records it holds, by name and type.
+type mockDoHServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	records map[string][]string
+	queries []string
+}
+
+func newMockDoHServer(t *testing.T) *mockDoHServer {
+	m := &mockDoHServer{records: map[string][]string{}}
+	m.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/dns-json", r.Header.Get("Accept"))
+		name, recordType := r.URL.Query().Get("name"), r.URL.Query().Get("type")
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.queries = append(m.queries, name+" "+recordType)
+		response := dohResponse{Status: dns.RcodeNameError}
+		for _, data := range m.records[name+" "+recordType] {
+			response.Status = dns.RcodeSuccess
+			response.Answer = append(response.Answer, dohAnswer{Name: name + ".", Type: dns.StringToType[recordType], Data: data})
+		}
+		w.Header().Set("Content-Type", "application/dns-json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(m.Close)
+	return m
+}
+
+func (m *mockDoHServer) set(key string, data ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.records[key] = data
+}
+
+func newPropagationProvider(t *testing.T, timeout time.Duration) (*CloudFlareProvider, *mockDoHServer) {
+	server := newMockDoHServer(t)
+	resolver := newDoHResolver()
+	resolver.endpoint = server.URL
+	resolver.interval = 10 * time.Millisecond
+	return &CloudFlareProvider{
+		Client:            NewMockCloudFlareClient(),
+		domainFilter:      endpoint.NewDomainFilter([]string{"bar.com"}),
+		PropagationConfig: PropagationConfig{Enabled: true, Timeout: timeout},
+		resolver:          resolver,
+	}, server
+}
+
+func TestCloudflarePropagationVisible(t *testing.T) {
+	p, server := newPropagationProvider(t, time.Second)
+	server.set("a.bar.com A", "1.2.3.4", "1.2.3.5")
+	server.set("v6.bar.com AAAA", "2001:db8:0:0:0:0:0:1")
+	server.set("cname.bar.com CNAME", "Target.Example.com.")
+	server.set("txt.bar.com TXT", `"v=spf1 " "-all"`)
+	server.set("proxied.bar.com A", "104.16.0.1")
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("a.bar.com", endpoint.RecordTypeA, "1.2.3.4", "1.2.3.5"),
+			endpoint.NewEndpoint("v6.bar.com", endpoint.RecordTypeAAAA, "2001:db8::1"),
+			endpoint.NewEndpoint("cname.bar.com", endpoint.RecordTypeCNAME, "target.example.com"),
+			endpoint.NewEndpoint("txt.bar.com", endpoint.RecordTypeTXT, "v=spf1 -all"),
+			endpoint.NewEndpoint("proxied.bar.com", endpoint.RecordTypeCNAME, "origin.example.com").
+				WithProviderSpecific(annotations.CloudflareProxiedKey, "true"),
+			// not in a managed zone, so not checked
+			endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"a.bar.com A",
+		"v6.bar.com AAAA",
+		"cname.bar.com CNAME",
+		"txt.bar.com TXT",
+		"proxied.bar.com A",
+	}, server.queries)
+}
+
+func TestCloudflarePropagationEventuallyVisible(t *testing.T) {
+	p, server := newPropagationProvider(t, 5*time.Second)
+	server.set("a.bar.com A", "1.1.1.1")
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		server.set("a.bar.com A", "1.2.3.4")
+	}()
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("a.bar.com", endpoint.RecordTypeA, "1.1.1.1")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("a.bar.com", endpoint.RecordTypeA, "1.2.3.4")},
+	})
+	require.NoError(t, err)
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	assert.Greater(t, len(server.queries), 1, "the record should be looked up until it is visible")
+}
+
+func TestCloudflarePropagationTimeout(t *testing.T) {
+	p, server := newPropagationProvider(t, 100*time.Millisecond)
+	server.set("a.bar.com A", "1.2.3.4")
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("a.bar.com", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("missing.bar.com", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+	})
+	require.ErrorIs(t, err, provider.SoftError)
+	assert.ErrorContains(t, err, `records not visible after 100ms: ["missing.bar.com A"]`)
+}
+
+func TestCloudflarePropagationSkipped(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		changes *plan.Changes
+		setup   func(p *CloudFlareProvider)
+	}{
+		{
+			name: "disabled",
+			changes: &plan.Changes{
+				Create: []*endpoint.Endpoint{endpoint.NewEndpoint("a.bar.com", endpoint.RecordTypeA, "1.2.3.4")},
+			},
+			setup: func(p *CloudFlareProvider) { p.PropagationConfig.Enabled = false },
+		},
+		{
+			name: "dry run",
+			changes: &plan.Changes{
+				Create: []*endpoint.Endpoint{endpoint.NewEndpoint("a.bar.com", endpoint.RecordTypeA, "1.2.3.4")},
+			},
+			setup: func(p *CloudFlareProvider) { p.DryRun = true },
+		},
+		{
+			name: "deletion",
+			changes: &plan.Changes{
+				Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("foobar.bar.com", endpoint.RecordTypeA, "1.2.3.4")},
+			},
+			setup: func(p *CloudFlareProvider) {},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, server := newPropagationProvider(t, time.Second)
+			tc.setup(p)
+
+			require.NoError(t, p.ApplyChanges(context.Background(), tc.changes))
+			assert.Empty(t, server.queries)
+		})
+	}
+}
+
+func TestDoHResolverErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("name") {
+		case "servfail.bar.com":
+			_ = json.NewEncoder(w).Encode(dohResponse{Status: dns.RcodeServerFailure})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+	resolver := newDoHResolver()
+	resolver.endpoint = server.URL
+
+	_, err := resolver.lookup(context.Background(), "servfail.bar.com", endpoint.RecordTypeA)
+	assert.EqualError(t, err, "lookup failed with SERVFAIL")
+
+	_, err = resolver.lookup(context.Background(), "a.bar.com", endpoint.RecordTypeA)
+	assert.EqualError(t, err, "unexpected status 400 Bad Request")
+}
@@ -75,6 +75,7 @@ func updateDataLocalizationRegionalHostnameParams(rhc regionalHostnameChange) cl
 // submitDataLocalizationRegionalHostnameChanges applies a set of data localization regional hostname changes, returns false if it fails
 func (p *CloudFlareProvider) submitDataLocalizationRegionalHostnameChanges(ctx context.Context, rhChanges []regionalHostnameChange, resourceContainer *cloudflare.ResourceContainer) bool {
 	failedChange := false
+	client := p.zoneClient(resourceContainer.Identifier)
 
 	for _, rhChange := range rhChanges {
 		logFields := log.Fields{
@@ -91,13 +92,13 @@ func (p *CloudFlareProvider) submitDataLocalizationRegionalHostnameChanges(ctx c
 				continue
 			}
 			regionalHostnameParam := createDataLocalizationRegionalHostnameParams(rhChange)
-			err := p.Client.CreateDataLocalizationRegionalHostname(ctx, resourceContainer, regionalHostnameParam)
+			err := client.CreateDataLocalizationRegionalHostname(ctx, resourceContainer, regionalHostnameParam)
 			if err != nil {
 				var apiErr *cloudflare.Error
 				if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
 					log.WithFields(logFields).Debug("Regional hostname already exists, updating instead")
 					params := updateDataLocalizationRegionalHostnameParams(rhChange)
-					err := p.Client.UpdateDataLocalizationRegionalHostname(ctx, resourceContainer, params)
+					err := client.UpdateDataLocalizationRegionalHostname(ctx, resourceContainer, params)
 					if err != nil {
 						failedChange = true
 						log.WithFields(logFields).Errorf("failed to update regional hostname: %v", err)
@@ -113,13 +114,13 @@ func (p *CloudFlareProvider) submitDataLocalizationRegionalHostnameChanges(ctx c
 				continue
 			}
 			regionalHostnameParam := updateDataLocalizationRegionalHostnameParams(rhChange)
-			err := p.Client.UpdateDataLocalizationRegionalHostname(ctx, resourceContainer, regionalHostnameParam)
+			err := client.UpdateDataLocalizationRegionalHostname(ctx, resourceContainer, regionalHostnameParam)
 			if err != nil {
 				var apiErr *cloudflare.Error
 				if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
 					log.WithFields(logFields).Debug("Regional hostname not does not exists, creating instead")
 					params := createDataLocalizationRegionalHostnameParams(rhChange)
-					err := p.Client.CreateDataLocalizationRegionalHostname(ctx, resourceContainer, params)
+					err := client.CreateDataLocalizationRegionalHostname(ctx, resourceContainer, params)
 					if err != nil {
 						failedChange = true
 						log.WithFields(logFields).Errorf("failed to create regional hostname: %v", err)
@@ -134,7 +135,7 @@ func (p *CloudFlareProvider) submitDataLocalizationRegionalHostnameChanges(ctx c
 			if p.DryRun {
 				continue
 			}
-			err := p.Client.DeleteDataLocalizationRegionalHostname(ctx, resourceContainer, rhChange.Hostname)
+			err := client.DeleteDataLocalizationRegionalHostname(ctx, resourceContainer, rhChange.Hostname)
 			if err != nil {
 				var apiErr *cloudflare.Error
 				if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
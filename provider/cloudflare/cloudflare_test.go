@@ -21,6 +21,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
 	"slices"
 	"sort"
 	"strings"
@@ -30,6 +32,7 @@ import (
 	"github.com/maxatome/go-testdeep/td"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/testutils"
@@ -936,6 +939,68 @@ func TestCloudFlareZonesWithIDFilter(t *testing.T) {
 	assert.Equal(t, "bar.com", zones[0].Name)
 }
 
+func TestCloudflareZonesWithNameFilter(t *testing.T) {
+	// 50 zones: team-a-00.example.com to team-a-24.example.com and team-b-00.example.org to team-b-24.example.org
+	client := NewMockCloudFlareClient()
+	client.Zones = map[string]string{}
+	for i := range 25 {
+		client.Zones[fmt.Sprintf("a%02d", i)] = fmt.Sprintf("team-a-%02d.example.com", i)
+		client.Zones[fmt.Sprintf("b%02d", i)] = fmt.Sprintf("team-b-%02d.example.org", i)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		filter   *regexp.Regexp
+		expected int
+	}{
+		{name: "no filter", filter: nil, expected: 50},
+		{name: "empty filter", filter: regexp.MustCompile(""), expected: 50},
+		{name: "team prefix", filter: regexp.MustCompile(`^team-a-`), expected: 25},
+		{name: "suffix", filter: regexp.MustCompile(`\.example\.org$`), expected: 25},
+		{name: "numbered zones", filter: regexp.MustCompile(`^team-[ab]-0[0-4]\.`), expected: 10},
+		{name: "alternation", filter: regexp.MustCompile(`^team-a-(01|13)\.example\.com$|^team-b-24\.`), expected: 3},
+		{name: "unanchored substring", filter: regexp.MustCompile(`-1`), expected: 20},
+		{name: "no match", filter: regexp.MustCompile(`^prod\.`), expected: 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &CloudFlareProvider{
+				Client:         client,
+				zoneNameFilter: tc.filter,
+			}
+
+			zones, err := p.Zones(context.Background())
+			require.NoError(t, err)
+			assert.Len(t, zones, tc.expected)
+			for _, zone := range zones {
+				if tc.filter != nil {
+					assert.Regexp(t, tc.filter, zone.Name)
+				}
+			}
+		})
+	}
+
+	// the filter applies after the domain filter
+	p := &CloudFlareProvider{
+		Client:         client,
+		domainFilter:   endpoint.NewDomainFilter([]string{"example.com"}),
+		zoneNameFilter: regexp.MustCompile(`-0[0-4]\.`),
+	}
+	zones, err := p.Zones(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, zones, 5)
+
+	// and to the zones of the zone ID filter
+	p = &CloudFlareProvider{
+		Client:         client,
+		zoneIDFilter:   provider.NewZoneIDFilter([]string{"a00", "b00"}),
+		zoneNameFilter: regexp.MustCompile(`^team-b-`),
+	}
+	zones, err = p.Zones(context.Background())
+	require.NoError(t, err)
+	require.Len(t, zones, 1)
+	assert.Equal(t, "team-b-00.example.org", zones[0].Name)
+}
+
 func TestCloudflareListZonesRateLimited(t *testing.T) {
 	// Create a mock client that returns a rate limit error
 	client := NewMockCloudFlareClient()
@@ -1097,11 +1162,16 @@ func TestCloudflareProvider(t *testing.T) {
 			_, err = NewCloudFlareProvider(
 				endpoint.NewDomainFilter([]string{"bar.com"}),
 				provider.NewZoneIDFilter([]string{""}),
+				nil,
 				false,
 				true,
 				"",
 				CustomHostnamesConfig{Enabled: false},
 				DNSRecordsConfig{PerPage: 5000, Comment: ""},
+				LoadBalancersConfig{},
+				WorkerRoutesConfig{},
+				PropagationConfig{},
+				nil,
 			)
 			if err != nil && !tc.ShouldFail {
 				t.Errorf("should not fail, %s", err)
@@ -1760,17 +1830,164 @@ func TestCustomTTLWithEnabledProxyNotChanged(t *testing.T) {
 	assert.Empty(t, planned.Changes.Delete, "no new changes should be here")
 }
 
+func TestCloudflareDMARCEndpoints(t *testing.T) {
+	provider := &CloudFlareProvider{}
+
+	endpoints, err := provider.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("bar.com", endpoint.RecordTypeA, 300, "1.2.3.4").
+			WithProviderSpecific(annotations.CloudflareDMARCKey, "v=DMARC1; p=reject; rua=mailto:dmarc@bar.com").
+			WithLabel(endpoint.ResourceLabelKey, "service/default/mail"),
+		// same hostname, a single DMARC record is created
+		endpoint.NewEndpointWithTTL("bar.com", endpoint.RecordTypeAAAA, 300, "2001:db8::1").
+			WithProviderSpecific(annotations.CloudflareDMARCKey, "v=DMARC1; p=reject; rua=mailto:dmarc@bar.com"),
+		endpoint.NewEndpoint("invalid.bar.com", endpoint.RecordTypeA, "1.2.3.5").
+			WithProviderSpecific(annotations.CloudflareDMARCKey, "p=reject"),
+		// the DMARC record defined by a TXT endpoint is kept
+		endpoint.NewEndpoint("explicit.bar.com", endpoint.RecordTypeA, "1.2.3.6").
+			WithProviderSpecific(annotations.CloudflareDMARCKey, "v=DMARC1; p=none"),
+		endpoint.NewEndpoint("_dmarc.explicit.bar.com", endpoint.RecordTypeTXT, "v=DMARC1; p=quarantine"),
+	})
+	assert.NoError(t, err)
+
+	var txtEndpoints []*endpoint.Endpoint
+	for _, e := range endpoints {
+		_, ok := e.GetProviderSpecificProperty(annotations.CloudflareDMARCKey)
+		assert.False(t, ok, "the DMARC property of %s should be removed", e.DNSName)
+		if e.RecordType == endpoint.RecordTypeTXT {
+			txtEndpoints = append(txtEndpoints, e)
+		}
+	}
+
+	assert.Len(t, endpoints, 6)
+	assert.Len(t, txtEndpoints, 2)
+	assert.Equal(t, "_dmarc.explicit.bar.com", txtEndpoints[0].DNSName)
+	assert.Equal(t, endpoint.Targets{"v=DMARC1; p=quarantine"}, txtEndpoints[0].Targets)
+
+	dmarc := txtEndpoints[1]
+	assert.Equal(t, "_dmarc.bar.com", dmarc.DNSName)
+	assert.Equal(t, endpoint.Targets{"v=DMARC1; p=reject; rua=mailto:dmarc@bar.com"}, dmarc.Targets)
+	assert.Equal(t, endpoint.TTL(300), dmarc.RecordTTL)
+	assert.Equal(t, "service/default/mail", dmarc.Labels[endpoint.ResourceLabelKey])
+	assert.False(t, shouldBeProxied(dmarc, true), "TXT records can't be proxied")
+}
+
+func TestValidateDMARCPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		policy  string
+		isValid bool
+	}{
+		{policy: "v=DMARC1; p=none", isValid: true},
+		{policy: "v=DMARC1;p=reject;pct=100", isValid: true},
+		{policy: "v = DMARC1 ; p=quarantine", isValid: true},
+		{policy: "v=DMARC1", isValid: true},
+		{policy: "", isValid: false},
+		{policy: "p=reject; v=DMARC1", isValid: false},
+		{policy: "v=DMARC10; p=reject", isValid: false},
+		{policy: "v=dmarc1; p=reject", isValid: false},
+		{policy: "v=spf1 -all", isValid: false},
+	} {
+		t.Run(tc.policy, func(t *testing.T) {
+			err := validateDMARCPolicy(tc.policy)
+			if tc.isValid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, "invalid DMARC policy")
+			}
+		})
+	}
+}
+
+func TestCloudFlareProvider_ZoneTokens(t *testing.T) {
+	_ = os.Setenv("CF_API_TOKEN", "abc123def")
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("zone-token\n"), 0o600))
+
+	p, err := NewCloudFlareProvider(
+		endpoint.NewDomainFilter([]string{"bar.com", "foo.com"}),
+		provider.ZoneIDFilter{},
+		nil,
+		false,
+		false,
+		"",
+		CustomHostnamesConfig{Enabled: false},
+		DNSRecordsConfig{PerPage: 50},
+		LoadBalancersConfig{},
+		WorkerRoutesConfig{},
+		PropagationConfig{},
+		map[string]string{"Foo.com.": "file:" + tokenFile},
+	)
+	require.NoError(t, err)
+	require.Contains(t, p.zoneClients, "foo.com")
+	assert.Equal(t, "zone-token", p.zoneClients["foo.com"].(zoneService).service.APIToken)
+
+	_, err = NewCloudFlareProvider(
+		endpoint.NewDomainFilter([]string{"foo.com"}),
+		provider.ZoneIDFilter{},
+		nil,
+		false,
+		false,
+		"",
+		CustomHostnamesConfig{Enabled: false},
+		DNSRecordsConfig{PerPage: 50},
+		LoadBalancersConfig{},
+		WorkerRoutesConfig{},
+		PropagationConfig{},
+		map[string]string{"foo.com": "file:" + filepath.Join(t.TempDir(), "missing")},
+	)
+	assert.ErrorContains(t, err, "failed to read the API token of zone foo.com")
+}
+
+func TestCloudFlareApplyChangesWithZoneTokens(t *testing.T) {
+	client := NewMockCloudFlareClient()
+	zoneClient := NewMockCloudFlareClient()
+	p := &CloudFlareProvider{
+		Client:      client,
+		zoneClients: map[string]cloudFlareDNS{"foo.com": zoneClient},
+	}
+
+	changes := &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("new.bar.com", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("new.foo.com", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+	}
+	require.NoError(t, p.ApplyChanges(context.Background(), changes))
+
+	// the zones are listed with the global token, the records of foo.com are changed with its own token
+	assert.Equal(t, []MockAction{{
+		Name:       "Create",
+		ZoneId:     "001",
+		RecordId:   generateDNSRecordID("A", "new.bar.com", "1.2.3.4"),
+		RecordData: cloudflare.DNSRecord{ID: generateDNSRecordID("A", "new.bar.com", "1.2.3.4"), Name: "new.bar.com", Type: "A", Content: "1.2.3.4", TTL: 1, Proxied: proxyDisabled},
+	}}, client.Actions)
+	assert.Equal(t, []MockAction{{
+		Name:       "Create",
+		ZoneId:     "002",
+		RecordId:   generateDNSRecordID("A", "new.foo.com", "1.2.3.4"),
+		RecordData: cloudflare.DNSRecord{ID: generateDNSRecordID("A", "new.foo.com", "1.2.3.4"), Name: "new.foo.com", Type: "A", Content: "1.2.3.4", TTL: 1, Proxied: proxyDisabled},
+	}}, zoneClient.Actions)
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, records, 2, "the records of foo.com must be listed with its own token")
+}
+
 func TestCloudFlareProvider_Region(t *testing.T) {
 	_ = os.Setenv("CF_API_TOKEN", "abc123def")
 	_ = os.Setenv("CF_API_EMAIL", "test@test.com")
 	provider, err := NewCloudFlareProvider(
 		endpoint.NewDomainFilter([]string{"example.com"}),
 		provider.ZoneIDFilter{},
+		nil,
 		true,
 		false,
 		"us",
 		CustomHostnamesConfig{Enabled: false},
 		DNSRecordsConfig{PerPage: 50, Comment: ""},
+		LoadBalancersConfig{},
+		WorkerRoutesConfig{},
+		PropagationConfig{},
+		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -1788,11 +2005,16 @@ func TestCloudFlareProvider_newCloudFlareChange(t *testing.T) {
 	p, err := NewCloudFlareProvider(
 		endpoint.NewDomainFilter([]string{"example.com"}),
 		provider.ZoneIDFilter{},
+		nil,
 		true,
 		false,
 		"us",
 		CustomHostnamesConfig{Enabled: false},
 		DNSRecordsConfig{PerPage: 50},
+		LoadBalancersConfig{},
+		WorkerRoutesConfig{},
+		PropagationConfig{},
+		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -1831,11 +2053,16 @@ func TestCloudFlareProvider_newCloudFlareChange(t *testing.T) {
 	paidProvider, err := NewCloudFlareProvider(
 		endpoint.NewDomainFilter([]string{"bar.com"}),
 		provider.ZoneIDFilter{},
+		nil,
 		true,
 		false,
 		"us",
 		CustomHostnamesConfig{Enabled: false},
 		DNSRecordsConfig{PerPage: 50, Comment: paidValidCommentBuilder.String()},
+		LoadBalancersConfig{},
+		WorkerRoutesConfig{},
+		PropagationConfig{},
+		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -0,0 +1,279 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudflare
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+// workerRoutePatternSuffix is appended to a hostname to route all of its paths to the script.
+const workerRoutePatternSuffix = "/*"
+
+type WorkerRoutesConfig struct {
+	Enabled bool
+}
+
+// cloudFlareWorkerRoutes is the subset of the CloudFlare Workers Routes API that we actually use. Signatures must match exactly.
+type cloudFlareWorkerRoutes interface {
+	ListWorkerRoutes(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListWorkerRoutesParams) (cloudflare.WorkerRoutesResponse, error)
+	CreateWorkerRoute(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.CreateWorkerRouteParams) (cloudflare.WorkerRouteResponse, error)
+	UpdateWorkerRoute(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.UpdateWorkerRouteParams) (cloudflare.WorkerRouteResponse, error)
+	DeleteWorkerRoute(ctx context.Context, rc *cloudflare.ResourceContainer, routeID string) (cloudflare.WorkerRouteResponse, error)
+}
+
+func (z zoneService) ListWorkerRoutes(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListWorkerRoutesParams) (cloudflare.WorkerRoutesResponse, error) {
+	return z.service.ListWorkerRoutes(ctx, rc, params)
+}
+
+func (z zoneService) CreateWorkerRoute(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.CreateWorkerRouteParams) (cloudflare.WorkerRouteResponse, error) {
+	return z.service.CreateWorkerRoute(ctx, rc, params)
+}
+
+func (z zoneService) UpdateWorkerRoute(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.UpdateWorkerRouteParams) (cloudflare.WorkerRouteResponse, error) {
+	return z.service.UpdateWorkerRoute(ctx, rc, params)
+}
+
+func (z zoneService) DeleteWorkerRoute(ctx context.Context, rc *cloudflare.ResourceContainer, routeID string) (cloudflare.WorkerRouteResponse, error) {
+	return z.service.DeleteWorkerRoute(ctx, rc, routeID)
+}
+
+// workerRouteChange is a change of the Workers Route of a hostname, the script is empty for a deletion.
+type workerRouteChange struct {
+	Action   changeAction
+	Hostname string
+	Script   string
+}
+
+// isWorkerRouteRecordType returns true for the types of the records serving the traffic of a hostname, which is
+// routed to the script.
+func isWorkerRouteRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME:
+		return true
+	default:
+		return false
+	}
+}
+
+// workerRouteScript returns the name of the script the annotated endpoint must be routed to.
+func workerRouteScript(ep *endpoint.Endpoint) string {
+	script, _ := ep.GetProviderSpecificProperty(annotations.CloudflareWorkerRouteKey)
+	return strings.TrimSpace(script)
+}
+
+// workerRoutePattern returns the pattern of the route of all the paths of the hostname.
+func workerRoutePattern(hostname string) string {
+	return strings.TrimSuffix(hostname, ".") + workerRoutePatternSuffix
+}
+
+// workerRouteHostname returns the hostname of a route pattern, or false when the route isn't one of a whole hostname.
+func workerRouteHostname(pattern string) (string, bool) {
+	hostname, ok := strings.CutSuffix(pattern, workerRoutePatternSuffix)
+	if !ok || hostname == "" || strings.ContainsAny(hostname, "/:") {
+		return "", false
+	}
+	return hostname, true
+}
+
+// newWorkerRouteChanges returns the Workers Route changes of the endpoint changes, a single change is
+// returned for a hostname even when several of its records are changed.
+func newWorkerRouteChanges(changes *plan.Changes) []*workerRouteChange {
+	desired := map[string]string{}
+	var hostnames []string
+	addHostname := func(hostname string) {
+		if _, ok := desired[hostname]; !ok {
+			desired[hostname] = ""
+			hostnames = append(hostnames, hostname)
+		}
+	}
+
+	for _, e := range changes.Delete {
+		if workerRouteScript(e) != "" {
+			addHostname(e.DNSName)
+		}
+	}
+	for _, e := range changes.UpdateOld {
+		if workerRouteScript(e) != "" {
+			addHostname(e.DNSName)
+		}
+	}
+	for _, eps := range [][]*endpoint.Endpoint{changes.Create, changes.UpdateNew} {
+		for _, e := range eps {
+			if script := workerRouteScript(e); script != "" {
+				addHostname(e.DNSName)
+				desired[e.DNSName] = script
+			}
+		}
+	}
+
+	result := make([]*workerRouteChange, 0, len(hostnames))
+	for _, hostname := range hostnames {
+		change := &workerRouteChange{Action: cloudFlareUpdate, Hostname: hostname, Script: desired[hostname]}
+		if change.Script == "" {
+			change.Action = cloudFlareDelete
+		}
+		result = append(result, change)
+	}
+	return result
+}
+
+// listWorkerRoutes returns the routes of whole hostnames of a zone, by hostname.
+func (p *CloudFlareProvider) listWorkerRoutes(ctx context.Context, zoneID string) (map[string]cloudflare.WorkerRoute, error) {
+	resp, err := p.WorkerRoutesClient.ListWorkerRoutes(ctx, cloudflare.ZoneIdentifier(zoneID), cloudflare.ListWorkerRoutesParams{})
+	if err != nil {
+		return nil, convertCloudflareError(err)
+	}
+	routes := make(map[string]cloudflare.WorkerRoute, len(resp.Routes))
+	for _, route := range resp.Routes {
+		if hostname, ok := workerRouteHostname(route.Pattern); ok {
+			routes[hostname] = route
+		}
+	}
+	return routes, nil
+}
+
+// withWorkerRoutes annotates the endpoints with the script of the route of their hostname, for the plan to
+// detect the changes of the annotation.
+func (p *CloudFlareProvider) withWorkerRoutes(ctx context.Context, zones []cloudflare.Zone, endpoints []*endpoint.Endpoint) error {
+	if !p.WorkerRoutesConfig.Enabled {
+		return nil
+	}
+
+	scripts := map[string]string{}
+	for _, zone := range zones {
+		routes, err := p.listWorkerRoutes(ctx, zone.ID)
+		if err != nil {
+			return err
+		}
+		for hostname, route := range routes {
+			if route.ScriptName != "" {
+				scripts[hostname] = route.ScriptName
+			}
+		}
+	}
+
+	for _, e := range endpoints {
+		if script, ok := scripts[e.DNSName]; ok && isWorkerRouteRecordType(e.RecordType) {
+			e.SetProviderSpecificProperty(annotations.CloudflareWorkerRouteKey, script)
+		}
+	}
+	return nil
+}
+
+// submitWorkerRouteChanges applies a set of Workers Route changes in the zones of their hostnames.
+func (p *CloudFlareProvider) submitWorkerRouteChanges(ctx context.Context, changes []*workerRouteChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return err
+	}
+	zoneNameIDMapper := provider.ZoneIDName{}
+	for _, z := range zones {
+		zoneNameIDMapper.Add(z.ID, z.Name)
+	}
+
+	routesByZone := map[string]map[string]cloudflare.WorkerRoute{}
+	var failedZones []string
+	for _, change := range changes {
+		zoneID, _ := zoneNameIDMapper.FindZone(change.Hostname)
+		if zoneID == "" {
+			log.Debugf("Skipping worker route of %q because no hosted zone matching record DNS Name was detected", change.Hostname)
+			continue
+		}
+
+		logFields := log.Fields{
+			"workerRoute": workerRoutePattern(change.Hostname),
+			"script":      change.Script,
+			"action":      change.Action,
+			"zone":        zoneID,
+		}
+		log.WithFields(logFields).Info("Changing worker route.")
+
+		if p.DryRun {
+			continue
+		}
+
+		routes, ok := routesByZone[zoneID]
+		if !ok {
+			routes, err = p.listWorkerRoutes(ctx, zoneID)
+			if err != nil {
+				return err
+			}
+			routesByZone[zoneID] = routes
+		}
+
+		if err := p.submitWorkerRouteChange(ctx, zoneID, routes, change); err != nil {
+			log.WithFields(logFields).Errorf("failed to %s worker route: %v", strings.ToLower(change.Action.String()), err)
+			failedZones = append(failedZones, zoneID)
+		}
+	}
+
+	if len(failedZones) > 0 {
+		return fmt.Errorf("failed to submit all worker route changes for the following zones: %q", failedZones)
+	}
+	return nil
+}
+
+func (p *CloudFlareProvider) submitWorkerRouteChange(ctx context.Context, zoneID string, routes map[string]cloudflare.WorkerRoute, change *workerRouteChange) error {
+	zoneContainer := cloudflare.ZoneIdentifier(zoneID)
+	pattern := workerRoutePattern(change.Hostname)
+	current, exists := routes[change.Hostname]
+
+	switch {
+	case change.Action == cloudFlareDelete:
+		if !exists {
+			return nil
+		}
+		if _, err := p.WorkerRoutesClient.DeleteWorkerRoute(ctx, zoneContainer, current.ID); err != nil {
+			return err
+		}
+		delete(routes, change.Hostname)
+	case !exists:
+		created, err := p.WorkerRoutesClient.CreateWorkerRoute(ctx, zoneContainer, cloudflare.CreateWorkerRouteParams{
+			Pattern: pattern,
+			Script:  change.Script,
+		})
+		if err != nil {
+			return err
+		}
+		routes[change.Hostname] = created.WorkerRoute
+	case current.ScriptName != change.Script:
+		updated, err := p.WorkerRoutesClient.UpdateWorkerRoute(ctx, zoneContainer, cloudflare.UpdateWorkerRouteParams{
+			ID:      current.ID,
+			Pattern: pattern,
+			Script:  change.Script,
+		})
+		if err != nil {
+			return err
+		}
+		routes[change.Hostname] = updated.WorkerRoute
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudflare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+type mockCloudFlareWorkerRoutesClient struct {
+	Routes      map[string][]cloudflare.WorkerRoute
+	Actions     []string
+	nextID      int
+	listError   error
+	createError error
+}
+
+func newMockCloudFlareWorkerRoutesClient() *mockCloudFlareWorkerRoutesClient {
+	return &mockCloudFlareWorkerRoutesClient{
+		Routes: map[string][]cloudflare.WorkerRoute{},
+	}
+}
+
+func (m *mockCloudFlareWorkerRoutesClient) ListWorkerRoutes(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.ListWorkerRoutesParams) (cloudflare.WorkerRoutesResponse, error) {
+	if m.listError != nil {
+		return cloudflare.WorkerRoutesResponse{}, m.listError
+	}
+	return cloudflare.WorkerRoutesResponse{Routes: m.Routes[rc.Identifier]}, nil
+}
+
+func (m *mockCloudFlareWorkerRoutesClient) CreateWorkerRoute(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.CreateWorkerRouteParams) (cloudflare.WorkerRouteResponse, error) {
+	if m.createError != nil {
+		return cloudflare.WorkerRouteResponse{}, m.createError
+	}
+	m.nextID++
+	route := cloudflare.WorkerRoute{ID: fmt.Sprintf("route-%d", m.nextID), Pattern: params.Pattern, ScriptName: params.Script}
+	m.Routes[rc.Identifier] = append(m.Routes[rc.Identifier], route)
+	m.Actions = append(m.Actions, "CreateWorkerRoute "+route.Pattern+" "+route.ScriptName)
+	return cloudflare.WorkerRouteResponse{WorkerRoute: route}, nil
+}
+
+func (m *mockCloudFlareWorkerRoutesClient) UpdateWorkerRoute(ctx context.Context, rc *cloudflare.ResourceContainer, params cloudflare.UpdateWorkerRouteParams) (cloudflare.WorkerRouteResponse, error) {
+	for i, route := range m.Routes[rc.Identifier] {
+		if route.ID == params.ID {
+			route = cloudflare.WorkerRoute{ID: params.ID, Pattern: params.Pattern, ScriptName: params.Script}
+			m.Routes[rc.Identifier][i] = route
+			m.Actions = append(m.Actions, "UpdateWorkerRoute "+route.Pattern+" "+route.ScriptName)
+			return cloudflare.WorkerRouteResponse{WorkerRoute: route}, nil
+		}
+	}
+	return cloudflare.WorkerRouteResponse{}, errors.New("route not found")
+}
+
+func (m *mockCloudFlareWorkerRoutesClient) DeleteWorkerRoute(ctx context.Context, rc *cloudflare.ResourceContainer, routeID string) (cloudflare.WorkerRouteResponse, error) {
+	for i, route := range m.Routes[rc.Identifier] {
+		if route.ID == routeID {
+			m.Routes[rc.Identifier] = append(m.Routes[rc.Identifier][:i], m.Routes[rc.Identifier][i+1:]...)
+			m.Actions = append(m.Actions, "DeleteWorkerRoute "+route.Pattern)
+			return cloudflare.WorkerRouteResponse{WorkerRoute: route}, nil
+		}
+	}
+	return cloudflare.WorkerRouteResponse{}, errors.New("route not found")
+}
+
+func newWorkerRoutesProvider(enabled bool) (*CloudFlareProvider, *mockCloudFlareClient, *mockCloudFlareWorkerRoutesClient) {
+	client := NewMockCloudFlareClient()
+	routesClient := newMockCloudFlareWorkerRoutesClient()
+	return &CloudFlareProvider{
+		Client:             client,
+		WorkerRoutesClient: routesClient,
+		WorkerRoutesConfig: WorkerRoutesConfig{Enabled: enabled},
+		domainFilter:       endpoint.NewDomainFilter([]string{"bar.com"}),
+	}, client, routesClient
+}
+
+func workerRouteEndpoint(name, recordType, script string, targets ...string) *endpoint.Endpoint {
+	return endpoint.NewEndpoint(name, recordType, targets...).
+		WithProviderSpecific(annotations.CloudflareWorkerRouteKey, script)
+}
+
+func TestCloudflareWorkerRouteLifecycle(t *testing.T) {
+	p, client, routesClient := newWorkerRoutesProvider(true)
+
+	// a single route is created for the hostname of both records
+	syncLoadBalancers(t, p, []*endpoint.Endpoint{
+		workerRouteEndpoint("app.bar.com", endpoint.RecordTypeA, "my-worker", "1.2.3.4"),
+		workerRouteEndpoint("app.bar.com", endpoint.RecordTypeAAAA, "my-worker", "2001:db8::1"),
+	})
+	assert.Len(t, client.Actions, 2, "the DNS records should be created")
+	assert.Equal(t, []string{"CreateWorkerRoute app.bar.com/* my-worker"}, routesClient.Actions)
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	for _, record := range records {
+		script, ok := record.GetProviderSpecificProperty(annotations.CloudflareWorkerRouteKey)
+		assert.True(t, ok)
+		assert.Equal(t, "my-worker", script)
+	}
+
+	// the route is up to date
+	routesClient.Actions = nil
+	changes := syncLoadBalancers(t, p, []*endpoint.Endpoint{
+		workerRouteEndpoint("app.bar.com", endpoint.RecordTypeA, "my-worker", "1.2.3.4"),
+		workerRouteEndpoint("app.bar.com", endpoint.RecordTypeAAAA, "my-worker", "2001:db8::1"),
+	})
+	assert.False(t, changes.HasChanges())
+	assert.Empty(t, routesClient.Actions)
+
+	// the route follows the script of the annotation
+	syncLoadBalancers(t, p, []*endpoint.Endpoint{
+		workerRouteEndpoint("app.bar.com", endpoint.RecordTypeA, "other-worker", "1.2.3.4"),
+		workerRouteEndpoint("app.bar.com", endpoint.RecordTypeAAAA, "other-worker", "2001:db8::1"),
+	})
+	assert.Equal(t, []string{"UpdateWorkerRoute app.bar.com/* other-worker"}, routesClient.Actions)
+
+	// the route is deleted with the annotation, the records are kept
+	routesClient.Actions = nil
+	client.Actions = nil
+	syncLoadBalancers(t, p, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.bar.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("app.bar.com", endpoint.RecordTypeAAAA, "2001:db8::1"),
+	})
+	assert.Equal(t, []string{"DeleteWorkerRoute app.bar.com/*"}, routesClient.Actions)
+	assert.Empty(t, routesClient.Routes["001"])
+	for _, action := range client.Actions {
+		assert.NotEqual(t, "Delete", action.Name)
+	}
+
+	// the route is deleted with the endpoint
+	syncLoadBalancers(t, p, []*endpoint.Endpoint{workerRouteEndpoint("app.bar.com", endpoint.RecordTypeA, "my-worker", "1.2.3.4")})
+	routesClient.Actions = nil
+	syncLoadBalancers(t, p, []*endpoint.Endpoint{})
+	assert.Equal(t, []string{"DeleteWorkerRoute app.bar.com/*"}, routesClient.Actions)
+}
+
+func TestCloudflareWorkerRouteIgnoresOtherRoutes(t *testing.T) {
+	p, _, routesClient := newWorkerRoutesProvider(true)
+	routesClient.Routes["001"] = []cloudflare.WorkerRoute{
+		{ID: "api", Pattern: "app.bar.com/api/*", ScriptName: "api-worker"},
+		{ID: "wildcard", Pattern: "*bar.com/*", ScriptName: "wildcard-worker"},
+	}
+
+	syncLoadBalancers(t, p, []*endpoint.Endpoint{workerRouteEndpoint("app.bar.com", endpoint.RecordTypeCNAME, "my-worker", "origin.example.com")})
+	syncLoadBalancers(t, p, []*endpoint.Endpoint{})
+
+	assert.Equal(t, []string{
+		"CreateWorkerRoute app.bar.com/* my-worker",
+		"DeleteWorkerRoute app.bar.com/*",
+	}, routesClient.Actions)
+	assert.Len(t, routesClient.Routes["001"], 2, "the routes not managed for a hostname should be kept")
+}
+
+func TestCloudflareWorkerRouteNotOnOtherRecordTypes(t *testing.T) {
+	p, _, _ := newWorkerRoutesProvider(true)
+
+	adjusted, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		workerRouteEndpoint("app.bar.com", endpoint.RecordTypeTXT, "my-worker", "text"),
+		workerRouteEndpoint("app.bar.com", endpoint.RecordTypeA, "my-worker", "1.2.3.4"),
+	})
+	require.NoError(t, err)
+
+	_, ok := adjusted[0].GetProviderSpecificProperty(annotations.CloudflareWorkerRouteKey)
+	assert.False(t, ok)
+	_, ok = adjusted[1].GetProviderSpecificProperty(annotations.CloudflareWorkerRouteKey)
+	assert.True(t, ok)
+}
+
+func TestCloudflareWorkerRouteDisabled(t *testing.T) {
+	p, client, routesClient := newWorkerRoutesProvider(false)
+	routesClient.listError = errors.New("worker routes should not be listed")
+
+	syncLoadBalancers(t, p, []*endpoint.Endpoint{workerRouteEndpoint("app.bar.com", endpoint.RecordTypeA, "my-worker", "1.2.3.4")})
+
+	require.Len(t, client.Actions, 1)
+	assert.Equal(t, "Create", client.Actions[0].Name)
+	assert.Empty(t, routesClient.Actions)
+}
+
+func TestCloudflareWorkerRouteDryRun(t *testing.T) {
+	p, _, routesClient := newWorkerRoutesProvider(true)
+	p.DryRun = true
+
+	syncLoadBalancers(t, p, []*endpoint.Endpoint{workerRouteEndpoint("app.bar.com", endpoint.RecordTypeA, "my-worker", "1.2.3.4")})
+
+	assert.Empty(t, routesClient.Actions)
+}
+
+func TestCloudflareWorkerRouteErrors(t *testing.T) {
+	p, _, routesClient := newWorkerRoutesProvider(true)
+	routesClient.createError = errors.New("create failed")
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{workerRouteEndpoint("app.bar.com", endpoint.RecordTypeA, "my-worker", "1.2.3.4")},
+	})
+	assert.ErrorContains(t, err, "failed to submit all worker route changes")
+
+	routesClient.listError = &cloudflare.Error{StatusCode: http.StatusTooManyRequests, ErrorCodes: []int{10000}, Type: cloudflare.ErrorTypeRateLimit}
+	_, err = p.Records(context.Background())
+	assert.ErrorIs(t, err, provider.SoftError)
+}
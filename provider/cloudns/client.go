@@ -0,0 +1,258 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+const (
+	// defaultEndpoint is the base URL of the ClouDNS API.
+	defaultEndpoint = "https://api.cloudns.net"
+	// rowsPerPage is the page size of the zone listing, the maximum allowed by the API.
+	rowsPerPage = 100
+)
+
+// Record is a DNS record of a zone. The records are flat within a zone, a record holds a single value.
+type Record struct {
+	ID       string
+	Type     string
+	Host     string
+	Record   string
+	TTL      int
+	Priority int
+	Weight   int
+	Port     int
+}
+
+// RecordRequest holds the fields of the records written by the provider. The priority is set for MX and SRV records,
+// the weight and the port for SRV records.
+type RecordRequest struct {
+	Type     string
+	Host     string
+	Record   string
+	TTL      int
+	Priority *int
+	Weight   *int
+	Port     *int
+}
+
+// APIError is a failed status returned by the ClouDNS API.
+type APIError struct {
+	Path        string
+	Description string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("cloudns: %s failed: %s", e.Path, e.Description)
+}
+
+// DNSClient is the interface of the ClouDNS API used by the provider.
+type DNSClient interface {
+	ListZones(ctx context.Context) ([]string, error)
+	ListRecords(ctx context.Context, zone string) ([]Record, error)
+	CreateRecord(ctx context.Context, zone string, record RecordRequest) error
+	UpdateRecord(ctx context.Context, zone string, recordID string, record RecordRequest) error
+	DeleteRecord(ctx context.Context, zone string, recordID string) error
+}
+
+// Client calls the ClouDNS API with the credentials of an API user.
+type Client struct {
+	authID       string
+	authPassword string
+	endpoint     string
+	httpClient   *http.Client
+}
+
+// NewClient returns a client of the ClouDNS API authenticating with the ID and the password of an API user.
+func NewClient(authID, authPassword string, httpClient *http.Client) *Client {
+	return &Client{
+		authID:       authID,
+		authPassword: authPassword,
+		endpoint:     defaultEndpoint,
+		httpClient:   httpClient,
+	}
+}
+
+// statusResponse is the response of the methods changing a zone, and of the failed calls.
+type statusResponse struct {
+	Status            string `json:"status"`
+	StatusDescription string `json:"statusDescription"`
+}
+
+func (c *Client) ListZones(ctx context.Context) ([]string, error) {
+	var zones []string
+	for page := 1; ; page++ {
+		var items []struct {
+			Name string `json:"name"`
+			Type string `json:"type"`
+		}
+		params := url.Values{"page": {strconv.Itoa(page)}, "rows-per-page": {strconv.Itoa(rowsPerPage)}}
+		if err := c.do(ctx, "/dns/list-zones.json", params, &items); err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			// the records of the slave, parked and GeoDNS zones can't be managed
+			if strings.EqualFold(item.Type, "master") {
+				zones = append(zones, item.Name)
+			}
+		}
+		if len(items) < rowsPerPage {
+			return zones, nil
+		}
+	}
+}
+
+func (c *Client) ListRecords(ctx context.Context, zone string) ([]Record, error) {
+	// the records are keyed by their ID, an empty zone is an empty array
+	var items map[string]map[string]any
+	if err := c.do(ctx, "/dns/records.json", url.Values{"domain-name": {zone}}, &items); err != nil {
+		return nil, err
+	}
+	records := make([]Record, 0, len(items))
+	for id, item := range items {
+		records = append(records, Record{
+			ID:       id,
+			Type:     asString(item["type"]),
+			Host:     asString(item["host"]),
+			Record:   asString(item["record"]),
+			TTL:      asInt(item["ttl"]),
+			Priority: asInt(item["priority"]),
+			Weight:   asInt(item["weight"]),
+			Port:     asInt(item["port"]),
+		})
+	}
+	return records, nil
+}
+
+func (c *Client) CreateRecord(ctx context.Context, zone string, record RecordRequest) error {
+	params := recordParams(record)
+	params.Set("domain-name", zone)
+	params.Set("record-type", record.Type)
+	return c.change(ctx, "/dns/add-record.json", params)
+}
+
+func (c *Client) UpdateRecord(ctx context.Context, zone string, recordID string, record RecordRequest) error {
+	params := recordParams(record)
+	params.Set("domain-name", zone)
+	params.Set("record-id", recordID)
+	return c.change(ctx, "/dns/mod-record.json", params)
+}
+
+func (c *Client) DeleteRecord(ctx context.Context, zone string, recordID string) error {
+	return c.change(ctx, "/dns/delete-record.json", url.Values{"domain-name": {zone}, "record-id": {recordID}})
+}
+
+func recordParams(record RecordRequest) url.Values {
+	params := url.Values{
+		"host":   {record.Host},
+		"record": {record.Record},
+		"ttl":    {strconv.Itoa(record.TTL)},
+	}
+	if record.Priority != nil {
+		params.Set("priority", strconv.Itoa(*record.Priority))
+	}
+	if record.Weight != nil {
+		params.Set("weight", strconv.Itoa(*record.Weight))
+	}
+	if record.Port != nil {
+		params.Set("port", strconv.Itoa(*record.Port))
+	}
+	return params
+}
+
+// change calls a method changing a zone, which returns a status.
+func (c *Client) change(ctx context.Context, path string, params url.Values) error {
+	var status statusResponse
+	if err := c.do(ctx, path, params, &status); err != nil {
+		return err
+	}
+	if !strings.EqualFold(status.Status, "Success") {
+		return &APIError{Path: path, Description: status.StatusDescription}
+	}
+	return nil
+}
+
+// do posts the parameters to an API method, with the credentials, and decodes the response into the result. A
+// failed status is returned as an error, whatever the method.
+func (c *Client) do(ctx context.Context, path string, params url.Values, result any) error {
+	form := url.Values{"auth-id": {c.authID}, "auth-password": {c.authPassword}}
+	for name, values := range params {
+		form[name] = values
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", externaldns.UserAgent())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("cloudns: %s failed with HTTP status %s", path, resp.Status)
+	}
+
+	body = bytes.TrimSpace(body)
+	if bytes.HasPrefix(body, []byte("{")) {
+		var status statusResponse
+		if err := json.Unmarshal(body, &status); err == nil && strings.EqualFold(status.Status, "Failed") {
+			return &APIError{Path: path, Description: status.StatusDescription}
+		}
+	}
+	if bytes.Equal(body, []byte("[]")) {
+		// the empty listings are arrays, even when their items are keyed
+		return nil
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("cloudns: failed to decode the response of %s: %w", path, err)
+	}
+	return nil
+}
+
+func asString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return ""
+}
+
+func asInt(v any) int {
+	i, _ := strconv.Atoi(asString(v))
+	return i
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudns
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client := NewClient("1234", "secret", server.Client())
+	client.endpoint = server.URL
+	return client
+}
+
+func TestClientListZonesPagination(t *testing.T) {
+	var pages []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "/dns/list-zones.json", r.URL.Path)
+		assert.Equal(t, "1234", r.PostForm.Get("auth-id"))
+		assert.Equal(t, "secret", r.PostForm.Get("auth-password"))
+		assert.Equal(t, "100", r.PostForm.Get("rows-per-page"))
+		page := r.PostForm.Get("page")
+		pages = append(pages, page)
+		if page == "1" {
+			zones := "["
+			for i := range rowsPerPage {
+				if i > 0 {
+					zones += ","
+				}
+				zones += fmt.Sprintf(`{"name":"zone%d.com","type":"master","zone":"domain","status":"1"}`, i)
+			}
+			_, _ = w.Write([]byte(zones + "]"))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"name":"example.com","type":"master"},{"name":"example.net","type":"slave"}]`))
+	})
+
+	zones, err := client.ListZones(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, pages)
+	assert.Len(t, zones, rowsPerPage+1)
+	assert.Equal(t, "example.com", zones[rowsPerPage])
+}
+
+func TestClientListRecords(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		if r.PostForm.Get("domain-name") == "empty.com" {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"1":{"id":"1","type":"SRV","host":"_sip._tcp","record":"sip.example.com","ttl":"3600","priority":10,"weight":5,"port":5060,"status":1}}`))
+	})
+
+	records, err := client.ListRecords(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []Record{{ID: "1", Type: "SRV", Host: "_sip._tcp", Record: "sip.example.com", TTL: 3600, Priority: 10, Weight: 5, Port: 5060}}, records)
+
+	records, err = client.ListRecords(context.Background(), "empty.com")
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestClientCreateRecord(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "/dns/add-record.json", r.URL.Path)
+		assert.Equal(t, "example.com", r.PostForm.Get("domain-name"))
+		assert.Equal(t, "MX", r.PostForm.Get("record-type"))
+		assert.Empty(t, r.PostForm.Get("host"))
+		assert.Equal(t, "mail.example.com", r.PostForm.Get("record"))
+		assert.Equal(t, "300", r.PostForm.Get("ttl"))
+		assert.Equal(t, "10", r.PostForm.Get("priority"))
+		_, hasPort := r.PostForm["port"]
+		assert.False(t, hasPort)
+		_, _ = w.Write([]byte(`{"status":"Success","statusDescription":"The record was added successfully.","data":{"id":42}}`))
+	})
+
+	priority := 10
+	require.NoError(t, client.CreateRecord(context.Background(), "example.com", RecordRequest{Type: "MX", Record: "mail.example.com", TTL: 300, Priority: &priority}))
+}
+
+func TestClientAPIError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"Failed","statusDescription":"Invalid authentication, incorrect auth-id or auth-password."}`))
+	})
+
+	_, err := client.ListZones(context.Background())
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.EqualError(t, err, "cloudns: /dns/list-zones.json failed: Invalid authentication, incorrect auth-id or auth-password.")
+
+	err = client.DeleteRecord(context.Background(), "example.com", strconv.Itoa(1))
+	assert.EqualError(t, err, "cloudns: /dns/delete-record.json failed: Invalid authentication, incorrect auth-id or auth-password.")
+}
@@ -0,0 +1,380 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudns
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// defaultTTL is the TTL of the records without a configured TTL.
+const defaultTTL = 3600
+
+// allowedTTLs are the only TTLs ClouDNS accepts, in ascending order.
+var allowedTTLs = []int{60, 300, 900, 1800, 3600, 21600, 43200, 86400, 172800, 259200, 604800, 1209600, 2592000}
+
+// ClouDNSProvider is an implementation of Provider for ClouDNS.
+type ClouDNSProvider struct {
+	provider.BaseProvider
+	Client DNSClient
+	// only consider hosted zones managing domains ending in this suffix
+	domainFilter endpoint.DomainFilter
+	DryRun       bool
+}
+
+type cloudnsChangeCreate struct {
+	Options RecordRequest
+}
+
+type cloudnsChangeUpdate struct {
+	Record  Record
+	Options RecordRequest
+}
+
+type cloudnsChangeDelete struct {
+	Record Record
+}
+
+// cloudnsChanges contains the changes to apply to the DNS records of a zone
+type cloudnsChanges struct {
+	Creates []cloudnsChangeCreate
+	Updates []cloudnsChangeUpdate
+	Deletes []cloudnsChangeDelete
+}
+
+// NewClouDNSProvider initializes a new ClouDNS based Provider.
+func NewClouDNSProvider(domainFilter endpoint.DomainFilter, dryRun bool) (*ClouDNSProvider, error) {
+	authID := os.Getenv("CLOUDNS_AUTH_ID")
+	authPassword := os.Getenv("CLOUDNS_AUTH_PASSWORD")
+	if authID == "" || authPassword == "" {
+		return nil, fmt.Errorf("no API credentials found, set CLOUDNS_AUTH_ID and CLOUDNS_AUTH_PASSWORD")
+	}
+
+	return &ClouDNSProvider{
+		Client:       NewClient(authID, authPassword, &http.Client{Timeout: 30 * time.Second}),
+		domainFilter: domainFilter,
+		DryRun:       dryRun,
+	}, nil
+}
+
+// Zones returns the list of hosted zones.
+func (p *ClouDNSProvider) Zones(ctx context.Context) ([]string, error) {
+	zones, err := p.Client.ListZones(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zones: %w", err)
+	}
+
+	var filtered []string
+	for _, zone := range zones {
+		if p.domainFilter.Match(zone) {
+			filtered = append(filtered, zone)
+		}
+	}
+	return filtered, nil
+}
+
+// SupportedRecordType returns whether the record type is managed. The MX records are managed in addition to the
+// default types, but not the NS records, which ClouDNS creates with the zones.
+func (p *ClouDNSProvider) SupportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeMX:
+		return true
+	case endpoint.RecordTypeNS:
+		// the NS records of the zones are managed by ClouDNS
+		return false
+	default:
+		return provider.SupportedRecordType(recordType)
+	}
+}
+
+// AdjustEndpoints rounds the TTLs up to the closest TTL ClouDNS accepts, for the records not to be updated on every
+// synchronization.
+func (p *ClouDNSProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	for _, ep := range endpoints {
+		if ep.RecordTTL.IsConfigured() {
+			ep.RecordTTL = endpoint.TTL(allowedTTL(int(ep.RecordTTL)))
+		}
+	}
+	return endpoints, nil
+}
+
+// Records returns the list of records in all the zones, the records of a name and type are merged into a single
+// endpoint.
+func (p *ClouDNSProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, zone := range zones {
+		records, err := p.Client.ListRecords(ctx, zone)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list records of zone %s: %w", zone, err)
+		}
+		sortRecords(records)
+
+		for _, r := range records {
+			if p.SupportedRecordType(r.Type) {
+				endpoints = append(endpoints, endpoint.NewEndpointWithTTL(recordDNSName(zone, r.Host), r.Type, endpoint.TTL(r.TTL), recordTarget(r)))
+			}
+		}
+	}
+	endpoints = provider.MergeEndpointsByNameType(endpoints)
+
+	log.WithFields(log.Fields{
+		"endpoints": endpoints,
+	}).Debug("Endpoints generated from ClouDNS")
+
+	return endpoints, nil
+}
+
+// ApplyChanges applies the given changes, zone by zone. The records of a zone are listed at its first change and
+// compared with the targets of each endpoint, ClouDNS holding a record per value.
+func (p *ClouDNSProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return err
+	}
+
+	zoneNameIDMapper := provider.ZoneIDName{}
+	for _, zone := range zones {
+		zoneNameIDMapper.Add(zone, zone)
+	}
+
+	changesByZone := map[string]*cloudnsChanges{}
+	recordsByZone := map[string][]Record{}
+	err = provider.ForEachEndpointTargets(changes, func(ep *endpoint.Endpoint, targets []string, prune bool) error {
+		zone, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zone == "" {
+			log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", ep.DNSName)
+			return nil
+		}
+		if _, ok := changesByZone[zone]; !ok {
+			records, err := p.Client.ListRecords(ctx, zone)
+			if err != nil {
+				return fmt.Errorf("failed to list records of zone %s: %w", zone, err)
+			}
+			sortRecords(records)
+			recordsByZone[zone] = records
+			changesByZone[zone] = &cloudnsChanges{}
+		}
+		return changesByZone[zone].add(zone, recordsByZone[zone], ep, targets, prune)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, zone := range zones {
+		if zoneChanges, ok := changesByZone[zone]; ok {
+			if err := p.submitChanges(ctx, zone, zoneChanges); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// add adds the changes turning the existing records of the endpoint's host and type into a record per target. The
+// TTL of a record is only updated when it differs from the allowed TTL of the endpoint.
+func (c *cloudnsChanges) add(zone string, existing []Record, ep *endpoint.Endpoint, targets []string, prune bool) error {
+	host := recordHost(zone, ep.DNSName)
+	current := slices.DeleteFunc(slices.Clone(existing), func(r Record) bool { return r.Host != host || r.Type != ep.RecordType })
+	diff := provider.DiffRecords(current, targets, prune, recordTarget, func(r Record) bool {
+		return ep.RecordTTL.IsConfigured() && r.TTL != allowedTTL(int(ep.RecordTTL))
+	})
+
+	for _, target := range diff.Create {
+		options, err := newRecordRequest(host, ep.RecordType, target, ep.RecordTTL)
+		if err != nil {
+			return fmt.Errorf("invalid target %q of %s record %s: %w", target, ep.RecordType, ep.DNSName, err)
+		}
+		c.Creates = append(c.Creates, cloudnsChangeCreate{Options: options})
+	}
+	for _, update := range diff.Update {
+		options, err := newRecordRequest(host, ep.RecordType, update.Target, ep.RecordTTL)
+		if err != nil {
+			return fmt.Errorf("invalid target %q of %s record %s: %w", update.Target, ep.RecordType, ep.DNSName, err)
+		}
+		c.Updates = append(c.Updates, cloudnsChangeUpdate{Record: update.Record, Options: options})
+	}
+	for _, record := range diff.Delete {
+		c.Deletes = append(c.Deletes, cloudnsChangeDelete{Record: record})
+	}
+	return nil
+}
+
+// submitChanges applies the changes of a zone with a request per record, the ClouDNS API having no batch endpoint. The
+// deletions come first, so that a CNAME record can replace the records of its host.
+func (p *ClouDNSProvider) submitChanges(ctx context.Context, zone string, changes *cloudnsChanges) error {
+	for _, change := range changes.Deletes {
+		logFields := log.Fields{
+			"record":   change.Record.Host,
+			"type":     change.Record.Type,
+			"target":   change.Record.Record,
+			"action":   "Delete",
+			"zoneName": zone,
+		}
+		log.WithFields(logFields).Info("Deleting record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.DeleteRecord(ctx, zone, change.Record.ID); err != nil {
+			return fmt.Errorf("failed to delete %s record %s of zone %s: %w", change.Record.Type, change.Record.Host, zone, err)
+		}
+	}
+
+	for _, change := range changes.Updates {
+		logFields := log.Fields{
+			"record":   change.Record.Host,
+			"type":     change.Record.Type,
+			"target":   change.Options.Record,
+			"ttl":      change.Options.TTL,
+			"action":   "Update",
+			"zoneName": zone,
+		}
+		log.WithFields(logFields).Info("Updating record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.UpdateRecord(ctx, zone, change.Record.ID, change.Options); err != nil {
+			return fmt.Errorf("failed to update %s record %s of zone %s: %w", change.Record.Type, change.Record.Host, zone, err)
+		}
+	}
+
+	for _, change := range changes.Creates {
+		logFields := log.Fields{
+			"record":   change.Options.Host,
+			"type":     change.Options.Type,
+			"target":   change.Options.Record,
+			"action":   "Create",
+			"zoneName": zone,
+		}
+		log.WithFields(logFields).Info("Creating record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.CreateRecord(ctx, zone, change.Options); err != nil {
+			return fmt.Errorf("failed to create %s record %s of zone %s: %w", change.Options.Type, change.Options.Host, zone, err)
+		}
+	}
+
+	return nil
+}
+
+// allowedTTL returns the closest TTL ClouDNS accepts that isn't lower than the TTL.
+func allowedTTL(ttl int) int {
+	for _, allowed := range allowedTTLs {
+		if ttl <= allowed {
+			return allowed
+		}
+	}
+	return allowedTTLs[len(allowedTTLs)-1]
+}
+
+// recordHost returns the host of a record relative to its zone, the apex being the empty host.
+func recordHost(zone, dnsName string) string {
+	if dnsName == zone {
+		return ""
+	}
+	return strings.TrimSuffix(dnsName, "."+zone)
+}
+
+// recordDNSName returns the DNS name of a record of a zone.
+func recordDNSName(zone, host string) string {
+	if host == "" || host == "@" {
+		return zone
+	}
+	return host + "." + zone
+}
+
+// recordTarget returns the endpoint target of a record, the priority of MX records and the priority, weight and port
+// of SRV records are stored apart from their record.
+func recordTarget(r Record) string {
+	switch r.Type {
+	case endpoint.RecordTypeMX:
+		return fmt.Sprintf("%d %s", r.Priority, r.Record)
+	case endpoint.RecordTypeSRV:
+		return fmt.Sprintf("%d %d %d %s", r.Priority, r.Weight, r.Port, r.Record)
+	}
+	return r.Record
+}
+
+// newRecordRequest returns the request creating the record of an endpoint target. MX targets are of the form
+// "10 mail.example.com" and SRV targets of the form "10 5 443 target.example.com".
+func newRecordRequest(host, recordType, target string, ttl endpoint.TTL) (RecordRequest, error) {
+	request := RecordRequest{Type: recordType, Host: host, Record: target, TTL: defaultTTL}
+	if ttl.IsConfigured() {
+		request.TTL = allowedTTL(int(ttl))
+	}
+
+	var fields []string
+	switch recordType {
+	case endpoint.RecordTypeMX:
+		fields = strings.Fields(target)
+		if len(fields) != 2 {
+			return RecordRequest{}, fmt.Errorf("expected a priority and an exchange")
+		}
+	case endpoint.RecordTypeSRV:
+		fields = strings.Fields(target)
+		if len(fields) != 4 {
+			return RecordRequest{}, fmt.Errorf("expected a priority, a weight, a port and a target")
+		}
+	default:
+		return request, nil
+	}
+
+	values := make([]int, len(fields)-1)
+	for i, field := range fields[:len(fields)-1] {
+		value, err := strconv.Atoi(field)
+		if err != nil {
+			return RecordRequest{}, fmt.Errorf("invalid number %q", field)
+		}
+		values[i] = value
+	}
+	request.Priority = &values[0]
+	if recordType == endpoint.RecordTypeSRV {
+		request.Weight = &values[1]
+		request.Port = &values[2]
+	}
+	request.Record = fields[len(fields)-1]
+	return request, nil
+}
+
+// sortRecords orders the records by ID, which are numeric, so that the targets are listed in the order the records
+// were created.
+func sortRecords(records []Record) {
+	slices.SortFunc(records, func(a, b Record) int {
+		if c := cmp.Compare(len(a.ID), len(b.ID)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+}
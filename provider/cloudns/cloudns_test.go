@@ -0,0 +1,256 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// fakeClouDNS is an in-memory ClouDNS API.
+type fakeClouDNS struct {
+	t      *testing.T
+	zones  map[string][]Record
+	nextID int
+	writes int
+}
+
+func newFakeClouDNS(t *testing.T) *fakeClouDNS {
+	return &fakeClouDNS{t: t, zones: testClouDNSZones(), nextID: 100}
+}
+
+func (f *fakeClouDNS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	require.NoError(f.t, r.ParseForm())
+	assert.Equal(f.t, "1234", r.PostForm.Get("auth-id"))
+	form := r.PostForm
+	zone := form.Get("domain-name")
+	write := func(v any) {
+		require.NoError(f.t, json.NewEncoder(w).Encode(v))
+	}
+	success := map[string]string{"status": "Success", "statusDescription": "OK"}
+
+	switch r.URL.Path {
+	case "/dns/list-zones.json":
+		var names []string
+		for name := range f.zones {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		items := []map[string]string{}
+		for _, name := range names {
+			items = append(items, map[string]string{"name": name, "type": "master", "zone": "domain", "status": "1"})
+		}
+		write(items)
+	case "/dns/records.json":
+		if len(f.zones[zone]) == 0 {
+			write([]any{})
+			return
+		}
+		items := map[string]map[string]string{}
+		for _, record := range f.zones[zone] {
+			item := map[string]string{"id": record.ID, "type": record.Type, "host": record.Host, "record": record.Record, "ttl": strconv.Itoa(record.TTL)}
+			if record.Type == endpoint.RecordTypeMX || record.Type == endpoint.RecordTypeSRV {
+				item["priority"] = strconv.Itoa(record.Priority)
+			}
+			if record.Type == endpoint.RecordTypeSRV {
+				item["weight"] = strconv.Itoa(record.Weight)
+				item["port"] = strconv.Itoa(record.Port)
+			}
+			items[record.ID] = item
+		}
+		write(items)
+	case "/dns/add-record.json":
+		f.nextID++
+		f.writes++
+		f.zones[zone] = append(f.zones[zone], Record{
+			ID:       strconv.Itoa(f.nextID),
+			Type:     form.Get("record-type"),
+			Host:     form.Get("host"),
+			Record:   form.Get("record"),
+			TTL:      asInt(form.Get("ttl")),
+			Priority: asInt(form.Get("priority")),
+			Weight:   asInt(form.Get("weight")),
+			Port:     asInt(form.Get("port")),
+		})
+		write(success)
+	case "/dns/mod-record.json", "/dns/delete-record.json":
+		i := slices.IndexFunc(f.zones[zone], func(record Record) bool { return record.ID == form.Get("record-id") })
+		if i < 0 {
+			write(map[string]string{"status": "Failed", "statusDescription": "Invalid record-id param."})
+			return
+		}
+		f.writes++
+		if r.URL.Path == "/dns/delete-record.json" {
+			f.zones[zone] = slices.Delete(f.zones[zone], i, i+1)
+		} else {
+			record := &f.zones[zone][i]
+			record.Host = form.Get("host")
+			record.Record = form.Get("record")
+			record.TTL = asInt(form.Get("ttl"))
+			record.Priority = asInt(form.Get("priority"))
+			record.Weight = asInt(form.Get("weight"))
+			record.Port = asInt(form.Get("port"))
+		}
+		write(success)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func testClouDNSZones() map[string][]Record {
+	return map[string][]Record{
+		"example.com": {
+			{ID: "1", Type: "NS", Host: "", Record: "pns41.cloudns.net", TTL: 3600},
+			{ID: "2", Type: "A", Host: "", Record: "1.2.3.4", TTL: 3600},
+			{ID: "3", Type: "A", Host: "www", Record: "1.2.3.4", TTL: 300},
+			{ID: "4", Type: "A", Host: "www", Record: "1.2.3.5", TTL: 300},
+			{ID: "5", Type: "TXT", Host: "www", Record: "heritage=external-dns", TTL: 300},
+			{ID: "6", Type: "MX", Host: "", Record: "mail.example.com", Priority: 10, TTL: 3600},
+			{ID: "7", Type: "SRV", Host: "_sip._tcp", Record: "sip.example.com", Priority: 10, Weight: 5, Port: 5060, TTL: 3600},
+		},
+		"example.org": {},
+	}
+}
+
+func newTestProvider(t *testing.T, api *fakeClouDNS, domains ...string) *ClouDNSProvider {
+	return &ClouDNSProvider{
+		Client:       newTestClient(t, api.ServeHTTP),
+		domainFilter: endpoint.NewDomainFilter(domains),
+	}
+}
+
+func TestNewClouDNSProvider(t *testing.T) {
+	t.Setenv("CLOUDNS_AUTH_ID", "1234")
+	t.Setenv("CLOUDNS_AUTH_PASSWORD", "secret")
+	_, err := NewClouDNSProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.NoError(t, err)
+
+	t.Setenv("CLOUDNS_AUTH_PASSWORD", "")
+	_, err = NewClouDNSProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.EqualError(t, err, "no API credentials found, set CLOUDNS_AUTH_ID and CLOUDNS_AUTH_PASSWORD")
+}
+
+func TestClouDNSProviderAdjustEndpoints(t *testing.T) {
+	p := &ClouDNSProvider{}
+
+	endpoints, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("unset.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("low.example.com", endpoint.RecordTypeA, 30, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("between.example.com", endpoint.RecordTypeA, 1000, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("allowed.example.com", endpoint.RecordTypeA, 900, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("high.example.com", endpoint.RecordTypeA, 9999999, "1.2.3.4"),
+	})
+	require.NoError(t, err)
+	var ttls []endpoint.TTL
+	for _, ep := range endpoints {
+		ttls = append(ttls, ep.RecordTTL)
+	}
+	assert.Equal(t, []endpoint.TTL{0, 60, 1800, 900, 2592000}, ttls)
+}
+
+func TestClouDNSProviderRecords(t *testing.T) {
+	p := newTestProvider(t, newFakeClouDNS(t), "example.com", "example.org")
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 3600, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeTXT, 300, "heritage=external-dns"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+		endpoint.NewEndpointWithTTL("_sip._tcp.example.com", endpoint.RecordTypeSRV, 3600, "10 5 5060 sip.example.com"),
+	}, endpoints)
+}
+
+func TestClouDNSProviderApplyChanges(t *testing.T) {
+	api := newFakeClouDNS(t)
+	p := newTestProvider(t, api, "example.com", "example.org")
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeAAAA, "2001:db8::1", "2001:db8::2"),
+			endpoint.NewEndpointWithTTL("_xmpp._tcp.example.org", endpoint.RecordTypeSRV, 300, "20 0 5222 xmpp.example.org"),
+			// not in a managed zone
+			endpoint.NewEndpoint("www.example.net", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5"),
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.5", "1.2.3.6"),
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 900, "10 mail.example.com", "20 backup.example.com"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeTXT, "heritage=external-dns"),
+		},
+	}))
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 3600, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.5", "1.2.3.6"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 900, "10 mail.example.com", "20 backup.example.com"),
+		endpoint.NewEndpointWithTTL("_sip._tcp.example.com", endpoint.RecordTypeSRV, 3600, "10 5 5060 sip.example.com"),
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeAAAA, defaultTTL, "2001:db8::1", "2001:db8::2"),
+		endpoint.NewEndpointWithTTL("_xmpp._tcp.example.org", endpoint.RecordTypeSRV, 300, "20 0 5222 xmpp.example.org"),
+	}, endpoints)
+	// the unchanged target of www.example.com is kept
+	assert.Contains(t, api.zones["example.com"], Record{ID: "4", Type: "A", Host: "www", Record: "1.2.3.5", TTL: 300})
+	// the TTL of the MX record is updated in place
+	assert.Contains(t, api.zones["example.com"], Record{ID: "6", Type: "MX", Host: "", Record: "mail.example.com", Priority: 10, TTL: 900})
+}
+
+func TestClouDNSProviderApplyChangesDryRun(t *testing.T) {
+	api := newFakeClouDNS(t)
+	p := newTestProvider(t, api, "example.com")
+	p.DryRun = true
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.4", "1.2.3.5")},
+	}))
+	assert.Zero(t, api.writes)
+}
+
+func TestClouDNSProviderApplyChangesInvalidTarget(t *testing.T) {
+	api := newFakeClouDNS(t)
+	p := newTestProvider(t, api, "example.com")
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("_sip._udp.example.com", endpoint.RecordTypeSRV, "10 sip.example.com")},
+	})
+	require.EqualError(t, err, `invalid target "10 sip.example.com" of SRV record _sip._udp.example.com: expected a priority, a weight, a port and a target`)
+
+	err = p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "high mail.example.com")},
+	})
+	require.EqualError(t, err, `invalid target "high mail.example.com" of MX record example.com: invalid number "high"`)
+	assert.Zero(t, api.writes)
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constellix
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+// defaultEndpoint is the base URL of the Constellix DNS API.
+const defaultEndpoint = "https://api.dns.constellix.com/v1"
+
+// Domain is a domain of the account.
+type Domain struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// RoundRobin is a value of a record set, the fields but the value depending on the record type.
+type RoundRobin struct {
+	Value    string `json:"value"`
+	Level    *int   `json:"level,omitempty"`
+	Priority *int   `json:"priority,omitempty"`
+	Weight   *int   `json:"weight,omitempty"`
+	Port     *int   `json:"port,omitempty"`
+}
+
+// RecordSet is the set of the records of a name and type, served in round robin. The name is relative to the domain,
+// the apex being the empty name. CNAME record sets hold a single host instead of round robin values.
+type RecordSet struct {
+	ID         int64        `json:"id,omitempty"`
+	Name       string       `json:"name"`
+	TTL        int          `json:"ttl"`
+	Host       string       `json:"host,omitempty"`
+	RoundRobin []RoundRobin `json:"roundRobin,omitempty"`
+}
+
+// APIError is an error returned by the Constellix API.
+type APIError struct {
+	StatusCode int
+	Errors     []string `json:"errors"`
+}
+
+func (e *APIError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("constellix: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("constellix: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), strings.Join(e.Errors, "; "))
+}
+
+// DNSClient is the interface of the Constellix API used by the provider.
+type DNSClient interface {
+	ListDomains(ctx context.Context) ([]Domain, error)
+	ListRecordSets(ctx context.Context, domainID int64, recordType string) ([]RecordSet, error)
+	CreateRecordSet(ctx context.Context, domainID int64, recordType string, recordSet RecordSet) error
+	UpdateRecordSet(ctx context.Context, domainID int64, recordType string, recordSet RecordSet) error
+	DeleteRecordSet(ctx context.Context, domainID int64, recordType string, recordSetID int64) error
+}
+
+// Client calls the Constellix API, signing the requests with the secret key.
+type Client struct {
+	apiKey     string
+	secretKey  string
+	endpoint   string
+	httpClient *http.Client
+	// now returns the time the requests are signed at, replaced in tests.
+	now func() time.Time
+}
+
+// NewClient returns a client of the Constellix API authenticating with the API key and secret key.
+func NewClient(apiKey, secretKey string, httpClient *http.Client) *Client {
+	return &Client{
+		apiKey:     apiKey,
+		secretKey:  secretKey,
+		endpoint:   defaultEndpoint,
+		httpClient: httpClient,
+		now:        time.Now,
+	}
+}
+
+// ListDomains returns the domains of the account.
+func (c *Client) ListDomains(ctx context.Context) ([]Domain, error) {
+	var domains []Domain
+	if err := c.do(ctx, http.MethodGet, "/domains", nil, &domains); err != nil {
+		return nil, err
+	}
+	return domains, nil
+}
+
+// ListRecordSets returns the record sets of a type of a domain.
+func (c *Client) ListRecordSets(ctx context.Context, domainID int64, recordType string) ([]RecordSet, error) {
+	var recordSets []RecordSet
+	if err := c.do(ctx, http.MethodGet, recordsPath(domainID, recordType), nil, &recordSets); err != nil {
+		return nil, err
+	}
+	return recordSets, nil
+}
+
+// CreateRecordSet creates a record set of a domain.
+func (c *Client) CreateRecordSet(ctx context.Context, domainID int64, recordType string, recordSet RecordSet) error {
+	return c.do(ctx, http.MethodPost, recordsPath(domainID, recordType), recordSet, nil)
+}
+
+// UpdateRecordSet replaces the TTL and the values of a record set.
+func (c *Client) UpdateRecordSet(ctx context.Context, domainID int64, recordType string, recordSet RecordSet) error {
+	path := fmt.Sprintf("%s/%d", recordsPath(domainID, recordType), recordSet.ID)
+	recordSet.ID = 0
+	return c.do(ctx, http.MethodPut, path, recordSet, nil)
+}
+
+// DeleteRecordSet deletes a record set.
+func (c *Client) DeleteRecordSet(ctx context.Context, domainID int64, recordType string, recordSetID int64) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("%s/%d", recordsPath(domainID, recordType), recordSetID), nil, nil)
+}
+
+func recordsPath(domainID int64, recordType string) string {
+	return fmt.Sprintf("/domains/%d/records/%s", domainID, recordType)
+}
+
+// securityToken returns the token authenticating a request, of the form "apiKey:hmac:timestamp", the HMAC being the
+// HMAC-SHA1 of the timestamp in milliseconds with the secret key.
+func (c *Client) securityToken() string {
+	timestamp := strconv.FormatInt(c.now().UnixMilli(), 10)
+	mac := hmac.New(sha1.New, []byte(c.secretKey))
+	mac.Write([]byte(timestamp))
+	return c.apiKey + ":" + base64.StdEncoding.EncodeToString(mac.Sum(nil)) + ":" + timestamp
+}
+
+// do sends a request to the API, with the body encoded as JSON, and decodes the response into the result.
+func (c *Client) do(ctx context.Context, method, path string, body, result any) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-cns-security-token", c.securityToken())
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", externaldns.UserAgent())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		_ = json.NewDecoder(resp.Body).Decode(apiErr)
+		return apiErr
+	}
+	if result == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
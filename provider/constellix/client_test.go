@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constellix
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a client of a test server running the handler, whose HMAC signatures are computed at a fixed
+// time for the tests to compare them.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client := NewClient("api-key", "secret-key", server.Client())
+	client.endpoint = server.URL
+	client.now = func() time.Time { return time.UnixMilli(1700000000000) }
+	return client
+}
+
+func TestClientSecurityToken(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		// the base64 HMAC-SHA1 of the timestamp with the secret key
+		assert.Equal(t, "api-key:Dhf1y3gqwU2F1AsxuAO2Lx1tCmY=:1700000000000", req.Header.Get("x-cns-security-token"))
+		assert.Equal(t, "/domains", req.URL.Path)
+		fmt.Fprint(w, `[{"id":1,"name":"example.com","soa":{}}]`)
+	})
+
+	domains, err := client.ListDomains(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Domain{{ID: 1, Name: "example.com"}}, domains)
+}
+
+func TestClientUpdateRecordSet(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPut, req.Method)
+		assert.Equal(t, "/domains/1/records/MX/42", req.URL.Path)
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"name":"","ttl":600,"roundRobin":[{"value":"mail.example.com.","level":0}]}`, string(body))
+		fmt.Fprint(w, `{"success":"Record updated successfully"}`)
+	})
+
+	level := 0
+	require.NoError(t, client.UpdateRecordSet(context.Background(), 1, "MX", RecordSet{
+		ID: 42, TTL: 600, RoundRobin: []RoundRobin{{Value: "mail.example.com.", Level: &level}},
+	}))
+}
+
+func TestClientAPIError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"errors":["Record with this name already exists","TTL is invalid"]}`)
+	})
+
+	err := client.CreateRecordSet(context.Background(), 1, "A", RecordSet{Name: "www", TTL: 1})
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.EqualError(t, err, "constellix: 400 Bad Request: Record with this name already exists; TTL is invalid")
+}
@@ -0,0 +1,383 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constellix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// defaultTTL is the TTL of the record sets without a configured TTL.
+const defaultTTL = 3600
+
+// supportedRecordTypes are the record types managed by the provider, in the order they're listed.
+var supportedRecordTypes = []string{
+	endpoint.RecordTypeA,
+	endpoint.RecordTypeAAAA,
+	endpoint.RecordTypeCNAME,
+	endpoint.RecordTypeTXT,
+	endpoint.RecordTypeMX,
+	endpoint.RecordTypeSRV,
+}
+
+// ConstellixProvider is an implementation of Provider for Constellix DNS.
+type ConstellixProvider struct {
+	provider.BaseProvider
+	Client DNSClient
+	// only consider hosted zones managing domains ending in this suffix
+	domainFilter endpoint.DomainFilter
+	DryRun       bool
+}
+
+// constellixChange is a change of the record set of a name and type.
+type constellixChange struct {
+	Domain     Domain
+	RecordType string
+	RecordSet  RecordSet
+}
+
+// constellixChanges contains all changes to apply to DNS
+type constellixChanges struct {
+	Creates []constellixChange
+	Updates []constellixChange
+	Deletes []constellixChange
+}
+
+// NewConstellixProvider initializes a new Constellix DNS based Provider.
+func NewConstellixProvider(domainFilter endpoint.DomainFilter, dryRun bool) (*ConstellixProvider, error) {
+	apiKey := os.Getenv("CONSTELLIX_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("no API key found, set CONSTELLIX_API_KEY")
+	}
+	secretKey := os.Getenv("CONSTELLIX_SECRET_KEY")
+	if secretKey == "" {
+		return nil, fmt.Errorf("no secret key found, set CONSTELLIX_SECRET_KEY")
+	}
+
+	return &ConstellixProvider{
+		Client:       NewClient(apiKey, secretKey, &http.Client{Timeout: 30 * time.Second}),
+		domainFilter: domainFilter,
+		DryRun:       dryRun,
+	}, nil
+}
+
+// Zones returns the list of hosted zones.
+func (p *ConstellixProvider) Zones(ctx context.Context) ([]Domain, error) {
+	domains, err := p.Client.ListDomains(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list domains: %w", err)
+	}
+
+	var zones []Domain
+	for _, domain := range domains {
+		if p.domainFilter.Match(domain.Name) {
+			zones = append(zones, domain)
+		}
+	}
+	return zones, nil
+}
+
+// SupportedRecordType returns true for the record types whose record sets external-dns manages on Constellix DNS,
+// each of them being listed with its own endpoint of the API.
+func (p *ConstellixProvider) SupportedRecordType(recordType string) bool {
+	return slices.Contains(supportedRecordTypes, recordType)
+}
+
+// Records returns the list of records in all the zones, a record set being an endpoint.
+func (p *ConstellixProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, zone := range zones {
+		for _, recordType := range supportedRecordTypes {
+			recordSets, err := p.Client.ListRecordSets(ctx, zone.ID, recordType)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list %s records of domain %s: %w", recordType, zone.Name, err)
+			}
+			for _, rs := range recordSets {
+				endpoints = append(endpoints, endpoint.NewEndpointWithTTL(recordDNSName(zone.Name, rs.Name), recordType, endpoint.TTL(rs.TTL), recordSetTargets(recordType, rs)...))
+			}
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"endpoints": endpoints,
+	}).Debug("Endpoints generated from Constellix DNS")
+
+	return endpoints, nil
+}
+
+// ApplyChanges applies the given changes. The record set of a name and type is replaced as a whole, a creation adding
+// its targets to the record set of the name and type when there's one already.
+func (p *ConstellixProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return err
+	}
+
+	zonesByID := map[string]Domain{}
+	zoneNameIDMapper := provider.ZoneIDName{}
+	for _, zone := range zones {
+		id := strconv.FormatInt(zone.ID, 10)
+		zonesByID[id] = zone
+		zoneNameIDMapper.Add(id, zone.Name)
+	}
+
+	recordSetsByDomainType := map[string][]RecordSet{}
+	existingRecordSet := func(zone Domain, recordType, name string) (*RecordSet, error) {
+		key := fmt.Sprintf("%d/%s", zone.ID, recordType)
+		recordSets, ok := recordSetsByDomainType[key]
+		if !ok {
+			var err error
+			if recordSets, err = p.Client.ListRecordSets(ctx, zone.ID, recordType); err != nil {
+				return nil, fmt.Errorf("failed to list %s records of domain %s: %w", recordType, zone.Name, err)
+			}
+			recordSetsByDomainType[key] = recordSets
+		}
+		i := slices.IndexFunc(recordSets, func(rs RecordSet) bool { return rs.Name == name })
+		if i < 0 {
+			return nil, nil
+		}
+		return &recordSets[i], nil
+	}
+
+	var constellixChanges constellixChanges
+	err = provider.ForEachEndpointTargets(changes, func(ep *endpoint.Endpoint, targets []string, prune bool) error {
+		zoneID, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zoneID == "" {
+			log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", ep.DNSName)
+			return nil
+		}
+		zone := zonesByID[zoneID]
+		name := recordName(zone.Name, ep.DNSName)
+		existing, err := existingRecordSet(zone, ep.RecordType, name)
+		if err != nil {
+			return err
+		}
+		return constellixChanges.add(zone, existing, ep, name, targets, prune)
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.submitChanges(ctx, constellixChanges)
+}
+
+// add adds the change of the record set of an endpoint. The record sets are updated when they exist and created
+// otherwise, the TTL of the existing record set being kept when the endpoint has none. A record set without targets
+// is deleted, and the values of the existing record set are kept unless pruning.
+func (c *constellixChanges) add(zone Domain, existing *RecordSet, ep *endpoint.Endpoint, name string, targets []string, prune bool) error {
+	if len(targets) == 0 {
+		if existing == nil {
+			log.Debugf("Skipping deletion of %s record %s because it doesn't exist", ep.RecordType, ep.DNSName)
+			return nil
+		}
+		c.Deletes = append(c.Deletes, constellixChange{Domain: zone, RecordType: ep.RecordType, RecordSet: *existing})
+		return nil
+	}
+
+	recordSet, err := newRecordSet(name, ep, targets)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		c.Creates = append(c.Creates, constellixChange{Domain: zone, RecordType: ep.RecordType, RecordSet: recordSet})
+		return nil
+	}
+
+	recordSet.ID = existing.ID
+	if !ep.RecordTTL.IsConfigured() {
+		recordSet.TTL = existing.TTL
+	}
+	if !prune && ep.RecordType != endpoint.RecordTypeCNAME {
+		// the targets are added to the existing ones
+		existingTargets := recordSetTargets(ep.RecordType, *existing)
+		for i, value := range existing.RoundRobin {
+			if !slices.Contains(targets, existingTargets[i]) {
+				recordSet.RoundRobin = append(recordSet.RoundRobin, value)
+			}
+		}
+	}
+	c.Updates = append(c.Updates, constellixChange{Domain: zone, RecordType: ep.RecordType, RecordSet: recordSet})
+	return nil
+}
+
+// submitChanges submits the changes of the record sets to the Constellix API, the deletions first for a CNAME record
+// set to take the place of the other record sets of its name.
+func (p *ConstellixProvider) submitChanges(ctx context.Context, changes constellixChanges) error {
+	for _, group := range []struct {
+		changes []constellixChange
+		action  string
+		message string
+	}{
+		{changes: changes.Deletes, action: "Delete", message: "Deleting record set."},
+		{changes: changes.Updates, action: "Update", message: "Updating record set."},
+		{changes: changes.Creates, action: "Create", message: "Creating record set."},
+	} {
+		for _, change := range group.changes {
+			dnsName := recordDNSName(change.Domain.Name, change.RecordSet.Name)
+			logFields := log.Fields{
+				"record":   dnsName,
+				"type":     change.RecordType,
+				"targets":  recordSetTargets(change.RecordType, change.RecordSet),
+				"ttl":      change.RecordSet.TTL,
+				"action":   group.action,
+				"zoneName": change.Domain.Name,
+			}
+			log.WithFields(logFields).Info(group.message)
+			if p.DryRun {
+				continue
+			}
+
+			var err error
+			switch group.action {
+			case "Delete":
+				err = p.Client.DeleteRecordSet(ctx, change.Domain.ID, change.RecordType, change.RecordSet.ID)
+			case "Update":
+				err = p.Client.UpdateRecordSet(ctx, change.Domain.ID, change.RecordType, change.RecordSet)
+			case "Create":
+				err = p.Client.CreateRecordSet(ctx, change.Domain.ID, change.RecordType, change.RecordSet)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to %s %s record %s of domain %s: %w", strings.ToLower(group.action), change.RecordType, dnsName, change.Domain.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// recordName returns the name of a record relative to its domain, the apex being the empty name.
+func recordName(zone, dnsName string) string {
+	if dnsName == zone {
+		return ""
+	}
+	return strings.TrimSuffix(dnsName, "."+zone)
+}
+
+// recordDNSName returns the DNS name of a record of a domain.
+func recordDNSName(zone, name string) string {
+	if name == "" {
+		return zone
+	}
+	return name + "." + zone
+}
+
+// recordSetTargets returns the endpoint targets of a record set, the names being returned without a trailing dot.
+func recordSetTargets(recordType string, rs RecordSet) []string {
+	if recordType == endpoint.RecordTypeCNAME {
+		return []string{strings.TrimSuffix(rs.Host, ".")}
+	}
+	targets := make([]string, 0, len(rs.RoundRobin))
+	for _, value := range rs.RoundRobin {
+		switch recordType {
+		case endpoint.RecordTypeTXT:
+			targets = append(targets, unquote(value.Value))
+		case endpoint.RecordTypeMX:
+			targets = append(targets, fmt.Sprintf("%d %s", valueOf(value.Level), strings.TrimSuffix(value.Value, ".")))
+		case endpoint.RecordTypeSRV:
+			targets = append(targets, fmt.Sprintf("%d %d %d %s", valueOf(value.Priority), valueOf(value.Weight), valueOf(value.Port), strings.TrimSuffix(value.Value, ".")))
+		default:
+			targets = append(targets, value.Value)
+		}
+	}
+	return targets
+}
+
+// newRecordSet returns the record set of the targets of an endpoint. MX targets are of the form "10 mail.example.com" and SRV
+// targets of the form "10 5 443 target.example.com", the priority coming first. The names are sent fully qualified.
+func newRecordSet(name string, ep *endpoint.Endpoint, targets []string) (RecordSet, error) {
+	recordSet := RecordSet{Name: name, TTL: defaultTTL}
+	if ep.RecordTTL.IsConfigured() {
+		recordSet.TTL = int(ep.RecordTTL)
+	}
+	if ep.RecordType == endpoint.RecordTypeCNAME {
+		recordSet.Host = targets[0] + "."
+		return recordSet, nil
+	}
+
+	for _, target := range targets {
+		value, err := newRoundRobin(ep.RecordType, target)
+		if err != nil {
+			return RecordSet{}, fmt.Errorf("invalid target %q of %s record %s: %w", target, ep.RecordType, ep.DNSName, err)
+		}
+		recordSet.RoundRobin = append(recordSet.RoundRobin, value)
+	}
+	return recordSet, nil
+}
+
+func newRoundRobin(recordType, target string) (RoundRobin, error) {
+	switch recordType {
+	case endpoint.RecordTypeTXT:
+		return RoundRobin{Value: strconv.Quote(target)}, nil
+	case endpoint.RecordTypeMX:
+		levelRaw, exchange, ok := strings.Cut(target, " ")
+		if !ok {
+			return RoundRobin{}, fmt.Errorf("the priority is missing")
+		}
+		level, err := strconv.Atoi(levelRaw)
+		if err != nil {
+			return RoundRobin{}, fmt.Errorf("invalid priority %q", levelRaw)
+		}
+		return RoundRobin{Value: exchange + ".", Level: &level}, nil
+	case endpoint.RecordTypeSRV:
+		fields := strings.Fields(target)
+		if len(fields) != 4 {
+			return RoundRobin{}, fmt.Errorf("expected priority, weight, port and target")
+		}
+		var numbers [3]int
+		for i, raw := range fields[:3] {
+			n, err := strconv.Atoi(raw)
+			if err != nil {
+				return RoundRobin{}, fmt.Errorf("invalid number %q", raw)
+			}
+			numbers[i] = n
+		}
+		return RoundRobin{Value: fields[3] + ".", Priority: &numbers[0], Weight: &numbers[1], Port: &numbers[2]}, nil
+	}
+	return RoundRobin{Value: target}, nil
+}
+
+// unquote returns the text of a TXT value, which Constellix stores quoted.
+func unquote(value string) string {
+	if text, err := strconv.Unquote(value); err == nil {
+		return text
+	}
+	return value
+}
+
+func valueOf(p *int) int {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
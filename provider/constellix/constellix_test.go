@@ -0,0 +1,271 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constellix
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// fakeConstellixAPI is an in-memory Constellix API.
+type fakeConstellixAPI struct {
+	mu      sync.Mutex
+	domains []Domain
+	// record sets by domain ID and type, e.g. "1/A"
+	recordSets map[string][]RecordSet
+	nextID     int64
+	writes     int
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func newFakeConstellixAPI() *fakeConstellixAPI {
+	return &fakeConstellixAPI{
+		domains: []Domain{{ID: 1, Name: "example.com"}, {ID: 2, Name: "example.org"}},
+		recordSets: map[string][]RecordSet{
+			"1/A": {
+				{ID: 10, Name: "api", TTL: 300, RoundRobin: []RoundRobin{{Value: "1.2.3.4"}, {Value: "1.2.3.5"}}},
+			},
+			"1/TXT": {
+				{ID: 11, Name: "api", TTL: 300, RoundRobin: []RoundRobin{{Value: `"heritage=external-dns"`}}},
+			},
+			"1/MX": {
+				{ID: 12, Name: "", TTL: 3600, RoundRobin: []RoundRobin{{Value: "mail.example.com.", Level: intPtr(10)}}},
+			},
+			"1/SRV": {
+				{ID: 13, Name: "_sip._tcp", TTL: 3600, RoundRobin: []RoundRobin{{Value: "sip.example.com.", Priority: intPtr(10), Weight: intPtr(5), Port: intPtr(5060)}}},
+			},
+			"2/CNAME": {
+				{ID: 14, Name: "www", TTL: 300, Host: "example.com."},
+			},
+		},
+		nextID: 100,
+	}
+}
+
+func (f *fakeConstellixAPI) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if req.URL.Path == "/domains" {
+		_ = json.NewEncoder(w).Encode(f.domains)
+		return
+	}
+	// /domains/{id}/records/{type}[/{recordSetID}]
+	parts := strings.Split(strings.TrimPrefix(req.URL.Path, "/"), "/")
+	if len(parts) < 4 || parts[0] != "domains" || parts[2] != "records" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	key := parts[1] + "/" + parts[3]
+	fail := func(message string) {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string][]string{"errors": {message}})
+	}
+	index := func() int {
+		return slices.IndexFunc(f.recordSets[key], func(rs RecordSet) bool { return len(parts) == 5 && strconv.FormatInt(rs.ID, 10) == parts[4] })
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		recordSets := f.recordSets[key]
+		if recordSets == nil {
+			recordSets = []RecordSet{}
+		}
+		_ = json.NewEncoder(w).Encode(recordSets)
+	case http.MethodPost:
+		var rs RecordSet
+		_ = json.NewDecoder(req.Body).Decode(&rs)
+		if slices.ContainsFunc(f.recordSets[key], func(existing RecordSet) bool { return existing.Name == rs.Name }) {
+			fail("Record with this name already exists")
+			return
+		}
+		f.nextID++
+		f.writes++
+		rs.ID = f.nextID
+		f.recordSets[key] = append(f.recordSets[key], rs)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode([]RecordSet{rs})
+	case http.MethodPut:
+		i := index()
+		if i < 0 {
+			fail("Record not found")
+			return
+		}
+		var rs RecordSet
+		_ = json.NewDecoder(req.Body).Decode(&rs)
+		f.writes++
+		rs.ID = f.recordSets[key][i].ID
+		f.recordSets[key][i] = rs
+		_ = json.NewEncoder(w).Encode(map[string]string{"success": "Record updated successfully"})
+	case http.MethodDelete:
+		i := index()
+		if i < 0 {
+			fail("Record not found")
+			return
+		}
+		f.writes++
+		f.recordSets[key] = slices.Delete(f.recordSets[key], i, i+1)
+		_ = json.NewEncoder(w).Encode(map[string]string{"success": "Record deleted successfully"})
+	}
+}
+
+func newTestProvider(t *testing.T, api *fakeConstellixAPI, domains ...string) *ConstellixProvider {
+	return &ConstellixProvider{
+		Client:       newTestClient(t, api.ServeHTTP),
+		domainFilter: endpoint.NewDomainFilter(domains),
+	}
+}
+
+func TestNewConstellixProvider(t *testing.T) {
+	t.Setenv("CONSTELLIX_API_KEY", "api-key")
+	t.Setenv("CONSTELLIX_SECRET_KEY", "secret-key")
+	_, err := NewConstellixProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.NoError(t, err)
+
+	t.Setenv("CONSTELLIX_SECRET_KEY", "")
+	_, err = NewConstellixProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.EqualError(t, err, "no secret key found, set CONSTELLIX_SECRET_KEY")
+
+	t.Setenv("CONSTELLIX_API_KEY", "")
+	_, err = NewConstellixProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.EqualError(t, err, "no API key found, set CONSTELLIX_API_KEY")
+}
+
+func TestConstellixProviderRecords(t *testing.T) {
+	p := newTestProvider(t, newFakeConstellixAPI(), "example.com", "example.org")
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5"),
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeTXT, 300, "heritage=external-dns"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+		endpoint.NewEndpointWithTTL("_sip._tcp.example.com", endpoint.RecordTypeSRV, 3600, "10 5 5060 sip.example.com"),
+		endpoint.NewEndpointWithTTL("www.example.org", endpoint.RecordTypeCNAME, 300, "example.com"),
+	}, endpoints)
+}
+
+func TestConstellixProviderApplyChanges(t *testing.T) {
+	api := newFakeConstellixAPI()
+	p := newTestProvider(t, api, "example.com", "example.org")
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeAAAA, "2001:db8::1", "2001:db8::2"),
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeCNAME, 600, "api.example.com"),
+			// added to the existing record set
+			endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "20 backup.example.com"),
+			// not in a managed zone
+			endpoint.NewEndpoint("www.example.net", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5"),
+			endpoint.NewEndpointWithTTL("_sip._tcp.example.com", endpoint.RecordTypeSRV, 3600, "10 5 5060 sip.example.com"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 600, "1.2.3.5", "1.2.3.6"),
+			endpoint.NewEndpoint("_sip._tcp.example.com", endpoint.RecordTypeSRV, "10 5 5061 sip.example.com"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeTXT, "heritage=external-dns"),
+			endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeCNAME, "example.com"),
+		},
+	}))
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 600, "1.2.3.5", "1.2.3.6"),
+		endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeAAAA, defaultTTL, "2001:db8::1", "2001:db8::2"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeCNAME, 600, "api.example.com"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "20 backup.example.com", "10 mail.example.com"),
+		// the TTL of the record set is kept
+		endpoint.NewEndpointWithTTL("_sip._tcp.example.com", endpoint.RecordTypeSRV, 3600, "10 5 5061 sip.example.com"),
+	}, endpoints)
+	// the record sets are updated in place
+	assert.Equal(t, int64(10), api.recordSets["1/A"][0].ID)
+	assert.Equal(t, int64(12), api.recordSets["1/MX"][0].ID)
+	assert.Equal(t, 7, api.writes)
+}
+
+func TestConstellixProviderApplyChangesDryRun(t *testing.T) {
+	api := newFakeConstellixAPI()
+	p := newTestProvider(t, api, "example.com")
+	p.DryRun = true
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeA, "1.2.3.4", "1.2.3.5")},
+	}))
+	assert.Zero(t, api.writes)
+}
+
+func TestConstellixProviderApplyChangesInvalidTarget(t *testing.T) {
+	api := newFakeConstellixAPI()
+	p := newTestProvider(t, api, "example.com")
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("_ldap._tcp.example.com", endpoint.RecordTypeSRV, "10 5 ldap.example.com")},
+	})
+	require.EqualError(t, err, `invalid target "10 5 ldap.example.com" of SRV record _ldap._tcp.example.com: expected priority, weight, port and target`)
+
+	err = p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("mail.example.com", endpoint.RecordTypeMX, "mail.example.com")},
+	})
+	require.EqualError(t, err, `invalid target "mail.example.com" of MX record mail.example.com: the priority is missing`)
+	assert.Zero(t, api.writes)
+}
+
+func TestConstellixProviderApplyChangesError(t *testing.T) {
+	api := newFakeConstellixAPI()
+	p := newTestProvider(t, api, "example.com")
+	// the record set is gone by the time it's deleted
+	p.Client = &staleRecordSetsClient{DNSClient: p.Client}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeTXT, "heritage=external-dns")},
+	})
+	require.EqualError(t, err, "failed to delete TXT record api.example.com of domain example.com: constellix: 400 Bad Request: Record not found")
+}
+
+// staleRecordSetsClient returns record sets whose IDs don't exist anymore.
+type staleRecordSetsClient struct {
+	DNSClient
+}
+
+func (c *staleRecordSetsClient) ListRecordSets(ctx context.Context, domainID int64, recordType string) ([]RecordSet, error) {
+	recordSets, err := c.DNSClient.ListRecordSets(ctx, domainID, recordType)
+	for i := range recordSets {
+		recordSets[i].ID = 404
+	}
+	return recordSets, err
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package desec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+const (
+	// defaultEndpoint is the base URL of the deSEC API.
+	defaultEndpoint = "https://desec.io/api/v1"
+	// maxRetries is the number of times a throttled request is retried.
+	maxRetries = 5
+	// defaultRetryAfter is the delay before retrying a throttled request without a Retry-After header.
+	defaultRetryAfter = time.Second
+)
+
+// Domain is a DNS domain of the account.
+type Domain struct {
+	Name string `json:"name"`
+	// MinimumTTL is the lowest TTL accepted for the RRsets of the domain.
+	MinimumTTL int `json:"minimum_ttl"`
+}
+
+// RRSet is the set of the records of a name and type. The subname is the name relative to the domain, empty at the
+// apex, and the records are in presentation format. An RRSet without records is deleted.
+type RRSet struct {
+	Subname string   `json:"subname"`
+	Type    string   `json:"type"`
+	TTL     int      `json:"ttl,omitempty"`
+	Records []string `json:"records"`
+}
+
+// APIError is an error returned by the deSEC API.
+type APIError struct {
+	StatusCode int
+	Detail     string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("desec: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Detail)
+}
+
+// DNSClient is the interface of the deSEC API used by the provider.
+type DNSClient interface {
+	ListDomains(ctx context.Context) ([]Domain, error)
+	ListRRSets(ctx context.Context, domain string) ([]RRSet, error)
+	UpdateRRSets(ctx context.Context, domain string, rrsets []RRSet) error
+}
+
+// Client calls the deSEC API with a token.
+type Client struct {
+	token      string
+	endpoint   string
+	httpClient *http.Client
+	// sleep waits before retrying a throttled request, replaced in tests.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewClient returns a client of the deSEC API authenticating with the token.
+func NewClient(token string, httpClient *http.Client) *Client {
+	return &Client{
+		token:      token,
+		endpoint:   defaultEndpoint,
+		httpClient: httpClient,
+		sleep:      sleep,
+	}
+}
+
+func (c *Client) ListDomains(ctx context.Context) ([]Domain, error) {
+	var domains []Domain
+	err := c.list(ctx, "/domains/", func(body io.Reader) error {
+		var page []Domain
+		if err := json.NewDecoder(body).Decode(&page); err != nil {
+			return err
+		}
+		domains = append(domains, page...)
+		return nil
+	})
+	return domains, err
+}
+
+func (c *Client) ListRRSets(ctx context.Context, domain string) ([]RRSet, error) {
+	var rrsets []RRSet
+	err := c.list(ctx, "/domains/"+url.PathEscape(domain)+"/rrsets/", func(body io.Reader) error {
+		var page []RRSet
+		if err := json.NewDecoder(body).Decode(&page); err != nil {
+			return err
+		}
+		rrsets = append(rrsets, page...)
+		return nil
+	})
+	return rrsets, err
+}
+
+// UpdateRRSets creates, replaces and deletes the RRsets of a domain in a single atomic request.
+func (c *Client) UpdateRRSets(ctx context.Context, domain string, rrsets []RRSet) error {
+	b, err := json.Marshal(rrsets)
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(ctx, http.MethodPatch, c.endpoint+"/domains/"+url.PathEscape(domain)+"/rrsets/", b)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// list fetches the pages of a collection, starting with an empty cursor and following the next links of the
+// responses.
+func (c *Client) list(ctx context.Context, path string, decode func(body io.Reader) error) error {
+	next := c.endpoint + path + "?cursor="
+	for next != "" {
+		resp, err := c.do(ctx, http.MethodGet, next, nil)
+		if err != nil {
+			return err
+		}
+		err = decode(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("desec: failed to decode the response of %s: %w", path, err)
+		}
+		next = nextLink(resp.Header.Get("Link"))
+	}
+	return nil
+}
+
+// do sends a request to the API and returns the successful response. The throttled requests are retried after the
+// delay of the Retry-After header.
+func (c *Client) do(ctx context.Context, method, u string, body []byte) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Token "+c.token)
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("User-Agent", externaldns.UserAgent())
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return resp, nil
+		}
+
+		b, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		apiErr := &APIError{StatusCode: resp.StatusCode, Detail: errorDetail(b)}
+		if resp.StatusCode != http.StatusTooManyRequests || attempt == maxRetries {
+			return nil, apiErr
+		}
+		delay := retryAfter(resp.Header.Get("Retry-After"))
+		log.Debugf("deSEC API throttled the request, retrying in %s: %s", delay, apiErr.Detail)
+		if err := c.sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// errorDetail returns the message of an error response, the detail field when there's one.
+func errorDetail(body []byte) string {
+	var detail struct {
+		Detail string `json:"detail"`
+	}
+	if err := json.Unmarshal(body, &detail); err == nil && detail.Detail != "" {
+		return detail.Detail
+	}
+	return strings.TrimSpace(string(body))
+}
+
+// retryAfter returns the delay of a Retry-After header, in seconds or as an HTTP date.
+func retryAfter(header string) time.Duration {
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay
+		}
+		return 0
+	}
+	return defaultRetryAfter
+}
+
+// nextLink returns the URL of the next page of a Link header, empty on the last page.
+func nextLink(header string) string {
+	for _, link := range strings.Split(header, ",") {
+		target, params, ok := strings.Cut(link, ";")
+		if !ok || !strings.Contains(strings.ReplaceAll(params, " ", ""), `rel="next"`) {
+			continue
+		}
+		return strings.Trim(strings.TrimSpace(target), "<>")
+	}
+	return ""
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
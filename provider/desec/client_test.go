@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package desec
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a client of a test server, recording the delays it waits instead of sleeping.
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, *[]time.Duration) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client := NewClient("token", server.Client())
+	client.endpoint = server.URL
+	var delays []time.Duration
+	client.sleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	return client, &delays
+}
+
+func TestClientListRRSetsPagination(t *testing.T) {
+	var server string
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Token token", r.Header.Get("Authorization"))
+		assert.Equal(t, "/domains/example.com/rrsets/", r.URL.Path)
+		if r.URL.Query().Get("cursor") == "" {
+			w.Header().Set("Link", `<`+server+`/domains/example.com/rrsets/?cursor=>; rel="first", <`+server+`/domains/example.com/rrsets/?cursor=abc>; rel="next"`)
+			_, _ = w.Write([]byte(`[{"subname":"","type":"A","ttl":3600,"records":["1.2.3.4"]}]`))
+			return
+		}
+		assert.Equal(t, "abc", r.URL.Query().Get("cursor"))
+		w.Header().Set("Link", `<`+server+`/domains/example.com/rrsets/?cursor=>; rel="first"`)
+		_, _ = w.Write([]byte(`[{"subname":"www","type":"A","ttl":300,"records":["1.2.3.5"]}]`))
+	})
+	server = client.endpoint
+
+	rrsets, err := client.ListRRSets(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []RRSet{
+		{Subname: "", Type: "A", TTL: 3600, Records: []string{"1.2.3.4"}},
+		{Subname: "www", Type: "A", TTL: 300, Records: []string{"1.2.3.5"}},
+	}, rrsets)
+}
+
+func TestClientUpdateRRSets(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/domains/example.com/rrsets/", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var rrsets []map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&rrsets))
+		assert.Equal(t, []map[string]any{
+			{"subname": "www", "type": "A", "ttl": float64(300), "records": []any{"1.2.3.4"}},
+			{"subname": "old", "type": "TXT", "records": []any{}},
+		}, rrsets)
+		_, _ = w.Write([]byte(`[]`))
+	})
+
+	require.NoError(t, client.UpdateRRSets(context.Background(), "example.com", []RRSet{
+		{Subname: "www", Type: "A", TTL: 300, Records: []string{"1.2.3.4"}},
+		{Subname: "old", Type: "TXT", Records: []string{}},
+	}))
+}
+
+func TestClientRetryAfter(t *testing.T) {
+	calls := 0
+	client, delays := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"detail":"Request was throttled. Expected available in 2 seconds."}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"name":"example.com","minimum_ttl":3600}]`))
+	})
+
+	domains, err := client.ListDomains(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Domain{{Name: "example.com", MinimumTTL: 3600}}, domains)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []time.Duration{2 * time.Second, 2 * time.Second}, *delays)
+}
+
+func TestClientRetryAfterExhausted(t *testing.T) {
+	client, delays := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"detail":"Request was throttled."}`))
+	})
+
+	_, err := client.ListDomains(context.Background())
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+	assert.EqualError(t, err, "desec: 429 Too Many Requests: Request was throttled.")
+	assert.Len(t, *delays, maxRetries)
+	assert.Equal(t, defaultRetryAfter, (*delays)[0])
+}
+
+func TestClientAPIError(t *testing.T) {
+	client, delays := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`[{"ttl":["Ensure this value is greater than or equal to 3600."]}]`))
+	})
+
+	err := client.UpdateRRSets(context.Background(), "example.com", []RRSet{{Subname: "www", Type: "A", TTL: 60, Records: []string{"1.2.3.4"}}})
+	assert.EqualError(t, err, `desec: 400 Bad Request: [{"ttl":["Ensure this value is greater than or equal to 3600."]}]`)
+	assert.Empty(t, *delays)
+}
+
+func TestRetryAfter(t *testing.T) {
+	assert.Equal(t, 30*time.Second, retryAfter("30"))
+	assert.Equal(t, defaultRetryAfter, retryAfter(""))
+	assert.Equal(t, time.Duration(0), retryAfter("Wed, 21 Oct 2015 07:28:00 GMT"))
+}
+
+func TestNextLink(t *testing.T) {
+	assert.Equal(t, "https://desec.io/api/v1/domains/?cursor=def", nextLink(`<https://desec.io/api/v1/domains/?cursor=>; rel="first", <https://desec.io/api/v1/domains/?cursor=abc>; rel="prev", <https://desec.io/api/v1/domains/?cursor=def>; rel="next"`))
+	assert.Empty(t, nextLink(`<https://desec.io/api/v1/domains/?cursor=>; rel="first"`))
+	assert.Empty(t, nextLink(""))
+}
@@ -0,0 +1,344 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package desec
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	// defaultTTL is the TTL of the RRsets without a configured TTL, unless the minimum TTL of the domain is higher.
+	defaultTTL = 3600
+	// maxTXTStringLength is the maximum length of a character string of a TXT record.
+	maxTXTStringLength = 255
+)
+
+// DesecProvider is an implementation of Provider for deSEC.
+type DesecProvider struct {
+	provider.BaseProvider
+	Client DNSClient
+	// only consider hosted zones managing domains ending in this suffix
+	domainFilter endpoint.DomainFilter
+	DryRun       bool
+}
+
+// NewDesecProvider initializes a new deSEC based Provider.
+func NewDesecProvider(domainFilter endpoint.DomainFilter, dryRun bool) (*DesecProvider, error) {
+	token := os.Getenv("DESEC_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("no token found, set DESEC_TOKEN")
+	}
+
+	return &DesecProvider{
+		Client:       NewClient(token, &http.Client{Timeout: 30 * time.Second}),
+		domainFilter: domainFilter,
+		DryRun:       dryRun,
+	}, nil
+}
+
+// Zones returns the list of hosted zones.
+func (p *DesecProvider) Zones(ctx context.Context) ([]Domain, error) {
+	domains, err := p.Client.ListDomains(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list domains: %w", err)
+	}
+
+	var zones []Domain
+	for _, domain := range domains {
+		if p.domainFilter.Match(domain.Name) {
+			zones = append(zones, domain)
+		}
+	}
+	return zones, nil
+}
+
+// SupportedRecordType returns whether the record type is managed, the types whose targets are converted to and from
+// the presentation format of the deSEC RRsets.
+func (p *DesecProvider) SupportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT, endpoint.RecordTypeMX:
+		return true
+	default:
+		return false
+	}
+}
+
+// Records returns the list of records in all the zones, an RRset being an endpoint.
+func (p *DesecProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, zone := range zones {
+		rrsets, err := p.Client.ListRRSets(ctx, zone.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list RRsets of domain %s: %w", zone.Name, err)
+		}
+		for _, rrset := range rrsets {
+			if !p.SupportedRecordType(rrset.Type) || len(rrset.Records) == 0 {
+				continue
+			}
+			targets := make([]string, 0, len(rrset.Records))
+			for _, record := range rrset.Records {
+				targets = append(targets, recordTarget(rrset.Type, record))
+			}
+			endpoints = append(endpoints, endpoint.NewEndpointWithTTL(recordDNSName(zone.Name, rrset.Subname), rrset.Type, endpoint.TTL(rrset.TTL), targets...))
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"endpoints": endpoints,
+	}).Debug("Endpoints generated from deSEC")
+
+	return endpoints, nil
+}
+
+// ApplyChanges applies the given changes. The RRsets of an endpoint are replaced as a whole, the changes of a domain
+// are submitted in a single bulk request.
+func (p *DesecProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return err
+	}
+
+	zoneNameIDMapper := provider.ZoneIDName{}
+	minimumTTLs := map[string]int{}
+	for _, zone := range zones {
+		zoneNameIDMapper.Add(zone.Name, zone.Name)
+		minimumTTLs[zone.Name] = zone.MinimumTTL
+	}
+
+	// the RRsets to submit by domain, keyed by subname and type so that an RRset is changed once
+	rrsetsByZone := map[string]map[string]RRSet{}
+	err = provider.ForEachEndpointTargets(changes, func(ep *endpoint.Endpoint, targets []string, prune bool) error {
+		zone, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zone == "" {
+			log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", ep.DNSName)
+			return nil
+		}
+		// an RRset without records is deleted
+		rrset := RRSet{Subname: recordName(zone, ep.DNSName), Type: ep.RecordType, Records: []string{}}
+		for _, target := range targets {
+			record, err := recordContent(ep.RecordType, target)
+			if err != nil {
+				return fmt.Errorf("invalid target %q of %s record %s: %w", target, ep.RecordType, ep.DNSName, err)
+			}
+			rrset.Records = append(rrset.Records, record)
+		}
+		// the TTL of an updated RRset is kept when it isn't configured, the created RRsets aren't pruned
+		if len(targets) > 0 && (ep.RecordTTL.IsConfigured() || !prune) {
+			rrset.TTL = rrsetTTL(ep.RecordTTL, minimumTTLs[zone])
+		}
+		if rrsetsByZone[zone] == nil {
+			rrsetsByZone[zone] = map[string]RRSet{}
+		}
+		rrsetsByZone[zone][rrset.Subname+"/"+rrset.Type] = rrset
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.submitChanges(ctx, rrsetsByZone)
+}
+
+// submitChanges submits the RRsets of each domain in a bulk request, which deSEC applies atomically.
+func (p *DesecProvider) submitChanges(ctx context.Context, rrsetsByZone map[string]map[string]RRSet) error {
+	zones := make([]string, 0, len(rrsetsByZone))
+	for zone := range rrsetsByZone {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+
+	for _, zone := range zones {
+		keys := make([]string, 0, len(rrsetsByZone[zone]))
+		for key := range rrsetsByZone[zone] {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		rrsets := make([]RRSet, 0, len(keys))
+		for _, key := range keys {
+			rrset := rrsetsByZone[zone][key]
+			logFields := log.Fields{
+				"record":   recordDNSName(zone, rrset.Subname),
+				"type":     rrset.Type,
+				"targets":  rrset.Records,
+				"ttl":      rrset.TTL,
+				"zoneName": zone,
+			}
+			if len(rrset.Records) == 0 {
+				logFields["action"] = "Delete"
+				log.WithFields(logFields).Info("Deleting RRset.")
+			} else {
+				logFields["action"] = "Update"
+				log.WithFields(logFields).Info("Updating RRset.")
+			}
+			rrsets = append(rrsets, rrset)
+		}
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.UpdateRRSets(ctx, zone, rrsets); err != nil {
+			return fmt.Errorf("failed to update RRsets of domain %s: %w", zone, err)
+		}
+	}
+
+	return nil
+}
+
+// rrsetTTL returns the TTL of an RRset, deSEC rejecting the TTLs below the minimum TTL of the domain.
+func rrsetTTL(ttl endpoint.TTL, minimumTTL int) int {
+	value := defaultTTL
+	if ttl.IsConfigured() {
+		value = int(ttl)
+	}
+	if value < minimumTTL {
+		log.Debugf("Raising TTL %d to the minimum TTL %d of the domain", value, minimumTTL)
+		return minimumTTL
+	}
+	return value
+}
+
+// recordName returns the name of a record relative to its domain, the apex being the empty name.
+func recordName(zone, dnsName string) string {
+	if dnsName == zone {
+		return ""
+	}
+	return strings.TrimSuffix(dnsName, "."+zone)
+}
+
+// recordDNSName returns the DNS name of a record of a domain.
+func recordDNSName(zone, subname string) string {
+	if subname == "" {
+		return zone
+	}
+	return subname + "." + zone
+}
+
+// recordTarget returns the endpoint target of a record in presentation format, the character strings of TXT records
+// being joined.
+func recordTarget(recordType, record string) string {
+	if recordType == endpoint.RecordTypeTXT {
+		return parseTXT(record)
+	}
+	return record
+}
+
+// recordContent returns the record of an endpoint target in presentation format. The names are fully qualified, MX
+// targets are of the form "10 mail.example.com" and TXT targets are split into quoted character strings.
+func recordContent(recordType, target string) (string, error) {
+	switch recordType {
+	case endpoint.RecordTypeCNAME:
+		return fqdn(target), nil
+	case endpoint.RecordTypeMX:
+		fields := strings.Fields(target)
+		if len(fields) != 2 {
+			return "", fmt.Errorf("expected a priority and an exchange")
+		}
+		if _, err := strconv.ParseUint(fields[0], 10, 16); err != nil {
+			return "", fmt.Errorf("invalid priority %q", fields[0])
+		}
+		return fields[0] + " " + fqdn(fields[1]), nil
+	case endpoint.RecordTypeTXT:
+		return formatTXT(target), nil
+	default:
+		return target, nil
+	}
+}
+
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// formatTXT quotes a TXT value, split into character strings of at most 255 bytes.
+func formatTXT(value string) string {
+	var sb strings.Builder
+	for first := true; first || value != ""; first = false {
+		chunk := value
+		if len(chunk) > maxTXTStringLength {
+			chunk = chunk[:maxTXTStringLength]
+		}
+		value = value[len(chunk):]
+		if !first {
+			sb.WriteByte(' ')
+		}
+		sb.WriteByte('"')
+		for i := 0; i < len(chunk); i++ {
+			if chunk[i] == '"' || chunk[i] == '\\' {
+				sb.WriteByte('\\')
+			}
+			sb.WriteByte(chunk[i])
+		}
+		sb.WriteByte('"')
+	}
+	return sb.String()
+}
+
+// parseTXT returns the value of a TXT record, its quoted character strings unescaped and joined.
+func parseTXT(record string) string {
+	if !strings.HasPrefix(record, `"`) {
+		return record
+	}
+	var sb strings.Builder
+	quoted := false
+	for i := 0; i < len(record); i++ {
+		c := record[i]
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case c == '\\' && i+3 < len(record) && isDigits(record[i+1:i+4]):
+			// a byte escaped as \DDD
+			value, _ := strconv.Atoi(record[i+1 : i+4])
+			sb.WriteByte(byte(value))
+			i += 3
+		case c == '\\' && i+1 < len(record):
+			i++
+			sb.WriteByte(record[i])
+		case quoted:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
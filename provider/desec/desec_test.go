@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package desec
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// fakeDesec is an in-memory deSEC API.
+type fakeDesec struct {
+	t       *testing.T
+	domains []Domain
+	rrsets  map[string][]RRSet
+	patches int
+}
+
+func (f *fakeDesec) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	assert.Equal(f.t, "Token token", r.Header.Get("Authorization"))
+	path := strings.Trim(r.URL.Path, "/")
+	switch {
+	case path == "domains":
+		require.NoError(f.t, json.NewEncoder(w).Encode(f.domains))
+	case strings.HasPrefix(path, "domains/") && strings.HasSuffix(path, "/rrsets"):
+		domain := strings.TrimSuffix(strings.TrimPrefix(path, "domains/"), "/rrsets")
+		if r.Method == http.MethodGet {
+			require.NoError(f.t, json.NewEncoder(w).Encode(f.rrsets[domain]))
+			return
+		}
+		var changes []RRSet
+		require.NoError(f.t, json.NewDecoder(r.Body).Decode(&changes))
+		f.patches++
+		for _, change := range changes {
+			i := slices.IndexFunc(f.rrsets[domain], func(rrset RRSet) bool {
+				return rrset.Subname == change.Subname && rrset.Type == change.Type
+			})
+			switch {
+			case len(change.Records) == 0 && i >= 0:
+				f.rrsets[domain] = slices.Delete(f.rrsets[domain], i, i+1)
+			case i >= 0:
+				f.rrsets[domain][i].Records = change.Records
+				if change.TTL != 0 {
+					f.rrsets[domain][i].TTL = change.TTL
+				}
+			case len(change.Records) > 0:
+				require.NotZero(f.t, change.TTL, "the TTL of a new RRset is required")
+				f.rrsets[domain] = append(f.rrsets[domain], change)
+			}
+		}
+		_, _ = w.Write([]byte(`[]`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func testDesecAPI(t *testing.T) *fakeDesec {
+	return &fakeDesec{
+		t: t,
+		domains: []Domain{
+			{Name: "example.com", MinimumTTL: 3600},
+			{Name: "example.org", MinimumTTL: 60},
+		},
+		rrsets: map[string][]RRSet{
+			"example.com": {
+				{Subname: "", Type: "NS", TTL: 3600, Records: []string{"ns1.desec.io.", "ns2.desec.org."}},
+				{Subname: "", Type: "A", TTL: 3600, Records: []string{"1.2.3.4"}},
+				{Subname: "", Type: "MX", TTL: 3600, Records: []string{"10 mail.example.com."}},
+				{Subname: "www", Type: "A", TTL: 3600, Records: []string{"1.2.3.4", "1.2.3.5"}},
+				{Subname: "www", Type: "TXT", TTL: 3600, Records: []string{`"heritage=external-dns,external-dns/owner=default"`}},
+				{Subname: "blog", Type: "CNAME", TTL: 3600, Records: []string{"www.example.com."}},
+			},
+			"example.org": {},
+		},
+	}
+}
+
+func newTestProvider(t *testing.T, api *fakeDesec, domains ...string) *DesecProvider {
+	client, _ := newTestClient(t, api.ServeHTTP)
+	return &DesecProvider{
+		Client:       client,
+		domainFilter: endpoint.NewDomainFilter(domains),
+	}
+}
+
+func TestNewDesecProvider(t *testing.T) {
+	t.Setenv("DESEC_TOKEN", "token")
+	_, err := NewDesecProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.NoError(t, err)
+
+	t.Setenv("DESEC_TOKEN", "")
+	_, err = NewDesecProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.EqualError(t, err, "no token found, set DESEC_TOKEN")
+}
+
+func TestDesecProviderRecords(t *testing.T) {
+	p := newTestProvider(t, testDesecAPI(t), "example.com")
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 3600, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 3600, "1.2.3.4", "1.2.3.5"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeTXT, 3600, "heritage=external-dns,external-dns/owner=default"),
+		endpoint.NewEndpointWithTTL("blog.example.com", endpoint.RecordTypeCNAME, 3600, "www.example.com"),
+	}, endpoints)
+}
+
+func TestDesecProviderApplyChanges(t *testing.T) {
+	api := testDesecAPI(t)
+	p := newTestProvider(t, api, "example.com", "example.org")
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeAAAA, "2001:db8::1", "2001:db8::2"),
+			endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeTXT, 60, `say "hi"`),
+			endpoint.NewEndpointWithTTL("example.org", endpoint.RecordTypeCNAME, 60, "example.com"),
+			// not in a managed zone
+			endpoint.NewEndpoint("www.example.net", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 3600, "1.2.3.4", "1.2.3.5"),
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.5", "1.2.3.6"),
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 7200, "10 mail.example.com", "20 backup.example.com"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("blog.example.com", endpoint.RecordTypeCNAME, "www.example.com"),
+		},
+	}))
+	// a bulk request per domain
+	assert.Equal(t, 2, api.patches)
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 3600, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 7200, "10 mail.example.com", "20 backup.example.com"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 3600, "1.2.3.5", "1.2.3.6"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeTXT, 3600, "heritage=external-dns,external-dns/owner=default"),
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeAAAA, defaultTTL, "2001:db8::1", "2001:db8::2"),
+		// raised to the minimum TTL of the domain
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeTXT, 3600, `say "hi"`),
+		endpoint.NewEndpointWithTTL("example.org", endpoint.RecordTypeCNAME, 60, "example.com"),
+	}, endpoints)
+	assert.Contains(t, api.rrsets["example.com"], RRSet{Subname: "", Type: "MX", TTL: 7200, Records: []string{"10 mail.example.com.", "20 backup.example.com."}})
+	assert.Contains(t, api.rrsets["example.com"], RRSet{Subname: "api", Type: "TXT", TTL: 3600, Records: []string{`"say \"hi\""`}})
+}
+
+func TestDesecProviderApplyChangesDryRun(t *testing.T) {
+	api := testDesecAPI(t)
+	p := newTestProvider(t, api, "example.com")
+	p.DryRun = true
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.4", "1.2.3.5")},
+	}))
+	assert.Zero(t, api.patches)
+}
+
+func TestDesecProviderApplyChangesInvalidTarget(t *testing.T) {
+	api := testDesecAPI(t)
+	p := newTestProvider(t, api, "example.com")
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "mail.example.com")},
+	})
+	require.EqualError(t, err, `invalid target "mail.example.com" of MX record example.com: expected a priority and an exchange`)
+	assert.Zero(t, api.patches)
+}
+
+func TestTXT(t *testing.T) {
+	long := strings.Repeat("a", 300)
+	assert.Equal(t, `"`+strings.Repeat("a", 255)+`" "`+strings.Repeat("a", 45)+`"`, formatTXT(long))
+	assert.Equal(t, `""`, formatTXT(""))
+
+	for _, value := range []string{"v=spf1 -all", `say "hi" \o/`, long, ""} {
+		assert.Equal(t, value, parseTXT(formatTXT(value)))
+	}
+	assert.Equal(t, "caf\xc3\xa9", parseTXT(`"caf\195\169"`))
+}
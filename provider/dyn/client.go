@@ -0,0 +1,272 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dyn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+const (
+	// defaultEndpoint is the base URL of the Dyn REST API.
+	defaultEndpoint = "https://api.dynect.net/REST"
+	// maxJobPolls is the number of times an incomplete job is polled before giving up.
+	maxJobPolls = 30
+)
+
+// RData holds the data of a record, the fields depending on its type.
+type RData struct {
+	Address    string `json:"address,omitempty"`
+	CNAME      string `json:"cname,omitempty"`
+	TXTData    string `json:"txtdata,omitempty"`
+	Preference *int   `json:"preference,omitempty"`
+	Exchange   string `json:"exchange,omitempty"`
+}
+
+// Record is a DNS record of a zone. The records are flat within a zone, a record holds a single value.
+type Record struct {
+	FQDN       string `json:"fqdn,omitempty"`
+	RecordType string `json:"record_type,omitempty"`
+	RecordID   int64  `json:"record_id,omitempty"`
+	TTL        int    `json:"ttl,omitempty"`
+	RData      RData  `json:"rdata"`
+}
+
+// Message is a message of an API response.
+type Message struct {
+	Info   string `json:"INFO"`
+	Source string `json:"SOURCE"`
+	ErrCD  string `json:"ERR_CD"`
+	Level  string `json:"LVL"`
+}
+
+// APIError is a failure returned by the Dyn API.
+type APIError struct {
+	StatusCode int
+	Messages   []Message
+}
+
+func (e *APIError) Error() string {
+	var infos []string
+	for _, msg := range e.Messages {
+		if msg.Level == "ERROR" {
+			infos = append(infos, fmt.Sprintf("%s: %s", msg.ErrCD, msg.Info))
+		}
+	}
+	if len(infos) == 0 {
+		return fmt.Sprintf("dyn: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("dyn: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), strings.Join(infos, "; "))
+}
+
+// response is the envelope of the API responses.
+type response struct {
+	Status   string          `json:"status"`
+	Data     json.RawMessage `json:"data"`
+	JobID    int64           `json:"job_id"`
+	Messages []Message       `json:"msgs"`
+}
+
+// DNSClient is the interface of the Dyn API used by the provider. The record changes of a zone are queued until the
+// zone is published, the calls but the login requiring a session.
+type DNSClient interface {
+	Login(ctx context.Context) error
+	Logout(ctx context.Context) error
+	ListZones(ctx context.Context) ([]string, error)
+	ListRecords(ctx context.Context, zone string) ([]Record, error)
+	CreateRecord(ctx context.Context, zone string, record Record) error
+	ReplaceRecords(ctx context.Context, zone, fqdn, recordType string, records []Record) error
+	DeleteRecords(ctx context.Context, zone, fqdn, recordType string) error
+	Publish(ctx context.Context, zone string) error
+	DiscardChanges(ctx context.Context, zone string) error
+}
+
+// Client calls the Dyn API in a session opened with the credentials of a user of a customer.
+type Client struct {
+	customerName string
+	userName     string
+	password     string
+	endpoint     string
+	httpClient   *http.Client
+	token        string
+	// sleep waits between the polls of an incomplete job, replaced in tests.
+	sleep func(time.Duration)
+}
+
+// NewClient returns a client of the Dyn API authenticating with the customer name, user name and password.
+func NewClient(customerName, userName, password string, httpClient *http.Client) *Client {
+	return &Client{
+		customerName: customerName,
+		userName:     userName,
+		password:     password,
+		endpoint:     defaultEndpoint,
+		httpClient:   httpClient,
+		sleep:        time.Sleep,
+	}
+}
+
+// Login opens a session, whose token authenticates the following calls.
+func (c *Client) Login(ctx context.Context) error {
+	var session struct {
+		Token string `json:"token"`
+	}
+	body := map[string]string{"customer_name": c.customerName, "user_name": c.userName, "password": c.password}
+	if err := c.do(ctx, http.MethodPost, "/Session/", body, &session); err != nil {
+		return err
+	}
+	c.token = session.Token
+	return nil
+}
+
+// Logout closes the session.
+func (c *Client) Logout(ctx context.Context) error {
+	if c.token == "" {
+		return nil
+	}
+	err := c.do(ctx, http.MethodDelete, "/Session/", nil, nil)
+	c.token = ""
+	return err
+}
+
+// ListZones returns the names of the zones of the customer.
+func (c *Client) ListZones(ctx context.Context) ([]string, error) {
+	var uris []string
+	if err := c.do(ctx, http.MethodGet, "/Zone/", nil, &uris); err != nil {
+		return nil, err
+	}
+	zones := make([]string, 0, len(uris))
+	for _, uri := range uris {
+		// the zones are returned as URIs of the form /REST/Zone/example.com/
+		zones = append(zones, strings.TrimPrefix(strings.Trim(uri, "/"), "REST/Zone/"))
+	}
+	return zones, nil
+}
+
+// ListRecords returns the published records of a zone.
+func (c *Client) ListRecords(ctx context.Context, zone string) ([]Record, error) {
+	// the records are grouped by type, e.g. "a_records"
+	var recordsByType map[string][]Record
+	if err := c.do(ctx, http.MethodGet, "/AllRecord/"+url.PathEscape(zone)+"/?detail=Y", nil, &recordsByType); err != nil {
+		return nil, err
+	}
+	var records []Record
+	for _, r := range recordsByType {
+		records = append(records, r...)
+	}
+	return records, nil
+}
+
+// CreateRecord queues the creation of a record.
+func (c *Client) CreateRecord(ctx context.Context, zone string, record Record) error {
+	return c.do(ctx, http.MethodPost, recordPath(zone, record.FQDN, record.RecordType), Record{TTL: record.TTL, RData: record.RData}, nil)
+}
+
+// ReplaceRecords queues the replacement of the records of a name and type.
+func (c *Client) ReplaceRecords(ctx context.Context, zone, fqdn, recordType string, records []Record) error {
+	rrset := make([]Record, 0, len(records))
+	for _, r := range records {
+		rrset = append(rrset, Record{TTL: r.TTL, RData: r.RData})
+	}
+	body := map[string][]Record{recordType + "Records": rrset}
+	return c.do(ctx, http.MethodPut, recordPath(zone, fqdn, recordType), body, nil)
+}
+
+// DeleteRecords queues the deletion of the records of a name and type.
+func (c *Client) DeleteRecords(ctx context.Context, zone, fqdn, recordType string) error {
+	return c.do(ctx, http.MethodDelete, recordPath(zone, fqdn, recordType), nil, nil)
+}
+
+// Publish applies the queued changes of a zone.
+func (c *Client) Publish(ctx context.Context, zone string) error {
+	return c.do(ctx, http.MethodPut, "/Zone/"+url.PathEscape(zone)+"/", map[string]bool{"publish": true}, nil)
+}
+
+// DiscardChanges drops the queued changes of a zone.
+func (c *Client) DiscardChanges(ctx context.Context, zone string) error {
+	return c.do(ctx, http.MethodDelete, "/ZoneChanges/"+url.PathEscape(zone)+"/", nil, nil)
+}
+
+func recordPath(zone, fqdn, recordType string) string {
+	return "/" + recordType + "Record/" + url.PathEscape(zone) + "/" + url.PathEscape(fqdn) + "/"
+}
+
+// do sends a request to the API, with the body encoded as JSON, and decodes the data of the response into the result.
+// The jobs the API doesn't complete in time are polled until they are.
+func (c *Client) do(ctx context.Context, method, path string, body, result any) error {
+	resp, err := c.send(ctx, method, path, body)
+	for polls := 0; err == nil && resp.Status == "incomplete"; polls++ {
+		if polls == maxJobPolls {
+			return fmt.Errorf("dyn: job %d of %s %s is still incomplete", resp.JobID, method, path)
+		}
+		c.sleep(time.Second)
+		resp, err = c.send(ctx, http.MethodGet, fmt.Sprintf("/Job/%d/", resp.JobID), nil)
+	}
+	if err != nil {
+		return err
+	}
+	if result == nil || len(resp.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(resp.Data, result)
+}
+
+func (c *Client) send(ctx context.Context, method, path string, body any) (*response, error) {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", externaldns.UserAgent())
+	if c.token != "" {
+		req.Header.Set("Auth-Token", c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var r response
+	decodeErr := json.NewDecoder(resp.Body).Decode(&r)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 || r.Status == "failure" {
+		return nil, &APIError{StatusCode: resp.StatusCode, Messages: r.Messages}
+	}
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+	return &r, nil
+}
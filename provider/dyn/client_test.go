@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dyn
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a client of a test server running the handler, which doesn't wait before polling the jobs.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client := NewClient("customer", "user", "password", server.Client())
+	client.endpoint = server.URL
+	client.sleep = func(time.Duration) {}
+	return client
+}
+
+func TestClientSession(t *testing.T) {
+	var requests []string
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		requests = append(requests, fmt.Sprintf("%s %s %s %s", req.Method, req.URL.Path, req.Header.Get("Auth-Token"), body))
+		switch req.Method {
+		case http.MethodPost:
+			fmt.Fprint(w, `{"status":"success","data":{"token":"secret-token","version":"3.7.0"},"job_id":1,"msgs":[]}`)
+		case http.MethodGet:
+			fmt.Fprint(w, `{"status":"success","data":["/REST/Zone/example.com/","/REST/Zone/example.org/"],"job_id":2,"msgs":[]}`)
+		default:
+			fmt.Fprint(w, `{"status":"success","data":{},"job_id":3,"msgs":[]}`)
+		}
+	})
+
+	require.NoError(t, client.Login(context.Background()))
+	zones, err := client.ListZones(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example.com", "example.org"}, zones)
+	require.NoError(t, client.Logout(context.Background()))
+	// a closed session isn't closed again
+	require.NoError(t, client.Logout(context.Background()))
+
+	assert.Equal(t, []string{
+		`POST /Session/  {"customer_name":"customer","password":"password","user_name":"user"}`,
+		"GET /Zone/ secret-token ",
+		"DELETE /Session/ secret-token ",
+	}, requests)
+}
+
+func TestClientPublishPollsIncompleteJob(t *testing.T) {
+	var requests []string
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		requests = append(requests, fmt.Sprintf("%s %s %s", req.Method, req.URL.Path, body))
+		if len(requests) < 3 {
+			fmt.Fprint(w, `{"status":"incomplete","data":null,"job_id":42,"msgs":[]}`)
+			return
+		}
+		fmt.Fprint(w, `{"status":"success","data":{"zone":"example.com","serial":2},"job_id":42,"msgs":[]}`)
+	})
+
+	require.NoError(t, client.Publish(context.Background(), "example.com"))
+	assert.Equal(t, []string{
+		`PUT /Zone/example.com/ {"publish":true}`,
+		"GET /Job/42/ ",
+		"GET /Job/42/ ",
+	}, requests)
+}
+
+func TestClientReplaceRecords(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPut, req.Method)
+		assert.Equal(t, "/MXRecord/example.com/example.com/", req.URL.Path)
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"MXRecords":[{"ttl":600,"rdata":{"preference":0,"exchange":"mail.example.com"}}]}`, string(body))
+		fmt.Fprint(w, `{"status":"success","data":[],"job_id":1,"msgs":[]}`)
+	})
+
+	preference := 0
+	require.NoError(t, client.ReplaceRecords(context.Background(), "example.com", "example.com", "MX", []Record{
+		{FQDN: "example.com", RecordType: "MX", TTL: 600, RData: RData{Preference: &preference, Exchange: "mail.example.com"}},
+	}))
+}
+
+func TestClientAPIError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"status":"failure","data":{},"job_id":1,"msgs":[
+			{"INFO":"login: Credentials you entered did not match those in our database","SOURCE":"BLL","ERR_CD":"INVALID_DATA","LVL":"ERROR"},
+			{"INFO":"login: There was a problem with your credentials","SOURCE":"BLL","ERR_CD":null,"LVL":"INFO"}
+		]}`)
+	})
+
+	err := client.Login(context.Background())
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.EqualError(t, err, "dyn: 400 Bad Request: INVALID_DATA: login: Credentials you entered did not match those in our database")
+}
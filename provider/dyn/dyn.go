@@ -0,0 +1,305 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dyn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// DynProvider is an implementation of Provider for Oracle Dyn Managed DNS.
+type DynProvider struct {
+	provider.BaseProvider
+	Client DNSClient
+	// only consider hosted zones managing domains ending in this suffix
+	domainFilter endpoint.DomainFilter
+	DryRun       bool
+}
+
+// dynChange is a change of the records of a name and type.
+type dynChange struct {
+	Action   string
+	Endpoint *endpoint.Endpoint
+	Records  []Record
+}
+
+// NewDynProvider initializes a new Dyn DNS based Provider.
+func NewDynProvider(domainFilter endpoint.DomainFilter, dryRun bool) (*DynProvider, error) {
+	customerName := os.Getenv("DYN_CUSTOMER_NAME")
+	userName := os.Getenv("DYN_USERNAME")
+	password := os.Getenv("DYN_PASSWORD")
+	if customerName == "" || userName == "" || password == "" {
+		return nil, fmt.Errorf("no credentials found, set DYN_CUSTOMER_NAME, DYN_USERNAME and DYN_PASSWORD")
+	}
+
+	return &DynProvider{
+		Client:       NewClient(customerName, userName, password, &http.Client{Timeout: 30 * time.Second}),
+		domainFilter: domainFilter,
+		DryRun:       dryRun,
+	}, nil
+}
+
+// session opens a session, the returned function closing it.
+func (p *DynProvider) session(ctx context.Context) (func(), error) {
+	if err := p.Client.Login(ctx); err != nil {
+		return nil, fmt.Errorf("failed to open a session: %w", err)
+	}
+	return func() {
+		if err := p.Client.Logout(ctx); err != nil {
+			log.Warnf("Failed to close the Dyn session: %v", err)
+		}
+	}, nil
+}
+
+// zones returns the list of hosted zones, in an open session.
+func (p *DynProvider) zones(ctx context.Context) ([]string, error) {
+	zones, err := p.Client.ListZones(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zones: %w", err)
+	}
+
+	var filtered []string
+	for _, zone := range zones {
+		if p.domainFilter.Match(zone) {
+			filtered = append(filtered, zone)
+		}
+	}
+	return filtered, nil
+}
+
+// SupportedRecordType returns true for the record types of the Dyn REST API external-dns manages, each of them having
+// its own record resource.
+func (p *DynProvider) SupportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT, endpoint.RecordTypeMX:
+		return true
+	default:
+		return false
+	}
+}
+
+// Records returns the list of published records in all the zones, the records of a name and type are merged into a
+// single endpoint.
+func (p *DynProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	closeSession, err := p.session(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer closeSession()
+
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, zone := range zones {
+		records, err := p.Client.ListRecords(ctx, zone)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list records of zone %s: %w", zone, err)
+		}
+		// the records are grouped by type in no particular order
+		sort.SliceStable(records, func(i, j int) bool { return records[i].RecordID < records[j].RecordID })
+
+		for _, r := range records {
+			if !p.SupportedRecordType(r.RecordType) {
+				continue
+			}
+			endpoints = append(endpoints, endpoint.NewEndpointWithTTL(r.FQDN, r.RecordType, endpoint.TTL(r.TTL), recordTarget(r)))
+		}
+	}
+	endpoints = provider.MergeEndpointsByNameType(endpoints)
+
+	log.WithFields(log.Fields{
+		"endpoints": endpoints,
+	}).Debug("Endpoints generated from Dyn")
+
+	return endpoints, nil
+}
+
+// ApplyChanges applies the given changes. The records of a name and type are replaced as a whole, the changes of a
+// zone are queued and then published together, or discarded when one of them fails.
+func (p *DynProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	closeSession, err := p.session(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeSession()
+
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return err
+	}
+
+	zoneNameIDMapper := provider.ZoneIDName{}
+	for _, zone := range zones {
+		zoneNameIDMapper.Add(zone, zone)
+	}
+
+	changesByZone := map[string][]dynChange{}
+	err = provider.ForEachEndpointTargets(changes, func(ep *endpoint.Endpoint, targets []string, prune bool) error {
+		zone, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zone == "" {
+			log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", ep.DNSName)
+			return nil
+		}
+		// the existing records are deleted or replaced as a whole when pruning
+		change := dynChange{Action: "Create", Endpoint: ep}
+		switch {
+		case prune && targets == nil:
+			change.Action = "Delete"
+		case prune:
+			change.Action = "Update"
+		}
+		for _, target := range targets {
+			record, err := newRecord(ep, target)
+			if err != nil {
+				return fmt.Errorf("invalid target %q of %s record %s: %w", target, ep.RecordType, ep.DNSName, err)
+			}
+			change.Records = append(change.Records, record)
+		}
+		changesByZone[zone] = append(changesByZone[zone], change)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.submitChanges(ctx, changesByZone)
+}
+
+// submitChanges queues the changes of each zone and publishes them.
+func (p *DynProvider) submitChanges(ctx context.Context, changesByZone map[string][]dynChange) error {
+	zones := make([]string, 0, len(changesByZone))
+	for zone := range changesByZone {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+
+	for _, zone := range zones {
+		for _, change := range changesByZone[zone] {
+			log.WithFields(log.Fields{
+				"record":   change.Endpoint.DNSName,
+				"type":     change.Endpoint.RecordType,
+				"targets":  change.Endpoint.Targets,
+				"ttl":      change.Endpoint.RecordTTL,
+				"action":   change.Action,
+				"zoneName": zone,
+			}).Info("Queuing record change.")
+		}
+		if p.DryRun {
+			continue
+		}
+
+		if err := p.queueChanges(ctx, zone, changesByZone[zone]); err != nil {
+			if discardErr := p.Client.DiscardChanges(ctx, zone); discardErr != nil {
+				err = errors.Join(err, fmt.Errorf("failed to discard the changes of zone %s: %w", zone, discardErr))
+			}
+			return err
+		}
+		log.WithFields(log.Fields{"zoneName": zone}).Info("Publishing zone.")
+		if err := p.Client.Publish(ctx, zone); err != nil {
+			return fmt.Errorf("failed to publish zone %s: %w", zone, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *DynProvider) queueChanges(ctx context.Context, zone string, changes []dynChange) error {
+	for _, change := range changes {
+		ep := change.Endpoint
+		var err error
+		switch change.Action {
+		case "Delete":
+			err = p.Client.DeleteRecords(ctx, zone, ep.DNSName, ep.RecordType)
+		case "Update":
+			err = p.Client.ReplaceRecords(ctx, zone, ep.DNSName, ep.RecordType, change.Records)
+		case "Create":
+			for _, record := range change.Records {
+				if err = p.Client.CreateRecord(ctx, zone, record); err != nil {
+					break
+				}
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to %s %s record %s of zone %s: %w", strings.ToLower(change.Action), ep.RecordType, ep.DNSName, zone, err)
+		}
+	}
+	return nil
+}
+
+// recordTarget returns the endpoint target of a record. The names are returned fully qualified.
+func recordTarget(r Record) string {
+	switch r.RecordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA:
+		return r.RData.Address
+	case endpoint.RecordTypeCNAME:
+		return strings.TrimSuffix(r.RData.CNAME, ".")
+	case endpoint.RecordTypeTXT:
+		return r.RData.TXTData
+	case endpoint.RecordTypeMX:
+		var preference int
+		if r.RData.Preference != nil {
+			preference = *r.RData.Preference
+		}
+		return fmt.Sprintf("%d %s", preference, strings.TrimSuffix(r.RData.Exchange, "."))
+	}
+	return ""
+}
+
+// newRecord returns the record of an endpoint target. MX targets are of the form "10 mail.example.com", the
+// preference coming first. The TTL of the zone applies to the records without a configured TTL.
+func newRecord(ep *endpoint.Endpoint, target string) (Record, error) {
+	record := Record{FQDN: ep.DNSName, RecordType: ep.RecordType}
+	if ep.RecordTTL.IsConfigured() {
+		record.TTL = int(ep.RecordTTL)
+	}
+	switch ep.RecordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA:
+		record.RData.Address = target
+	case endpoint.RecordTypeCNAME:
+		record.RData.CNAME = target
+	case endpoint.RecordTypeTXT:
+		record.RData.TXTData = target
+	case endpoint.RecordTypeMX:
+		preferenceRaw, exchange, ok := strings.Cut(target, " ")
+		if !ok {
+			return Record{}, fmt.Errorf("the preference is missing")
+		}
+		preference, err := strconv.Atoi(preferenceRaw)
+		if err != nil {
+			return Record{}, fmt.Errorf("invalid preference %q", preferenceRaw)
+		}
+		record.RData.Preference = &preference
+		record.RData.Exchange = exchange
+	}
+	return record, nil
+}
@@ -0,0 +1,302 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dyn
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// fakeDynAPI is an in-memory Dyn REST API. The record changes of a zone are queued until the zone is published.
+type fakeDynAPI struct {
+	mu        sync.Mutex
+	sessions  map[string]bool
+	logins    int
+	published map[string][]Record
+	pending   map[string][]Record
+	publishes []string
+	discards  []string
+	nextID    int64
+	// failPath fails the requests to the path.
+	failPath string
+}
+
+func newFakeDynAPI() *fakeDynAPI {
+	preference := 10
+	return &fakeDynAPI{
+		sessions: map[string]bool{},
+		published: map[string][]Record{
+			"example.com": {
+				{FQDN: "example.com", RecordType: "SOA", RecordID: 1, TTL: 3600},
+				{FQDN: "api.example.com", RecordType: "A", RecordID: 2, TTL: 300, RData: RData{Address: "1.2.3.4"}},
+				{FQDN: "api.example.com", RecordType: "A", RecordID: 3, TTL: 300, RData: RData{Address: "1.2.3.5"}},
+				{FQDN: "api.example.com", RecordType: "TXT", RecordID: 4, TTL: 300, RData: RData{TXTData: "heritage=external-dns"}},
+				{FQDN: "example.com", RecordType: "MX", RecordID: 5, TTL: 3600, RData: RData{Preference: &preference, Exchange: "mail.example.com."}},
+			},
+			"example.org": {
+				{FQDN: "www.example.org", RecordType: "CNAME", RecordID: 6, TTL: 3600, RData: RData{CNAME: "example.com."}},
+			},
+		},
+		pending: map[string][]Record{},
+		nextID:  100,
+	}
+}
+
+func (f *fakeDynAPI) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	respond := func(data any) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"status": "success", "data": data, "job_id": 1, "msgs": []Message{}})
+	}
+	fail := func(status int, errCD, info string) {
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(map[string]any{"status": "failure", "data": map[string]any{}, "job_id": 1, "msgs": []Message{{Info: info, ErrCD: errCD, Level: "ERROR"}}})
+	}
+
+	if req.URL.Path == "/Session/" && req.Method == http.MethodPost {
+		var credentials map[string]string
+		_ = json.NewDecoder(req.Body).Decode(&credentials)
+		if credentials["customer_name"] != "customer" || credentials["user_name"] != "user" || credentials["password"] != "password" {
+			fail(http.StatusBadRequest, "INVALID_DATA", "login: Credentials you entered did not match those in our database")
+			return
+		}
+		f.logins++
+		token := "token-" + strconv.Itoa(f.logins)
+		f.sessions[token] = true
+		respond(map[string]string{"token": token, "version": "3.7.0"})
+		return
+	}
+	token := req.Header.Get("Auth-Token")
+	if !f.sessions[token] {
+		fail(http.StatusBadRequest, "ILLEGAL_OPERATION", "login: Bad or expired credentials")
+		return
+	}
+	if req.URL.Path == f.failPath {
+		fail(http.StatusBadRequest, "TARGET_EXISTS", "node: Cannot create node")
+		return
+	}
+
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	switch {
+	case req.URL.Path == "/Session/" && req.Method == http.MethodDelete:
+		delete(f.sessions, token)
+		respond(map[string]any{})
+	case req.URL.Path == "/Zone/" && req.Method == http.MethodGet:
+		zones := make([]string, 0, len(f.published))
+		for zone := range f.published {
+			zones = append(zones, "/REST/Zone/"+zone+"/")
+		}
+		slices.Sort(zones)
+		respond(zones)
+	case parts[0] == "AllRecord" && req.Method == http.MethodGet:
+		byType := map[string][]Record{}
+		for _, r := range f.published[parts[1]] {
+			key := strings.ToLower(r.RecordType) + "_records"
+			byType[key] = append(byType[key], r)
+		}
+		respond(byType)
+	case parts[0] == "Zone" && req.Method == http.MethodPut:
+		if records, ok := f.pending[parts[1]]; ok {
+			f.published[parts[1]] = records
+			delete(f.pending, parts[1])
+		}
+		f.publishes = append(f.publishes, parts[1])
+		respond(map[string]any{})
+	case parts[0] == "ZoneChanges" && req.Method == http.MethodDelete:
+		delete(f.pending, parts[1])
+		f.discards = append(f.discards, parts[1])
+		respond([]any{})
+	case strings.HasSuffix(parts[0], "Record") && len(parts) == 3:
+		recordType, zone, fqdn := strings.TrimSuffix(parts[0], "Record"), parts[1], parts[2]
+		records, ok := f.pending[zone]
+		if !ok {
+			records = slices.Clone(f.published[zone])
+		}
+		records = f.change(req, records, recordType, fqdn)
+		f.pending[zone] = records
+		respond(map[string]any{})
+	default:
+		fail(http.StatusNotFound, "NOT_FOUND", "path: Not found")
+	}
+}
+
+// change applies a record change to the records of a zone.
+func (f *fakeDynAPI) change(req *http.Request, records []Record, recordType, fqdn string) []Record {
+	newRecord := func(r Record) Record {
+		f.nextID++
+		r.FQDN, r.RecordType, r.RecordID = fqdn, recordType, f.nextID
+		if r.RData.CNAME != "" {
+			r.RData.CNAME += "."
+		}
+		if r.RData.Exchange != "" {
+			r.RData.Exchange += "."
+		}
+		return r
+	}
+	switch req.Method {
+	case http.MethodPost:
+		var r Record
+		_ = json.NewDecoder(req.Body).Decode(&r)
+		return append(records, newRecord(r))
+	case http.MethodPut:
+		var body map[string][]Record
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		records = slices.DeleteFunc(records, func(r Record) bool { return r.FQDN == fqdn && r.RecordType == recordType })
+		for _, r := range body[recordType+"Records"] {
+			records = append(records, newRecord(r))
+		}
+		return records
+	case http.MethodDelete:
+		return slices.DeleteFunc(records, func(r Record) bool { return r.FQDN == fqdn && r.RecordType == recordType })
+	}
+	return records
+}
+
+func newTestProvider(t *testing.T, api *fakeDynAPI, domains ...string) *DynProvider {
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+	client := NewClient("customer", "user", "password", server.Client())
+	client.endpoint = server.URL
+	return &DynProvider{
+		Client:       client,
+		domainFilter: endpoint.NewDomainFilter(domains),
+	}
+}
+
+func TestNewDynProvider(t *testing.T) {
+	t.Setenv("DYN_CUSTOMER_NAME", "customer")
+	t.Setenv("DYN_USERNAME", "user")
+	t.Setenv("DYN_PASSWORD", "password")
+	_, err := NewDynProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.NoError(t, err)
+
+	t.Setenv("DYN_PASSWORD", "")
+	_, err = NewDynProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.EqualError(t, err, "no credentials found, set DYN_CUSTOMER_NAME, DYN_USERNAME and DYN_PASSWORD")
+}
+
+func TestDynProviderRecords(t *testing.T) {
+	api := newFakeDynAPI()
+	p := newTestProvider(t, api, "example.com")
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5"),
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeTXT, 300, "heritage=external-dns"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+	}, endpoints)
+	// the session is closed
+	assert.Equal(t, 1, api.logins)
+	assert.Empty(t, api.sessions)
+}
+
+func TestDynProviderApplyChanges(t *testing.T) {
+	api := newFakeDynAPI()
+	p := newTestProvider(t, api, "example.com", "example.org")
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeAAAA, "2001:db8::1", "2001:db8::2"),
+			endpoint.NewEndpointWithTTL("example.org", endpoint.RecordTypeMX, 600, "10 mail.example.org"),
+			// not in a managed zone
+			endpoint.NewEndpoint("www.example.net", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 60, "1.2.3.5", "1.2.3.6"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeTXT, "heritage=external-dns"),
+			endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeCNAME, "example.com"),
+		},
+	}))
+
+	// the changes are published once per zone and the session is closed
+	assert.Equal(t, []string{"example.com", "example.org"}, api.publishes)
+	assert.Empty(t, api.pending)
+	assert.Empty(t, api.sessions)
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 60, "1.2.3.5", "1.2.3.6"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeAAAA, "2001:db8::1", "2001:db8::2"),
+		endpoint.NewEndpointWithTTL("example.org", endpoint.RecordTypeMX, 600, "10 mail.example.org"),
+	}, endpoints)
+}
+
+func TestDynProviderApplyChangesDryRun(t *testing.T) {
+	api := newFakeDynAPI()
+	p := newTestProvider(t, api, "example.com")
+	p.DryRun = true
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeA, "1.2.3.4", "1.2.3.5")},
+	}))
+	assert.Empty(t, api.pending)
+	assert.Empty(t, api.publishes)
+	assert.Empty(t, api.sessions)
+}
+
+func TestDynProviderApplyChangesDiscardsOnError(t *testing.T) {
+	api := newFakeDynAPI()
+	api.failPath = "/ARecord/example.com/app.example.com/"
+	p := newTestProvider(t, api, "example.com")
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeTXT, "heritage=external-dns")},
+	})
+	require.EqualError(t, err, "failed to create A record app.example.com of zone example.com: dyn: 400 Bad Request: TARGET_EXISTS: node: Cannot create node")
+	// the queued deletion is discarded instead of being published
+	assert.Equal(t, []string{"example.com"}, api.discards)
+	assert.Empty(t, api.publishes)
+	assert.Empty(t, api.pending)
+	assert.Len(t, api.published["example.com"], 5)
+	assert.Empty(t, api.sessions)
+}
+
+func TestDynProviderApplyChangesInvalidTarget(t *testing.T) {
+	api := newFakeDynAPI()
+	p := newTestProvider(t, api, "example.com")
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "mail.example.com")},
+	})
+	require.EqualError(t, err, `invalid target "mail.example.com" of MX record example.com: the preference is missing`)
+	assert.Empty(t, api.pending)
+	assert.Empty(t, api.sessions)
+}
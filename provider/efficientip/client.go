@@ -0,0 +1,227 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package efficientip
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+// pageSize is the number of objects listed per request.
+const pageSize = 1000
+
+// Zone is a DNS zone of SOLIDserver. A zone belongs to a DNS server, or a smart architecture, and optionally to a
+// view of the server, the same zone name being possibly served in several of them.
+type Zone struct {
+	ID     string `json:"zone_id"`
+	Name   string `json:"zone_name"`
+	Type   string `json:"zone_type"`
+	Server string `json:"dns_name"`
+	View   string `json:"dnsview_name"`
+}
+
+// Record is a DNS record of a zone. The records are flat within a zone, a record holds a single value. The name is
+// fully qualified.
+type Record struct {
+	ID     string
+	Name   string
+	Type   string
+	TTL    int
+	Value  string
+	ZoneID string
+}
+
+// rawRecord is a record as returned by the API, whose values are all strings.
+type rawRecord struct {
+	ID     string `json:"rr_id"`
+	Name   string `json:"rr_full_name"`
+	Type   string `json:"rr_type"`
+	TTL    string `json:"ttl"`
+	Value  string `json:"value1"`
+	ZoneID string `json:"zone_id"`
+}
+
+// RecordRequest holds the parameters of the requests adding or updating a record.
+type RecordRequest struct {
+	Name  string
+	Type  string
+	Value string
+	TTL   int
+}
+
+// APIError is an error returned by the SOLIDserver API.
+type APIError struct {
+	StatusCode int
+	Errno      string `json:"errno"`
+	Message    string `json:"errmsg"`
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("efficientip: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("efficientip: %d %s: %s (errno %s)", e.StatusCode, http.StatusText(e.StatusCode), e.Message, e.Errno)
+}
+
+// DNSClient is the interface of the SOLIDserver API used by the provider.
+type DNSClient interface {
+	ListZones(ctx context.Context, server, view string) ([]Zone, error)
+	ListRecords(ctx context.Context, zoneID string) ([]Record, error)
+	AddRecord(ctx context.Context, zoneID string, record RecordRequest) error
+	UpdateRecord(ctx context.Context, recordID string, record RecordRequest) error
+	DeleteRecord(ctx context.Context, recordID string) error
+}
+
+// Client calls the SOLIDserver REST API with the credentials of a user.
+type Client struct {
+	username   string
+	password   string
+	endpoint   string
+	httpClient *http.Client
+}
+
+// NewClient returns a client of the REST API of the SOLIDserver at host, authenticating with the user name and
+// password.
+func NewClient(host, username, password string, httpClient *http.Client) *Client {
+	endpoint := host
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	return &Client{
+		username:   username,
+		password:   password,
+		endpoint:   strings.TrimSuffix(endpoint, "/") + "/rest",
+		httpClient: httpClient,
+	}
+}
+
+// ListZones returns the master zones, of the server and view when they're set.
+func (c *Client) ListZones(ctx context.Context, server, view string) ([]Zone, error) {
+	where := []string{"zone_type='master'"}
+	if server != "" {
+		where = append(where, "dns_name="+quote(server))
+	}
+	if view != "" {
+		where = append(where, "dnsview_name="+quote(view))
+	}
+
+	var zones []Zone
+	for offset := 0; ; offset += pageSize {
+		var page []Zone
+		if err := c.do(ctx, http.MethodGet, "dns_zone_list", listQuery(strings.Join(where, " and "), offset), &page); err != nil {
+			return nil, err
+		}
+		zones = append(zones, page...)
+		if len(page) < pageSize {
+			return zones, nil
+		}
+	}
+}
+
+// ListRecords returns the records of a zone.
+func (c *Client) ListRecords(ctx context.Context, zoneID string) ([]Record, error) {
+	var records []Record
+	for offset := 0; ; offset += pageSize {
+		var page []rawRecord
+		if err := c.do(ctx, http.MethodGet, "dns_rr_list", listQuery("zone_id="+quote(zoneID), offset), &page); err != nil {
+			return nil, err
+		}
+		for _, r := range page {
+			ttl, _ := strconv.Atoi(r.TTL)
+			records = append(records, Record{ID: r.ID, Name: r.Name, Type: r.Type, TTL: ttl, Value: r.Value, ZoneID: r.ZoneID})
+		}
+		if len(page) < pageSize {
+			return records, nil
+		}
+	}
+}
+
+// AddRecord adds a record to a zone.
+func (c *Client) AddRecord(ctx context.Context, zoneID string, record RecordRequest) error {
+	query := recordQuery(record)
+	query.Set("dnszone_id", zoneID)
+	return c.do(ctx, http.MethodPost, "dns_rr_add", query, nil)
+}
+
+// UpdateRecord updates the value and TTL of a record.
+func (c *Client) UpdateRecord(ctx context.Context, recordID string, record RecordRequest) error {
+	query := recordQuery(record)
+	query.Set("rr_id", recordID)
+	return c.do(ctx, http.MethodPut, "dns_rr_update", query, nil)
+}
+
+// DeleteRecord deletes a record.
+func (c *Client) DeleteRecord(ctx context.Context, recordID string) error {
+	return c.do(ctx, http.MethodDelete, "dns_rr_delete", url.Values{"rr_id": {recordID}}, nil)
+}
+
+func listQuery(where string, offset int) url.Values {
+	return url.Values{"WHERE": {where}, "limit": {strconv.Itoa(pageSize)}, "offset": {strconv.Itoa(offset)}}
+}
+
+func recordQuery(record RecordRequest) url.Values {
+	query := url.Values{"rr_name": {record.Name}, "rr_type": {record.Type}, "value1": {record.Value}}
+	if record.TTL > 0 {
+		query.Set("rr_ttl", strconv.Itoa(record.TTL))
+	}
+	return query
+}
+
+// quote quotes a value of a WHERE clause.
+func quote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// do calls a service of the API, with the parameters in the query, and decodes the response into the result. The
+// services answer the lists without results with no content.
+func (c *Client) do(ctx context.Context, method, service string, query url.Values, result any) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+"/"+service+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-IPM-Username", base64.StdEncoding.EncodeToString([]byte(c.username)))
+	req.Header.Set("X-IPM-Password", base64.StdEncoding.EncodeToString([]byte(c.password)))
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", externaldns.UserAgent())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		var errs []APIError
+		if json.NewDecoder(resp.Body).Decode(&errs) == nil && len(errs) > 0 {
+			apiErr.Errno, apiErr.Message = errs[0].Errno, errs[0].Message
+		}
+		return apiErr
+	}
+	if result == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package efficientip
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a client of the ipmadmin user, whose endpoint is a test server running the handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewClient(server.URL, "ipmadmin", "secret", server.Client())
+}
+
+func TestNewClientEndpoint(t *testing.T) {
+	assert.Equal(t, "https://solidserver.example.com/rest", NewClient("solidserver.example.com", "", "", nil).endpoint)
+	assert.Equal(t, "http://10.0.0.1:8080/rest", NewClient("http://10.0.0.1:8080/", "", "", nil).endpoint)
+}
+
+func TestClientListZones(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodGet, req.Method)
+		assert.Equal(t, "/rest/dns_zone_list", req.URL.Path)
+		// the credentials are base64 encoded
+		assert.Equal(t, "aXBtYWRtaW4=", req.Header.Get("X-IPM-Username"))
+		assert.Equal(t, "c2VjcmV0", req.Header.Get("X-IPM-Password"))
+		assert.Equal(t, "zone_type='master' and dns_name='smart.example.com' and dnsview_name='o''internal'", req.URL.Query().Get("WHERE"))
+		fmt.Fprint(w, `[
+			{"zone_id":"12","zone_name":"example.com","zone_type":"master","dns_name":"smart.example.com","dnsview_name":"o'internal"}
+		]`)
+	})
+
+	zones, err := client.ListZones(context.Background(), "smart.example.com", "o'internal")
+	require.NoError(t, err)
+	assert.Equal(t, []Zone{{ID: "12", Name: "example.com", Type: "master", Server: "smart.example.com", View: "o'internal"}}, zones)
+}
+
+func TestClientListRecordsPages(t *testing.T) {
+	var offsets []string
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "zone_id='12'", req.URL.Query().Get("WHERE"))
+		offsets = append(offsets, req.URL.Query().Get("offset"))
+		if req.URL.Query().Get("offset") != "0" {
+			// the lists without results have no content
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		fmt.Fprint(w, "[")
+		for i := range pageSize {
+			if i > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"rr_id":"%d","rr_full_name":"host%d.example.com","rr_type":"A","ttl":"3600","value1":"10.0.0.1","zone_id":"12"}`, i, i)
+		}
+		fmt.Fprint(w, "]")
+	})
+
+	records, err := client.ListRecords(context.Background(), "12")
+	require.NoError(t, err)
+	assert.Len(t, records, pageSize)
+	assert.Equal(t, Record{ID: "0", Name: "host0.example.com", Type: "A", TTL: 3600, Value: "10.0.0.1", ZoneID: "12"}, records[0])
+	assert.Equal(t, []string{"0", "1000"}, offsets)
+}
+
+func TestClientAddRecord(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "/rest/dns_rr_add", req.URL.Path)
+		assert.Equal(t, "dnszone_id=12&rr_name=api.example.com&rr_ttl=300&rr_type=A&value1=10.0.0.1", req.URL.RawQuery)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `[{"ret_oid":"42"}]`)
+	})
+
+	require.NoError(t, client.AddRecord(context.Background(), "12", RecordRequest{Name: "api.example.com", Type: "A", Value: "10.0.0.1", TTL: 300}))
+}
+
+func TestClientAPIError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `[{"errno":"1906","errmsg":"The RR already exists","severity":"ERROR","category":"dns"}]`)
+	})
+
+	err := client.DeleteRecord(context.Background(), "42")
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.EqualError(t, err, "efficientip: 400 Bad Request: The RR already exists (errno 1906)")
+
+	client = newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	assert.EqualError(t, client.DeleteRecord(context.Background(), "42"), "efficientip: 401 Unauthorized")
+}
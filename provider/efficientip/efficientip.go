@@ -0,0 +1,321 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package efficientip
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// EfficientIPProvider is an implementation of Provider for EfficientIP SOLIDserver DDI.
+type EfficientIPProvider struct {
+	provider.BaseProvider
+	Client DNSClient
+	// only consider hosted zones managing domains ending in this suffix
+	domainFilter endpoint.DomainFilter
+	// only consider the zones of this DNS server and view, when set
+	dnsServer string
+	dnsView   string
+	DryRun    bool
+}
+
+type efficientIPChangeCreate struct {
+	Zone    Zone
+	Options RecordRequest
+}
+
+type efficientIPChangeUpdate struct {
+	Zone    Zone
+	Record  Record
+	Options RecordRequest
+}
+
+type efficientIPChangeDelete struct {
+	Zone   Zone
+	Record Record
+}
+
+// efficientIPChanges contains all changes to apply to DNS
+type efficientIPChanges struct {
+	Creates []efficientIPChangeCreate
+	Updates []efficientIPChangeUpdate
+	Deletes []efficientIPChangeDelete
+}
+
+// NewEfficientIPProvider initializes a new EfficientIP SOLIDserver based Provider.
+func NewEfficientIPProvider(domainFilter endpoint.DomainFilter, dryRun bool) (*EfficientIPProvider, error) {
+	host := os.Getenv("EFFICIENTIP_HOST")
+	if host == "" {
+		return nil, fmt.Errorf("no SOLIDserver host found, set EFFICIENTIP_HOST")
+	}
+	username := os.Getenv("EFFICIENTIP_USERNAME")
+	password := os.Getenv("EFFICIENTIP_PASSWORD")
+	if username == "" || password == "" {
+		return nil, fmt.Errorf("no credentials found, set EFFICIENTIP_USERNAME and EFFICIENTIP_PASSWORD")
+	}
+
+	var skipTLSVerify bool
+	if value := os.Getenv("EFFICIENTIP_SKIP_TLS_VERIFY"); value != "" {
+		var err error
+		if skipTLSVerify, err = strconv.ParseBool(value); err != nil {
+			return nil, fmt.Errorf("invalid EFFICIENTIP_SKIP_TLS_VERIFY %q: %w", value, err)
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: skipTLSVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	return &EfficientIPProvider{
+		Client:       NewClient(host, username, password, &http.Client{Timeout: 30 * time.Second, Transport: transport}),
+		domainFilter: domainFilter,
+		dnsServer:    os.Getenv("EFFICIENTIP_DNS_SERVER"),
+		dnsView:      os.Getenv("EFFICIENTIP_DNS_VIEW"),
+		DryRun:       dryRun,
+	}, nil
+}
+
+// Zones returns the list of hosted zones. A zone served in several servers or views is only managed in the first
+// one, the server and view to manage being selected with EFFICIENTIP_DNS_SERVER and EFFICIENTIP_DNS_VIEW.
+func (p *EfficientIPProvider) Zones(ctx context.Context) ([]Zone, error) {
+	zones, err := p.Client.ListZones(ctx, p.dnsServer, p.dnsView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zones: %w", err)
+	}
+
+	var filtered []Zone
+	seen := map[string]Zone{}
+	for _, zone := range zones {
+		if !p.domainFilter.Match(zone.Name) {
+			continue
+		}
+		if first, ok := seen[zone.Name]; ok {
+			log.Warnf("Skipping zone %s of server %s and view %q, already managed in server %s and view %q", zone.Name, zone.Server, zone.View, first.Server, first.View)
+			continue
+		}
+		seen[zone.Name] = zone
+		filtered = append(filtered, zone)
+	}
+	return filtered, nil
+}
+
+// SupportedRecordType returns true for the resource record types of SOLIDserver external-dns manages, PTR records
+// included for the reverse zones.
+func (p *EfficientIPProvider) SupportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT, endpoint.RecordTypePTR:
+		return true
+	default:
+		return false
+	}
+}
+
+// Records returns the list of records in all the zones. SOLIDserver stores a resource record per value, the records
+// of a name and type are merged into a single endpoint.
+func (p *EfficientIPProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, zone := range zones {
+		records, err := p.Client.ListRecords(ctx, zone.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list records of zone %s: %w", zone.Name, err)
+		}
+
+		for _, r := range records {
+			if !p.SupportedRecordType(r.Type) {
+				continue
+			}
+			endpoints = append(endpoints, endpoint.NewEndpointWithTTL(r.Name, r.Type, endpoint.TTL(r.TTL), recordTarget(r)))
+		}
+	}
+	endpoints = provider.MergeEndpointsByNameType(endpoints)
+
+	log.WithFields(log.Fields{
+		"endpoints": endpoints,
+	}).Debug("Endpoints generated from EfficientIP SOLIDserver")
+
+	return endpoints, nil
+}
+
+// ApplyChanges applies the given changes, listing the records of a zone of its DNS server and view once for all its
+// changes.
+func (p *EfficientIPProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return err
+	}
+
+	zonesByID := map[string]Zone{}
+	zoneNameIDMapper := provider.ZoneIDName{}
+	for _, zone := range zones {
+		zonesByID[zone.ID] = zone
+		zoneNameIDMapper.Add(zone.ID, zone.Name)
+	}
+
+	recordsByZone := map[string][]Record{}
+	records := func(zone Zone) ([]Record, error) {
+		if r, ok := recordsByZone[zone.ID]; ok {
+			return r, nil
+		}
+		r, err := p.Client.ListRecords(ctx, zone.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list records of zone %s: %w", zone.Name, err)
+		}
+		recordsByZone[zone.ID] = r
+		return r, nil
+	}
+
+	var efficientIPChanges efficientIPChanges
+	err = provider.ForEachEndpointTargets(changes, func(ep *endpoint.Endpoint, targets []string, prune bool) error {
+		zoneID, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zoneID == "" {
+			log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", ep.DNSName)
+			return nil
+		}
+		zone := zonesByID[zoneID]
+		existing, err := records(zone)
+		if err != nil {
+			return err
+		}
+		efficientIPChanges.add(zone, existing, ep, targets, prune)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.submitChanges(ctx, efficientIPChanges)
+}
+
+// add adds the changes turning the zone's records of the endpoint's name and type into a record per target. The names
+// are compared case-insensitively, as DNS names are.
+func (c *efficientIPChanges) add(zone Zone, existing []Record, ep *endpoint.Endpoint, targets []string, prune bool) {
+	current := slices.DeleteFunc(slices.Clone(existing), func(r Record) bool {
+		return !strings.EqualFold(r.Name, ep.DNSName) || r.Type != ep.RecordType
+	})
+	diff := provider.DiffRecords(current, targets, prune, recordTarget, func(r Record) bool {
+		return ep.RecordTTL.IsConfigured() && r.TTL != int(ep.RecordTTL)
+	})
+
+	options := func(target string) RecordRequest {
+		options := RecordRequest{Name: ep.DNSName, Type: ep.RecordType, Value: target}
+		if ep.RecordTTL.IsConfigured() {
+			options.TTL = int(ep.RecordTTL)
+		}
+		return options
+	}
+	for _, target := range diff.Create {
+		c.Creates = append(c.Creates, efficientIPChangeCreate{Zone: zone, Options: options(target)})
+	}
+	for _, update := range diff.Update {
+		c.Updates = append(c.Updates, efficientIPChangeUpdate{Zone: zone, Record: update.Record, Options: options(update.Target)})
+	}
+	for _, record := range diff.Delete {
+		c.Deletes = append(c.Deletes, efficientIPChangeDelete{Zone: zone, Record: record})
+	}
+}
+
+// submitChanges submits the changes to the SOLIDserver REST API one resource record at a time. The deletions come
+// first, for a CNAME record to take the place of the other records of its name.
+func (p *EfficientIPProvider) submitChanges(ctx context.Context, changes efficientIPChanges) error {
+	for _, change := range changes.Deletes {
+		logFields := log.Fields{
+			"record":   change.Record.Name,
+			"type":     change.Record.Type,
+			"target":   change.Record.Value,
+			"action":   "Delete",
+			"zoneName": change.Zone.Name,
+			"server":   change.Zone.Server,
+			"view":     change.Zone.View,
+		}
+		log.WithFields(logFields).Info("Deleting record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.DeleteRecord(ctx, change.Record.ID); err != nil {
+			return fmt.Errorf("failed to delete %s record %s of zone %s: %w", change.Record.Type, change.Record.Name, change.Zone.Name, err)
+		}
+	}
+
+	for _, change := range changes.Updates {
+		logFields := log.Fields{
+			"record":   change.Record.Name,
+			"type":     change.Record.Type,
+			"target":   change.Options.Value,
+			"ttl":      change.Options.TTL,
+			"action":   "Update",
+			"zoneName": change.Zone.Name,
+			"server":   change.Zone.Server,
+			"view":     change.Zone.View,
+		}
+		log.WithFields(logFields).Info("Updating record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.UpdateRecord(ctx, change.Record.ID, change.Options); err != nil {
+			return fmt.Errorf("failed to update %s record %s of zone %s: %w", change.Record.Type, change.Record.Name, change.Zone.Name, err)
+		}
+	}
+
+	for _, change := range changes.Creates {
+		logFields := log.Fields{
+			"record":   change.Options.Name,
+			"type":     change.Options.Type,
+			"target":   change.Options.Value,
+			"action":   "Create",
+			"zoneName": change.Zone.Name,
+			"server":   change.Zone.Server,
+			"view":     change.Zone.View,
+		}
+		log.WithFields(logFields).Info("Creating record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.AddRecord(ctx, change.Zone.ID, change.Options); err != nil {
+			return fmt.Errorf("failed to create %s record %s of zone %s: %w", change.Options.Type, change.Options.Name, change.Zone.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// recordTarget returns the endpoint target of a record, the names being returned without a trailing dot.
+func recordTarget(r Record) string {
+	switch r.Type {
+	case endpoint.RecordTypeCNAME, endpoint.RecordTypePTR:
+		return strings.TrimSuffix(r.Value, ".")
+	}
+	return r.Value
+}
@@ -0,0 +1,282 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package efficientip
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// fakeSOLIDserverAPI is an in-memory SOLIDserver REST API.
+type fakeSOLIDserverAPI struct {
+	mu      sync.Mutex
+	zones   []Zone
+	records []Record
+	nextID  int
+	writes  int
+}
+
+func newFakeSOLIDserverAPI() *fakeSOLIDserverAPI {
+	return &fakeSOLIDserverAPI{
+		zones: []Zone{
+			{ID: "12", Name: "example.com", Type: "master", Server: "smart.example.com", View: "internal"},
+			{ID: "13", Name: "example.com", Type: "master", Server: "smart.example.com", View: "external"},
+			{ID: "14", Name: "10.in-addr.arpa", Type: "master", Server: "smart.example.com", View: "internal"},
+		},
+		records: []Record{
+			{ID: "1", Name: "example.com", Type: "SOA", TTL: 3600, Value: "ns1.example.com", ZoneID: "12"},
+			{ID: "2", Name: "api.example.com", Type: "A", TTL: 3600, Value: "10.0.0.1", ZoneID: "12"},
+			{ID: "3", Name: "api.example.com", Type: "A", TTL: 3600, Value: "10.0.0.2", ZoneID: "12"},
+			{ID: "4", Name: "api.example.com", Type: "TXT", TTL: 3600, Value: "heritage=external-dns", ZoneID: "12"},
+			{ID: "5", Name: "www.example.com", Type: "CNAME", TTL: 3600, Value: "api.example.com.", ZoneID: "12"},
+			{ID: "6", Name: "api.example.com", Type: "A", TTL: 3600, Value: "203.0.113.1", ZoneID: "13"},
+			{ID: "7", Name: "1.0.0.10.in-addr.arpa", Type: "PTR", TTL: 3600, Value: "api.example.com.", ZoneID: "14"},
+		},
+		nextID: 100,
+	}
+}
+
+func (f *fakeSOLIDserverAPI) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	query := req.URL.Query()
+	fail := func(errno, message string) {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode([]map[string]string{{"errno": errno, "errmsg": message, "severity": "ERROR"}})
+	}
+	respond := func(v any) {
+		// the lists without results have no content
+		if v == nil || (query.Get("offset") != "" && query.Get("offset") != "0") {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	index := func() int {
+		return slices.IndexFunc(f.records, func(r Record) bool { return r.ID == query.Get("rr_id") })
+	}
+	ttl := func() int {
+		ttl, err := strconv.Atoi(query.Get("rr_ttl"))
+		if err != nil {
+			return 3600
+		}
+		return ttl
+	}
+
+	switch strings.TrimPrefix(req.URL.Path, "/rest/") {
+	case "dns_zone_list":
+		var zones []Zone
+		for _, z := range f.zones {
+			where := query.Get("WHERE")
+			if strings.Contains(where, "dnsview_name=") && !strings.Contains(where, "dnsview_name="+quote(z.View)) {
+				continue
+			}
+			zones = append(zones, z)
+		}
+		respond(zones)
+	case "dns_rr_list":
+		zoneID := strings.Trim(strings.TrimPrefix(query.Get("WHERE"), "zone_id="), "'")
+		var records []map[string]string
+		for _, r := range f.records {
+			if r.ZoneID == zoneID {
+				records = append(records, map[string]string{
+					"rr_id": r.ID, "rr_full_name": r.Name, "rr_type": r.Type, "ttl": strconv.Itoa(r.TTL),
+					"value1": r.Value, "zone_id": r.ZoneID,
+				})
+			}
+		}
+		if len(records) == 0 {
+			respond(nil)
+			return
+		}
+		respond(records)
+	case "dns_rr_add":
+		f.nextID++
+		f.writes++
+		f.records = append(f.records, Record{
+			ID: strconv.Itoa(f.nextID), Name: query.Get("rr_name"), Type: query.Get("rr_type"), TTL: ttl(),
+			Value: query.Get("value1"), ZoneID: query.Get("dnszone_id"),
+		})
+		w.WriteHeader(http.StatusCreated)
+		respond([]map[string]string{{"ret_oid": strconv.Itoa(f.nextID)}})
+	case "dns_rr_update":
+		i := index()
+		if i < 0 {
+			fail("1900", "The RR does not exist")
+			return
+		}
+		f.writes++
+		f.records[i].TTL = ttl()
+		f.records[i].Value = query.Get("value1")
+		respond([]map[string]string{{"ret_oid": f.records[i].ID}})
+	case "dns_rr_delete":
+		i := index()
+		if i < 0 {
+			fail("1900", "The RR does not exist")
+			return
+		}
+		f.writes++
+		f.records = slices.Delete(f.records, i, i+1)
+		respond([]map[string]string{{"ret_oid": query.Get("rr_id")}})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestProvider(t *testing.T, api *fakeSOLIDserverAPI, domains ...string) *EfficientIPProvider {
+	return &EfficientIPProvider{
+		Client:       newTestClient(t, api.ServeHTTP),
+		domainFilter: endpoint.NewDomainFilter(domains),
+		dnsView:      "internal",
+	}
+}
+
+func TestNewEfficientIPProvider(t *testing.T) {
+	t.Setenv("EFFICIENTIP_HOST", "solidserver.example.com")
+	t.Setenv("EFFICIENTIP_USERNAME", "ipmadmin")
+	t.Setenv("EFFICIENTIP_PASSWORD", "secret")
+	t.Setenv("EFFICIENTIP_DNS_VIEW", "internal")
+	p, err := NewEfficientIPProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.NoError(t, err)
+	assert.Equal(t, "internal", p.dnsView)
+
+	t.Setenv("EFFICIENTIP_SKIP_TLS_VERIFY", "maybe")
+	_, err = NewEfficientIPProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.ErrorContains(t, err, `invalid EFFICIENTIP_SKIP_TLS_VERIFY "maybe"`)
+
+	t.Setenv("EFFICIENTIP_PASSWORD", "")
+	_, err = NewEfficientIPProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.EqualError(t, err, "no credentials found, set EFFICIENTIP_USERNAME and EFFICIENTIP_PASSWORD")
+
+	t.Setenv("EFFICIENTIP_HOST", "")
+	_, err = NewEfficientIPProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.EqualError(t, err, "no SOLIDserver host found, set EFFICIENTIP_HOST")
+}
+
+func TestEfficientIPProviderZones(t *testing.T) {
+	api := newFakeSOLIDserverAPI()
+	p := newTestProvider(t, api, "example.com")
+	p.dnsView = ""
+
+	// the zone of the second view is skipped
+	zones, err := p.Zones(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Zone{api.zones[0]}, zones)
+}
+
+func TestEfficientIPProviderRecords(t *testing.T) {
+	p := newTestProvider(t, newFakeSOLIDserverAPI(), "example.com", "10.in-addr.arpa")
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 3600, "10.0.0.1", "10.0.0.2"),
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeTXT, 3600, "heritage=external-dns"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeCNAME, 3600, "api.example.com"),
+		endpoint.NewEndpointWithTTL("1.0.0.10.in-addr.arpa", endpoint.RecordTypePTR, 3600, "api.example.com"),
+	}, endpoints)
+}
+
+func TestEfficientIPProviderApplyChanges(t *testing.T) {
+	api := newFakeSOLIDserverAPI()
+	p := newTestProvider(t, api, "example.com", "10.in-addr.arpa")
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeAAAA, "2001:db8::1"),
+			endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeTXT, 300, "heritage=external-dns"),
+			endpoint.NewEndpoint("2.0.0.10.in-addr.arpa", endpoint.RecordTypePTR, "api.example.com"),
+			// not in a managed zone
+			endpoint.NewEndpoint("www.example.net", endpoint.RecordTypeA, "10.0.0.1"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 3600, "10.0.0.1", "10.0.0.2"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 600, "10.0.0.2", "10.0.0.3"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, "api.example.com"),
+		},
+	}))
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 600, "10.0.0.2", "10.0.0.3"),
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeTXT, 3600, "heritage=external-dns"),
+		endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeAAAA, 3600, "2001:db8::1"),
+		endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeTXT, 300, "heritage=external-dns"),
+		endpoint.NewEndpointWithTTL("1.0.0.10.in-addr.arpa", endpoint.RecordTypePTR, 3600, "api.example.com"),
+		endpoint.NewEndpointWithTTL("2.0.0.10.in-addr.arpa", endpoint.RecordTypePTR, 3600, "api.example.com"),
+	}, endpoints)
+	// the unchanged target of api.example.com is updated in place
+	assert.Contains(t, api.records, Record{ID: "3", Name: "api.example.com", Type: "A", TTL: 600, Value: "10.0.0.2", ZoneID: "12"})
+	// the records of the other view are left untouched
+	assert.Contains(t, api.records, Record{ID: "6", Name: "api.example.com", Type: "A", TTL: 3600, Value: "203.0.113.1", ZoneID: "13"})
+	assert.Equal(t, 7, api.writes)
+}
+
+func TestEfficientIPProviderApplyChangesDryRun(t *testing.T) {
+	api := newFakeSOLIDserverAPI()
+	p := newTestProvider(t, api, "example.com")
+	p.DryRun = true
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "10.0.0.9")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeA, "10.0.0.1", "10.0.0.2")},
+	}))
+	assert.Zero(t, api.writes)
+}
+
+func TestEfficientIPProviderApplyChangesError(t *testing.T) {
+	api := newFakeSOLIDserverAPI()
+	p := newTestProvider(t, api, "example.com")
+	// the record is gone by the time it's deleted
+	p.Client = &staleRecordsClient{DNSClient: p.Client}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeTXT, "heritage=external-dns")},
+	})
+	require.EqualError(t, err, "failed to delete TXT record api.example.com of zone example.com: efficientip: 400 Bad Request: The RR does not exist (errno 1900)")
+}
+
+// staleRecordsClient returns records whose IDs don't exist anymore.
+type staleRecordsClient struct {
+	DNSClient
+}
+
+func (c *staleRecordsClient) ListRecords(ctx context.Context, zoneID string) ([]Record, error) {
+	records, err := c.DNSClient.ListRecords(ctx, zoneID)
+	for i := range records {
+		records[i].ID = "404"
+	}
+	return records, err
+}
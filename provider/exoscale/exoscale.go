@@ -18,6 +18,9 @@ package exoscale
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"strconv"
 	"strings"
 
 	egoscale "github.com/exoscale/egoscale/v2"
@@ -53,8 +56,15 @@ type ExoscaleProvider struct {
 // ExoscaleOption for Provider options
 type ExoscaleOption func(*ExoscaleProvider)
 
-// NewExoscaleProvider returns ExoscaleProvider DNS provider interface implementation
+// NewExoscaleProvider returns ExoscaleProvider DNS provider interface implementation. The API key and secret default
+// to the EXOSCALE_API_KEY and EXOSCALE_API_SECRET environment variables.
 func NewExoscaleProvider(env, zone, key, secret string, dryRun bool, opts ...ExoscaleOption) (*ExoscaleProvider, error) {
+	if key == "" {
+		key = os.Getenv("EXOSCALE_API_KEY")
+	}
+	if secret == "" {
+		secret = os.Getenv("EXOSCALE_API_SECRET")
+	}
 	client, err := egoscale.NewClient(
 		key,
 		secret,
@@ -132,13 +142,18 @@ func (ep *ExoscaleProvider) ApplyChanges(ctx context.Context, changes *plan.Chan
 			t := int64(epoint.RecordTTL)
 			ttl = &t
 		}
+		content, priority, err := recordContent(epoint)
+		if err != nil {
+			return err
+		}
 		record := egoscale.DNSDomainRecord{
-			Name:    &name,
-			Type:    &epoint.RecordType,
-			TTL:     ttl,
-			Content: &epoint.Targets[0],
+			Name:     &name,
+			Type:     &epoint.RecordType,
+			TTL:      ttl,
+			Content:  &content,
+			Priority: priority,
 		}
-		_, err := ep.client.CreateDNSDomainRecord(ctx, ep.apiZone, zoneID, &record)
+		_, err = ep.client.CreateDNSDomainRecord(ctx, ep.apiZone, zoneID, &record)
 		if err != nil {
 			return err
 		}
@@ -160,12 +175,16 @@ func (ep *ExoscaleProvider) ApplyChanges(ctx context.Context, changes *plan.Chan
 		}
 
 		for _, record := range records {
-			if *record.Name != name {
+			if *record.Name != name || *record.Type != epoint.RecordType {
 				continue
 			}
 
-			record.Type = &epoint.RecordType
-			record.Content = &epoint.Targets[0]
+			content, priority, err := recordContent(epoint)
+			if err != nil {
+				return err
+			}
+			record.Content = &content
+			record.Priority = priority
 			if epoint.RecordTTL != 0 {
 				ttl := int64(epoint.RecordTTL)
 				record.TTL = &ttl
@@ -202,7 +221,7 @@ func (ep *ExoscaleProvider) ApplyChanges(ctx context.Context, changes *plan.Chan
 		}
 
 		for _, record := range records {
-			if *record.Name != name {
+			if *record.Name != name || *record.Type != epoint.RecordType {
 				continue
 			}
 
@@ -235,14 +254,26 @@ func (ep *ExoscaleProvider) Records(ctx context.Context) ([]*endpoint.Endpoint,
 		}
 
 		for _, record := range records {
+			target := *record.Content
 			switch *record.Type {
-			case "A", "CNAME", "TXT":
+			case "A", "AAAA", "CNAME", "TXT":
 				break
+			case "MX":
+				// Exoscale stores the priority of MX records apart from their content
+				var priority int64
+				if record.Priority != nil {
+					priority = *record.Priority
+				}
+				target = fmt.Sprintf("%d %s", priority, target)
 			default:
 				continue
 			}
 
-			e := endpoint.NewEndpointWithTTL((*record.Name)+"."+(*domain.UnicodeName), *record.Type, endpoint.TTL(*record.TTL), *record.Content)
+			dnsName := *domain.UnicodeName
+			if *record.Name != "" {
+				dnsName = *record.Name + "." + dnsName
+			}
+			e := endpoint.NewEndpointWithTTL(dnsName, *record.Type, endpoint.TTL(*record.TTL), target)
 			endpoints = append(endpoints, e)
 		}
 	}
@@ -299,7 +330,12 @@ func (f *zoneFilter) EndpointZoneID(endpoint *endpoint.Endpoint, zones map[strin
 	var matchZoneID string
 	var matchZoneName string
 	for zoneID, zoneName := range zones {
-		if strings.HasSuffix(endpoint.DNSName, "."+zoneName) && len(zoneName) > len(matchZoneName) {
+		if endpoint.DNSName == zoneName && len(zoneName) > len(matchZoneName) {
+			// the apex of the zone has an empty name
+			matchZoneName = zoneName
+			matchZoneID = zoneID
+			name = ""
+		} else if strings.HasSuffix(endpoint.DNSName, "."+zoneName) && len(zoneName) > len(matchZoneName) {
 			matchZoneName = zoneName
 			matchZoneID = zoneID
 			name = strings.TrimSuffix(endpoint.DNSName, "."+zoneName)
@@ -308,6 +344,24 @@ func (f *zoneFilter) EndpointZoneID(endpoint *endpoint.Endpoint, zones map[strin
 	return matchZoneID, name
 }
 
+// recordContent returns the content of the record of an endpoint, and its priority for MX records whose targets are
+// of the form "10 mail.example.com".
+func recordContent(epoint *endpoint.Endpoint) (string, *int64, error) {
+	target := epoint.Targets[0]
+	if epoint.RecordType != endpoint.RecordTypeMX {
+		return target, nil, nil
+	}
+	priorityRaw, exchange, ok := strings.Cut(target, " ")
+	if !ok {
+		return "", nil, fmt.Errorf("invalid MX target %q of %s, expected a priority and an exchange", target, epoint.DNSName)
+	}
+	priority, err := strconv.ParseInt(priorityRaw, 10, 64)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid priority of MX target %q of %s: %w", target, epoint.DNSName, err)
+	}
+	return exchange, &priority, nil
+}
+
 func merge(updateOld, updateNew []*endpoint.Endpoint) []*endpoint.Endpoint {
 	findMatch := func(template *endpoint.Endpoint) *endpoint.Endpoint {
 		for _, record := range updateNew {
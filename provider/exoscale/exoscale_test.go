@@ -69,6 +69,8 @@ var groups = map[string][]egoscale.DNSDomainRecord{
 	},
 }
 
+var mxPriority int64 = 10
+
 func strPtr(s string) *string {
 	return &s
 }
@@ -151,7 +153,7 @@ func TestExoscaleApplyChanges(t *testing.T) {
 		Delete: []*endpoint.Endpoint{
 			{
 				DNSName:    "v1.foo.com",
-				RecordType: "A",
+				RecordType: "TXT",
 				Targets:    []string{""},
 			},
 			{
@@ -163,7 +165,7 @@ func TestExoscaleApplyChanges(t *testing.T) {
 		UpdateOld: []*endpoint.Endpoint{
 			{
 				DNSName:    "v1.foo.com",
-				RecordType: "A",
+				RecordType: "TXT",
 				Targets:    []string{""},
 			},
 			{
@@ -175,7 +177,7 @@ func TestExoscaleApplyChanges(t *testing.T) {
 		UpdateNew: []*endpoint.Endpoint{
 			{
 				DNSName:    "v1.foo.com",
-				RecordType: "A",
+				RecordType: "TXT",
 				Targets:    []string{""},
 			},
 			{
@@ -203,6 +205,56 @@ func TestExoscaleApplyChanges(t *testing.T) {
 	assert.Equal(t, *groups[domainIDs[0]][0].ID, *updateExoscale[0].record.ID)
 }
 
+func TestNewExoscaleProviderCredentialsFromEnv(t *testing.T) {
+	t.Setenv("EXOSCALE_API_KEY", "")
+	t.Setenv("EXOSCALE_API_SECRET", "")
+	_, err := NewExoscaleProvider("api", "ch-gva-2", "", "", false)
+	assert.Error(t, err)
+
+	t.Setenv("EXOSCALE_API_KEY", "EXOkey")
+	t.Setenv("EXOSCALE_API_SECRET", "secret")
+	_, err = NewExoscaleProvider("api", "ch-gva-2", "", "", false)
+	assert.NoError(t, err)
+}
+
+func TestExoscaleGetRecordsMX(t *testing.T) {
+	groups[domainIDs[1]] = append(groups[domainIDs[1]],
+		egoscale.DNSDomainRecord{ID: strPtr(uuid.New().String()), Name: strPtr(""), Type: strPtr("MX"), Content: strPtr("mail.bar.com"), Priority: &mxPriority, TTL: &defaultTTL},
+		egoscale.DNSDomainRecord{ID: strPtr(uuid.New().String()), Name: strPtr("v6"), Type: strPtr("AAAA"), Content: strPtr("2001:db8::1"), TTL: &defaultTTL},
+	)
+	defer func() { groups[domainIDs[1]] = groups[domainIDs[1]][:2] }()
+	provider := NewExoscaleProviderWithClient(NewExoscaleClientStub(), "", "", false)
+
+	recs, err := provider.Records(context.Background())
+	assert.NoError(t, err)
+	assert.Contains(t, recs, endpoint.NewEndpointWithTTL("bar.com", endpoint.RecordTypeMX, endpoint.TTL(defaultTTL), "10 mail.bar.com"))
+	assert.Contains(t, recs, endpoint.NewEndpointWithTTL("v6.bar.com", endpoint.RecordTypeAAAA, endpoint.TTL(defaultTTL), "2001:db8::1"))
+}
+
+func TestExoscaleApplyChangesMX(t *testing.T) {
+	provider := NewExoscaleProviderWithClient(NewExoscaleClientStub(), "", "", false)
+	createExoscale = make([]createRecordExoscale, 0)
+
+	err := provider.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("foo.com", endpoint.RecordTypeMX, "20 mail.foo.com"),
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, createExoscale, 1)
+	assert.Equal(t, domainIDs[0], createExoscale[0].domainID)
+	assert.Empty(t, *createExoscale[0].record.Name)
+	assert.Equal(t, "mail.foo.com", *createExoscale[0].record.Content)
+	assert.Equal(t, int64(20), *createExoscale[0].record.Priority)
+
+	err = provider.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("foo.com", endpoint.RecordTypeMX, "mail.foo.com"),
+		},
+	})
+	assert.EqualError(t, err, `invalid MX target "mail.foo.com" of foo.com, expected a priority and an exchange`)
+}
+
 func TestExoscaleMerge_NoUpdateOnTTL0Changes(t *testing.T) {
 	updateOld := []*endpoint.Endpoint{
 		{
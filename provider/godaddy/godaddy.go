@@ -20,7 +20,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 	"slices"
+	"strconv"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -134,8 +136,19 @@ func (z gdZoneIDName) findZoneRecord(hostname string) (suitableZoneID string, su
 	return
 }
 
-// NewGoDaddyProvider initializes a new GoDaddy DNS based Provider.
+// NewGoDaddyProvider initializes a new GoDaddy DNS based Provider. The API key and secret default to the
+// GODADDY_API_KEY and GODADDY_API_SECRET environment variables.
 func NewGoDaddyProvider(ctx context.Context, domainFilter endpoint.DomainFilter, ttl int64, apiKey, apiSecret string, useOTE, dryRun bool) (*GDProvider, error) {
+	if apiKey == "" {
+		apiKey = os.Getenv("GODADDY_API_KEY")
+	}
+	if apiSecret == "" {
+		apiSecret = os.Getenv("GODADDY_API_SECRET")
+	}
+	if apiKey == "" || apiSecret == "" {
+		return nil, fmt.Errorf("no API key and secret found, set --godaddy-api-key and --godaddy-api-secret or GODADDY_API_KEY and GODADDY_API_SECRET")
+	}
+
 	client, err := NewClient(useOTE, apiKey, apiSecret)
 	if err != nil {
 		return nil, err
@@ -149,6 +162,17 @@ func NewGoDaddyProvider(ctx context.Context, domainFilter endpoint.DomainFilter,
 	}, nil
 }
 
+// SupportedRecordType returns true for the record types of the default record filter and for MX records, whose
+// priority GoDaddy stores apart from their data.
+func (p *GDProvider) SupportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeMX:
+		return true
+	default:
+		return provider.SupportedRecordType(recordType)
+	}
+}
+
 func (p *GDProvider) zones() ([]string, error) {
 	zones := []gdZone{}
 	filteredZones := []string{}
@@ -240,7 +264,7 @@ func (p *GDProvider) records(ctx *context.Context, zone string, all bool) (*gdRe
 	}
 
 	for _, rec := range recordsIds {
-		if provider.SupportedRecordType(rec.Type) {
+		if p.SupportedRecordType(rec.Type) {
 			log.Debugf("GoDaddy: Record %s for %s is %+v", rec.Name, zone, rec)
 
 			results.records = append(results.records, rec)
@@ -264,6 +288,7 @@ func (p *GDProvider) groupByNameAndType(zoneRecords []gdRecords) []*endpoint.End
 		groupsByZone[zone.zone] = groups
 
 		for _, r := range zone.records {
+			r.Name = recordName(r)
 			groupBy := fmt.Sprintf("%s - %s", r.Type, r.Name)
 
 			if _, ok := groups[groupBy]; !ok {
@@ -280,7 +305,7 @@ func (p *GDProvider) groupByNameAndType(zoneRecords []gdRecords) []*endpoint.End
 			targets := []string{}
 
 			for _, record := range records {
-				targets = append(targets, record.Data)
+				targets = append(targets, recordTarget(record))
 			}
 
 			var recordName string
@@ -349,7 +374,7 @@ func (p *GDProvider) changeAllRecords(endpoints []gdEndpoint, zoneRecords []*gdR
 				dnsName = ""
 			}
 
-			if e.endpoint.RecordType == endpoint.RecordTypeA && (len(dnsName) == 0) {
+			if len(dnsName) == 0 {
 				dnsName = "@"
 			}
 
@@ -440,11 +465,9 @@ func (p *GDProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) er
 func (p *gdRecords) addRecord(client gdClient, endpoint endpoint.Endpoint, dnsName string, dryRun bool) error {
 	var response GDErrorResponse
 	for _, target := range endpoint.Targets {
-		change := gdRecordField{
-			Type: endpoint.RecordType,
-			Name: dnsName,
-			TTL:  int64(endpoint.RecordTTL),
-			Data: target,
+		change, err := newRecordField(endpoint.RecordType, dnsName, target, int64(endpoint.RecordTTL))
+		if err != nil {
+			return err
 		}
 
 		p.records = append(p.records, change)
@@ -468,15 +491,13 @@ func (p *gdRecords) replaceRecord(client gdClient, endpoint endpoint.Endpoint, d
 	records := []string{}
 
 	for _, target := range endpoint.Targets {
-		change := gdRecordField{
-			Type: endpoint.RecordType,
-			Name: dnsName,
-			TTL:  int64(endpoint.RecordTTL),
-			Data: target,
+		change, err := newRecordField(endpoint.RecordType, dnsName, target, int64(endpoint.RecordTTL))
+		if err != nil {
+			return err
 		}
 
 		for index, record := range p.records {
-			if record.Type == change.Type && record.Name == change.Name {
+			if record.Type == change.Type && recordName(record) == change.Name {
 				p.records[index] = change
 				p.changed = true
 			}
@@ -529,7 +550,7 @@ func (p *gdRecords) deleteRecord(client gdClient, endpoint endpoint.Endpoint, dn
 		deleteIndex := -1
 
 		for index, record := range p.records {
-			if record.Type == change.Type && record.Name == change.Name && record.Data == change.Data {
+			if record.Type == change.Type && recordName(record) == change.Name && recordTarget(record) == target {
 				deleteIndex = index
 				break
 			}
@@ -576,6 +597,97 @@ func (c gdRecordField) String() string {
 	return fmt.Sprintf("%s %d IN %s %s", c.Name, c.TTL, c.Type, c.Data)
 }
 
+// recordName returns the name of a record relative to its zone. GoDaddy may return the name of SRV records without
+// their service and protocol, which are stored apart.
+func recordName(r gdRecordField) string {
+	if r.Type != endpoint.RecordTypeSRV || r.Service == nil || r.Protocol == nil {
+		return r.Name
+	}
+	prefix := *r.Service + "." + *r.Protocol
+	if r.Name == prefix || strings.HasPrefix(r.Name, prefix+".") {
+		return r.Name
+	}
+	if r.Name == "@" || r.Name == "" {
+		return prefix
+	}
+	return prefix + "." + r.Name
+}
+
+// recordTarget returns the endpoint target of a record. GoDaddy stores the priority of MX records and the priority,
+// weight and port of SRV records apart from their data.
+func recordTarget(r gdRecordField) string {
+	switch r.Type {
+	case endpoint.RecordTypeMX:
+		return fmt.Sprintf("%d %s", valueOf(r.Priority), r.Data)
+	case endpoint.RecordTypeSRV:
+		var weight int64
+		if r.Weight != nil {
+			weight = *r.Weight
+		}
+		return fmt.Sprintf("%d %d %d %s", valueOf(r.Priority), weight, valueOf(r.Port), r.Data)
+	}
+	return r.Data
+}
+
+// newRecordField returns the record of an endpoint target. MX targets are of the form "10 mail.example.com" and SRV
+// targets of the form "10 5 443 target.example.com", the name of SRV records starting with the service and protocol,
+// e.g. "_sip._tcp".
+func newRecordField(recordType, dnsName, target string, ttl int64) (gdRecordField, error) {
+	record := gdRecordField{
+		Type: recordType,
+		Name: dnsName,
+		TTL:  ttl,
+		Data: target,
+	}
+
+	switch recordType {
+	case endpoint.RecordTypeMX:
+		fields := strings.Fields(target)
+		if len(fields) != 2 {
+			return gdRecordField{}, fmt.Errorf("invalid MX target %q of record %s, expected a priority and an exchange", target, dnsName)
+		}
+		priority, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return gdRecordField{}, fmt.Errorf("invalid priority %q of MX record %s", fields[0], dnsName)
+		}
+		record.Priority = &priority
+		record.Data = fields[1]
+	case endpoint.RecordTypeSRV:
+		fields := strings.Fields(target)
+		if len(fields) != 4 {
+			return gdRecordField{}, fmt.Errorf("invalid SRV target %q of record %s, expected a priority, a weight, a port and a target", target, dnsName)
+		}
+		var values [3]int64
+		for i, field := range fields[:3] {
+			value, err := strconv.ParseUint(field, 10, 16)
+			if err != nil {
+				return gdRecordField{}, fmt.Errorf("invalid SRV target %q of record %s: %w", target, dnsName, err)
+			}
+			values[i] = int64(value)
+		}
+		priority, port := int(values[0]), int(values[2])
+		record.Priority = &priority
+		record.Weight = &values[1]
+		record.Port = &port
+		record.Data = fields[3]
+		labels := strings.SplitN(dnsName, ".", 3)
+		if len(labels) < 2 || !strings.HasPrefix(labels[0], "_") || !strings.HasPrefix(labels[1], "_") {
+			return gdRecordField{}, fmt.Errorf("invalid SRV record name %s, expected a service and a protocol", dnsName)
+		}
+		record.Service = &labels[0]
+		record.Protocol = &labels[1]
+	}
+
+	return record, nil
+}
+
+func valueOf(v *int) int {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
 func countTargets(p *plan.Changes) int {
 	changes := [][]*endpoint.Endpoint{p.Create, p.UpdateNew, p.UpdateOld, p.Delete}
 	count := 0
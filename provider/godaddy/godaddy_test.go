@@ -20,6 +20,9 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"sort"
 	"testing"
 
@@ -27,6 +30,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/plan"
 )
@@ -457,3 +461,82 @@ func TestGoDaddyErrorResponse(t *testing.T) {
 
 	client.AssertExpectations(t)
 }
+
+func TestGoDaddyMXAndSRVRecords(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "sso-key key:secret", r.Header.Get("Authorization"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+string(body))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1/domains":
+			_, _ = w.Write([]byte(`[{"domain":"example.net"}]`))
+		case "GET /v1/domains/example.net/records":
+			_, _ = w.Write([]byte(`[
+				{"name":"@","type":"MX","data":"mail.example.net","ttl":600,"priority":10},
+				{"name":"@","type":"SRV","data":"sip.example.net","ttl":600,"priority":10,"weight":5,"port":5060,"service":"_sip","protocol":"_udp"},
+				{"name":"@","type":"CAA","data":"0 issue \"letsencrypt.org\"","ttl":600}
+			]`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	provider := &GDProvider{
+		client: &Client{
+			APIKey:      "key",
+			APISecret:   "secret",
+			APIEndPoint: server.URL,
+			Client:      &http.Client{},
+			Ratelimiter: rate.NewLimiter(rate.Inf, 1),
+			Timeout:     DefaultTimeout,
+		},
+	}
+
+	endpoints, err := provider.Records(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.net", endpoint.RecordTypeMX, defaultTTL, "10 mail.example.net"),
+		endpoint.NewEndpointWithTTL("_sip._udp.example.net", endpoint.RecordTypeSRV, defaultTTL, "10 5 5060 sip.example.net"),
+	}, endpoints)
+
+	requests = nil
+	require.NoError(t, provider.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("_xmpp._tcp.example.net", endpoint.RecordTypeSRV, 3600, "0 5 5222 xmpp.example.net"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("example.net", endpoint.RecordTypeMX, defaultTTL, "10 mail.example.net"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("example.net", endpoint.RecordTypeMX, defaultTTL, "10 mail.example.net", "20 backup.example.net"),
+		},
+	}))
+	assert.Equal(t, []string{
+		"GET /v1/domains ",
+		"GET /v1/domains/example.net/records ",
+		`PUT /v1/domains/example.net/records/MX/@ [{"data":"mail.example.net","ttl":600,"priority":10},{"data":"backup.example.net","ttl":600,"priority":20}]`,
+		`PATCH /v1/domains/example.net/records [{"data":"xmpp.example.net","name":"_xmpp._tcp","ttl":3600,"type":"SRV","port":5222,"priority":0,"weight":5,"protocol":"_tcp","service":"_xmpp"}]`,
+	}, requests)
+}
+
+func TestGoDaddyInvalidTargets(t *testing.T) {
+	_, err := newRecordField(endpoint.RecordTypeMX, "@", "mail.example.net", defaultTTL)
+	assert.EqualError(t, err, `invalid MX target "mail.example.net" of record @, expected a priority and an exchange`)
+
+	_, err = newRecordField(endpoint.RecordTypeSRV, "sip", "10 5 5060 sip.example.net", defaultTTL)
+	assert.EqualError(t, err, "invalid SRV record name sip, expected a service and a protocol")
+
+	_, err = newRecordField(endpoint.RecordTypeSRV, "_sip._udp", "10 5 sip.example.net", defaultTTL)
+	assert.EqualError(t, err, `invalid SRV target "10 5 sip.example.net" of record _sip._udp, expected a priority, a weight, a port and a target`)
+}
+
+func TestNewGoDaddyProviderMissingCredentials(t *testing.T) {
+	t.Setenv("GODADDY_API_KEY", "key")
+	t.Setenv("GODADDY_API_SECRET", "")
+	_, err := NewGoDaddyProvider(context.Background(), endpoint.NewDomainFilter(nil), defaultTTL, "", "", false, true)
+	assert.EqualError(t, err, "no API key and secret found, set --godaddy-api-key and --godaddy-api-secret or GODADDY_API_KEY and GODADDY_API_SECRET")
+}
@@ -51,6 +51,7 @@ type managedZonesListCallInterface interface {
 type managedZonesServiceInterface interface {
 	Create(project string, managedzone *dns.ManagedZone) managedZonesCreateCallInterface
 	List(project string) managedZonesListCallInterface
+	Patch(project string, managedZone string, zone *dns.ManagedZone) managedZonesPatchCallInterface
 }
 
 type resourceRecordSetsListCallInterface interface {
@@ -120,12 +121,18 @@ type GoogleProvider struct {
 	managedZonesClient managedZonesServiceInterface
 	// A client for managing change sets
 	changesClient changesServiceInterface
+	// The response policy whose rules hold the records instead of the managed zones, when set
+	responsePolicy string
+	// A client for managing the rules of the response policy
+	responsePolicyRulesClient responsePolicyRulesServiceInterface
+	// The forwarding zones kept in sync with the configured targets
+	forwardingConfig ForwardingConfig
 	// The context parameter to be passed for gcloud API calls.
 	ctx context.Context
 }
 
 // NewGoogleProvider initializes a new Google CloudDNS based Provider.
-func NewGoogleProvider(ctx context.Context, project string, domainFilter endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, batchChangeSize int, batchChangeInterval time.Duration, zoneVisibility string, dryRun bool) (*GoogleProvider, error) {
+func NewGoogleProvider(ctx context.Context, project string, domainFilter endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, batchChangeSize int, batchChangeInterval time.Duration, zoneVisibility string, responsePolicy string, forwardingConfig ForwardingConfig, dryRun bool) (*GoogleProvider, error) {
 	gcloud, err := google.DefaultClient(ctx, dns.NdevClouddnsReadwriteScope)
 	if err != nil {
 		return nil, err
@@ -155,27 +162,34 @@ func NewGoogleProvider(ctx context.Context, project string, domainFilter endpoin
 	zoneTypeFilter := provider.NewZoneTypeFilter(zoneVisibility)
 
 	return &GoogleProvider{
-		project:                  project,
-		dryRun:                   dryRun,
-		batchChangeSize:          batchChangeSize,
-		batchChangeInterval:      batchChangeInterval,
-		domainFilter:             domainFilter,
-		zoneTypeFilter:           zoneTypeFilter,
-		zoneIDFilter:             zoneIDFilter,
-		resourceRecordSetsClient: resourceRecordSetsService{dnsClient.ResourceRecordSets},
-		managedZonesClient:       managedZonesService{dnsClient.ManagedZones},
-		changesClient:            changesService{dnsClient.Changes},
-		ctx:                      ctx,
+		project:                   project,
+		dryRun:                    dryRun,
+		batchChangeSize:           batchChangeSize,
+		batchChangeInterval:       batchChangeInterval,
+		domainFilter:              domainFilter,
+		zoneTypeFilter:            zoneTypeFilter,
+		zoneIDFilter:              zoneIDFilter,
+		resourceRecordSetsClient:  resourceRecordSetsService{dnsClient.ResourceRecordSets},
+		managedZonesClient:        managedZonesService{dnsClient.ManagedZones},
+		changesClient:             changesService{dnsClient.Changes},
+		responsePolicy:            responsePolicy,
+		responsePolicyRulesClient: responsePolicyRulesService{dnsClient.ResponsePolicyRules},
+		forwardingConfig:          forwardingConfig,
+		ctx:                       ctx,
 	}, nil
 }
 
 // Zones returns the list of hosted zones.
 func (p *GoogleProvider) Zones(ctx context.Context) (map[string]*dns.ManagedZone, error) {
 	zones := make(map[string]*dns.ManagedZone)
+	var forwardingZones []*dns.ManagedZone
 
 	f := func(resp *dns.ManagedZonesListResponse) error {
 		for _, zone := range resp.ManagedZones {
-			if zone.PeeringConfig == nil {
+			if zone.ForwardingConfig != nil {
+				forwardingZones = append(forwardingZones, zone)
+				log.Debugf("Filtered forwarding zone %s (zone: %s) (visibility: %s)", zone.DnsName, zone.Name, zone.Visibility)
+			} else if zone.PeeringConfig == nil {
 				if p.domainFilter.Match(zone.DnsName) && p.zoneTypeFilter.Match(zone.Visibility) && (p.zoneIDFilter.Match(fmt.Sprintf("%v", zone.Id)) || p.zoneIDFilter.Match(fmt.Sprintf("%v", zone.Name))) {
 					zones[zone.Name] = zone
 					log.Debugf("Matched %s (zone: %s) (visibility: %s)", zone.DnsName, zone.Name, zone.Visibility)
@@ -195,6 +209,10 @@ func (p *GoogleProvider) Zones(ctx context.Context) (map[string]*dns.ManagedZone
 		return nil, provider.NewSoftError(fmt.Errorf("failed to list zones: %w", err))
 	}
 
+	if err := p.ensureForwardingZones(forwardingZones); err != nil {
+		return nil, provider.NewSoftError(err)
+	}
+
 	if len(zones) == 0 {
 		log.Warnf("No zones in the project, %s, match domain filters: %v", p.project, p.domainFilter)
 	}
@@ -206,8 +224,12 @@ func (p *GoogleProvider) Zones(ctx context.Context) (map[string]*dns.ManagedZone
 	return zones, nil
 }
 
-// Records returns the list of records in all relevant zones.
+// Records returns the list of records in all relevant zones, or in the response policy when one is set.
 func (p *GoogleProvider) Records(ctx context.Context) (endpoints []*endpoint.Endpoint, _ error) {
+	if p.responsePolicy != "" {
+		return p.responsePolicyRecords(ctx)
+	}
+
 	zones, err := p.Zones(ctx)
 	if err != nil {
 		return nil, err
@@ -235,6 +257,10 @@ func (p *GoogleProvider) Records(ctx context.Context) (endpoints []*endpoint.End
 
 // ApplyChanges applies a given set of changes in a given zone.
 func (p *GoogleProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	if p.responsePolicy != "" {
+		return p.applyResponsePolicyChanges(ctx, changes)
+	}
+
 	change := &dns.Change{}
 
 	change.Additions = append(change.Additions, p.newFilteredRecords(changes.Create)...)
@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package google
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	dns "google.golang.org/api/dns/v1"
+	googleapi "google.golang.org/api/googleapi"
+
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	// forwardingZonePrefix is the prefix of the names of the forwarding zones created by external-dns.
+	forwardingZonePrefix = "external-dns-"
+	// maxManagedZoneNameLength is the maximum length of the name of a managed zone.
+	maxManagedZoneNameLength = 63
+
+	ForwardingPathDefault = "default"
+	ForwardingPathPrivate = "private"
+)
+
+type managedZonesPatchCallInterface interface {
+	Do(opts ...googleapi.CallOption) (*dns.Operation, error)
+}
+
+func (m managedZonesService) Patch(project string, managedZone string, zone *dns.ManagedZone) managedZonesPatchCallInterface {
+	return m.service.Patch(project, managedZone, zone)
+}
+
+// ForwardingTarget is a name server the queries of the forwarding zones are forwarded to.
+type ForwardingTarget struct {
+	IPAddress string
+	// ForwardingPath is "default" to forward over the internet for public addresses, or "private" to always
+	// forward through the VPC network.
+	ForwardingPath string
+}
+
+// ForwardingConfig is the configuration of the forwarding zones kept in sync by the provider.
+type ForwardingConfig struct {
+	// DNS names of the forwarding zones
+	Zones []string
+	// name servers the queries are forwarded to
+	Targets []ForwardingTarget
+	// URLs of the VPC networks the forwarding zones are visible to
+	Networks []string
+}
+
+// ParseForwardingTarget parses a target in the form ip or ip,path where path is default or private.
+func ParseForwardingTarget(value string) (ForwardingTarget, error) {
+	address, path, _ := strings.Cut(value, ",")
+	if net.ParseIP(address) == nil {
+		return ForwardingTarget{}, fmt.Errorf("invalid forwarding target %q: %q is not an IP address", value, address)
+	}
+	switch path {
+	case "":
+		path = ForwardingPathDefault
+	case ForwardingPathDefault, ForwardingPathPrivate:
+	default:
+		return ForwardingTarget{}, fmt.Errorf("invalid forwarding target %q: the forwarding path must be %s or %s", value, ForwardingPathDefault, ForwardingPathPrivate)
+	}
+	return ForwardingTarget{IPAddress: address, ForwardingPath: path}, nil
+}
+
+// forwardingZoneName returns the name of the forwarding zone of a DNS name, zone names only allow lowercase letters,
+// digits and hyphens.
+func forwardingZoneName(dnsName string) string {
+	name := strings.ToLower(strings.TrimSuffix(dnsName, "."))
+	name = forwardingZonePrefix + strings.NewReplacer(".", "-", "_", "-").Replace(name)
+	if len(name) > maxManagedZoneNameLength {
+		name = strings.TrimRight(name[:maxManagedZoneNameLength], "-")
+	}
+	return name
+}
+
+// newForwardingConfig returns the forwarding configuration of the zones with the targets.
+func (c ForwardingConfig) newForwardingConfig() *dns.ManagedZoneForwardingConfig {
+	config := &dns.ManagedZoneForwardingConfig{}
+	for _, target := range c.Targets {
+		nameServer := &dns.ManagedZoneForwardingConfigNameServerTarget{ForwardingPath: target.ForwardingPath}
+		if ip := net.ParseIP(target.IPAddress); ip != nil && ip.To4() == nil {
+			nameServer.Ipv6Address = target.IPAddress
+		} else {
+			nameServer.Ipv4Address = target.IPAddress
+		}
+		config.TargetNameServers = append(config.TargetNameServers, nameServer)
+	}
+	return config
+}
+
+// newPrivateVisibilityConfig returns the visibility of the zones in the networks.
+func (c ForwardingConfig) newPrivateVisibilityConfig() *dns.ManagedZonePrivateVisibilityConfig {
+	config := &dns.ManagedZonePrivateVisibilityConfig{}
+	for _, network := range c.Networks {
+		config.Networks = append(config.Networks, &dns.ManagedZonePrivateVisibilityConfigNetwork{NetworkUrl: network})
+	}
+	return config
+}
+
+// forwardingTargetKeys returns the targets of a forwarding configuration in a comparable form.
+func forwardingTargetKeys(config *dns.ManagedZoneForwardingConfig) []string {
+	var keys []string
+	if config != nil {
+		for _, target := range config.TargetNameServers {
+			path := target.ForwardingPath
+			if path == "" {
+				path = ForwardingPathDefault
+			}
+			keys = append(keys, target.Ipv4Address+target.Ipv6Address+","+path)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// networkKeys returns the networks of a visibility configuration in a comparable form.
+func networkKeys(config *dns.ManagedZonePrivateVisibilityConfig) []string {
+	var keys []string
+	if config != nil {
+		for _, network := range config.Networks {
+			keys = append(keys, network.NetworkUrl)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// ensureForwardingZones creates the missing forwarding zones, and updates the targets and networks of the
+// existing ones when they differ from the configuration. The forwarding zones are found by DNS name.
+func (p *GoogleProvider) ensureForwardingZones(forwardingZones []*dns.ManagedZone) error {
+	if len(p.forwardingConfig.Zones) == 0 {
+		return nil
+	}
+
+	existing := make(map[string]*dns.ManagedZone, len(forwardingZones))
+	for _, zone := range forwardingZones {
+		existing[strings.ToLower(provider.EnsureTrailingDot(zone.DnsName))] = zone
+	}
+
+	desiredForwarding := p.forwardingConfig.newForwardingConfig()
+	desiredVisibility := p.forwardingConfig.newPrivateVisibilityConfig()
+
+	for _, dnsName := range p.forwardingConfig.Zones {
+		dnsName = strings.ToLower(provider.EnsureTrailingDot(dnsName))
+		zone, ok := existing[dnsName]
+		if !ok {
+			zone = &dns.ManagedZone{
+				Name:                    forwardingZoneName(dnsName),
+				DnsName:                 dnsName,
+				Description:             "Forwarding zone managed by external-dns",
+				Visibility:              "private",
+				ForwardingConfig:        desiredForwarding,
+				PrivateVisibilityConfig: desiredVisibility,
+			}
+			log.Infof("Create forwarding zone %s (domain: %s)", zone.Name, dnsName)
+			if p.dryRun {
+				continue
+			}
+			if _, err := p.managedZonesClient.Create(p.project, zone).Do(); err != nil {
+				return fmt.Errorf("failed to create forwarding zone %s: %w", zone.Name, err)
+			}
+			continue
+		}
+
+		if slices.Equal(forwardingTargetKeys(zone.ForwardingConfig), forwardingTargetKeys(desiredForwarding)) &&
+			slices.Equal(networkKeys(zone.PrivateVisibilityConfig), networkKeys(desiredVisibility)) {
+			continue
+		}
+		log.Infof("Update forwarding zone %s (domain: %s)", zone.Name, dnsName)
+		if p.dryRun {
+			continue
+		}
+		patch := &dns.ManagedZone{
+			ForwardingConfig:        desiredForwarding,
+			PrivateVisibilityConfig: desiredVisibility,
+		}
+		if _, err := p.managedZonesClient.Patch(p.project, zone.Name, patch).Do(); err != nil {
+			return fmt.Errorf("failed to update forwarding zone %s: %w", zone.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package google
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dns "google.golang.org/api/dns/v1"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const testForwardingNetwork = "https://www.googleapis.com/compute/v1/projects/forwarding-test/global/networks/default"
+
+// newGoogleForwardingProvider returns a provider of its own project, so that the forwarding zones it creates don't
+// show up in the other tests.
+func newGoogleForwardingProvider(t *testing.T, project string, dryRun bool, config ForwardingConfig) *GoogleProvider {
+	t.Cleanup(func() {
+		for key := range testZones {
+			if strings.HasPrefix(key, project+"/") {
+				delete(testZones, key)
+			}
+		}
+	})
+	return &GoogleProvider{
+		project:            project,
+		dryRun:             dryRun,
+		domainFilter:       endpoint.NewDomainFilter([]string{}),
+		managedZonesClient: &mockManagedZonesClient{},
+		forwardingConfig:   config,
+	}
+}
+
+func TestGoogleForwardingZonesCreate(t *testing.T) {
+	p := newGoogleForwardingProvider(t, "forwarding-create", false, ForwardingConfig{
+		Zones: []string{"corp.example.com", "Onprem.Example.org."},
+		Targets: []ForwardingTarget{
+			{IPAddress: "10.0.0.53", ForwardingPath: ForwardingPathDefault},
+			{IPAddress: "fd00::53", ForwardingPath: ForwardingPathPrivate},
+		},
+		Networks: []string{testForwardingNetwork},
+	})
+
+	zones, err := p.Zones(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, zones, "forwarding zones don't hold records")
+
+	zone := testZones[zoneKey("forwarding-create", "external-dns-corp-example-com")]
+	require.NotNil(t, zone)
+	assert.Equal(t, "corp.example.com.", zone.DnsName)
+	assert.Equal(t, "private", zone.Visibility)
+	assert.Equal(t, []*dns.ManagedZoneForwardingConfigNameServerTarget{
+		{Ipv4Address: "10.0.0.53", ForwardingPath: ForwardingPathDefault},
+		{Ipv6Address: "fd00::53", ForwardingPath: ForwardingPathPrivate},
+	}, zone.ForwardingConfig.TargetNameServers)
+	assert.Equal(t, []*dns.ManagedZonePrivateVisibilityConfigNetwork{
+		{NetworkUrl: testForwardingNetwork},
+	}, zone.PrivateVisibilityConfig.Networks)
+
+	zone = testZones[zoneKey("forwarding-create", "external-dns-onprem-example-org")]
+	require.NotNil(t, zone)
+	assert.Equal(t, "onprem.example.org.", zone.DnsName)
+}
+
+func TestGoogleForwardingZonesUpdate(t *testing.T) {
+	p := newGoogleForwardingProvider(t, "forwarding-update", false, ForwardingConfig{
+		Zones:    []string{"corp.example.com", "lab.example.com"},
+		Targets:  []ForwardingTarget{{IPAddress: "10.0.0.53", ForwardingPath: ForwardingPathPrivate}},
+		Networks: []string{testForwardingNetwork},
+	})
+	outdated := &dns.ManagedZone{
+		Name:    "corp-forwarding",
+		DnsName: "corp.example.com.",
+		ForwardingConfig: &dns.ManagedZoneForwardingConfig{
+			TargetNameServers: []*dns.ManagedZoneForwardingConfigNameServerTarget{{Ipv4Address: "10.0.0.1"}},
+		},
+	}
+	upToDate := &dns.ManagedZone{
+		Name:                    "lab-forwarding",
+		DnsName:                 "lab.example.com.",
+		ForwardingConfig:        p.forwardingConfig.newForwardingConfig(),
+		PrivateVisibilityConfig: p.forwardingConfig.newPrivateVisibilityConfig(),
+	}
+	testZones[zoneKey("forwarding-update", outdated.Name)] = outdated
+	testZones[zoneKey("forwarding-update", upToDate.Name)] = upToDate
+
+	_, err := p.Zones(context.Background())
+	require.NoError(t, err)
+
+	assert.NotContains(t, testZones, zoneKey("forwarding-update", "external-dns-corp-example-com"))
+	assert.NotContains(t, testZones, zoneKey("forwarding-update", "external-dns-lab-example-com"))
+	assert.Equal(t, []*dns.ManagedZoneForwardingConfigNameServerTarget{
+		{Ipv4Address: "10.0.0.53", ForwardingPath: ForwardingPathPrivate},
+	}, outdated.ForwardingConfig.TargetNameServers)
+	assert.Len(t, outdated.PrivateVisibilityConfig.Networks, 1)
+}
+
+func TestGoogleForwardingZonesDryRun(t *testing.T) {
+	p := newGoogleForwardingProvider(t, "forwarding-dry-run", true, ForwardingConfig{
+		Zones:   []string{"corp.example.com"},
+		Targets: []ForwardingTarget{{IPAddress: "10.0.0.53", ForwardingPath: ForwardingPathDefault}},
+	})
+
+	_, err := p.Zones(context.Background())
+	require.NoError(t, err)
+	assert.NotContains(t, testZones, zoneKey("forwarding-dry-run", "external-dns-corp-example-com"))
+}
+
+func TestGoogleForwardingZonesFiltered(t *testing.T) {
+	p := newGoogleForwardingProvider(t, "forwarding-filtered", false, ForwardingConfig{})
+	testZones[zoneKey("forwarding-filtered", "records")] = &dns.ManagedZone{Name: "records", DnsName: "example.com."}
+	testZones[zoneKey("forwarding-filtered", "forwarding")] = &dns.ManagedZone{
+		Name:             "forwarding",
+		DnsName:          "corp.example.com.",
+		ForwardingConfig: &dns.ManagedZoneForwardingConfig{},
+	}
+
+	zones, err := p.Zones(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, zones, "records")
+	assert.NotContains(t, zones, "forwarding")
+}
+
+func TestGoogleForwardingZonesCreateError(t *testing.T) {
+	p := newGoogleForwardingProvider(t, "forwarding-error", false, ForwardingConfig{
+		Zones:   []string{"corp.example.com"},
+		Targets: []ForwardingTarget{{IPAddress: "10.0.0.53", ForwardingPath: ForwardingPathDefault}},
+	})
+	// a zone of the same name which isn't a forwarding zone makes the creation fail
+	testZones[zoneKey("forwarding-error", "external-dns-corp-example-com")] = &dns.ManagedZone{
+		Name:    "external-dns-corp-example-com",
+		DnsName: "corp.example.net.",
+	}
+
+	_, err := p.Zones(context.Background())
+	require.ErrorIs(t, err, provider.SoftError)
+	assert.ErrorContains(t, err, "failed to create forwarding zone external-dns-corp-example-com")
+}
+
+func TestParseForwardingTarget(t *testing.T) {
+	for _, tc := range []struct {
+		value    string
+		expected ForwardingTarget
+		err      string
+	}{
+		{value: "10.0.0.53", expected: ForwardingTarget{IPAddress: "10.0.0.53", ForwardingPath: ForwardingPathDefault}},
+		{value: "10.0.0.53,private", expected: ForwardingTarget{IPAddress: "10.0.0.53", ForwardingPath: ForwardingPathPrivate}},
+		{value: "fd00::53,default", expected: ForwardingTarget{IPAddress: "fd00::53", ForwardingPath: ForwardingPathDefault}},
+		{value: "ns.example.com", err: `invalid forwarding target "ns.example.com": "ns.example.com" is not an IP address`},
+		{value: "10.0.0.53,public", err: `invalid forwarding target "10.0.0.53,public": the forwarding path must be default or private`},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			target, err := ParseForwardingTarget(tc.value)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, target)
+		})
+	}
+}
+
+func TestForwardingZoneName(t *testing.T) {
+	assert.Equal(t, "external-dns-corp-example-com", forwardingZoneName("Corp.Example.com."))
+	name := forwardingZoneName(strings.Repeat("a", 30) + "." + strings.Repeat("b", 30) + ".com.")
+	assert.LessOrEqual(t, len(name), maxManagedZoneNameLength)
+	assert.False(t, strings.HasSuffix(name, "-"))
+}
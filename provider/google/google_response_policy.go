@@ -0,0 +1,231 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package google
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	dns "google.golang.org/api/dns/v1"
+	googleapi "google.golang.org/api/googleapi"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	// responsePolicyRulePrefix is the prefix of the names of the rules created by external-dns.
+	responsePolicyRulePrefix = "external-dns-"
+	// maxResponsePolicyRuleNameLength is the maximum length of the name of a rule.
+	maxResponsePolicyRuleNameLength = 63
+)
+
+type responsePolicyRulesListCallInterface interface {
+	Pages(ctx context.Context, f func(*dns.ResponsePolicyRulesListResponse) error) error
+}
+
+type responsePolicyRulesCreateCallInterface interface {
+	Do(opts ...googleapi.CallOption) (*dns.ResponsePolicyRule, error)
+}
+
+type responsePolicyRulesUpdateCallInterface interface {
+	Do(opts ...googleapi.CallOption) (*dns.ResponsePolicyRulesUpdateResponse, error)
+}
+
+type responsePolicyRulesDeleteCallInterface interface {
+	Do(opts ...googleapi.CallOption) error
+}
+
+type responsePolicyRulesServiceInterface interface {
+	List(project string, responsePolicy string) responsePolicyRulesListCallInterface
+	Create(project string, responsePolicy string, rule *dns.ResponsePolicyRule) responsePolicyRulesCreateCallInterface
+	Update(project string, responsePolicy string, ruleName string, rule *dns.ResponsePolicyRule) responsePolicyRulesUpdateCallInterface
+	Delete(project string, responsePolicy string, ruleName string) responsePolicyRulesDeleteCallInterface
+}
+
+type responsePolicyRulesService struct {
+	service *dns.ResponsePolicyRulesService
+}
+
+func (r responsePolicyRulesService) List(project string, responsePolicy string) responsePolicyRulesListCallInterface {
+	return r.service.List(project, responsePolicy)
+}
+
+func (r responsePolicyRulesService) Create(project string, responsePolicy string, rule *dns.ResponsePolicyRule) responsePolicyRulesCreateCallInterface {
+	return r.service.Create(project, responsePolicy, rule)
+}
+
+func (r responsePolicyRulesService) Update(project string, responsePolicy string, ruleName string, rule *dns.ResponsePolicyRule) responsePolicyRulesUpdateCallInterface {
+	return r.service.Update(project, responsePolicy, ruleName, rule)
+}
+
+func (r responsePolicyRulesService) Delete(project string, responsePolicy string, ruleName string) responsePolicyRulesDeleteCallInterface {
+	return r.service.Delete(project, responsePolicy, ruleName)
+}
+
+// responsePolicyRuleName returns the name of the rule of a DNS name, rule names only allow lowercase letters, digits
+// and hyphens, so a hash of the DNS name is used when it doesn't fit.
+func responsePolicyRuleName(dnsName string) string {
+	name := strings.ToLower(strings.TrimSuffix(dnsName, "."))
+	name = strings.NewReplacer(".", "-", "*", "wildcard", "_", "-").Replace(name)
+	name = responsePolicyRulePrefix + name
+	if len(name) <= maxResponsePolicyRuleNameLength {
+		return name
+	}
+	hash := sha256.Sum256([]byte(dnsName))
+	return responsePolicyRulePrefix + hex.EncodeToString(hash[:])[:32]
+}
+
+// listResponsePolicyRules returns the rules of the response policy, by DNS name.
+func (p *GoogleProvider) listResponsePolicyRules(ctx context.Context) (map[string]*dns.ResponsePolicyRule, error) {
+	rules := make(map[string]*dns.ResponsePolicyRule)
+	f := func(resp *dns.ResponsePolicyRulesListResponse) error {
+		for _, rule := range resp.ResponsePolicyRules {
+			rules[provider.EnsureTrailingDot(rule.DnsName)] = rule
+		}
+		return nil
+	}
+	if err := p.responsePolicyRulesClient.List(p.project, p.responsePolicy).Pages(ctx, f); err != nil {
+		return nil, provider.NewSoftError(fmt.Errorf("failed to list rules of response policy %s: %w", p.responsePolicy, err))
+	}
+	return rules, nil
+}
+
+// responsePolicyRecords returns the local data of the rules of the response policy, the rules with a behavior
+// rather than local data are ignored.
+func (p *GoogleProvider) responsePolicyRecords(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	rules, err := p.listResponsePolicyRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, rule := range rules {
+		if rule.LocalData == nil || !p.domainFilter.Match(rule.DnsName) {
+			continue
+		}
+		for _, r := range rule.LocalData.LocalDatas {
+			if !p.SupportedRecordType(r.Type) {
+				continue
+			}
+			endpoints = append(endpoints, endpoint.NewEndpointWithTTL(r.Name, r.Type, endpoint.TTL(r.Ttl), r.Rrdatas...))
+		}
+	}
+	return endpoints, nil
+}
+
+// applyResponsePolicyChanges applies the changes to the rules of the response policy, each DNS name is a rule
+// whose local data holds a record set per type.
+func (p *GoogleProvider) applyResponsePolicyChanges(ctx context.Context, changes *plan.Changes) error {
+	deletions := append(p.newFilteredRecords(changes.UpdateOld), p.newFilteredRecords(changes.Delete)...)
+	additions := append(p.newFilteredRecords(changes.Create), p.newFilteredRecords(changes.UpdateNew)...)
+	if len(additions) == 0 && len(deletions) == 0 {
+		log.Info("All records are already up to date")
+		return nil
+	}
+
+	rules, err := p.listResponsePolicyRules(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the record sets of the changed DNS names, by type
+	desired := map[string]map[string]*dns.ResourceRecordSet{}
+	recordSets := func(name string) map[string]*dns.ResourceRecordSet {
+		if sets, ok := desired[name]; ok {
+			return sets
+		}
+		sets := map[string]*dns.ResourceRecordSet{}
+		if rule, ok := rules[name]; ok && rule.LocalData != nil {
+			for _, r := range rule.LocalData.LocalDatas {
+				sets[r.Type] = r
+			}
+		}
+		desired[name] = sets
+		return sets
+	}
+	for _, del := range deletions {
+		log.Infof("Del records: %s %s %s %d", del.Name, del.Type, del.Rrdatas, del.Ttl)
+		delete(recordSets(del.Name), del.Type)
+	}
+	for _, add := range additions {
+		log.Infof("Add records: %s %s %s %d", add.Name, add.Type, add.Rrdatas, add.Ttl)
+		recordSets(add.Name)[add.Type] = add
+	}
+
+	if p.dryRun {
+		return nil
+	}
+
+	names := make([]string, 0, len(desired))
+	for name := range desired {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := p.submitResponsePolicyRule(name, rules[name], desired[name]); err != nil {
+			return provider.NewSoftError(fmt.Errorf("failed to change rule of %s in response policy %s: %w", name, p.responsePolicy, err))
+		}
+	}
+	return nil
+}
+
+// submitResponsePolicyRule creates, updates or deletes the rule of the DNS name so that it holds the record sets.
+func (p *GoogleProvider) submitResponsePolicyRule(dnsName string, current *dns.ResponsePolicyRule, recordSets map[string]*dns.ResourceRecordSet) error {
+	localDatas := make([]*dns.ResourceRecordSet, 0, len(recordSets))
+	for _, r := range recordSets {
+		localDatas = append(localDatas, r)
+	}
+	sort.Slice(localDatas, func(i, j int) bool { return localDatas[i].Type < localDatas[j].Type })
+
+	switch {
+	case current == nil && len(localDatas) == 0:
+		return nil
+	case current != nil && current.LocalData == nil:
+		// the rules with a behavior are not managed by external-dns
+		log.Warnf("Skipping response policy rule %s for %s which has no local data", current.RuleName, dnsName)
+		return nil
+	case current == nil:
+		rule := &dns.ResponsePolicyRule{
+			RuleName:  responsePolicyRuleName(dnsName),
+			DnsName:   dnsName,
+			LocalData: &dns.ResponsePolicyRuleLocalData{LocalDatas: localDatas},
+		}
+		log.Infof("Create response policy rule %s for %s", rule.RuleName, dnsName)
+		_, err := p.responsePolicyRulesClient.Create(p.project, p.responsePolicy, rule).Do()
+		return err
+	case len(localDatas) == 0:
+		log.Infof("Delete response policy rule %s for %s", current.RuleName, dnsName)
+		return p.responsePolicyRulesClient.Delete(p.project, p.responsePolicy, current.RuleName).Do()
+	default:
+		rule := &dns.ResponsePolicyRule{
+			RuleName:  current.RuleName,
+			DnsName:   current.DnsName,
+			LocalData: &dns.ResponsePolicyRuleLocalData{LocalDatas: localDatas},
+		}
+		log.Infof("Update response policy rule %s for %s", rule.RuleName, dnsName)
+		_, err := p.responsePolicyRulesClient.Update(p.project, p.responsePolicy, current.RuleName, rule).Do()
+		return err
+	}
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package google
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dns "google.golang.org/api/dns/v1"
+	"google.golang.org/api/googleapi"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const testResponsePolicy = "cluster-policy"
+
+// mockResponsePolicyRulesClient holds the rules of the response policies, by policy and rule name.
+type mockResponsePolicyRulesClient struct {
+	rules   map[string]map[string]*dns.ResponsePolicyRule
+	actions []string
+	listErr error
+}
+
+func newMockResponsePolicyRulesClient() *mockResponsePolicyRulesClient {
+	return &mockResponsePolicyRulesClient{
+		rules: map[string]map[string]*dns.ResponsePolicyRule{testResponsePolicy: {}},
+	}
+}
+
+type mockResponsePolicyRulesListCall struct {
+	rules []*dns.ResponsePolicyRule
+	err   error
+}
+
+func (m *mockResponsePolicyRulesListCall) Pages(ctx context.Context, f func(*dns.ResponsePolicyRulesListResponse) error) error {
+	if m.err != nil {
+		return m.err
+	}
+	return f(&dns.ResponsePolicyRulesListResponse{ResponsePolicyRules: m.rules})
+}
+
+type mockResponsePolicyRulesCall struct {
+	do func() error
+}
+
+func (m *mockResponsePolicyRulesCall) Do(opts ...googleapi.CallOption) error {
+	return m.do()
+}
+
+type mockResponsePolicyRulesCreateCall struct {
+	mockResponsePolicyRulesCall
+	rule *dns.ResponsePolicyRule
+}
+
+func (m *mockResponsePolicyRulesCreateCall) Do(opts ...googleapi.CallOption) (*dns.ResponsePolicyRule, error) {
+	return m.rule, m.do()
+}
+
+type mockResponsePolicyRulesUpdateCall struct {
+	mockResponsePolicyRulesCall
+	rule *dns.ResponsePolicyRule
+}
+
+func (m *mockResponsePolicyRulesUpdateCall) Do(opts ...googleapi.CallOption) (*dns.ResponsePolicyRulesUpdateResponse, error) {
+	return &dns.ResponsePolicyRulesUpdateResponse{ResponsePolicyRule: m.rule}, m.do()
+}
+
+func (m *mockResponsePolicyRulesClient) List(project string, responsePolicy string) responsePolicyRulesListCallInterface {
+	call := &mockResponsePolicyRulesListCall{err: m.listErr}
+	policy, ok := m.rules[responsePolicy]
+	if !ok {
+		call.err = &googleapi.Error{Code: http.StatusNotFound}
+	}
+	for _, rule := range policy {
+		call.rules = append(call.rules, rule)
+	}
+	return call
+}
+
+func (m *mockResponsePolicyRulesClient) Create(project string, responsePolicy string, rule *dns.ResponsePolicyRule) responsePolicyRulesCreateCallInterface {
+	return &mockResponsePolicyRulesCreateCall{rule: rule, mockResponsePolicyRulesCall: mockResponsePolicyRulesCall{do: func() error {
+		if _, ok := m.rules[responsePolicy][rule.RuleName]; ok {
+			return &googleapi.Error{Code: http.StatusConflict}
+		}
+		m.rules[responsePolicy][rule.RuleName] = rule
+		m.actions = append(m.actions, "Create "+rule.RuleName)
+		return nil
+	}}}
+}
+
+func (m *mockResponsePolicyRulesClient) Update(project string, responsePolicy string, ruleName string, rule *dns.ResponsePolicyRule) responsePolicyRulesUpdateCallInterface {
+	return &mockResponsePolicyRulesUpdateCall{rule: rule, mockResponsePolicyRulesCall: mockResponsePolicyRulesCall{do: func() error {
+		if _, ok := m.rules[responsePolicy][ruleName]; !ok {
+			return &googleapi.Error{Code: http.StatusNotFound}
+		}
+		m.rules[responsePolicy][ruleName] = rule
+		m.actions = append(m.actions, "Update "+ruleName)
+		return nil
+	}}}
+}
+
+func (m *mockResponsePolicyRulesClient) Delete(project string, responsePolicy string, ruleName string) responsePolicyRulesDeleteCallInterface {
+	return &mockResponsePolicyRulesCall{do: func() error {
+		if _, ok := m.rules[responsePolicy][ruleName]; !ok {
+			return &googleapi.Error{Code: http.StatusNotFound}
+		}
+		delete(m.rules[responsePolicy], ruleName)
+		m.actions = append(m.actions, "Delete "+ruleName)
+		return nil
+	}}
+}
+
+func newGoogleResponsePolicyProvider(dryRun bool) (*GoogleProvider, *mockResponsePolicyRulesClient) {
+	client := newMockResponsePolicyRulesClient()
+	return &GoogleProvider{
+		project:                   "zalando-external-dns-test",
+		dryRun:                    dryRun,
+		domainFilter:              endpoint.NewDomainFilter([]string{"cluster.local."}),
+		responsePolicy:            testResponsePolicy,
+		responsePolicyRulesClient: client,
+		// the managed zones must not be used
+		managedZonesClient: &mockManagedZonesClient{zonesErr: errors.New("zones should not be listed")},
+		changesClient:      &mockChangesClient{},
+	}, client
+}
+
+func TestGoogleResponsePolicyApplyChanges(t *testing.T) {
+	p, client := newGoogleResponsePolicyProvider(false)
+	ctx := context.Background()
+
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("api.cluster.local", endpoint.RecordTypeA, "10.0.0.1", "10.0.0.2"),
+			endpoint.NewEndpoint("api.cluster.local", endpoint.RecordTypeTXT, "heritage=external-dns"),
+			endpoint.NewEndpointWithTTL("*.apps.cluster.local", endpoint.RecordTypeCNAME, 60, "ingress.cluster.local"),
+			// filtered out by the domain filter
+			endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeA, "10.0.0.1"),
+		},
+	}))
+	assert.Equal(t, []string{
+		"Create external-dns-wildcard-apps-cluster-local",
+		"Create external-dns-api-cluster-local",
+	}, client.actions)
+
+	rule := client.rules[testResponsePolicy]["external-dns-api-cluster-local"]
+	require.NotNil(t, rule)
+	assert.Equal(t, "api.cluster.local.", rule.DnsName)
+	assert.Equal(t, []*dns.ResourceRecordSet{
+		{Name: "api.cluster.local.", Type: endpoint.RecordTypeA, Ttl: defaultTTL, Rrdatas: []string{"10.0.0.1", "10.0.0.2"}},
+		{Name: "api.cluster.local.", Type: endpoint.RecordTypeTXT, Ttl: defaultTTL, Rrdatas: []string{"heritage=external-dns"}},
+	}, rule.LocalData.LocalDatas)
+
+	records, err := p.Records(ctx)
+	require.NoError(t, err)
+	validateEndpoints(t, records, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("api.cluster.local", endpoint.RecordTypeA, defaultTTL, "10.0.0.1", "10.0.0.2"),
+		endpoint.NewEndpointWithTTL("api.cluster.local", endpoint.RecordTypeTXT, defaultTTL, "heritage=external-dns"),
+		endpoint.NewEndpointWithTTL("*.apps.cluster.local", endpoint.RecordTypeCNAME, 60, "ingress.cluster.local."),
+	})
+
+	// an update keeps the other record sets of the rule
+	client.actions = nil
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("api.cluster.local", endpoint.RecordTypeA, "10.0.0.1", "10.0.0.2")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("api.cluster.local", endpoint.RecordTypeA, "10.0.0.3")},
+	}))
+	assert.Equal(t, []string{"Update external-dns-api-cluster-local"}, client.actions)
+	rule = client.rules[testResponsePolicy]["external-dns-api-cluster-local"]
+	require.Len(t, rule.LocalData.LocalDatas, 2)
+	assert.Equal(t, []string{"10.0.0.3"}, rule.LocalData.LocalDatas[0].Rrdatas)
+
+	// the rule is deleted with its last record set
+	client.actions = nil
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("api.cluster.local", endpoint.RecordTypeTXT, "heritage=external-dns")},
+	}))
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("api.cluster.local", endpoint.RecordTypeA, "10.0.0.3"),
+			endpoint.NewEndpointWithTTL("*.apps.cluster.local", endpoint.RecordTypeCNAME, 60, "ingress.cluster.local"),
+		},
+	}))
+	assert.Equal(t, []string{
+		"Update external-dns-api-cluster-local",
+		"Delete external-dns-wildcard-apps-cluster-local",
+		"Delete external-dns-api-cluster-local",
+	}, client.actions)
+	assert.Empty(t, client.rules[testResponsePolicy])
+}
+
+func TestGoogleResponsePolicyExistingRules(t *testing.T) {
+	p, client := newGoogleResponsePolicyProvider(false)
+	client.rules[testResponsePolicy]["manual-rule"] = &dns.ResponsePolicyRule{
+		RuleName: "manual-rule",
+		DnsName:  "db.cluster.local.",
+		LocalData: &dns.ResponsePolicyRuleLocalData{LocalDatas: []*dns.ResourceRecordSet{
+			{Name: "db.cluster.local.", Type: endpoint.RecordTypeA, Ttl: 30, Rrdatas: []string{"10.0.1.1"}},
+		}},
+	}
+	client.rules[testResponsePolicy]["bypass"] = &dns.ResponsePolicyRule{
+		RuleName: "bypass",
+		DnsName:  "public.cluster.local.",
+		Behavior: "bypassResponsePolicy",
+	}
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	validateEndpoints(t, records, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("db.cluster.local", endpoint.RecordTypeA, 30, "10.0.1.1"),
+	})
+
+	// the records are added to the rule of their name, whatever its name, the rules with a behavior are left untouched
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("db.cluster.local", endpoint.RecordTypeTXT, "heritage=external-dns"),
+			endpoint.NewEndpoint("public.cluster.local", endpoint.RecordTypeA, "10.0.1.2"),
+		},
+	}))
+	assert.Equal(t, []string{"Update manual-rule"}, client.actions)
+	assert.Len(t, client.rules[testResponsePolicy]["manual-rule"].LocalData.LocalDatas, 2)
+	assert.Nil(t, client.rules[testResponsePolicy]["bypass"].LocalData)
+}
+
+func TestGoogleResponsePolicyDryRun(t *testing.T) {
+	p, client := newGoogleResponsePolicyProvider(true)
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("api.cluster.local", endpoint.RecordTypeA, "10.0.0.1")},
+	}))
+	assert.Empty(t, client.actions)
+	assert.Empty(t, client.rules[testResponsePolicy])
+}
+
+func TestGoogleResponsePolicyErrors(t *testing.T) {
+	p, client := newGoogleResponsePolicyProvider(false)
+	p.responsePolicy = "missing-policy"
+
+	_, err := p.Records(context.Background())
+	require.ErrorIs(t, err, provider.SoftError)
+	assert.ErrorContains(t, err, "failed to list rules of response policy missing-policy")
+
+	p.responsePolicy = testResponsePolicy
+	client.rules[testResponsePolicy]["external-dns-api-cluster-local"] = &dns.ResponsePolicyRule{
+		RuleName: "external-dns-api-cluster-local",
+		DnsName:  "other.cluster.local.",
+	}
+	err = p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("api.cluster.local", endpoint.RecordTypeA, "10.0.0.1")},
+	})
+	require.ErrorIs(t, err, provider.SoftError)
+	assert.ErrorContains(t, err, "failed to change rule of api.cluster.local. in response policy cluster-policy")
+}
+
+func TestResponsePolicyRuleName(t *testing.T) {
+	assert.Equal(t, "external-dns-api-cluster-local", responsePolicyRuleName("API.cluster.local."))
+	assert.Equal(t, "external-dns-wildcard-apps-cluster-local", responsePolicyRuleName("*.apps.cluster.local"))
+	assert.Equal(t, "external-dns--tcp-srv-cluster-local", responsePolicyRuleName("_tcp.srv.cluster.local."))
+
+	long := responsePolicyRuleName(strings.Repeat("a", 60) + ".cluster.local.")
+	assert.Regexp(t, "^external-dns-[0-9a-f]{32}$", long)
+	assert.LessOrEqual(t, len(long), maxResponsePolicyRuleNameLength)
+}
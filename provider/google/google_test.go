@@ -80,6 +80,24 @@ func (m *mockManagedZonesListCall) Pages(ctx context.Context, f func(*dns.Manage
 	return f(&dns.ManagedZonesListResponse{ManagedZones: zones})
 }
 
+type mockManagedZonesPatchCall struct {
+	project     string
+	managedZone string
+	patch       *dns.ManagedZone
+}
+
+func (m *mockManagedZonesPatchCall) Do(opts ...googleapi.CallOption) (*dns.Operation, error) {
+	zone, ok := testZones[zoneKey(m.project, m.managedZone)]
+	if !ok {
+		return nil, &googleapi.Error{Code: http.StatusNotFound}
+	}
+
+	zone.ForwardingConfig = m.patch.ForwardingConfig
+	zone.PrivateVisibilityConfig = m.patch.PrivateVisibilityConfig
+
+	return &dns.Operation{}, nil
+}
+
 type mockManagedZonesClient struct {
 	zonesErr error
 }
@@ -92,6 +110,10 @@ func (m *mockManagedZonesClient) List(project string) managedZonesListCallInterf
 	return &mockManagedZonesListCall{project: project, zonesListSoftErr: m.zonesErr}
 }
 
+func (m *mockManagedZonesClient) Patch(project string, managedZone string, zone *dns.ManagedZone) managedZonesPatchCallInterface {
+	return &mockManagedZonesPatchCall{project: project, managedZone: managedZone, patch: zone}
+}
+
 type mockResourceRecordSetsListCall struct {
 	project            string
 	managedZone        string
@@ -252,6 +274,48 @@ func TestGoogleZonesVisibilityFilterPrivate(t *testing.T) {
 	})
 }
 
+func TestGoogleZonesVisibilityFilterMixed(t *testing.T) {
+	for _, tc := range []struct {
+		visibility string
+		expected   map[string]*dns.ManagedZone
+	}{
+		{
+			visibility: "public",
+			expected: map[string]*dns.ManagedZone{
+				"split-horizon-1": {Name: "split-horizon-1", DnsName: "cluster.local.", Visibility: "public"},
+			},
+		},
+		{
+			visibility: "private",
+			expected: map[string]*dns.ManagedZone{
+				"internal-1": {Name: "internal-1", DnsName: "cluster.local.", Visibility: "private"},
+				"internal-2": {Name: "internal-2", DnsName: "cluster.local.", Visibility: "private"},
+				"internal-3": {Name: "internal-3", DnsName: "cluster.local.", Visibility: "private"},
+				"svc-local":  {Name: "svc-local", DnsName: "svc.local.", Visibility: "private"},
+			},
+		},
+		{
+			visibility: "",
+			expected: map[string]*dns.ManagedZone{
+				"internal-1":      {Name: "internal-1", DnsName: "cluster.local.", Visibility: "private"},
+				"internal-2":      {Name: "internal-2", DnsName: "cluster.local.", Visibility: "private"},
+				"internal-3":      {Name: "internal-3", DnsName: "cluster.local.", Visibility: "private"},
+				"split-horizon-1": {Name: "split-horizon-1", DnsName: "cluster.local.", Visibility: "public"},
+				"svc-local":       {Name: "svc-local", DnsName: "svc.local.", Visibility: "private"},
+			},
+		},
+	} {
+		t.Run(tc.visibility, func(t *testing.T) {
+			provider := newGoogleProviderZoneOverlap(t, endpoint.NewDomainFilter([]string{"cluster.local.", "svc.local."}), provider.NewZoneIDFilter([]string{""}), provider.NewZoneTypeFilter(tc.visibility), false, []*endpoint.Endpoint{})
+
+			zones, err := provider.Zones(context.Background())
+			require.NoError(t, err)
+
+			validateZones(t, zones, tc.expected)
+		})
+	}
+}
+
 func TestGoogleZonesVisibilityFilterPrivatePeering(t *testing.T) {
 	provider := newGoogleProviderZoneOverlap(t, endpoint.NewDomainFilter([]string{"svc.local."}), provider.NewZoneIDFilter([]string{""}), provider.NewZoneTypeFilter("private"), false, []*endpoint.Endpoint{})
 
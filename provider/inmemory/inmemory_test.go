@@ -36,6 +36,7 @@ func TestInMemoryProvider(t *testing.T) {
 	t.Run("ApplyChanges", testInMemoryApplyChanges)
 	t.Run("NewInMemoryProvider", testNewInMemoryProvider)
 	t.Run("CreateZone", testInMemoryCreateZone)
+	t.Run("NestedZones", testInMemoryNestedZones)
 }
 
 func testInMemoryRecords(t *testing.T) {
@@ -573,6 +574,25 @@ func testInMemoryCreateZone(t *testing.T) {
 	require.EqualError(t, err, ErrZoneAlreadyExists.Error())
 }
 
+func testInMemoryNestedZones(t *testing.T) {
+	im := NewInMemoryProvider(InMemoryInitZones([]string{"example.com", "sub.example.com"}))
+
+	p := &plan.Plan{
+		Policies:       []plan.Policy{&plan.SyncPolicy{}},
+		Desired:        []*endpoint.Endpoint{endpoint.NewEndpoint("www.sub.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		ManagedRecords: []string{endpoint.RecordTypeA},
+		Zones:          []string{"example.com", "sub.example.com"},
+	}
+	require.NoError(t, im.ApplyChanges(context.Background(), p.Calculate().Changes))
+
+	parent, err := im.client.Records("example.com")
+	require.NoError(t, err)
+	assert.Empty(t, parent)
+	child, err := im.client.Records("sub.example.com")
+	require.NoError(t, err)
+	assert.True(t, testutils.SameEndpoints(child, p.Desired))
+}
+
 func makeZone(s ...string) map[endpoint.EndpointKey]*endpoint.Endpoint {
 	if len(s)%3 != 0 {
 		panic("makeZone arguments must be multiple of 3")
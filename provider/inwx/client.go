@@ -0,0 +1,310 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inwx
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+const (
+	// productionEndpoint is the domrobot XML-RPC endpoint.
+	productionEndpoint = "https://api.domrobot.com/xmlrpc/"
+	// sandboxEndpoint is the domrobot XML-RPC endpoint of the INWX test environment (OTE).
+	sandboxEndpoint = "https://api.ote.domrobot.com/xmlrpc/"
+
+	// the domrobot result codes used by the client
+	codeSuccess          = 1000
+	codeSuccessPending   = 1001
+	codeAuthenticationKO = 2200
+
+	// pageLimit is the page size of the domain listing.
+	pageLimit = 1000
+)
+
+// Record is a DNS record of a domain. The records are flat within a domain, a record holds a single value, and
+// their names are fully qualified.
+type Record struct {
+	ID      int
+	Name    string
+	Type    string
+	Content string
+	TTL     int
+	Prio    int
+}
+
+// RecordRequest holds the fields of the records written by the provider.
+type RecordRequest struct {
+	Name    string
+	Type    string
+	Content string
+	TTL     int
+	Prio    int
+}
+
+// APIError is an error result of a domrobot method.
+type APIError struct {
+	Method  string
+	Code    int
+	Message string
+	Reason  string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("inwx: %s failed with code %d: %s", e.Method, e.Code, e.Message)
+	if e.Reason != "" {
+		msg += " (" + e.Reason + ")"
+	}
+	return msg
+}
+
+// DNSClient is the interface of the domrobot API used by the provider.
+type DNSClient interface {
+	ListDomains(ctx context.Context) ([]string, error)
+	ListRecords(ctx context.Context, domain string) ([]Record, error)
+	CreateRecord(ctx context.Context, domain string, record RecordRequest) error
+	UpdateRecord(ctx context.Context, recordID int, record RecordRequest) error
+	DeleteRecord(ctx context.Context, recordID int) error
+}
+
+// Client calls the domrobot API within a session, logged in with the account credentials. The session is kept in a
+// cookie and renewed when it expires.
+type Client struct {
+	username   string
+	password   string
+	otpSecret  string
+	endpoint   string
+	httpClient *http.Client
+	loggedIn   bool
+	now        func() time.Time
+}
+
+// NewClient returns a client of the domrobot API. The OTP secret is the shared secret of the two factor
+// authentication of the account, if enabled, which the one-time PINs are generated from.
+func NewClient(username, password, otpSecret string, sandbox bool, httpClient *http.Client) *Client {
+	if httpClient.Jar == nil {
+		// cookiejar.New never fails without options
+		httpClient.Jar, _ = cookiejar.New(nil)
+	}
+	endpoint := productionEndpoint
+	if sandbox {
+		endpoint = sandboxEndpoint
+	}
+	return &Client{
+		username:   username,
+		password:   password,
+		otpSecret:  otpSecret,
+		endpoint:   endpoint,
+		httpClient: httpClient,
+		now:        time.Now,
+	}
+}
+
+func (c *Client) ListDomains(ctx context.Context) ([]string, error) {
+	var domains []string
+	for page := 1; ; page++ {
+		resData, err := c.call(ctx, "nameserver.list", map[string]any{"pagelimit": pageLimit, "page": page})
+		if err != nil {
+			return nil, err
+		}
+		items, _ := resData["domains"].([]any)
+		for _, item := range items {
+			domain, _ := item.(map[string]any)
+			if name := asString(domain["domain"]); name != "" {
+				domains = append(domains, name)
+			}
+		}
+		if len(items) < pageLimit || len(domains) >= asInt(resData["count"]) {
+			return domains, nil
+		}
+	}
+}
+
+func (c *Client) ListRecords(ctx context.Context, domain string) ([]Record, error) {
+	resData, err := c.call(ctx, "nameserver.info", map[string]any{"domain": domain})
+	if err != nil {
+		return nil, err
+	}
+	items, _ := resData["record"].([]any)
+	records := make([]Record, 0, len(items))
+	for _, item := range items {
+		record, _ := item.(map[string]any)
+		records = append(records, Record{
+			ID:      asInt(record["id"]),
+			Name:    asString(record["name"]),
+			Type:    asString(record["type"]),
+			Content: asString(record["content"]),
+			TTL:     asInt(record["ttl"]),
+			Prio:    asInt(record["prio"]),
+		})
+	}
+	return records, nil
+}
+
+func (c *Client) CreateRecord(ctx context.Context, domain string, record RecordRequest) error {
+	_, err := c.call(ctx, "nameserver.createRecord", map[string]any{
+		"domain":  domain,
+		"name":    record.Name,
+		"type":    record.Type,
+		"content": record.Content,
+		"ttl":     record.TTL,
+		"prio":    record.Prio,
+	})
+	return err
+}
+
+func (c *Client) UpdateRecord(ctx context.Context, recordID int, record RecordRequest) error {
+	_, err := c.call(ctx, "nameserver.updateRecord", map[string]any{
+		"id":      recordID,
+		"content": record.Content,
+		"ttl":     record.TTL,
+		"prio":    record.Prio,
+	})
+	return err
+}
+
+func (c *Client) DeleteRecord(ctx context.Context, recordID int) error {
+	_, err := c.call(ctx, "nameserver.deleteRecord", map[string]any{"id": recordID})
+	return err
+}
+
+// login opens a session, unlocking it with a one-time PIN when the account has two factor authentication enabled.
+func (c *Client) login(ctx context.Context) error {
+	resData, err := c.do(ctx, "account.login", map[string]any{"user": c.username, "pass": c.password})
+	if err != nil {
+		return err
+	}
+	if tfa := asString(resData["tfa"]); tfa != "" && tfa != "0" {
+		if c.otpSecret == "" {
+			return fmt.Errorf("inwx: the account requires two factor authentication (%s), set the OTP secret", tfa)
+		}
+		tan, err := totp(c.otpSecret, c.now())
+		if err != nil {
+			return err
+		}
+		if _, err := c.do(ctx, "account.unlock", map[string]any{"tan": tan}); err != nil {
+			return err
+		}
+	}
+	c.loggedIn = true
+	return nil
+}
+
+// call calls a method within the session, logging in first and once more when the session has expired.
+func (c *Client) call(ctx context.Context, method string, params map[string]any) (map[string]any, error) {
+	if !c.loggedIn {
+		if err := c.login(ctx); err != nil {
+			return nil, err
+		}
+	}
+	resData, err := c.do(ctx, method, params)
+	if apiErr, ok := err.(*APIError); ok && apiErr.Code == codeAuthenticationKO {
+		c.loggedIn = false
+		if err := c.login(ctx); err != nil {
+			return nil, err
+		}
+		resData, err = c.do(ctx, method, params)
+	}
+	return resData, err
+}
+
+// do calls a method and returns the data of its result.
+func (c *Client) do(ctx context.Context, method string, params map[string]any) (map[string]any, error) {
+	body, err := encodeMethodCall(method, params)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "text/xml")
+	req.Header.Set("User-Agent", externaldns.UserAgent())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("inwx: %s failed with HTTP status %s", method, resp.Status)
+	}
+
+	value, err := decodeMethodResponse(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("inwx: %s: %w", method, err)
+	}
+	result, _ := value.(map[string]any)
+	if code := asInt(result["code"]); code != codeSuccess && code != codeSuccessPending {
+		return nil, &APIError{Method: method, Code: code, Message: asString(result["msg"]), Reason: asString(result["reason"])}
+	}
+	resData, _ := result["resData"].(map[string]any)
+	return resData, nil
+}
+
+// totp returns the time-based one-time password (RFC 6238) of a base32 encoded shared secret, with the default
+// parameters of authenticator apps: HMAC-SHA1, 6 digits and 30 seconds steps.
+func totp(secret string, now time.Time) (string, error) {
+	secret = strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(secret, "="))
+	if err != nil {
+		return "", fmt.Errorf("inwx: invalid OTP secret: %w", err)
+	}
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(now.Unix()/30))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", code%1000000), nil
+}
+
+func asString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case int:
+		return fmt.Sprint(v)
+	}
+	return ""
+}
+
+func asInt(v any) int {
+	switch v := v.(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	case string:
+		var i int
+		_, _ = fmt.Sscan(v, &i)
+		return i
+	}
+	return 0
+}
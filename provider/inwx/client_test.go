@@ -0,0 +1,207 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inwx
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDomrobot is an XML-RPC server answering the domrobot methods with a handler, within a session cookie.
+type fakeDomrobot struct {
+	t        *testing.T
+	server   *httptest.Server
+	handler  func(method string, params map[string]any) map[string]any
+	sessions map[string]bool
+	calls    []string
+}
+
+func newFakeDomrobot(t *testing.T, handler func(method string, params map[string]any) map[string]any) *fakeDomrobot {
+	f := &fakeDomrobot{t: t, handler: handler, sessions: map[string]bool{}}
+	f.server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeDomrobot) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	var call struct {
+		MethodName string     `xml:"methodName"`
+		Params     []xmlValue `xml:"params>param>value"`
+	}
+	require.NoError(f.t, xml.NewDecoder(r.Body).Decode(&call))
+	require.Len(f.t, call.Params, 1)
+	decoded, err := call.Params[0].decode()
+	require.NoError(f.t, err)
+	params, _ := decoded.(map[string]any)
+	f.calls = append(f.calls, call.MethodName)
+
+	var result map[string]any
+	cookie, _ := r.Cookie("domrobot")
+	switch {
+	case call.MethodName == "account.login":
+		result = f.handler(call.MethodName, params)
+		if asInt(result["code"]) == codeSuccess {
+			session := "session" + string(rune('0'+len(f.sessions)))
+			f.sessions[session] = true
+			http.SetCookie(w, &http.Cookie{Name: "domrobot", Value: session})
+		}
+	case cookie == nil || !f.sessions[cookie.Value]:
+		result = map[string]any{"code": codeAuthenticationKO, "msg": "Authentication error"}
+	default:
+		result = f.handler(call.MethodName, params)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header + "<methodResponse><params><param>")
+	require.NoError(f.t, encodeValue(&buf, result))
+	buf.WriteString("</param></params></methodResponse>")
+	w.Header().Set("Content-Type", "text/xml")
+	_, _ = w.Write(buf.Bytes())
+}
+
+func (f *fakeDomrobot) client(otpSecret string) *Client {
+	client := NewClient("user", "pass", otpSecret, false, &http.Client{})
+	client.endpoint = f.server.URL
+	return client
+}
+
+func TestEncodeMethodCall(t *testing.T) {
+	body, err := encodeMethodCall("nameserver.createRecord", map[string]any{
+		"name":   "a&b",
+		"ttl":    300,
+		"active": true,
+		"tags":   []any{"x", 1.5},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, xml.Header+`<methodCall><methodName>nameserver.createRecord</methodName><params><param><value><struct>`+
+		`<member><name>active</name><value><boolean>1</boolean></value></member>`+
+		`<member><name>name</name><value><string>a&amp;b</string></value></member>`+
+		`<member><name>tags</name><value><array><data><value><string>x</string></value><value><double>1.5</double></value></data></array></value></member>`+
+		`<member><name>ttl</name><value><int>300</int></value></member>`+
+		`</struct></value></param></params></methodCall>`, string(body))
+
+	_, err = encodeMethodCall("method", map[string]any{"invalid": struct{}{}})
+	assert.EqualError(t, err, "unsupported XML-RPC value type struct {}")
+}
+
+func TestDecodeMethodResponse(t *testing.T) {
+	value, err := decodeMethodResponse(bytes.NewBufferString(`<?xml version="1.0"?>
+<methodResponse><params><param><value><struct>
+  <member><name>code</name><value><int>1000</int></value></member>
+  <member><name>msg</name><value>Command completed successfully</value></member>
+  <member><name>resData</name><value><struct>
+    <member><name>record</name><value><array><data>
+      <value><struct><member><name>id</name><value><i4>7</i4></value></member><member><name>active</name><value><boolean>1</boolean></value></member></struct></value>
+    </data></array></value></member>
+  </struct></value></member>
+</struct></value></param></params></methodResponse>`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"code": 1000,
+		"msg":  "Command completed successfully",
+		"resData": map[string]any{
+			"record": []any{map[string]any{"id": 7, "active": true}},
+		},
+	}, value)
+
+	_, err = decodeMethodResponse(bytes.NewBufferString(`<methodResponse><fault><value><struct>
+  <member><name>faultCode</name><value><int>4</int></value></member>
+  <member><name>faultString</name><value><string>Too many parameters.</string></value></member>
+</struct></value></fault></methodResponse>`))
+	assert.EqualError(t, err, "XML-RPC fault 4: Too many parameters.")
+}
+
+func TestTOTP(t *testing.T) {
+	// RFC 6238 test vector of the SHA1 secret "12345678901234567890", truncated to 6 digits
+	code, err := totp("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", time.Unix(59, 0))
+	require.NoError(t, err)
+	assert.Equal(t, "287082", code)
+
+	code, err = totp("gezd gnbv gy3t qojq gezd gnbv gy3t qojq", time.Unix(1111111109, 0))
+	require.NoError(t, err)
+	assert.Equal(t, "081804", code)
+
+	_, err = totp("not base32!", time.Unix(59, 0))
+	assert.Error(t, err)
+}
+
+func TestClientLoginTwoFactor(t *testing.T) {
+	var tan string
+	domrobot := newFakeDomrobot(t, func(method string, params map[string]any) map[string]any {
+		switch method {
+		case "account.login":
+			assert.Equal(t, map[string]any{"user": "user", "pass": "pass"}, params)
+			return map[string]any{"code": codeSuccess, "resData": map[string]any{"tfa": "GOOGLE-AUTH"}}
+		case "account.unlock":
+			tan = asString(params["tan"])
+			return map[string]any{"code": codeSuccess}
+		}
+		return map[string]any{"code": codeSuccess, "resData": map[string]any{"count": 1, "domains": []any{map[string]any{"domain": "example.com"}}}}
+	})
+
+	client := domrobot.client("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ")
+	client.now = func() time.Time { return time.Unix(59, 0) }
+	domains, err := client.ListDomains(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example.com"}, domains)
+	assert.Equal(t, "287082", tan)
+	assert.Equal(t, []string{"account.login", "account.unlock", "nameserver.list"}, domrobot.calls)
+
+	_, err = domrobot.client("").ListDomains(context.Background())
+	assert.EqualError(t, err, "inwx: the account requires two factor authentication (GOOGLE-AUTH), set the OTP secret")
+}
+
+func TestClientSessionRenewal(t *testing.T) {
+	domrobot := newFakeDomrobot(t, func(method string, params map[string]any) map[string]any {
+		if method == "account.login" {
+			return map[string]any{"code": codeSuccess, "resData": map[string]any{"tfa": "0"}}
+		}
+		return map[string]any{"code": codeSuccess, "resData": map[string]any{"record": []any{}}}
+	})
+	client := domrobot.client("")
+
+	_, err := client.ListRecords(context.Background(), "example.com")
+	require.NoError(t, err)
+	// the session expires
+	domrobot.sessions = map[string]bool{}
+	_, err = client.ListRecords(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"account.login", "nameserver.info", "nameserver.info", "account.login", "nameserver.info"}, domrobot.calls)
+}
+
+func TestClientAPIError(t *testing.T) {
+	domrobot := newFakeDomrobot(t, func(method string, params map[string]any) map[string]any {
+		if method == "account.login" {
+			return map[string]any{"code": 2200, "msg": "Authentication error", "reason": "Invalid credentials"}
+		}
+		return map[string]any{"code": codeSuccess}
+	})
+
+	_, err := domrobot.client("").ListDomains(context.Background())
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, 2200, apiErr.Code)
+	assert.EqualError(t, err, "inwx: account.login failed with code 2200: Authentication error (Invalid credentials)")
+}
@@ -0,0 +1,317 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inwx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// defaultTTL is the TTL of the records without a configured TTL, INWX doesn't accept TTLs below 300 seconds.
+const defaultTTL = 3600
+
+// InwxConfig holds the configuration of the INWX provider.
+type InwxConfig struct {
+	Username     string
+	Password     string
+	OTPSecret    string
+	Sandbox      bool
+	DomainFilter endpoint.DomainFilter
+	DryRun       bool
+}
+
+// InwxProvider is an implementation of Provider for INWX's DNS.
+type InwxProvider struct {
+	provider.BaseProvider
+	Client DNSClient
+	// only consider hosted zones managing domains ending in this suffix
+	domainFilter endpoint.DomainFilter
+	DryRun       bool
+}
+
+type inwxChangeCreate struct {
+	Domain  string
+	Options RecordRequest
+}
+
+type inwxChangeUpdate struct {
+	Domain  string
+	Record  Record
+	Options RecordRequest
+}
+
+type inwxChangeDelete struct {
+	Domain string
+	Record Record
+}
+
+// inwxChanges contains all changes to apply to DNS
+type inwxChanges struct {
+	Creates []inwxChangeCreate
+	Updates []inwxChangeUpdate
+	Deletes []inwxChangeDelete
+}
+
+// NewInwxProvider initializes a new INWX DNS based Provider.
+func NewInwxProvider(config InwxConfig) (*InwxProvider, error) {
+	if config.Username == "" || config.Password == "" {
+		return nil, fmt.Errorf("no INWX credentials found, set the username and the password")
+	}
+
+	return &InwxProvider{
+		Client:       NewClient(config.Username, config.Password, config.OTPSecret, config.Sandbox, &http.Client{Timeout: 30 * time.Second}),
+		domainFilter: config.DomainFilter,
+		DryRun:       config.DryRun,
+	}, nil
+}
+
+// Zones returns the list of hosted zones.
+func (p *InwxProvider) Zones(ctx context.Context) ([]string, error) {
+	domains, err := p.Client.ListDomains(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list domains: %w", err)
+	}
+
+	var zones []string
+	for _, domain := range domains {
+		if p.domainFilter.Match(domain) {
+			zones = append(zones, domain)
+		}
+	}
+	return zones, nil
+}
+
+// SupportedRecordType returns true for the record types of the INWX domrobot external-dns manages, the MX records
+// included and the NS records excluded.
+func (p *InwxProvider) SupportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeMX:
+		return true
+	case endpoint.RecordTypeNS:
+		// the NS records of the zones are managed by INWX
+		return false
+	default:
+		return provider.SupportedRecordType(recordType)
+	}
+}
+
+// Records returns the list of records in all the domains. INWX stores a record per target, the records of a name and
+// type are merged into a single endpoint.
+func (p *InwxProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, zone := range zones {
+		records, err := p.Client.ListRecords(ctx, zone)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list records of domain %s: %w", zone, err)
+		}
+
+		for _, r := range records {
+			if !p.SupportedRecordType(r.Type) {
+				continue
+			}
+			endpoints = append(endpoints, endpoint.NewEndpointWithTTL(r.Name, r.Type, endpoint.TTL(r.TTL), recordTarget(r)))
+		}
+	}
+	endpoints = provider.MergeEndpointsByNameType(endpoints)
+
+	log.WithFields(log.Fields{
+		"endpoints": endpoints,
+	}).Debug("Endpoints generated from INWX DNS")
+
+	return endpoints, nil
+}
+
+// ApplyChanges applies the given changes, querying the records of a domain once for all its changes.
+func (p *InwxProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return err
+	}
+
+	zoneNameIDMapper := provider.ZoneIDName{}
+	for _, zone := range zones {
+		zoneNameIDMapper.Add(zone, zone)
+	}
+
+	recordsByZone := map[string][]Record{}
+	records := func(zone string) ([]Record, error) {
+		if r, ok := recordsByZone[zone]; ok {
+			return r, nil
+		}
+		r, err := p.Client.ListRecords(ctx, zone)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list records of domain %s: %w", zone, err)
+		}
+		recordsByZone[zone] = r
+		return r, nil
+	}
+
+	var inwxChanges inwxChanges
+	err = provider.ForEachEndpointTargets(changes, func(ep *endpoint.Endpoint, targets []string, prune bool) error {
+		zone, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zone == "" {
+			log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", ep.DNSName)
+			return nil
+		}
+		existing, err := records(zone)
+		if err != nil {
+			return err
+		}
+		return inwxChanges.add(zone, existing, ep, targets, prune)
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.submitChanges(ctx, inwxChanges)
+}
+
+// add adds the changes turning the domain's records of the endpoint's name and type into a record per target. INWX
+// names the records by their full name, the record IDs being global to the account.
+func (c *inwxChanges) add(zone string, existing []Record, ep *endpoint.Endpoint, targets []string, prune bool) error {
+	current := slices.DeleteFunc(slices.Clone(existing), func(r Record) bool {
+		return r.Name != ep.DNSName || r.Type != ep.RecordType
+	})
+	diff := provider.DiffRecords(current, targets, prune, recordTarget, func(r Record) bool {
+		return ep.RecordTTL.IsConfigured() && r.TTL != int(ep.RecordTTL)
+	})
+
+	for _, target := range diff.Create {
+		options, err := newRecordRequest(ep.DNSName, ep.RecordType, target, ep.RecordTTL)
+		if err != nil {
+			return fmt.Errorf("invalid target %q of %s record %s: %w", target, ep.RecordType, ep.DNSName, err)
+		}
+		c.Creates = append(c.Creates, inwxChangeCreate{Domain: zone, Options: options})
+	}
+	for _, update := range diff.Update {
+		options, err := newRecordRequest(ep.DNSName, ep.RecordType, update.Target, ep.RecordTTL)
+		if err != nil {
+			return fmt.Errorf("invalid target %q of %s record %s: %w", update.Target, ep.RecordType, ep.DNSName, err)
+		}
+		c.Updates = append(c.Updates, inwxChangeUpdate{Domain: zone, Record: update.Record, Options: options})
+	}
+	for _, record := range diff.Delete {
+		c.Deletes = append(c.Deletes, inwxChangeDelete{Domain: zone, Record: record})
+	}
+	return nil
+}
+
+// submitChanges submits the changes to the domrobot one record at a time, the records being addressed by their ID
+// alone. The deletions come first, for a CNAME record to take the place of the other records of its name.
+func (p *InwxProvider) submitChanges(ctx context.Context, changes inwxChanges) error {
+	for _, change := range changes.Deletes {
+		logFields := log.Fields{
+			"record":   change.Record.Name,
+			"type":     change.Record.Type,
+			"target":   change.Record.Content,
+			"action":   "Delete",
+			"zoneName": change.Domain,
+		}
+		log.WithFields(logFields).Info("Deleting record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.DeleteRecord(ctx, change.Record.ID); err != nil {
+			return fmt.Errorf("failed to delete %s record %s: %w", change.Record.Type, change.Record.Name, err)
+		}
+	}
+
+	for _, change := range changes.Updates {
+		logFields := log.Fields{
+			"record":   change.Record.Name,
+			"type":     change.Record.Type,
+			"target":   change.Options.Content,
+			"ttl":      change.Options.TTL,
+			"action":   "Update",
+			"zoneName": change.Domain,
+		}
+		log.WithFields(logFields).Info("Updating record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.UpdateRecord(ctx, change.Record.ID, change.Options); err != nil {
+			return fmt.Errorf("failed to update %s record %s: %w", change.Record.Type, change.Record.Name, err)
+		}
+	}
+
+	for _, change := range changes.Creates {
+		logFields := log.Fields{
+			"record":   change.Options.Name,
+			"type":     change.Options.Type,
+			"target":   change.Options.Content,
+			"action":   "Create",
+			"zoneName": change.Domain,
+		}
+		log.WithFields(logFields).Info("Creating record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.CreateRecord(ctx, change.Domain, change.Options); err != nil {
+			return fmt.Errorf("failed to create %s record %s: %w", change.Options.Type, change.Options.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// recordTarget returns the endpoint target of a record, the priority of MX and SRV records is stored apart from
+// their content.
+func recordTarget(r Record) string {
+	switch r.Type {
+	case endpoint.RecordTypeMX, endpoint.RecordTypeSRV:
+		return fmt.Sprintf("%d %s", r.Prio, r.Content)
+	}
+	return r.Content
+}
+
+// newRecordRequest returns the request creating the record of an endpoint target. MX targets are of the form
+// "10 mail.example.com" and SRV targets of the form "10 5 443 target.example.com", the priority coming first.
+func newRecordRequest(name, recordType, target string, ttl endpoint.TTL) (RecordRequest, error) {
+	request := RecordRequest{Name: name, Type: recordType, Content: target, TTL: defaultTTL}
+	if ttl.IsConfigured() {
+		request.TTL = int(ttl)
+	}
+	if recordType == endpoint.RecordTypeMX || recordType == endpoint.RecordTypeSRV {
+		prioRaw, content, ok := strings.Cut(target, " ")
+		if !ok {
+			return RecordRequest{}, fmt.Errorf("the priority is missing")
+		}
+		prio, err := strconv.Atoi(prioRaw)
+		if err != nil {
+			return RecordRequest{}, fmt.Errorf("invalid priority %q", prioRaw)
+		}
+		request.Prio = prio
+		request.Content = content
+	}
+	return request, nil
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inwx
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// fakeNameservers holds the records of the domains served by a fake domrobot.
+type fakeNameservers struct {
+	records map[string][]Record
+	nextID  int
+	writes  int
+}
+
+// newFakeNameservers returns fake nameservers serving the test records.
+func newFakeNameservers() *fakeNameservers {
+	return &fakeNameservers{records: testInwxRecords(), nextID: 100}
+}
+
+func testInwxRecords() map[string][]Record {
+	return map[string][]Record{
+		"example.com": {
+			{ID: 1, Type: "SOA", Name: "example.com", Content: "ns.inwx.de hostmaster.inwx.de 2025010101 10800 3600 604800 3600", TTL: 86400},
+			{ID: 2, Type: "NS", Name: "example.com", Content: "ns.inwx.de", TTL: 86400},
+			{ID: 3, Type: "A", Name: "example.com", Content: "1.2.3.4", TTL: 3600},
+			{ID: 4, Type: "A", Name: "www.example.com", Content: "1.2.3.4", TTL: 300},
+			{ID: 5, Type: "A", Name: "www.example.com", Content: "1.2.3.5", TTL: 300},
+			{ID: 6, Type: "TXT", Name: "www.example.com", Content: "heritage=external-dns", TTL: 300},
+			{ID: 7, Type: "MX", Name: "example.com", Content: "mail.example.com", Prio: 10, TTL: 3600},
+			{ID: 8, Type: "SRV", Name: "_sip._tcp.example.com", Content: "5 5060 sip.example.com", Prio: 10, TTL: 3600},
+		},
+		"example.org": {
+			{ID: 9, Type: "CNAME", Name: "www.example.org", Content: "example.com", TTL: 3600},
+		},
+	}
+}
+
+func (f *fakeNameservers) handle(method string, params map[string]any) map[string]any {
+	ok := func(resData map[string]any) map[string]any {
+		return map[string]any{"code": codeSuccess, "msg": "Command completed successfully", "resData": resData}
+	}
+	switch method {
+	case "account.login":
+		return ok(map[string]any{"tfa": "0"})
+	case "nameserver.list":
+		var domains []string
+		for domain := range f.records {
+			domains = append(domains, domain)
+		}
+		sort.Strings(domains)
+		items := []any{}
+		for _, domain := range domains {
+			items = append(items, map[string]any{"domain": domain, "type": "MASTER"})
+		}
+		return ok(map[string]any{"count": len(items), "domains": items})
+	case "nameserver.info":
+		items := []any{}
+		for _, r := range f.records[asString(params["domain"])] {
+			items = append(items, map[string]any{"id": r.ID, "name": r.Name, "type": r.Type, "content": r.Content, "ttl": r.TTL, "prio": r.Prio})
+		}
+		return ok(map[string]any{"domain": params["domain"], "record": items})
+	case "nameserver.createRecord":
+		domain := asString(params["domain"])
+		f.nextID++
+		f.writes++
+		f.records[domain] = append(f.records[domain], Record{
+			ID:      f.nextID,
+			Name:    asString(params["name"]),
+			Type:    asString(params["type"]),
+			Content: asString(params["content"]),
+			TTL:     asInt(params["ttl"]),
+			Prio:    asInt(params["prio"]),
+		})
+		return ok(map[string]any{"id": f.nextID})
+	case "nameserver.updateRecord", "nameserver.deleteRecord":
+		for domain, records := range f.records {
+			i := slices.IndexFunc(records, func(r Record) bool { return r.ID == asInt(params["id"]) })
+			if i < 0 {
+				continue
+			}
+			f.writes++
+			if method == "nameserver.deleteRecord" {
+				f.records[domain] = slices.Delete(records, i, i+1)
+			} else {
+				records[i].Content = asString(params["content"])
+				records[i].TTL = asInt(params["ttl"])
+				records[i].Prio = asInt(params["prio"])
+			}
+			return ok(nil)
+		}
+		return map[string]any{"code": 2303, "msg": "Object does not exist"}
+	}
+	return map[string]any{"code": 2000, "msg": "Unknown command"}
+}
+
+func newTestProvider(t *testing.T, nameservers *fakeNameservers, domains ...string) *InwxProvider {
+	return &InwxProvider{
+		Client:       newFakeDomrobot(t, nameservers.handle).client(""),
+		domainFilter: endpoint.NewDomainFilter(domains),
+	}
+}
+
+func TestNewInwxProvider(t *testing.T) {
+	_, err := NewInwxProvider(InwxConfig{Username: "user", Password: "pass"})
+	require.NoError(t, err)
+
+	_, err = NewInwxProvider(InwxConfig{Username: "user"})
+	require.EqualError(t, err, "no INWX credentials found, set the username and the password")
+}
+
+func TestInwxProviderRecords(t *testing.T) {
+	p := newTestProvider(t, newFakeNameservers(), "example.com")
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 3600, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeTXT, 300, "heritage=external-dns"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+		endpoint.NewEndpointWithTTL("_sip._tcp.example.com", endpoint.RecordTypeSRV, 3600, "10 5 5060 sip.example.com"),
+	}, endpoints)
+}
+
+func TestInwxProviderApplyChanges(t *testing.T) {
+	nameservers := newFakeNameservers()
+	p := newTestProvider(t, nameservers, "example.com", "example.org")
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeAAAA, "2001:db8::1", "2001:db8::2"),
+			endpoint.NewEndpointWithTTL("_xmpp._tcp.example.com", endpoint.RecordTypeSRV, 600, "20 0 5222 xmpp.example.com"),
+			// not in a managed zone
+			endpoint.NewEndpoint("www.example.net", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5"),
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.5", "1.2.3.6"),
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 900, "10 mail.example.com", "20 backup.example.com"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeTXT, "heritage=external-dns"),
+			endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeCNAME, "example.com"),
+		},
+	}))
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 3600, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.5", "1.2.3.6"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 900, "10 mail.example.com", "20 backup.example.com"),
+		endpoint.NewEndpointWithTTL("_sip._tcp.example.com", endpoint.RecordTypeSRV, 3600, "10 5 5060 sip.example.com"),
+		endpoint.NewEndpointWithTTL("_xmpp._tcp.example.com", endpoint.RecordTypeSRV, 600, "20 0 5222 xmpp.example.com"),
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeAAAA, defaultTTL, "2001:db8::1", "2001:db8::2"),
+	}, endpoints)
+	// the unchanged target of www.example.com is kept
+	assert.Contains(t, nameservers.records["example.com"], Record{ID: 5, Type: "A", Name: "www.example.com", Content: "1.2.3.5", TTL: 300})
+	// the TTL of the MX record is updated in place
+	assert.Contains(t, nameservers.records["example.com"], Record{ID: 7, Type: "MX", Name: "example.com", Content: "mail.example.com", Prio: 10, TTL: 900})
+}
+
+func TestInwxProviderApplyChangesDryRun(t *testing.T) {
+	nameservers := newFakeNameservers()
+	p := newTestProvider(t, nameservers, "example.com")
+	p.DryRun = true
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.4", "1.2.3.5")},
+	}))
+	assert.Zero(t, nameservers.writes)
+}
+
+func TestInwxProviderApplyChangesInvalidTarget(t *testing.T) {
+	nameservers := newFakeNameservers()
+	p := newTestProvider(t, nameservers, "example.com")
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "mail.example.com")},
+	})
+	require.EqualError(t, err, `invalid target "mail.example.com" of MX record example.com: the priority is missing`)
+	assert.Zero(t, nameservers.writes)
+}
+
+func TestInwxProviderApplyChangesError(t *testing.T) {
+	nameservers := newFakeNameservers()
+	p := &InwxProvider{
+		Client: newFakeDomrobot(t, func(method string, params map[string]any) map[string]any {
+			if method == "nameserver.deleteRecord" {
+				return map[string]any{"code": 2303, "msg": "Object does not exist"}
+			}
+			return nameservers.handle(method, params)
+		}).client(""),
+		domainFilter: endpoint.NewDomainFilter([]string{"example.com"}),
+	}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeTXT, "heritage=external-dns")},
+	})
+	require.EqualError(t, err, "failed to delete TXT record www.example.com: inwx: nameserver.deleteRecord failed with code 2303: Object does not exist")
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inwx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// The domrobot API speaks XML-RPC. The values are encoded from and decoded into plain Go values: strings, ints,
+// bools, float64s, []any for arrays and map[string]any for structs.
+
+// encodeMethodCall returns the XML-RPC call of a method with a single struct parameter, as the domrobot methods take.
+func encodeMethodCall(method string, params map[string]any) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString("<methodCall><methodName>")
+	if err := xml.EscapeText(&buf, []byte(method)); err != nil {
+		return nil, err
+	}
+	buf.WriteString("</methodName><params><param>")
+	if err := encodeValue(&buf, params); err != nil {
+		return nil, err
+	}
+	buf.WriteString("</param></params></methodCall>")
+	return buf.Bytes(), nil
+}
+
+func encodeValue(buf *bytes.Buffer, v any) error {
+	buf.WriteString("<value>")
+	switch v := v.(type) {
+	case string:
+		buf.WriteString("<string>")
+		if err := xml.EscapeText(buf, []byte(v)); err != nil {
+			return err
+		}
+		buf.WriteString("</string>")
+	case int:
+		buf.WriteString("<int>" + strconv.Itoa(v) + "</int>")
+	case bool:
+		if v {
+			buf.WriteString("<boolean>1</boolean>")
+		} else {
+			buf.WriteString("<boolean>0</boolean>")
+		}
+	case float64:
+		buf.WriteString("<double>" + strconv.FormatFloat(v, 'f', -1, 64) + "</double>")
+	case []any:
+		buf.WriteString("<array><data>")
+		for _, item := range v {
+			if err := encodeValue(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteString("</data></array>")
+	case map[string]any:
+		buf.WriteString("<struct>")
+		// sorted for the requests to be deterministic
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			buf.WriteString("<member><name>")
+			if err := xml.EscapeText(buf, []byte(name)); err != nil {
+				return err
+			}
+			buf.WriteString("</name>")
+			if err := encodeValue(buf, v[name]); err != nil {
+				return err
+			}
+			buf.WriteString("</member>")
+		}
+		buf.WriteString("</struct>")
+	default:
+		return fmt.Errorf("unsupported XML-RPC value type %T", v)
+	}
+	buf.WriteString("</value>")
+	return nil
+}
+
+// xmlValue is the XML-RPC encoding of a value. A value without a type element is a string.
+type xmlValue struct {
+	Text     string     `xml:",chardata"`
+	String   *string    `xml:"string"`
+	Int      *string    `xml:"int"`
+	I4       *string    `xml:"i4"`
+	Boolean  *string    `xml:"boolean"`
+	Double   *string    `xml:"double"`
+	DateTime *string    `xml:"dateTime.iso8601"`
+	Base64   *string    `xml:"base64"`
+	Array    *xmlArray  `xml:"array"`
+	Struct   *xmlStruct `xml:"struct"`
+	Nil      *struct{}  `xml:"nil"`
+}
+
+type xmlArray struct {
+	Values []xmlValue `xml:"data>value"`
+}
+
+type xmlStruct struct {
+	Members []xmlMember `xml:"member"`
+}
+
+type xmlMember struct {
+	Name  string   `xml:"name"`
+	Value xmlValue `xml:"value"`
+}
+
+type xmlMethodResponse struct {
+	Params []xmlValue `xml:"params>param>value"`
+	Fault  *xmlValue  `xml:"fault>value"`
+}
+
+// decodeMethodResponse returns the value of an XML-RPC response, or an error for a fault.
+func decodeMethodResponse(r io.Reader) (any, error) {
+	var resp xmlMethodResponse
+	if err := xml.NewDecoder(r).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to decode the XML-RPC response: %w", err)
+	}
+	if resp.Fault != nil {
+		fault, err := resp.Fault.decode()
+		if err != nil {
+			return nil, err
+		}
+		faultStruct, _ := fault.(map[string]any)
+		return nil, fmt.Errorf("XML-RPC fault %v: %v", faultStruct["faultCode"], faultStruct["faultString"])
+	}
+	if len(resp.Params) != 1 {
+		return nil, fmt.Errorf("invalid XML-RPC response with %d values", len(resp.Params))
+	}
+	return resp.Params[0].decode()
+}
+
+func (v xmlValue) decode() (any, error) {
+	switch {
+	case v.String != nil:
+		return *v.String, nil
+	case v.Int != nil, v.I4 != nil:
+		raw := v.Int
+		if raw == nil {
+			raw = v.I4
+		}
+		return strconv.Atoi(strings.TrimSpace(*raw))
+	case v.Boolean != nil:
+		return strings.TrimSpace(*v.Boolean) == "1", nil
+	case v.Double != nil:
+		return strconv.ParseFloat(strings.TrimSpace(*v.Double), 64)
+	case v.DateTime != nil:
+		return strings.TrimSpace(*v.DateTime), nil
+	case v.Base64 != nil:
+		return strings.TrimSpace(*v.Base64), nil
+	case v.Nil != nil:
+		return nil, nil
+	case v.Array != nil:
+		values := make([]any, 0, len(v.Array.Values))
+		for _, item := range v.Array.Values {
+			decoded, err := item.decode()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, decoded)
+		}
+		return values, nil
+	case v.Struct != nil:
+		members := make(map[string]any, len(v.Struct.Members))
+		for _, member := range v.Struct.Members {
+			decoded, err := member.Value.decode()
+			if err != nil {
+				return nil, err
+			}
+			members[member.Name] = decoded
+		}
+		return members, nil
+	default:
+		return v.Text, nil
+	}
+}
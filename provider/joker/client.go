@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package joker
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+// defaultEndpoint is the base URL of the Joker.com DMAPI.
+const defaultEndpoint = "https://dmapi.joker.com/request"
+
+// response is a DMAPI response, made of header lines of the form "Key: value", then an empty line and the body.
+type response struct {
+	Headers map[string]string
+	Errors  []string
+	Body    string
+}
+
+// APIError is an error returned by the DMAPI.
+type APIError struct {
+	Command    string
+	StatusCode string
+	StatusText string
+	Errors     []string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("joker: %s failed with status %s: %s", e.Command, e.StatusCode, e.StatusText)
+	if len(e.Errors) > 0 {
+		msg += ": " + strings.Join(e.Errors, "; ")
+	}
+	return msg
+}
+
+// DNSClient is the interface of the DMAPI used by the provider, the calls but the login requiring a session.
+type DNSClient interface {
+	Login(ctx context.Context) error
+	Logout(ctx context.Context) error
+	ListDomains(ctx context.Context) ([]string, error)
+	GetZone(ctx context.Context, domain string) (string, error)
+	PutZone(ctx context.Context, domain, zone string) error
+}
+
+// Client calls the DMAPI in a session opened with an API key.
+type Client struct {
+	apiKey     string
+	endpoint   string
+	httpClient *http.Client
+	sessionID  string
+}
+
+// NewClient returns a client of the DMAPI authenticating with the API key.
+func NewClient(apiKey string, httpClient *http.Client) *Client {
+	return &Client{
+		apiKey:     apiKey,
+		endpoint:   defaultEndpoint,
+		httpClient: httpClient,
+	}
+}
+
+// Login opens a session, whose ID authenticates the following calls.
+func (c *Client) Login(ctx context.Context) error {
+	resp, err := c.do(ctx, "login", url.Values{"api-key": {c.apiKey}})
+	if err != nil {
+		return err
+	}
+	c.sessionID = resp.Headers["Auth-Sid"]
+	if c.sessionID == "" {
+		return fmt.Errorf("joker: login returned no session ID")
+	}
+	return nil
+}
+
+// Logout closes the session.
+func (c *Client) Logout(ctx context.Context) error {
+	if c.sessionID == "" {
+		return nil
+	}
+	_, err := c.do(ctx, "logout", url.Values{})
+	c.sessionID = ""
+	return err
+}
+
+// ListDomains returns the domains of the account.
+func (c *Client) ListDomains(ctx context.Context) ([]string, error) {
+	resp, err := c.do(ctx, "query-domain-list", url.Values{"pattern": {"*"}})
+	if err != nil {
+		return nil, err
+	}
+	var domains []string
+	// the domains are listed one per line, followed by their expiration date
+	for _, line := range strings.Split(resp.Body, "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 {
+			domains = append(domains, fields[0])
+		}
+	}
+	return domains, nil
+}
+
+// GetZone returns the zone of a domain, in the zone format of Joker.com.
+func (c *Client) GetZone(ctx context.Context, domain string) (string, error) {
+	resp, err := c.do(ctx, "dns-zone-get", url.Values{"domain": {domain}})
+	if err != nil {
+		return "", err
+	}
+	return resp.Body, nil
+}
+
+// PutZone replaces the zone of a domain.
+func (c *Client) PutZone(ctx context.Context, domain, zone string) error {
+	_, err := c.do(ctx, "dns-zone-put", url.Values{"domain": {domain}, "zone": {zone}})
+	return err
+}
+
+// do sends a command with its parameters as a form, and parses the response.
+func (c *Client) do(ctx context.Context, command string, params url.Values) (*response, error) {
+	if c.sessionID != "" {
+		params.Set("auth-sid", c.sessionID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+"/"+command, strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", externaldns.UserAgent())
+
+	httpResp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	resp, err := parseResponse(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if statusCode := resp.Headers["Status-Code"]; statusCode != "0" || httpResp.StatusCode != http.StatusOK {
+		if statusCode == "" {
+			statusCode = fmt.Sprint(httpResp.StatusCode)
+		}
+		return nil, &APIError{Command: command, StatusCode: statusCode, StatusText: resp.Headers["Status-Text"], Errors: resp.Errors}
+	}
+	return resp, nil
+}
+
+func parseResponse(r io.Reader) (*response, error) {
+	resp := &response{Headers: map[string]string{}}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+		key, value, _ := strings.Cut(line, ":")
+		value = strings.TrimSpace(value)
+		if key == "Error" {
+			resp.Errors = append(resp.Errors, value)
+			continue
+		}
+		resp.Headers[key] = value
+	}
+
+	var body strings.Builder
+	for scanner.Scan() {
+		body.WriteString(scanner.Text())
+		body.WriteString("\n")
+	}
+	resp.Body = body.String()
+	return resp, scanner.Err()
+}
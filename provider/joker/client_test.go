@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package joker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a client logging in with a test API key to the DMAPI of a test server running the handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client := NewClient("api-key", server.Client())
+	client.endpoint = server.URL
+	return client
+}
+
+func TestClientSession(t *testing.T) {
+	var requests []string
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		require.NoError(t, req.ParseForm())
+		requests = append(requests, fmt.Sprintf("%s %s", req.URL.Path, req.PostForm.Encode()))
+		switch req.URL.Path {
+		case "/login":
+			fmt.Fprint(w, "Auth-Sid: session-id\nStatus-Code: 0\nStatus-Text: OK\n\n")
+		case "/query-domain-list":
+			fmt.Fprint(w, "Status-Code: 0\nStatus-Text: OK\n\nexample.com 2026-01-01\nexample.org 2026-02-01\n")
+		default:
+			fmt.Fprint(w, "Status-Code: 0\nStatus-Text: OK\n\n")
+		}
+	})
+
+	require.NoError(t, client.Login(context.Background()))
+	domains, err := client.ListDomains(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example.com", "example.org"}, domains)
+	require.NoError(t, client.Logout(context.Background()))
+	// a closed session isn't closed again
+	require.NoError(t, client.Logout(context.Background()))
+
+	assert.Equal(t, []string{
+		"/login api-key=api-key",
+		"/query-domain-list auth-sid=session-id&pattern=%2A",
+		"/logout auth-sid=session-id",
+	}, requests)
+}
+
+func TestClientZone(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		require.NoError(t, req.ParseForm())
+		switch req.URL.Path {
+		case "/dns-zone-get":
+			assert.Equal(t, "example.com", req.PostForm.Get("domain"))
+			fmt.Fprint(w, "Status-Code: 0\nStatus-Text: OK\n\nwww A 0 1.2.3.4 3600\n$dyndns=yes:user:pass\n")
+		case "/dns-zone-put":
+			assert.Equal(t, "example.com", req.PostForm.Get("domain"))
+			assert.Equal(t, "www A 0 1.2.3.5 3600\n", req.PostForm.Get("zone"))
+			fmt.Fprint(w, "Status-Code: 0\nStatus-Text: OK\n\n")
+		}
+	})
+
+	zone, err := client.GetZone(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "www A 0 1.2.3.4 3600\n$dyndns=yes:user:pass\n", zone)
+	require.NoError(t, client.PutZone(context.Background(), "example.com", "www A 0 1.2.3.5 3600\n"))
+}
+
+func TestClientAPIError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, "Status-Code: 2200\nStatus-Text: Authentication error\nError: Invalid API key\n\n")
+	})
+
+	err := client.Login(context.Background())
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.EqualError(t, err, "joker: login failed with status 2200: Authentication error: Invalid API key")
+}
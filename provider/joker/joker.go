@@ -0,0 +1,268 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package joker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// defaultTTL is the TTL of the new records without a configured TTL.
+const defaultTTL = 3600
+
+// JokerProvider is an implementation of Provider for the DNS service of Joker.com.
+type JokerProvider struct {
+	provider.BaseProvider
+	Client DNSClient
+	// only consider hosted zones managing domains ending in this suffix
+	domainFilter endpoint.DomainFilter
+	DryRun       bool
+}
+
+// jokerChange is a change of the records of a name and type.
+type jokerChange struct {
+	Action   string
+	Message  string
+	Endpoint *endpoint.Endpoint
+	Records  []dns.RR
+	// Prune is whether the existing records without a target are removed.
+	Prune bool
+}
+
+// NewJokerProvider initializes a new Joker.com DNS based Provider.
+func NewJokerProvider(domainFilter endpoint.DomainFilter, dryRun bool) (*JokerProvider, error) {
+	apiKey := os.Getenv("JOKER_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("no API key found, set JOKER_API_KEY")
+	}
+
+	return &JokerProvider{
+		Client:       NewClient(apiKey, &http.Client{Timeout: 30 * time.Second}),
+		domainFilter: domainFilter,
+		DryRun:       dryRun,
+	}, nil
+}
+
+// session opens a session, the returned function closing it.
+func (p *JokerProvider) session(ctx context.Context) (func(), error) {
+	if err := p.Client.Login(ctx); err != nil {
+		return nil, fmt.Errorf("failed to open a session: %w", err)
+	}
+	return func() {
+		if err := p.Client.Logout(ctx); err != nil {
+			log.Warnf("Failed to close the Joker.com session: %v", err)
+		}
+	}, nil
+}
+
+// zones returns the list of domains, in an open session.
+func (p *JokerProvider) zones(ctx context.Context) ([]string, error) {
+	domains, err := p.Client.ListDomains(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list domains: %w", err)
+	}
+
+	var filtered []string
+	for _, domain := range domains {
+		if p.domainFilter.Match(domain) {
+			filtered = append(filtered, domain)
+		}
+	}
+	return filtered, nil
+}
+
+// SupportedRecordType returns true for the record types external-dns manages in the zone files of Joker.com. The
+// other lines of a zone, such as its URL redirections, are kept as they are.
+func (p *JokerProvider) SupportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT, endpoint.RecordTypeMX:
+		return true
+	default:
+		return false
+	}
+}
+
+// Records returns the list of records in the zone files of all the domains, the records of a name and type being
+// merged into a single endpoint. The names of the zone files are case-insensitive, they are lowercased.
+func (p *JokerProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	closeSession, err := p.session(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer closeSession()
+
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, zone := range zones {
+		text, err := p.Client.GetZone(ctx, zone)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get zone %s: %w", zone, err)
+		}
+
+		for _, line := range parseZone(zone, text) {
+			if line.RR == nil {
+				continue
+			}
+			name := strings.ToLower(recordDNSNameOf(line.RR))
+			endpoints = append(endpoints, endpoint.NewEndpointWithTTL(name, recordTypeOf(line.RR), endpoint.TTL(line.RR.Header().Ttl), recordTarget(line.RR)))
+		}
+	}
+	endpoints = provider.MergeEndpointsByNameType(endpoints)
+
+	log.WithFields(log.Fields{
+		"endpoints": endpoints,
+	}).Debug("Endpoints generated from Joker.com DNS")
+
+	return endpoints, nil
+}
+
+// ApplyChanges applies the given changes. Joker.com only replaces the zone of a domain as a whole, the zone of each
+// changed domain is fetched, the records of the changed names and types are replaced and the complete zone is
+// submitted, keeping the lines ExternalDNS doesn't manage.
+func (p *JokerProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	closeSession, err := p.session(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeSession()
+
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return err
+	}
+
+	zoneNameIDMapper := provider.ZoneIDName{}
+	for _, zone := range zones {
+		zoneNameIDMapper.Add(zone, zone)
+	}
+
+	changesByZone := map[string][]jokerChange{}
+	err = provider.ForEachEndpointTargets(changes, func(ep *endpoint.Endpoint, targets []string, prune bool) error {
+		zone, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zone == "" {
+			log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", ep.DNSName)
+			return nil
+		}
+		change := jokerChange{Action: "Create", Message: "Creating records.", Endpoint: ep, Prune: prune}
+		switch {
+		case prune && targets == nil:
+			change.Action, change.Message = "Delete", "Deleting records."
+		case prune:
+			change.Action, change.Message = "Update", "Updating records."
+		}
+		for _, target := range targets {
+			record, err := newRecord(ep, target)
+			if err != nil {
+				return fmt.Errorf("invalid target %q of %s record %s: %w", target, ep.RecordType, ep.DNSName, err)
+			}
+			change.Records = append(change.Records, record)
+		}
+		changesByZone[zone] = append(changesByZone[zone], change)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.submitChanges(ctx, changesByZone)
+}
+
+// submitChanges replaces the zone of each changed domain.
+func (p *JokerProvider) submitChanges(ctx context.Context, changesByZone map[string][]jokerChange) error {
+	zones := make([]string, 0, len(changesByZone))
+	for zone := range changesByZone {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+
+	for _, zone := range zones {
+		text, err := p.Client.GetZone(ctx, zone)
+		if err != nil {
+			return fmt.Errorf("failed to get zone %s: %w", zone, err)
+		}
+		lines := parseZone(zone, text)
+
+		for _, change := range changesByZone[zone] {
+			log.WithFields(log.Fields{
+				"record":   change.Endpoint.DNSName,
+				"type":     change.Endpoint.RecordType,
+				"targets":  change.Endpoint.Targets,
+				"ttl":      change.Endpoint.RecordTTL,
+				"action":   change.Action,
+				"zoneName": zone,
+			}).Info(change.Message)
+			lines = replaceRecords(lines, change)
+		}
+
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.PutZone(ctx, zone, renderZone(zone, lines)); err != nil {
+			return fmt.Errorf("failed to put zone %s: %w", zone, err)
+		}
+	}
+
+	return nil
+}
+
+// replaceRecords replaces the records of the change's name and type, the existing records being kept unless pruning. The
+// TTL of the replaced records is kept when the endpoint's TTL isn't configured.
+func replaceRecords(lines []zoneLine, change jokerChange) []zoneLine {
+	ttl := uint32(defaultTTL)
+	if change.Endpoint.RecordTTL.IsConfigured() {
+		ttl = uint32(change.Endpoint.RecordTTL)
+	}
+
+	replaced := make([]zoneLine, 0, len(lines)+len(change.Records))
+	existing := map[string]bool{}
+	for _, line := range lines {
+		if line.RR != nil && recordTypeOf(line.RR) == change.Endpoint.RecordType && strings.EqualFold(recordDNSNameOf(line.RR), change.Endpoint.DNSName) {
+			if !change.Endpoint.RecordTTL.IsConfigured() {
+				ttl = line.RR.Header().Ttl
+			}
+			if change.Prune {
+				continue
+			}
+			existing[recordTarget(line.RR)] = true
+		}
+		replaced = append(replaced, line)
+	}
+	for _, rr := range change.Records {
+		if existing[recordTarget(rr)] {
+			continue
+		}
+		rr.Header().Ttl = ttl
+		replaced = append(replaced, zoneLine{RR: rr})
+	}
+	return replaced
+}
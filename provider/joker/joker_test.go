@@ -0,0 +1,232 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package joker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// fakeJokerAPI is an in-memory DMAPI, storing the zones as text.
+type fakeJokerAPI struct {
+	mu       sync.Mutex
+	sessions map[string]bool
+	logins   int
+	zones    map[string]string
+	puts     []string
+	// failCommand fails the requests of the command.
+	failCommand string
+}
+
+func newFakeJokerAPI() *fakeJokerAPI {
+	return &fakeJokerAPI{
+		sessions: map[string]bool{},
+		zones: map[string]string{
+			"example.com": `@ A 0 1.2.3.4 3600
+api A 0 1.2.3.4 300
+api A 0 1.2.3.5 300
+api TXT 0 "heritage=external-dns" 300
+@ MX 10 mail 3600
+$dyndns=yes:user:pass
+`,
+			"example.org": `www CNAME 0 example.com 3600
+`,
+		},
+	}
+}
+
+func (f *fakeJokerAPI) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_ = req.ParseForm()
+	command := strings.TrimPrefix(req.URL.Path, "/")
+	respond := func(headers, body string) {
+		fmt.Fprintf(w, "%sStatus-Code: 0\nStatus-Text: OK\n\n%s", headers, body)
+	}
+	fail := func(statusCode, statusText, err string) {
+		fmt.Fprintf(w, "Status-Code: %s\nStatus-Text: %s\nError: %s\n\n", statusCode, statusText, err)
+	}
+
+	if command == "login" {
+		if req.PostForm.Get("api-key") != "api-key" {
+			fail("2200", "Authentication error", "Invalid API key")
+			return
+		}
+		f.logins++
+		sessionID := "session-" + strconv.Itoa(f.logins)
+		f.sessions[sessionID] = true
+		respond("Auth-Sid: "+sessionID+"\n", "")
+		return
+	}
+	sessionID := req.PostForm.Get("auth-sid")
+	if !f.sessions[sessionID] {
+		fail("2200", "Authentication error", "Invalid session")
+		return
+	}
+	if command == f.failCommand {
+		fail("2400", "Command failed due to internal error", "Zone is locked")
+		return
+	}
+
+	switch command {
+	case "logout":
+		delete(f.sessions, sessionID)
+		respond("", "")
+	case "query-domain-list":
+		domains := make([]string, 0, len(f.zones))
+		for domain := range f.zones {
+			domains = append(domains, domain+" 2026-01-01")
+		}
+		slices.Sort(domains)
+		respond("", strings.Join(domains, "\n")+"\n")
+	case "dns-zone-get":
+		respond("", f.zones[req.PostForm.Get("domain")])
+	case "dns-zone-put":
+		f.zones[req.PostForm.Get("domain")] = req.PostForm.Get("zone")
+		f.puts = append(f.puts, req.PostForm.Get("domain"))
+		respond("", "")
+	default:
+		fail("2400", "Command failed", "Unknown command")
+	}
+}
+
+func newTestProvider(t *testing.T, api *fakeJokerAPI, domains ...string) *JokerProvider {
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+	client := NewClient("api-key", server.Client())
+	client.endpoint = server.URL
+	return &JokerProvider{
+		Client:       client,
+		domainFilter: endpoint.NewDomainFilter(domains),
+	}
+}
+
+func TestNewJokerProvider(t *testing.T) {
+	t.Setenv("JOKER_API_KEY", "api-key")
+	_, err := NewJokerProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.NoError(t, err)
+
+	t.Setenv("JOKER_API_KEY", "")
+	_, err = NewJokerProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.EqualError(t, err, "no API key found, set JOKER_API_KEY")
+}
+
+func TestJokerProviderRecords(t *testing.T) {
+	api := newFakeJokerAPI()
+	p := newTestProvider(t, api, "example.com")
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 3600, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5"),
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeTXT, 300, "heritage=external-dns"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+	}, endpoints)
+	// the session is closed
+	assert.Equal(t, 1, api.logins)
+	assert.Empty(t, api.sessions)
+}
+
+func TestJokerProviderApplyChanges(t *testing.T) {
+	api := newFakeJokerAPI()
+	p := newTestProvider(t, api, "example.com", "example.org")
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeAAAA, "2001:db8::1", "2001:db8::2"),
+			endpoint.NewEndpointWithTTL("example.org", endpoint.RecordTypeMX, 600, "10 mail.example.org"),
+			// not in a managed zone
+			endpoint.NewEndpoint("www.example.net", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeA, "1.2.3.5", "1.2.3.6"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeTXT, "heritage=external-dns"),
+			endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeCNAME, "example.com"),
+		},
+	}))
+
+	// the zones are put once per domain, keeping the lines ExternalDNS doesn't manage, and the session is closed
+	assert.Equal(t, []string{"example.com", "example.org"}, api.puts)
+	assert.Equal(t, `@ A 0 1.2.3.4 3600
+@ MX 10 mail.example.com 3600
+$dyndns=yes:user:pass
+api A 0 1.2.3.5 300
+api A 0 1.2.3.6 300
+app AAAA 0 2001:db8::1 3600
+app AAAA 0 2001:db8::2 3600
+`, api.zones["example.com"])
+	assert.Equal(t, "@ MX 10 mail.example.org 600\n", api.zones["example.org"])
+	assert.Empty(t, api.sessions)
+}
+
+func TestJokerProviderApplyChangesDryRun(t *testing.T) {
+	api := newFakeJokerAPI()
+	p := newTestProvider(t, api, "example.com")
+	p.DryRun = true
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeA, "1.2.3.4", "1.2.3.5")},
+	}))
+	assert.Empty(t, api.puts)
+	assert.Equal(t, newFakeJokerAPI().zones, api.zones)
+	assert.Empty(t, api.sessions)
+}
+
+func TestJokerProviderApplyChangesInvalidTarget(t *testing.T) {
+	api := newFakeJokerAPI()
+	p := newTestProvider(t, api, "example.com")
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "mail.example.com")},
+	})
+	require.EqualError(t, err, `invalid target "mail.example.com" of MX record example.com: the priority is missing`)
+	assert.Empty(t, api.puts)
+	assert.Empty(t, api.sessions)
+}
+
+func TestJokerProviderApplyChangesError(t *testing.T) {
+	api := newFakeJokerAPI()
+	api.failCommand = "dns-zone-put"
+	p := newTestProvider(t, api, "example.com")
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+	})
+	require.EqualError(t, err, "failed to put zone example.com: joker: dns-zone-put failed with status 2400: Command failed due to internal error: Zone is locked")
+	assert.Empty(t, api.sessions)
+}
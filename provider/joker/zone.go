@@ -0,0 +1,216 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package joker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+var (
+	txtEscaper   = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	txtUnescaper = strings.NewReplacer(`\\`, `\`, `\"`, `"`)
+)
+
+// zoneLine is a line of a zone. The records of the supported types are parsed into resource records, the other lines,
+// such as the records of other types and the settings of the zone, are kept as they are.
+type zoneLine struct {
+	RR  dns.RR
+	Raw string
+}
+
+// parseZone parses a zone in the format of Joker.com, whose records are lines of the form "label type priority target
+// ttl", the label being relative to the domain and "@" at the apex.
+func parseZone(domain, text string) []zoneLine {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+	var lines []zoneLine
+	for _, raw := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		rr, err := parseRecord(domain, raw)
+		if err != nil {
+			log.Debugf("Keeping unparsable line %q of zone %s: %v", raw, domain, err)
+		}
+		lines = append(lines, zoneLine{RR: rr, Raw: raw})
+	}
+	return lines
+}
+
+// parseRecord returns the resource record of a zone line, nil when the line isn't a record of a supported type.
+func parseRecord(domain, raw string) (dns.RR, error) {
+	fields := splitFields(raw)
+	if len(fields) != 5 || strings.HasPrefix(fields[0], "$") || strings.HasPrefix(fields[0], "#") {
+		return nil, nil
+	}
+	label, recordType, priority, target, ttl := fields[0], strings.ToUpper(fields[1]), fields[2], fields[3], fields[4]
+
+	var rdata string
+	switch recordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA:
+		rdata = target
+	case endpoint.RecordTypeCNAME:
+		rdata = absoluteName(domain, target)
+	case endpoint.RecordTypeMX:
+		rdata = priority + " " + absoluteName(domain, target)
+	case endpoint.RecordTypeTXT:
+		rdata = target
+		if !strings.HasPrefix(rdata, `"`) {
+			rdata = `"` + txtEscaper.Replace(rdata) + `"`
+		}
+	default:
+		return nil, nil
+	}
+	return dns.NewRR(fmt.Sprintf("%s %s IN %s %s", provider.EnsureTrailingDot(recordDNSName(domain, label)), ttl, recordType, rdata))
+}
+
+// splitFields splits a zone line on spaces, the quoted strings being kept together with their quotes.
+func splitFields(line string) []string {
+	var fields []string
+	var field strings.Builder
+	inField, quoted, escaped := false, false, false
+	for _, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case !quoted && (r == ' ' || r == '\t'):
+			if inField {
+				fields = append(fields, field.String())
+				field.Reset()
+				inField = false
+			}
+			continue
+		}
+		field.WriteRune(r)
+		inField = true
+	}
+	if inField {
+		fields = append(fields, field.String())
+	}
+	return fields
+}
+
+// renderZone returns a zone in the format of Joker.com.
+func renderZone(domain string, lines []zoneLine) string {
+	var zone strings.Builder
+	for _, line := range lines {
+		if line.RR != nil {
+			zone.WriteString(renderRecord(domain, line.RR))
+		} else {
+			zone.WriteString(line.Raw)
+		}
+		zone.WriteString("\n")
+	}
+	return zone.String()
+}
+
+// renderRecord returns the zone line of a resource record, the names being written fully qualified without their
+// trailing dot.
+func renderRecord(domain string, rr dns.RR) string {
+	priority, target := "0", recordTarget(rr)
+	switch rr := rr.(type) {
+	case *dns.MX:
+		priority, target = strconv.Itoa(int(rr.Preference)), strings.TrimSuffix(rr.Mx, ".")
+	case *dns.TXT:
+		target = `"` + strings.Join(rr.Txt, "") + `"`
+	}
+	return fmt.Sprintf("%s %s %s %s %d", recordName(domain, recordDNSNameOf(rr)), recordTypeOf(rr), priority, target, rr.Header().Ttl)
+}
+
+// recordTarget returns the endpoint target of a resource record. MX targets are of the form "10 mail.example.com", the
+// priority coming first.
+func recordTarget(rr dns.RR) string {
+	switch rr := rr.(type) {
+	case *dns.A:
+		return rr.A.String()
+	case *dns.AAAA:
+		return rr.AAAA.String()
+	case *dns.CNAME:
+		return strings.TrimSuffix(rr.Target, ".")
+	case *dns.MX:
+		return fmt.Sprintf("%d %s", rr.Preference, strings.TrimSuffix(rr.Mx, "."))
+	case *dns.TXT:
+		return txtUnescaper.Replace(strings.Join(rr.Txt, ""))
+	}
+	return ""
+}
+
+// newRecord returns the resource record of an endpoint target, the TTL being set on submission.
+func newRecord(ep *endpoint.Endpoint, target string) (dns.RR, error) {
+	rdata := target
+	switch ep.RecordType {
+	case endpoint.RecordTypeCNAME:
+		rdata = provider.EnsureTrailingDot(target)
+	case endpoint.RecordTypeMX:
+		priority, exchange, ok := strings.Cut(target, " ")
+		if !ok {
+			return nil, fmt.Errorf("the priority is missing")
+		}
+		rdata = priority + " " + provider.EnsureTrailingDot(exchange)
+	case endpoint.RecordTypeTXT:
+		rdata = `"` + txtEscaper.Replace(target) + `"`
+	}
+	return dns.NewRR(fmt.Sprintf("%s 0 IN %s %s", provider.EnsureTrailingDot(ep.DNSName), ep.RecordType, rdata))
+}
+
+// recordDNSNameOf returns the DNS name of a resource record, without its trailing dot.
+func recordDNSNameOf(rr dns.RR) string {
+	return strings.TrimSuffix(rr.Header().Name, ".")
+}
+
+// recordTypeOf returns the type of a resource record.
+func recordTypeOf(rr dns.RR) string {
+	return dns.TypeToString[rr.Header().Rrtype]
+}
+
+// recordName returns the label of a record relative to its domain, "@" at the apex.
+func recordName(domain, dnsName string) string {
+	if dnsName == domain {
+		return "@"
+	}
+	return strings.TrimSuffix(dnsName, "."+domain)
+}
+
+// recordDNSName returns the DNS name of a label of a domain.
+func recordDNSName(domain, label string) string {
+	if label == "" || label == "@" {
+		return domain
+	}
+	return label + "." + domain
+}
+
+// absoluteName returns the fully qualified name of a zone target, the targets without a dot being relative to the
+// domain.
+func absoluteName(domain, target string) string {
+	switch {
+	case target == "@":
+		return domain + "."
+	case strings.Contains(target, "."):
+		return provider.EnsureTrailingDot(target)
+	}
+	return target + "." + domain + "."
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package joker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestParseZone(t *testing.T) {
+	lines := parseZone("example.com", `@ A 0 1.2.3.4 3600
+www CNAME 0 @ 3600
+api CNAME 0 lb.example.net 300
+@ MX 10 mail 3600
+@ TXT 0 "v=spf1 include:\"spf.example.com\" -all" 3600
+_sip._tcp SRV 10/20 sip.example.com:5060 3600
+$dyndns=yes:user:pass
+www A 0 not-an-address 3600
+`)
+	require.Len(t, lines, 8)
+
+	var targets []string
+	for _, line := range lines {
+		if line.RR != nil {
+			targets = append(targets, recordDNSNameOf(line.RR)+" "+recordTypeOf(line.RR)+" "+recordTarget(line.RR))
+		}
+	}
+	assert.Equal(t, []string{
+		"example.com A 1.2.3.4",
+		"www.example.com CNAME example.com",
+		"api.example.com CNAME lb.example.net",
+		"example.com MX 10 mail.example.com",
+		`example.com TXT v=spf1 include:"spf.example.com" -all`,
+	}, targets)
+}
+
+func TestRenderZone(t *testing.T) {
+	zone := `@ A 0 1.2.3.4 3600
+api CNAME 0 lb.example.net 300
+@ MX 10 mail.example.com 3600
+@ TXT 0 "v=spf1 include:\"spf.example.com\" -all" 3600
+_sip._tcp SRV 10/20 sip.example.com:5060 3600
+$dyndns=yes:user:pass
+`
+	assert.Equal(t, zone, renderZone("example.com", parseZone("example.com", zone)))
+}
+
+func TestNewRecord(t *testing.T) {
+	for _, tt := range []struct {
+		ep     *endpoint.Endpoint
+		target string
+		line   string
+	}{
+		{endpoint.NewEndpoint("example.com", endpoint.RecordTypeAAAA, "2001:db8::1"), "2001:db8::1", "@ AAAA 0 2001:db8::1 0"},
+		{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, "example.com"), "example.com", "www CNAME 0 example.com 0"},
+		{endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "10 mail.example.com"), "10 mail.example.com", "@ MX 10 mail.example.com 0"},
+		{endpoint.NewEndpoint("txt.example.com", endpoint.RecordTypeTXT, `say "hi"`), `say "hi"`, `txt TXT 0 "say \"hi\"" 0`},
+	} {
+		rr, err := newRecord(tt.ep, tt.target)
+		require.NoError(t, err)
+		assert.Equal(t, tt.line, renderRecord("example.com", rr))
+		assert.Equal(t, tt.target, recordTarget(rr))
+	}
+
+	_, err := newRecord(endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "mail.example.com"), "mail.example.com")
+	require.EqualError(t, err, "the priority is missing")
+	_, err = newRecord(endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "not-an-address"), "not-an-address")
+	require.Error(t, err)
+}
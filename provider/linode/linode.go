@@ -129,7 +129,7 @@ func (p *LinodeProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, err
 		}
 
 		for _, r := range records {
-			if provider.SupportedRecordType(string(r.Type)) {
+			if p.SupportedRecordType(string(r.Type)) {
 				name := fmt.Sprintf("%s.%s", r.Name, zone.Domain)
 
 				// root name is identified by the empty string and should be
@@ -138,7 +138,7 @@ func (p *LinodeProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, err
 					name = zone.Domain
 				}
 
-				endpoints = append(endpoints, endpoint.NewEndpointWithTTL(name, string(r.Type), endpoint.TTL(r.TTLSec), r.Target))
+				endpoints = append(endpoints, endpoint.NewEndpointWithTTL(name, string(r.Type), endpoint.TTL(r.TTLSec), getRecordTarget(r)))
 			}
 		}
 	}
@@ -146,6 +146,17 @@ func (p *LinodeProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, err
 	return endpoints, nil
 }
 
+// SupportedRecordType returns true for the record types of the default record filter and for MX records, whose
+// priority Linode stores apart from their target.
+func (p *LinodeProvider) SupportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeMX:
+		return true
+	default:
+		return provider.SupportedRecordType(recordType)
+	}
+}
+
 func (p *LinodeProvider) fetchRecords(ctx context.Context, domainID int) ([]linodego.DomainRecord, error) {
 	records, err := p.Client.ListDomainRecords(ctx, domainID, nil)
 	if err != nil {
@@ -331,15 +342,26 @@ func (p *LinodeProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 			}
 
 			for _, target := range ep.Targets {
+				recordTarget, priority, err := parseTarget(recordType, target)
+				if err != nil {
+					log.WithFields(log.Fields{
+						"zoneID":     zoneID,
+						"dnsName":    ep.DNSName,
+						"recordType": ep.RecordType,
+						"target":     target,
+					}).Warnf("Skipping invalid target: %v", err)
+					continue
+				}
+
 				linodeCreates = append(linodeCreates, LinodeChangeCreate{
 					Domain: zone,
 					Options: linodego.DomainRecordCreateOptions{
-						Target:   target,
+						Target:   recordTarget,
 						Name:     getStrippedRecordName(zone, ep),
 						Type:     recordType,
 						Weight:   getWeight(recordType),
 						Port:     getPort(),
-						Priority: getPriority(),
+						Priority: priority,
 						TTLSec:   int(ep.RecordTTL),
 					},
 				})
@@ -381,10 +403,21 @@ func (p *LinodeProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 			matchedRecordsByTarget := make(map[string]linodego.DomainRecord)
 
 			for _, record := range matchedRecords {
-				matchedRecordsByTarget[record.Target] = record
+				matchedRecordsByTarget[getRecordTarget(record)] = record
 			}
 
 			for _, target := range ep.Targets {
+				recordTarget, priority, err := parseTarget(recordType, target)
+				if err != nil {
+					log.WithFields(log.Fields{
+						"zoneID":     zoneID,
+						"dnsName":    ep.DNSName,
+						"recordType": ep.RecordType,
+						"target":     target,
+					}).Warnf("Skipping invalid target: %v", err)
+					continue
+				}
+
 				if record, ok := matchedRecordsByTarget[target]; ok {
 					log.WithFields(log.Fields{
 						"zoneID":     zoneID,
@@ -398,12 +431,12 @@ func (p *LinodeProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 						Domain:       zone,
 						DomainRecord: record,
 						Options: linodego.DomainRecordUpdateOptions{
-							Target:   target,
+							Target:   recordTarget,
 							Name:     getStrippedRecordName(zone, ep),
 							Type:     recordType,
 							Weight:   getWeight(recordType),
 							Port:     getPort(),
-							Priority: getPriority(),
+							Priority: priority,
 							TTLSec:   int(ep.RecordTTL),
 						},
 					})
@@ -422,12 +455,12 @@ func (p *LinodeProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 					linodeCreates = append(linodeCreates, LinodeChangeCreate{
 						Domain: zone,
 						Options: linodego.DomainRecordCreateOptions{
-							Target:   target,
+							Target:   recordTarget,
 							Name:     getStrippedRecordName(zone, ep),
 							Type:     recordType,
 							Weight:   getWeight(recordType),
 							Port:     getPort(),
-							Priority: getPriority(),
+							Priority: priority,
 							TTLSec:   int(ep.RecordTTL),
 						},
 					})
@@ -441,7 +474,7 @@ func (p *LinodeProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 					"dnsName":    ep.DNSName,
 					"zoneName":   zone.Domain,
 					"recordType": ep.RecordType,
-					"target":     record.Target,
+					"target":     getRecordTarget(record),
 				}).Warn("Deleting Target")
 
 				linodeDeletes = append(linodeDeletes, LinodeChangeDelete{
@@ -523,11 +556,38 @@ func convertRecordType(recordType string) (linodego.DomainRecordType, error) {
 		return linodego.RecordTypeSRV, nil
 	case "NS":
 		return linodego.RecordTypeNS, nil
+	case "MX":
+		return linodego.RecordTypeMX, nil
 	default:
 		return "", fmt.Errorf("invalid Record Type: %s", recordType)
 	}
 }
 
+// parseTarget returns the target and priority of the record of an endpoint target, MX targets are of the form
+// "10 mail.example.com".
+func parseTarget(recordType linodego.DomainRecordType, target string) (string, *int, error) {
+	if recordType != linodego.RecordTypeMX {
+		return target, getPriority(), nil
+	}
+	priorityRaw, exchange, ok := strings.Cut(target, " ")
+	if !ok {
+		return "", nil, fmt.Errorf("mx target needs to be of form '10 example.com'")
+	}
+	priority, err := strconv.Atoi(priorityRaw)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid priority specified")
+	}
+	return exchange, &priority, nil
+}
+
+// getRecordTarget returns the endpoint target of a record, including the priority of MX records.
+func getRecordTarget(record linodego.DomainRecord) string {
+	if record.Type == linodego.RecordTypeMX {
+		return fmt.Sprintf("%d %s", record.Priority, record.Target)
+	}
+	return record.Target
+}
+
 func getStrippedRecordName(zone linodego.Domain, ep endpoint.Endpoint) string {
 	// Handle root
 	if ep.DNSName == zone.Domain {
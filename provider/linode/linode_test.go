@@ -139,6 +139,10 @@ func TestLinodeConvertRecordType(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, linodego.RecordTypeNS, record)
 
+	record, err = convertRecordType("MX")
+	require.NoError(t, err)
+	assert.Equal(t, linodego.RecordTypeMX, record)
+
 	_, err = convertRecordType("INVALID")
 	require.Error(t, err)
 }
@@ -455,6 +459,110 @@ func TestLinodeApplyChangesTargetAdded(t *testing.T) {
 	mockDomainClient.AssertExpectations(t)
 }
 
+func TestLinodeRecordsMX(t *testing.T) {
+	mockDomainClient := MockDomainClient{}
+
+	provider := &LinodeProvider{
+		Client:       &mockDomainClient,
+		domainFilter: endpoint.NewDomainFilter([]string{}),
+		DryRun:       false,
+	}
+
+	mockDomainClient.On(
+		"ListDomains",
+		mock.Anything,
+		mock.Anything,
+	).Return([]linodego.Domain{{Domain: "example.com", ID: 1}}, nil).Once()
+
+	mockDomainClient.On(
+		"ListDomainRecords",
+		mock.Anything,
+		1,
+		mock.Anything,
+	).Return([]linodego.DomainRecord{
+		{ID: 11, Name: "", Type: "MX", Target: "mail.example.com", Priority: 10, TTLSec: 300},
+		{ID: 12, Name: "", Type: "CAA", Target: "letsencrypt.org"},
+	}, nil).Once()
+
+	endpoints, err := provider.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 300, "10 mail.example.com"),
+	}, endpoints)
+
+	mockDomainClient.AssertExpectations(t)
+}
+
+func TestLinodeApplyChangesMX(t *testing.T) {
+	mockDomainClient := MockDomainClient{}
+
+	provider := &LinodeProvider{
+		Client:       &mockDomainClient,
+		domainFilter: endpoint.NewDomainFilter([]string{}),
+		DryRun:       false,
+	}
+
+	mockDomainClient.On(
+		"ListDomains",
+		mock.Anything,
+		mock.Anything,
+	).Return([]linodego.Domain{{Domain: "example.com", ID: 1}}, nil).Once()
+
+	mockDomainClient.On(
+		"ListDomainRecords",
+		mock.Anything,
+		1,
+		mock.Anything,
+	).Return([]linodego.DomainRecord{
+		{ID: 11, Name: "", Type: "MX", Target: "mail.example.com", Priority: 10},
+		{ID: 12, Name: "", Type: "MX", Target: "backup.example.com", Priority: 20},
+	}, nil).Once()
+
+	priority := func(p int) *int { return &p }
+
+	// Apply Actions
+	mockDomainClient.On(
+		"UpdateDomainRecord",
+		mock.Anything,
+		1,
+		11,
+		linodego.DomainRecordUpdateOptions{
+			Type: "MX", Name: "", Target: "mail.example.com",
+			Priority: priority(10), Weight: getWeight(linodego.RecordTypeMX), Port: getPort(),
+		},
+	).Return(&linodego.DomainRecord{}, nil).Once()
+
+	mockDomainClient.On(
+		"CreateDomainRecord",
+		mock.Anything,
+		1,
+		linodego.DomainRecordCreateOptions{
+			Type: "MX", Name: "", Target: "backup.example.com",
+			Priority: priority(30), Weight: getWeight(linodego.RecordTypeMX), Port: getPort(),
+		},
+	).Return(&linodego.DomainRecord{}, nil).Once()
+
+	mockDomainClient.On(
+		"DeleteDomainRecord",
+		mock.Anything,
+		1,
+		12,
+	).Return(nil).Once()
+
+	err := provider.ApplyChanges(context.Background(), &plan.Changes{
+		// the priority of the backup exchange changes, the invalid target is skipped
+		UpdateNew: []*endpoint.Endpoint{{
+			DNSName:    "example.com",
+			RecordType: "MX",
+			Targets:    []string{"10 mail.example.com", "30 backup.example.com", "invalid"},
+		}},
+		UpdateOld: []*endpoint.Endpoint{},
+	})
+	require.NoError(t, err)
+
+	mockDomainClient.AssertExpectations(t)
+}
+
 func TestLinodeApplyChangesTargetRemoved(t *testing.T) {
 	mockDomainClient := MockDomainClient{}
 
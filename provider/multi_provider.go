@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// NamedProvider is a provider and the name it is configured with.
+type NamedProvider struct {
+	Name     string
+	Provider Provider
+}
+
+// MultiProvider writes the changes to several providers which hold the same records. The records are
+// read from the first provider, the primary one, and the changes are applied to all of them.
+type MultiProvider struct {
+	providers []NamedProvider
+}
+
+// NewMultiProvider creates a MultiProvider, the first provider is the primary one.
+func NewMultiProvider(providers ...NamedProvider) (*MultiProvider, error) {
+	if len(providers) == 0 {
+		return nil, errors.New("at least one provider is required")
+	}
+	return &MultiProvider{providers: providers}, nil
+}
+
+// Records returns the records of the primary provider.
+func (m *MultiProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	return m.providers[0].Provider.Records(ctx)
+}
+
+// ApplyChanges applies the changes to every provider, a failure of one of them doesn't prevent
+// the changes from being applied to the others. The error is soft when all the failures are.
+func (m *MultiProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	var errs, hardErrs []error
+	for i, p := range m.providers {
+		providerChanges := changes
+		// each provider gets its own copy, as the providers may modify the changes they apply
+		if i > 0 {
+			providerChanges = copyChanges(changes)
+		}
+		if err := p.Provider.ApplyChanges(ctx, providerChanges); err != nil {
+			log.Errorf("Failed to apply the changes to provider %s: %v", p.Name, err)
+			err = fmt.Errorf("provider %s: %w", p.Name, err)
+			errs = append(errs, err)
+			if !errors.Is(err, SoftError) {
+				hardErrs = append(hardErrs, err)
+			}
+		}
+	}
+	if len(hardErrs) > 0 {
+		return errors.Join(hardErrs...)
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+// AdjustEndpoints adjusts the endpoints for the primary provider, which the records are read from.
+func (m *MultiProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	return m.providers[0].Provider.AdjustEndpoints(endpoints)
+}
+
+// GetDomainFilter returns the domain filter of the primary provider.
+func (m *MultiProvider) GetDomainFilter() endpoint.DomainFilterInterface {
+	return m.providers[0].Provider.GetDomainFilter()
+}
+
+// ZoneNamesByID returns the names of the zones of the primary provider by zone ID.
+func (m *MultiProvider) ZoneNamesByID(ctx context.Context) (map[string]string, error) {
+	return ZoneNamesByID(ctx, m.providers[0].Provider)
+}
+
+func copyChanges(changes *plan.Changes) *plan.Changes {
+	copyEndpoints := func(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+		if endpoints == nil {
+			return nil
+		}
+		copied := make([]*endpoint.Endpoint, 0, len(endpoints))
+		for _, ep := range endpoints {
+			copied = append(copied, ep.DeepCopy())
+		}
+		return copied
+	}
+	return &plan.Changes{
+		Create:    copyEndpoints(changes.Create),
+		UpdateOld: copyEndpoints(changes.UpdateOld),
+		UpdateNew: copyEndpoints(changes.UpdateNew),
+		Delete:    copyEndpoints(changes.Delete),
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// recordingProvider records the changes it receives and fails with err.
+func recordingProvider(t *testing.T, received *[]*plan.Changes, err error) *testProviderFunc {
+	return &testProviderFunc{
+		records: recordsNotCalled(t),
+		applyChanges: func(_ context.Context, changes *plan.Changes) error {
+			*received = append(*received, changes)
+			return err
+		},
+	}
+}
+
+func TestMultiProviderApplyChanges(t *testing.T) {
+	changes := &plan.Changes{
+		Create:    []*endpoint.Endpoint{endpoint.NewEndpoint("create.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("update.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("update.example.com", endpoint.RecordTypeA, "5.6.7.8")},
+		Delete:    []*endpoint.Endpoint{endpoint.NewEndpoint("delete.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+	}
+
+	for _, tt := range []struct {
+		name      string
+		errs      [2]error
+		err       string
+		softError bool
+	}{
+		{
+			name: "all providers succeed",
+		},
+		{
+			name:      "the first provider fails",
+			errs:      [2]error{errors.New("unavailable")},
+			err:       "provider aws: unavailable",
+			softError: false,
+		},
+		{
+			name:      "the second provider fails softly",
+			errs:      [2]error{nil, NewSoftErrorf("rate limited")},
+			err:       "provider cloudflare: soft error\nrate limited",
+			softError: true,
+		},
+		{
+			name:      "a hard failure wins over a soft one",
+			errs:      [2]error{NewSoftErrorf("rate limited"), errors.New("forbidden")},
+			err:       "provider cloudflare: forbidden",
+			softError: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var first, second []*plan.Changes
+			m, err := NewMultiProvider(
+				NamedProvider{Name: "aws", Provider: recordingProvider(t, &first, tt.errs[0])},
+				NamedProvider{Name: "cloudflare", Provider: recordingProvider(t, &second, tt.errs[1])},
+			)
+			require.NoError(t, err)
+
+			err = m.ApplyChanges(context.Background(), changes)
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.err)
+				assert.Equal(t, tt.softError, errors.Is(err, SoftError))
+			}
+
+			require.Len(t, first, 1, "the first provider should be called")
+			require.Len(t, second, 1, "the second provider should be called")
+			assert.Equal(t, changes, first[0])
+			assert.Equal(t, changes, second[0], "both providers should receive the same changes")
+			assert.NotSame(t, first[0].Create[0], second[0].Create[0], "each provider should receive its own copy of the changes")
+		})
+	}
+}
+
+func TestMultiProviderReadsPrimary(t *testing.T) {
+	records := []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.4")}
+	primary := &testProviderFunc{
+		records: func(_ context.Context) ([]*endpoint.Endpoint, error) { return records, nil },
+		adjustEndpoints: func(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+			return append(endpoints, endpoint.NewEndpoint("adjusted.example.com", endpoint.RecordTypeA, "1.2.3.4")), nil
+		},
+		getDomainFilter: func() endpoint.DomainFilterInterface { return endpoint.NewDomainFilter([]string{"example.com"}) },
+	}
+	secondary := &testProviderFunc{records: recordsNotCalled(t)}
+
+	m, err := NewMultiProvider(NamedProvider{Name: "aws", Provider: primary}, NamedProvider{Name: "cloudflare", Provider: secondary})
+	require.NoError(t, err)
+
+	got, err := m.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, records, got)
+
+	adjusted, err := m.AdjustEndpoints(nil)
+	require.NoError(t, err)
+	assert.Len(t, adjusted, 1)
+
+	assert.Equal(t, endpoint.NewDomainFilter([]string{"example.com"}), m.GetDomainFilter())
+
+	_, err = NewMultiProvider()
+	assert.EqualError(t, err, "at least one provider is required")
+}
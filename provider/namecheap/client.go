@@ -0,0 +1,216 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namecheap
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+const (
+	// defaultEndpoint is the URL of the Namecheap XML API.
+	defaultEndpoint = "https://api.namecheap.com/xml.response"
+	// pageSize is the number of domains listed per request, the largest page size Namecheap accepts.
+	pageSize = 100
+)
+
+// Domain is a domain of the account.
+type Domain struct {
+	Name string `xml:"Name,attr"`
+	// IsOurDNS is set when the domain uses the Namecheap name servers, whose records can be managed.
+	IsOurDNS bool `xml:"IsOurDNS,attr"`
+}
+
+// Host is a DNS record of a domain. The records are flat within a domain, a record holds a single address. The name
+// is relative to the domain, "@" at the apex.
+type Host struct {
+	Name    string `xml:"Name,attr"`
+	Type    string `xml:"Type,attr"`
+	Address string `xml:"Address,attr"`
+	MXPref  int    `xml:"MXPref,attr"`
+	TTL     int    `xml:"TTL,attr"`
+}
+
+// Hosts is the whole set of records of a domain, with the email type of the domain, "MX" when it has MX records.
+type Hosts struct {
+	EmailType string `xml:"EmailType,attr"`
+	Hosts     []Host `xml:"host"`
+}
+
+// APIError is an error returned by the Namecheap API.
+type APIError struct {
+	Number  string `xml:"Number,attr"`
+	Message string `xml:",chardata"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("namecheap: error %s: %s", e.Number, strings.TrimSpace(e.Message))
+}
+
+// DNSClient is the interface of the Namecheap API used by the provider. The records of a domain can only be replaced
+// as a whole.
+type DNSClient interface {
+	ListDomains(ctx context.Context) ([]Domain, error)
+	GetHosts(ctx context.Context, domain string) (Hosts, error)
+	SetHosts(ctx context.Context, domain string, hosts Hosts) error
+}
+
+// Client calls the Namecheap API with an API key, from a whitelisted client IP.
+type Client struct {
+	apiUser    string
+	apiKey     string
+	userName   string
+	clientIP   string
+	endpoint   string
+	httpClient *http.Client
+}
+
+// NewClient returns a client of the Namecheap API authenticating with the API user and key, acting on behalf of the
+// user name.
+func NewClient(apiUser, apiKey, userName, clientIP string, httpClient *http.Client) *Client {
+	return &Client{
+		apiUser:    apiUser,
+		apiKey:     apiKey,
+		userName:   userName,
+		clientIP:   clientIP,
+		endpoint:   defaultEndpoint,
+		httpClient: httpClient,
+	}
+}
+
+func (c *Client) ListDomains(ctx context.Context) ([]Domain, error) {
+	var domains []Domain
+	for page := 1; ; page++ {
+		var result struct {
+			Domains []Domain `xml:"DomainGetListResult>Domain"`
+			Total   int      `xml:"Paging>TotalItems"`
+		}
+		params := url.Values{"Page": {strconv.Itoa(page)}, "PageSize": {strconv.Itoa(pageSize)}}
+		if err := c.do(ctx, "namecheap.domains.getList", params, &result); err != nil {
+			return nil, err
+		}
+		domains = append(domains, result.Domains...)
+		if len(result.Domains) < pageSize || len(domains) >= result.Total {
+			return domains, nil
+		}
+	}
+}
+
+func (c *Client) GetHosts(ctx context.Context, domain string) (Hosts, error) {
+	var result struct {
+		Hosts Hosts `xml:"DomainDNSGetHostsResult"`
+	}
+	if err := c.do(ctx, "namecheap.domains.dns.getHosts", domainParams(domain), &result); err != nil {
+		return Hosts{}, err
+	}
+	return result.Hosts, nil
+}
+
+func (c *Client) SetHosts(ctx context.Context, domain string, hosts Hosts) error {
+	params := domainParams(domain)
+	if hosts.EmailType != "" {
+		params.Set("EmailType", hosts.EmailType)
+	}
+	for i, host := range hosts.Hosts {
+		n := strconv.Itoa(i + 1)
+		params.Set("HostName"+n, host.Name)
+		params.Set("RecordType"+n, host.Type)
+		params.Set("Address"+n, host.Address)
+		params.Set("TTL"+n, strconv.Itoa(host.TTL))
+		if host.Type == "MX" {
+			params.Set("MXPref"+n, strconv.Itoa(host.MXPref))
+		}
+	}
+
+	var result struct {
+		Result struct {
+			IsSuccess bool `xml:"IsSuccess,attr"`
+		} `xml:"DomainDNSSetHostsResult"`
+	}
+	if err := c.do(ctx, "namecheap.domains.dns.setHosts", params, &result); err != nil {
+		return err
+	}
+	if !result.Result.IsSuccess {
+		return fmt.Errorf("namecheap: the records of domain %s were not set", domain)
+	}
+	return nil
+}
+
+// domainParams returns the parameters naming a domain, which Namecheap splits into its second-level domain and its
+// top-level domain, e.g. "example" and "co.uk".
+func domainParams(domain string) url.Values {
+	sld, tld, _ := strings.Cut(domain, ".")
+	return url.Values{"SLD": {sld}, "TLD": {tld}}
+}
+
+// do posts a command of the API, with the credentials, and decodes its command response into the result. The
+// parameters are posted as a form, the records of a domain not fitting in a query.
+func (c *Client) do(ctx context.Context, command string, params url.Values, result any) error {
+	form := url.Values{}
+	for k, v := range params {
+		form[k] = v
+	}
+	form.Set("ApiUser", c.apiUser)
+	form.Set("ApiKey", c.apiKey)
+	form.Set("UserName", c.userName)
+	form.Set("ClientIp", c.clientIP)
+	form.Set("Command", command)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", externaldns.UserAgent())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var response struct {
+		Status          string     `xml:"Status,attr"`
+		Errors          []APIError `xml:"Errors>Error"`
+		CommandResponse struct {
+			InnerXML []byte `xml:",innerxml"`
+		} `xml:"CommandResponse"`
+	}
+	if err := xml.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("namecheap: %s failed with HTTP status %s", command, resp.Status)
+	}
+	if response.Status != "OK" {
+		if len(response.Errors) > 0 {
+			return &response.Errors[0]
+		}
+		return fmt.Errorf("namecheap: %s failed with status %q", command, response.Status)
+	}
+	inner := append(append([]byte("<CommandResponse>"), response.CommandResponse.InnerXML...), "</CommandResponse>"...)
+	return xml.Unmarshal(inner, result)
+}
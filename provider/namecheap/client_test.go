@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namecheap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a client of a test server checking the credentials and the whitelisted IP of each command,
+// whose form is then handled by the handler.
+func newTestClient(t *testing.T, handler func(w http.ResponseWriter, form url.Values)) *Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		require.NoError(t, req.ParseForm())
+		assert.Equal(t, "apiuser", req.PostForm.Get("ApiUser"))
+		assert.Equal(t, "apikey", req.PostForm.Get("ApiKey"))
+		assert.Equal(t, "username", req.PostForm.Get("UserName"))
+		assert.Equal(t, "192.0.2.1", req.PostForm.Get("ClientIp"))
+		w.Header().Set("Content-Type", "text/xml")
+		handler(w, req.PostForm)
+	}))
+	t.Cleanup(server.Close)
+	client := NewClient("apiuser", "apikey", "username", "192.0.2.1", server.Client())
+	client.endpoint = server.URL
+	return client
+}
+
+// writeResponse writes a successful API response wrapping the command response.
+func writeResponse(w http.ResponseWriter, command, commandResponse string) {
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <Errors />
+  <RequestedCommand>%s</RequestedCommand>
+  <CommandResponse Type="%s">%s</CommandResponse>
+</ApiResponse>`, command, command, commandResponse)
+}
+
+func TestClientListDomainsPagination(t *testing.T) {
+	var pages []string
+	client := newTestClient(t, func(w http.ResponseWriter, form url.Values) {
+		assert.Equal(t, "namecheap.domains.getList", form.Get("Command"))
+		assert.Equal(t, "100", form.Get("PageSize"))
+		page := form.Get("Page")
+		pages = append(pages, page)
+		if page == "1" {
+			domains := make([]string, pageSize)
+			for i := range domains {
+				domains[i] = fmt.Sprintf(`<Domain ID="%d" Name="example%d.com" IsOurDNS="true" />`, i, i)
+			}
+			writeResponse(w, "namecheap.domains.getList", `<DomainGetListResult>`+strings.Join(domains, "")+
+				`</DomainGetListResult><Paging><TotalItems>101</TotalItems><CurrentPage>1</CurrentPage><PageSize>100</PageSize></Paging>`)
+			return
+		}
+		writeResponse(w, "namecheap.domains.getList", `<DomainGetListResult><Domain ID="100" Name="example.com" IsOurDNS="false" /></DomainGetListResult>`+
+			`<Paging><TotalItems>101</TotalItems><CurrentPage>2</CurrentPage><PageSize>100</PageSize></Paging>`)
+	})
+
+	domains, err := client.ListDomains(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, pages)
+	assert.Len(t, domains, pageSize+1)
+	assert.Equal(t, Domain{Name: "example0.com", IsOurDNS: true}, domains[0])
+	assert.Equal(t, Domain{Name: "example.com"}, domains[pageSize])
+}
+
+func TestClientGetHosts(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, form url.Values) {
+		assert.Equal(t, "namecheap.domains.dns.getHosts", form.Get("Command"))
+		assert.Equal(t, "example", form.Get("SLD"))
+		assert.Equal(t, "co.uk", form.Get("TLD"))
+		writeResponse(w, "namecheap.domains.dns.getHosts", `<DomainDNSGetHostsResult Domain="example.co.uk" EmailType="MX" IsUsingOurDNS="true">
+  <host HostId="1" Name="@" Type="MX" Address="mail.example.co.uk." MXPref="10" TTL="1800" />
+  <host HostId="2" Name="www" Type="A" Address="1.2.3.4" MXPref="10" TTL="60" />
+</DomainDNSGetHostsResult>`)
+	})
+
+	hosts, err := client.GetHosts(context.Background(), "example.co.uk")
+	require.NoError(t, err)
+	assert.Equal(t, Hosts{EmailType: "MX", Hosts: []Host{
+		{Name: "@", Type: "MX", Address: "mail.example.co.uk.", MXPref: 10, TTL: 1800},
+		{Name: "www", Type: "A", Address: "1.2.3.4", MXPref: 10, TTL: 60},
+	}}, hosts)
+}
+
+func TestClientSetHosts(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, form url.Values) {
+		assert.Equal(t, "namecheap.domains.dns.setHosts", form.Get("Command"))
+		assert.Equal(t, "example", form.Get("SLD"))
+		assert.Equal(t, "com", form.Get("TLD"))
+		assert.Equal(t, "MX", form.Get("EmailType"))
+		assert.Equal(t, "@", form.Get("HostName1"))
+		assert.Equal(t, "MX", form.Get("RecordType1"))
+		assert.Equal(t, "mail.example.com", form.Get("Address1"))
+		assert.Equal(t, "10", form.Get("MXPref1"))
+		assert.Equal(t, "1800", form.Get("TTL1"))
+		assert.Equal(t, "www", form.Get("HostName2"))
+		assert.Equal(t, "A", form.Get("RecordType2"))
+		assert.Equal(t, "1.2.3.4", form.Get("Address2"))
+		assert.Empty(t, form.Get("MXPref2"))
+		assert.Equal(t, "60", form.Get("TTL2"))
+		assert.Empty(t, form.Get("HostName3"))
+		writeResponse(w, "namecheap.domains.dns.setHosts", `<DomainDNSSetHostsResult Domain="example.com" IsSuccess="true" />`)
+	})
+
+	require.NoError(t, client.SetHosts(context.Background(), "example.com", Hosts{EmailType: "MX", Hosts: []Host{
+		{Name: "@", Type: "MX", Address: "mail.example.com", MXPref: 10, TTL: 1800},
+		{Name: "www", Type: "A", Address: "1.2.3.4", TTL: 60},
+	}}))
+}
+
+func TestClientAPIError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, form url.Values) {
+		fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="ERROR" xmlns="http://api.namecheap.com/xml.response">
+  <Errors>
+    <Error Number="1011150">Parameter RequestIP is invalid</Error>
+  </Errors>
+  <CommandResponse />
+</ApiResponse>`)
+	})
+
+	_, err := client.GetHosts(context.Background(), "example.com")
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "1011150", apiErr.Number)
+	assert.EqualError(t, err, "namecheap: error 1011150: Parameter RequestIP is invalid")
+}
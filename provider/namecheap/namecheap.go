@@ -0,0 +1,335 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namecheap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	// defaultTTL is the TTL of the records without a configured TTL, Namecheap's automatic TTL.
+	defaultTTL = 1799
+	// minTTL is the lowest TTL Namecheap accepts.
+	minTTL = 60
+	// emailTypeMX is the email type of the domains with MX records.
+	emailTypeMX = "MX"
+)
+
+// NamecheapProvider is an implementation of Provider for Namecheap's DNS.
+type NamecheapProvider struct {
+	provider.BaseProvider
+	Client DNSClient
+	// only consider hosted zones managing domains ending in this suffix
+	domainFilter endpoint.DomainFilter
+	DryRun       bool
+}
+
+// namecheapChange is a change of the records of a name and type, which are replaced by the hosts.
+type namecheapChange struct {
+	Action   string
+	Message  string
+	Name     string
+	Endpoint *endpoint.Endpoint
+	Hosts    []Host
+	// Prune is whether the existing hosts without a target are removed.
+	Prune bool
+}
+
+// NewNamecheapProvider initializes a new Namecheap DNS based Provider.
+func NewNamecheapProvider(domainFilter endpoint.DomainFilter, dryRun bool) (*NamecheapProvider, error) {
+	apiUser := os.Getenv("NAMECHEAP_API_USER")
+	if apiUser == "" {
+		return nil, fmt.Errorf("no API user found, set NAMECHEAP_API_USER")
+	}
+	apiKey := os.Getenv("NAMECHEAP_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("no API key found, set NAMECHEAP_API_KEY")
+	}
+	clientIP := os.Getenv("NAMECHEAP_CLIENT_IP")
+	if clientIP == "" {
+		return nil, fmt.Errorf("no client IP found, set NAMECHEAP_CLIENT_IP to the whitelisted address ExternalDNS connects from")
+	}
+	userName := os.Getenv("NAMECHEAP_USERNAME")
+	if userName == "" {
+		userName = apiUser
+	}
+
+	return &NamecheapProvider{
+		Client:       NewClient(apiUser, apiKey, userName, clientIP, &http.Client{Timeout: 30 * time.Second}),
+		domainFilter: domainFilter,
+		DryRun:       dryRun,
+	}, nil
+}
+
+// Zones returns the list of hosted zones, the domains using the Namecheap name servers.
+func (p *NamecheapProvider) Zones(ctx context.Context) ([]Domain, error) {
+	domains, err := p.Client.ListDomains(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list domains: %w", err)
+	}
+
+	var zones []Domain
+	for _, domain := range domains {
+		if domain.IsOurDNS && p.domainFilter.Match(domain.Name) {
+			zones = append(zones, domain)
+		}
+	}
+	return zones, nil
+}
+
+// SupportedRecordType returns true for the host record types external-dns manages on Namecheap. The URL redirect and
+// frame records of Namecheap, as well as its ALIAS records, are left alone.
+func (p *NamecheapProvider) SupportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT, endpoint.RecordTypeMX:
+		return true
+	default:
+		return false
+	}
+}
+
+// AdjustEndpoints raises the TTLs below the lowest TTL Namecheap accepts, for the records not to be updated on every
+// synchronization.
+func (p *NamecheapProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	for _, ep := range endpoints {
+		if ep.RecordTTL.IsConfigured() && ep.RecordTTL < minTTL {
+			ep.RecordTTL = minTTL
+		}
+	}
+	return endpoints, nil
+}
+
+// Records returns the list of records in all the domains. Namecheap returns a host per target, the hosts of a name and
+// type are merged into a single endpoint.
+func (p *NamecheapProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, zone := range zones {
+		hosts, err := p.Client.GetHosts(ctx, zone.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get records of domain %s: %w", zone.Name, err)
+		}
+
+		for _, h := range hosts.Hosts {
+			if !p.SupportedRecordType(h.Type) {
+				continue
+			}
+			endpoints = append(endpoints, endpoint.NewEndpointWithTTL(recordDNSName(zone.Name, h.Name), h.Type, endpoint.TTL(h.TTL), recordTarget(h)))
+		}
+	}
+	endpoints = provider.MergeEndpointsByNameType(endpoints)
+
+	log.WithFields(log.Fields{
+		"endpoints": endpoints,
+	}).Debug("Endpoints generated from Namecheap DNS")
+
+	return endpoints, nil
+}
+
+// ApplyChanges applies the given changes. Namecheap only replaces the records of a domain as a whole, the records of
+// each changed domain are fetched, the records of the changed names and types are replaced and the complete set is
+// submitted, keeping the records ExternalDNS doesn't manage.
+func (p *NamecheapProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return err
+	}
+
+	zoneNameIDMapper := provider.ZoneIDName{}
+	for _, zone := range zones {
+		zoneNameIDMapper.Add(zone.Name, zone.Name)
+	}
+
+	changesByZone := map[string][]namecheapChange{}
+	err = provider.ForEachEndpointTargets(changes, func(ep *endpoint.Endpoint, targets []string, prune bool) error {
+		zone, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zone == "" {
+			log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", ep.DNSName)
+			return nil
+		}
+		change := namecheapChange{Action: "Create", Message: "Creating records.", Name: recordName(zone, ep.DNSName), Endpoint: ep, Prune: prune}
+		switch {
+		case prune && targets == nil:
+			change.Action, change.Message = "Delete", "Deleting records."
+		case prune:
+			change.Action, change.Message = "Update", "Updating records."
+		}
+		for _, target := range targets {
+			host, err := newHost(change.Name, ep.RecordType, target)
+			if err != nil {
+				return fmt.Errorf("invalid target %q of %s record %s: %w", target, ep.RecordType, ep.DNSName, err)
+			}
+			change.Hosts = append(change.Hosts, host)
+		}
+		changesByZone[zone] = append(changesByZone[zone], change)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.submitChanges(ctx, changesByZone)
+}
+
+// submitChanges replaces the records of each changed domain.
+func (p *NamecheapProvider) submitChanges(ctx context.Context, changesByZone map[string][]namecheapChange) error {
+	zones := make([]string, 0, len(changesByZone))
+	for zone := range changesByZone {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+
+	for _, zone := range zones {
+		hosts, err := p.Client.GetHosts(ctx, zone)
+		if err != nil {
+			return fmt.Errorf("failed to get records of domain %s: %w", zone, err)
+		}
+
+		for _, change := range changesByZone[zone] {
+			logFields := log.Fields{
+				"record":   change.Endpoint.DNSName,
+				"type":     change.Endpoint.RecordType,
+				"targets":  change.Endpoint.Targets,
+				"ttl":      change.Endpoint.RecordTTL,
+				"action":   change.Action,
+				"zoneName": zone,
+			}
+			log.WithFields(logFields).Info(change.Message)
+			hosts.Hosts = replaceHosts(hosts.Hosts, change)
+		}
+		hosts.EmailType = emailType(hosts)
+
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.SetHosts(ctx, zone, hosts); err != nil {
+			return fmt.Errorf("failed to set records of domain %s: %w", zone, err)
+		}
+	}
+
+	return nil
+}
+
+// replaceHosts replaces the hosts of the change's name and type, the existing hosts being kept unless pruning. The TTL of
+// the replaced hosts is kept when the endpoint's TTL isn't configured.
+func replaceHosts(hosts []Host, change namecheapChange) []Host {
+	ttl := defaultTTL
+	if change.Endpoint.RecordTTL.IsConfigured() {
+		ttl = int(change.Endpoint.RecordTTL)
+	}
+
+	replaced := make([]Host, 0, len(hosts)+len(change.Hosts))
+	existing := map[string]bool{}
+	for _, h := range hosts {
+		if h.Type == change.Endpoint.RecordType && strings.EqualFold(h.Name, change.Name) {
+			if !change.Endpoint.RecordTTL.IsConfigured() {
+				ttl = h.TTL
+			}
+			if change.Prune {
+				continue
+			}
+			existing[recordTarget(h)] = true
+		}
+		replaced = append(replaced, h)
+	}
+	for _, h := range change.Hosts {
+		if existing[recordTarget(h)] {
+			continue
+		}
+		h.TTL = ttl
+		replaced = append(replaced, h)
+	}
+	return replaced
+}
+
+// emailType returns the email type of a domain, which must be MX for its MX records to be kept. The email type of a
+// domain whose MX records are all gone is reset.
+func emailType(hosts Hosts) string {
+	for _, h := range hosts.Hosts {
+		if h.Type == endpoint.RecordTypeMX {
+			return emailTypeMX
+		}
+	}
+	if hosts.EmailType == emailTypeMX {
+		return "NONE"
+	}
+	return hosts.EmailType
+}
+
+// recordName returns the name of a record relative to its domain, "@" at the apex.
+func recordName(zone, dnsName string) string {
+	if dnsName == zone {
+		return "@"
+	}
+	return strings.TrimSuffix(dnsName, "."+zone)
+}
+
+// recordDNSName returns the DNS name of a record of a domain.
+func recordDNSName(zone, name string) string {
+	if name == "" || name == "@" {
+		return zone
+	}
+	return name + "." + zone
+}
+
+// recordTarget returns the endpoint target of a record, the priority of MX records is stored apart from their
+// address. Namecheap returns the names fully qualified.
+func recordTarget(h Host) string {
+	switch h.Type {
+	case endpoint.RecordTypeMX:
+		return fmt.Sprintf("%d %s", h.MXPref, strings.TrimSuffix(h.Address, "."))
+	case endpoint.RecordTypeCNAME:
+		return strings.TrimSuffix(h.Address, ".")
+	}
+	return h.Address
+}
+
+// newHost returns the record of an endpoint target. MX targets are of the form "10 mail.example.com", the priority
+// coming first.
+func newHost(name, recordType, target string) (Host, error) {
+	host := Host{Name: name, Type: recordType, Address: target}
+	if recordType == endpoint.RecordTypeMX {
+		priorityRaw, address, ok := strings.Cut(target, " ")
+		if !ok {
+			return Host{}, fmt.Errorf("the priority is missing")
+		}
+		priority, err := strconv.Atoi(priorityRaw)
+		if err != nil {
+			return Host{}, fmt.Errorf("invalid priority %q", priorityRaw)
+		}
+		host.MXPref = priority
+		host.Address = address
+	}
+	return host, nil
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namecheap
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// fakeNamecheapAPI is an in-memory Namecheap XML API.
+type fakeNamecheapAPI struct {
+	mu      sync.Mutex
+	domains []Domain
+	hosts   map[string]Hosts
+	writes  int
+}
+
+func newFakeNamecheapAPI() *fakeNamecheapAPI {
+	return &fakeNamecheapAPI{
+		domains: []Domain{
+			{Name: "example.com", IsOurDNS: true},
+			{Name: "example.org", IsOurDNS: true},
+			{Name: "example.net"},
+		},
+		hosts: map[string]Hosts{
+			"example.com": {EmailType: "MX", Hosts: []Host{
+				{Name: "@", Type: "URL301", Address: "https://www.example.com", TTL: 1800},
+				{Name: "api", Type: "A", Address: "1.2.3.4", TTL: 300},
+				{Name: "api", Type: "A", Address: "1.2.3.5", TTL: 300},
+				{Name: "api", Type: "TXT", Address: "heritage=external-dns", TTL: 300},
+				{Name: "@", Type: "MX", Address: "mail.example.com.", MXPref: 10, TTL: 1800},
+			}},
+			"example.org": {EmailType: "FWD", Hosts: []Host{
+				{Name: "www", Type: "CNAME", Address: "example.com.", TTL: 1799},
+			}},
+		},
+	}
+}
+
+func (f *fakeNamecheapAPI) handle(w http.ResponseWriter, form url.Values) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	command := form.Get("Command")
+	domain := form.Get("SLD") + "." + form.Get("TLD")
+	var response any
+	switch command {
+	case "namecheap.domains.getList":
+		response = struct {
+			XMLName xml.Name `xml:"DomainGetListResult"`
+			Domains []Domain `xml:"Domain"`
+		}{Domains: f.domains}
+	case "namecheap.domains.dns.getHosts":
+		response = struct {
+			XMLName xml.Name `xml:"DomainDNSGetHostsResult"`
+			Hosts
+		}{Hosts: f.hosts[domain]}
+	case "namecheap.domains.dns.setHosts":
+		hosts := Hosts{EmailType: form.Get("EmailType")}
+		for i := 1; form.Has("HostName" + strconv.Itoa(i)); i++ {
+			n := strconv.Itoa(i)
+			mxPref, _ := strconv.Atoi(form.Get("MXPref" + n))
+			ttl, _ := strconv.Atoi(form.Get("TTL" + n))
+			address := form.Get("Address" + n)
+			// Namecheap qualifies the names
+			if t := form.Get("RecordType" + n); (t == "CNAME" || t == "MX") && !strings.HasSuffix(address, ".") {
+				address += "."
+			}
+			hosts.Hosts = append(hosts.Hosts, Host{Name: form.Get("HostName" + n), Type: form.Get("RecordType" + n), Address: address, MXPref: mxPref, TTL: ttl})
+		}
+		f.hosts[domain] = hosts
+		f.writes++
+		response = struct {
+			XMLName   xml.Name `xml:"DomainDNSSetHostsResult"`
+			IsSuccess bool     `xml:"IsSuccess,attr"`
+		}{IsSuccess: true}
+	}
+
+	b, err := xml.Marshal(response)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	fmt.Fprintf(w, `<ApiResponse Status="OK"><Errors /><CommandResponse Type="%s">%s</CommandResponse></ApiResponse>`, command, b)
+}
+
+func newTestProvider(t *testing.T, api *fakeNamecheapAPI, domains ...string) *NamecheapProvider {
+	return &NamecheapProvider{
+		Client:       newTestClient(t, api.handle),
+		domainFilter: endpoint.NewDomainFilter(domains),
+	}
+}
+
+func TestNewNamecheapProvider(t *testing.T) {
+	t.Setenv("NAMECHEAP_API_USER", "apiuser")
+	t.Setenv("NAMECHEAP_API_KEY", "apikey")
+	t.Setenv("NAMECHEAP_CLIENT_IP", "192.0.2.1")
+	p, err := NewNamecheapProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.NoError(t, err)
+	assert.Equal(t, "apiuser", p.Client.(*Client).userName)
+
+	t.Setenv("NAMECHEAP_CLIENT_IP", "")
+	_, err = NewNamecheapProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.EqualError(t, err, "no client IP found, set NAMECHEAP_CLIENT_IP to the whitelisted address ExternalDNS connects from")
+
+	t.Setenv("NAMECHEAP_API_KEY", "")
+	_, err = NewNamecheapProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.EqualError(t, err, "no API key found, set NAMECHEAP_API_KEY")
+}
+
+func TestNamecheapProviderRecords(t *testing.T) {
+	p := newTestProvider(t, newFakeNamecheapAPI(), "example.com", "example.net")
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5"),
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeTXT, 300, "heritage=external-dns"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 1800, "10 mail.example.com"),
+	}, endpoints)
+}
+
+func TestNamecheapProviderApplyChanges(t *testing.T) {
+	api := newFakeNamecheapAPI()
+	p := newTestProvider(t, api, "example.com", "example.org")
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeAAAA, "2001:db8::1", "2001:db8::2"),
+			endpoint.NewEndpointWithTTL("example.org", endpoint.RecordTypeMX, 3600, "10 mail.example.org"),
+			// not in a managed zone
+			endpoint.NewEndpoint("www.example.net", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeA, "1.2.3.5", "1.2.3.6"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "10 mail.example.com"),
+			endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeCNAME, "example.com"),
+		},
+	}))
+
+	// a single request replaces the records of each domain
+	assert.Equal(t, 2, api.writes)
+	assert.ElementsMatch(t, []Host{
+		{Name: "@", Type: "URL301", Address: "https://www.example.com", TTL: 1800},
+		{Name: "api", Type: "TXT", Address: "heritage=external-dns", TTL: 300},
+		{Name: "api", Type: "A", Address: "1.2.3.5", TTL: 300},
+		{Name: "api", Type: "A", Address: "1.2.3.6", TTL: 300},
+		{Name: "app", Type: "AAAA", Address: "2001:db8::1", TTL: defaultTTL},
+		{Name: "app", Type: "AAAA", Address: "2001:db8::2", TTL: defaultTTL},
+	}, api.hosts["example.com"].Hosts)
+	// the MX records of example.com are gone
+	assert.Equal(t, "NONE", api.hosts["example.com"].EmailType)
+	assert.Equal(t, Hosts{EmailType: "MX", Hosts: []Host{
+		{Name: "@", Type: "MX", Address: "mail.example.org.", MXPref: 10, TTL: 3600},
+	}}, api.hosts["example.org"])
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 300, "1.2.3.5", "1.2.3.6"),
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeTXT, 300, "heritage=external-dns"),
+		endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeAAAA, defaultTTL, "2001:db8::1", "2001:db8::2"),
+		endpoint.NewEndpointWithTTL("example.org", endpoint.RecordTypeMX, 3600, "10 mail.example.org"),
+	}, endpoints)
+}
+
+func TestNamecheapProviderApplyChangesCreateKeepsRecords(t *testing.T) {
+	api := newFakeNamecheapAPI()
+	p := newTestProvider(t, api, "example.com")
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeA, "1.2.3.5", "1.2.3.6")},
+	}))
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, endpoints, endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5", "1.2.3.6"))
+}
+
+func TestNamecheapProviderApplyChangesDryRun(t *testing.T) {
+	api := newFakeNamecheapAPI()
+	p := newTestProvider(t, api, "example.com")
+	p.DryRun = true
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeA, "1.2.3.4", "1.2.3.5")},
+	}))
+	assert.Zero(t, api.writes)
+}
+
+func TestNamecheapProviderApplyChangesInvalidTarget(t *testing.T) {
+	api := newFakeNamecheapAPI()
+	p := newTestProvider(t, api, "example.com")
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "mail.example.com")},
+	})
+	require.EqualError(t, err, `invalid target "mail.example.com" of MX record example.com: the priority is missing`)
+	assert.Zero(t, api.writes)
+}
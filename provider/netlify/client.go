@@ -0,0 +1,182 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package netlify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+const (
+	// defaultEndpoint is the base URL of the Netlify API.
+	defaultEndpoint = "https://api.netlify.com/api/v1"
+	// perPage is the page size of the listings.
+	perPage = 100
+)
+
+// DNSZone is a DNS zone of the account.
+type DNSZone struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// DNSRecord is a DNS record of a zone. The records are flat within a zone, a record holds a single value.
+type DNSRecord struct {
+	ID       string `json:"id"`
+	Hostname string `json:"hostname"`
+	Type     string `json:"type"`
+	Value    string `json:"value"`
+	TTL      int    `json:"ttl"`
+	Priority int    `json:"priority"`
+	// Managed is set on the records Netlify manages for its sites, which can't be changed.
+	Managed bool `json:"managed"`
+}
+
+// DNSRecordCreate is the body of the requests creating a record.
+type DNSRecordCreate struct {
+	Hostname string `json:"hostname"`
+	Type     string `json:"type"`
+	Value    string `json:"value"`
+	TTL      int    `json:"ttl,omitempty"`
+	Priority *int   `json:"priority,omitempty"`
+}
+
+// APIError is an error returned by the Netlify API.
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Message    string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("netlify: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// DNSClient is the interface of the Netlify DNS API used by the provider.
+type DNSClient interface {
+	ListDNSZones(ctx context.Context) ([]DNSZone, error)
+	ListDNSRecords(ctx context.Context, zoneID string) ([]DNSRecord, error)
+	CreateDNSRecord(ctx context.Context, zoneID string, record DNSRecordCreate) error
+	DeleteDNSRecord(ctx context.Context, zoneID string, recordID string) error
+}
+
+// Client calls the Netlify API with a personal access token.
+type Client struct {
+	token      string
+	endpoint   string
+	httpClient *http.Client
+}
+
+// NewClient returns a client of the Netlify API authenticating with the token.
+func NewClient(token string, httpClient *http.Client) *Client {
+	return &Client{
+		token:      token,
+		endpoint:   defaultEndpoint,
+		httpClient: httpClient,
+	}
+}
+
+func (c *Client) ListDNSZones(ctx context.Context) ([]DNSZone, error) {
+	var zones []DNSZone
+	for page := 1; ; page++ {
+		var items []DNSZone
+		if err := c.do(ctx, http.MethodGet, "/dns_zones", listQuery(page), nil, &items); err != nil {
+			return nil, err
+		}
+		zones = append(zones, items...)
+		if len(items) < perPage {
+			return zones, nil
+		}
+	}
+}
+
+func (c *Client) ListDNSRecords(ctx context.Context, zoneID string) ([]DNSRecord, error) {
+	var records []DNSRecord
+	for page := 1; ; page++ {
+		var items []DNSRecord
+		if err := c.do(ctx, http.MethodGet, "/dns_zones/"+url.PathEscape(zoneID)+"/dns_records", listQuery(page), nil, &items); err != nil {
+			return nil, err
+		}
+		records = append(records, items...)
+		if len(items) < perPage {
+			return records, nil
+		}
+	}
+}
+
+func (c *Client) CreateDNSRecord(ctx context.Context, zoneID string, record DNSRecordCreate) error {
+	return c.do(ctx, http.MethodPost, "/dns_zones/"+url.PathEscape(zoneID)+"/dns_records", nil, record, nil)
+}
+
+func (c *Client) DeleteDNSRecord(ctx context.Context, zoneID string, recordID string) error {
+	return c.do(ctx, http.MethodDelete, "/dns_zones/"+url.PathEscape(zoneID)+"/dns_records/"+url.PathEscape(recordID), nil, nil, nil)
+}
+
+func listQuery(page int) url.Values {
+	return url.Values{"page": {strconv.Itoa(page)}, "per_page": {strconv.Itoa(perPage)}}
+}
+
+// do sends a request to the API, with the body encoded as JSON, and decodes the response into the result.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, result any) error {
+	u := c.endpoint + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", externaldns.UserAgent())
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		_ = json.NewDecoder(resp.Body).Decode(apiErr)
+		return apiErr
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package netlify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTripFunc is an http.RoundTripper answering the requests with a function.
+type roundTripFunc func(req *http.Request) *http.Response
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestClientListDNSRecordsPagination(t *testing.T) {
+	var pages []string
+	client := NewClient("secret", &http.Client{Transport: roundTripFunc(func(req *http.Request) *http.Response {
+		assert.Equal(t, "Bearer secret", req.Header.Get("Authorization"))
+		assert.Equal(t, "/api/v1/dns_zones/example_com/dns_records", req.URL.Path)
+		assert.Equal(t, "100", req.URL.Query().Get("per_page"))
+		page := req.URL.Query().Get("page")
+		pages = append(pages, page)
+		if page == "1" {
+			items := make([]string, perPage)
+			for i := range items {
+				items[i] = fmt.Sprintf(`{"id":"%d","hostname":"host%d.example.com","type":"A","value":"1.2.3.4","ttl":3600}`, i, i)
+			}
+			return jsonResponse(http.StatusOK, "["+strings.Join(items, ",")+"]")
+		}
+		return jsonResponse(http.StatusOK, `[{"id":"mx","hostname":"example.com","type":"MX","value":"mail.example.com","ttl":3600,"priority":10,"managed":false}]`)
+	})})
+
+	records, err := client.ListDNSRecords(context.Background(), "example_com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, pages)
+	assert.Len(t, records, perPage+1)
+	assert.Equal(t, DNSRecord{ID: "mx", Hostname: "example.com", Type: "MX", Value: "mail.example.com", TTL: 3600, Priority: 10}, records[perPage])
+}
+
+func TestClientCreateDNSRecord(t *testing.T) {
+	client := NewClient("secret", &http.Client{Transport: roundTripFunc(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "/api/v1/dns_zones/example_com/dns_records", req.URL.Path)
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"hostname":"example.com","type":"MX","value":"mail.example.com","ttl":3600,"priority":10}`, string(body))
+		return jsonResponse(http.StatusCreated, `{"id":"1"}`)
+	})})
+
+	priority := 10
+	require.NoError(t, client.CreateDNSRecord(context.Background(), "example_com", DNSRecordCreate{Hostname: "example.com", Type: "MX", Value: "mail.example.com", TTL: 3600, Priority: &priority}))
+}
+
+func TestClientAPIError(t *testing.T) {
+	client := NewClient("invalid", &http.Client{Transport: roundTripFunc(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodDelete, req.Method)
+		assert.Equal(t, "/api/v1/dns_zones/example_com/dns_records/1", req.URL.Path)
+		return jsonResponse(http.StatusUnauthorized, `{"code":401,"message":"Access Denied"}`)
+	})})
+
+	err := client.DeleteDNSRecord(context.Background(), "example_com", "1")
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	assert.EqualError(t, err, "netlify: 401 Unauthorized: Access Denied")
+}
@@ -0,0 +1,282 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package netlify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// defaultTTL is the TTL of the records without a configured TTL.
+const defaultTTL = 3600
+
+// NetlifyProvider is an implementation of Provider for Netlify DNS.
+type NetlifyProvider struct {
+	provider.BaseProvider
+	Client DNSClient
+	// only consider hosted zones managing domains ending in this suffix
+	domainFilter endpoint.DomainFilter
+	DryRun       bool
+}
+
+type netlifyChangeCreate struct {
+	Zone    DNSZone
+	Options DNSRecordCreate
+}
+
+type netlifyChangeDelete struct {
+	Zone   DNSZone
+	Record DNSRecord
+}
+
+// netlifyChanges contains all changes to apply to DNS. Netlify records can't be updated, a record is replaced by
+// deleting it and creating it again.
+type netlifyChanges struct {
+	Creates []netlifyChangeCreate
+	Deletes []netlifyChangeDelete
+}
+
+// NewNetlifyProvider initializes a new Netlify DNS based Provider.
+func NewNetlifyProvider(domainFilter endpoint.DomainFilter, dryRun bool) (*NetlifyProvider, error) {
+	token := os.Getenv("NETLIFY_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("no access token found, set NETLIFY_TOKEN")
+	}
+
+	return &NetlifyProvider{
+		Client:       NewClient(token, &http.Client{Timeout: 30 * time.Second}),
+		domainFilter: domainFilter,
+		DryRun:       dryRun,
+	}, nil
+}
+
+// Zones returns the list of hosted zones.
+func (p *NetlifyProvider) Zones(ctx context.Context) ([]DNSZone, error) {
+	zones, err := p.Client.ListDNSZones(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DNS zones: %w", err)
+	}
+
+	var filtered []DNSZone
+	for _, zone := range zones {
+		if p.domainFilter.Match(zone.Name) {
+			filtered = append(filtered, zone)
+		}
+	}
+	return filtered, nil
+}
+
+// SupportedRecordType returns true for the record types external-dns manages on Netlify DNS. Netlify's own NETLIFY
+// records, pointing the domain at a site, are left alone.
+func (p *NetlifyProvider) SupportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT, endpoint.RecordTypeMX:
+		return true
+	default:
+		return false
+	}
+}
+
+// Records returns the list of records in all the zones. Netlify stores a record per target, the records of a name and
+// type are merged into a single endpoint, and the records managed by Netlify are left out.
+func (p *NetlifyProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, zone := range zones {
+		records, err := p.Client.ListDNSRecords(ctx, zone.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list records of DNS zone %s: %w", zone.Name, err)
+		}
+
+		for _, r := range records {
+			if r.Managed || !p.SupportedRecordType(r.Type) {
+				continue
+			}
+			endpoints = append(endpoints, endpoint.NewEndpointWithTTL(r.Hostname, r.Type, endpoint.TTL(r.TTL), recordTarget(r)))
+		}
+	}
+	endpoints = provider.MergeEndpointsByNameType(endpoints)
+
+	log.WithFields(log.Fields{
+		"endpoints": endpoints,
+	}).Debug("Endpoints generated from Netlify DNS")
+
+	return endpoints, nil
+}
+
+// ApplyChanges applies the given changes, listing the records of a zone once for all its changes.
+func (p *NetlifyProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return err
+	}
+
+	zoneNameIDMapper := provider.ZoneIDName{}
+	zonesByID := map[string]DNSZone{}
+	for _, zone := range zones {
+		zoneNameIDMapper.Add(zone.ID, zone.Name)
+		zonesByID[zone.ID] = zone
+	}
+
+	recordsByZone := map[string][]DNSRecord{}
+	records := func(zone DNSZone) ([]DNSRecord, error) {
+		if r, ok := recordsByZone[zone.ID]; ok {
+			return r, nil
+		}
+		r, err := p.Client.ListDNSRecords(ctx, zone.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list records of DNS zone %s: %w", zone.Name, err)
+		}
+		recordsByZone[zone.ID] = r
+		return r, nil
+	}
+
+	var netlifyChanges netlifyChanges
+	err = provider.ForEachEndpointTargets(changes, func(ep *endpoint.Endpoint, targets []string, prune bool) error {
+		zoneID, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zoneID == "" {
+			log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", ep.DNSName)
+			return nil
+		}
+		zone := zonesByID[zoneID]
+		existing, err := records(zone)
+		if err != nil {
+			return err
+		}
+		return netlifyChanges.add(zone, existing, ep, targets, prune)
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.submitChanges(ctx, netlifyChanges)
+}
+
+// add adds the changes turning the zone's records of the endpoint's name and type into a record per target. Netlify
+// records can't be updated, a record whose TTL changes is deleted and created again.
+func (c *netlifyChanges) add(zone DNSZone, existing []DNSRecord, ep *endpoint.Endpoint, targets []string, prune bool) error {
+	current := slices.DeleteFunc(slices.Clone(existing), func(r DNSRecord) bool {
+		return r.Managed || r.Hostname != ep.DNSName || r.Type != ep.RecordType
+	})
+	diff := provider.DiffRecords(current, targets, prune, recordTarget, func(r DNSRecord) bool {
+		return ep.RecordTTL.IsConfigured() && r.TTL != int(ep.RecordTTL)
+	})
+
+	var replaced []string
+	for _, update := range diff.Update {
+		c.Deletes = append(c.Deletes, netlifyChangeDelete{Zone: zone, Record: update.Record})
+		replaced = append(replaced, update.Target)
+	}
+	for _, target := range append(replaced, diff.Create...) {
+		options, err := newDNSRecordCreate(ep.DNSName, ep.RecordType, target, ep.RecordTTL)
+		if err != nil {
+			return fmt.Errorf("invalid target %q of %s record %s: %w", target, ep.RecordType, ep.DNSName, err)
+		}
+		c.Creates = append(c.Creates, netlifyChangeCreate{Zone: zone, Options: options})
+	}
+	for _, record := range diff.Delete {
+		c.Deletes = append(c.Deletes, netlifyChangeDelete{Zone: zone, Record: record})
+	}
+	return nil
+}
+
+// submitChanges submits the changes to the Netlify API one record at a time. The deletions come first, both for the
+// replaced records and for a CNAME record to take the place of the other records of its name.
+func (p *NetlifyProvider) submitChanges(ctx context.Context, changes netlifyChanges) error {
+	for _, change := range changes.Deletes {
+		logFields := log.Fields{
+			"record":   change.Record.Hostname,
+			"type":     change.Record.Type,
+			"target":   change.Record.Value,
+			"action":   "Delete",
+			"zoneName": change.Zone.Name,
+		}
+		log.WithFields(logFields).Info("Deleting record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.DeleteDNSRecord(ctx, change.Zone.ID, change.Record.ID); err != nil {
+			return fmt.Errorf("failed to delete %s record %s: %w", change.Record.Type, change.Record.Hostname, err)
+		}
+	}
+
+	for _, change := range changes.Creates {
+		logFields := log.Fields{
+			"record":   change.Options.Hostname,
+			"type":     change.Options.Type,
+			"target":   change.Options.Value,
+			"ttl":      change.Options.TTL,
+			"action":   "Create",
+			"zoneName": change.Zone.Name,
+		}
+		log.WithFields(logFields).Info("Creating record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.CreateDNSRecord(ctx, change.Zone.ID, change.Options); err != nil {
+			return fmt.Errorf("failed to create %s record %s: %w", change.Options.Type, change.Options.Hostname, err)
+		}
+	}
+
+	return nil
+}
+
+// recordTarget returns the endpoint target of a record, the priority of MX records is stored apart from their value.
+func recordTarget(r DNSRecord) string {
+	if r.Type == endpoint.RecordTypeMX {
+		return fmt.Sprintf("%d %s", r.Priority, r.Value)
+	}
+	return r.Value
+}
+
+// newDNSRecordCreate returns the request creating the record of an endpoint target. MX targets are of the form
+// "10 mail.example.com", the priority coming first.
+func newDNSRecordCreate(hostname, recordType, target string, ttl endpoint.TTL) (DNSRecordCreate, error) {
+	request := DNSRecordCreate{Hostname: hostname, Type: recordType, Value: target, TTL: defaultTTL}
+	if ttl.IsConfigured() {
+		request.TTL = int(ttl)
+	}
+	if recordType == endpoint.RecordTypeMX {
+		priorityRaw, value, ok := strings.Cut(target, " ")
+		if !ok {
+			return DNSRecordCreate{}, fmt.Errorf("the priority is missing")
+		}
+		priority, err := strconv.Atoi(priorityRaw)
+		if err != nil {
+			return DNSRecordCreate{}, fmt.Errorf("invalid priority %q", priorityRaw)
+		}
+		request.Priority = &priority
+		request.Value = value
+	}
+	return request, nil
+}
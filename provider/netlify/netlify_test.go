@@ -0,0 +1,213 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package netlify
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// mockDNSClient is an in-memory Netlify DNS API client.
+type mockDNSClient struct {
+	zones   []DNSZone
+	records map[string][]DNSRecord
+	nextID  int
+	creates int
+	deletes int
+}
+
+func (m *mockDNSClient) ListDNSZones(context.Context) ([]DNSZone, error) {
+	return m.zones, nil
+}
+
+func (m *mockDNSClient) ListDNSRecords(_ context.Context, zoneID string) ([]DNSRecord, error) {
+	return slices.Clone(m.records[zoneID]), nil
+}
+
+func (m *mockDNSClient) CreateDNSRecord(_ context.Context, zoneID string, record DNSRecordCreate) error {
+	m.nextID++
+	m.creates++
+	created := DNSRecord{ID: strconv.Itoa(m.nextID), Hostname: record.Hostname, Type: record.Type, Value: record.Value, TTL: record.TTL}
+	if record.Priority != nil {
+		created.Priority = *record.Priority
+	}
+	m.records[zoneID] = append(m.records[zoneID], created)
+	return nil
+}
+
+func (m *mockDNSClient) DeleteDNSRecord(_ context.Context, zoneID string, recordID string) error {
+	i := slices.IndexFunc(m.records[zoneID], func(r DNSRecord) bool { return r.ID == recordID })
+	if i < 0 {
+		return &APIError{StatusCode: http.StatusNotFound, Message: "Not Found"}
+	}
+	m.deletes++
+	m.records[zoneID] = slices.Delete(m.records[zoneID], i, i+1)
+	return nil
+}
+
+func newMockDNSClient() *mockDNSClient {
+	return &mockDNSClient{
+		zones: []DNSZone{
+			{ID: "example_com", Name: "example.com"},
+			{ID: "example_org", Name: "example.org"},
+		},
+		records: map[string][]DNSRecord{
+			"example_com": {
+				{ID: "1", Hostname: "example.com", Type: "NETLIFY", Value: "example.netlify.app", TTL: 3600, Managed: true},
+				{ID: "2", Hostname: "www.example.com", Type: "A", Value: "75.2.60.5", TTL: 3600, Managed: true},
+				{ID: "3", Hostname: "api.example.com", Type: "A", Value: "1.2.3.4", TTL: 300},
+				{ID: "4", Hostname: "api.example.com", Type: "A", Value: "1.2.3.5", TTL: 300},
+				{ID: "5", Hostname: "api.example.com", Type: "TXT", Value: "heritage=external-dns", TTL: 300},
+				{ID: "6", Hostname: "example.com", Type: "MX", Value: "mail.example.com", Priority: 10, TTL: 3600},
+			},
+			"example_org": {
+				{ID: "7", Hostname: "www.example.org", Type: "CNAME", Value: "example.com", TTL: 3600},
+			},
+		},
+		nextID: 100,
+	}
+}
+
+func newTestProvider(client DNSClient, domains ...string) *NetlifyProvider {
+	return &NetlifyProvider{Client: client, domainFilter: endpoint.NewDomainFilter(domains)}
+}
+
+func TestNewNetlifyProvider(t *testing.T) {
+	t.Setenv("NETLIFY_TOKEN", "secret")
+	_, err := NewNetlifyProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.NoError(t, err)
+
+	t.Setenv("NETLIFY_TOKEN", "")
+	_, err = NewNetlifyProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.EqualError(t, err, "no access token found, set NETLIFY_TOKEN")
+}
+
+func TestNetlifyProviderRecords(t *testing.T) {
+	p := newTestProvider(newMockDNSClient(), "example.com")
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5"),
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeTXT, 300, "heritage=external-dns"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+	}, endpoints)
+}
+
+func TestNetlifyProviderApplyChanges(t *testing.T) {
+	client := newMockDNSClient()
+	p := newTestProvider(client, "example.com", "example.org")
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeAAAA, "2001:db8::1", "2001:db8::2"),
+			endpoint.NewEndpointWithTTL("example.org", endpoint.RecordTypeTXT, 600, "v=spf1 -all"),
+			// not in a managed zone
+			endpoint.NewEndpoint("www.example.net", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5"),
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 300, "1.2.3.5", "1.2.3.6"),
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 900, "10 mail.example.com", "20 backup.example.com"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeTXT, "heritage=external-dns"),
+			endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeCNAME, "example.com"),
+		},
+	}))
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 300, "1.2.3.5", "1.2.3.6"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 900, "10 mail.example.com", "20 backup.example.com"),
+		endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeAAAA, defaultTTL, "2001:db8::1", "2001:db8::2"),
+		endpoint.NewEndpointWithTTL("example.org", endpoint.RecordTypeTXT, 600, "v=spf1 -all"),
+	}, endpoints)
+	// the unchanged target of api.example.com is kept
+	assert.Contains(t, client.records["example_com"], DNSRecord{ID: "4", Hostname: "api.example.com", Type: "A", Value: "1.2.3.5", TTL: 300})
+	// the records managed by Netlify are left alone
+	assert.Contains(t, client.records["example_com"], DNSRecord{ID: "2", Hostname: "www.example.com", Type: "A", Value: "75.2.60.5", TTL: 3600, Managed: true})
+	// the MX record whose TTL changed is replaced
+	assert.Equal(t, 4, client.deletes)
+	assert.Equal(t, 6, client.creates)
+}
+
+func TestNetlifyProviderApplyChangesDryRun(t *testing.T) {
+	client := newMockDNSClient()
+	p := newTestProvider(client, "example.com")
+	p.DryRun = true
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeA, "1.2.3.4", "1.2.3.5")},
+	}))
+	assert.Zero(t, client.creates)
+	assert.Zero(t, client.deletes)
+}
+
+func TestNetlifyProviderApplyChangesManagedRecord(t *testing.T) {
+	client := newMockDNSClient()
+	p := newTestProvider(client, "example.com")
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "75.2.60.5")},
+	}))
+	assert.Zero(t, client.deletes)
+}
+
+func TestNetlifyProviderApplyChangesInvalidTarget(t *testing.T) {
+	client := newMockDNSClient()
+	p := newTestProvider(client, "example.com")
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "mail.example.com")},
+	})
+	require.EqualError(t, err, `invalid target "mail.example.com" of MX record example.com: the priority is missing`)
+	assert.Zero(t, client.creates)
+}
+
+func TestNetlifyProviderApplyChangesError(t *testing.T) {
+	client := newMockDNSClient()
+	p := newTestProvider(&failingDeleteClient{DNSClient: client}, "example.com")
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeTXT, "heritage=external-dns")},
+	})
+	require.EqualError(t, err, "failed to delete TXT record api.example.com: netlify: 500 Internal Server Error: boom")
+}
+
+// failingDeleteClient fails the deletion of the records.
+type failingDeleteClient struct {
+	DNSClient
+}
+
+func (c *failingDeleteClient) DeleteDNSRecord(context.Context, string, string) error {
+	return &APIError{StatusCode: http.StatusInternalServerError, Message: "boom"}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"maps"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// FromEndpoints converts endpoints to the Endpoint messages of provider.proto.
+func FromEndpoints(endpoints []*endpoint.Endpoint) []*Endpoint {
+	if endpoints == nil {
+		return nil
+	}
+	messages := make([]*Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		m := &Endpoint{
+			DnsName:       ep.DNSName,
+			Targets:       ep.Targets,
+			RecordType:    ep.RecordType,
+			SetIdentifier: ep.SetIdentifier,
+			RecordTtl:     int64(ep.RecordTTL),
+			Labels:        maps.Clone(ep.Labels),
+		}
+		for _, ps := range ep.ProviderSpecific {
+			m.ProviderSpecific = append(m.ProviderSpecific, &ProviderSpecificProperty{Name: ps.Name, Value: ps.Value})
+		}
+		messages = append(messages, m)
+	}
+	return messages
+}
+
+// ToEndpoints converts the Endpoint messages of provider.proto to endpoints, which always have labels.
+func ToEndpoints(messages []*Endpoint) []*endpoint.Endpoint {
+	if messages == nil {
+		return nil
+	}
+	endpoints := make([]*endpoint.Endpoint, 0, len(messages))
+	for _, m := range messages {
+		ep := &endpoint.Endpoint{
+			DNSName:       m.GetDnsName(),
+			Targets:       m.GetTargets(),
+			RecordType:    m.GetRecordType(),
+			SetIdentifier: m.GetSetIdentifier(),
+			RecordTTL:     endpoint.TTL(m.GetRecordTtl()),
+			Labels:        endpoint.Labels{},
+		}
+		maps.Copy(ep.Labels, m.GetLabels())
+		for _, ps := range m.GetProviderSpecific() {
+			ep.ProviderSpecific = append(ep.ProviderSpecific, endpoint.ProviderSpecificProperty{Name: ps.GetName(), Value: ps.GetValue()})
+		}
+		endpoints = append(endpoints, ep)
+	}
+	return endpoints
+}
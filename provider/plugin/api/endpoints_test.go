@@ -0,0 +1,51 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestEndpointsRoundTrip(t *testing.T) {
+	endpoints := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("foo.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "5.6.7.8").
+			WithSetIdentifier("eu").
+			WithLabel(endpoint.OwnerLabelKey, "default").
+			WithLabel(endpoint.ResourceLabelKey, "service/default/foo").
+			WithProviderSpecific("alias", "false"),
+		endpoint.NewEndpoint("baz.example.com", endpoint.RecordTypeTXT, "\"heritage=external-dns\""),
+	}
+
+	b, err := proto.Marshal(&ApplyChangesRequest{Create: FromEndpoints(endpoints)})
+	require.NoError(t, err)
+	out := &ApplyChangesRequest{}
+	require.NoError(t, proto.Unmarshal(b, out))
+	assert.Equal(t, endpoints, ToEndpoints(out.GetCreate()))
+}
+
+func TestToEndpointsLabels(t *testing.T) {
+	endpoints := ToEndpoints([]*Endpoint{{DnsName: "foo.example.com", RecordType: endpoint.RecordTypeA}})
+	require.Len(t, endpoints, 1)
+	assert.NotNil(t, endpoints[0].Labels)
+	assert.Nil(t, ToEndpoints(nil))
+}
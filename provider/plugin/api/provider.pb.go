@@ -0,0 +1,550 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: provider/plugin/api/provider.proto
+
+package api
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Endpoint struct {
+	state            protoimpl.MessageState      `protogen:"open.v1"`
+	DnsName          string                      `protobuf:"bytes,1,opt,name=dns_name,json=dnsName,proto3" json:"dns_name,omitempty"`
+	Targets          []string                    `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`
+	RecordType       string                      `protobuf:"bytes,3,opt,name=record_type,json=recordType,proto3" json:"record_type,omitempty"`
+	SetIdentifier    string                      `protobuf:"bytes,4,opt,name=set_identifier,json=setIdentifier,proto3" json:"set_identifier,omitempty"`
+	RecordTtl        int64                       `protobuf:"varint,5,opt,name=record_ttl,json=recordTtl,proto3" json:"record_ttl,omitempty"`
+	Labels           map[string]string           `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ProviderSpecific []*ProviderSpecificProperty `protobuf:"bytes,7,rep,name=provider_specific,json=providerSpecific,proto3" json:"provider_specific,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Endpoint) Reset() {
+	*x = Endpoint{}
+	mi := &file_provider_plugin_api_provider_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Endpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Endpoint) ProtoMessage() {}
+
+func (x *Endpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_plugin_api_provider_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Endpoint.ProtoReflect.Descriptor instead.
+func (*Endpoint) Descriptor() ([]byte, []int) {
+	return file_provider_plugin_api_provider_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Endpoint) GetDnsName() string {
+	if x != nil {
+		return x.DnsName
+	}
+	return ""
+}
+
+func (x *Endpoint) GetTargets() []string {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *Endpoint) GetRecordType() string {
+	if x != nil {
+		return x.RecordType
+	}
+	return ""
+}
+
+func (x *Endpoint) GetSetIdentifier() string {
+	if x != nil {
+		return x.SetIdentifier
+	}
+	return ""
+}
+
+func (x *Endpoint) GetRecordTtl() int64 {
+	if x != nil {
+		return x.RecordTtl
+	}
+	return 0
+}
+
+func (x *Endpoint) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Endpoint) GetProviderSpecific() []*ProviderSpecificProperty {
+	if x != nil {
+		return x.ProviderSpecific
+	}
+	return nil
+}
+
+type ProviderSpecificProperty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProviderSpecificProperty) Reset() {
+	*x = ProviderSpecificProperty{}
+	mi := &file_provider_plugin_api_provider_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProviderSpecificProperty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderSpecificProperty) ProtoMessage() {}
+
+func (x *ProviderSpecificProperty) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_plugin_api_provider_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderSpecificProperty.ProtoReflect.Descriptor instead.
+func (*ProviderSpecificProperty) Descriptor() ([]byte, []int) {
+	return file_provider_plugin_api_provider_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ProviderSpecificProperty) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProviderSpecificProperty) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type RecordsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordsRequest) Reset() {
+	*x = RecordsRequest{}
+	mi := &file_provider_plugin_api_provider_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordsRequest) ProtoMessage() {}
+
+func (x *RecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_plugin_api_provider_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordsRequest.ProtoReflect.Descriptor instead.
+func (*RecordsRequest) Descriptor() ([]byte, []int) {
+	return file_provider_plugin_api_provider_proto_rawDescGZIP(), []int{2}
+}
+
+type RecordsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Endpoints     []*Endpoint            `protobuf:"bytes,1,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordsResponse) Reset() {
+	*x = RecordsResponse{}
+	mi := &file_provider_plugin_api_provider_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordsResponse) ProtoMessage() {}
+
+func (x *RecordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_plugin_api_provider_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordsResponse.ProtoReflect.Descriptor instead.
+func (*RecordsResponse) Descriptor() ([]byte, []int) {
+	return file_provider_plugin_api_provider_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RecordsResponse) GetEndpoints() []*Endpoint {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+type ApplyChangesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Create        []*Endpoint            `protobuf:"bytes,1,rep,name=create,proto3" json:"create,omitempty"`
+	UpdateOld     []*Endpoint            `protobuf:"bytes,2,rep,name=update_old,json=updateOld,proto3" json:"update_old,omitempty"`
+	UpdateNew     []*Endpoint            `protobuf:"bytes,3,rep,name=update_new,json=updateNew,proto3" json:"update_new,omitempty"`
+	Delete        []*Endpoint            `protobuf:"bytes,4,rep,name=delete,proto3" json:"delete,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyChangesRequest) Reset() {
+	*x = ApplyChangesRequest{}
+	mi := &file_provider_plugin_api_provider_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyChangesRequest) ProtoMessage() {}
+
+func (x *ApplyChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_plugin_api_provider_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyChangesRequest.ProtoReflect.Descriptor instead.
+func (*ApplyChangesRequest) Descriptor() ([]byte, []int) {
+	return file_provider_plugin_api_provider_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ApplyChangesRequest) GetCreate() []*Endpoint {
+	if x != nil {
+		return x.Create
+	}
+	return nil
+}
+
+func (x *ApplyChangesRequest) GetUpdateOld() []*Endpoint {
+	if x != nil {
+		return x.UpdateOld
+	}
+	return nil
+}
+
+func (x *ApplyChangesRequest) GetUpdateNew() []*Endpoint {
+	if x != nil {
+		return x.UpdateNew
+	}
+	return nil
+}
+
+func (x *ApplyChangesRequest) GetDelete() []*Endpoint {
+	if x != nil {
+		return x.Delete
+	}
+	return nil
+}
+
+type ApplyChangesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyChangesResponse) Reset() {
+	*x = ApplyChangesResponse{}
+	mi := &file_provider_plugin_api_provider_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyChangesResponse) ProtoMessage() {}
+
+func (x *ApplyChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_plugin_api_provider_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyChangesResponse.ProtoReflect.Descriptor instead.
+func (*ApplyChangesResponse) Descriptor() ([]byte, []int) {
+	return file_provider_plugin_api_provider_proto_rawDescGZIP(), []int{5}
+}
+
+type AdjustEndpointsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Endpoints     []*Endpoint            `protobuf:"bytes,1,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustEndpointsRequest) Reset() {
+	*x = AdjustEndpointsRequest{}
+	mi := &file_provider_plugin_api_provider_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustEndpointsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustEndpointsRequest) ProtoMessage() {}
+
+func (x *AdjustEndpointsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_plugin_api_provider_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustEndpointsRequest.ProtoReflect.Descriptor instead.
+func (*AdjustEndpointsRequest) Descriptor() ([]byte, []int) {
+	return file_provider_plugin_api_provider_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AdjustEndpointsRequest) GetEndpoints() []*Endpoint {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+type AdjustEndpointsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Endpoints     []*Endpoint            `protobuf:"bytes,1,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustEndpointsResponse) Reset() {
+	*x = AdjustEndpointsResponse{}
+	mi := &file_provider_plugin_api_provider_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustEndpointsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustEndpointsResponse) ProtoMessage() {}
+
+func (x *AdjustEndpointsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_provider_plugin_api_provider_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustEndpointsResponse.ProtoReflect.Descriptor instead.
+func (*AdjustEndpointsResponse) Descriptor() ([]byte, []int) {
+	return file_provider_plugin_api_provider_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AdjustEndpointsResponse) GetEndpoints() []*Endpoint {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+var File_provider_plugin_api_provider_proto protoreflect.FileDescriptor
+
+const file_provider_plugin_api_provider_proto_rawDesc = "" +
+	"\n" +
+	"\"provider/plugin/api/provider.proto\x12\x15externaldns.plugin.v1\"\x84\x03\n" +
+	"\bEndpoint\x12\x19\n" +
+	"\bdns_name\x18\x01 \x01(\tR\adnsName\x12\x18\n" +
+	"\atargets\x18\x02 \x03(\tR\atargets\x12\x1f\n" +
+	"\vrecord_type\x18\x03 \x01(\tR\n" +
+	"recordType\x12%\n" +
+	"\x0eset_identifier\x18\x04 \x01(\tR\rsetIdentifier\x12\x1d\n" +
+	"\n" +
+	"record_ttl\x18\x05 \x01(\x03R\trecordTtl\x12C\n" +
+	"\x06labels\x18\x06 \x03(\v2+.externaldns.plugin.v1.Endpoint.LabelsEntryR\x06labels\x12\\\n" +
+	"\x11provider_specific\x18\a \x03(\v2/.externaldns.plugin.v1.ProviderSpecificPropertyR\x10providerSpecific\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"D\n" +
+	"\x18ProviderSpecificProperty\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\x10\n" +
+	"\x0eRecordsRequest\"P\n" +
+	"\x0fRecordsResponse\x12=\n" +
+	"\tendpoints\x18\x01 \x03(\v2\x1f.externaldns.plugin.v1.EndpointR\tendpoints\"\x87\x02\n" +
+	"\x13ApplyChangesRequest\x127\n" +
+	"\x06create\x18\x01 \x03(\v2\x1f.externaldns.plugin.v1.EndpointR\x06create\x12>\n" +
+	"\n" +
+	"update_old\x18\x02 \x03(\v2\x1f.externaldns.plugin.v1.EndpointR\tupdateOld\x12>\n" +
+	"\n" +
+	"update_new\x18\x03 \x03(\v2\x1f.externaldns.plugin.v1.EndpointR\tupdateNew\x127\n" +
+	"\x06delete\x18\x04 \x03(\v2\x1f.externaldns.plugin.v1.EndpointR\x06delete\"\x16\n" +
+	"\x14ApplyChangesResponse\"W\n" +
+	"\x16AdjustEndpointsRequest\x12=\n" +
+	"\tendpoints\x18\x01 \x03(\v2\x1f.externaldns.plugin.v1.EndpointR\tendpoints\"X\n" +
+	"\x17AdjustEndpointsResponse\x12=\n" +
+	"\tendpoints\x18\x01 \x03(\v2\x1f.externaldns.plugin.v1.EndpointR\tendpoints2\xc5\x02\n" +
+	"\x0eProviderServer\x12X\n" +
+	"\aRecords\x12%.externaldns.plugin.v1.RecordsRequest\x1a&.externaldns.plugin.v1.RecordsResponse\x12g\n" +
+	"\fApplyChanges\x12*.externaldns.plugin.v1.ApplyChangesRequest\x1a+.externaldns.plugin.v1.ApplyChangesResponse\x12p\n" +
+	"\x0fAdjustEndpoints\x12-.externaldns.plugin.v1.AdjustEndpointsRequest\x1a..externaldns.plugin.v1.AdjustEndpointsResponseB.Z,sigs.k8s.io/external-dns/provider/plugin/apib\x06proto3"
+
+var (
+	file_provider_plugin_api_provider_proto_rawDescOnce sync.Once
+	file_provider_plugin_api_provider_proto_rawDescData []byte
+)
+
+func file_provider_plugin_api_provider_proto_rawDescGZIP() []byte {
+	file_provider_plugin_api_provider_proto_rawDescOnce.Do(func() {
+		file_provider_plugin_api_provider_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_provider_plugin_api_provider_proto_rawDesc), len(file_provider_plugin_api_provider_proto_rawDesc)))
+	})
+	return file_provider_plugin_api_provider_proto_rawDescData
+}
+
+var file_provider_plugin_api_provider_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_provider_plugin_api_provider_proto_goTypes = []any{
+	(*Endpoint)(nil),                 // 0: externaldns.plugin.v1.Endpoint
+	(*ProviderSpecificProperty)(nil), // 1: externaldns.plugin.v1.ProviderSpecificProperty
+	(*RecordsRequest)(nil),           // 2: externaldns.plugin.v1.RecordsRequest
+	(*RecordsResponse)(nil),          // 3: externaldns.plugin.v1.RecordsResponse
+	(*ApplyChangesRequest)(nil),      // 4: externaldns.plugin.v1.ApplyChangesRequest
+	(*ApplyChangesResponse)(nil),     // 5: externaldns.plugin.v1.ApplyChangesResponse
+	(*AdjustEndpointsRequest)(nil),   // 6: externaldns.plugin.v1.AdjustEndpointsRequest
+	(*AdjustEndpointsResponse)(nil),  // 7: externaldns.plugin.v1.AdjustEndpointsResponse
+	nil,                              // 8: externaldns.plugin.v1.Endpoint.LabelsEntry
+}
+var file_provider_plugin_api_provider_proto_depIdxs = []int32{
+	8,  // 0: externaldns.plugin.v1.Endpoint.labels:type_name -> externaldns.plugin.v1.Endpoint.LabelsEntry
+	1,  // 1: externaldns.plugin.v1.Endpoint.provider_specific:type_name -> externaldns.plugin.v1.ProviderSpecificProperty
+	0,  // 2: externaldns.plugin.v1.RecordsResponse.endpoints:type_name -> externaldns.plugin.v1.Endpoint
+	0,  // 3: externaldns.plugin.v1.ApplyChangesRequest.create:type_name -> externaldns.plugin.v1.Endpoint
+	0,  // 4: externaldns.plugin.v1.ApplyChangesRequest.update_old:type_name -> externaldns.plugin.v1.Endpoint
+	0,  // 5: externaldns.plugin.v1.ApplyChangesRequest.update_new:type_name -> externaldns.plugin.v1.Endpoint
+	0,  // 6: externaldns.plugin.v1.ApplyChangesRequest.delete:type_name -> externaldns.plugin.v1.Endpoint
+	0,  // 7: externaldns.plugin.v1.AdjustEndpointsRequest.endpoints:type_name -> externaldns.plugin.v1.Endpoint
+	0,  // 8: externaldns.plugin.v1.AdjustEndpointsResponse.endpoints:type_name -> externaldns.plugin.v1.Endpoint
+	2,  // 9: externaldns.plugin.v1.ProviderServer.Records:input_type -> externaldns.plugin.v1.RecordsRequest
+	4,  // 10: externaldns.plugin.v1.ProviderServer.ApplyChanges:input_type -> externaldns.plugin.v1.ApplyChangesRequest
+	6,  // 11: externaldns.plugin.v1.ProviderServer.AdjustEndpoints:input_type -> externaldns.plugin.v1.AdjustEndpointsRequest
+	3,  // 12: externaldns.plugin.v1.ProviderServer.Records:output_type -> externaldns.plugin.v1.RecordsResponse
+	5,  // 13: externaldns.plugin.v1.ProviderServer.ApplyChanges:output_type -> externaldns.plugin.v1.ApplyChangesResponse
+	7,  // 14: externaldns.plugin.v1.ProviderServer.AdjustEndpoints:output_type -> externaldns.plugin.v1.AdjustEndpointsResponse
+	12, // [12:15] is the sub-list for method output_type
+	9,  // [9:12] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_provider_plugin_api_provider_proto_init() }
+func file_provider_plugin_api_provider_proto_init() {
+	if File_provider_plugin_api_provider_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_provider_plugin_api_provider_proto_rawDesc), len(file_provider_plugin_api_provider_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_provider_plugin_api_provider_proto_goTypes,
+		DependencyIndexes: file_provider_plugin_api_provider_proto_depIdxs,
+		MessageInfos:      file_provider_plugin_api_provider_proto_msgTypes,
+	}.Build()
+	File_provider_plugin_api_provider_proto = out.File
+	file_provider_plugin_api_provider_proto_goTypes = nil
+	file_provider_plugin_api_provider_proto_depIdxs = nil
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	ServiceName = "externaldns.plugin.v1.ProviderServer"
+
+	methodRecords         = "/" + ServiceName + "/Records"
+	methodApplyChanges    = "/" + ServiceName + "/ApplyChanges"
+	methodAdjustEndpoints = "/" + ServiceName + "/AdjustEndpoints"
+)
+
+// ProviderServer is the server API of the ProviderServer service.
+type ProviderServer interface {
+	Records(context.Context, *RecordsRequest) (*RecordsResponse, error)
+	ApplyChanges(context.Context, *ApplyChangesRequest) (*ApplyChangesResponse, error)
+	AdjustEndpoints(context.Context, *AdjustEndpointsRequest) (*AdjustEndpointsResponse, error)
+}
+
+// RegisterProviderServer registers the implementation of the ProviderServer service on s.
+func RegisterProviderServer(s grpc.ServiceRegistrar, srv ProviderServer) {
+	s.RegisterService(&serviceDesc, srv)
+}
+
+func handler[Req any, Resp any](method string, call func(ProviderServer, context.Context, *Req) (Resp, error)) grpc.MethodHandler {
+	return func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		in := new(Req)
+		if err := dec(in); err != nil {
+			return nil, err
+		}
+		if interceptor == nil {
+			return call(srv.(ProviderServer), ctx, in)
+		}
+		info := &grpc.UnaryServerInfo{Server: srv, FullMethod: method}
+		return interceptor(ctx, in, info, func(ctx context.Context, req any) (any, error) {
+			return call(srv.(ProviderServer), ctx, req.(*Req))
+		})
+	}
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*ProviderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Records", Handler: handler[RecordsRequest](methodRecords, ProviderServer.Records)},
+		{MethodName: "ApplyChanges", Handler: handler[ApplyChangesRequest](methodApplyChanges, ProviderServer.ApplyChanges)},
+		{MethodName: "AdjustEndpoints", Handler: handler[AdjustEndpointsRequest](methodAdjustEndpoints, ProviderServer.AdjustEndpoints)},
+	},
+	Metadata: "provider/plugin/api/provider.proto",
+}
+
+// ProviderClient is the client API of the ProviderServer service.
+type ProviderClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewProviderClient creates a ProviderClient using the given connection.
+func NewProviderClient(cc grpc.ClientConnInterface) *ProviderClient {
+	return &ProviderClient{cc: cc}
+}
+
+func (c *ProviderClient) Records(ctx context.Context, in *RecordsRequest, opts ...grpc.CallOption) (*RecordsResponse, error) {
+	out := new(RecordsResponse)
+	if err := c.cc.Invoke(ctx, methodRecords, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ProviderClient) ApplyChanges(ctx context.Context, in *ApplyChangesRequest, opts ...grpc.CallOption) (*ApplyChangesResponse, error) {
+	out := new(ApplyChangesResponse)
+	if err := c.cc.Invoke(ctx, methodApplyChanges, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ProviderClient) AdjustEndpoints(ctx context.Context, in *AdjustEndpointsRequest, opts ...grpc.CallOption) (*AdjustEndpointsResponse, error) {
+	out := new(AdjustEndpointsResponse)
+	if err := c.cc.Invoke(ctx, methodAdjustEndpoints, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// providerServer serves a provider.Provider as a ProviderServer.
+type providerServer struct {
+	provider provider.Provider
+}
+
+// NewProviderServer creates a ProviderServer backed by the given provider, so that
+// the in-tree providers can be run as plugins.
+func NewProviderServer(p provider.Provider) ProviderServer {
+	return &providerServer{provider: p}
+}
+
+func (s *providerServer) Records(ctx context.Context, _ *RecordsRequest) (*RecordsResponse, error) {
+	records, err := s.provider.Records(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &RecordsResponse{Endpoints: FromEndpoints(records)}, nil
+}
+
+func (s *providerServer) ApplyChanges(ctx context.Context, in *ApplyChangesRequest) (*ApplyChangesResponse, error) {
+	changes := &plan.Changes{
+		Create:    ToEndpoints(in.GetCreate()),
+		UpdateOld: ToEndpoints(in.GetUpdateOld()),
+		UpdateNew: ToEndpoints(in.GetUpdateNew()),
+		Delete:    ToEndpoints(in.GetDelete()),
+	}
+	if err := s.provider.ApplyChanges(ctx, changes); err != nil {
+		return nil, toStatus(err)
+	}
+	return &ApplyChangesResponse{}, nil
+}
+
+func (s *providerServer) AdjustEndpoints(_ context.Context, in *AdjustEndpointsRequest) (*AdjustEndpointsResponse, error) {
+	endpoints, err := s.provider.AdjustEndpoints(ToEndpoints(in.GetEndpoints()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &AdjustEndpointsResponse{Endpoints: FromEndpoints(endpoints)}, nil
+}
+
+// toStatus reports soft errors of the provider as Unavailable so that clients can retry them.
+func toStatus(err error) error {
+	if errors.Is(err, provider.SoftError) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	pluginapi "sigs.k8s.io/external-dns/provider/plugin/api"
+)
+
+// GRPCProvider is a provider that delegates to an out-of-tree plugin implementing
+// the ProviderServer gRPC service.
+type GRPCProvider struct {
+	provider.BaseProvider
+	conn         *grpc.ClientConn
+	client       *pluginapi.ProviderClient
+	timeout      time.Duration
+	domainFilter endpoint.DomainFilterInterface
+}
+
+// NewGRPCProvider creates a GRPCProvider connecting to the plugin at target, e.g. "localhost:8888"
+// or "unix:///var/run/plugin.sock". The connection is established lazily on the first call.
+func NewGRPCProvider(target string, timeout time.Duration, domainFilter endpoint.DomainFilterInterface, opts ...grpc.DialOption) (*GRPCProvider, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create plugin client for %q: %w", target, err)
+	}
+	return &GRPCProvider{
+		conn:         conn,
+		client:       pluginapi.NewProviderClient(conn),
+		timeout:      timeout,
+		domainFilter: domainFilter,
+	}, nil
+}
+
+// Records returns the records of the plugin.
+func (p *GRPCProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	ctx, cancel := p.withTimeout(ctx)
+	defer cancel()
+
+	resp, err := p.client.Records(ctx, &pluginapi.RecordsRequest{})
+	if err != nil {
+		return nil, convertError("Records", err)
+	}
+	return pluginapi.ToEndpoints(resp.GetEndpoints()), nil
+}
+
+// ApplyChanges sends the changes to the plugin.
+func (p *GRPCProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	ctx, cancel := p.withTimeout(ctx)
+	defer cancel()
+
+	_, err := p.client.ApplyChanges(ctx, &pluginapi.ApplyChangesRequest{
+		Create:    pluginapi.FromEndpoints(changes.Create),
+		UpdateOld: pluginapi.FromEndpoints(changes.UpdateOld),
+		UpdateNew: pluginapi.FromEndpoints(changes.UpdateNew),
+		Delete:    pluginapi.FromEndpoints(changes.Delete),
+	})
+	if err != nil {
+		return convertError("ApplyChanges", err)
+	}
+	return nil
+}
+
+// AdjustEndpoints lets the plugin canonicalize the endpoints.
+func (p *GRPCProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	ctx, cancel := p.withTimeout(context.Background())
+	defer cancel()
+
+	resp, err := p.client.AdjustEndpoints(ctx, &pluginapi.AdjustEndpointsRequest{Endpoints: pluginapi.FromEndpoints(endpoints)})
+	if err != nil {
+		return nil, convertError("AdjustEndpoints", err)
+	}
+	return pluginapi.ToEndpoints(resp.GetEndpoints()), nil
+}
+
+// GetDomainFilter returns the domain filter the provider was configured with.
+func (p *GRPCProvider) GetDomainFilter() endpoint.DomainFilterInterface {
+	return p.domainFilter
+}
+
+// Close closes the connection to the plugin.
+func (p *GRPCProvider) Close() error {
+	return p.conn.Close()
+}
+
+func (p *GRPCProvider) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, p.timeout)
+}
+
+// convertError returns a soft error for the failures that are expected to be transient.
+func convertError(method string, err error) error {
+	log.Debugf("Plugin %s call failed: %v", method, err)
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.DeadlineExceeded, codes.Aborted:
+		return provider.NewSoftErrorf("plugin %s failed: %v", method, status.Convert(err).Message())
+	default:
+		return fmt.Errorf("plugin %s failed: %s", method, status.Convert(err).Message())
+	}
+}
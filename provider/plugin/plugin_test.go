@@ -0,0 +1,180 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	pluginapi "sigs.k8s.io/external-dns/provider/plugin/api"
+)
+
+// failingProvider is a provider whose calls fail with the given error.
+type failingProvider struct {
+	provider.BaseProvider
+	err   error
+	delay time.Duration
+}
+
+func (p *failingProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	select {
+	case <-time.After(p.delay):
+	case <-ctx.Done():
+	}
+	return nil, p.err
+}
+
+func (p *failingProvider) ApplyChanges(_ context.Context, _ *plan.Changes) error {
+	return p.err
+}
+
+func (p *failingProvider) AdjustEndpoints(_ []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	return nil, p.err
+}
+
+// newTestProvider serves the given provider in-process and returns a GRPCProvider connected to it.
+func newTestProvider(t *testing.T, backend provider.Provider, timeout time.Duration) *GRPCProvider {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	pluginapi.RegisterProviderServer(server, pluginapi.NewProviderServer(backend))
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	p, err := NewGRPCProvider("passthrough:///bufnet", timeout, endpoint.NewDomainFilter([]string{"example.com"}),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = p.Close() })
+	return p
+}
+
+func TestGRPCProvider(t *testing.T) {
+	backend := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.com"}))
+	p := newTestProvider(t, backend, time.Second)
+	ctx := context.Background()
+
+	records, err := p.Records(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	created := endpoint.NewEndpointWithTTL("foo.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "5.6.7.8").
+		WithSetIdentifier("eu").
+		WithLabel(endpoint.OwnerLabelKey, "default").
+		WithProviderSpecific("alias", "false")
+	err = p.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{created}})
+	require.NoError(t, err)
+
+	records, err = p.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, created, records[0])
+
+	updated := endpoint.NewEndpointWithTTL("foo.example.com", endpoint.RecordTypeA, 60, "9.9.9.9").WithSetIdentifier("eu")
+	err = p.ApplyChanges(ctx, &plan.Changes{UpdateOld: records, UpdateNew: []*endpoint.Endpoint{updated}})
+	require.NoError(t, err)
+
+	records, err = p.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, endpoint.Targets{"9.9.9.9"}, records[0].Targets)
+	assert.Equal(t, endpoint.TTL(60), records[0].RecordTTL)
+
+	err = p.ApplyChanges(ctx, &plan.Changes{Delete: records})
+	require.NoError(t, err)
+
+	records, err = p.Records(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	adjusted, err := p.AdjustEndpoints([]*endpoint.Endpoint{created})
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{created}, adjusted)
+
+	assert.Equal(t, endpoint.NewDomainFilter([]string{"example.com"}), p.GetDomainFilter())
+}
+
+func TestGRPCProviderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		backend *failingProvider
+		timeout time.Duration
+		soft    bool
+		err     string
+	}{
+		{
+			name:    "provider error",
+			backend: &failingProvider{err: errors.New("invalid zone")},
+			timeout: time.Second,
+			err:     "plugin Records failed: invalid zone",
+		},
+		{
+			name:    "provider soft error",
+			backend: &failingProvider{err: provider.NewSoftErrorf("throttled")},
+			timeout: time.Second,
+			soft:    true,
+			err:     "throttled",
+		},
+		{
+			name:    "timeout",
+			backend: &failingProvider{delay: time.Second},
+			timeout: 50 * time.Millisecond,
+			soft:    true,
+			err:     "plugin Records failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProvider(t, tt.backend, tt.timeout)
+
+			_, err := p.Records(context.Background())
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tt.err)
+			assert.Equal(t, tt.soft, errors.Is(err, provider.SoftError))
+		})
+	}
+}
+
+func TestGRPCProviderUnavailable(t *testing.T) {
+	lis := bufconn.Listen(1024)
+	require.NoError(t, lis.Close())
+
+	p, err := NewGRPCProvider("passthrough:///bufnet", time.Second, endpoint.DomainFilter{},
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}))
+	require.NoError(t, err)
+	defer p.Close()
+
+	err = p.ApplyChanges(context.Background(), &plan.Changes{})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, provider.SoftError)
+}
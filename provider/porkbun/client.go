@@ -0,0 +1,217 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package porkbun
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+const (
+	// defaultEndpoint is the base URL of the Porkbun API.
+	defaultEndpoint = "https://api.porkbun.com/api/json/v3"
+	// pageSize is the number of domains listed per request.
+	pageSize = 1000
+)
+
+// Domain is a domain of the account.
+type Domain struct {
+	Domain string `json:"domain"`
+}
+
+// Record is a DNS record of a domain. The records are flat within a domain, a record holds a single value. The name
+// is fully qualified.
+type Record struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Content string `json:"content"`
+	TTL     int    `json:"-"`
+	Prio    int    `json:"-"`
+}
+
+// UnmarshalJSON decodes a record, whose numbers are returned as strings.
+func (r *Record) UnmarshalJSON(data []byte) error {
+	type record Record
+	var raw struct {
+		record
+		TTL  json.RawMessage `json:"ttl"`
+		Prio json.RawMessage `json:"prio"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*r = Record(raw.record)
+	r.TTL = parseNumber(raw.TTL)
+	r.Prio = parseNumber(raw.Prio)
+	return nil
+}
+
+// RecordRequest holds the fields of the requests creating or editing a record. The name is relative to the domain,
+// empty at the apex.
+type RecordRequest struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Content string `json:"content"`
+	TTL     string `json:"ttl,omitempty"`
+	Prio    string `json:"prio,omitempty"`
+}
+
+// APIError is an error status returned by the Porkbun API.
+type APIError struct {
+	Path    string
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("porkbun: %s failed: %s", e.Path, e.Message)
+}
+
+// DNSClient is the interface of the Porkbun API used by the provider.
+type DNSClient interface {
+	ListDomains(ctx context.Context) ([]Domain, error)
+	ListRecords(ctx context.Context, domain string) ([]Record, error)
+	CreateRecord(ctx context.Context, domain string, record RecordRequest) error
+	EditRecord(ctx context.Context, domain string, recordID string, record RecordRequest) error
+	DeleteRecord(ctx context.Context, domain string, recordID string) error
+}
+
+// Client calls the Porkbun API with an API key and its secret key.
+type Client struct {
+	apiKey       string
+	secretAPIKey string
+	endpoint     string
+	httpClient   *http.Client
+}
+
+// NewClient returns a client of the Porkbun API authenticating with the API key and the secret API key.
+func NewClient(apiKey, secretAPIKey string, httpClient *http.Client) *Client {
+	return &Client{
+		apiKey:       apiKey,
+		secretAPIKey: secretAPIKey,
+		endpoint:     defaultEndpoint,
+		httpClient:   httpClient,
+	}
+}
+
+func (c *Client) ListDomains(ctx context.Context) ([]Domain, error) {
+	var domains []Domain
+	for start := 0; ; start += pageSize {
+		var result struct {
+			Domains []Domain `json:"domains"`
+		}
+		if err := c.do(ctx, "/domain/listAll", map[string]any{"start": strconv.Itoa(start)}, &result); err != nil {
+			return nil, err
+		}
+		domains = append(domains, result.Domains...)
+		if len(result.Domains) < pageSize {
+			return domains, nil
+		}
+	}
+}
+
+func (c *Client) ListRecords(ctx context.Context, domain string) ([]Record, error) {
+	var result struct {
+		Records []Record `json:"records"`
+	}
+	if err := c.do(ctx, "/dns/retrieve/"+url.PathEscape(domain), nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Records, nil
+}
+
+func (c *Client) CreateRecord(ctx context.Context, domain string, record RecordRequest) error {
+	return c.do(ctx, "/dns/create/"+url.PathEscape(domain), record, nil)
+}
+
+func (c *Client) EditRecord(ctx context.Context, domain string, recordID string, record RecordRequest) error {
+	return c.do(ctx, "/dns/edit/"+url.PathEscape(domain)+"/"+url.PathEscape(recordID), record, nil)
+}
+
+func (c *Client) DeleteRecord(ctx context.Context, domain string, recordID string) error {
+	return c.do(ctx, "/dns/delete/"+url.PathEscape(domain)+"/"+url.PathEscape(recordID), nil, nil)
+}
+
+// do posts the parameters to an API method, with the keys, and decodes the response into the result. The API
+// answers every method with a status, an error status is returned as an error.
+func (c *Client) do(ctx context.Context, path string, params, result any) error {
+	body := map[string]any{}
+	if params != nil {
+		b, err := json.Marshal(params)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(b, &body); err != nil {
+			return err
+		}
+	}
+	body["apikey"] = c.apiKey
+	body["secretapikey"] = c.secretAPIKey
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+path, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", externaldns.UserAgent())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var status struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(respBody, &status); err != nil {
+		return fmt.Errorf("porkbun: %s failed with HTTP status %s", path, resp.Status)
+	}
+	if !strings.EqualFold(status.Status, "SUCCESS") {
+		return &APIError{Path: path, Message: status.Message}
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(respBody, result)
+}
+
+// parseNumber decodes a number returned either as a number or as a string, zero when it's empty or null.
+func parseNumber(raw json.RawMessage) int {
+	s := strings.Trim(string(raw), `"`)
+	n, _ := strconv.Atoi(s)
+	return n
+}
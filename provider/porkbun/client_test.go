@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package porkbun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a client signing its requests with test API keys, sent to a test server running the handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client := NewClient("pk1_key", "sk1_secret", server.Client())
+	client.endpoint = server.URL
+	return client
+}
+
+func decodeBody(t *testing.T, req *http.Request) map[string]any {
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+	assert.Equal(t, "pk1_key", body["apikey"])
+	assert.Equal(t, "sk1_secret", body["secretapikey"])
+	return body
+}
+
+func TestClientListDomainsPagination(t *testing.T) {
+	var starts []any
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "/domain/listAll", req.URL.Path)
+		body := decodeBody(t, req)
+		starts = append(starts, body["start"])
+		if body["start"] == "0" {
+			domains := make([]string, pageSize)
+			for i := range domains {
+				domains[i] = fmt.Sprintf(`{"domain":"example%d.com","status":"ACTIVE"}`, i)
+			}
+			fmt.Fprintf(w, `{"status":"SUCCESS","domains":[%s]}`, strings.Join(domains, ","))
+			return
+		}
+		fmt.Fprint(w, `{"status":"SUCCESS","domains":[{"domain":"example.com","status":"ACTIVE"}]}`)
+	})
+
+	domains, err := client.ListDomains(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []any{"0", "1000"}, starts)
+	assert.Len(t, domains, pageSize+1)
+	assert.Equal(t, Domain{Domain: "example.com"}, domains[pageSize])
+}
+
+func TestClientListRecords(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/dns/retrieve/example.com", req.URL.Path)
+		decodeBody(t, req)
+		fmt.Fprint(w, `{"status":"SUCCESS","records":[
+			{"id":"1","name":"example.com","type":"MX","content":"mail.example.com","ttl":"600","prio":"10","notes":""},
+			{"id":"2","name":"www.example.com","type":"A","content":"1.2.3.4","ttl":"600","prio":null,"notes":null}
+		]}`)
+	})
+
+	records, err := client.ListRecords(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []Record{
+		{ID: "1", Name: "example.com", Type: "MX", Content: "mail.example.com", TTL: 600, Prio: 10},
+		{ID: "2", Name: "www.example.com", Type: "A", Content: "1.2.3.4", TTL: 600},
+	}, records)
+}
+
+func TestClientEditRecord(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/dns/edit/example.com/1", req.URL.Path)
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		body := decodeBody(t, req)
+		assert.Equal(t, map[string]any{
+			"apikey":       "pk1_key",
+			"secretapikey": "sk1_secret",
+			"name":         "",
+			"type":         "MX",
+			"content":      "mail.example.com",
+			"ttl":          "900",
+			"prio":         "10",
+		}, body)
+		fmt.Fprint(w, `{"status":"SUCCESS"}`)
+	})
+
+	require.NoError(t, client.EditRecord(context.Background(), "example.com", "1", RecordRequest{Type: "MX", Content: "mail.example.com", TTL: "900", Prio: "10"}))
+}
+
+func TestClientAPIError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/dns/delete/example.com/1", req.URL.Path)
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"status":"ERROR","message":"Invalid API key. (002)"}`)
+	})
+
+	err := client.DeleteRecord(context.Background(), "example.com", "1")
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.EqualError(t, err, "porkbun: /dns/delete/example.com/1 failed: Invalid API key. (002)")
+}
@@ -0,0 +1,329 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package porkbun
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// defaultTTL is the TTL of the records without a configured TTL, it's also the lowest TTL Porkbun accepts.
+const defaultTTL = 600
+
+// PorkbunProvider is an implementation of Provider for Porkbun's DNS.
+type PorkbunProvider struct {
+	provider.BaseProvider
+	Client DNSClient
+	// only consider hosted zones managing domains ending in this suffix
+	domainFilter endpoint.DomainFilter
+	DryRun       bool
+}
+
+type porkbunChangeCreate struct {
+	Domain  string
+	Options RecordRequest
+}
+
+type porkbunChangeUpdate struct {
+	Domain  string
+	Record  Record
+	Options RecordRequest
+}
+
+type porkbunChangeDelete struct {
+	Domain string
+	Record Record
+}
+
+// porkbunChanges contains all changes to apply to DNS
+type porkbunChanges struct {
+	Creates []porkbunChangeCreate
+	Updates []porkbunChangeUpdate
+	Deletes []porkbunChangeDelete
+}
+
+// NewPorkbunProvider initializes a new Porkbun DNS based Provider.
+func NewPorkbunProvider(domainFilter endpoint.DomainFilter, dryRun bool) (*PorkbunProvider, error) {
+	apiKey := os.Getenv("PORKBUN_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("no API key found, set PORKBUN_API_KEY")
+	}
+	secretAPIKey := os.Getenv("PORKBUN_SECRET_API_KEY")
+	if secretAPIKey == "" {
+		return nil, fmt.Errorf("no secret API key found, set PORKBUN_SECRET_API_KEY")
+	}
+
+	return &PorkbunProvider{
+		Client:       NewClient(apiKey, secretAPIKey, &http.Client{Timeout: 30 * time.Second}),
+		domainFilter: domainFilter,
+		DryRun:       dryRun,
+	}, nil
+}
+
+// Zones returns the list of hosted zones.
+func (p *PorkbunProvider) Zones(ctx context.Context) ([]Domain, error) {
+	domains, err := p.Client.ListDomains(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list domains: %w", err)
+	}
+
+	var zones []Domain
+	for _, domain := range domains {
+		if p.domainFilter.Match(domain.Domain) {
+			zones = append(zones, domain)
+		}
+	}
+	return zones, nil
+}
+
+// SupportedRecordType returns true for the record types of the Porkbun DNS API external-dns manages. The ALIAS, NS and
+// SRV records of a domain are left alone.
+func (p *PorkbunProvider) SupportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT,
+		endpoint.RecordTypeMX, endpoint.RecordTypeCAA:
+		return true
+	default:
+		return false
+	}
+}
+
+// AdjustEndpoints raises the TTLs below the lowest TTL Porkbun accepts, for the records not to be updated on every
+// synchronization.
+func (p *PorkbunProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	for _, ep := range endpoints {
+		if ep.RecordTTL.IsConfigured() && ep.RecordTTL < defaultTTL {
+			ep.RecordTTL = defaultTTL
+		}
+	}
+	return endpoints, nil
+}
+
+// Records returns the list of records in all the domains. Porkbun stores a record per target, the records of a name and
+// type are merged into a single endpoint.
+func (p *PorkbunProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, zone := range zones {
+		records, err := p.Client.ListRecords(ctx, zone.Domain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list records of domain %s: %w", zone.Domain, err)
+		}
+
+		for _, r := range records {
+			if !p.SupportedRecordType(r.Type) {
+				continue
+			}
+			endpoints = append(endpoints, endpoint.NewEndpointWithTTL(r.Name, r.Type, endpoint.TTL(r.TTL), recordTarget(r)))
+		}
+	}
+	endpoints = provider.MergeEndpointsByNameType(endpoints)
+
+	log.WithFields(log.Fields{
+		"endpoints": endpoints,
+	}).Debug("Endpoints generated from Porkbun DNS")
+
+	return endpoints, nil
+}
+
+// ApplyChanges applies the given changes, retrieving the records of a domain once for all its changes.
+func (p *PorkbunProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return err
+	}
+
+	zoneNameIDMapper := provider.ZoneIDName{}
+	for _, zone := range zones {
+		zoneNameIDMapper.Add(zone.Domain, zone.Domain)
+	}
+
+	recordsByZone := map[string][]Record{}
+	records := func(zone string) ([]Record, error) {
+		if r, ok := recordsByZone[zone]; ok {
+			return r, nil
+		}
+		r, err := p.Client.ListRecords(ctx, zone)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list records of domain %s: %w", zone, err)
+		}
+		recordsByZone[zone] = r
+		return r, nil
+	}
+
+	var porkbunChanges porkbunChanges
+	err = provider.ForEachEndpointTargets(changes, func(ep *endpoint.Endpoint, targets []string, prune bool) error {
+		zone, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zone == "" {
+			log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", ep.DNSName)
+			return nil
+		}
+		existing, err := records(zone)
+		if err != nil {
+			return err
+		}
+		return porkbunChanges.add(zone, existing, ep, targets, prune)
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.submitChanges(ctx, porkbunChanges)
+}
+
+// add adds the changes turning the domain's records of the endpoint's name and type into a record per target. Porkbun
+// lists the records by their full name, while it creates and edits them by their name relative to the domain.
+func (c *porkbunChanges) add(zone string, existing []Record, ep *endpoint.Endpoint, targets []string, prune bool) error {
+	current := slices.DeleteFunc(slices.Clone(existing), func(r Record) bool {
+		return r.Name != ep.DNSName || r.Type != ep.RecordType
+	})
+	diff := provider.DiffRecords(current, targets, prune, recordTarget, func(r Record) bool {
+		return ep.RecordTTL.IsConfigured() && r.TTL != int(ep.RecordTTL)
+	})
+
+	name := recordName(zone, ep.DNSName)
+	for _, target := range diff.Create {
+		options, err := newRecordRequest(name, ep.RecordType, target, ep.RecordTTL)
+		if err != nil {
+			return fmt.Errorf("invalid target %q of %s record %s: %w", target, ep.RecordType, ep.DNSName, err)
+		}
+		c.Creates = append(c.Creates, porkbunChangeCreate{Domain: zone, Options: options})
+	}
+	for _, update := range diff.Update {
+		options, err := newRecordRequest(name, ep.RecordType, update.Target, ep.RecordTTL)
+		if err != nil {
+			return fmt.Errorf("invalid target %q of %s record %s: %w", update.Target, ep.RecordType, ep.DNSName, err)
+		}
+		c.Updates = append(c.Updates, porkbunChangeUpdate{Domain: zone, Record: update.Record, Options: options})
+	}
+	for _, record := range diff.Delete {
+		c.Deletes = append(c.Deletes, porkbunChangeDelete{Domain: zone, Record: record})
+	}
+	return nil
+}
+
+// submitChanges submits the changes to the Porkbun API, which has no batch endpoint, one record at a time. The deletions
+// come first, for a CNAME record to take the place of the other records of its name.
+func (p *PorkbunProvider) submitChanges(ctx context.Context, changes porkbunChanges) error {
+	for _, change := range changes.Deletes {
+		logFields := log.Fields{
+			"record":   change.Record.Name,
+			"type":     change.Record.Type,
+			"target":   change.Record.Content,
+			"action":   "Delete",
+			"zoneName": change.Domain,
+		}
+		log.WithFields(logFields).Info("Deleting record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.DeleteRecord(ctx, change.Domain, change.Record.ID); err != nil {
+			return fmt.Errorf("failed to delete %s record %s of domain %s: %w", change.Record.Type, change.Record.Name, change.Domain, err)
+		}
+	}
+
+	for _, change := range changes.Updates {
+		logFields := log.Fields{
+			"record":   change.Record.Name,
+			"type":     change.Record.Type,
+			"target":   change.Options.Content,
+			"ttl":      change.Options.TTL,
+			"action":   "Update",
+			"zoneName": change.Domain,
+		}
+		log.WithFields(logFields).Info("Updating record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.EditRecord(ctx, change.Domain, change.Record.ID, change.Options); err != nil {
+			return fmt.Errorf("failed to update %s record %s of domain %s: %w", change.Record.Type, change.Record.Name, change.Domain, err)
+		}
+	}
+
+	for _, change := range changes.Creates {
+		logFields := log.Fields{
+			"record":   change.Options.Name,
+			"type":     change.Options.Type,
+			"target":   change.Options.Content,
+			"action":   "Create",
+			"zoneName": change.Domain,
+		}
+		log.WithFields(logFields).Info("Creating record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.CreateRecord(ctx, change.Domain, change.Options); err != nil {
+			return fmt.Errorf("failed to create %s record %s of domain %s: %w", change.Options.Type, change.Options.Name, change.Domain, err)
+		}
+	}
+
+	return nil
+}
+
+// recordName returns the name of a record relative to its domain, the apex being the empty name.
+func recordName(zone, dnsName string) string {
+	if dnsName == zone {
+		return ""
+	}
+	return strings.TrimSuffix(dnsName, "."+zone)
+}
+
+// recordTarget returns the endpoint target of a record, the priority of MX records is stored apart from their
+// content.
+func recordTarget(r Record) string {
+	if r.Type == endpoint.RecordTypeMX {
+		return fmt.Sprintf("%d %s", r.Prio, r.Content)
+	}
+	return r.Content
+}
+
+// newRecordRequest returns the request creating the record of an endpoint target. MX targets are of the form
+// "10 mail.example.com", the priority coming first, and CAA targets of the form `0 issue "letsencrypt.org"`.
+func newRecordRequest(name, recordType, target string, ttl endpoint.TTL) (RecordRequest, error) {
+	request := RecordRequest{Name: name, Type: recordType, Content: target}
+	if ttl.IsConfigured() {
+		request.TTL = strconv.FormatInt(int64(ttl), 10)
+	}
+	if recordType == endpoint.RecordTypeMX {
+		priorityRaw, content, ok := strings.Cut(target, " ")
+		if !ok {
+			return RecordRequest{}, fmt.Errorf("the priority is missing")
+		}
+		if _, err := strconv.Atoi(priorityRaw); err != nil {
+			return RecordRequest{}, fmt.Errorf("invalid priority %q", priorityRaw)
+		}
+		request.Prio = priorityRaw
+		request.Content = content
+	}
+	return request, nil
+}
@@ -0,0 +1,297 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package porkbun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// fakePorkbunAPI is an in-memory Porkbun API.
+type fakePorkbunAPI struct {
+	mu      sync.Mutex
+	domains []string
+	records map[string][]Record
+	nextID  int
+	writes  int
+}
+
+func newFakePorkbunAPI() *fakePorkbunAPI {
+	return &fakePorkbunAPI{
+		domains: []string{"example.com", "example.org"},
+		records: map[string][]Record{
+			"example.com": {
+				{ID: "1", Name: "example.com", Type: "NS", Content: "curitiba.ns.porkbun.com", TTL: 86400},
+				{ID: "2", Name: "api.example.com", Type: "A", Content: "1.2.3.4", TTL: 600},
+				{ID: "3", Name: "api.example.com", Type: "A", Content: "1.2.3.5", TTL: 600},
+				{ID: "4", Name: "api.example.com", Type: "TXT", Content: "heritage=external-dns", TTL: 600},
+				{ID: "5", Name: "example.com", Type: "MX", Content: "mail.example.com", TTL: 600, Prio: 10},
+				{ID: "6", Name: "example.com", Type: "CAA", Content: `0 issue "letsencrypt.org"`, TTL: 600},
+			},
+			"example.org": {
+				{ID: "7", Name: "www.example.org", Type: "CNAME", Content: "example.com", TTL: 600},
+			},
+		},
+		nextID: 100,
+	}
+}
+
+func (f *fakePorkbunAPI) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var body RecordRequest
+	_ = json.NewDecoder(req.Body).Decode(&body)
+	parts := strings.Split(strings.TrimPrefix(req.URL.Path, "/"), "/")
+	respond := func(v map[string]any) {
+		if v == nil {
+			v = map[string]any{}
+		}
+		v["status"] = "SUCCESS"
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	fail := func(message string) {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]any{"status": "ERROR", "message": message})
+	}
+
+	if req.URL.Path == "/domain/listAll" {
+		var domains []map[string]string
+		for _, d := range f.domains {
+			domains = append(domains, map[string]string{"domain": d})
+		}
+		respond(map[string]any{"domains": domains})
+		return
+	}
+	if len(parts) < 3 || parts[0] != "dns" {
+		fail("Invalid method.")
+		return
+	}
+	domain := parts[2]
+	if _, ok := f.records[domain]; !ok {
+		fail("Invalid domain.")
+		return
+	}
+	index := func() int {
+		if len(parts) < 4 {
+			return -1
+		}
+		return slices.IndexFunc(f.records[domain], func(r Record) bool { return r.ID == parts[3] })
+	}
+	fromRequest := func(id string) Record {
+		name := domain
+		if body.Name != "" {
+			name = body.Name + "." + domain
+		}
+		ttl, _ := strconv.Atoi(body.TTL)
+		if ttl == 0 {
+			ttl = defaultTTL
+		}
+		prio, _ := strconv.Atoi(body.Prio)
+		return Record{ID: id, Name: name, Type: body.Type, Content: body.Content, TTL: ttl, Prio: prio}
+	}
+
+	switch parts[1] {
+	case "retrieve":
+		var records []map[string]string
+		for _, r := range f.records[domain] {
+			records = append(records, map[string]string{
+				"id": r.ID, "name": r.Name, "type": r.Type, "content": r.Content,
+				"ttl": strconv.Itoa(r.TTL), "prio": strconv.Itoa(r.Prio),
+			})
+		}
+		respond(map[string]any{"records": records})
+	case "create":
+		f.nextID++
+		f.writes++
+		record := fromRequest(strconv.Itoa(f.nextID))
+		f.records[domain] = append(f.records[domain], record)
+		respond(map[string]any{"id": f.nextID})
+	case "edit":
+		i := index()
+		if i < 0 {
+			fail("Invalid record ID.")
+			return
+		}
+		f.writes++
+		f.records[domain][i] = fromRequest(parts[3])
+		respond(nil)
+	case "delete":
+		i := index()
+		if i < 0 {
+			fail("Invalid record ID.")
+			return
+		}
+		f.writes++
+		f.records[domain] = slices.Delete(f.records[domain], i, i+1)
+		respond(nil)
+	default:
+		fail("Invalid method.")
+	}
+}
+
+func newTestProvider(t *testing.T, api *fakePorkbunAPI, domains ...string) *PorkbunProvider {
+	return &PorkbunProvider{
+		Client:       newTestClient(t, api.ServeHTTP),
+		domainFilter: endpoint.NewDomainFilter(domains),
+	}
+}
+
+func TestNewPorkbunProvider(t *testing.T) {
+	t.Setenv("PORKBUN_API_KEY", "pk1_key")
+	t.Setenv("PORKBUN_SECRET_API_KEY", "sk1_secret")
+	_, err := NewPorkbunProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.NoError(t, err)
+
+	t.Setenv("PORKBUN_SECRET_API_KEY", "")
+	_, err = NewPorkbunProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.EqualError(t, err, "no secret API key found, set PORKBUN_SECRET_API_KEY")
+
+	t.Setenv("PORKBUN_API_KEY", "")
+	_, err = NewPorkbunProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.EqualError(t, err, "no API key found, set PORKBUN_API_KEY")
+}
+
+func TestPorkbunProviderRecords(t *testing.T) {
+	p := newTestProvider(t, newFakePorkbunAPI(), "example.com")
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 600, "1.2.3.4", "1.2.3.5"),
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeTXT, 600, "heritage=external-dns"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 600, "10 mail.example.com"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeCAA, 600, `0 issue "letsencrypt.org"`),
+	}, endpoints)
+}
+
+func TestPorkbunProviderAdjustEndpoints(t *testing.T) {
+	p := &PorkbunProvider{}
+
+	endpoints, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("a.example.com", endpoint.RecordTypeA, 60, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("b.example.com", endpoint.RecordTypeA, 3600, "1.2.3.4"),
+		endpoint.NewEndpoint("c.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, endpoint.TTL(600), endpoints[0].RecordTTL)
+	assert.Equal(t, endpoint.TTL(3600), endpoints[1].RecordTTL)
+	assert.False(t, endpoints[2].RecordTTL.IsConfigured())
+}
+
+func TestPorkbunProviderApplyChanges(t *testing.T) {
+	api := newFakePorkbunAPI()
+	p := newTestProvider(t, api, "example.com", "example.org")
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeAAAA, "2001:db8::1", "2001:db8::2"),
+			endpoint.NewEndpointWithTTL("example.org", endpoint.RecordTypeTXT, 3600, "v=spf1 -all"),
+			endpoint.NewEndpoint("example.org", endpoint.RecordTypeCAA, `0 issuewild ";"`),
+			// not in a managed zone
+			endpoint.NewEndpoint("www.example.net", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 600, "1.2.3.4", "1.2.3.5"),
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 600, "10 mail.example.com"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 600, "1.2.3.5", "1.2.3.6"),
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 900, "10 mail.example.com", "20 backup.example.com"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeTXT, "heritage=external-dns"),
+			endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeCNAME, "example.com"),
+		},
+	}))
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 600, "1.2.3.5", "1.2.3.6"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 900, "10 mail.example.com", "20 backup.example.com"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeCAA, 600, `0 issue "letsencrypt.org"`),
+		endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeAAAA, defaultTTL, "2001:db8::1", "2001:db8::2"),
+		endpoint.NewEndpointWithTTL("example.org", endpoint.RecordTypeTXT, 3600, "v=spf1 -all"),
+		endpoint.NewEndpointWithTTL("example.org", endpoint.RecordTypeCAA, defaultTTL, `0 issuewild ";"`),
+	}, endpoints)
+	// the unchanged target of api.example.com is kept
+	assert.Contains(t, api.records["example.com"], Record{ID: "3", Name: "api.example.com", Type: "A", Content: "1.2.3.5", TTL: 600})
+	// the MX record whose TTL changed is edited in place
+	assert.Contains(t, api.records["example.com"], Record{ID: "5", Name: "example.com", Type: "MX", Content: "mail.example.com", TTL: 900, Prio: 10})
+	assert.Equal(t, 10, api.writes)
+}
+
+func TestPorkbunProviderApplyChangesDryRun(t *testing.T) {
+	api := newFakePorkbunAPI()
+	p := newTestProvider(t, api, "example.com")
+	p.DryRun = true
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeA, "1.2.3.4", "1.2.3.5")},
+	}))
+	assert.Zero(t, api.writes)
+}
+
+func TestPorkbunProviderApplyChangesInvalidTarget(t *testing.T) {
+	api := newFakePorkbunAPI()
+	p := newTestProvider(t, api, "example.com")
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "mail.example.com")},
+	})
+	require.EqualError(t, err, `invalid target "mail.example.com" of MX record example.com: the priority is missing`)
+	assert.Zero(t, api.writes)
+}
+
+func TestPorkbunProviderApplyChangesError(t *testing.T) {
+	api := newFakePorkbunAPI()
+	p := newTestProvider(t, api, "example.com")
+	// the record is gone by the time it's deleted
+	p.Client = &staleRecordsClient{DNSClient: p.Client}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeTXT, "heritage=external-dns")},
+	})
+	require.EqualError(t, err, "failed to delete TXT record api.example.com of domain example.com: porkbun: /dns/delete/example.com/404 failed: Invalid record ID.")
+}
+
+// staleRecordsClient returns records whose IDs don't exist anymore.
+type staleRecordsClient struct {
+	DNSClient
+}
+
+func (c *staleRecordsClient) ListRecords(ctx context.Context, domain string) ([]Record, error) {
+	records, err := c.DNSClient.ListRecords(ctx, domain)
+	for i := range records {
+		records[i].ID = "404"
+	}
+	return records, err
+}
@@ -57,6 +57,23 @@ type Provider interface {
 	GetDomainFilter() endpoint.DomainFilterInterface
 }
 
+// ZoneNameResolver is implemented by the providers which identify their zones by ID, so that the zones given by
+// ID, e.g. with --protected-zones, can be matched by name.
+type ZoneNameResolver interface {
+	// ZoneNamesByID returns the names of the zones of the provider by zone ID.
+	ZoneNamesByID(ctx context.Context) (map[string]string, error)
+}
+
+// ZoneNamesByID returns the names of the zones of the provider by zone ID, none when the provider doesn't identify
+// its zones by ID.
+func ZoneNamesByID(ctx context.Context, p Provider) (map[string]string, error) {
+	resolver, ok := p.(ZoneNameResolver)
+	if !ok {
+		return nil, nil
+	}
+	return resolver.ZoneNamesByID(ctx)
+}
+
 type BaseProvider struct{}
 
 func (b BaseProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
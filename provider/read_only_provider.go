@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// ReadOnlyProvider reads the records from the wrapped provider but never applies any change to it,
+// the changes are only logged.
+type ReadOnlyProvider struct {
+	Provider
+}
+
+// NewReadOnlyProvider returns a ReadOnlyProvider wrapping provider.
+func NewReadOnlyProvider(provider Provider) *ReadOnlyProvider {
+	return &ReadOnlyProvider{Provider: provider}
+}
+
+// ApplyChanges logs the changes without applying them.
+func (p *ReadOnlyProvider) ApplyChanges(_ context.Context, changes *plan.Changes) error {
+	if !changes.HasChanges() {
+		return nil
+	}
+	logChanges("create", changes.Create)
+	logChanges("update", changes.UpdateNew)
+	logChanges("delete", changes.Delete)
+	return nil
+}
+
+// ZoneNamesByID returns the names of the zones of the wrapped provider by zone ID.
+func (p *ReadOnlyProvider) ZoneNamesByID(ctx context.Context) (map[string]string, error) {
+	return ZoneNamesByID(ctx, p.Provider)
+}
+
+func logChanges(action string, endpoints []*endpoint.Endpoint) {
+	for _, ep := range endpoints {
+		log.WithFields(log.Fields{
+			"record": ep.DNSName,
+			"type":   ep.RecordType,
+			"ttl":    ep.RecordTTL,
+		}).Infof("Read-only provider: not applying %s of targets %s", action, ep.Targets)
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestReadOnlyProviderApplyChanges(t *testing.T) {
+	hook := testutils.LogsUnderTestWithLogLevel(log.InfoLevel, t)
+	p := NewReadOnlyProvider(&testProviderFunc{
+		applyChanges: applyChangesNotCalled(t),
+	})
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{}))
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create:    []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "5.6.7.8")},
+		Delete:    []*endpoint.Endpoint{endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeCNAME, "app.example.com")},
+	}))
+
+	testutils.TestHelperLogContains("Read-only provider: not applying create of targets 1.2.3.4", hook, t)
+	testutils.TestHelperLogContains("Read-only provider: not applying update of targets 5.6.7.8", hook, t)
+	testutils.TestHelperLogContains("Read-only provider: not applying delete of targets app.example.com", hook, t)
+}
+
+func TestReadOnlyProviderReadsWrappedProvider(t *testing.T) {
+	records := []*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.4")}
+	p := NewReadOnlyProvider(&testProviderFunc{
+		records: func(context.Context) ([]*endpoint.Endpoint, error) {
+			return records, nil
+		},
+		applyChanges: applyChangesNotCalled(t),
+	})
+
+	got, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, records, got)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"slices"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// MergeEndpointsByNameType merges the endpoints of a name and type into the first of them, for the providers storing
+// a record per target. The merged endpoint has the targets of all the endpoints and the TTL of the first one.
+func MergeEndpointsByNameType(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	byNameType := map[string]*endpoint.Endpoint{}
+	var merged []*endpoint.Endpoint
+	for _, ep := range endpoints {
+		key := ep.DNSName + "/" + ep.RecordType
+		if first, ok := byNameType[key]; ok {
+			first.Targets = append(first.Targets, ep.Targets...)
+			continue
+		}
+		byNameType[key] = ep
+		merged = append(merged, ep)
+	}
+	return merged
+}
+
+// ForEachEndpointTargets calls fn with the targets the records of each changed endpoint must have, the deletions
+// first. The records without a target are pruned on deletions and updates, while they are kept on creations so that
+// the records of other owners aren't deleted.
+func ForEachEndpointTargets(changes *plan.Changes, fn func(ep *endpoint.Endpoint, targets []string, prune bool) error) error {
+	for _, ep := range changes.Delete {
+		if err := fn(ep, nil, true); err != nil {
+			return err
+		}
+	}
+	for _, ep := range changes.UpdateNew {
+		if err := fn(ep, ep.Targets, true); err != nil {
+			return err
+		}
+	}
+	for _, ep := range changes.Create {
+		if err := fn(ep, ep.Targets, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordsDiff are the changes turning the records of an endpoint's name and type into a record per target.
+type RecordsDiff[R any] struct {
+	// Create are the targets without a record.
+	Create []string
+	// Update are the records of a target which are outdated.
+	Update []TargetRecord[R]
+	// Delete are the records without a target, when pruning.
+	Delete []R
+}
+
+// TargetRecord is the record of a target.
+type TargetRecord[R any] struct {
+	Target string
+	Record R
+}
+
+// DiffRecords matches the records of an endpoint's name and type with its targets, target returning the endpoint
+// target of a record. The records of a target are updated when outdated returns true, and the records without a
+// target are deleted when pruning.
+func DiffRecords[R any](records []R, targets []string, prune bool, target func(R) string, outdated func(R) bool) RecordsDiff[R] {
+	var diff RecordsDiff[R]
+	records = slices.Clone(records)
+	for _, t := range targets {
+		i := slices.IndexFunc(records, func(r R) bool { return target(r) == t })
+		if i < 0 {
+			diff.Create = append(diff.Create, t)
+			continue
+		}
+		if outdated(records[i]) {
+			diff.Update = append(diff.Update, TargetRecord[R]{Target: t, Record: records[i]})
+		}
+		records = slices.Delete(records, i, i+1)
+	}
+	if prune {
+		diff.Delete = records
+	}
+	return diff
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestMergeEndpointsByNameType(t *testing.T) {
+	merged := MergeEndpointsByNameType([]*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 600, "1.2.3.5"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeAAAA, 300, "2001:db8::1"),
+	})
+
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeAAAA, 300, "2001:db8::1"),
+	}, merged)
+}
+
+func TestForEachEndpointTargets(t *testing.T) {
+	type call struct {
+		name    string
+		targets []string
+		prune   bool
+	}
+	changes := &plan.Changes{
+		Create:    []*endpoint.Endpoint{endpoint.NewEndpoint("create.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("update.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("update.example.com", endpoint.RecordTypeA, "1.2.3.5")},
+		Delete:    []*endpoint.Endpoint{endpoint.NewEndpoint("delete.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+	}
+
+	var calls []call
+	require.NoError(t, ForEachEndpointTargets(changes, func(ep *endpoint.Endpoint, targets []string, prune bool) error {
+		calls = append(calls, call{ep.DNSName, targets, prune})
+		return nil
+	}))
+	assert.Equal(t, []call{
+		{"delete.example.com", nil, true},
+		{"update.example.com", []string{"1.2.3.5"}, true},
+		{"create.example.com", []string{"1.2.3.4"}, false},
+	}, calls)
+
+	err := ForEachEndpointTargets(changes, func(*endpoint.Endpoint, []string, bool) error { return errors.New("invalid") })
+	assert.EqualError(t, err, "invalid")
+}
+
+func TestDiffRecords(t *testing.T) {
+	type record struct {
+		id     string
+		target string
+		ttl    int
+	}
+	records := []record{{"1", "1.2.3.4", 300}, {"2", "1.2.3.5", 600}, {"3", "1.2.3.6", 300}, {"4", "1.2.3.6", 300}}
+	target := func(r record) string { return r.target }
+	outdated := func(r record) bool { return r.ttl != 300 }
+
+	for _, tt := range []struct {
+		name     string
+		targets  []string
+		prune    bool
+		expected RecordsDiff[record]
+	}{
+		{
+			name:    "deletion",
+			prune:   true,
+			targets: nil,
+			expected: RecordsDiff[record]{
+				Delete: records,
+			},
+		},
+		{
+			name:    "update",
+			prune:   true,
+			targets: []string{"1.2.3.4", "1.2.3.5", "1.2.3.6", "1.2.3.7"},
+			expected: RecordsDiff[record]{
+				Create: []string{"1.2.3.7"},
+				Update: []TargetRecord[record]{{Target: "1.2.3.5", Record: records[1]}},
+				// each record matches a single target
+				Delete: []record{records[3]},
+			},
+		},
+		{
+			name:    "creation keeping the records of other targets",
+			prune:   false,
+			targets: []string{"1.2.3.4", "1.2.3.7"},
+			expected: RecordsDiff[record]{
+				Create: []string{"1.2.3.7"},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DiffRecords(records, tt.targets, tt.prune, target, outdated))
+		})
+	}
+}
@@ -112,6 +112,11 @@ func (p *ScalewayProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*e
 		if !eps[i].RecordTTL.IsConfigured() {
 			eps[i].RecordTTL = endpoint.TTL(defaultTTL)
 		}
+		// the priority of MX records is part of their targets
+		if eps[i].RecordType == endpoint.RecordTypeMX {
+			eps[i].DeleteProviderSpecificProperty(scalewayPriorityKey)
+			continue
+		}
 		if _, ok := eps[i].GetProviderSpecificProperty(scalewayPriorityKey); !ok {
 			eps[i] = eps[i].WithProviderSpecific(scalewayPriorityKey, fmt.Sprintf("%d", scalewayDefaultPriority))
 		}
@@ -119,6 +124,16 @@ func (p *ScalewayProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*e
 	return eps, nil
 }
 
+// SupportedRecordType returns true if the record type is supported by the provider
+func (p *ScalewayProvider) SupportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeMX:
+		return true
+	default:
+		return provider.SupportedRecordType(recordType)
+	}
+}
+
 // Zones returns the list of hosted zones.
 func (p *ScalewayProvider) Zones(ctx context.Context) ([]*domain.DNSZone, error) {
 	res := []*domain.DNSZone{}
@@ -159,7 +174,7 @@ func (p *ScalewayProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, e
 			// trim any leading or ending dot
 			fullRecordName := strings.Trim(name+getCompleteZoneName(zone), ".")
 
-			if !provider.SupportedRecordType(record.Type.String()) {
+			if !p.SupportedRecordType(record.Type.String()) {
 				log.Infof("Skipping record %s because type %s is not supported", fullRecordName, record.Type.String())
 				continue
 			}
@@ -169,12 +184,19 @@ func (p *ScalewayProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, e
 			// the record is modified without going through ExternalDNS, we could have
 			// different priorities of ttls for a same name.
 			// In this case, we juste take the first one.
+			// MX records hold their priority in their target instead.
+			target := record.Data
+			if record.Type == domain.RecordTypeMX {
+				target = fmt.Sprintf("%d %s", record.Priority, record.Data)
+			}
 			if existingEndpoint, ok := endpoints[record.Type.String()+"/"+fullRecordName]; ok {
-				existingEndpoint.Targets = append(existingEndpoint.Targets, record.Data)
-				log.Infof("Appending target %s to record %s, using TTL and priority of target %s", record.Data, fullRecordName, existingEndpoint.Targets[0])
+				existingEndpoint.Targets = append(existingEndpoint.Targets, strings.TrimSuffix(target, "."))
+				log.Infof("Appending target %s to record %s, using TTL and priority of target %s", target, fullRecordName, existingEndpoint.Targets[0])
 			} else {
-				ep := endpoint.NewEndpointWithTTL(fullRecordName, record.Type.String(), endpoint.TTL(record.TTL), record.Data)
-				ep = ep.WithProviderSpecific(scalewayPriorityKey, fmt.Sprintf("%d", record.Priority))
+				ep := endpoint.NewEndpointWithTTL(fullRecordName, record.Type.String(), endpoint.TTL(record.TTL), target)
+				if record.Type != domain.RecordTypeMX {
+					ep = ep.WithProviderSpecific(scalewayPriorityKey, fmt.Sprintf("%d", record.Priority))
+				}
 				endpoints[record.Type.String()+"/"+fullRecordName] = ep
 			}
 		}
@@ -318,14 +340,24 @@ func endpointToScalewayRecords(zoneName string, ep *endpoint.Endpoint) []*domain
 
 	for _, target := range ep.Targets {
 		finalTargetName := target
-		if domain.RecordType(ep.RecordType) == domain.RecordTypeCNAME {
+		targetPriority := priority
+		switch domain.RecordType(ep.RecordType) {
+		case domain.RecordTypeCNAME:
 			finalTargetName = provider.EnsureTrailingDot(target)
+		case domain.RecordTypeMX:
+			mxPriority, exchange, err := parseMXTarget(target)
+			if err != nil {
+				log.Errorf("Skipping target %s of MX record %s: %v", target, ep.DNSName, err)
+				continue
+			}
+			finalTargetName = exchange
+			targetPriority = mxPriority
 		}
 
 		records = append(records, &domain.Record{
 			Data:     finalTargetName,
 			Name:     strings.Trim(strings.TrimSuffix(ep.DNSName, zoneName), ". "),
-			Priority: priority,
+			Priority: targetPriority,
 			TTL:      ttl,
 			Type:     domain.RecordType(ep.RecordType),
 		})
@@ -339,8 +371,16 @@ func endpointToScalewayRecordsChangeDelete(zoneName string, ep *endpoint.Endpoin
 
 	for _, target := range ep.Targets {
 		finalTargetName := target
-		if domain.RecordType(ep.RecordType) == domain.RecordTypeCNAME {
+		switch domain.RecordType(ep.RecordType) {
+		case domain.RecordTypeCNAME:
 			finalTargetName = provider.EnsureTrailingDot(target)
+		case domain.RecordTypeMX:
+			_, exchange, err := parseMXTarget(target)
+			if err != nil {
+				log.Errorf("Skipping target %s of MX record %s: %v", target, ep.DNSName, err)
+				continue
+			}
+			finalTargetName = exchange
 		}
 
 		records = append(records, &domain.RecordChange{
@@ -357,6 +397,20 @@ func endpointToScalewayRecordsChangeDelete(zoneName string, ep *endpoint.Endpoin
 	return records
 }
 
+// parseMXTarget splits an MX target of the form "10 mail.example.com" into the priority and the fully qualified
+// exchange, which Scaleway stores apart.
+func parseMXTarget(target string) (uint32, string, error) {
+	priorityRaw, exchange, ok := strings.Cut(strings.TrimSpace(target), " ")
+	if !ok {
+		return 0, "", fmt.Errorf("invalid MX target %q, expected a priority and an exchange", target)
+	}
+	priority, err := strconv.ParseUint(priorityRaw, 10, 32)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid priority of MX target %q: %w", target, err)
+	}
+	return uint32(priority), provider.EnsureTrailingDot(strings.TrimSpace(exchange)), nil
+}
+
 func logChanges(req *domain.UpdateDNSZoneRecordsRequest) {
 	if !log.IsLevelEnabled(log.InfoLevel) {
 		return
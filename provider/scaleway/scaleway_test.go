@@ -83,6 +83,20 @@ func (m *mockScalewayDomain) ListDNSZoneRecords(req *domain.ListDNSZoneRecordsRe
 				Priority: 0,
 				Type:     domain.RecordTypeA,
 			},
+			{
+				Data:     "mail.example.com.",
+				Name:     "",
+				TTL:      3600,
+				Priority: 10,
+				Type:     domain.RecordTypeMX,
+			},
+			{
+				Data:     "backup.example.com.",
+				Name:     "",
+				TTL:      3600,
+				Priority: 20,
+				Type:     domain.RecordTypeMX,
+			},
 		}
 	} else if req.DNSZone == "test.example.com" {
 		records = []*domain.Record{
@@ -205,6 +219,18 @@ func TestScalewayProvider_AdjustEndpoints(t *testing.T) {
 			Targets:          []string{"1.1.1.1"},
 			ProviderSpecific: endpoint.ProviderSpecific{},
 		},
+		{
+			DNSName:    "example.com",
+			RecordTTL:  0,
+			RecordType: "MX",
+			Targets:    []string{"10 mail.example.com"},
+			ProviderSpecific: endpoint.ProviderSpecific{
+				{
+					Name:  scalewayPriorityKey,
+					Value: "10",
+				},
+			},
+		},
 	}
 
 	expected := []*endpoint.Endpoint{
@@ -244,6 +270,13 @@ func TestScalewayProvider_AdjustEndpoints(t *testing.T) {
 				},
 			},
 		},
+		{
+			DNSName:          "example.com",
+			RecordTTL:        300,
+			RecordType:       "MX",
+			Targets:          []string{"10 mail.example.com"},
+			ProviderSpecific: endpoint.ProviderSpecific{},
+		},
 	}
 
 	after, err := provider.AdjustEndpoints(before)
@@ -314,6 +347,13 @@ func TestScalewayProvider_Records(t *testing.T) {
 				},
 			},
 		},
+		{
+			DNSName:          "example.com",
+			RecordTTL:        3600,
+			RecordType:       "MX",
+			Targets:          []string{"10 mail.example.com", "20 backup.example.com"},
+			ProviderSpecific: nil,
+		},
 		{
 			DNSName:    "test.example.com",
 			RecordTTL:  300,
@@ -575,6 +615,76 @@ func TestScalewayProvider_generateApplyRequests(t *testing.T) {
 	assert.Equal(t, 0, total)
 }
 
+func TestScalewayProvider_generateApplyRequestsMX(t *testing.T) {
+	mocked := mockScalewayDomain{nil}
+	provider := &ScalewayProvider{
+		domainAPI:    &mocked,
+		domainFilter: endpoint.NewDomainFilter([]string{"dummy.me"}),
+	}
+
+	expected := []*domain.UpdateDNSZoneRecordsRequest{
+		{
+			DNSZone: "dummy.me",
+			Changes: []*domain.RecordChange{
+				{
+					Add: &domain.RecordChangeAdd{
+						Records: []*domain.Record{
+							{
+								Data:     "mail.dummy.me.",
+								Name:     "",
+								TTL:      3600,
+								Type:     domain.RecordTypeMX,
+								Priority: 10,
+							},
+							{
+								Data:     "backup.dummy.me.",
+								Name:     "",
+								TTL:      3600,
+								Type:     domain.RecordTypeMX,
+								Priority: 20,
+							},
+						},
+					},
+				},
+				{
+					Delete: &domain.RecordChangeDelete{
+						IDFields: &domain.RecordIdentifier{
+							Data: scw.StringPtr("old.dummy.me."),
+							Name: "",
+							Type: domain.RecordTypeMX,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	changes := &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{
+			{
+				DNSName:    "dummy.me",
+				RecordType: "MX",
+				RecordTTL:  3600,
+				Targets:    []string{"10 old.dummy.me"},
+			},
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			{
+				DNSName:    "dummy.me",
+				RecordType: "MX",
+				RecordTTL:  3600,
+				// the invalid target is skipped
+				Targets: []string{"10 mail.dummy.me", "20 backup.dummy.me", "mail.dummy.me"},
+			},
+		},
+	}
+
+	requests, err := provider.generateApplyRequests(context.TODO(), changes)
+	require.NoError(t, err)
+	require.Len(t, requests, len(expected))
+	assert.True(t, checkScalewayReqChanges(requests[0], expected[0]))
+}
+
 func checkRecordEquality(record1, record2 *endpoint.Endpoint) bool {
 	return record1.Targets.Same(record2.Targets) &&
 		record1.DNSName == record2.DNSName &&
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -31,6 +32,9 @@ import (
 	"sigs.k8s.io/external-dns/provider"
 )
 
+// errZoneNotFound is returned for the endpoints outside of the zones of the account
+var errZoneNotFound = errors.New("could not find zoneName")
+
 const (
 	// 60 seconds is the current minimal TTL for TransIP and will replace unconfigured
 	// TTL's for Endpoints
@@ -84,7 +88,8 @@ func NewTransIPProvider(accountName, privateKeyFile string, domainFilter endpoin
 	}, nil
 }
 
-// ApplyChanges applies a given set of changes in a given zone.
+// ApplyChanges applies a given set of changes. TransIP replaces the DNS entries of a zone as a whole, so the changes
+// are applied to the current entries of each zone, which are then replaced in a single call per zone.
 func (p *TransIPProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
 	// fetch all zones we currently have
 	// this does NOT include any DNS entries, so we'll have to fetch these for
@@ -103,161 +108,114 @@ func (p *TransIPProvider) ApplyChanges(ctx context.Context, changes *plan.Change
 	}
 	p.zoneMap = zoneMap
 
-	// first remove obsolete DNS records
-	for _, ep := range changes.Delete {
-		epLog := log.WithFields(log.Fields{
-			"record": ep.DNSName,
-			"type":   ep.RecordType,
-		})
-		epLog.Info("endpoint has to go")
-
-		zoneName, entries, err := p.entriesForEndpoint(ep)
+	// current and desired DNS entries of the changed zones
+	var zoneNames []string
+	currentEntries := map[string][]domain.DNSEntry{}
+	desiredEntries := map[string][]domain.DNSEntry{}
+	entriesForZone := func(ep *endpoint.Endpoint) (string, error) {
+		zoneName, err := p.zoneNameForDNSName(ep.DNSName)
 		if err != nil {
-			epLog.WithError(err).Error("could not get DNS entries")
-			return err
+			return "", err
 		}
-
-		epLog = epLog.WithField("zone", zoneName)
-
-		if len(entries) == 0 {
-			epLog.Info("no matching entries found")
-			continue
-		}
-
-		if p.dryRun {
-			epLog.Info("not removing DNS entries in dry-run mode")
-			continue
-		}
-
-		for _, entry := range entries {
-			log.WithFields(log.Fields{
-				"domain":  zoneName,
-				"name":    entry.Name,
-				"type":    entry.Type,
-				"content": entry.Content,
-				"ttl":     entry.Expire,
-			}).Info("removing DNS entry")
-
-			err = p.domainRepo.RemoveDNSEntry(zoneName, entry)
+		if _, ok := currentEntries[zoneName]; !ok {
+			entries, err := p.domainRepo.GetDNSEntries(zoneName)
 			if err != nil {
-				epLog.WithError(err).Error("could not remove DNS entry")
-				return err
+				return "", fmt.Errorf("could not get DNS entries of zone %s: %w", zoneName, err)
 			}
+			zoneNames = append(zoneNames, zoneName)
+			currentEntries[zoneName] = entries
+			desiredEntries[zoneName] = slices.Clone(entries)
 		}
+		return zoneName, nil
 	}
 
-	// then create new DNS records
-	for _, ep := range changes.Create {
+	// first remove the obsolete DNS entries and the ones being updated
+	for _, ep := range slices.Concat(changes.Delete, changes.UpdateNew) {
 		epLog := log.WithFields(log.Fields{
 			"record": ep.DNSName,
 			"type":   ep.RecordType,
 		})
-		epLog.Info("endpoint should be created")
-
-		zoneName, err := p.zoneNameForDNSName(ep.DNSName)
+		zoneName, err := entriesForZone(ep)
 		if err != nil {
-			epLog.WithError(err).Warn("could not find zone for endpoint")
-			continue
-		}
-
-		epLog = epLog.WithField("zone", zoneName)
-
-		if p.dryRun {
-			epLog.Info("not adding DNS entries in dry-run mode")
-			continue
-		}
-
-		for _, entry := range dnsEntriesForEndpoint(ep, zoneName) {
-			log.WithFields(log.Fields{
-				"domain":  zoneName,
-				"name":    entry.Name,
-				"type":    entry.Type,
-				"content": entry.Content,
-				"ttl":     entry.Expire,
-			}).Info("creating DNS entry")
-
-			err = p.domainRepo.AddDNSEntry(zoneName, entry)
-			if err != nil {
-				epLog.WithError(err).Error("could not add DNS entry")
-				return err
+			if errors.Is(err, errZoneNotFound) {
+				epLog.WithError(err).Warn("could not find zone for endpoint")
+				continue
 			}
+			return err
 		}
+
+		name := recordNameForEndpoint(ep, zoneName)
+		desiredEntries[zoneName] = slices.DeleteFunc(desiredEntries[zoneName], func(entry domain.DNSEntry) bool {
+			return entry.Name == name && entry.Type == ep.RecordType
+		})
 	}
 
-	// then update existing DNS records
-	for _, ep := range changes.UpdateNew {
+	// then add the new and updated DNS entries
+	for _, ep := range slices.Concat(changes.UpdateNew, changes.Create) {
 		epLog := log.WithFields(log.Fields{
 			"record": ep.DNSName,
 			"type":   ep.RecordType,
 		})
-		epLog.Debug("endpoint needs updating")
-
-		zoneName, entries, err := p.entriesForEndpoint(ep)
+		zoneName, err := entriesForZone(ep)
 		if err != nil {
-			epLog.WithError(err).Error("could not get DNS entries")
+			if errors.Is(err, errZoneNotFound) {
+				epLog.WithError(err).Warn("could not find zone for endpoint")
+				continue
+			}
 			return err
 		}
 
-		epLog = epLog.WithField("zone", zoneName)
-
-		if len(entries) == 0 {
-			epLog.Info("no matching entries found")
-			continue
-		}
+		desiredEntries[zoneName] = append(desiredEntries[zoneName], dnsEntriesForEndpoint(ep, zoneName)...)
+	}
 
-		newEntries := dnsEntriesForEndpoint(ep, zoneName)
+	for _, zoneName := range zoneNames {
+		zoneLog := log.WithField("zone", zoneName)
 
 		// check to see if actually anything changed in the DNSEntry set
-		if dnsEntriesAreEqual(newEntries, entries) {
-			epLog.Debug("not updating identical DNS entries")
+		if dnsEntriesAreEqual(desiredEntries[zoneName], currentEntries[zoneName]) {
+			zoneLog.Debug("not replacing identical DNS entries")
 			continue
 		}
 
-		if p.dryRun {
-			epLog.Info("not updating DNS entries in dry-run mode")
-			continue
+		for _, entry := range currentEntries[zoneName] {
+			if !slices.Contains(desiredEntries[zoneName], entry) {
+				zoneLog.WithFields(dnsEntryFields(entry)).Info("removing DNS entry")
+			}
 		}
-
-		// TransIP API client does have an UpdateDNSEntry call but that does only
-		// allow you to update the content of a DNSEntry, not the TTL
-		// to work around this, remove the old entry first and add the new entry
-		for _, entry := range entries {
-			log.WithFields(log.Fields{
-				"domain":  zoneName,
-				"name":    entry.Name,
-				"type":    entry.Type,
-				"content": entry.Content,
-				"ttl":     entry.Expire,
-			}).Info("removing DNS entry")
-
-			err = p.domainRepo.RemoveDNSEntry(zoneName, entry)
-			if err != nil {
-				epLog.WithError(err).Error("could not remove DNS entry")
-				return err
+		for _, entry := range desiredEntries[zoneName] {
+			if !slices.Contains(currentEntries[zoneName], entry) {
+				zoneLog.WithFields(dnsEntryFields(entry)).Info("adding DNS entry")
 			}
 		}
 
-		for _, entry := range newEntries {
-			log.WithFields(log.Fields{
-				"domain":  zoneName,
-				"name":    entry.Name,
-				"type":    entry.Type,
-				"content": entry.Content,
-				"ttl":     entry.Expire,
-			}).Info("adding DNS entry")
+		if p.dryRun {
+			zoneLog.Info("not replacing DNS entries in dry-run mode")
+			continue
+		}
 
-			err = p.domainRepo.AddDNSEntry(zoneName, entry)
-			if err != nil {
-				epLog.WithError(err).Error("could not add DNS entry")
-				return err
-			}
+		if err := p.domainRepo.ReplaceDNSEntries(zoneName, desiredEntries[zoneName]); err != nil {
+			zoneLog.WithError(err).Error("could not replace DNS entries")
+			return err
 		}
 	}
 
 	return nil
 }
 
-// Records returns the list of records in all zones
+// SupportedRecordType returns true for the record types of the default record
+// filter and for MX records, whose DNS entries hold the priority in their content
+
+func (p *TransIPProvider) SupportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeMX:
+		return true
+	default:
+		return provider.SupportedRecordType(recordType)
+	}
+}
+
+// Records returns the list of records in all zones, the DNS entries of a name
+// and type being merged into a single endpoint
 func (p *TransIPProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	zones, err := p.domainRepo.GetAll()
 	if err != nil {
@@ -272,43 +230,34 @@ func (p *TransIPProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, er
 			return nil, err
 		}
 
+		byNameType := map[string]*endpoint.Endpoint{}
 		for _, r := range entries {
-			if !provider.SupportedRecordType(r.Type) {
+			if !p.SupportedRecordType(r.Type) {
 				continue
 			}
 
 			name := endpointNameForRecord(r, zone.Name)
-			endpoints = append(endpoints, endpoint.NewEndpointWithTTL(name, r.Type, endpoint.TTL(r.Expire), r.Content))
+			target := targetForRecord(r)
+			if ep, ok := byNameType[name+"/"+r.Type]; ok {
+				ep.Targets = append(ep.Targets, target)
+				continue
+			}
+			ep := endpoint.NewEndpointWithTTL(name, r.Type, endpoint.TTL(r.Expire), target)
+			byNameType[name+"/"+r.Type] = ep
+			endpoints = append(endpoints, ep)
 		}
 	}
 
 	return endpoints, nil
 }
 
-func (p *TransIPProvider) entriesForEndpoint(ep *endpoint.Endpoint) (string, []domain.DNSEntry, error) {
-	zoneName, err := p.zoneNameForDNSName(ep.DNSName)
-	if err != nil {
-		return "", nil, err
-	}
-
-	epName := recordNameForEndpoint(ep, zoneName)
-	dnsEntries, err := p.domainRepo.GetDNSEntries(zoneName)
-	if err != nil {
-		return zoneName, nil, err
-	}
-
-	matches := []domain.DNSEntry{}
-	for _, entry := range dnsEntries {
-		if ep.RecordType != entry.Type {
-			continue
-		}
-
-		if entry.Name == epName {
-			matches = append(matches, entry)
-		}
+func dnsEntryFields(entry domain.DNSEntry) log.Fields {
+	return log.Fields{
+		"name":    entry.Name,
+		"type":    entry.Type,
+		"content": entry.Content,
+		"ttl":     entry.Expire,
 	}
-
-	return zoneName, matches, nil
 }
 
 // endpointNameForRecord returns "www.example.org" for DNSEntry with Name "www" and
@@ -323,6 +272,17 @@ func endpointNameForRecord(r domain.DNSEntry, zoneName string) string {
 	return fmt.Sprintf("%s.%s", r.Name, zoneName)
 }
 
+// targetForRecord returns the endpoint target of a DNSEntry, the hostnames of
+// CNAME, MX and SRV entries being returned without their trailing dot
+func targetForRecord(r domain.DNSEntry) string {
+	switch r.Type {
+	case endpoint.RecordTypeCNAME, endpoint.RecordTypeMX, endpoint.RecordTypeSRV:
+		return strings.TrimSuffix(r.Content, ".")
+	}
+
+	return r.Content
+}
+
 // recordNameForEndpoint returns "www" for Endpoint with DNSName "www.example.org"
 // and Domain with Name "example.org"
 func recordNameForEndpoint(ep *endpoint.Endpoint, zoneName string) string {
@@ -386,8 +346,10 @@ func dnsEntriesForEndpoint(ep *endpoint.Endpoint, zoneName string) []domain.DNSE
 
 	entries := []domain.DNSEntry{}
 	for _, target := range ep.Targets {
-		// external hostnames require a trailing dot in TransIP API
-		if ep.RecordType == "CNAME" {
+		// external hostnames require a trailing dot in TransIP API, MX and SRV
+		// targets ending with the hostname, e.g. "10 mail.example.org"
+		switch ep.RecordType {
+		case endpoint.RecordTypeCNAME, endpoint.RecordTypeMX, endpoint.RecordTypeSRV:
 			target = provider.EnsureTrailingDot(target)
 		}
 
@@ -407,7 +369,7 @@ func dnsEntriesForEndpoint(ep *endpoint.Endpoint, zoneName string) []domain.DNSE
 func (p *TransIPProvider) zoneNameForDNSName(name string) (string, error) {
 	_, zoneName := p.zoneMap.FindZone(name)
 	if zoneName == "" {
-		return "", fmt.Errorf("could not find zoneName for %s", name)
+		return "", fmt.Errorf("%w for %s", errZoneNotFound, name)
 	}
 
 	return zoneName, nil
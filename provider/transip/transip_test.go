@@ -29,6 +29,7 @@ import (
 	"github.com/transip/gotransip/v6/rest"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
 
@@ -192,6 +193,7 @@ func TestZoneNameForDNSName(t *testing.T) {
 // fakeClient mocks the REST API client
 type fakeClient struct {
 	getFunc func(rest.Request, interface{}) error
+	putFunc func(rest.Request) error
 }
 
 func (f *fakeClient) Get(request rest.Request, dest interface{}) error {
@@ -203,7 +205,11 @@ func (f *fakeClient) Get(request rest.Request, dest interface{}) error {
 }
 
 func (f fakeClient) Put(request rest.Request) error {
-	return errors.New("PUT not implemented")
+	if f.putFunc == nil {
+		return errors.New("PUT not implemented")
+	}
+
+	return f.putFunc(request)
 }
 
 func (f fakeClient) Post(request rest.Request) error {
@@ -256,95 +262,147 @@ func TestProviderRecords(t *testing.T) {
 
 	endpoints, err := p.Records(context.TODO())
 	if assert.NoError(t, err) {
-		if assert.Len(t, endpoints, 4) {
+		if assert.Len(t, endpoints, 6) {
 			assert.Equal(t, "www.example.org", endpoints[0].DNSName)
 			assert.Equal(t, "@", endpoints[0].Targets[0])
 			assert.Equal(t, "CNAME", endpoints[0].RecordType)
 			assert.Empty(t, endpoints[0].Labels)
 			assert.EqualValues(t, 1234, endpoints[0].RecordTTL)
+			assert.Equal(t, "MX", endpoints[1].RecordType)
 		}
 	}
 }
 
-func TestProviderEntriesForEndpoint(t *testing.T) {
-	// set up fake REST client
+func TestProviderRecordsMergesEntries(t *testing.T) {
 	client := &fakeClient{}
+	client.getFunc = func(req rest.Request, dest interface{}) error {
+		var data []byte
+		switch {
+		case req.Endpoint == "/domains":
+			data = []byte(`{"domains":[{"name":"example.org"}]}`)
+		case strings.HasSuffix(req.Endpoint, "/dns"):
+			data = []byte(`{"dnsEntries":[
+				{"name":"www", "expire":300, "type":"A", "content":"1.2.3.4"},
+				{"name":"@", "expire":300, "type":"MX", "content":"10 mail.example.org."},
+				{"name":"www", "expire":300, "type":"A", "content":"1.2.3.5"},
+				{"name":"_sip._tcp", "expire":300, "type":"SRV", "content":"10 5 5060 sip.example.org."}
+			]}`)
+		}
+
+		return json.Unmarshal(data, &dest)
+	}
 
-	// set up provider
 	p := newProvider()
 	p.domainRepo = domain.Repository{Client: client}
-	p.zoneMap.Add("example.com", "example.com")
 
-	// get entries for endpoint with unknown zone
-	_, _, err := p.entriesForEndpoint(&endpoint.Endpoint{
-		DNSName: "www.example.org",
-	})
-	if assert.Error(t, err) {
-		assert.Equal(t, "could not find zoneName for www.example.org", err.Error())
+	endpoints, err := p.Records(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("www.example.org", "A", 300, "1.2.3.4", "1.2.3.5"),
+		endpoint.NewEndpointWithTTL("example.org", "MX", 300, "10 mail.example.org"),
+		endpoint.NewEndpointWithTTL("_sip._tcp.example.org", "SRV", 300, "10 5 5060 sip.example.org"),
+	}, endpoints)
+}
+
+// newReplacingProvider returns a provider of a fake REST client serving the
+// given DNS entries of example.com, and recording the replaced entries
+func newReplacingProvider(t *testing.T, entries []domain.DNSEntry, replaced *[]domain.DNSEntry) *TransIPProvider {
+	client := &fakeClient{}
+	client.getFunc = func(req rest.Request, dest interface{}) error {
+		var data []byte
+		switch {
+		case req.Endpoint == "/domains":
+			data = []byte(`{"domains":[{"name":"example.com"}]}`)
+		case req.Endpoint == "/domains/example.com/dns":
+			var err error
+			data, err = json.Marshal(map[string][]domain.DNSEntry{"dnsEntries": entries})
+			require.NoError(t, err)
+		default:
+			return errors.New("unexpected GET " + req.Endpoint)
+		}
+
+		return json.Unmarshal(data, &dest)
+	}
+	client.putFunc = func(req rest.Request) error {
+		assert.Equal(t, "/domains/example.com/dns", req.Endpoint)
+		data, err := json.Marshal(req.Body)
+		require.NoError(t, err)
+		var v struct {
+			DNSEntries []domain.DNSEntry `json:"dnsEntries"`
+		}
+		require.NoError(t, json.Unmarshal(data, &v))
+		*replaced = append(*replaced, v.DNSEntries...)
+		return nil
 	}
 
-	// get entries for endpoint with known zone but client returns error
-	// we leave GET functions undefined so we know which error to expect
-	zoneName, _, err := p.entriesForEndpoint(&endpoint.Endpoint{
-		DNSName: "www.example.com",
-	})
-	if assert.Error(t, err) {
-		assert.Equal(t, "GET not defined", err.Error())
+	p := newProvider()
+	p.domainRepo = domain.Repository{Client: client}
+	return p
+}
+
+func TestProviderApplyChanges(t *testing.T) {
+	entries := []domain.DNSEntry{
+		{Name: "www", Type: "A", Expire: 3600, Content: "1.2.3.4"},
+		{Name: "ftp", Type: "A", Expire: 86400, Content: "3.4.5.6"},
+		{Name: "www", Type: "A", Expire: 3600, Content: "2.3.4.5"},
+		{Name: "old", Type: "TXT", Expire: 3600, Content: "heritage=external-dns"},
+		{Name: "@", Type: "NS", Expire: 86400, Content: "ns0.transip.net."},
 	}
-	assert.Equal(t, "example.com", zoneName)
-
-	// to be able to return a valid set of DNS entries through the API, we define
-	// some first, then JSON encode them and have the fake API client's Get function
-	// return that
-	// in this set are some entries that do and others that don't match the given
-	// endpoint
-	dnsEntries := []domain.DNSEntry{
-		{
-			Name:    "www",
-			Type:    "A",
-			Expire:  3600,
-			Content: "1.2.3.4",
+	var replaced []domain.DNSEntry
+	p := newReplacingProvider(t, entries, &replaced)
+
+	err := p.ApplyChanges(context.TODO(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("example.com", "MX", "10 mail.example.com"),
+			endpoint.NewEndpointWithTTL("_sip._tcp.example.com", "SRV", 300, "10 5 5060 sip.example.com"),
+			// not in a managed zone
+			endpoint.NewEndpoint("www.example.org", "A", "1.2.3.4"),
 		},
-		{
-			Name:    "ftp",
-			Type:    "A",
-			Expire:  86400,
-			Content: "3.4.5.6",
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", "A", 3600, "1.2.3.4", "2.3.4.5"),
 		},
-		{
-			Name:    "www",
-			Type:    "A",
-			Expire:  3600,
-			Content: "2.3.4.5",
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", "A", 600, "1.2.3.4"),
 		},
-		{
-			Name:    "www",
-			Type:    "CNAME",
-			Expire:  3600,
-			Content: "@",
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("old.example.com", "TXT", "heritage=external-dns"),
 		},
-	}
-	var v struct {
-		DNSEntries []domain.DNSEntry `json:"dnsEntries"`
-	}
-	v.DNSEntries = dnsEntries
-	returnData, err := json.Marshal(&v)
+	})
 	require.NoError(t, err)
 
-	// define GET function
-	client.getFunc = func(unused rest.Request, dest interface{}) error {
-		// unmarshal the prepared return data into the given dnsEntriesWrapper
-		return json.Unmarshal(returnData, &dest)
+	// the entries of the zone are replaced in a single call
+	assert.Equal(t, []domain.DNSEntry{
+		{Name: "ftp", Type: "A", Expire: 86400, Content: "3.4.5.6"},
+		{Name: "@", Type: "NS", Expire: 86400, Content: "ns0.transip.net."},
+		{Name: "www", Type: "A", Expire: 600, Content: "1.2.3.4"},
+		{Name: "@", Type: "MX", Expire: defaultTTL, Content: "10 mail.example.com."},
+		{Name: "_sip._tcp", Type: "SRV", Expire: 300, Content: "10 5 5060 sip.example.com."},
+	}, replaced)
+}
+
+func TestProviderApplyChangesIdenticalEntries(t *testing.T) {
+	entries := []domain.DNSEntry{
+		{Name: "www", Type: "A", Expire: 3600, Content: "1.2.3.4"},
 	}
-	_, entries, err := p.entriesForEndpoint(&endpoint.Endpoint{
-		DNSName:    "www.example.com",
-		RecordType: "A",
+	var replaced []domain.DNSEntry
+	p := newReplacingProvider(t, entries, &replaced)
+
+	err := p.ApplyChanges(context.TODO(), &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("www.example.com", "A", 3600, "1.2.3.4")},
 	})
-	if assert.NoError(t, err) {
-		if assert.Len(t, entries, 2) {
-			// only first and third entry should be returned
-			assert.Equal(t, dnsEntries[0], entries[0])
-			assert.Equal(t, dnsEntries[2], entries[1])
-		}
-	}
+	require.NoError(t, err)
+	assert.Empty(t, replaced)
+}
+
+func TestProviderApplyChangesDryRun(t *testing.T) {
+	var replaced []domain.DNSEntry
+	p := newReplacingProvider(t, nil, &replaced)
+	p.dryRun = true
+
+	err := p.ApplyChanges(context.TODO(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", "A", "1.2.3.4")},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, replaced)
 }
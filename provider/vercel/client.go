@@ -0,0 +1,216 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vercel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+const (
+	// defaultEndpoint is the base URL of the Vercel REST API.
+	defaultEndpoint = "https://api.vercel.com"
+	// perPage is the page size of the listings, the maximum allowed by the API.
+	perPage = 100
+)
+
+// Domain is a domain of the account or of the team.
+type Domain struct {
+	Name string `json:"name"`
+}
+
+// Record is a DNS record of a domain. The records are flat within a domain, a record holds a single value.
+type Record struct {
+	ID         string `json:"id"`
+	Type       string `json:"type"`
+	Name       string `json:"name"`
+	Value      string `json:"value"`
+	TTL        int    `json:"ttl"`
+	MXPriority int    `json:"mxPriority"`
+}
+
+// RecordRequest is the body of the requests creating or updating a record.
+type RecordRequest struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Value      string `json:"value"`
+	TTL        int    `json:"ttl,omitempty"`
+	MXPriority *int   `json:"mxPriority,omitempty"`
+}
+
+// APIError is an error returned by the Vercel API.
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("vercel: %d %s: %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Code, e.Message)
+}
+
+// DNSClient is the interface of the Vercel DNS API used by the provider.
+type DNSClient interface {
+	ListDomains(ctx context.Context) ([]Domain, error)
+	ListRecords(ctx context.Context, domain string) ([]Record, error)
+	CreateRecord(ctx context.Context, domain string, record RecordRequest) error
+	UpdateRecord(ctx context.Context, recordID string, record RecordRequest) error
+	DeleteRecord(ctx context.Context, domain string, recordID string) error
+}
+
+// Client calls the Vercel API with an access token, in the scope of a team when its ID is set.
+type Client struct {
+	token      string
+	teamID     string
+	endpoint   string
+	httpClient *http.Client
+}
+
+// NewClient returns a client of the Vercel API authenticating with the token. The team ID is empty to manage the
+// domains of the personal account.
+func NewClient(token, teamID string, httpClient *http.Client) *Client {
+	return &Client{
+		token:      token,
+		teamID:     teamID,
+		endpoint:   defaultEndpoint,
+		httpClient: httpClient,
+	}
+}
+
+// pagination holds the pagination of the listings, the next page is requested until the timestamp of the next page
+// is null.
+type pagination struct {
+	Next *int64 `json:"next"`
+}
+
+func (c *Client) ListDomains(ctx context.Context) ([]Domain, error) {
+	var domains []Domain
+	var until *int64
+	for {
+		var page struct {
+			Domains    []Domain   `json:"domains"`
+			Pagination pagination `json:"pagination"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/v5/domains", listQuery(until), nil, &page); err != nil {
+			return nil, err
+		}
+		domains = append(domains, page.Domains...)
+		if until = page.Pagination.Next; until == nil {
+			return domains, nil
+		}
+	}
+}
+
+func (c *Client) ListRecords(ctx context.Context, domain string) ([]Record, error) {
+	var records []Record
+	var until *int64
+	for {
+		var page struct {
+			Records    []Record   `json:"records"`
+			Pagination pagination `json:"pagination"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/v4/domains/"+url.PathEscape(domain)+"/records", listQuery(until), nil, &page); err != nil {
+			return nil, err
+		}
+		records = append(records, page.Records...)
+		if until = page.Pagination.Next; until == nil {
+			return records, nil
+		}
+	}
+}
+
+func (c *Client) CreateRecord(ctx context.Context, domain string, record RecordRequest) error {
+	return c.do(ctx, http.MethodPost, "/v2/domains/"+url.PathEscape(domain)+"/records", nil, record, nil)
+}
+
+func (c *Client) UpdateRecord(ctx context.Context, recordID string, record RecordRequest) error {
+	return c.do(ctx, http.MethodPatch, "/v1/domains/records/"+url.PathEscape(recordID), nil, record, nil)
+}
+
+func (c *Client) DeleteRecord(ctx context.Context, domain string, recordID string) error {
+	return c.do(ctx, http.MethodDelete, "/v2/domains/"+url.PathEscape(domain)+"/records/"+url.PathEscape(recordID), nil, nil, nil)
+}
+
+func listQuery(until *int64) url.Values {
+	query := url.Values{"limit": {strconv.Itoa(perPage)}}
+	if until != nil {
+		query.Set("until", strconv.FormatInt(*until, 10))
+	}
+	return query
+}
+
+// do sends a request to the API, in the scope of the team, with the body encoded as JSON, and decodes the response
+// into the result.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, result any) error {
+	if c.teamID != "" {
+		if query == nil {
+			query = url.Values{}
+		}
+		query.Set("teamId", c.teamID)
+	}
+	u := c.endpoint + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", externaldns.UserAgent())
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var errResp struct {
+			Error APIError `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&errResp)
+		errResp.Error.StatusCode = resp.StatusCode
+		return &errResp.Error
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vercel
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a client of the team sending its requests to a test server running the handler.
+func newTestClient(t *testing.T, teamID string, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client := NewClient("secret", teamID, server.Client())
+	client.endpoint = server.URL
+	return client
+}
+
+func TestClientListRecordsPagination(t *testing.T) {
+	var untils []string
+	client := newTestClient(t, "team_123", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "/v4/domains/example.com/records", r.URL.Path)
+		assert.Equal(t, "team_123", r.URL.Query().Get("teamId"))
+		assert.Equal(t, "100", r.URL.Query().Get("limit"))
+		until := r.URL.Query().Get("until")
+		untils = append(untils, until)
+		if until == "" {
+			_, _ = w.Write([]byte(`{"records":[{"id":"rec_1","type":"A","name":"www","value":"1.2.3.4","ttl":60,"creator":"user"}],"pagination":{"count":1,"next":1700000000000,"prev":null}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"records":[{"id":"rec_2","type":"MX","name":"","value":"mail.example.com","mxPriority":10,"ttl":60}],"pagination":{"count":1,"next":null,"prev":1700000000001}}`))
+	})
+
+	records, err := client.ListRecords(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "1700000000000"}, untils)
+	assert.Equal(t, []Record{
+		{ID: "rec_1", Type: "A", Name: "www", Value: "1.2.3.4", TTL: 60},
+		{ID: "rec_2", Type: "MX", Name: "", Value: "mail.example.com", MXPriority: 10, TTL: 60},
+	}, records)
+}
+
+func TestClientPersonalAccount(t *testing.T) {
+	client := newTestClient(t, "", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v5/domains", r.URL.Path)
+		_, hasTeam := r.URL.Query()["teamId"]
+		assert.False(t, hasTeam)
+		_, _ = w.Write([]byte(`{"domains":[{"name":"example.com","serviceType":"zeit.world"}],"pagination":{"count":1,"next":null,"prev":null}}`))
+	})
+
+	domains, err := client.ListDomains(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Domain{{Name: "example.com"}}, domains)
+}
+
+func TestClientCreateRecord(t *testing.T) {
+	client := newTestClient(t, "team_123", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v2/domains/example.com/records", r.URL.Path)
+		assert.Equal(t, "team_123", r.URL.Query().Get("teamId"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"name":"","type":"MX","value":"mail.example.com","ttl":60,"mxPriority":10}`, string(body))
+		_, _ = w.Write([]byte(`{"uid":"rec_3","updated":1700000000000}`))
+	})
+
+	priority := 10
+	require.NoError(t, client.CreateRecord(context.Background(), "example.com", RecordRequest{Type: "MX", Value: "mail.example.com", TTL: 60, MXPriority: &priority}))
+}
+
+func TestClientAPIError(t *testing.T) {
+	client := newTestClient(t, "team_123", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/v2/domains/example.com/records/rec_1", r.URL.Path)
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":{"code":"forbidden","message":"Not authorized"}}`))
+	})
+
+	err := client.DeleteRecord(context.Background(), "example.com", "rec_1")
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	assert.EqualError(t, err, "vercel: 403 Forbidden: forbidden: Not authorized")
+}
@@ -0,0 +1,333 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vercel
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// defaultTTL is the TTL of the records without a configured TTL, and the lowest TTL Vercel accepts.
+const defaultTTL = 60
+
+// VercelProvider is an implementation of Provider for Vercel DNS.
+type VercelProvider struct {
+	provider.BaseProvider
+	Client DNSClient
+	// only consider hosted zones managing domains ending in this suffix
+	domainFilter endpoint.DomainFilter
+	DryRun       bool
+}
+
+type vercelChangeCreate struct {
+	Domain  string
+	Options RecordRequest
+}
+
+type vercelChangeUpdate struct {
+	Domain  string
+	Record  Record
+	Options RecordRequest
+}
+
+type vercelChangeDelete struct {
+	Domain string
+	Record Record
+}
+
+// vercelChanges contains all changes to apply to DNS
+type vercelChanges struct {
+	Creates []vercelChangeCreate
+	Updates []vercelChangeUpdate
+	Deletes []vercelChangeDelete
+}
+
+// NewVercelProvider initializes a new Vercel DNS based Provider. The domains of the team of VERCEL_TEAM_ID are
+// managed when it's set, the domains of the personal account otherwise.
+func NewVercelProvider(domainFilter endpoint.DomainFilter, dryRun bool) (*VercelProvider, error) {
+	token := os.Getenv("VERCEL_API_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("no API token found, set VERCEL_API_TOKEN")
+	}
+
+	return &VercelProvider{
+		Client:       NewClient(token, os.Getenv("VERCEL_TEAM_ID"), &http.Client{Timeout: 30 * time.Second}),
+		domainFilter: domainFilter,
+		DryRun:       dryRun,
+	}, nil
+}
+
+// Zones returns the list of hosted zones.
+func (p *VercelProvider) Zones(ctx context.Context) ([]string, error) {
+	domains, err := p.Client.ListDomains(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list domains: %w", err)
+	}
+
+	var zones []string
+	for _, domain := range domains {
+		if p.domainFilter.Match(domain.Name) {
+			zones = append(zones, domain.Name)
+		}
+	}
+	return zones, nil
+}
+
+// SupportedRecordType returns true for the record types managed on Vercel DNS, the records Vercel manages itself,
+// such as the ALIAS records of the deployments, are left alone.
+func (p *VercelProvider) SupportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT, endpoint.RecordTypeMX:
+		return true
+	default:
+		return false
+	}
+}
+
+// AdjustEndpoints raises the TTLs below the lowest TTL Vercel accepts, for the records not to be updated on every
+// synchronization.
+func (p *VercelProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	for _, ep := range endpoints {
+		if ep.RecordTTL.IsConfigured() && ep.RecordTTL < defaultTTL {
+			ep.RecordTTL = defaultTTL
+		}
+	}
+	return endpoints, nil
+}
+
+// Records returns the list of records in all the domains. Vercel stores a record per target, the records of a name and
+// type are merged into a single endpoint.
+func (p *VercelProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, zone := range zones {
+		records, err := p.Client.ListRecords(ctx, zone)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list records of domain %s: %w", zone, err)
+		}
+
+		for _, r := range records {
+			if !p.SupportedRecordType(r.Type) {
+				continue
+			}
+			endpoints = append(endpoints, endpoint.NewEndpointWithTTL(recordDNSName(zone, r.Name), r.Type, endpoint.TTL(r.TTL), recordTarget(r)))
+		}
+	}
+	endpoints = provider.MergeEndpointsByNameType(endpoints)
+
+	log.WithFields(log.Fields{
+		"endpoints": endpoints,
+	}).Debug("Endpoints generated from Vercel DNS")
+
+	return endpoints, nil
+}
+
+// ApplyChanges applies the given changes, listing the records of a domain once for all its changes.
+func (p *VercelProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return err
+	}
+
+	zoneNameIDMapper := provider.ZoneIDName{}
+	for _, zone := range zones {
+		zoneNameIDMapper.Add(zone, zone)
+	}
+
+	recordsByZone := map[string][]Record{}
+	records := func(zone string) ([]Record, error) {
+		if r, ok := recordsByZone[zone]; ok {
+			return r, nil
+		}
+		r, err := p.Client.ListRecords(ctx, zone)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list records of domain %s: %w", zone, err)
+		}
+		recordsByZone[zone] = r
+		return r, nil
+	}
+
+	var vercelChanges vercelChanges
+	err = provider.ForEachEndpointTargets(changes, func(ep *endpoint.Endpoint, targets []string, prune bool) error {
+		zone, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zone == "" {
+			log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", ep.DNSName)
+			return nil
+		}
+		existing, err := records(zone)
+		if err != nil {
+			return err
+		}
+		return vercelChanges.add(zone, existing, ep, targets, prune)
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.submitChanges(ctx, vercelChanges)
+}
+
+// add adds the changes turning the domain's records of the endpoint's name and type into a record per target. Vercel
+// names the records relative to their domain, the apex being the empty name.
+func (c *vercelChanges) add(zone string, existing []Record, ep *endpoint.Endpoint, targets []string, prune bool) error {
+	name := recordName(zone, ep.DNSName)
+	current := slices.DeleteFunc(slices.Clone(existing), func(r Record) bool {
+		return r.Name != name || r.Type != ep.RecordType
+	})
+	diff := provider.DiffRecords(current, targets, prune, recordTarget, func(r Record) bool {
+		return ep.RecordTTL.IsConfigured() && r.TTL != int(ep.RecordTTL)
+	})
+
+	for _, target := range diff.Create {
+		options, err := newRecordRequest(name, ep.RecordType, target, ep.RecordTTL)
+		if err != nil {
+			return fmt.Errorf("invalid target %q of %s record %s: %w", target, ep.RecordType, ep.DNSName, err)
+		}
+		c.Creates = append(c.Creates, vercelChangeCreate{Domain: zone, Options: options})
+	}
+	for _, update := range diff.Update {
+		options, err := newRecordRequest(name, ep.RecordType, update.Target, ep.RecordTTL)
+		if err != nil {
+			return fmt.Errorf("invalid target %q of %s record %s: %w", update.Target, ep.RecordType, ep.DNSName, err)
+		}
+		c.Updates = append(c.Updates, vercelChangeUpdate{Domain: zone, Record: update.Record, Options: options})
+	}
+	for _, record := range diff.Delete {
+		c.Deletes = append(c.Deletes, vercelChangeDelete{Domain: zone, Record: record})
+	}
+	return nil
+}
+
+// submitChanges submits the changes to the Vercel API one record at a time. The deletions come first, Vercel refusing
+// a CNAME record next to the other records of its name.
+func (p *VercelProvider) submitChanges(ctx context.Context, changes vercelChanges) error {
+	for _, change := range changes.Deletes {
+		logFields := log.Fields{
+			"record":   change.Record.Name,
+			"type":     change.Record.Type,
+			"target":   change.Record.Value,
+			"action":   "Delete",
+			"zoneName": change.Domain,
+		}
+		log.WithFields(logFields).Info("Deleting record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.DeleteRecord(ctx, change.Domain, change.Record.ID); err != nil {
+			return fmt.Errorf("failed to delete %s record %s of domain %s: %w", change.Record.Type, change.Record.Name, change.Domain, err)
+		}
+	}
+
+	for _, change := range changes.Updates {
+		logFields := log.Fields{
+			"record":   change.Record.Name,
+			"type":     change.Record.Type,
+			"target":   change.Options.Value,
+			"ttl":      change.Options.TTL,
+			"action":   "Update",
+			"zoneName": change.Domain,
+		}
+		log.WithFields(logFields).Info("Updating record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.UpdateRecord(ctx, change.Record.ID, change.Options); err != nil {
+			return fmt.Errorf("failed to update %s record %s of domain %s: %w", change.Record.Type, change.Record.Name, change.Domain, err)
+		}
+	}
+
+	for _, change := range changes.Creates {
+		logFields := log.Fields{
+			"record":   change.Options.Name,
+			"type":     change.Options.Type,
+			"target":   change.Options.Value,
+			"action":   "Create",
+			"zoneName": change.Domain,
+		}
+		log.WithFields(logFields).Info("Creating record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.CreateRecord(ctx, change.Domain, change.Options); err != nil {
+			return fmt.Errorf("failed to create %s record %s of domain %s: %w", change.Options.Type, change.Options.Name, change.Domain, err)
+		}
+	}
+
+	return nil
+}
+
+// recordName returns the name of a record relative to its domain, the apex being the empty name.
+func recordName(zone, dnsName string) string {
+	if dnsName == zone {
+		return ""
+	}
+	return strings.TrimSuffix(dnsName, "."+zone)
+}
+
+// recordDNSName returns the DNS name of a record of a domain.
+func recordDNSName(zone, name string) string {
+	if name == "" || name == "@" {
+		return zone
+	}
+	return name + "." + zone
+}
+
+// recordTarget returns the endpoint target of a record, the priority of MX records is stored apart from their value.
+func recordTarget(r Record) string {
+	if r.Type == endpoint.RecordTypeMX {
+		return fmt.Sprintf("%d %s", r.MXPriority, r.Value)
+	}
+	return r.Value
+}
+
+// newRecordRequest returns the request creating the record of an endpoint target. MX targets are of the form
+// "10 mail.example.com", the priority coming first.
+func newRecordRequest(name, recordType, target string, ttl endpoint.TTL) (RecordRequest, error) {
+	request := RecordRequest{Name: name, Type: recordType, Value: target, TTL: defaultTTL}
+	if ttl.IsConfigured() {
+		request.TTL = int(ttl)
+	}
+	if recordType == endpoint.RecordTypeMX {
+		priorityRaw, value, ok := strings.Cut(target, " ")
+		if !ok {
+			return RecordRequest{}, fmt.Errorf("the priority is missing")
+		}
+		priority, err := strconv.Atoi(priorityRaw)
+		if err != nil {
+			return RecordRequest{}, fmt.Errorf("invalid priority %q", priorityRaw)
+		}
+		request.MXPriority = &priority
+		request.Value = value
+	}
+	return request, nil
+}
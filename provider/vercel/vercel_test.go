@@ -0,0 +1,252 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vercel
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// fakeVercelAPI is an in-memory Vercel DNS API, scoped to a team.
+type fakeVercelAPI struct {
+	t       *testing.T
+	records map[string][]Record
+	nextID  int
+	writes  int
+}
+
+// newFakeVercelAPI returns a fake API serving the test records.
+func newFakeVercelAPI(t *testing.T) *fakeVercelAPI {
+	return &fakeVercelAPI{t: t, records: testVercelRecords(), nextID: 100}
+}
+
+func (f *fakeVercelAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	assert.Equal(f.t, "Bearer secret", r.Header.Get("Authorization"))
+	if r.URL.Query().Get("teamId") != "team_123" {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":{"code":"forbidden","message":"Not authorized"}}`))
+		return
+	}
+	write := func(v any) {
+		require.NoError(f.t, json.NewEncoder(w).Encode(v))
+	}
+	notFound := func() {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":"not_found","message":"The DNS record was not found"}}`))
+	}
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case r.URL.Path == "/v5/domains":
+		var domains []Domain
+		for domain := range f.records {
+			domains = append(domains, Domain{Name: domain})
+		}
+		sort.Slice(domains, func(i, j int) bool { return domains[i].Name < domains[j].Name })
+		write(map[string]any{"domains": domains, "pagination": map[string]any{"next": nil}})
+	case len(parts) == 4 && parts[0] == "v4" && r.Method == http.MethodGet:
+		write(map[string]any{"records": f.records[parts[2]], "pagination": map[string]any{"next": nil}})
+	case len(parts) == 4 && parts[0] == "v2" && r.Method == http.MethodPost:
+		var body RecordRequest
+		require.NoError(f.t, json.NewDecoder(r.Body).Decode(&body))
+		f.nextID++
+		f.writes++
+		record := Record{ID: "rec_" + strconv.Itoa(f.nextID), Type: body.Type, Name: body.Name, Value: body.Value, TTL: body.TTL}
+		if body.MXPriority != nil {
+			record.MXPriority = *body.MXPriority
+		}
+		f.records[parts[2]] = append(f.records[parts[2]], record)
+		write(map[string]any{"uid": record.ID})
+	case len(parts) == 5 && parts[0] == "v2" && r.Method == http.MethodDelete:
+		domain := parts[2]
+		i := slices.IndexFunc(f.records[domain], func(record Record) bool { return record.ID == parts[4] })
+		if i < 0 {
+			notFound()
+			return
+		}
+		f.writes++
+		f.records[domain] = slices.Delete(f.records[domain], i, i+1)
+		write(map[string]any{})
+	case len(parts) == 4 && parts[0] == "v1" && r.Method == http.MethodPatch:
+		var body RecordRequest
+		require.NoError(f.t, json.NewDecoder(r.Body).Decode(&body))
+		for _, records := range f.records {
+			i := slices.IndexFunc(records, func(record Record) bool { return record.ID == parts[3] })
+			if i < 0 {
+				continue
+			}
+			f.writes++
+			records[i].Value = body.Value
+			records[i].TTL = body.TTL
+			write(records[i])
+			return
+		}
+		notFound()
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func testVercelRecords() map[string][]Record {
+	return map[string][]Record{
+		"example.com": {
+			{ID: "rec_a", Type: "ALIAS", Name: "", Value: "cname.vercel-dns.com", TTL: 60},
+			{ID: "rec_b", Type: "A", Name: "", Value: "1.2.3.4", TTL: 60},
+			{ID: "rec_c", Type: "A", Name: "www", Value: "1.2.3.4", TTL: 300},
+			{ID: "rec_d", Type: "A", Name: "www", Value: "1.2.3.5", TTL: 300},
+			{ID: "rec_e", Type: "TXT", Name: "www", Value: "heritage=external-dns", TTL: 300},
+			{ID: "rec_f", Type: "MX", Name: "", Value: "mail.example.com", MXPriority: 10, TTL: 3600},
+		},
+		"example.org": {
+			{ID: "rec_g", Type: "CNAME", Name: "www", Value: "example.com", TTL: 60},
+		},
+	}
+}
+
+func newTestProvider(t *testing.T, api *fakeVercelAPI, domains ...string) *VercelProvider {
+	return &VercelProvider{
+		Client:       newTestClient(t, "team_123", api.ServeHTTP),
+		domainFilter: endpoint.NewDomainFilter(domains),
+	}
+}
+
+func TestNewVercelProvider(t *testing.T) {
+	t.Setenv("VERCEL_API_TOKEN", "secret")
+	t.Setenv("VERCEL_TEAM_ID", "team_123")
+	p, err := NewVercelProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.NoError(t, err)
+	assert.Equal(t, "team_123", p.Client.(*Client).teamID)
+
+	t.Setenv("VERCEL_API_TOKEN", "")
+	_, err = NewVercelProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.EqualError(t, err, "no API token found, set VERCEL_API_TOKEN")
+}
+
+func TestVercelProviderAdjustEndpoints(t *testing.T) {
+	p := &VercelProvider{}
+
+	endpoints, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("unset.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("low.example.com", endpoint.RecordTypeA, 30, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("high.example.com", endpoint.RecordTypeA, 300, "1.2.3.4"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, endpoint.TTL(0), endpoints[0].RecordTTL)
+	assert.Equal(t, endpoint.TTL(60), endpoints[1].RecordTTL)
+	assert.Equal(t, endpoint.TTL(300), endpoints[2].RecordTTL)
+}
+
+func TestVercelProviderRecords(t *testing.T) {
+	p := newTestProvider(t, newFakeVercelAPI(t), "example.com")
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 60, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeTXT, 300, "heritage=external-dns"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+	}, endpoints)
+}
+
+func TestVercelProviderApplyChanges(t *testing.T) {
+	api := newFakeVercelAPI(t)
+	p := newTestProvider(t, api, "example.com", "example.org")
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeAAAA, "2001:db8::1", "2001:db8::2"),
+			endpoint.NewEndpointWithTTL("example.org", endpoint.RecordTypeTXT, 600, "v=spf1 -all"),
+			// not in a managed zone
+			endpoint.NewEndpoint("www.example.net", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5"),
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.5", "1.2.3.6"),
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 900, "10 mail.example.com", "20 backup.example.com"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeTXT, "heritage=external-dns"),
+			endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeCNAME, "example.com"),
+		},
+	}))
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 60, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.5", "1.2.3.6"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 900, "10 mail.example.com", "20 backup.example.com"),
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeAAAA, defaultTTL, "2001:db8::1", "2001:db8::2"),
+		endpoint.NewEndpointWithTTL("example.org", endpoint.RecordTypeTXT, 600, "v=spf1 -all"),
+	}, endpoints)
+	// the unchanged target of www.example.com is kept
+	assert.Contains(t, api.records["example.com"], Record{ID: "rec_d", Type: "A", Name: "www", Value: "1.2.3.5", TTL: 300})
+	// the TTL of the MX record is updated in place
+	assert.Contains(t, api.records["example.com"], Record{ID: "rec_f", Type: "MX", Name: "", Value: "mail.example.com", MXPriority: 10, TTL: 900})
+	// the records managed by Vercel are left alone
+	assert.Contains(t, api.records["example.com"], Record{ID: "rec_a", Type: "ALIAS", Name: "", Value: "cname.vercel-dns.com", TTL: 60})
+}
+
+func TestVercelProviderApplyChangesDryRun(t *testing.T) {
+	api := newFakeVercelAPI(t)
+	p := newTestProvider(t, api, "example.com")
+	p.DryRun = true
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.4", "1.2.3.5")},
+	}))
+	assert.Zero(t, api.writes)
+}
+
+func TestVercelProviderApplyChangesInvalidTarget(t *testing.T) {
+	api := newFakeVercelAPI(t)
+	p := newTestProvider(t, api, "example.com")
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "mail.example.com")},
+	})
+	require.EqualError(t, err, `invalid target "mail.example.com" of MX record example.com: the priority is missing`)
+	assert.Zero(t, api.writes)
+}
+
+func TestVercelProviderWrongTeam(t *testing.T) {
+	api := newFakeVercelAPI(t)
+	p := &VercelProvider{
+		Client:       newTestClient(t, "team_other", api.ServeHTTP),
+		domainFilter: endpoint.NewDomainFilter([]string{"example.com"}),
+	}
+
+	_, err := p.Records(context.Background())
+	require.EqualError(t, err, "failed to list domains: vercel: 403 Forbidden: forbidden: Not authorized")
+}
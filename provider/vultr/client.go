@@ -0,0 +1,203 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vultr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+const (
+	// defaultEndpoint is the base URL of the Vultr API.
+	defaultEndpoint = "https://api.vultr.com/v2"
+	// perPage is the page size of the listings, the maximum allowed by the API.
+	perPage = 500
+)
+
+// Domain is a DNS domain of the account.
+type Domain struct {
+	Domain string `json:"domain"`
+}
+
+// Record is a DNS record of a domain. The records are flat within a domain, a record holds a single value.
+type Record struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Data     string `json:"data"`
+	Priority int    `json:"priority"`
+	TTL      int    `json:"ttl"`
+}
+
+// RecordRequest is the body of the requests creating or updating a record.
+type RecordRequest struct {
+	Name     string `json:"name"`
+	Type     string `json:"type,omitempty"`
+	Data     string `json:"data"`
+	TTL      int    `json:"ttl,omitempty"`
+	Priority *int   `json:"priority,omitempty"`
+}
+
+// APIError is an error returned by the Vultr API.
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Message    string `json:"error"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("vultr: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// DNSClient is the interface of the Vultr DNS API used by the provider.
+type DNSClient interface {
+	ListDomains(ctx context.Context) ([]Domain, error)
+	ListRecords(ctx context.Context, domain string) ([]Record, error)
+	CreateRecord(ctx context.Context, domain string, record RecordRequest) error
+	UpdateRecord(ctx context.Context, domain string, recordID string, record RecordRequest) error
+	DeleteRecord(ctx context.Context, domain string, recordID string) error
+}
+
+// Client calls the Vultr API with an API key.
+type Client struct {
+	apiKey     string
+	endpoint   string
+	httpClient *http.Client
+}
+
+// NewClient returns a client of the Vultr API authenticating with the API key.
+func NewClient(apiKey string, httpClient *http.Client) *Client {
+	return &Client{
+		apiKey:     apiKey,
+		endpoint:   defaultEndpoint,
+		httpClient: httpClient,
+	}
+}
+
+// listMeta holds the pagination of the listings, the next page is requested with the next cursor until it's empty.
+type listMeta struct {
+	Links struct {
+		Next string `json:"next"`
+	} `json:"links"`
+}
+
+func (c *Client) ListDomains(ctx context.Context) ([]Domain, error) {
+	var domains []Domain
+	cursor := ""
+	for {
+		var page struct {
+			Domains []Domain `json:"domains"`
+			Meta    listMeta `json:"meta"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/domains", listQuery(cursor), nil, &page); err != nil {
+			return nil, err
+		}
+		domains = append(domains, page.Domains...)
+		if cursor = page.Meta.Links.Next; cursor == "" {
+			return domains, nil
+		}
+	}
+}
+
+func (c *Client) ListRecords(ctx context.Context, domain string) ([]Record, error) {
+	var records []Record
+	cursor := ""
+	for {
+		var page struct {
+			Records []Record `json:"records"`
+			Meta    listMeta `json:"meta"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/domains/"+url.PathEscape(domain)+"/records", listQuery(cursor), nil, &page); err != nil {
+			return nil, err
+		}
+		records = append(records, page.Records...)
+		if cursor = page.Meta.Links.Next; cursor == "" {
+			return records, nil
+		}
+	}
+}
+
+func (c *Client) CreateRecord(ctx context.Context, domain string, record RecordRequest) error {
+	return c.do(ctx, http.MethodPost, "/domains/"+url.PathEscape(domain)+"/records", nil, record, nil)
+}
+
+func (c *Client) UpdateRecord(ctx context.Context, domain string, recordID string, record RecordRequest) error {
+	return c.do(ctx, http.MethodPatch, "/domains/"+url.PathEscape(domain)+"/records/"+url.PathEscape(recordID), nil, record, nil)
+}
+
+func (c *Client) DeleteRecord(ctx context.Context, domain string, recordID string) error {
+	return c.do(ctx, http.MethodDelete, "/domains/"+url.PathEscape(domain)+"/records/"+url.PathEscape(recordID), nil, nil, nil)
+}
+
+func listQuery(cursor string) url.Values {
+	query := url.Values{"per_page": {strconv.Itoa(perPage)}}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	return query
+}
+
+// do sends a request to the API, with the body encoded as JSON, and decodes the response into the result.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, result any) error {
+	u := c.endpoint + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", externaldns.UserAgent())
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		_ = json.NewDecoder(resp.Body).Decode(apiErr)
+		return apiErr
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
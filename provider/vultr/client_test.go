@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vultr
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTripFunc is an http.RoundTripper answering the requests with a function.
+type roundTripFunc func(req *http.Request) *http.Response
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestClientListRecordsPagination(t *testing.T) {
+	var cursors []string
+	client := NewClient("secret", &http.Client{Transport: roundTripFunc(func(req *http.Request) *http.Response {
+		assert.Equal(t, "Bearer secret", req.Header.Get("Authorization"))
+		assert.Equal(t, "/v2/domains/example.com/records", req.URL.Path)
+		assert.Equal(t, "500", req.URL.Query().Get("per_page"))
+		cursor := req.URL.Query().Get("cursor")
+		cursors = append(cursors, cursor)
+		if cursor == "" {
+			return jsonResponse(http.StatusOK, `{"records":[{"id":"1","type":"A","name":"www","data":"1.2.3.4","ttl":300}],"meta":{"links":{"next":"page2"}}}`)
+		}
+		return jsonResponse(http.StatusOK, `{"records":[{"id":"2","type":"MX","name":"","data":"mail.example.com","priority":10,"ttl":300}],"meta":{"links":{"next":""}}}`)
+	})})
+
+	records, err := client.ListRecords(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "page2"}, cursors)
+	assert.Equal(t, []Record{
+		{ID: "1", Type: "A", Name: "www", Data: "1.2.3.4", TTL: 300},
+		{ID: "2", Type: "MX", Name: "", Data: "mail.example.com", Priority: 10, TTL: 300},
+	}, records)
+}
+
+func TestClientCreateRecord(t *testing.T) {
+	client := NewClient("secret", &http.Client{Transport: roundTripFunc(func(req *http.Request) *http.Response {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "/v2/domains/example.com/records", req.URL.Path)
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"name":"","type":"MX","data":"mail.example.com","priority":10}`, string(body))
+		return jsonResponse(http.StatusCreated, `{"record":{"id":"1"}}`)
+	})})
+
+	priority := 10
+	require.NoError(t, client.CreateRecord(context.Background(), "example.com", RecordRequest{Type: "MX", Data: "mail.example.com", Priority: &priority}))
+}
+
+func TestClientAPIError(t *testing.T) {
+	client := NewClient("invalid", &http.Client{Transport: roundTripFunc(func(req *http.Request) *http.Response {
+		return jsonResponse(http.StatusUnauthorized, `{"error":"Invalid API token.","status":401}`)
+	})})
+
+	_, err := client.ListDomains(context.Background())
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	assert.Equal(t, "Invalid API token.", apiErr.Message)
+	assert.EqualError(t, err, "vultr: 401 Unauthorized: Invalid API token.")
+}
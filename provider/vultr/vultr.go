@@ -0,0 +1,323 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vultr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// VultrProvider is an implementation of Provider for Vultr's DNS.
+type VultrProvider struct {
+	provider.BaseProvider
+	Client DNSClient
+	// only consider hosted zones managing domains ending in this suffix
+	domainFilter endpoint.DomainFilter
+	DryRun       bool
+}
+
+type vultrChangeCreate struct {
+	Domain  string
+	Options RecordRequest
+}
+
+type vultrChangeUpdate struct {
+	Domain  string
+	Record  Record
+	Options RecordRequest
+}
+
+type vultrChangeDelete struct {
+	Domain string
+	Record Record
+}
+
+// vultrChanges contains all changes to apply to DNS
+type vultrChanges struct {
+	Creates []vultrChangeCreate
+	Updates []vultrChangeUpdate
+	Deletes []vultrChangeDelete
+}
+
+// NewVultrProvider initializes a new Vultr DNS based Provider.
+func NewVultrProvider(domainFilter endpoint.DomainFilter, dryRun bool) (*VultrProvider, error) {
+	apiKey := os.Getenv("VULTR_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("no API key found, set VULTR_API_KEY")
+	}
+
+	return &VultrProvider{
+		Client:       NewClient(apiKey, &http.Client{Timeout: 30 * time.Second}),
+		domainFilter: domainFilter,
+		DryRun:       dryRun,
+	}, nil
+}
+
+// Zones returns the list of hosted zones.
+func (p *VultrProvider) Zones(ctx context.Context) ([]Domain, error) {
+	domains, err := p.Client.ListDomains(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list domains: %w", err)
+	}
+
+	var zones []Domain
+	for _, domain := range domains {
+		if p.domainFilter.Match(domain.Domain) {
+			zones = append(zones, domain)
+		}
+	}
+	return zones, nil
+}
+
+// SupportedRecordType returns whether the record type is managed, the MX records of Vultr in addition to the
+// default types.
+func (p *VultrProvider) SupportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeMX:
+		return true
+	default:
+		return provider.SupportedRecordType(recordType)
+	}
+}
+
+// Records returns the list of records in all the zones, the records of a name and type are merged into a single
+// endpoint.
+func (p *VultrProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, zone := range zones {
+		records, err := p.Client.ListRecords(ctx, zone.Domain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list records of domain %s: %w", zone.Domain, err)
+		}
+
+		for _, r := range records {
+			if p.SupportedRecordType(r.Type) {
+				endpoints = append(endpoints, endpoint.NewEndpointWithTTL(recordDNSName(zone.Domain, r.Name), r.Type, endpoint.TTL(r.TTL), recordTarget(r)))
+			}
+		}
+	}
+	endpoints = provider.MergeEndpointsByNameType(endpoints)
+
+	log.WithFields(log.Fields{
+		"endpoints": endpoints,
+	}).Debug("Endpoints generated from Vultr DNS")
+
+	return endpoints, nil
+}
+
+// ApplyChanges applies the given changes. Vultr holds a record per value, the records of the domains are listed once
+// and compared with the targets of each endpoint.
+func (p *VultrProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	zones, err := p.Zones(ctx)
+	if err != nil {
+		return err
+	}
+
+	zoneNameIDMapper := provider.ZoneIDName{}
+	for _, zone := range zones {
+		zoneNameIDMapper.Add(zone.Domain, zone.Domain)
+	}
+
+	recordsByZone := map[string][]Record{}
+	records := func(zone string) ([]Record, error) {
+		if r, ok := recordsByZone[zone]; ok {
+			return r, nil
+		}
+		r, err := p.Client.ListRecords(ctx, zone)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list records of domain %s: %w", zone, err)
+		}
+		recordsByZone[zone] = r
+		return r, nil
+	}
+
+	var vultrChanges vultrChanges
+	err = provider.ForEachEndpointTargets(changes, func(ep *endpoint.Endpoint, targets []string, prune bool) error {
+		zone, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zone == "" {
+			log.Debugf("Skipping record %s because no hosted zone matching record DNS Name was detected", ep.DNSName)
+			return nil
+		}
+		existing, err := records(zone)
+		if err != nil {
+			return err
+		}
+		return vultrChanges.add(zone, existing, ep, targets, prune)
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.submitChanges(ctx, vultrChanges)
+}
+
+// add adds the changes turning the existing records of the endpoint's name and type into a record per target. A
+// record can't change of type, only its data, priority and TTL are updated.
+func (c *vultrChanges) add(zone string, existing []Record, ep *endpoint.Endpoint, targets []string, prune bool) error {
+	name := recordName(zone, ep.DNSName)
+	current := slices.DeleteFunc(slices.Clone(existing), func(r Record) bool { return r.Name != name || r.Type != ep.RecordType })
+	diff := provider.DiffRecords(current, targets, prune, recordTarget, func(r Record) bool {
+		return ep.RecordTTL.IsConfigured() && r.TTL != int(ep.RecordTTL)
+	})
+
+	for _, target := range diff.Create {
+		options, err := newRecordRequest(name, ep.RecordType, target, ep.RecordTTL)
+		if err != nil {
+			return fmt.Errorf("invalid target %q of %s record %s: %w", target, ep.RecordType, ep.DNSName, err)
+		}
+		c.Creates = append(c.Creates, vultrChangeCreate{Domain: zone, Options: options})
+	}
+	for _, update := range diff.Update {
+		options, err := newRecordRequest(name, ep.RecordType, update.Target, ep.RecordTTL)
+		if err != nil {
+			return fmt.Errorf("invalid target %q of %s record %s: %w", update.Target, ep.RecordType, ep.DNSName, err)
+		}
+		options.Type = ""
+		c.Updates = append(c.Updates, vultrChangeUpdate{Domain: zone, Record: update.Record, Options: options})
+	}
+	for _, record := range diff.Delete {
+		c.Deletes = append(c.Deletes, vultrChangeDelete{Domain: zone, Record: record})
+	}
+	return nil
+}
+
+// submitChanges deletes, updates and creates the records one by one, as the Vultr API has no batch endpoint. The
+// deletions come first, so that a CNAME record can replace the records of its name.
+func (p *VultrProvider) submitChanges(ctx context.Context, changes vultrChanges) error {
+	for _, change := range changes.Deletes {
+		logFields := log.Fields{
+			"record":   change.Record.Name,
+			"type":     change.Record.Type,
+			"target":   change.Record.Data,
+			"action":   "Delete",
+			"zoneName": change.Domain,
+		}
+		log.WithFields(logFields).Info("Deleting record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.DeleteRecord(ctx, change.Domain, change.Record.ID); err != nil {
+			return fmt.Errorf("failed to delete %s record %s of domain %s: %w", change.Record.Type, change.Record.Name, change.Domain, err)
+		}
+	}
+
+	for _, change := range changes.Updates {
+		logFields := log.Fields{
+			"record":   change.Record.Name,
+			"type":     change.Record.Type,
+			"target":   change.Options.Data,
+			"ttl":      change.Options.TTL,
+			"action":   "Update",
+			"zoneName": change.Domain,
+		}
+		log.WithFields(logFields).Info("Updating record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.UpdateRecord(ctx, change.Domain, change.Record.ID, change.Options); err != nil {
+			return fmt.Errorf("failed to update %s record %s of domain %s: %w", change.Record.Type, change.Record.Name, change.Domain, err)
+		}
+	}
+
+	for _, change := range changes.Creates {
+		logFields := log.Fields{
+			"record":   change.Options.Name,
+			"type":     change.Options.Type,
+			"target":   change.Options.Data,
+			"action":   "Create",
+			"zoneName": change.Domain,
+		}
+		log.WithFields(logFields).Info("Creating record.")
+		if p.DryRun {
+			continue
+		}
+		if err := p.Client.CreateRecord(ctx, change.Domain, change.Options); err != nil {
+			return fmt.Errorf("failed to create %s record %s of domain %s: %w", change.Options.Type, change.Options.Name, change.Domain, err)
+		}
+	}
+
+	return nil
+}
+
+// recordName returns the name of a record relative to its domain, the apex being the empty name.
+func recordName(zone, dnsName string) string {
+	if dnsName == zone {
+		return ""
+	}
+	return strings.TrimSuffix(dnsName, "."+zone)
+}
+
+// recordDNSName returns the DNS name of a record of a domain.
+func recordDNSName(zone, name string) string {
+	if name == "" || name == "@" {
+		return zone
+	}
+	return name + "." + zone
+}
+
+// recordTarget returns the endpoint target of a record, the priority of MX and SRV records is stored apart from
+// their data.
+func recordTarget(r Record) string {
+	switch r.Type {
+	case endpoint.RecordTypeMX, endpoint.RecordTypeSRV:
+		return fmt.Sprintf("%d %s", r.Priority, r.Data)
+	case endpoint.RecordTypeTXT:
+		if len(r.Data) >= 2 && strings.HasPrefix(r.Data, `"`) && strings.HasSuffix(r.Data, `"`) {
+			return r.Data[1 : len(r.Data)-1]
+		}
+	}
+	return r.Data
+}
+
+// newRecordRequest returns the request creating the record of an endpoint target. MX targets are of the form
+// "10 mail.example.com" and SRV targets of the form "10 5 443 target.example.com", the priority coming first.
+func newRecordRequest(name, recordType, target string, ttl endpoint.TTL) (RecordRequest, error) {
+	request := RecordRequest{Name: name, Type: recordType, Data: target}
+	if ttl.IsConfigured() {
+		request.TTL = int(ttl)
+	}
+	if recordType == endpoint.RecordTypeMX || recordType == endpoint.RecordTypeSRV {
+		priorityRaw, data, ok := strings.Cut(target, " ")
+		if !ok {
+			return RecordRequest{}, fmt.Errorf("the priority is missing")
+		}
+		priority, err := strconv.Atoi(priorityRaw)
+		if err != nil {
+			return RecordRequest{}, fmt.Errorf("invalid priority %q", priorityRaw)
+		}
+		request.Priority = &priority
+		request.Data = data
+	}
+	return request, nil
+}
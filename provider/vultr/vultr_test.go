@@ -0,0 +1,246 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vultr
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// fakeVultrAPI is an in-memory Vultr DNS API, served as an http.RoundTripper.
+type fakeVultrAPI struct {
+	t       *testing.T
+	records map[string][]Record
+	nextID  int
+	writes  int
+}
+
+func newFakeVultrAPI(t *testing.T) *fakeVultrAPI {
+	return &fakeVultrAPI{t: t, records: testVultrRecords(), nextID: 100}
+}
+
+func (f *fakeVultrAPI) RoundTrip(req *http.Request) (*http.Response, error) {
+	assert.Equal(f.t, "Bearer secret", req.Header.Get("Authorization"))
+	parts := strings.Split(strings.TrimPrefix(req.URL.Path, "/v2/domains"), "/")
+
+	if len(parts) == 1 && req.Method == http.MethodGet {
+		var domains []Domain
+		for domain := range f.records {
+			domains = append(domains, Domain{Domain: domain})
+		}
+		sort.Slice(domains, func(i, j int) bool { return domains[i].Domain < domains[j].Domain })
+		return f.respond(http.StatusOK, map[string]any{"domains": domains, "meta": listMeta{}}), nil
+	}
+
+	domain := parts[1]
+	records, ok := f.records[domain]
+	if !ok {
+		return jsonResponse(http.StatusNotFound, `{"error":"Domain not found"}`), nil
+	}
+
+	switch {
+	case len(parts) == 3 && req.Method == http.MethodGet:
+		return f.respond(http.StatusOK, map[string]any{"records": records, "meta": listMeta{}}), nil
+	case len(parts) == 3 && req.Method == http.MethodPost:
+		var body RecordRequest
+		require.NoError(f.t, json.NewDecoder(req.Body).Decode(&body))
+		f.nextID++
+		record := Record{ID: strconv.Itoa(f.nextID), Type: body.Type, Name: body.Name, Data: body.Data, TTL: body.TTL}
+		if record.TTL == 0 {
+			record.TTL = 300
+		}
+		if body.Priority != nil {
+			record.Priority = *body.Priority
+		}
+		f.records[domain] = append(records, record)
+		f.writes++
+		return f.respond(http.StatusCreated, map[string]any{"record": record}), nil
+	}
+
+	for i, record := range records {
+		if len(parts) != 4 || record.ID != parts[3] {
+			continue
+		}
+		f.writes++
+		switch req.Method {
+		case http.MethodPatch:
+			var body RecordRequest
+			require.NoError(f.t, json.NewDecoder(req.Body).Decode(&body))
+			assert.Empty(f.t, body.Type)
+			records[i].Data = body.Data
+			records[i].TTL = body.TTL
+			return jsonResponse(http.StatusNoContent, ""), nil
+		case http.MethodDelete:
+			f.records[domain] = append(records[:i], records[i+1:]...)
+			return jsonResponse(http.StatusNoContent, ""), nil
+		}
+	}
+	return jsonResponse(http.StatusNotFound, `{"error":"Record not found"}`), nil
+}
+
+func (f *fakeVultrAPI) respond(status int, body any) *http.Response {
+	b, err := json.Marshal(body)
+	require.NoError(f.t, err)
+	return jsonResponse(status, string(b))
+}
+
+func newTestProvider(api http.RoundTripper, domains ...string) *VultrProvider {
+	return &VultrProvider{
+		Client:       NewClient("secret", &http.Client{Transport: api}),
+		domainFilter: endpoint.NewDomainFilter(domains),
+	}
+}
+
+func testVultrRecords() map[string][]Record {
+	return map[string][]Record{
+		"example.com": {
+			{ID: "1", Type: "A", Name: "", Data: "1.2.3.4", TTL: 300},
+			{ID: "2", Type: "A", Name: "www", Data: "1.2.3.4", TTL: 300},
+			{ID: "3", Type: "A", Name: "www", Data: "1.2.3.5", TTL: 300},
+			{ID: "4", Type: "TXT", Name: "www", Data: `"heritage=external-dns"`, TTL: 300},
+			{ID: "5", Type: "MX", Name: "", Data: "mail.example.com", Priority: 10, TTL: 3600},
+			{ID: "6", Type: "CAA", Name: "", Data: "0 issue \"letsencrypt.org\"", TTL: 300},
+			{ID: "7", Type: "SRV", Name: "_sip._tcp", Data: "5 5060 sip.example.com", Priority: 10, TTL: 300},
+		},
+		"example.org": {
+			{ID: "8", Type: "CNAME", Name: "@", Data: "example.com", TTL: 300},
+		},
+	}
+}
+
+func TestNewVultrProvider(t *testing.T) {
+	t.Setenv("VULTR_API_KEY", "secret")
+	_, err := NewVultrProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.NoError(t, err)
+
+	t.Setenv("VULTR_API_KEY", "")
+	_, err = NewVultrProvider(endpoint.NewDomainFilter([]string{"example.com"}), true)
+	require.EqualError(t, err, "no API key found, set VULTR_API_KEY")
+}
+
+func TestVultrProviderRecords(t *testing.T) {
+	p := newTestProvider(newFakeVultrAPI(t), "example.com")
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 300, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeTXT, 300, "heritage=external-dns"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+		endpoint.NewEndpointWithTTL("_sip._tcp.example.com", endpoint.RecordTypeSRV, 300, "10 5 5060 sip.example.com"),
+	}, endpoints)
+}
+
+func TestVultrProviderRecordsApex(t *testing.T) {
+	p := newTestProvider(newFakeVultrAPI(t), "example.org")
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.org", endpoint.RecordTypeCNAME, 300, "example.com"),
+	}, endpoints)
+}
+
+func TestVultrProviderApplyChanges(t *testing.T) {
+	api := newFakeVultrAPI(t)
+	p := newTestProvider(api, "example.com")
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeAAAA, "2001:db8::1", "2001:db8::2"),
+			endpoint.NewEndpointWithTTL("mail.example.com", endpoint.RecordTypeMX, 600, "20 backup.example.com"),
+			// not in a managed zone
+			endpoint.NewEndpoint("www.example.net", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5"),
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.5", "1.2.3.6"),
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 300, "10 mail.example.com"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeTXT, "heritage=external-dns"),
+		},
+	}))
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 300, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.5", "1.2.3.6"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 300, "10 mail.example.com"),
+		endpoint.NewEndpointWithTTL("_sip._tcp.example.com", endpoint.RecordTypeSRV, 300, "10 5 5060 sip.example.com"),
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeAAAA, 300, "2001:db8::1", "2001:db8::2"),
+		endpoint.NewEndpointWithTTL("mail.example.com", endpoint.RecordTypeMX, 600, "20 backup.example.com"),
+	}, endpoints)
+	// the unchanged target of www.example.com is kept
+	assert.Contains(t, api.records["example.com"], Record{ID: "3", Type: "A", Name: "www", Data: "1.2.3.5", TTL: 300})
+}
+
+func TestVultrProviderApplyChangesDryRun(t *testing.T) {
+	api := newFakeVultrAPI(t)
+	p := newTestProvider(api, "example.com")
+	p.DryRun = true
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.4", "1.2.3.5")},
+	}))
+	assert.Zero(t, api.writes)
+}
+
+func TestVultrProviderApplyChangesInvalidTarget(t *testing.T) {
+	api := newFakeVultrAPI(t)
+	p := newTestProvider(api, "example.com")
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("mail.example.com", endpoint.RecordTypeMX, "mail.example.com")},
+	})
+	require.EqualError(t, err, `invalid target "mail.example.com" of MX record mail.example.com: the priority is missing`)
+	assert.Zero(t, api.writes)
+}
+
+func TestVultrProviderApplyChangesError(t *testing.T) {
+	p := newTestProvider(roundTripFunc(func(req *http.Request) *http.Response {
+		if req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/domains") {
+			return jsonResponse(http.StatusOK, `{"domains":[{"domain":"example.com"}]}`)
+		}
+		if req.Method == http.MethodGet {
+			return jsonResponse(http.StatusOK, `{"records":[]}`)
+		}
+		return jsonResponse(http.StatusBadRequest, `{"error":"Invalid record data"}`)
+	}), "example.com")
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+	})
+	require.EqualError(t, err, "failed to create A record www of domain example.com: vultr: 400 Bad Request: Invalid record data")
+}
@@ -35,6 +35,11 @@ func NewZoneTypeFilter(zoneType string) ZoneTypeFilter {
 	return ZoneTypeFilter{zoneType: zoneType}
 }
 
+// IsPrivate reports whether only private zones are filtered for.
+func (f ZoneTypeFilter) IsPrivate() bool {
+	return f.zoneType == zoneTypePrivate
+}
+
 // Match checks whether a zone matches the zone type that's filtered for.
 func (f ZoneTypeFilter) Match(rawZoneType interface{}) bool {
 	// An empty zone filter includes all hosted zones.
@@ -114,6 +114,11 @@ func (im *DynamoDBRegistry) GetDomainFilter() endpoint.DomainFilterInterface {
 	return im.provider.GetDomainFilter()
 }
 
+// ZoneNamesByID returns the names of the zones of the provider by zone ID.
+func (im *DynamoDBRegistry) ZoneNamesByID(ctx context.Context) (map[string]string, error) {
+	return provider.ZoneNamesByID(ctx, im.provider)
+}
+
 func (im *DynamoDBRegistry) OwnerID() string {
 	return im.ownerID
 }
@@ -556,6 +561,11 @@ func (im *DynamoDBRegistry) executeStatements(ctx context.Context, statements []
 	return nil
 }
 
+// ResetCache drops the cached records, which are updated with the changes even when the provider fails to apply them.
+func (im *DynamoDBRegistry) ResetCache() {
+	im.recordsCache = nil
+}
+
 func (im *DynamoDBRegistry) addToCache(ep *endpoint.Endpoint) {
 	if im.recordsCache != nil {
 		im.recordsCache = append(im.recordsCache, ep)
@@ -40,6 +40,11 @@ func (im *NoopRegistry) GetDomainFilter() endpoint.DomainFilterInterface {
 	return im.provider.GetDomainFilter()
 }
 
+// ZoneNamesByID returns the names of the zones of the provider by zone ID.
+func (im *NoopRegistry) ZoneNamesByID(ctx context.Context) (map[string]string, error) {
+	return provider.ZoneNamesByID(ctx, im.provider)
+}
+
 func (im *NoopRegistry) OwnerID() string {
 	return ""
 }
@@ -34,3 +34,20 @@ type Registry interface {
 	GetDomainFilter() endpoint.DomainFilterInterface
 	OwnerID() string
 }
+
+// CacheResetter is implemented by the registries which cache the records, so that the next call to
+// Records reads them from the provider again.
+type CacheResetter interface {
+	ResetCache()
+}
+
+// ZoneNameResolver is implemented by the registries whose provider identifies its zones by ID, so that the zones
+// given by ID can be matched by name.
+type ZoneNameResolver interface {
+	ZoneNamesByID(ctx context.Context) (map[string]string, error)
+}
+
+// OwnershipTransferrer is implemented by the registries which can take over the records of another owner.
+type OwnershipTransferrer interface {
+	TransferOwnership(ctx context.Context, fromOwnerID string) error
+}
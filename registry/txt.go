@@ -19,6 +19,7 @@ package registry
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -115,6 +116,11 @@ func (im *TXTRegistry) GetDomainFilter() endpoint.DomainFilterInterface {
 	return im.provider.GetDomainFilter()
 }
 
+// ZoneNamesByID returns the names of the zones of the provider by zone ID.
+func (im *TXTRegistry) ZoneNamesByID(ctx context.Context) (map[string]string, error) {
+	return provider.ZoneNamesByID(ctx, im.provider)
+}
+
 func (im *TXTRegistry) OwnerID() string {
 	return im.ownerID
 }
@@ -324,6 +330,66 @@ func (im *TXTRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes)
 	return im.provider.ApplyChanges(ctx, filteredChanges)
 }
 
+// TransferOwnership rewrites the TXT records of the records owned by fromOwnerID with the owner id
+// of this instance, e.g. after renaming the owner id or replacing a controller which is gone for good.
+// The records of the other owners are left alone.
+func (im *TXTRegistry) TransferOwnership(ctx context.Context, fromOwnerID string) error {
+	if fromOwnerID == "" || fromOwnerID == im.ownerID {
+		return fmt.Errorf("invalid owner id %q to transfer the ownership from", fromOwnerID)
+	}
+
+	// the existing TXT records by name and set identifier, as only the formats which exist are updated
+	existing := map[endpoint.EndpointKey]struct{}{}
+	records, err := im.provider.Records(ctx)
+	if err != nil {
+		return err
+	}
+	for _, r := range records {
+		if r.RecordType == endpoint.RecordTypeTXT {
+			existing[endpoint.EndpointKey{DNSName: strings.ToLower(r.DNSName), SetIdentifier: r.SetIdentifier}] = struct{}{}
+		}
+	}
+
+	im.recordsCache = nil
+	endpoints, err := im.Records(ctx)
+	if err != nil {
+		return err
+	}
+
+	changes := &plan.Changes{}
+	for _, ep := range endpoints {
+		owner := ep.Labels[endpoint.OwnerLabelKey]
+		if owner != fromOwnerID || !plan.IsManagedRecord(ep.RecordType, im.managedRecordTypes, im.excludeRecordTypes) {
+			continue
+		}
+		log.Infof("Transferring the ownership of %s %s from owner %q to owner %q", ep.DNSName, ep.RecordType, owner, im.ownerID)
+
+		transferred := ep.DeepCopy()
+		transferred.Labels[endpoint.OwnerLabelKey] = im.ownerID
+		current, desired := im.generateTXTRecord(ep), im.generateTXTRecord(transferred)
+		for i := range desired {
+			if _, ok := existing[endpoint.EndpointKey{DNSName: strings.ToLower(current[i].DNSName), SetIdentifier: current[i].SetIdentifier}]; ok {
+				changes.UpdateOld = append(changes.UpdateOld, current[i])
+				changes.UpdateNew = append(changes.UpdateNew, desired[i])
+			} else {
+				changes.Create = append(changes.Create, desired[i])
+			}
+		}
+	}
+
+	if !changes.HasChanges() {
+		log.Infof("No records owned by owner %q to transfer", fromOwnerID)
+		return nil
+	}
+
+	// the records are read again once the ownership is transferred
+	im.recordsCache = nil
+	if im.cacheInterval > 0 {
+		ctx = context.WithValue(ctx, provider.RecordsContextKey, nil)
+	}
+	return im.provider.ApplyChanges(ctx, changes)
+}
+
 // AdjustEndpoints modifies the endpoints as needed by the specific provider
 func (im *TXTRegistry) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
 	return im.provider.AdjustEndpoints(endpoints)
@@ -494,6 +560,11 @@ func (pr affixNameMapper) toNewTXTName(endpointDNSName, recordType string) strin
 	return prefix + DNSName[0] + suffix + "." + DNSName[1]
 }
 
+// ResetCache drops the cached records, which are updated with the changes even when the provider fails to apply them.
+func (im *TXTRegistry) ResetCache() {
+	im.recordsCache = nil
+}
+
 func (im *TXTRegistry) addToCache(ep *endpoint.Endpoint) {
 	if im.recordsCache != nil {
 		im.recordsCache = append(im.recordsCache, ep)
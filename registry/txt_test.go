@@ -1146,6 +1146,29 @@ func testTXTRegistryMissingRecordsWithPrefix(t *testing.T) {
 	assert.True(t, testutils.SameEndpoints(records, expectedRecords))
 }
 
+func TestTXTRegistryResetCache(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	p.CreateZone(testZone)
+	r, err := NewTXTRegistry(p, "", "", "owner", time.Hour, "", []string{}, []string{}, false, nil, false)
+	require.NoError(t, err)
+
+	_, err = r.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{newEndpointWithOwner("new.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "")},
+	}))
+
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, records, "the records must be read from the cache")
+
+	r.ResetCache()
+	records, err = r.Records(ctx)
+	require.NoError(t, err)
+	assert.Len(t, records, 1, "the records must be read from the provider after the reset")
+}
+
 func TestCacheMethods(t *testing.T) {
 	cache := []*endpoint.Endpoint{
 		newEndpointWithOwner("thing.com", "1.2.3.4", "A", "owner"),
@@ -1838,3 +1861,89 @@ func TestTXTRegistryRecordsWithEmptyTargets(t *testing.T) {
 
 	testutils.TestHelperLogContains("TXT record has no targets empty-targets.test-zone.example.org", hook, t)
 }
+
+func TestTXTRegistryTransferOwnership(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+	managed := []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME}
+
+	previous, _ := NewTXTRegistry(p, "txt.", "", "previous", 0, "", managed, []string{}, false, nil, false)
+	require.NoError(t, previous.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{
+		newEndpointWithOwnerAndLabels("foo.test-zone.example.org", "foo.loadbalancer.com", endpoint.RecordTypeCNAME, "", endpoint.Labels{endpoint.ResourceLabelKey: "ingress/default/foo"}),
+		newEndpointWithOwner("bar.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, ""),
+	}}))
+	current, _ := NewTXTRegistry(p, "txt.", "", "current", 0, "", managed, []string{}, false, nil, false)
+	require.NoError(t, current.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{
+		newEndpointWithOwner("baz.test-zone.example.org", "5.6.7.8", endpoint.RecordTypeA, ""),
+	}}))
+	other, _ := NewTXTRegistry(p, "txt.", "", "other", 0, "", managed, []string{}, false, nil, false)
+	require.NoError(t, other.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{
+		newEndpointWithOwner("qux.test-zone.example.org", "5.6.7.9", endpoint.RecordTypeA, ""),
+	}}))
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{
+		newEndpointWithOwner("unmanaged.test-zone.example.org", "9.9.9.9", endpoint.RecordTypeA, ""),
+	}}))
+	before, err := p.Records(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, current.TransferOwnership(ctx, "previous"))
+
+	after, err := p.Records(ctx)
+	require.NoError(t, err)
+	assert.Len(t, after, len(before), "the existing TXT records must be updated in place")
+
+	records, err := current.Records(ctx)
+	require.NoError(t, err)
+	owners := map[string]string{}
+	for _, r := range records {
+		owners[r.DNSName] = r.Labels[endpoint.OwnerLabelKey]
+		if r.DNSName == "foo.test-zone.example.org" {
+			assert.Equal(t, "ingress/default/foo", r.Labels[endpoint.ResourceLabelKey], "the other labels must be kept")
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"foo.test-zone.example.org":       "current",
+		"bar.test-zone.example.org":       "current",
+		"baz.test-zone.example.org":       "current",
+		"qux.test-zone.example.org":       "other",
+		"unmanaged.test-zone.example.org": "",
+	}, owners, "only the records of the given owner must be transferred")
+}
+
+func TestTXTRegistryTransferOwnershipInvalidOwner(t *testing.T) {
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+	r, _ := NewTXTRegistry(p, "txt.", "", "current", 0, "", []string{endpoint.RecordTypeA}, []string{}, false, nil, false)
+
+	assert.EqualError(t, r.TransferOwnership(context.Background(), ""), `invalid owner id "" to transfer the ownership from`)
+	assert.EqualError(t, r.TransferOwnership(context.Background(), "current"), `invalid owner id "current" to transfer the ownership from`)
+}
+
+func TestTXTRegistryTransferOwnershipCreatesMissingFormats(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+
+	previous, _ := NewTXTRegistry(p, "txt.", "", "previous", 0, "", []string{endpoint.RecordTypeA}, []string{}, false, nil, true)
+	require.NoError(t, previous.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{
+		newEndpointWithOwner("bar.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, ""),
+	}}))
+	before, err := p.Records(ctx)
+	require.NoError(t, err)
+
+	current, _ := NewTXTRegistry(p, "txt.", "", "current", 0, "", []string{endpoint.RecordTypeA}, []string{}, false, nil, false)
+	require.NoError(t, current.TransferOwnership(ctx, "previous"))
+
+	after, err := p.Records(ctx)
+	require.NoError(t, err)
+	assert.Len(t, after, len(before)+1, "the TXT record in the old format must be created")
+	for _, r := range after {
+		if r.RecordType == endpoint.RecordTypeTXT {
+			assert.Contains(t, r.Targets[0], "external-dns/owner=current")
+		}
+	}
+
+	// nothing is left to transfer
+	require.NoError(t, current.TransferOwnership(ctx, "previous"))
+}
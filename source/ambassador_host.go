@@ -74,12 +74,12 @@ func NewAmbassadorHostSource(
 	namespace string,
 	annotationFilter string,
 	labelSelector labels.Selector,
+	informerOpts informers.Options,
 ) (Source, error) {
 	var err error
 
 	// Use shared informer to listen for add/update/delete of Host in the specified namespace.
-	// Set resync period to 0, to prevent processing when nothing has changed.
-	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, namespace, nil)
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, informerOpts.ResyncPeriod, namespace, nil)
 	ambassadorHostInformer := informerFactory.ForResource(ambHostGVR)
 
 	// Add default resource event handlers to properly initialize informer.
@@ -90,6 +90,7 @@ func NewAmbassadorHostSource(
 		},
 	)
 
+	informers.SetWatchBackoff(informerOpts.WatchBackoffMaxDelay, ambassadorHostInformer.Informer())
 	informerFactory.Start(ctx.Done())
 
 	// wait for the local cache to be populated.
@@ -23,12 +23,23 @@ const (
 	CloudflareCustomHostnameKey = "external-dns.alpha.kubernetes.io/cloudflare-custom-hostname"
 	CloudflareRegionKey         = "external-dns.alpha.kubernetes.io/cloudflare-region-key"
 	CloudflareRecordCommentKey  = "external-dns.alpha.kubernetes.io/cloudflare-record-comment"
+	CloudflareLoadBalancerKey   = "external-dns.alpha.kubernetes.io/cloudflare-load-balancer"
+	CloudflareDMARCKey          = "external-dns.alpha.kubernetes.io/cloudflare-dmarc"
+	CloudflareWorkerRouteKey    = "external-dns.alpha.kubernetes.io/cloudflare-worker-route"
 
 	AWSPrefix        = "external-dns.alpha.kubernetes.io/aws-"
 	SCWPrefix        = "external-dns.alpha.kubernetes.io/scw-"
+	AzurePrefix      = "external-dns.alpha.kubernetes.io/azure-"
 	WebhookPrefix    = "external-dns.alpha.kubernetes.io/webhook-"
 	CloudflarePrefix = "external-dns.alpha.kubernetes.io/cloudflare-"
 
+	// AWSWeightKey is the annotation of the weight of a Route53 weighted record, the records of
+	// the resources sharing a hostname with different weights are published as separate records.
+	AWSWeightKey = AWSPrefix + "weight"
+	// AWSWeightProperty is the provider specific property set from AWSWeightKey.
+	AWSWeightProperty = "aws/weight"
+	awsWeightMaximum  = 255
+
 	TtlKey     = "external-dns.alpha.kubernetes.io/ttl"
 	ttlMinimum = 1
 	ttlMaximum = math.MaxInt32
@@ -36,6 +47,10 @@ const (
 	SetIdentifierKey = "external-dns.alpha.kubernetes.io/set-identifier"
 	AliasKey         = "external-dns.alpha.kubernetes.io/alias"
 	TargetKey        = "external-dns.alpha.kubernetes.io/target"
+	// The annotation used for the targets of the records in private zones, for split-horizon DNS
+	InternalTargetKey = "external-dns.alpha.kubernetes.io/internal-target"
+	// InternalTargetProperty is the provider specific property set from InternalTargetKey.
+	InternalTargetProperty = "aws/internal-target"
 	// The annotation used for figuring out which controller is responsible
 	ControllerKey = "external-dns.alpha.kubernetes.io/controller"
 	// The annotation used for defining the desired hostname
@@ -47,6 +62,12 @@ const (
 	// The annotation used to determine the source of hostnames for ingresses.  This is an optional field - all
 	// available hostname sources are used if not specified.
 	IngressHostnameSourceKey = "external-dns.alpha.kubernetes.io/ingress-hostname-source"
+	// The annotation used for overriding the FQDN template of a DNSEndpoint
+	FQDNTemplateKey = "external-dns.alpha.kubernetes.io/fqdn-template"
+	// The annotation used for registering wildcard records of the desired hostnames
+	WildcardKey = "external-dns.alpha.kubernetes.io/wildcard"
+	// The annotation used for skipping services until at least one of their endpoints is ready
+	RequireReadyKey = "external-dns.alpha.kubernetes.io/require-ready"
 	// The value of the controller annotation so that we feel responsible
 	ControllerValue = "dns-controller"
 	// The annotation used for defining the desired hostname
@@ -21,6 +21,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"sigs.k8s.io/external-dns/endpoint"
 )
@@ -110,6 +111,28 @@ func InternalHostnamesFromAnnotations(input map[string]string) []string {
 	return extractHostnamesFromAnnotations(input, InternalHostnameKey)
 }
 
+// WildcardHostnames prepends "*." to the hostnames when the WildcardKey annotation is "true",
+// hostnames which are already wildcards are kept. Hostnames which don't result in a valid wildcard
+// DNS name are skipped.
+func WildcardHostnames(input map[string]string, hostnames []string) []string {
+	if input[WildcardKey] != "true" {
+		return hostnames
+	}
+
+	var wildcards []string
+	for _, hostname := range hostnames {
+		if !strings.HasPrefix(hostname, "*.") {
+			hostname = "*." + hostname
+		}
+		if errs := validation.IsWildcardDNS1123Subdomain(strings.ToLower(strings.TrimSuffix(hostname, "."))); len(errs) > 0 {
+			log.Warnf("Skipping invalid wildcard hostname %q: %s", hostname, strings.Join(errs, ", "))
+			continue
+		}
+		wildcards = append(wildcards, hostname)
+	}
+	return wildcards
+}
+
 // SplitHostnameAnnotation splits a comma-separated hostname annotation string into a slice of hostnames.
 // It trims any leading or trailing whitespace and removes any spaces within the anno
 func SplitHostnameAnnotation(input string) []string {
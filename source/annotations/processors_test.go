@@ -348,3 +348,50 @@ func TestInternalHostnamesFromAnnotations(t *testing.T) {
 		})
 	}
 }
+
+func TestWildcardHostnames(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		hostnames   []string
+		expected    []string
+	}{
+		{
+			name:        "no wildcard annotation",
+			annotations: map[string]string{},
+			hostnames:   []string{"example.com"},
+			expected:    []string{"example.com"},
+		},
+		{
+			name:        "wildcard annotation not true",
+			annotations: map[string]string{WildcardKey: "false"},
+			hostnames:   []string{"example.com"},
+			expected:    []string{"example.com"},
+		},
+		{
+			name:        "wildcard annotation",
+			annotations: map[string]string{WildcardKey: "true"},
+			hostnames:   []string{"example.com", "apps.example.org."},
+			expected:    []string{"*.example.com", "*.apps.example.org."},
+		},
+		{
+			name:        "hostname already a wildcard",
+			annotations: map[string]string{WildcardKey: "true"},
+			hostnames:   []string{"*.example.com"},
+			expected:    []string{"*.example.com"},
+		},
+		{
+			name:        "invalid wildcard hostnames are skipped",
+			annotations: map[string]string{WildcardKey: "true"},
+			hostnames:   []string{"foo.*.example.com", "-invalid.example.com", "example.com", ""},
+			expected:    []string{"*.example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := WildcardHostnames(tt.annotations, tt.hostnames)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -15,8 +15,11 @@ package annotations
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
+	log "github.com/sirupsen/logrus"
+
 	"sigs.k8s.io/external-dns/endpoint"
 )
 
@@ -33,6 +36,30 @@ func ProviderSpecificAnnotations(annotations map[string]string) (endpoint.Provid
 	for k, v := range annotations {
 		if k == SetIdentifierKey {
 			setIdentifier = v
+		} else if k == InternalTargetKey {
+			var targets []string
+			for _, target := range SplitHostnameAnnotation(v) {
+				if target = strings.TrimSuffix(target, "."); target != "" {
+					targets = append(targets, target)
+				}
+			}
+			if len(targets) == 0 {
+				continue
+			}
+			providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+				Name:  InternalTargetProperty,
+				Value: strings.Join(targets, ","),
+			})
+		} else if k == AWSWeightKey {
+			weight, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || weight < 0 || weight > awsWeightMaximum {
+				log.Warnf("Ignoring invalid %s annotation %q, the weight must be an integer between 0 and %d", AWSWeightKey, v, awsWeightMaximum)
+				continue
+			}
+			providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+				Name:  AWSWeightProperty,
+				Value: strconv.FormatInt(weight, 10),
+			})
 		} else if strings.HasPrefix(k, AWSPrefix) {
 			attr := strings.TrimPrefix(k, AWSPrefix)
 			providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
@@ -45,6 +72,12 @@ func ProviderSpecificAnnotations(annotations map[string]string) (endpoint.Provid
 				Name:  fmt.Sprintf("scw/%s", attr),
 				Value: v,
 			})
+		} else if strings.HasPrefix(k, AzurePrefix) {
+			attr := strings.TrimPrefix(k, AzurePrefix)
+			providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+				Name:  fmt.Sprintf("azure/%s", attr),
+				Value: v,
+			})
 		} else if strings.HasPrefix(k, WebhookPrefix) {
 			// Support for wildcard annotations for webhook providers
 			attr := strings.TrimPrefix(k, WebhookPrefix)
@@ -73,6 +106,21 @@ func ProviderSpecificAnnotations(annotations map[string]string) (endpoint.Provid
 					Name:  CloudflareRecordCommentKey,
 					Value: v,
 				})
+			} else if strings.Contains(k, CloudflareLoadBalancerKey) {
+				providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+					Name:  CloudflareLoadBalancerKey,
+					Value: v,
+				})
+			} else if strings.Contains(k, CloudflareDMARCKey) {
+				providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+					Name:  CloudflareDMARCKey,
+					Value: v,
+				})
+			} else if strings.Contains(k, CloudflareWorkerRouteKey) {
+				providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+					Name:  CloudflareWorkerRouteKey,
+					Value: v,
+				})
 			}
 		}
 	}
@@ -54,6 +54,54 @@ func TestProviderSpecificAnnotations(t *testing.T) {
 			},
 			setIdentifier: "",
 		},
+		{
+			name: "Cloudflare load balancer annotation",
+			annotations: map[string]string{
+				CloudflareLoadBalancerKey: "true",
+			},
+			expected: endpoint.ProviderSpecific{
+				{Name: CloudflareLoadBalancerKey, Value: "true"},
+			},
+			setIdentifier: "",
+		},
+		{
+			name: "Cloudflare DMARC annotation",
+			annotations: map[string]string{
+				CloudflareDMARCKey: "v=DMARC1; p=reject",
+			},
+			expected: endpoint.ProviderSpecific{
+				{Name: CloudflareDMARCKey, Value: "v=DMARC1; p=reject"},
+			},
+			setIdentifier: "",
+		},
+		{
+			name: "Cloudflare worker route annotation",
+			annotations: map[string]string{
+				CloudflareWorkerRouteKey: "my-worker",
+			},
+			expected: endpoint.ProviderSpecific{
+				{Name: CloudflareWorkerRouteKey, Value: "my-worker"},
+			},
+			setIdentifier: "",
+		},
+		{
+			name: "internal target annotation",
+			annotations: map[string]string{
+				InternalTargetKey: "10.0.0.1, internal.example.com.,",
+			},
+			expected: endpoint.ProviderSpecific{
+				{Name: InternalTargetProperty, Value: "10.0.0.1,internal.example.com"},
+			},
+			setIdentifier: "",
+		},
+		{
+			name: "empty internal target annotation",
+			annotations: map[string]string{
+				InternalTargetKey: "",
+			},
+			expected:      endpoint.ProviderSpecific{},
+			setIdentifier: "",
+		},
 		{
 			name: "AWS annotation",
 			annotations: map[string]string{
@@ -64,6 +112,29 @@ func TestProviderSpecificAnnotations(t *testing.T) {
 			},
 			setIdentifier: "",
 		},
+		{
+			name: "AWS weight annotation is normalized",
+			annotations: map[string]string{
+				AWSWeightKey: "010",
+			},
+			expected: endpoint.ProviderSpecific{
+				{Name: AWSWeightProperty, Value: "10"},
+			},
+		},
+		{
+			name: "invalid AWS weight annotation is ignored",
+			annotations: map[string]string{
+				AWSWeightKey: "heavy",
+			},
+			expected: endpoint.ProviderSpecific{},
+		},
+		{
+			name: "out of range AWS weight annotation is ignored",
+			annotations: map[string]string{
+				AWSWeightKey: "256",
+			},
+			expected: endpoint.ProviderSpecific{},
+		},
 		{
 			name: "Set identifier annotation",
 			annotations: map[string]string{
@@ -300,6 +371,15 @@ func TestGetProviderSpecificIdentifierAnnotations(t *testing.T) {
 			},
 			expectedIdentifier: "id1",
 		},
+		{
+			title: "azure- provider specific annotations are set correctly",
+			annotations: map[string]string{
+				"external-dns.alpha.kubernetes.io/azure-geo-location": "GEO-EU,DE",
+			},
+			expectedResult: map[string]string{
+				"azure/geo-location": "GEO-EU,DE",
+			},
+		},
 		{
 			title: "webhook- provider specific annotations are set correctly",
 			annotations: map[string]string{
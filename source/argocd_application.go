@@ -0,0 +1,209 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+var argoCDApplicationGVR = schema.GroupVersionResource{
+	Group:    "argoproj.io",
+	Version:  "v1alpha1",
+	Resource: "applications",
+}
+
+const (
+	argoCDSyncStatusSynced          = "Synced"
+	argoCDOperationPhaseFailed      = "Failed"
+	argoCDOperationPhaseError       = "Error"
+	argoCDApplicationResourcePrefix = "argocd-application"
+)
+
+// argoCDApplication holds the fields of an Argo CD Application used by the source,
+// the Argo CD API module is not imported to keep the dependencies small.
+type argoCDApplication struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Status            argoCDApplicationStatus `json:"status,omitempty"`
+}
+
+type argoCDApplicationStatus struct {
+	Sync           argoCDSyncStatus      `json:"sync,omitempty"`
+	OperationState *argoCDOperationState `json:"operationState,omitempty"`
+}
+
+type argoCDSyncStatus struct {
+	Status   string `json:"status,omitempty"`
+	Revision string `json:"revision,omitempty"`
+}
+
+type argoCDOperationState struct {
+	Phase      string            `json:"phase,omitempty"`
+	SyncResult *argoCDSyncResult `json:"syncResult,omitempty"`
+}
+
+type argoCDSyncResult struct {
+	Revision string `json:"revision,omitempty"`
+}
+
+// argoCDApplicationSource is an implementation of Source for Argo CD Application objects.
+// The hostnames and targets are read from the annotations of the Application and only
+// published once the Application is synced.
+type argoCDApplicationSource struct {
+	namespace                string
+	annotationFilter         string
+	labelSelector            labels.Selector
+	ignoreHostnameAnnotation bool
+	applicationInformer      kubeinformers.GenericInformer
+}
+
+// NewArgoCDApplicationSource creates a new argoCDApplicationSource with the given config.
+func NewArgoCDApplicationSource(
+	ctx context.Context,
+	dynamicKubeClient dynamic.Interface,
+	namespace string,
+	annotationFilter string,
+	labelSelector labels.Selector,
+	ignoreHostnameAnnotation bool,
+	informerOpts informers.Options,
+) (Source, error) {
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, informerOpts.ResyncPeriod, namespace, nil)
+	applicationInformer := informerFactory.ForResource(argoCDApplicationGVR)
+
+	applicationInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+			},
+		},
+	)
+
+	informers.SetWatchBackoff(informerOpts.WatchBackoffMaxDelay, applicationInformer.Informer())
+	informerFactory.Start(ctx.Done())
+
+	// wait for the local cache to be populated.
+	if err := informers.WaitForDynamicCacheSync(context.Background(), informerFactory); err != nil {
+		return nil, err
+	}
+
+	return &argoCDApplicationSource{
+		namespace:                namespace,
+		annotationFilter:         annotationFilter,
+		labelSelector:            labelSelector,
+		ignoreHostnameAnnotation: ignoreHostnameAnnotation,
+		applicationInformer:      applicationInformer,
+	}, nil
+}
+
+// Endpoints returns endpoint objects for each synced Application in the source's namespace(s).
+func (as *argoCDApplicationSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	objects, err := as.applicationInformer.Lister().ByNamespace(as.namespace).List(as.labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	selector, err := annotations.ParseFilter(as.annotationFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := []*endpoint.Endpoint{}
+	for _, obj := range objects {
+		unstructuredApplication, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return nil, errors.New("could not convert")
+		}
+
+		app := &argoCDApplication{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredApplication.Object, app); err != nil {
+			return nil, err
+		}
+
+		if !selector.Matches(labels.Set(app.Annotations)) {
+			continue
+		}
+
+		if !isArgoCDApplicationSynced(app) {
+			log.Debugf("Skipping Argo CD Application %s/%s, it is not synced", app.Namespace, app.Name)
+			continue
+		}
+
+		endpoints = append(endpoints, as.endpointsFromApplication(app)...)
+	}
+
+	return endpoints, nil
+}
+
+func (as *argoCDApplicationSource) AddEventHandler(ctx context.Context, handler func()) {
+	log.Debug("Adding event handler for Argo CD Application")
+
+	as.applicationInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
+}
+
+// endpointsFromApplication returns the endpoints of the hostname and target annotations of the Application.
+func (as *argoCDApplicationSource) endpointsFromApplication(app *argoCDApplication) []*endpoint.Endpoint {
+	if as.ignoreHostnameAnnotation {
+		return nil
+	}
+
+	resource := fmt.Sprintf("%s/%s/%s", argoCDApplicationResourcePrefix, app.Namespace, app.Name)
+
+	targets := annotations.TargetsFromTargetAnnotation(app.Annotations)
+	if len(targets) == 0 {
+		log.Debugf("Skipping Argo CD Application %s/%s, it has no target annotation", app.Namespace, app.Name)
+		return nil
+	}
+
+	ttl := annotations.TTLFromAnnotations(app.Annotations, resource)
+	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(app.Annotations)
+
+	var endpoints []*endpoint.Endpoint
+	for _, hostname := range annotations.HostnamesFromAnnotations(app.Annotations) {
+		endpoints = append(endpoints, endpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
+	}
+	return endpoints
+}
+
+// isArgoCDApplicationSynced returns true when the live state of the Application matches its target revision,
+// unless the last sync operation of that revision failed, e.g. because of a failing sync hook.
+// Running operations don't change the result so that the records are kept while an Application is resynced.
+func isArgoCDApplicationSynced(app *argoCDApplication) bool {
+	if app.Status.Sync.Status != argoCDSyncStatusSynced {
+		return false
+	}
+	operation := app.Status.OperationState
+	if operation == nil || operation.SyncResult == nil || operation.SyncResult.Revision != app.Status.Sync.Revision {
+		return true
+	}
+	return operation.Phase != argoCDOperationPhaseFailed && operation.Phase != argoCDOperationPhaseError
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakeDynamic "k8s.io/client-go/dynamic/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+func newArgoCDApplication(namespace, name string, appAnnotations map[string]string, status map[string]any) *unstructured.Unstructured {
+	app := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Application",
+		"metadata": map[string]any{
+			"namespace": namespace,
+			"name":      name,
+		},
+	}}
+	if status != nil {
+		app.Object["status"] = status
+	}
+	app.SetAnnotations(appAnnotations)
+	return app
+}
+
+func argoCDStatus(syncStatus, revision string, operation map[string]any) map[string]any {
+	status := map[string]any{
+		"sync": map[string]any{"status": syncStatus, "revision": revision},
+	}
+	if operation != nil {
+		status["operationState"] = operation
+	}
+	return status
+}
+
+func argoCDOperation(phase, revision string) map[string]any {
+	return map[string]any{
+		"phase":      phase,
+		"syncResult": map[string]any{"revision": revision},
+	}
+}
+
+func argoCDAnnotations(hostname, target string) map[string]string {
+	return map[string]string{
+		annotations.HostnameKey: hostname,
+		annotations.TargetKey:   target,
+	}
+}
+
+func TestArgoCDApplicationSourceEndpoints(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                     string
+		namespace                string
+		annotationFilter         string
+		ignoreHostnameAnnotation bool
+		applications             []*unstructured.Unstructured
+		expected                 []*endpoint.Endpoint
+	}{
+		{
+			name: "synced application without operation",
+			applications: []*unstructured.Unstructured{
+				newArgoCDApplication("argocd", "web", argoCDAnnotations("web.example.com", "1.2.3.4"), argoCDStatus("Synced", "abc", nil)),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("web.example.com", endpoint.RecordTypeA, "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "argocd-application/argocd/web"),
+			},
+		},
+		{
+			name: "synced application with succeeded operation",
+			applications: []*unstructured.Unstructured{
+				newArgoCDApplication("argocd", "web", map[string]string{
+					annotations.HostnameKey: "web.example.com,www.example.com",
+					annotations.TargetKey:   "lb.example.org",
+					annotations.TtlKey:      "60",
+				}, argoCDStatus("Synced", "abc", argoCDOperation("Succeeded", "abc"))),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("web.example.com", endpoint.RecordTypeCNAME, 60, "lb.example.org").
+					WithLabel(endpoint.ResourceLabelKey, "argocd-application/argocd/web"),
+				endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeCNAME, 60, "lb.example.org").
+					WithLabel(endpoint.ResourceLabelKey, "argocd-application/argocd/web"),
+			},
+		},
+		{
+			name: "synced application being resynced",
+			applications: []*unstructured.Unstructured{
+				newArgoCDApplication("argocd", "web", argoCDAnnotations("web.example.com", "1.2.3.4"), argoCDStatus("Synced", "abc", argoCDOperation("Running", "abc"))),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("web.example.com", endpoint.RecordTypeA, "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "argocd-application/argocd/web"),
+			},
+		},
+		{
+			name: "synced application with a failed operation of a previous revision",
+			applications: []*unstructured.Unstructured{
+				newArgoCDApplication("argocd", "web", argoCDAnnotations("web.example.com", "1.2.3.4"), argoCDStatus("Synced", "def", argoCDOperation("Failed", "abc"))),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("web.example.com", endpoint.RecordTypeA, "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "argocd-application/argocd/web"),
+			},
+		},
+		{
+			name: "synced application with a failed operation",
+			applications: []*unstructured.Unstructured{
+				newArgoCDApplication("argocd", "web", argoCDAnnotations("web.example.com", "1.2.3.4"), argoCDStatus("Synced", "abc", argoCDOperation("Failed", "abc"))),
+				newArgoCDApplication("argocd", "api", argoCDAnnotations("api.example.com", "1.2.3.5"), argoCDStatus("Synced", "abc", argoCDOperation("Error", "abc"))),
+			},
+			expected: []*endpoint.Endpoint{},
+		},
+		{
+			name: "out of sync application",
+			applications: []*unstructured.Unstructured{
+				newArgoCDApplication("argocd", "web", argoCDAnnotations("web.example.com", "1.2.3.4"), argoCDStatus("OutOfSync", "abc", argoCDOperation("Succeeded", "abc"))),
+			},
+			expected: []*endpoint.Endpoint{},
+		},
+		{
+			name: "application with unknown sync status",
+			applications: []*unstructured.Unstructured{
+				newArgoCDApplication("argocd", "web", argoCDAnnotations("web.example.com", "1.2.3.4"), argoCDStatus("Unknown", "", nil)),
+				newArgoCDApplication("argocd", "api", argoCDAnnotations("api.example.com", "1.2.3.5"), nil),
+			},
+			expected: []*endpoint.Endpoint{},
+		},
+		{
+			name: "synced application without target",
+			applications: []*unstructured.Unstructured{
+				newArgoCDApplication("argocd", "web", map[string]string{annotations.HostnameKey: "web.example.com"}, argoCDStatus("Synced", "abc", nil)),
+			},
+			expected: []*endpoint.Endpoint{},
+		},
+		{
+			name:      "single namespace",
+			namespace: "team-a",
+			applications: []*unstructured.Unstructured{
+				newArgoCDApplication("team-a", "web", argoCDAnnotations("a.example.com", "1.2.3.4"), argoCDStatus("Synced", "abc", nil)),
+				newArgoCDApplication("team-b", "web", argoCDAnnotations("b.example.com", "1.2.3.5"), argoCDStatus("Synced", "abc", nil)),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "argocd-application/team-a/web"),
+			},
+		},
+		{
+			name:             "annotation filter",
+			annotationFilter: "dns=public",
+			applications: []*unstructured.Unstructured{
+				newArgoCDApplication("argocd", "web", map[string]string{
+					annotations.HostnameKey: "web.example.com",
+					annotations.TargetKey:   "1.2.3.4",
+					"dns":                   "public",
+				}, argoCDStatus("Synced", "abc", nil)),
+				newArgoCDApplication("argocd", "api", argoCDAnnotations("api.example.com", "1.2.3.5"), argoCDStatus("Synced", "abc", nil)),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("web.example.com", endpoint.RecordTypeA, "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "argocd-application/argocd/web"),
+			},
+		},
+		{
+			name:                     "ignore hostname annotation",
+			ignoreHostnameAnnotation: true,
+			applications: []*unstructured.Unstructured{
+				newArgoCDApplication("argocd", "web", argoCDAnnotations("web.example.com", "1.2.3.4"), argoCDStatus("Synced", "abc", nil)),
+			},
+			expected: []*endpoint.Endpoint{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fakeDynamicClient := fakeDynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+				argoCDApplicationGVR: "ApplicationList",
+			})
+			for _, app := range tt.applications {
+				_, err := fakeDynamicClient.Resource(argoCDApplicationGVR).Namespace(app.GetNamespace()).
+					Create(context.Background(), app, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
+			src, err := NewArgoCDApplicationSource(context.Background(), fakeDynamicClient, tt.namespace, tt.annotationFilter, labels.Everything(), tt.ignoreHostnameAnnotation, informers.Options{})
+			require.NoError(t, err)
+
+			endpoints, err := src.Endpoints(context.Background())
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tt.expected)
+		})
+	}
+}
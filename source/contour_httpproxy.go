@@ -62,6 +62,7 @@ func NewContourHTTPProxySource(
 	fqdnTemplate string,
 	combineFqdnAnnotation bool,
 	ignoreHostnameAnnotation bool,
+	informerOpts informers.Options,
 ) (Source, error) {
 	tmpl, err := fqdn.ParseTemplate(fqdnTemplate)
 	if err != nil {
@@ -69,8 +70,7 @@ func NewContourHTTPProxySource(
 	}
 
 	// Use shared informer to listen for add/update/delete of HTTPProxys in the specified namespace.
-	// Set resync period to 0, to prevent processing when nothing has changed.
-	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, namespace, nil)
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, informerOpts.ResyncPeriod, namespace, nil)
 	httpProxyInformer := informerFactory.ForResource(projectcontour.HTTPProxyGVR)
 
 	// Add default resource event handlers to properly initialize informer.
@@ -81,6 +81,7 @@ func NewContourHTTPProxySource(
 		},
 	)
 
+	informers.SetWatchBackoff(informerOpts.WatchBackoffMaxDelay, httpProxyInformer.Informer())
 	informerFactory.Start(ctx.Done())
 
 	// wait for the local cache to be populated.
@@ -33,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/informers"
 )
 
 // This is a compile-time validation that httpProxySource is a Source.
@@ -96,6 +97,7 @@ func (suite *HTTPProxySuite) SetupTest() {
 		"{{.Name}}",
 		false,
 		false,
+		informers.Options{},
 	)
 	suite.NoError(err, "should initialize httpproxy source")
 
@@ -193,6 +195,7 @@ func TestNewContourHTTPProxySource(t *testing.T) {
 				ti.fqdnTemplate,
 				ti.combineFQDNAndAnnotation,
 				false,
+				informers.Options{},
 			)
 			if ti.expectError {
 				assert.Error(t, err)
@@ -1061,6 +1064,7 @@ func testHTTPProxyEndpoints(t *testing.T) {
 				ti.fqdnTemplate,
 				ti.combineFQDNAndAnnotation,
 				ti.ignoreHostnameAnnotation,
+				informers.Options{},
 			)
 			require.NoError(t, err)
 
@@ -1088,6 +1092,7 @@ func newTestHTTPProxySource() (*httpProxySource, error) {
 		"{{.Name}}",
 		false,
 		false,
+		informers.Options{},
 	)
 	if err != nil {
 		return nil, err
@@ -19,27 +19,41 @@ package source
 import (
 	"context"
 	"fmt"
-	"os"
+	"slices"
 	"strings"
+	"sync"
+	"text/template"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/tools/cache"
 
 	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/informers"
 
 	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
 
 	apiv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/fqdn"
+)
+
+const (
+	crdReasonSynced              = "Synced"
+	crdReasonSyncFailed          = "SyncFailed"
+	crdReasonInvalidFQDNTemplate = "InvalidFQDNTemplate"
 )
 
 // crdSource is an implementation of Source that provides endpoints by listing
@@ -52,6 +66,16 @@ type crdSource struct {
 	annotationFilter string
 	labelSelector    labels.Selector
 	informer         *cache.SharedInformer
+	// finalizer enables the cleanup finalizer on the DNSEndpoints
+	finalizer bool
+	// cleanupMutex guards cleanup
+	cleanupMutex sync.Mutex
+	// cleanup holds the deleted DNSEndpoints whose records are removed by the current synchronization
+	cleanup map[types.UID]struct{}
+	// fqdnTemplate generates the DNS names of the endpoints without one, unless overridden by the annotation of the DNSEndpoint
+	fqdnTemplate *template.Template
+	// eventRecorder records the events of the DNSEndpoints, nil disables them
+	eventRecorder record.EventRecorder
 }
 
 func addKnownTypes(scheme *runtime.Scheme, groupVersion schema.GroupVersion) error {
@@ -64,14 +88,8 @@ func addKnownTypes(scheme *runtime.Scheme, groupVersion schema.GroupVersion) err
 }
 
 // NewCRDClientForAPIVersionKind return rest client for the given apiVersion and kind of the CRD
-func NewCRDClientForAPIVersionKind(client kubernetes.Interface, kubeConfig, apiServerURL, apiVersion, kind string) (*rest.RESTClient, *runtime.Scheme, error) {
-	if kubeConfig == "" {
-		if _, err := os.Stat(clientcmd.RecommendedHomeFile); err == nil {
-			kubeConfig = clientcmd.RecommendedHomeFile
-		}
-	}
-
-	config, err := clientcmd.BuildConfigFromFlags(apiServerURL, kubeConfig)
+func NewCRDClientForAPIVersionKind(client kubernetes.Interface, kubeConfig, apiServerURL string, opts RestConfigOptions, apiVersion, kind string) (*rest.RESTClient, *runtime.Scheme, error) {
+	config, err := GetRestConfig(kubeConfig, apiServerURL, opts)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -111,7 +129,12 @@ func NewCRDClientForAPIVersionKind(client kubernetes.Interface, kubeConfig, apiS
 }
 
 // NewCRDSource creates a new crdSource with the given config.
-func NewCRDSource(crdClient rest.Interface, namespace, kind string, annotationFilter string, labelSelector labels.Selector, scheme *runtime.Scheme, startInformer bool) (Source, error) {
+func NewCRDSource(crdClient rest.Interface, namespace, kind string, annotationFilter string, labelSelector labels.Selector, scheme *runtime.Scheme, startInformer, finalizer bool, fqdnTemplate string, eventRecorder record.EventRecorder, informerOpts informers.Options) (Source, error) {
+	tmpl, err := fqdn.ParseTemplate(fqdnTemplate)
+	if err != nil {
+		return nil, err
+	}
+
 	sourceCrd := crdSource{
 		crdResource:      strings.ToLower(kind) + "s",
 		namespace:        namespace,
@@ -119,10 +142,14 @@ func NewCRDSource(crdClient rest.Interface, namespace, kind string, annotationFi
 		labelSelector:    labelSelector,
 		crdClient:        crdClient,
 		codec:            runtime.NewParameterCodec(scheme),
+		finalizer:        finalizer,
+		cleanup:          make(map[types.UID]struct{}),
+		fqdnTemplate:     tmpl,
+		eventRecorder:    eventRecorder,
 	}
-	if startInformer {
+	if startInformer || finalizer {
 		// external-dns already runs its sync-handler periodically (controlled by `--interval` flag) to ensure any
-		// missed or dropped events are handled. specify resync period 0 to avoid unnecessary sync handler invocations.
+		// missed or dropped events are handled, the resync period is 0 unless configured with `--resync-period`.
 		informer := cache.NewSharedInformer(
 			&cache.ListWatch{
 				ListWithContextFunc: func(ctx context.Context, lo metav1.ListOptions) (result runtime.Object, err error) {
@@ -133,7 +160,18 @@ func NewCRDSource(crdClient rest.Interface, namespace, kind string, annotationFi
 				},
 			},
 			&apiv1alpha1.DNSEndpoint{},
-			0)
+			informerOpts.ResyncPeriod)
+		informers.SetWatchBackoff(informerOpts.WatchBackoffMaxDelay, informer)
+		if finalizer {
+			// the finalizer is added as soon as the DNSEndpoints are created, so that a DNSEndpoint deleted before
+			// a synchronization still waits for the removal of its records
+			_, _ = informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+				AddFunc: sourceCrd.addFinalizer,
+				UpdateFunc: func(_, obj interface{}) {
+					sourceCrd.addFinalizer(obj)
+				},
+			})
+		}
 		sourceCrd.informer = &informer
 		go informer.Run(wait.NeverStop)
 	}
@@ -181,10 +219,20 @@ func (cs *crdSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error
 		return nil, err
 	}
 
+	cleanup := make(map[types.UID]struct{})
 	for _, dnsEndpoint := range result.Items {
+		if cs.finalizer && !dnsEndpoint.DeletionTimestamp.IsZero() {
+			// The records of a deleted DNSEndpoint are not returned, so this synchronization removes them
+			// and the finalizer is removed afterwards by ReportSync.
+			if slices.Contains(dnsEndpoint.Finalizers, apiv1alpha1.DNSEndpointFinalizer) {
+				cleanup[dnsEndpoint.UID] = struct{}{}
+			}
+			continue
+		}
+
 		// Make sure that all endpoints have targets for A or CNAME type
 		var crdEndpoints []*endpoint.Endpoint
-		for _, ep := range dnsEndpoint.Spec.Endpoints {
+		for _, ep := range cs.endpointsFromTemplate(&dnsEndpoint) {
 			if (ep.RecordType == endpoint.RecordTypeCNAME || ep.RecordType == endpoint.RecordTypeA || ep.RecordType == endpoint.RecordTypeAAAA) && len(ep.Targets) < 1 {
 				log.Warnf("Endpoint %s with DNSName %s has an empty list of targets", dnsEndpoint.Name, ep.DNSName)
 				continue
@@ -225,9 +273,182 @@ func (cs *crdSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error
 		}
 	}
 
+	cs.cleanupMutex.Lock()
+	cs.cleanup = cleanup
+	cs.cleanupMutex.Unlock()
+
 	return endpoints, nil
 }
 
+// endpointsFromTemplate returns the endpoints of the DNSEndpoint, those without a DNS name get a copy for
+// every hostname generated by the FQDN template. The template of the annotation of the DNSEndpoint overrides
+// the global one, the endpoints without a DNS name are skipped when it is invalid.
+func (cs *crdSource) endpointsFromTemplate(dnsEndpoint *apiv1alpha1.DNSEndpoint) []*endpoint.Endpoint {
+	tmpl := cs.fqdnTemplate
+	if override, ok := dnsEndpoint.Annotations[annotations.FQDNTemplateKey]; ok {
+		var err error
+		if tmpl, err = fqdn.ParseTemplate(override); err != nil {
+			cs.invalidFQDNTemplate(dnsEndpoint, err)
+			return endpointsWithDNSName(dnsEndpoint.Spec.Endpoints)
+		}
+	}
+	if tmpl == nil || !slices.ContainsFunc(dnsEndpoint.Spec.Endpoints, func(ep *endpoint.Endpoint) bool { return ep.DNSName == "" }) {
+		return dnsEndpoint.Spec.Endpoints
+	}
+
+	hostnames, err := fqdn.ExecTemplate(tmpl, dnsEndpoint)
+	if err != nil {
+		cs.invalidFQDNTemplate(dnsEndpoint, err)
+		return endpointsWithDNSName(dnsEndpoint.Spec.Endpoints)
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, ep := range dnsEndpoint.Spec.Endpoints {
+		if ep.DNSName != "" {
+			endpoints = append(endpoints, ep)
+			continue
+		}
+		for _, hostname := range hostnames {
+			templated := ep.DeepCopy()
+			templated.DNSName = hostname
+			endpoints = append(endpoints, templated)
+		}
+	}
+	return endpoints
+}
+
+func endpointsWithDNSName(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	return slices.DeleteFunc(slices.Clone(endpoints), func(ep *endpoint.Endpoint) bool {
+		return ep.DNSName == ""
+	})
+}
+
+// invalidFQDNTemplate reports an FQDN template which can't generate the DNS names of the DNSEndpoint.
+func (cs *crdSource) invalidFQDNTemplate(dnsEndpoint *apiv1alpha1.DNSEndpoint, err error) {
+	log.Warnf("Skipping the endpoints without DNS name of the CRD %s/%s, invalid FQDN template: %v", dnsEndpoint.Namespace, dnsEndpoint.Name, err)
+	if cs.eventRecorder == nil {
+		return
+	}
+	ref := &corev1.ObjectReference{
+		Kind:       "DNSEndpoint",
+		APIVersion: apiv1alpha1.GroupVersion.String(),
+		Namespace:  dnsEndpoint.Namespace,
+		Name:       dnsEndpoint.Name,
+		UID:        dnsEndpoint.UID,
+	}
+	cs.eventRecorder.Eventf(ref, corev1.EventTypeWarning, crdReasonInvalidFQDNTemplate, "Invalid FQDN template: %v", err)
+}
+
+// ReportSync updates the status conditions of the DNSEndpoints with the result of the last synchronization.
+// Only DNSEndpoints whose status changed are updated.
+func (cs *crdSource) ReportSync(ctx context.Context, syncErr error) {
+	result, err := cs.List(ctx, &metav1.ListOptions{LabelSelector: cs.labelSelector.String()})
+	if err != nil {
+		log.Warnf("Could not list the CRDs to report the sync status: %v", err)
+		return
+	}
+
+	result, err = cs.filterByAnnotations(result)
+	if err != nil {
+		log.Warnf("Could not filter the CRDs to report the sync status: %v", err)
+		return
+	}
+
+	cs.cleanupMutex.Lock()
+	defer cs.cleanupMutex.Unlock()
+
+	for i := range result.Items {
+		dnsEndpoint := &result.Items[i]
+		if !dnsEndpoint.DeletionTimestamp.IsZero() {
+			if _, ok := cs.cleanup[dnsEndpoint.UID]; ok && syncErr == nil {
+				cs.removeFinalizer(ctx, dnsEndpoint)
+			}
+			continue
+		}
+
+		status := dnsEndpoint.Status.DeepCopy()
+		setSyncConditions(&dnsEndpoint.Status, dnsEndpoint.Generation, syncErr)
+		if equality.Semantic.DeepEqual(status, &dnsEndpoint.Status) {
+			continue
+		}
+		_, err = cs.UpdateStatus(ctx, dnsEndpoint)
+		if err != nil {
+			log.Warnf("Could not update the sync status of the CRD %s/%s: %v", dnsEndpoint.Namespace, dnsEndpoint.Name, err)
+		}
+	}
+}
+
+// addFinalizer adds the cleanup finalizer to a DNSEndpoint of the informer, unless it is being deleted or isn't
+// selected by the label selector and the annotation filter.
+func (cs *crdSource) addFinalizer(obj interface{}) {
+	dnsEndpoint, ok := obj.(*apiv1alpha1.DNSEndpoint)
+	if !ok || !dnsEndpoint.DeletionTimestamp.IsZero() || slices.Contains(dnsEndpoint.Finalizers, apiv1alpha1.DNSEndpointFinalizer) {
+		return
+	}
+	if !cs.labelSelector.Matches(labels.Set(dnsEndpoint.Labels)) {
+		return
+	}
+	filtered, err := cs.filterByAnnotations(&apiv1alpha1.DNSEndpointList{Items: []apiv1alpha1.DNSEndpoint{*dnsEndpoint}})
+	if err != nil || len(filtered.Items) == 0 {
+		return
+	}
+
+	// the objects of the informer are shared, they are only modified as copies
+	dnsEndpoint = dnsEndpoint.DeepCopy()
+	dnsEndpoint.Finalizers = append(dnsEndpoint.Finalizers, apiv1alpha1.DNSEndpointFinalizer)
+	if _, err := cs.Update(context.Background(), dnsEndpoint); err != nil {
+		log.Warnf("Could not add the finalizer to the CRD %s/%s: %v", dnsEndpoint.Namespace, dnsEndpoint.Name, err)
+		return
+	}
+	log.Debugf("Added the finalizer to the CRD %s/%s", dnsEndpoint.Namespace, dnsEndpoint.Name)
+}
+
+// removeFinalizer removes the cleanup finalizer once the records of the deleted DNSEndpoint were removed.
+func (cs *crdSource) removeFinalizer(ctx context.Context, dnsEndpoint *apiv1alpha1.DNSEndpoint) {
+	dnsEndpoint.Finalizers = slices.DeleteFunc(dnsEndpoint.Finalizers, func(f string) bool {
+		return f == apiv1alpha1.DNSEndpointFinalizer
+	})
+	if _, err := cs.Update(ctx, dnsEndpoint); err != nil {
+		log.Warnf("Could not remove the finalizer from the CRD %s/%s: %v", dnsEndpoint.Namespace, dnsEndpoint.Name, err)
+		return
+	}
+	log.Infof("Removed the finalizer from the CRD %s/%s after cleaning up its records", dnsEndpoint.Namespace, dnsEndpoint.Name)
+	delete(cs.cleanup, dnsEndpoint.UID)
+}
+
+// setSyncConditions sets the Ready, Synced and Error conditions according to the result of a synchronization.
+func setSyncConditions(status *apiv1alpha1.DNSEndpointStatus, generation int64, syncErr error) {
+	status.ObservedGeneration = generation
+
+	synced := metav1.Condition{
+		Type:               apiv1alpha1.DNSEndpointSynced,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             crdReasonSynced,
+		Message:            "Endpoints are synchronized with the DNS provider",
+	}
+	failed := metav1.Condition{
+		Type:               apiv1alpha1.DNSEndpointError,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             crdReasonSynced,
+	}
+	if syncErr != nil {
+		synced.Status = metav1.ConditionFalse
+		synced.Reason = crdReasonSyncFailed
+		synced.Message = syncErr.Error()
+		failed.Status = metav1.ConditionTrue
+		failed.Reason = crdReasonSyncFailed
+		failed.Message = syncErr.Error()
+	}
+	ready := synced
+	ready.Type = apiv1alpha1.DNSEndpointReady
+
+	meta.SetStatusCondition(&status.Conditions, ready)
+	meta.SetStatusCondition(&status.Conditions, synced)
+	meta.SetStatusCondition(&status.Conditions, failed)
+}
+
 func (cs *crdSource) watch(ctx context.Context, opts *metav1.ListOptions) (watch.Interface, error) {
 	opts.Watch = true
 	return cs.crdClient.Get().
@@ -248,6 +469,18 @@ func (cs *crdSource) List(ctx context.Context, opts *metav1.ListOptions) (result
 	return
 }
 
+func (cs *crdSource) Update(ctx context.Context, dnsEndpoint *apiv1alpha1.DNSEndpoint) (result *apiv1alpha1.DNSEndpoint, err error) {
+	result = &apiv1alpha1.DNSEndpoint{}
+	err = cs.crdClient.Put().
+		Namespace(dnsEndpoint.Namespace).
+		Resource(cs.crdResource).
+		Name(dnsEndpoint.Name).
+		Body(dnsEndpoint).
+		Do(ctx).
+		Into(result)
+	return
+}
+
 func (cs *crdSource) UpdateStatus(ctx context.Context, dnsEndpoint *apiv1alpha1.DNSEndpoint) (result *apiv1alpha1.DNSEndpoint, err error) {
 	result = &apiv1alpha1.DNSEndpoint{}
 	err = cs.crdClient.Put().
@@ -20,11 +20,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/rand"
 	"net/http"
+	"slices"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -41,8 +44,11 @@ import (
 	"k8s.io/client-go/rest/fake"
 	"k8s.io/client-go/tools/cache"
 	cachetesting "k8s.io/client-go/tools/cache/testing"
+	"k8s.io/client-go/tools/record"
+
 	apiv1alpha1 "sigs.k8s.io/external-dns/apis/v1alpha1"
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/informers"
 )
 
 type CRDSuite struct {
@@ -494,7 +500,7 @@ func testCRDSourceEndpoints(t *testing.T) {
 			// At present, client-go's fake.RESTClient (used by crd_test.go) is known to cause race conditions when used
 			// with informers: https://github.com/kubernetes/kubernetes/issues/95372
 			// So don't start the informer during testing.
-			cs, err := NewCRDSource(restClient, ti.namespace, ti.kind, ti.annotationFilter, labelSelector, scheme, false)
+			cs, err := NewCRDSource(restClient, ti.namespace, ti.kind, ti.annotationFilter, labelSelector, scheme, false, false, "", nil, informers.Options{})
 			require.NoError(t, err)
 
 			receivedEndpoints, err := cs.Endpoints(t.Context())
@@ -721,7 +727,7 @@ func TestDNSEndpointsWithSetResourceLabels(t *testing.T) {
 	}
 }
 
-func helperCreateWatcherWithInformer(t *testing.T) (*cachetesting.FakeControllerSource, crdSource) {
+func helperCreateWatcherWithInformer(t *testing.T) (*cachetesting.FakeControllerSource, *crdSource) {
 	t.Helper()
 	ctx := t.Context()
 
@@ -739,7 +745,7 @@ func helperCreateWatcherWithInformer(t *testing.T) (*cachetesting.FakeController
 		informer: &informer,
 	}
 
-	return watcher, *cs
+	return watcher, cs
 }
 
 // generateTestFixtureDNSEndpointsByType generates DNSEndpoint CRDs according to the provided counts per RecordType.
@@ -777,3 +783,313 @@ func generateTestFixtureDNSEndpointsByType(namespace string, typeCounts map[stri
 		Items: result,
 	}
 }
+
+func TestCRDSourceReportSync(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1alpha1.AddToScheme(scheme))
+	codecFactory := serializer.WithoutConversionCodecFactory{
+		CodecFactory: serializer.NewCodecFactory(scheme),
+	}
+	codec := codecFactory.LegacyCodec(apiv1alpha1.GroupVersion)
+
+	dnsEndpoint := apiv1alpha1.DNSEndpoint{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apiv1alpha1.GroupVersion.String(),
+			Kind:       "DNSEndpoint",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "foo",
+			Namespace:  "default",
+			Generation: 2,
+		},
+	}
+
+	var updates int
+	client := &fake.RESTClient{
+		GroupVersion:         apiv1alpha1.GroupVersion,
+		VersionedAPIPath:     "/apis/" + apiv1alpha1.GroupVersion.String(),
+		NegotiatedSerializer: codecFactory,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			switch p, m := req.URL.Path, req.Method; {
+			case p == "/apis/externaldns.k8s.io/v1alpha1/namespaces/default/dnsendpoints" && m == http.MethodGet:
+				list := &apiv1alpha1.DNSEndpointList{Items: []apiv1alpha1.DNSEndpoint{dnsEndpoint}}
+				return &http.Response{StatusCode: http.StatusOK, Header: defaultHeader(), Body: objBody(codec, list)}, nil
+			case p == "/apis/externaldns.k8s.io/v1alpha1/namespaces/default/dnsendpoints/foo/status" && m == http.MethodPut:
+				updates++
+				var body apiv1alpha1.DNSEndpoint
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					return nil, err
+				}
+				dnsEndpoint.Status = body.Status
+				return &http.Response{StatusCode: http.StatusOK, Header: defaultHeader(), Body: objBody(codec, &dnsEndpoint)}, nil
+			default:
+				return nil, fmt.Errorf("unexpected request: %#v\n%#v", req.URL, req)
+			}
+		}),
+	}
+
+	cs := &crdSource{
+		crdClient:     client,
+		namespace:     "default",
+		crdResource:   "dnsendpoints",
+		codec:         runtime.NewParameterCodec(scheme),
+		labelSelector: labels.Everything(),
+	}
+
+	conditions := func() map[string]metav1.Condition {
+		result := map[string]metav1.Condition{}
+		for _, c := range dnsEndpoint.Status.Conditions {
+			result[c.Type] = c
+		}
+		return result
+	}
+
+	cs.ReportSync(t.Context(), nil)
+	require.Equal(t, 1, updates)
+	require.Equal(t, int64(2), dnsEndpoint.Status.ObservedGeneration)
+	c := conditions()
+	require.Len(t, c, 3)
+	require.Equal(t, metav1.ConditionTrue, c[apiv1alpha1.DNSEndpointReady].Status)
+	require.Equal(t, metav1.ConditionTrue, c[apiv1alpha1.DNSEndpointSynced].Status)
+	require.Equal(t, metav1.ConditionFalse, c[apiv1alpha1.DNSEndpointError].Status)
+	require.Equal(t, int64(2), c[apiv1alpha1.DNSEndpointSynced].ObservedGeneration)
+
+	// an unchanged result doesn't update the status again
+	cs.ReportSync(t.Context(), nil)
+	require.Equal(t, 1, updates)
+
+	cs.ReportSync(t.Context(), errors.New("throttled"))
+	require.Equal(t, 2, updates)
+	c = conditions()
+	require.Equal(t, metav1.ConditionFalse, c[apiv1alpha1.DNSEndpointReady].Status)
+	require.Equal(t, metav1.ConditionFalse, c[apiv1alpha1.DNSEndpointSynced].Status)
+	require.Equal(t, "SyncFailed", c[apiv1alpha1.DNSEndpointSynced].Reason)
+	require.Equal(t, "throttled", c[apiv1alpha1.DNSEndpointSynced].Message)
+	require.Equal(t, metav1.ConditionTrue, c[apiv1alpha1.DNSEndpointError].Status)
+	require.Equal(t, "throttled", c[apiv1alpha1.DNSEndpointError].Message)
+}
+
+func TestCRDSourceFinalizer(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1alpha1.AddToScheme(scheme))
+	codecFactory := serializer.WithoutConversionCodecFactory{
+		CodecFactory: serializer.NewCodecFactory(scheme),
+	}
+	codec := codecFactory.LegacyCodec(apiv1alpha1.GroupVersion)
+
+	dnsEndpoint := apiv1alpha1.DNSEndpoint{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apiv1alpha1.GroupVersion.String(),
+			Kind:       "DNSEndpoint",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "foo",
+			Namespace:  "default",
+			UID:        "3e1a0a36-7c1c-4b4e-9d0c-54e4a1b1a0e1",
+			Generation: 1,
+		},
+		Spec: apiv1alpha1.DNSEndpointSpec{
+			Endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+			},
+		},
+	}
+
+	// the watch of the informer is kept open until the end of the test
+	watchBody, watchWriter := io.Pipe()
+	t.Cleanup(func() { _ = watchWriter.Close() })
+
+	var mu sync.Mutex
+	finalizers := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return dnsEndpoint.Finalizers
+	}
+	// unlike the fake RESTClient, a RESTClient of a fake HTTP client is safe for the concurrent requests of the informer
+	httpClient := fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+		const path = "/apis/externaldns.k8s.io/v1alpha1/namespaces/default/dnsendpoints"
+		mu.Lock()
+		defer mu.Unlock()
+		var body apiv1alpha1.DNSEndpoint
+		switch p, m := req.URL.Path, req.Method; {
+		case p == path && m == http.MethodGet && req.URL.Query().Get("watch") == "true":
+			return &http.Response{StatusCode: http.StatusOK, Header: defaultHeader(), Body: watchBody}, nil
+		case p == path && m == http.MethodGet:
+			list := &apiv1alpha1.DNSEndpointList{Items: []apiv1alpha1.DNSEndpoint{dnsEndpoint}}
+			return &http.Response{StatusCode: http.StatusOK, Header: defaultHeader(), Body: objBody(codec, list)}, nil
+		case p == path+"/foo" && m == http.MethodPut:
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			dnsEndpoint.Finalizers = body.Finalizers
+		case p == path+"/foo/status" && m == http.MethodPut:
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			dnsEndpoint.Status = body.Status
+		default:
+			return nil, fmt.Errorf("unexpected request: %#v\n%#v", req.URL, req)
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: defaultHeader(), Body: objBody(codec, &dnsEndpoint)}, nil
+	})
+	client, err := rest.RESTClientForConfigAndClient(&rest.Config{
+		Host:    "https://localhost",
+		APIPath: "/apis",
+		ContentConfig: rest.ContentConfig{
+			GroupVersion:         &apiv1alpha1.GroupVersion,
+			NegotiatedSerializer: codecFactory,
+		},
+	}, httpClient)
+	require.NoError(t, err)
+
+	src, err := NewCRDSource(client, "default", "DNSEndpoint", "", labels.Everything(), scheme, false, true, "", nil, informers.Options{})
+	require.NoError(t, err)
+	cs := src.(*crdSource)
+
+	// the finalizer is added to new objects by the informer
+	require.Eventually(t, func() bool {
+		return slices.Equal(finalizers(), []string{apiv1alpha1.DNSEndpointFinalizer})
+	}, 5*time.Second, 10*time.Millisecond)
+	endpoints, err := cs.Endpoints(t.Context())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	cs.ReportSync(t.Context(), nil)
+	require.Equal(t, []string{apiv1alpha1.DNSEndpointFinalizer}, finalizers())
+
+	// the records of deleted objects are removed while the finalizer blocks the deletion
+	mu.Lock()
+	dnsEndpoint.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	mu.Unlock()
+	endpoints, err = cs.Endpoints(t.Context())
+	require.NoError(t, err)
+	require.Empty(t, endpoints)
+
+	// a failed synchronization keeps the finalizer
+	cs.ReportSync(t.Context(), errors.New("throttled"))
+	require.Equal(t, []string{apiv1alpha1.DNSEndpointFinalizer}, finalizers())
+
+	// the finalizer is removed after the records were cleaned up
+	_, err = cs.Endpoints(t.Context())
+	require.NoError(t, err)
+	cs.ReportSync(t.Context(), nil)
+	require.Empty(t, finalizers())
+}
+
+func TestCRDSourceWithoutFinalizer(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1alpha1.AddToScheme(scheme))
+	codecFactory := serializer.WithoutConversionCodecFactory{
+		CodecFactory: serializer.NewCodecFactory(scheme),
+	}
+	codec := codecFactory.LegacyCodec(apiv1alpha1.GroupVersion)
+
+	dnsEndpoint := apiv1alpha1.DNSEndpoint{
+		TypeMeta:   metav1.TypeMeta{APIVersion: apiv1alpha1.GroupVersion.String(), Kind: "DNSEndpoint"},
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default", Generation: 1},
+		Spec: apiv1alpha1.DNSEndpointSpec{
+			Endpoints: []*endpoint.Endpoint{endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		},
+		Status: apiv1alpha1.DNSEndpointStatus{ObservedGeneration: 1},
+	}
+	client := &fake.RESTClient{
+		GroupVersion:         apiv1alpha1.GroupVersion,
+		VersionedAPIPath:     "/apis/" + apiv1alpha1.GroupVersion.String(),
+		NegotiatedSerializer: codecFactory,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			if req.Method != http.MethodGet {
+				return nil, fmt.Errorf("unexpected request: %s %s", req.Method, req.URL)
+			}
+			list := &apiv1alpha1.DNSEndpointList{Items: []apiv1alpha1.DNSEndpoint{dnsEndpoint}}
+			return &http.Response{StatusCode: http.StatusOK, Header: defaultHeader(), Body: objBody(codec, list)}, nil
+		}),
+	}
+
+	src, err := NewCRDSource(client, "default", "DNSEndpoint", "", labels.Everything(), scheme, false, false, "", nil, informers.Options{})
+	require.NoError(t, err)
+
+	// the DNSEndpoints are not updated when the finalizer is disabled
+	endpoints, err := src.Endpoints(t.Context())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+}
+
+func TestCRDSourceFQDNTemplate(t *testing.T) {
+	apiVersion := apiv1alpha1.GroupVersion.String()
+	newEndpoints := func() []*endpoint.Endpoint {
+		return []*endpoint.Endpoint{
+			{RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+			{DNSName: "static.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+		}
+	}
+	resource := "crd/default/web"
+
+	for _, tt := range []struct {
+		title        string
+		annotations  map[string]string
+		fqdnTemplate string
+		expected     []*endpoint.Endpoint
+		expectEvent  bool
+	}{
+		{
+			title:        "global template",
+			fqdnTemplate: "{{ .Name }}.example.com",
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("web.example.com", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, resource),
+				endpoint.NewEndpoint("static.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, resource),
+			},
+		},
+		{
+			title:        "annotation overrides the global template",
+			annotations:  map[string]string{"external-dns.alpha.kubernetes.io/fqdn-template": "{{ .Name }}.{{ .Namespace }}.example.net,{{ .Name }}.example.net"},
+			fqdnTemplate: "{{ .Name }}.example.com",
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("web.default.example.net", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, resource),
+				endpoint.NewEndpoint("web.example.net", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, resource),
+				endpoint.NewEndpoint("static.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, resource),
+			},
+		},
+		{
+			title:        "invalid annotation skips the endpoints without DNS name",
+			annotations:  map[string]string{"external-dns.alpha.kubernetes.io/fqdn-template": "{{ .Name }.example.net"},
+			fqdnTemplate: "{{ .Name }}.example.com",
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("static.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, resource),
+			},
+			expectEvent: true,
+		},
+		{
+			title:       "annotation failing to execute skips the endpoints without DNS name",
+			annotations: map[string]string{"external-dns.alpha.kubernetes.io/fqdn-template": "{{ .Missing }}.example.net"},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("static.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, resource),
+			},
+			expectEvent: true,
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			restClient := fakeRESTClient(newEndpoints(), apiVersion, "DNSEndpoint", "default", "web", tt.annotations, nil, t)
+			scheme := runtime.NewScheme()
+			require.NoError(t, addKnownTypes(scheme, apiv1alpha1.GroupVersion))
+			recorder := record.NewFakeRecorder(10)
+
+			cs, err := NewCRDSource(restClient, "default", "DNSEndpoint", "", labels.Everything(), scheme, false, false, tt.fqdnTemplate, recorder, informers.Options{})
+			require.NoError(t, err)
+
+			endpoints, err := cs.Endpoints(t.Context())
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tt.expected)
+
+			if tt.expectEvent {
+				require.Len(t, recorder.Events, 1)
+				require.Contains(t, <-recorder.Events, "Warning InvalidFQDNTemplate Invalid FQDN template")
+			} else {
+				require.Empty(t, recorder.Events)
+			}
+		})
+	}
+
+	t.Run("invalid global template", func(t *testing.T) {
+		_, err := NewCRDSource(fakeRESTClient(nil, apiVersion, "DNSEndpoint", "default", "web", nil, nil, t), "default", "DNSEndpoint", "", labels.Everything(), runtime.NewScheme(), false, false, "{{ .Name", nil, informers.Options{})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+
+	dnsrecordv1alpha1 "sigs.k8s.io/external-dns/apis/dnsrecord/v1alpha1"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+var dnsRecordGVR = dnsrecordv1alpha1.GroupVersion.WithResource("dnsrecords")
+
+// dnsRecordSource is an implementation of Source for DNSRecord objects.
+// Each DNSRecord is converted to exactly one endpoint.
+type dnsRecordSource struct {
+	dnsRecordInformer     kubeinformers.GenericInformer
+	namespace             string
+	annotationFilter      string
+	labelSelector         labels.Selector
+	unstructuredConverter *unstructuredConverter
+}
+
+// NewDNSRecordSource creates a new dnsRecordSource with the given config.
+func NewDNSRecordSource(
+	ctx context.Context,
+	dynamicKubeClient dynamic.Interface,
+	namespace string,
+	annotationFilter string,
+	labelSelector labels.Selector,
+	informerOpts informers.Options,
+) (Source, error) {
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, informerOpts.ResyncPeriod, namespace, nil)
+	dnsRecordInformer := informerFactory.ForResource(dnsRecordGVR)
+
+	dnsRecordInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+			},
+		},
+	)
+
+	informers.SetWatchBackoff(informerOpts.WatchBackoffMaxDelay, dnsRecordInformer.Informer())
+	informerFactory.Start(ctx.Done())
+
+	// wait for the local cache to be populated.
+	if err := informers.WaitForDynamicCacheSync(context.Background(), informerFactory); err != nil {
+		return nil, err
+	}
+
+	uc := &unstructuredConverter{scheme: runtime.NewScheme()}
+	if err := dnsrecordv1alpha1.AddToScheme(uc.scheme); err != nil {
+		return nil, fmt.Errorf("failed to setup unstructured converter: %w", err)
+	}
+
+	return &dnsRecordSource{
+		dnsRecordInformer:     dnsRecordInformer,
+		namespace:             namespace,
+		annotationFilter:      annotationFilter,
+		labelSelector:         labelSelector,
+		unstructuredConverter: uc,
+	}, nil
+}
+
+// Endpoints returns an endpoint for each DNSRecord in the source's namespace(s).
+func (rs *dnsRecordSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	objects, err := rs.dnsRecordInformer.Lister().ByNamespace(rs.namespace).List(rs.labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	selector, err := annotations.ParseFilter(rs.annotationFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := []*endpoint.Endpoint{}
+	for _, obj := range objects {
+		unstructuredRecord, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return nil, errors.New("could not convert")
+		}
+
+		record := &dnsrecordv1alpha1.DNSRecord{}
+		if err := rs.unstructuredConverter.scheme.Convert(unstructuredRecord, record, nil); err != nil {
+			return nil, err
+		}
+
+		if !selector.Matches(labels.Set(record.Annotations)) {
+			continue
+		}
+
+		ep := endpointFromDNSRecord(record)
+		if ep == nil {
+			log.Warnf("DNSRecord %s/%s has no name, type or targets, skipping", record.Namespace, record.Name)
+			continue
+		}
+		endpoints = append(endpoints, ep)
+	}
+
+	return endpoints, nil
+}
+
+func (rs *dnsRecordSource) AddEventHandler(ctx context.Context, handler func()) {
+	log.Debug("Adding event handler for DNSRecord")
+
+	rs.dnsRecordInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
+}
+
+// endpointFromDNSRecord converts a DNSRecord to an endpoint. The API server validates the spec,
+// only the required fields are checked again as the CRD may not be the one provided by external-dns.
+func endpointFromDNSRecord(record *dnsrecordv1alpha1.DNSRecord) *endpoint.Endpoint {
+	spec := record.Spec
+	if spec.Name == "" || spec.RecordType == "" || len(spec.Targets) == 0 {
+		return nil
+	}
+
+	var ttl endpoint.TTL
+	if spec.TTL != nil {
+		ttl = endpoint.TTL(*spec.TTL)
+	}
+
+	ep := endpoint.NewEndpointWithTTL(spec.Name, spec.RecordType, ttl, spec.Targets...).
+		WithSetIdentifier(spec.SetIdentifier).
+		WithLabel(endpoint.ResourceLabelKey, fmt.Sprintf("dnsrecord/%s/%s", record.Namespace, record.Name))
+	for _, ps := range spec.ProviderSpecific {
+		ep.WithProviderSpecific(ps.Name, ps.Value)
+	}
+	return ep
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeDynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/utils/ptr"
+
+	dnsrecordv1alpha1 "sigs.k8s.io/external-dns/apis/dnsrecord/v1alpha1"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+func newDNSRecord(namespace, name string, labels, annotations map[string]string, spec dnsrecordv1alpha1.DNSRecordSpec) *dnsrecordv1alpha1.DNSRecord {
+	return &dnsrecordv1alpha1.DNSRecord{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: dnsrecordv1alpha1.GroupVersion.String(),
+			Kind:       "DNSRecord",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        name,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: spec,
+	}
+}
+
+func TestDNSRecordSourceEndpoints(t *testing.T) {
+	t.Parallel()
+
+	records := []*dnsrecordv1alpha1.DNSRecord{
+		newDNSRecord("default", "web", map[string]string{"team": "web"}, nil, dnsrecordv1alpha1.DNSRecordSpec{
+			Name:       "www.example.com.",
+			RecordType: endpoint.RecordTypeA,
+			Targets:    []string{"1.2.3.4", "5.6.7.8"},
+			TTL:        ptr.To[int64](300),
+		}),
+		newDNSRecord("default", "weighted", map[string]string{"team": "api"}, map[string]string{"dns": "public"}, dnsrecordv1alpha1.DNSRecordSpec{
+			Name:          "api.example.com",
+			RecordType:    endpoint.RecordTypeCNAME,
+			Targets:       []string{"lb.example.org"},
+			SetIdentifier: "eu",
+			ProviderSpecific: []dnsrecordv1alpha1.ProviderSpecificProperty{
+				{Name: "aws/weight", Value: "10"},
+			},
+		}),
+		newDNSRecord("other", "txt", nil, nil, dnsrecordv1alpha1.DNSRecordSpec{
+			Name:       "txt.example.com",
+			RecordType: endpoint.RecordTypeTXT,
+			Targets:    []string{"v=spf1 -all"},
+		}),
+		// invalid records created before the CRD validation are skipped
+		newDNSRecord("other", "invalid", nil, nil, dnsrecordv1alpha1.DNSRecordSpec{
+			Name:       "invalid.example.com",
+			RecordType: endpoint.RecordTypeA,
+		}),
+	}
+
+	web := endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "5.6.7.8").
+		WithLabel(endpoint.ResourceLabelKey, "dnsrecord/default/web")
+	weighted := endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeCNAME, "lb.example.org").
+		WithSetIdentifier("eu").
+		WithProviderSpecific("aws/weight", "10").
+		WithLabel(endpoint.ResourceLabelKey, "dnsrecord/default/weighted")
+	txt := endpoint.NewEndpoint("txt.example.com", endpoint.RecordTypeTXT, "v=spf1 -all").
+		WithLabel(endpoint.ResourceLabelKey, "dnsrecord/other/txt")
+
+	tests := []struct {
+		name             string
+		namespace        string
+		annotationFilter string
+		labelFilter      string
+		expected         []*endpoint.Endpoint
+	}{
+		{
+			name:     "all namespaces",
+			expected: []*endpoint.Endpoint{web, weighted, txt},
+		},
+		{
+			name:      "single namespace",
+			namespace: "default",
+			expected:  []*endpoint.Endpoint{web, weighted},
+		},
+		{
+			name:             "annotation filter",
+			annotationFilter: "dns=public",
+			expected:         []*endpoint.Endpoint{weighted},
+		},
+		{
+			name:        "label filter",
+			labelFilter: "team=web",
+			expected:    []*endpoint.Endpoint{web},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			scheme := runtime.NewScheme()
+			require.NoError(t, dnsrecordv1alpha1.AddToScheme(scheme))
+			fakeDynamicClient := fakeDynamic.NewSimpleDynamicClient(scheme)
+
+			for _, record := range records {
+				obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(record)
+				require.NoError(t, err)
+				_, err = fakeDynamicClient.Resource(dnsRecordGVR).Namespace(record.Namespace).
+					Create(context.Background(), &unstructured.Unstructured{Object: obj}, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
+			labelSelector, err := labels.Parse(tt.labelFilter)
+			require.NoError(t, err)
+
+			src, err := NewDNSRecordSource(context.Background(), fakeDynamicClient, tt.namespace, tt.annotationFilter, labelSelector, informers.Options{})
+			require.NoError(t, err)
+
+			endpoints, err := src.Endpoints(context.Background())
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// NewExtraEndpointClientGenerators returns a client generator for each additional API server, given as url=kubeconfig.
+// The kubeconfig is optional, the credentials of the default kubeconfig are used without it.
+func NewExtraEndpointClientGenerators(values []string, requestTimeout time.Duration) (map[string]ClientGenerator, error) {
+	clients := make(map[string]ClientGenerator, len(values))
+	for _, value := range values {
+		apiServerURL, kubeConfig, _ := strings.Cut(strings.TrimSpace(value), "=")
+		u, err := url.Parse(apiServerURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid API server endpoint %q, expected url or url=kubeconfig", value)
+		}
+		if _, ok := clients[apiServerURL]; ok {
+			return nil, fmt.Errorf("duplicate API server endpoint %q", apiServerURL)
+		}
+		clients[apiServerURL] = &SingletonClientGenerator{
+			KubeConfig:     kubeConfig,
+			APIServerURL:   apiServerURL,
+			RequestTimeout: requestTimeout,
+		}
+	}
+	return clients, nil
+}
+
+// ExtraEndpointSources builds the sources of the given names on each additional API server, each with its own informers.
+// As the API servers may serve different resources, a source which can't be built on an API server is skipped with a warning.
+// The endpoints of these sources are meant to be merged with the ones of the default API server by a MultiSource.
+func ExtraEndpointSources(ctx context.Context, clients map[string]ClientGenerator, names []string, cfg *Config) ([]Source, error) {
+	apiServerURLs := make([]string, 0, len(clients))
+	for apiServerURL := range clients {
+		apiServerURLs = append(apiServerURLs, apiServerURL)
+	}
+	slices.Sort(apiServerURLs)
+
+	sources := []Source{}
+	for _, apiServerURL := range apiServerURLs {
+		for _, name := range names {
+			src, err := BuildWithConfig(ctx, name, clients[apiServerURL], cfg)
+			if errors.Is(err, ErrSourceNotFound) {
+				return nil, err
+			}
+			if err != nil {
+				log.Warnf("Skipping the %s source of the API server %s: %v", name, apiServerURL, err)
+				continue
+			}
+			log.Infof("Created the %s source of the API server %s", name, apiServerURL)
+			sources = append(sources, src)
+		}
+	}
+	return sources, nil
+}
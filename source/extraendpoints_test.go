@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	fakeKube "k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func newExtraEndpointService(name, hostname, ip string) *v1.Service {
+	return &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        name,
+			Annotations: map[string]string{hostnameAnnotationKey: hostname},
+		},
+		Spec: v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer},
+		Status: v1.ServiceStatus{
+			LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: ip}}},
+		},
+	}
+}
+
+func newExtraEndpointClientGenerator(client kubernetes.Interface) ClientGenerator {
+	m := new(MockClientGenerator)
+	m.On("KubeClient").Return(client, nil)
+	return m
+}
+
+func TestExtraEndpointSources(t *testing.T) {
+	defaultClient := fakeKube.NewSimpleClientset(newExtraEndpointService("shared", "shared.example.org", "1.2.3.4"))
+	clients := map[string]ClientGenerator{
+		"https://api.a.example.com": newExtraEndpointClientGenerator(fakeKube.NewSimpleClientset(
+			newExtraEndpointService("shared", "shared.example.org", "1.2.3.4"),
+			newExtraEndpointService("a", "a.example.org", "1.1.1.1"),
+		)),
+		"https://api.b.example.com": newExtraEndpointClientGenerator(fakeKube.NewSimpleClientset(
+			newExtraEndpointService("shared", "shared.example.org", "1.2.3.4"),
+			newExtraEndpointService("b", "b.example.org", "2.2.2.2"),
+		)),
+	}
+	cfg := &Config{LabelFilter: labels.Everything()}
+
+	sources, err := ByNames(context.Background(), newExtraEndpointClientGenerator(defaultClient), []string{"service"}, cfg)
+	require.NoError(t, err)
+	extraSources, err := ExtraEndpointSources(context.Background(), clients, []string{"service"}, cfg)
+	require.NoError(t, err)
+	require.Len(t, extraSources, 2, "a source should be built for each API server")
+
+	endpoints, err := NewDedupSource(NewMultiSource(append(sources, extraSources...), nil)).Endpoints(context.Background())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("shared.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "service/default/shared"),
+		endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.1.1.1").WithLabel(endpoint.ResourceLabelKey, "service/default/a"),
+		endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeA, "2.2.2.2").WithLabel(endpoint.ResourceLabelKey, "service/default/b"),
+	})
+}
+
+func TestExtraEndpointSourcesSkipsUnavailableSources(t *testing.T) {
+	failing := new(MockClientGenerator)
+	failing.On("KubeClient").Return(nil, errors.New("unreachable"))
+	clients := map[string]ClientGenerator{
+		"https://api.a.example.com": failing,
+		"https://api.b.example.com": newExtraEndpointClientGenerator(fakeKube.NewSimpleClientset()),
+	}
+
+	sources, err := ExtraEndpointSources(context.Background(), clients, []string{"service"}, &Config{LabelFilter: labels.Everything()})
+	require.NoError(t, err)
+	assert.Len(t, sources, 1)
+
+	_, err = ExtraEndpointSources(context.Background(), clients, []string{"unknown"}, &Config{})
+	assert.ErrorIs(t, err, ErrSourceNotFound)
+}
+
+func TestNewExtraEndpointClientGenerators(t *testing.T) {
+	clients, err := NewExtraEndpointClientGenerators([]string{"https://api.a.example.com", "https://api.b.example.com=/path/to/b"}, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]ClientGenerator{
+		"https://api.a.example.com": &SingletonClientGenerator{APIServerURL: "https://api.a.example.com", RequestTimeout: time.Minute},
+		"https://api.b.example.com": &SingletonClientGenerator{APIServerURL: "https://api.b.example.com", KubeConfig: "/path/to/b", RequestTimeout: time.Minute},
+	}, clients)
+
+	for _, tt := range []struct {
+		values []string
+		err    string
+	}{
+		{values: []string{"api.a.example.com"}, err: `invalid API server endpoint "api.a.example.com", expected url or url=kubeconfig`},
+		{values: []string{"=/path/to/a"}, err: `invalid API server endpoint "=/path/to/a", expected url or url=kubeconfig`},
+		{values: []string{"https://api.a.example.com", "https://api.a.example.com=/path/to/a"}, err: `duplicate API server endpoint "https://api.a.example.com"`},
+	} {
+		_, err := NewExtraEndpointClientGenerators(tt.values, time.Minute)
+		assert.EqualError(t, err, tt.err)
+	}
+}
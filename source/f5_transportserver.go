@@ -65,8 +65,9 @@ func NewF5TransportServerSource(
 	kubeClient kubernetes.Interface,
 	namespace string,
 	annotationFilter string,
+	informerOpts informers.Options,
 ) (Source, error) {
-	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, namespace, nil)
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, informerOpts.ResyncPeriod, namespace, nil)
 	transportServerInformer := informerFactory.ForResource(f5TransportServerGVR)
 
 	transportServerInformer.Informer().AddEventHandler(
@@ -76,6 +77,7 @@ func NewF5TransportServerSource(
 		},
 	)
 
+	informers.SetWatchBackoff(informerOpts.WatchBackoffMaxDelay, transportServerInformer.Informer())
 	informerFactory.Start(ctx.Done())
 
 	// wait for the local cache to be populated.
@@ -65,8 +65,9 @@ func NewF5VirtualServerSource(
 	kubeClient kubernetes.Interface,
 	namespace string,
 	annotationFilter string,
+	informerOpts informers.Options,
 ) (Source, error) {
-	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, namespace, nil)
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, informerOpts.ResyncPeriod, namespace, nil)
 	virtualServerInformer := informerFactory.ForResource(f5VirtualServerGVR)
 
 	virtualServerInformer.Informer().AddEventHandler(
@@ -76,6 +77,7 @@ func NewF5VirtualServerSource(
 		},
 	)
 
+	informers.SetWatchBackoff(informerOpts.WatchBackoffMaxDelay, virtualServerInformer.Informer())
 	informerFactory.Start(ctx.Done())
 
 	// wait for the local cache to be populated.
@@ -0,0 +1,157 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// federatedCluster is a remote cluster of the federation source, its source is built
+// on first use so that clusters which are unreachable at startup are retried.
+type federatedCluster struct {
+	name   string
+	client ClientGenerator
+	// source is guarded by the mutex of the federation source, as it is set while handlers may be added.
+	source Source
+}
+
+// federationSource is an implementation of Source that aggregates the endpoints
+// of a source type from multiple remote clusters.
+type federationSource struct {
+	// ctx is the lifetime of the informers of the sources built lazily.
+	ctx        context.Context
+	sourceType string
+	cfg        *Config
+	clusters   []*federatedCluster
+
+	mutex    sync.Mutex
+	handlers []func()
+}
+
+// NewFederationSource creates a new federationSource building a source of the given type
+// for each cluster, the clusters are identified by the name they are keyed by.
+func NewFederationSource(ctx context.Context, sourceType string, clusters map[string]ClientGenerator, cfg *Config) (Source, error) {
+	if sourceType == "" || sourceType == "federation" {
+		return nil, fmt.Errorf("invalid federation source type %q", sourceType)
+	}
+	if len(clusters) == 0 {
+		return nil, errors.New("federation source requires at least one cluster, use --federation-kubeconfig")
+	}
+
+	fs := &federationSource{
+		ctx:        ctx,
+		sourceType: sourceType,
+		cfg:        cfg,
+	}
+	for name, client := range clusters {
+		fs.clusters = append(fs.clusters, &federatedCluster{name: name, client: client})
+	}
+	slices.SortFunc(fs.clusters, func(a, b *federatedCluster) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	for _, cluster := range fs.clusters {
+		if _, err := fs.buildSource(cluster); err != nil {
+			if errors.Is(err, ErrSourceNotFound) {
+				return nil, fmt.Errorf("invalid federation source type %q: %w", sourceType, err)
+			}
+			log.Warnf("Failed to create %s source for cluster %s, it will be retried: %v", sourceType, cluster.name, err)
+		}
+	}
+	return fs, nil
+}
+
+// clusterSource returns the source of the cluster, building it when it couldn't be built so far.
+func (fs *federationSource) clusterSource(cluster *federatedCluster) (Source, error) {
+	fs.mutex.Lock()
+	src := cluster.source
+	fs.mutex.Unlock()
+	if src != nil {
+		return src, nil
+	}
+	return fs.buildSource(cluster)
+}
+
+// buildSource builds the source of the cluster without holding the mutex, as it may wait for the informers to sync.
+func (fs *federationSource) buildSource(cluster *federatedCluster) (Source, error) {
+	src, err := BuildWithConfig(fs.ctx, fs.sourceType, cluster.client, fs.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+	if cluster.source != nil {
+		// built concurrently
+		return cluster.source, nil
+	}
+	cluster.source = src
+	for _, handler := range fs.handlers {
+		src.AddEventHandler(fs.ctx, handler)
+	}
+	return src, nil
+}
+
+// Endpoints returns the endpoints of all the reachable clusters.
+// The clusters that fail are skipped with a warning, an error is only returned when all of them fail.
+func (fs *federationSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints := []*endpoint.Endpoint{}
+	var errs []error
+
+	for _, cluster := range fs.clusters {
+		src, err := fs.clusterSource(cluster)
+		if err != nil {
+			log.Warnf("Skipping cluster %s, failed to create %s source: %v", cluster.name, fs.sourceType, err)
+			errs = append(errs, fmt.Errorf("cluster %s: %w", cluster.name, err))
+			continue
+		}
+
+		clusterEndpoints, err := src.Endpoints(ctx)
+		if err != nil {
+			log.Warnf("Skipping cluster %s, failed to get endpoints: %v", cluster.name, err)
+			errs = append(errs, fmt.Errorf("cluster %s: %w", cluster.name, err))
+			continue
+		}
+		log.Debugf("Cluster %s returned %d endpoints", cluster.name, len(clusterEndpoints))
+		endpoints = append(endpoints, clusterEndpoints...)
+	}
+
+	if len(errs) == len(fs.clusters) {
+		return nil, fmt.Errorf("all federated clusters failed: %w", errors.Join(errs...))
+	}
+	return endpoints, nil
+}
+
+func (fs *federationSource) AddEventHandler(ctx context.Context, handler func()) {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+	fs.handlers = append(fs.handlers, handler)
+	for _, cluster := range fs.clusters {
+		if cluster.source != nil {
+			cluster.source.AddEventHandler(ctx, handler)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	fakeKube "k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+)
+
+// newFederatedCluster returns the client generator of a cluster running a LoadBalancer service.
+func newFederatedCluster(hostname, ip string) *MockClientGenerator {
+	client := fakeKube.NewClientset(&v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "web",
+			Annotations: map[string]string{annotations.HostnameKey: hostname},
+		},
+		Spec: v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer},
+		Status: v1.ServiceStatus{
+			LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: ip}}},
+		},
+	})
+	generator := new(MockClientGenerator)
+	generator.On("KubeClient").Return(client, nil)
+	return generator
+}
+
+func newFailingFederatedCluster() *MockClientGenerator {
+	generator := new(MockClientGenerator)
+	generator.On("KubeClient").Return(nil, errors.New("cluster unreachable"))
+	return generator
+}
+
+func federationTestConfig() *Config {
+	return &Config{
+		LabelFilter:       labels.Everything(),
+		ServiceTypeFilter: []string{},
+	}
+}
+
+func TestFederationSource(t *testing.T) {
+	src, err := NewFederationSource(context.Background(), "service", map[string]ClientGenerator{
+		"cluster-a": newFederatedCluster("a.example.com", "1.1.1.1"),
+		"cluster-b": newFederatedCluster("b.example.com", "2.2.2.2"),
+	}, federationTestConfig())
+	require.NoError(t, err)
+
+	endpoints, err := src.Endpoints(context.Background())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.1.1.1").WithLabel(endpoint.ResourceLabelKey, "service/default/web"),
+		endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "2.2.2.2").WithLabel(endpoint.ResourceLabelKey, "service/default/web"),
+	})
+}
+
+func TestFederationSourcePartialFailure(t *testing.T) {
+	src, err := NewFederationSource(context.Background(), "service", map[string]ClientGenerator{
+		"cluster-a": newFederatedCluster("a.example.com", "1.1.1.1"),
+		"cluster-b": newFailingFederatedCluster(),
+	}, federationTestConfig())
+	require.NoError(t, err)
+
+	endpoints, err := src.Endpoints(context.Background())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.1.1.1").WithLabel(endpoint.ResourceLabelKey, "service/default/web"),
+	})
+}
+
+func TestFederationSourceRecovers(t *testing.T) {
+	client := fakeKube.NewClientset()
+	generator := new(MockClientGenerator)
+	generator.On("KubeClient").Return(nil, errors.New("cluster unreachable")).Once()
+	generator.On("KubeClient").Return(kubernetes.Interface(client), nil)
+
+	src, err := NewFederationSource(context.Background(), "service", map[string]ClientGenerator{
+		"cluster-a": newFederatedCluster("a.example.com", "1.1.1.1"),
+		"cluster-b": generator,
+	}, federationTestConfig())
+	require.NoError(t, err)
+
+	// handlers are also added to the sources created later
+	src.AddEventHandler(context.Background(), func() {})
+
+	// the source of cluster-b is created on the first synchronization
+	_, err = src.Endpoints(context.Background())
+	require.NoError(t, err)
+	generator.AssertNumberOfCalls(t, "KubeClient", 2)
+
+	fs := src.(*federationSource)
+	require.NotNil(t, fs.clusters[1].source)
+}
+
+// lockedClientGenerator serializes the calls of a mocked client generator, for the sources built concurrently.
+type lockedClientGenerator struct {
+	*MockClientGenerator
+	mutex sync.Mutex
+}
+
+func (g *lockedClientGenerator) KubeClient() (kubernetes.Interface, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.MockClientGenerator.KubeClient()
+}
+
+func TestFederationSourceConcurrentAccess(t *testing.T) {
+	client := fakeKube.NewClientset()
+	generator := new(MockClientGenerator)
+	generator.On("KubeClient").Return(nil, errors.New("cluster unreachable")).Once()
+	generator.On("KubeClient").Return(kubernetes.Interface(client), nil)
+
+	src, err := NewFederationSource(context.Background(), "service", map[string]ClientGenerator{
+		"cluster-a": &lockedClientGenerator{MockClientGenerator: generator},
+	}, federationTestConfig())
+	require.NoError(t, err)
+
+	// the source built on first use is read by the other synchronizations and when handlers are added
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := src.Endpoints(context.Background())
+			assert.NoError(t, err)
+		}()
+		go func() {
+			defer wg.Done()
+			src.AddEventHandler(context.Background(), func() {})
+		}()
+	}
+	wg.Wait()
+
+	fs := src.(*federationSource)
+	require.NotNil(t, fs.clusters[0].source)
+	assert.Len(t, fs.handlers, 4)
+}
+
+func TestFederationSourceAllClustersFail(t *testing.T) {
+	src, err := NewFederationSource(context.Background(), "service", map[string]ClientGenerator{
+		"cluster-a": newFailingFederatedCluster(),
+		"cluster-b": newFailingFederatedCluster(),
+	}, federationTestConfig())
+	require.NoError(t, err)
+
+	// returning no endpoints would delete all the records
+	endpoints, err := src.Endpoints(context.Background())
+	assert.ErrorContains(t, err, "all federated clusters failed")
+	assert.ErrorContains(t, err, "cluster cluster-a: cluster unreachable")
+	assert.ErrorContains(t, err, "cluster cluster-b: cluster unreachable")
+	assert.Nil(t, endpoints)
+}
+
+func TestNewFederationSourceErrors(t *testing.T) {
+	cfg := federationTestConfig()
+	clusters := map[string]ClientGenerator{"cluster-a": newFederatedCluster("a.example.com", "1.1.1.1")}
+
+	_, err := NewFederationSource(context.Background(), "service", nil, cfg)
+	assert.ErrorContains(t, err, "at least one cluster")
+
+	_, err = NewFederationSource(context.Background(), "federation", clusters, cfg)
+	assert.ErrorContains(t, err, "invalid federation source type")
+
+	_, err = NewFederationSource(context.Background(), "unknown", clusters, cfg)
+	assert.ErrorIs(t, err, ErrSourceNotFound)
+}
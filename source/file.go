@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/filewatch"
+)
+
+// fileSource is an implementation of Source that provides endpoints from a local YAML or JSON file
+// containing a list of endpoints. The file is only read again once a change is notified by the
+// file system, or on each call when the changes can't be watched.
+type fileSource struct {
+	path string
+
+	mu        sync.Mutex
+	watched   bool
+	changed   bool
+	endpoints []*endpoint.Endpoint
+	handlers  []func()
+}
+
+// NewFileSource creates a new fileSource reading the given file, which is watched until the context is done.
+func NewFileSource(ctx context.Context, path string) (Source, error) {
+	if path == "" {
+		return nil, errors.New("file source requires --source-file")
+	}
+	fs := &fileSource{path: path, changed: true}
+	if _, err := fs.read(); err != nil {
+		return nil, err
+	}
+
+	if err := filewatch.Watch(ctx, path, fs.onChange, fs.onWatchError); err != nil {
+		log.Warnf("Failed to watch %s, it is read on each synchronization: %v", path, err)
+		return fs, nil
+	}
+	fs.mu.Lock()
+	fs.watched = true
+	fs.mu.Unlock()
+	return fs, nil
+}
+
+// onChange marks the file as changed and notifies the event handlers.
+func (fs *fileSource) onChange(event fsnotify.Event) {
+	log.Debugf("File source: %s", event)
+	fs.mu.Lock()
+	fs.changed = true
+	handlers := fs.handlers
+	fs.mu.Unlock()
+	for _, handler := range handlers {
+		handler()
+	}
+}
+
+// onWatchError marks the file as changed, as a change may have been missed.
+func (fs *fileSource) onWatchError(err error) {
+	log.Warnf("File source: failed to watch %s: %v", fs.path, err)
+	fs.mu.Lock()
+	fs.changed = true
+	fs.mu.Unlock()
+}
+
+// read parses the file unless it is unchanged since the last read.
+func (fs *fileSource) read() ([]*endpoint.Endpoint, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if fs.watched && !fs.changed {
+		return fs.endpoints, nil
+	}
+
+	data, err := os.ReadFile(fs.path)
+	if err != nil {
+		return nil, fmt.Errorf("file source: %w", err)
+	}
+	endpoints := []*endpoint.Endpoint{}
+	if err := yaml.Unmarshal(data, &endpoints); err != nil {
+		return nil, fmt.Errorf("file source: decoding %s: %w", fs.path, err)
+	}
+	// an empty file is handled as an empty list
+	if endpoints == nil {
+		endpoints = []*endpoint.Endpoint{}
+	}
+	for i, ep := range endpoints {
+		if ep == nil || ep.DNSName == "" {
+			return nil, fmt.Errorf("file source: the endpoint %d of %s has no dnsName", i, fs.path)
+		}
+		if ep.Labels == nil {
+			ep.Labels = endpoint.NewLabels()
+		}
+	}
+	log.Debugf("File source read %d endpoints from %s", len(endpoints), fs.path)
+
+	fs.endpoints = endpoints
+	fs.changed = false
+	return endpoints, nil
+}
+
+// Endpoints returns the endpoints of the file.
+func (fs *fileSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := fs.read()
+	if err != nil {
+		return nil, err
+	}
+	// the endpoints are copied as the following sources and the planner may modify them
+	copied := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		copied = append(copied, ep.DeepCopy())
+	}
+	return copied, nil
+}
+
+// AddEventHandler registers a handler called when the file changes.
+func (fs *fileSource) AddEventHandler(_ context.Context, handler func()) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.handlers = append(fs.handlers, handler)
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func writeSourceFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestFileSource(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []*endpoint.Endpoint
+		err      string
+	}{
+		{
+			name: "yaml",
+			content: `
+- dnsName: foo.example.com
+  targets: ["1.2.3.4"]
+  recordType: A
+  recordTTL: 300
+- dnsName: bar.example.com
+  targets: ["foo.example.com"]
+  recordType: CNAME
+  labels:
+    team: dns
+`,
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("foo.example.com", endpoint.RecordTypeA, 300, "1.2.3.4"),
+				endpoint.NewEndpoint("bar.example.com", endpoint.RecordTypeCNAME, "foo.example.com").WithLabel("team", "dns"),
+			},
+		},
+		{
+			name:     "json",
+			content:  `[{"dnsName":"foo.example.com","targets":["1.2.3.4"],"recordType":"A"}]`,
+			expected: []*endpoint.Endpoint{endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		},
+		{
+			name:     "empty file",
+			content:  "",
+			expected: []*endpoint.Endpoint{},
+		},
+		{
+			name:    "invalid file",
+			content: "dnsName: foo.example.com",
+			err:     "file source: decoding",
+		},
+		{
+			name:    "missing dnsName",
+			content: `[{"targets":["1.2.3.4"],"recordType":"A"}]`,
+			err:     "has no dnsName",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "endpoints.yaml")
+			writeSourceFile(t, path, tt.content)
+
+			src, err := NewFileSource(t.Context(), path)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+
+			endpoints, err := src.Endpoints(context.Background())
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tt.expected)
+		})
+	}
+}
+
+func TestFileSourceRequiresPath(t *testing.T) {
+	_, err := NewFileSource(context.Background(), "")
+	assert.EqualError(t, err, "file source requires --source-file")
+
+	_, err = NewFileSource(context.Background(), filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "file source: open")
+}
+
+func TestFileSourceReadsChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "endpoints.yaml")
+	writeSourceFile(t, path, `[{"dnsName":"foo.example.com","targets":["1.2.3.4"],"recordType":"A"}]`)
+
+	src, err := NewFileSource(t.Context(), path)
+	require.NoError(t, err)
+	var events atomic.Int32
+	src.AddEventHandler(context.Background(), func() { events.Add(1) })
+
+	endpoints, err := src.Endpoints(context.Background())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4")})
+
+	// the returned endpoints are copies, modifying them doesn't change the following results
+	endpoints[0].Targets = endpoint.Targets{"5.6.7.8"}
+
+	// the file is replaced, as most editors do
+	tmp := filepath.Join(filepath.Dir(path), "endpoints.yaml.tmp")
+	writeSourceFile(t, tmp, `[{"dnsName":"bar.example.com","targets":["4.3.2.1"],"recordType":"A"}]`)
+	require.NoError(t, os.Rename(tmp, path))
+
+	assert.Eventually(t, func() bool { return events.Load() > 0 }, 5*time.Second, 10*time.Millisecond, "the event handler should be called when the file changes")
+
+	endpoints, err = src.Endpoints(context.Background())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{endpoint.NewEndpoint("bar.example.com", endpoint.RecordTypeA, "4.3.2.1")})
+}
+
+func TestFileSourceCachesUnchangedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "endpoints.yaml")
+	writeSourceFile(t, path, `[{"dnsName":"foo.example.com","targets":["1.2.3.4"],"recordType":"A"}]`)
+
+	src, err := NewFileSource(t.Context(), path)
+	require.NoError(t, err)
+	fs := src.(*fileSource)
+	require.True(t, fs.watched)
+
+	endpoints, err := src.Endpoints(context.Background())
+	require.NoError(t, err)
+	endpoints[0].Targets = endpoint.Targets{"5.6.7.8"}
+
+	endpoints, err = src.Endpoints(context.Background())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4")})
+	assert.False(t, fs.changed, "the file should not be read again without a change")
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+var fluxHelmReleaseGVR = schema.GroupVersionResource{
+	Group:    "helm.toolkit.fluxcd.io",
+	Version:  "v2beta1",
+	Resource: "helmreleases",
+}
+
+const fluxHelmReleaseResourcePrefix = "flux-helmrelease"
+
+// fluxHelmRelease holds the fields of a Flux HelmRelease used by the source, the records
+// are declared with annotations on the HelmRelease rather than inferred from the chart values.
+type fluxHelmRelease struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+}
+
+// fluxHelmReleaseSource is an implementation of Source for Flux HelmRelease objects.
+type fluxHelmReleaseSource struct {
+	namespace                string
+	annotationFilter         string
+	labelSelector            labels.Selector
+	ignoreHostnameAnnotation bool
+	helmReleaseInformer      kubeinformers.GenericInformer
+}
+
+// NewFluxHelmReleaseSource creates a new fluxHelmReleaseSource with the given config.
+func NewFluxHelmReleaseSource(
+	ctx context.Context,
+	dynamicKubeClient dynamic.Interface,
+	namespace string,
+	annotationFilter string,
+	labelSelector labels.Selector,
+	ignoreHostnameAnnotation bool,
+	informerOpts informers.Options,
+) (Source, error) {
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, informerOpts.ResyncPeriod, namespace, nil)
+	helmReleaseInformer := informerFactory.ForResource(fluxHelmReleaseGVR)
+
+	helmReleaseInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+			},
+		},
+	)
+
+	informers.SetWatchBackoff(informerOpts.WatchBackoffMaxDelay, helmReleaseInformer.Informer())
+	informerFactory.Start(ctx.Done())
+
+	// wait for the local cache to be populated.
+	if err := informers.WaitForDynamicCacheSync(context.Background(), informerFactory); err != nil {
+		return nil, err
+	}
+
+	return &fluxHelmReleaseSource{
+		namespace:                namespace,
+		annotationFilter:         annotationFilter,
+		labelSelector:            labelSelector,
+		ignoreHostnameAnnotation: ignoreHostnameAnnotation,
+		helmReleaseInformer:      helmReleaseInformer,
+	}, nil
+}
+
+// Endpoints returns endpoint objects for each HelmRelease in the source's namespace(s).
+func (fs *fluxHelmReleaseSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	objects, err := fs.helmReleaseInformer.Lister().ByNamespace(fs.namespace).List(fs.labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	selector, err := annotations.ParseFilter(fs.annotationFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := []*endpoint.Endpoint{}
+	for _, obj := range objects {
+		unstructuredHelmRelease, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return nil, errors.New("could not convert")
+		}
+
+		helmRelease := &fluxHelmRelease{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredHelmRelease.Object, helmRelease); err != nil {
+			return nil, err
+		}
+
+		if !selector.Matches(labels.Set(helmRelease.Annotations)) {
+			continue
+		}
+
+		endpoints = append(endpoints, fs.endpointsFromHelmRelease(helmRelease)...)
+	}
+
+	return endpoints, nil
+}
+
+func (fs *fluxHelmReleaseSource) AddEventHandler(ctx context.Context, handler func()) {
+	log.Debug("Adding event handler for Flux HelmRelease")
+
+	fs.helmReleaseInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
+}
+
+// endpointsFromHelmRelease returns the endpoints of the hostname and target annotations of the HelmRelease.
+func (fs *fluxHelmReleaseSource) endpointsFromHelmRelease(helmRelease *fluxHelmRelease) []*endpoint.Endpoint {
+	if fs.ignoreHostnameAnnotation {
+		return nil
+	}
+
+	hostnames := annotations.HostnamesFromAnnotations(helmRelease.Annotations)
+	if len(hostnames) == 0 {
+		return nil
+	}
+
+	resource := fmt.Sprintf("%s/%s/%s", fluxHelmReleaseResourcePrefix, helmRelease.Namespace, helmRelease.Name)
+
+	targets := annotations.TargetsFromTargetAnnotation(helmRelease.Annotations)
+	if len(targets) == 0 {
+		log.Debugf("Skipping Flux HelmRelease %s/%s, it has no target annotation", helmRelease.Namespace, helmRelease.Name)
+		return nil
+	}
+
+	ttl := annotations.TTLFromAnnotations(helmRelease.Annotations, resource)
+	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(helmRelease.Annotations)
+
+	var endpoints []*endpoint.Endpoint
+	for _, hostname := range hostnames {
+		endpoints = append(endpoints, endpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
+	}
+	return endpoints
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakeDynamic "k8s.io/client-go/dynamic/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+func newFluxHelmRelease(namespace, name string, objLabels, objAnnotations map[string]string) *unstructured.Unstructured {
+	helmRelease := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "helm.toolkit.fluxcd.io/v2beta1",
+		"kind":       "HelmRelease",
+		"metadata": map[string]any{
+			"namespace": namespace,
+			"name":      name,
+		},
+		"spec": map[string]any{
+			"chart": map[string]any{"spec": map[string]any{"chart": "podinfo"}},
+			// hostnames in the chart values are ignored
+			"values": map[string]any{"ingress": map[string]any{"hosts": []any{"values.example.com"}}},
+		},
+	}}
+	helmRelease.SetLabels(objLabels)
+	helmRelease.SetAnnotations(objAnnotations)
+	return helmRelease
+}
+
+func TestFluxHelmReleaseSourceEndpoints(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                     string
+		namespace                string
+		annotationFilter         string
+		labelFilter              string
+		ignoreHostnameAnnotation bool
+		helmReleases             []*unstructured.Unstructured
+		expected                 []*endpoint.Endpoint
+	}{
+		{
+			name: "hostname and target annotations",
+			helmReleases: []*unstructured.Unstructured{
+				newFluxHelmRelease("flux-system", "podinfo", nil, map[string]string{
+					annotations.HostnameKey: "podinfo.example.com,www.example.com",
+					annotations.TargetKey:   "1.2.3.4",
+					annotations.TtlKey:      "60",
+				}),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("podinfo.example.com", endpoint.RecordTypeA, 60, "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "flux-helmrelease/flux-system/podinfo"),
+				endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 60, "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "flux-helmrelease/flux-system/podinfo"),
+			},
+		},
+		{
+			name: "provider specific annotations",
+			helmReleases: []*unstructured.Unstructured{
+				newFluxHelmRelease("flux-system", "podinfo", nil, map[string]string{
+					annotations.HostnameKey:      "podinfo.example.com",
+					annotations.TargetKey:        "lb.example.org",
+					annotations.SetIdentifierKey: "eu",
+				}),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("podinfo.example.com", endpoint.RecordTypeCNAME, "lb.example.org").
+					WithSetIdentifier("eu").
+					WithLabel(endpoint.ResourceLabelKey, "flux-helmrelease/flux-system/podinfo"),
+			},
+		},
+		{
+			name: "without annotations",
+			helmReleases: []*unstructured.Unstructured{
+				newFluxHelmRelease("flux-system", "podinfo", nil, nil),
+			},
+			expected: []*endpoint.Endpoint{},
+		},
+		{
+			name: "without target annotation",
+			helmReleases: []*unstructured.Unstructured{
+				newFluxHelmRelease("flux-system", "podinfo", nil, map[string]string{annotations.HostnameKey: "podinfo.example.com"}),
+			},
+			expected: []*endpoint.Endpoint{},
+		},
+		{
+			name: "without hostname annotation",
+			helmReleases: []*unstructured.Unstructured{
+				newFluxHelmRelease("flux-system", "podinfo", nil, map[string]string{annotations.TargetKey: "1.2.3.4"}),
+			},
+			expected: []*endpoint.Endpoint{},
+		},
+		{
+			name:      "single namespace",
+			namespace: "team-a",
+			helmReleases: []*unstructured.Unstructured{
+				newFluxHelmRelease("team-a", "podinfo", nil, map[string]string{annotations.HostnameKey: "a.example.com", annotations.TargetKey: "1.2.3.4"}),
+				newFluxHelmRelease("team-b", "podinfo", nil, map[string]string{annotations.HostnameKey: "b.example.com", annotations.TargetKey: "1.2.3.5"}),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "flux-helmrelease/team-a/podinfo"),
+			},
+		},
+		{
+			name:             "annotation filter",
+			annotationFilter: "dns=public",
+			helmReleases: []*unstructured.Unstructured{
+				newFluxHelmRelease("flux-system", "public", nil, map[string]string{annotations.HostnameKey: "a.example.com", annotations.TargetKey: "1.2.3.4", "dns": "public"}),
+				newFluxHelmRelease("flux-system", "private", nil, map[string]string{annotations.HostnameKey: "b.example.com", annotations.TargetKey: "1.2.3.5"}),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "flux-helmrelease/flux-system/public"),
+			},
+		},
+		{
+			name:        "label filter",
+			labelFilter: "team=web",
+			helmReleases: []*unstructured.Unstructured{
+				newFluxHelmRelease("flux-system", "web", map[string]string{"team": "web"}, map[string]string{annotations.HostnameKey: "a.example.com", annotations.TargetKey: "1.2.3.4"}),
+				newFluxHelmRelease("flux-system", "api", map[string]string{"team": "api"}, map[string]string{annotations.HostnameKey: "b.example.com", annotations.TargetKey: "1.2.3.5"}),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "flux-helmrelease/flux-system/web"),
+			},
+		},
+		{
+			name:                     "ignore hostname annotation",
+			ignoreHostnameAnnotation: true,
+			helmReleases: []*unstructured.Unstructured{
+				newFluxHelmRelease("flux-system", "podinfo", nil, map[string]string{annotations.HostnameKey: "podinfo.example.com", annotations.TargetKey: "1.2.3.4"}),
+			},
+			expected: []*endpoint.Endpoint{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fakeDynamicClient := fakeDynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+				fluxHelmReleaseGVR: "HelmReleaseList",
+			})
+			for _, helmRelease := range tt.helmReleases {
+				_, err := fakeDynamicClient.Resource(fluxHelmReleaseGVR).Namespace(helmRelease.GetNamespace()).
+					Create(context.Background(), helmRelease, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
+			labelSelector, err := labels.Parse(tt.labelFilter)
+			require.NoError(t, err)
+
+			src, err := NewFluxHelmReleaseSource(context.Background(), fakeDynamicClient, tt.namespace, tt.annotationFilter, labelSelector, tt.ignoreHostnameAnnotation, informers.Options{})
+			require.NoError(t, err)
+
+			endpoints, err := src.Endpoints(context.Background())
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tt.expected)
+		})
+	}
+}
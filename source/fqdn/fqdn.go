@@ -24,6 +24,7 @@ import (
 	"text/template"
 	"unicode"
 
+	log "github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -63,11 +64,32 @@ func ExecTemplate(tmpl *template.Template, obj kubeObject) ([]string, error) {
 	for _, name := range strings.Split(buf.String(), ",") {
 		name = strings.TrimFunc(name, unicode.IsSpace)
 		name = strings.TrimSuffix(name, ".")
+		if !isValidHostname(name) {
+			log.Warnf("Skipping invalid hostname %q generated by the template for %s/%s, a label or annotation referenced by the template may be missing", name, obj.GetNamespace(), obj.GetName())
+			continue
+		}
 		hostnames = append(hostnames, name)
 	}
 	return hostnames, nil
 }
 
+// isValidHostname reports whether a hostname generated by a template has no empty label, which
+// is the result of a template referencing a missing label or annotation, e.g. {{index .Labels "team"}}.example.com.
+func isValidHostname(name string) bool {
+	if name == "" {
+		return true
+	}
+	if strings.Contains(name, "<no value>") {
+		return false
+	}
+	for _, label := range strings.Split(name, ".") {
+		if label == "" {
+			return false
+		}
+	}
+	return true
+}
+
 // replace all instances of oldValue with newValue in target string.
 // adheres to syntax from https://masterminds.github.io/sprig/strings.html.
 func replace(oldValue, newValue, target string) string {
@@ -198,6 +198,38 @@ func TestExecTemplate(t *testing.T) {
 			},
 			want: []string{"abrakadabra.google.com"},
 		},
+		{
+			name: "label present",
+			tmpl: "{{ index .Labels \"team\" }}.{{ .Name }}.example.com",
+			obj: &testObject{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "test",
+					Labels: map[string]string{"team": "payments"},
+				},
+			},
+			want: []string{"payments.test.example.com"},
+		},
+		{
+			name: "label absent",
+			tmpl: "{{ index .Labels \"team\" }}.{{ .Name }}.example.com, {{ .Name }}.example.org",
+			obj: &testObject{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+			},
+			want: []string{"test.example.org"},
+		},
+		{
+			name: "field of absent label",
+			tmpl: "{{ .Labels.team }}.{{ .Name }}.example.com",
+			obj: &testObject{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "test",
+					Labels: map[string]string{"app": "myapp"},
+				},
+			},
+			want: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -23,6 +23,7 @@ import (
 	"sort"
 	"strings"
 	"text/template"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
@@ -72,7 +73,7 @@ type gatewayRouteInformer interface {
 	Informer() cache.SharedIndexInformer
 }
 
-func newGatewayInformerFactory(client gateway.Interface, namespace string, labelSelector labels.Selector) gwinformers.SharedInformerFactory {
+func newGatewayInformerFactory(client gateway.Interface, namespace string, labelSelector labels.Selector, resyncPeriod time.Duration) gwinformers.SharedInformerFactory {
 	var opts []gwinformers.SharedInformerOption
 	if namespace != "" {
 		opts = append(opts, gwinformers.WithNamespace(namespace))
@@ -83,7 +84,7 @@ func newGatewayInformerFactory(client gateway.Interface, namespace string, label
 			o.LabelSelector = lbls
 		}))
 	}
-	return gwinformers.NewSharedInformerFactoryWithOptions(client, 0, opts...)
+	return gwinformers.NewSharedInformerFactoryWithOptions(client, resyncPeriod, opts...)
 }
 
 type gatewayRouteSource struct {
@@ -130,13 +131,13 @@ func newGatewayRouteSource(clients ClientGenerator, config *Config, kind string,
 		return nil, err
 	}
 
-	informerFactory := newGatewayInformerFactory(client, config.GatewayNamespace, gwLabels)
+	informerFactory := newGatewayInformerFactory(client, config.GatewayNamespace, gwLabels, config.ResyncPeriod)
 	gwInformer := informerFactory.Gateway().V1beta1().Gateways() // TODO: Gateway informer should be shared across gateway sources.
 	gwInformer.Informer()                                        // Register with factory before starting.
 
 	rtInformerFactory := informerFactory
 	if config.Namespace != config.GatewayNamespace || !selectorsEqual(rtLabels, gwLabels) {
-		rtInformerFactory = newGatewayInformerFactory(client, config.Namespace, rtLabels)
+		rtInformerFactory = newGatewayInformerFactory(client, config.Namespace, rtLabels, config.ResyncPeriod)
 	}
 	rtInformer := newInformerFn(rtInformerFactory)
 	rtInformer.Informer() // Register with factory before starting.
@@ -146,10 +147,11 @@ func newGatewayRouteSource(clients ClientGenerator, config *Config, kind string,
 		return nil, err
 	}
 
-	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeClient, 0)
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeClient, config.ResyncPeriod)
 	nsInformer := kubeInformerFactory.Core().V1().Namespaces() // TODO: Namespace informer should be shared across gateway sources.
 	nsInformer.Informer()                                      // Register with factory before starting.
 
+	informers.SetWatchBackoff(config.WatchBackoffMaxDelay, gwInformer.Informer(), rtInformer.Informer(), nsInformer.Informer())
 	informerFactory.Start(wait.NeverStop)
 	kubeInformerFactory.Start(wait.NeverStop)
 	if rtInformerFactory != informerFactory {
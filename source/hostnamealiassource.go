@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// hostnameAliasSource is a Source that expands the short hostnames of its wrapped source to FQDNs.
+type hostnameAliasSource struct {
+	source  Source
+	aliases map[string]string
+}
+
+// NewHostnameAliasSource creates a new hostnameAliasSource wrapping the provided Source.
+func NewHostnameAliasSource(source Source, aliases map[string]string) Source {
+	return &hostnameAliasSource{source: source, aliases: aliases}
+}
+
+// ParseHostnameAliases parses a comma separated list of alias=fqdn, e.g. web=web.example.com,api=api.example.com.
+func ParseHostnameAliases(input string) (map[string]string, error) {
+	aliases := map[string]string{}
+	for _, entry := range strings.Split(input, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		alias, fqdn, found := strings.Cut(entry, "=")
+		alias = strings.ToLower(strings.TrimSpace(alias))
+		fqdn = strings.TrimSuffix(strings.TrimSpace(fqdn), ".")
+		if !found || alias == "" || fqdn == "" {
+			return nil, fmt.Errorf("invalid hostname alias %q, expected alias=fqdn", entry)
+		}
+		if _, ok := aliases[alias]; ok {
+			return nil, fmt.Errorf("duplicate hostname alias %q", alias)
+		}
+		aliases[alias] = fqdn
+	}
+	return aliases, nil
+}
+
+// Endpoints collects endpoints from its wrapped source and returns them with
+// the hostnames matching an alias replaced by the FQDN of the alias.
+func (s *hostnameAliasSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := s.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ep := range endpoints {
+		if fqdn, ok := s.aliases[strings.ToLower(strings.TrimSuffix(ep.DNSName, "."))]; ok {
+			log.Debugf("Expanding hostname alias %s to %s", ep.DNSName, fqdn)
+			ep.DNSName = fqdn
+		}
+	}
+
+	return endpoints, nil
+}
+
+func (s *hostnameAliasSource) AddEventHandler(ctx context.Context, handler func()) {
+	s.source.AddEventHandler(ctx, handler)
+}
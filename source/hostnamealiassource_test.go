@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+)
+
+// Validates that hostnameAliasSource is a Source
+var _ Source = &hostnameAliasSource{}
+
+func TestHostnameAliasSource(t *testing.T) {
+	aliases := map[string]string{"web": "web.example.com", "api": "api.example.com"}
+
+	for _, tc := range []struct {
+		title     string
+		endpoints []*endpoint.Endpoint
+		expected  []*endpoint.Endpoint
+	}{
+		{
+			"short names matching an alias are expanded",
+			[]*endpoint.Endpoint{
+				{DNSName: "web", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+				{DNSName: "API.", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.example.org"}},
+			},
+			[]*endpoint.Endpoint{
+				{DNSName: "web.example.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+				{DNSName: "api.example.com", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.example.org"}},
+			},
+		},
+		{
+			"fully qualified names are passed through",
+			[]*endpoint.Endpoint{
+				{DNSName: "web.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+			[]*endpoint.Endpoint{
+				{DNSName: "web.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+		},
+		{
+			"short names without alias are passed through",
+			[]*endpoint.Endpoint{
+				{DNSName: "db", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+			[]*endpoint.Endpoint{
+				{DNSName: "db", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			mockSource := new(testutils.MockSource)
+			mockSource.On("Endpoints").Return(tc.endpoints, nil)
+
+			source := NewHostnameAliasSource(mockSource, aliases)
+
+			endpoints, err := source.Endpoints(context.Background())
+			require.NoError(t, err)
+
+			validateEndpoints(t, endpoints, tc.expected)
+			mockSource.AssertExpectations(t)
+		})
+	}
+}
+
+func TestParseHostnameAliases(t *testing.T) {
+	aliases, err := ParseHostnameAliases(" web=web.example.com., API = api.example.com ,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"web": "web.example.com", "api": "api.example.com"}, aliases)
+
+	aliases, err = ParseHostnameAliases("")
+	require.NoError(t, err)
+	assert.Empty(t, aliases)
+
+	_, err = ParseHostnameAliases("web")
+	require.EqualError(t, err, `invalid hostname alias "web", expected alias=fqdn`)
+	_, err = ParseHostnameAliases("web=")
+	require.EqualError(t, err, `invalid hostname alias "web=", expected alias=fqdn`)
+	_, err = ParseHostnameAliases("web=web.example.com,web=web.example.org")
+	require.EqualError(t, err, `duplicate hostname alias "web"`)
+}
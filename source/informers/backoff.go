@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	watchBackoffFactor = 2.0
+	watchBackoffJitter = 0.1
+	// watchBackoffResetAfter is the time after the longest delay without watch failure after which the delay starts over.
+	watchBackoffResetAfter = 2 * time.Minute
+)
+
+// watchBackoffInitialDelay is the delay before reconnecting the first failed watch.
+var watchBackoffInitialDelay = time.Second
+
+// SetWatchBackoff makes the informers wait with an exponential backoff of at most maxDelay before reconnecting
+// a failed watch, so that an unavailable API server isn't hammered. It must be called before the informers are
+// started, a maxDelay of 0 disables the backoff.
+func SetWatchBackoff(maxDelay time.Duration, informers ...cache.SharedInformer) {
+	if maxDelay <= 0 {
+		return
+	}
+	for _, informer := range informers {
+		if err := informer.SetWatchErrorHandlerWithContext(newWatchBackoff(maxDelay).handle); err != nil {
+			log.Warnf("Failed to set the watch backoff: %v", err)
+		}
+	}
+}
+
+// watchBackoff is the watch error handler of an informer, the delay grows with each consecutive failure.
+type watchBackoff struct {
+	mu          sync.Mutex
+	maxDelay    time.Duration
+	backoff     wait.Backoff
+	lastFailure time.Time
+}
+
+func newWatchBackoff(maxDelay time.Duration) *watchBackoff {
+	b := &watchBackoff{maxDelay: maxDelay}
+	b.reset()
+	return b
+}
+
+func (b *watchBackoff) reset() {
+	b.backoff = wait.Backoff{
+		Duration: min(watchBackoffInitialDelay, b.maxDelay),
+		Factor:   watchBackoffFactor,
+		Jitter:   watchBackoffJitter,
+		Steps:    math.MaxInt32,
+		Cap:      b.maxDelay,
+	}
+}
+
+// next returns the delay before reconnecting after a failure at the given time.
+func (b *watchBackoff) next(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.lastFailure.IsZero() && now.Sub(b.lastFailure) > b.maxDelay+watchBackoffResetAfter {
+		b.reset()
+	}
+	b.lastFailure = now
+	return b.backoff.Step()
+}
+
+func (b *watchBackoff) handle(ctx context.Context, r *cache.Reflector, err error) {
+	cache.DefaultWatchErrorHandler(ctx, r, err)
+	delay := b.next(time.Now())
+	log.Debugf("Watch failed, reconnecting in %s: %v", delay, err)
+	select {
+	case <-ctx.Done():
+	case <-time.After(delay):
+	}
+}
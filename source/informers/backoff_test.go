@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestWatchBackoffNext(t *testing.T) {
+	b := newWatchBackoff(5 * time.Second)
+	now := time.Now()
+
+	for _, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		delay := b.next(now)
+		assert.GreaterOrEqual(t, delay, expected)
+		assert.LessOrEqual(t, delay, expected+time.Duration(float64(expected)*watchBackoffJitter))
+		now = now.Add(delay)
+	}
+
+	delay := b.next(now.Add(5*time.Second + watchBackoffResetAfter + time.Second))
+	assert.Less(t, delay, 2*time.Second, "the delay should start over after a period without failure")
+}
+
+func TestWatchBackoffHandleStopsWithContext(t *testing.T) {
+	b := newWatchBackoff(time.Hour)
+	b.backoff.Duration = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		b.handle(ctx, cache.NewReflector(&cache.ListWatch{}, &corev1.Namespace{}, cache.NewStore(cache.MetaNamespaceKeyFunc), 0), errors.New("watch failed"))
+		close(done)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the handler should return when the context is done")
+	}
+}
+
+func TestSetWatchBackoff(t *testing.T) {
+	initialDelay := watchBackoffInitialDelay
+	watchBackoffInitialDelay = 50 * time.Millisecond
+	t.Cleanup(func() { watchBackoffInitialDelay = initialDelay })
+
+	var mu sync.Mutex
+	var watches []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("watch") != "true" {
+			_, _ = w.Write([]byte(`{"kind":"NamespaceList","apiVersion":"v1","metadata":{"resourceVersion":"1"},"items":[]}`))
+			return
+		}
+		mu.Lock()
+		watches = append(watches, time.Now())
+		attempt := len(watches)
+		mu.Unlock()
+		if attempt <= 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","message":"unavailable","code":500}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	require.NoError(t, err)
+	factory := kubeinformers.NewSharedInformerFactory(client, 0)
+	informer := factory.Core().V1().Namespaces().Informer()
+	SetWatchBackoff(time.Second, informer)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	factory.Start(ctx.Done())
+	defer factory.Shutdown()
+	defer cancel()
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(watches) >= 3
+	}, 30*time.Second, 10*time.Millisecond, "the watch should be reconnected after the failures")
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Len(t, watches, 3, "the watch should not be reconnected once established")
+	assert.GreaterOrEqual(t, watches[1].Sub(watches[0]), watchBackoffInitialDelay)
+	assert.GreaterOrEqual(t, watches[2].Sub(watches[1]), 2*watchBackoffInitialDelay)
+}
@@ -0,0 +1,28 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package informers
+
+import "time"
+
+// Options configures the informers of the sources.
+type Options struct {
+	// ResyncPeriod is the interval at which the informers re-deliver all the objects of their cache to the event
+	// handlers, 0 disables the resync to prevent processing when nothing has changed.
+	ResyncPeriod time.Duration
+	// WatchBackoffMaxDelay is the maximum delay before reconnecting a failed watch, 0 disables the backoff.
+	WatchBackoffMaxDelay time.Duration
+}
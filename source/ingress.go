@@ -18,17 +18,22 @@ package source
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"sort"
 	"strings"
 	"text/template"
 
 	log "github.com/sirupsen/logrus"
 	networkv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
 	kubeinformers "k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	netinformers "k8s.io/client-go/informers/networking/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
@@ -48,6 +53,10 @@ const (
 	IngressClassAnnotationKey = "kubernetes.io/ingress.class"
 )
 
+// inheritedServiceAnnotations are the annotations of the backend services which are inherited by the ingresses
+// with --inherit-service-annotations.
+var inheritedServiceAnnotations = []string{annotations.TtlKey, annotations.AWSWeightKey}
+
 // ingressSource is an implementation of Source for Kubernetes ingress objects.
 // Ingress implementation will use the spec.rules.host value for the hostname
 // Use targetAnnotationKey to explicitly set Endpoint. (useful if the ingress
@@ -64,10 +73,14 @@ type ingressSource struct {
 	ignoreIngressTLSSpec     bool
 	ignoreIngressRulesSpec   bool
 	labelSelector            labels.Selector
+	// serviceInformer is only set when the annotations of the backend services are inherited
+	serviceInformer coreinformers.ServiceInformer
+	// patch the load balancer status of the ingresses with the targets of their target annotation
+	updateIngressStatus bool
 }
 
 // NewIngressSource creates a new ingressSource with the given config.
-func NewIngressSource(ctx context.Context, kubeClient kubernetes.Interface, namespace, annotationFilter string, fqdnTemplate string, combineFqdnAnnotation bool, ignoreHostnameAnnotation bool, ignoreIngressTLSSpec bool, ignoreIngressRulesSpec bool, labelSelector labels.Selector, ingressClassNames []string) (Source, error) {
+func NewIngressSource(ctx context.Context, kubeClient kubernetes.Interface, namespace, annotationFilter string, fqdnTemplate string, combineFqdnAnnotation bool, ignoreHostnameAnnotation bool, ignoreIngressTLSSpec bool, ignoreIngressRulesSpec bool, labelSelector labels.Selector, ingressClassNames []string, inheritServiceAnnotations bool, updateIngressStatus bool, informerOpts informers.Options) (Source, error) {
 	tmpl, err := fqdn.ParseTemplate(fqdnTemplate)
 	if err != nil {
 		return nil, err
@@ -89,8 +102,7 @@ func NewIngressSource(ctx context.Context, kubeClient kubernetes.Interface, name
 		}
 	}
 	// Use shared informer to listen for add/update/delete of ingresses in the specified namespace.
-	// Set resync period to 0, to prevent processing when nothing has changed.
-	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(namespace))
+	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, informerOpts.ResyncPeriod, kubeinformers.WithNamespace(namespace))
 	ingressInformer := informerFactory.Networking().V1().Ingresses()
 
 	// Add default resource event handlers to properly initialize informer.
@@ -101,6 +113,20 @@ func NewIngressSource(ctx context.Context, kubeClient kubernetes.Interface, name
 		},
 	)
 
+	informers.SetWatchBackoff(informerOpts.WatchBackoffMaxDelay, ingressInformer.Informer())
+
+	var serviceInformer coreinformers.ServiceInformer
+	if inheritServiceAnnotations {
+		serviceInformer = informerFactory.Core().V1().Services()
+		serviceInformer.Informer().AddEventHandler(
+			cache.ResourceEventHandlerFuncs{
+				AddFunc: func(obj interface{}) {
+				},
+			},
+		)
+		informers.SetWatchBackoff(informerOpts.WatchBackoffMaxDelay, serviceInformer.Informer())
+	}
+
 	informerFactory.Start(ctx.Done())
 
 	// wait for the local cache to be populated.
@@ -120,6 +146,8 @@ func NewIngressSource(ctx context.Context, kubeClient kubernetes.Interface, name
 		ignoreIngressTLSSpec:     ignoreIngressTLSSpec,
 		ignoreIngressRulesSpec:   ignoreIngressRulesSpec,
 		labelSelector:            labelSelector,
+		serviceInformer:          serviceInformer,
+		updateIngressStatus:      updateIngressStatus,
 	}
 	return sc, nil
 }
@@ -152,11 +180,12 @@ func (sc *ingressSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, e
 			continue
 		}
 
-		ingEndpoints := endpointsFromIngress(ing, sc.ignoreHostnameAnnotation, sc.ignoreIngressTLSSpec, sc.ignoreIngressRulesSpec)
+		hostAnnotations := sc.hostAnnotations(ing)
+		ingEndpoints := endpointsFromIngress(ing, hostAnnotations, sc.ignoreHostnameAnnotation, sc.ignoreIngressTLSSpec, sc.ignoreIngressRulesSpec)
 
 		// apply template if host is missing on ingress
 		if (sc.combineFQDNAnnotation || len(ingEndpoints) == 0) && sc.fqdnTemplate != nil {
-			iEndpoints, err := sc.endpointsFromTemplate(ing)
+			iEndpoints, err := sc.endpointsFromTemplate(ing, hostAnnotations)
 			if err != nil {
 				return nil, err
 			}
@@ -169,6 +198,13 @@ func (sc *ingressSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, e
 			continue
 		}
 
+		// the rules of the paths of a host, and its TLS section, all produce the same endpoints
+		ingEndpoints = endpoint.RemoveDuplicates(ingEndpoints)
+
+		if sc.updateIngressStatus {
+			sc.updateStatus(ctx, ing)
+		}
+
 		log.Debugf("Endpoints generated from ingress: %s/%s: %v", ing.Namespace, ing.Name, ingEndpoints)
 		endpoints = append(endpoints, ingEndpoints...)
 	}
@@ -180,7 +216,7 @@ func (sc *ingressSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, e
 	return endpoints, nil
 }
 
-func (sc *ingressSource) endpointsFromTemplate(ing *networkv1.Ingress) ([]*endpoint.Endpoint, error) {
+func (sc *ingressSource) endpointsFromTemplate(ing *networkv1.Ingress, hostAnnotations map[string]map[string]string) ([]*endpoint.Endpoint, error) {
 	hostnames, err := fqdn.ExecTemplate(sc.fqdnTemplate, ing)
 	if err != nil {
 		return nil, err
@@ -188,14 +224,15 @@ func (sc *ingressSource) endpointsFromTemplate(ing *networkv1.Ingress) ([]*endpo
 
 	resource := fmt.Sprintf("ingress/%s/%s", ing.Namespace, ing.Name)
 
-	ttl := annotations.TTLFromAnnotations(ing.Annotations, resource)
+	ingAnnotations := annotationsForHost(ing, hostAnnotations, "")
+	ttl := annotations.TTLFromAnnotations(ingAnnotations, resource)
 
 	targets := annotations.TargetsFromTargetAnnotation(ing.Annotations)
 	if len(targets) == 0 {
 		targets = targetsFromIngressStatus(ing.Status)
 	}
 
-	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(ing.Annotations)
+	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(ingAnnotations)
 
 	var endpoints []*endpoint.Endpoint
 	for _, hostname := range hostnames {
@@ -272,11 +309,94 @@ func (sc *ingressSource) filterByIngressClass(ingresses []*networkv1.Ingress) ([
 	return filteredList, nil
 }
 
-// endpointsFromIngress extracts the endpoints from ingress object
-func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool, ignoreIngressTLSSpec bool, ignoreIngressRulesSpec bool) []*endpoint.Endpoint {
-	resource := fmt.Sprintf("ingress/%s/%s", ing.Namespace, ing.Name)
+// hostAnnotations returns the annotations of the ingress merged with the inherited annotations of the backend
+// services of each host, the annotations of the ingress taking precedence. The empty host holds the annotations
+// merged with those of all the backend services, for the hosts which aren't in the rules.
+// It returns nil when the annotations of the services aren't inherited.
+func (sc *ingressSource) hostAnnotations(ing *networkv1.Ingress) map[string]map[string]string {
+	if sc.serviceInformer == nil {
+		return nil
+	}
 
-	ttl := annotations.TTLFromAnnotations(ing.Annotations, resource)
+	var defaultBackends []string
+	if ing.Spec.DefaultBackend != nil && ing.Spec.DefaultBackend.Service != nil {
+		defaultBackends = append(defaultBackends, ing.Spec.DefaultBackend.Service.Name)
+	}
+
+	allBackends := defaultBackends
+	hostBackends := map[string][]string{}
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service == nil {
+				continue
+			}
+			allBackends = append(allBackends, path.Backend.Service.Name)
+			if rule.Host != "" {
+				hostBackends[rule.Host] = append(hostBackends[rule.Host], path.Backend.Service.Name)
+			}
+		}
+	}
+
+	result := map[string]map[string]string{
+		"": sc.mergeServiceAnnotations(ing, allBackends),
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.Host == "" {
+			continue
+		}
+		backends, ok := hostBackends[rule.Host]
+		if !ok {
+			backends = defaultBackends
+		}
+		result[rule.Host] = sc.mergeServiceAnnotations(ing, backends)
+	}
+	return result
+}
+
+// mergeServiceAnnotations returns the annotations of the ingress with the inherited annotations of the services
+// it doesn't set, the first service setting an annotation wins.
+func (sc *ingressSource) mergeServiceAnnotations(ing *networkv1.Ingress, serviceNames []string) map[string]string {
+	merged := make(map[string]string, len(ing.Annotations))
+	for k, v := range ing.Annotations {
+		merged[k] = v
+	}
+
+	for _, name := range serviceNames {
+		svc, err := sc.serviceInformer.Lister().Services(ing.Namespace).Get(name)
+		if err != nil {
+			log.Debugf("Not inheriting the annotations of service %s/%s of ingress %s/%s: %v", ing.Namespace, name, ing.Namespace, ing.Name, err)
+			continue
+		}
+		for _, key := range inheritedServiceAnnotations {
+			if _, ok := merged[key]; ok {
+				continue
+			}
+			if v, ok := svc.Annotations[key]; ok {
+				merged[key] = v
+			}
+		}
+	}
+	return merged
+}
+
+// annotationsForHost returns the annotations of the endpoints of the host, see ingressSource.hostAnnotations.
+func annotationsForHost(ing *networkv1.Ingress, hostAnnotations map[string]map[string]string, host string) map[string]string {
+	if a, ok := hostAnnotations[host]; ok {
+		return a
+	}
+	if a, ok := hostAnnotations[""]; ok {
+		return a
+	}
+	return ing.Annotations
+}
+
+// endpointsFromIngress extracts the endpoints from ingress object, the annotations of each host are given by hostAnnotations
+// when the annotations of the backend services are inherited.
+func endpointsFromIngress(ing *networkv1.Ingress, hostAnnotations map[string]map[string]string, ignoreHostnameAnnotation bool, ignoreIngressTLSSpec bool, ignoreIngressRulesSpec bool) []*endpoint.Endpoint {
+	resource := fmt.Sprintf("ingress/%s/%s", ing.Namespace, ing.Name)
 
 	targets := annotations.TargetsFromTargetAnnotation(ing.Annotations)
 
@@ -284,7 +404,12 @@ func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool,
 		targets = targetsFromIngressStatus(ing.Status)
 	}
 
-	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(ing.Annotations)
+	endpointsForHost := func(host string) []*endpoint.Endpoint {
+		merged := annotationsForHost(ing, hostAnnotations, host)
+		ttl := annotations.TTLFromAnnotations(merged, resource)
+		providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(merged)
+		return endpointsForHostname(host, targets, ttl, providerSpecific, setIdentifier, resource)
+	}
 
 	// Gather endpoints defined on hosts sections of the ingress
 	var definedHostsEndpoints []*endpoint.Endpoint
@@ -294,7 +419,7 @@ func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool,
 			if rule.Host == "" {
 				continue
 			}
-			definedHostsEndpoints = append(definedHostsEndpoints, endpointsForHostname(rule.Host, targets, ttl, providerSpecific, setIdentifier, resource)...)
+			definedHostsEndpoints = append(definedHostsEndpoints, endpointsForHost(rule.Host)...)
 		}
 	}
 
@@ -305,7 +430,7 @@ func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool,
 				if host == "" {
 					continue
 				}
-				definedHostsEndpoints = append(definedHostsEndpoints, endpointsForHostname(host, targets, ttl, providerSpecific, setIdentifier, resource)...)
+				definedHostsEndpoints = append(definedHostsEndpoints, endpointsForHost(host)...)
 			}
 		}
 	}
@@ -314,7 +439,7 @@ func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool,
 	var annotationEndpoints []*endpoint.Endpoint
 	if !ignoreHostnameAnnotation {
 		for _, hostname := range annotations.HostnamesFromAnnotations(ing.Annotations) {
-			annotationEndpoints = append(annotationEndpoints, endpointsForHostname(hostname, targets, ttl, providerSpecific, setIdentifier, resource)...)
+			annotationEndpoints = append(annotationEndpoints, endpointsForHost(hostname)...)
 		}
 	}
 
@@ -335,6 +460,38 @@ func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool,
 	return endpoints
 }
 
+// updateStatus patches the load balancer status of the ingress with the targets of its target annotation,
+// for the ingress controllers which don't know the address they are reachable at.
+func (sc *ingressSource) updateStatus(ctx context.Context, ing *networkv1.Ingress) {
+	targets := annotations.TargetsFromTargetAnnotation(ing.Annotations)
+	if len(targets) == 0 || targets.Same(targetsFromIngressStatus(ing.Status)) {
+		return
+	}
+
+	lbIngress := make([]networkv1.IngressLoadBalancerIngress, 0, len(targets))
+	for _, target := range targets {
+		if net.ParseIP(target) != nil {
+			lbIngress = append(lbIngress, networkv1.IngressLoadBalancerIngress{IP: target})
+		} else {
+			lbIngress = append(lbIngress, networkv1.IngressLoadBalancerIngress{Hostname: target})
+		}
+	}
+	patch, err := json.Marshal(map[string]any{
+		"status": networkv1.IngressStatus{LoadBalancer: networkv1.IngressLoadBalancerStatus{Ingress: lbIngress}},
+	})
+	if err != nil {
+		log.Warnf("Failed to update the status of ingress %s/%s: %v", ing.Namespace, ing.Name, err)
+		return
+	}
+
+	_, err = sc.client.NetworkingV1().Ingresses(ing.Namespace).Patch(ctx, ing.Name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+	if err != nil {
+		log.Warnf("Failed to update the status of ingress %s/%s: %v", ing.Namespace, ing.Name, err)
+		return
+	}
+	log.Debugf("Updated the load balancer status of ingress %s/%s to %v", ing.Namespace, ing.Name, targets)
+}
+
 func targetsFromIngressStatus(status networkv1.IngressStatus) endpoint.Targets {
 	var targets endpoint.Targets
 
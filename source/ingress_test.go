@@ -23,12 +23,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
 	networkv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes/fake"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/informers"
 )
 
 // Validates that ingressSource is a Source
@@ -65,6 +67,9 @@ func (suite *IngressSuite) SetupTest() {
 		false,
 		labels.Everything(),
 		[]string{},
+		false,
+		false,
+		informers.Options{},
 	)
 	suite.NoError(err, "should initialize ingress source")
 }
@@ -154,6 +159,9 @@ func TestNewIngressSource(t *testing.T) {
 				false,
 				labels.Everything(),
 				ti.ingressClassNames,
+				false,
+				false,
+				informers.Options{},
 			)
 			if ti.expectError {
 				assert.Error(t, err)
@@ -285,7 +293,7 @@ func testEndpointsFromIngress(t *testing.T) {
 	} {
 		t.Run(ti.title, func(t *testing.T) {
 			realIngress := ti.ingress.Ingress()
-			validateEndpoints(t, endpointsFromIngress(realIngress, ti.ignoreHostnameAnnotation, ti.ignoreIngressTLSSpec, ti.ignoreIngressRulesSpec), ti.expected)
+			validateEndpoints(t, endpointsFromIngress(realIngress, nil, ti.ignoreHostnameAnnotation, ti.ignoreIngressTLSSpec, ti.ignoreIngressRulesSpec), ti.expected)
 		})
 	}
 }
@@ -384,7 +392,7 @@ func testEndpointsFromIngressHostnameSourceAnnotation(t *testing.T) {
 	} {
 		t.Run(ti.title, func(t *testing.T) {
 			realIngress := ti.ingress.Ingress()
-			validateEndpoints(t, endpointsFromIngress(realIngress, false, false, false), ti.expected)
+			validateEndpoints(t, endpointsFromIngress(realIngress, nil, false, false, false), ti.expected)
 		})
 	}
 }
@@ -1451,6 +1459,9 @@ func testIngressEndpoints(t *testing.T) {
 				ti.ignoreIngressRulesSpec,
 				ti.ingressLabelSelector,
 				ti.ingressClassNames,
+				false,
+				false,
+				informers.Options{},
 			)
 			// Informer cache has all of the ingresses. Retrieve and validate their endpoints.
 			res, err := source.Endpoints(context.Background())
@@ -1469,6 +1480,149 @@ func testIngressEndpoints(t *testing.T) {
 	}
 }
 
+func TestIngressInheritServiceAnnotations(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset()
+
+	for name, svcAnnotations := range map[string]map[string]string{
+		"with-ttl":    {"external-dns.alpha.kubernetes.io/ttl": "60", "external-dns.alpha.kubernetes.io/aws-weight": "10"},
+		"without-ttl": {"external-dns.alpha.kubernetes.io/hostname": "ignored.example.org"},
+	} {
+		_, err := fakeClient.CoreV1().Services("default").Create(context.Background(), &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Annotations: svcAnnotations},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	ingress := func(name, host, service string, ingAnnotations map[string]string) *networkv1.Ingress {
+		ing := fakeIngress{
+			namespace:   "default",
+			name:        name,
+			dnsnames:    []string{host},
+			ips:         []string{"1.2.3.4"},
+			annotations: ingAnnotations,
+		}.Ingress()
+		ing.Spec.Rules[0].HTTP = &networkv1.HTTPIngressRuleValue{
+			Paths: []networkv1.HTTPIngressPath{{
+				Backend: networkv1.IngressBackend{Service: &networkv1.IngressServiceBackend{Name: service}},
+			}},
+		}
+		return ing
+	}
+	for _, ing := range []*networkv1.Ingress{
+		ingress("ingress-only", "ingress-only.example.org", "without-ttl", map[string]string{"external-dns.alpha.kubernetes.io/ttl": "30"}),
+		ingress("service-only", "service-only.example.org", "with-ttl", nil),
+		ingress("both", "both.example.org", "with-ttl", map[string]string{"external-dns.alpha.kubernetes.io/ttl": "30"}),
+		ingress("missing-service", "missing-service.example.org", "missing", nil),
+	} {
+		_, err := fakeClient.NetworkingV1().Ingresses("default").Create(context.Background(), ing, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	for _, tt := range []struct {
+		title    string
+		inherit  bool
+		expected []*endpoint.Endpoint
+	}{
+		{
+			title:   "inherited",
+			inherit: true,
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("ingress-only.example.org", endpoint.RecordTypeA, 30, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "ingress/default/ingress-only"),
+				endpoint.NewEndpointWithTTL("service-only.example.org", endpoint.RecordTypeA, 60, "1.2.3.4").WithProviderSpecific("aws/weight", "10").WithLabel(endpoint.ResourceLabelKey, "ingress/default/service-only"),
+				endpoint.NewEndpointWithTTL("both.example.org", endpoint.RecordTypeA, 30, "1.2.3.4").WithProviderSpecific("aws/weight", "10").WithLabel(endpoint.ResourceLabelKey, "ingress/default/both"),
+				endpoint.NewEndpoint("missing-service.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "ingress/default/missing-service"),
+			},
+		},
+		{
+			title: "not inherited",
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("ingress-only.example.org", endpoint.RecordTypeA, 30, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "ingress/default/ingress-only"),
+				endpoint.NewEndpoint("service-only.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "ingress/default/service-only"),
+				endpoint.NewEndpointWithTTL("both.example.org", endpoint.RecordTypeA, 30, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "ingress/default/both"),
+				endpoint.NewEndpoint("missing-service.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "ingress/default/missing-service"),
+			},
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			source, err := NewIngressSource(context.TODO(), fakeClient, "", "", "", false, false, false, false, labels.Everything(), nil, tt.inherit, false, informers.Options{})
+			require.NoError(t, err)
+
+			res, err := source.Endpoints(context.Background())
+			require.NoError(t, err)
+			validateEndpoints(t, res, tt.expected)
+		})
+	}
+}
+
+func TestIngressDeduplicatesHostnames(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset()
+	ing := fakeIngress{
+		namespace:   "default",
+		name:        "paths",
+		dnsnames:    []string{"app.example.org", "app.example.org", "api.example.org"},
+		tlsdnsnames: [][]string{{"app.example.org"}},
+		ips:         []string{"1.2.3.4"},
+	}.Ingress()
+	_, err := fakeClient.NetworkingV1().Ingresses("default").Create(context.Background(), ing, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	source, err := NewIngressSource(context.TODO(), fakeClient, "", "", "", false, false, false, false, labels.Everything(), nil, false, false, informers.Options{})
+	require.NoError(t, err)
+
+	res, err := source.Endpoints(context.Background())
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	validateEndpoints(t, res, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "ingress/default/paths"),
+		endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "ingress/default/paths"),
+	})
+}
+
+func TestIngressUpdateStatus(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewSimpleClientset()
+	for _, ing := range []*networkv1.Ingress{
+		fakeIngress{
+			namespace:   "default",
+			name:        "annotated",
+			dnsnames:    []string{"app.example.org"},
+			annotations: map[string]string{targetAnnotationKey: "1.2.3.4,lb.example.org"},
+		}.Ingress(),
+		fakeIngress{
+			namespace: "default",
+			name:      "reported",
+			dnsnames:  []string{"api.example.org"},
+			ips:       []string{"8.8.8.8"},
+		}.Ingress(),
+	} {
+		_, err := fakeClient.NetworkingV1().Ingresses("default").Create(ctx, ing, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	source, err := NewIngressSource(ctx, fakeClient, "", "", "", false, false, false, false, labels.Everything(), nil, false, true, informers.Options{})
+	require.NoError(t, err)
+
+	_, err = source.Endpoints(ctx)
+	require.NoError(t, err)
+
+	annotated, err := fakeClient.NetworkingV1().Ingresses("default").Get(ctx, "annotated", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []networkv1.IngressLoadBalancerIngress{{IP: "1.2.3.4"}, {Hostname: "lb.example.org"}}, annotated.Status.LoadBalancer.Ingress)
+
+	reported, err := fakeClient.NetworkingV1().Ingresses("default").Get(ctx, "reported", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []networkv1.IngressLoadBalancerIngress{{IP: "8.8.8.8"}}, reported.Status.LoadBalancer.Ingress)
+
+	var patches int
+	for _, action := range fakeClient.Actions() {
+		if action.GetVerb() == "patch" {
+			assert.Equal(t, "status", action.GetSubresource())
+			patches++
+		}
+	}
+	assert.Equal(t, 1, patches, "only the ingress with a target annotation must be patched")
+}
+
 // ingress specific helper functions
 type fakeIngress struct {
 	dnsnames         []string
@@ -34,6 +34,7 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/informers"
 )
 
 // This is a compile-time validation that istioVirtualServiceSource is a Source.
@@ -119,6 +120,7 @@ func (suite *VirtualServiceSuite) SetupTest() {
 		"{{.Name}}",
 		false,
 		false,
+		informers.Options{},
 	)
 	suite.NoError(err, "should initialize virtualservice source")
 }
@@ -196,6 +198,7 @@ func TestNewIstioVirtualServiceSource(t *testing.T) {
 				ti.fqdnTemplate,
 				ti.combineFQDNAndAnnotation,
 				false,
+				informers.Options{},
 			)
 			if ti.expectError {
 				assert.Error(t, err)
@@ -1993,6 +1996,7 @@ func testVirtualServiceEndpoints(t *testing.T) {
 				ti.fqdnTemplate,
 				ti.combineFQDNAndAnnotation,
 				ti.ignoreHostnameAnnotation,
+				informers.Options{},
 			)
 			require.NoError(t, err)
 
@@ -2079,6 +2083,7 @@ func newTestVirtualServiceSource(loadBalancerList []fakeIngressGatewayService, i
 		"{{.Name}}",
 		false,
 		false,
+		informers.Options{},
 	)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,173 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+	kubeinformers "k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+// namespaceSource is an implementation of Source for the Services of the annotated Namespaces.
+// A Service without its own hostname annotation inherits the hostnames of its Namespace and
+// is published as "<service>.<namespace hostname>", the targets are the ones of the service source.
+type namespaceSource struct {
+	namespace         string
+	namespaceInformer coreinformers.NamespaceInformer
+	services          *serviceSource
+}
+
+// NewNamespaceSource creates a new namespaceSource with the given config.
+func NewNamespaceSource(
+	ctx context.Context,
+	kubeClient kubernetes.Interface,
+	namespace string,
+	annotationFilter string,
+	publishInternal bool,
+	publishHostIP bool,
+	alwaysPublishNotReadyAddresses bool,
+	serviceTypeFilter []string,
+	labelSelector labels.Selector,
+	resolveLoadBalancerHostname bool,
+	exposeInternalIPv6 bool,
+	loadBalancerClassFilter []string,
+	includeClusterIP bool,
+	informerOpts informers.Options,
+) (Source, error) {
+	services, err := NewServiceSource(ctx, kubeClient, namespace, annotationFilter, "", false, "", publishInternal, publishHostIP,
+		alwaysPublishNotReadyAddresses, serviceTypeFilter, false, labelSelector, resolveLoadBalancerHostname, false, exposeInternalIPv6, loadBalancerClassFilter, includeClusterIP, informerOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Namespaces are cluster scoped, the informer is not restricted to the namespace of the source.
+	informerFactory := kubeinformers.NewSharedInformerFactory(kubeClient, informerOpts.ResyncPeriod)
+	namespaceInformer := informerFactory.Core().V1().Namespaces()
+
+	namespaceInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+			},
+		},
+	)
+
+	informers.SetWatchBackoff(informerOpts.WatchBackoffMaxDelay, namespaceInformer.Informer())
+	informerFactory.Start(ctx.Done())
+
+	// wait for the local cache to be populated.
+	if err := informers.WaitForCacheSync(context.Background(), informerFactory); err != nil {
+		return nil, err
+	}
+
+	return &namespaceSource{
+		namespace:         namespace,
+		namespaceInformer: namespaceInformer,
+		services:          services.(*serviceSource),
+	}, nil
+}
+
+// Endpoints returns endpoint objects for each Service of the Namespaces with a hostname annotation.
+func (ns *namespaceSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+	namespaces, err := ns.namespaceInformer.Lister().List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	parentHostnames := map[string][]string{}
+	for _, namespace := range namespaces {
+		if ns.namespace != "" && namespace.Name != ns.namespace {
+			continue
+		}
+		if hostnames := annotations.HostnamesFromAnnotations(namespace.Annotations); len(hostnames) > 0 {
+			parentHostnames[namespace.Name] = hostnames
+		}
+	}
+	if len(parentHostnames) == 0 {
+		return []*endpoint.Endpoint{}, nil
+	}
+
+	sc := ns.services
+	services, err := sc.serviceInformer.Lister().Services(ns.namespace).List(sc.labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	services = sc.filterByServiceType(services)
+
+	services = sc.filterByLoadBalancerClass(services)
+
+	services, err = sc.filterByAnnotations(services)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := []*endpoint.Endpoint{}
+	for _, svc := range services {
+		hostnames, ok := parentHostnames[svc.Namespace]
+		if !ok {
+			continue
+		}
+
+		// Check controller annotation to see if we are responsible.
+		controller, ok := svc.Annotations[controllerAnnotationKey]
+		if ok && controller != controllerAnnotationValue {
+			log.Debugf("Skipping service %s/%s because controller value does not match, found: %s, required: %s",
+				svc.Namespace, svc.Name, controller, controllerAnnotationValue)
+			continue
+		}
+
+		var svcEndpoints []*endpoint.Endpoint
+		if len(annotations.HostnamesFromAnnotations(svc.Annotations)) > 0 {
+			// the hostname annotation of the service takes priority over the one of its namespace
+			svcEndpoints = sc.endpoints(svc)
+		} else {
+			providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(svc.Annotations)
+			for _, hostname := range hostnames {
+				hostname = svc.Name + "." + strings.TrimSuffix(hostname, ".")
+				svcEndpoints = append(svcEndpoints, sc.generateEndpoints(svc, hostname, providerSpecific, setIdentifier, false)...)
+			}
+		}
+
+		if len(svcEndpoints) == 0 {
+			log.Debugf("No endpoints could be generated from service %s/%s", svc.Namespace, svc.Name)
+			continue
+		}
+
+		log.Debugf("Endpoints generated from service %s/%s of annotated namespace: %v", svc.Namespace, svc.Name, svcEndpoints)
+		sc.setResourceLabel(svc, svcEndpoints)
+		endpoints = append(endpoints, svcEndpoints...)
+	}
+
+	return endpoints, nil
+}
+
+func (ns *namespaceSource) AddEventHandler(ctx context.Context, handler func()) {
+	log.Debug("Adding event handler for namespace")
+
+	ns.namespaceInformer.Informer().AddEventHandler(eventHandlerFunc(handler))
+	ns.services.AddEventHandler(ctx, handler)
+}
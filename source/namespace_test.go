@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeKube "k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/informers"
+)
+
+func newNamespace(name string, objAnnotations map[string]string) *v1.Namespace {
+	return &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: objAnnotations,
+		},
+	}
+}
+
+func newLoadBalancerService(namespace, name, ip string, objAnnotations map[string]string) *v1.Service {
+	return &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        name,
+			Annotations: objAnnotations,
+		},
+		Spec: v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer},
+		Status: v1.ServiceStatus{
+			LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: ip}}},
+		},
+	}
+}
+
+func TestNamespaceSourceEndpoints(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		namespace string
+		objects   []runtime.Object
+		expected  []*endpoint.Endpoint
+	}{
+		{
+			name: "service inherits the namespace hostname",
+			objects: []runtime.Object{
+				newNamespace("team-a", map[string]string{annotations.HostnameKey: "team-a.example.com."}),
+				newLoadBalancerService("team-a", "web", "1.2.3.4", nil),
+				newLoadBalancerService("team-a", "api", "1.2.3.5", map[string]string{annotations.TtlKey: "60"}),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("web.team-a.example.com", endpoint.RecordTypeA, "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "service/team-a/web"),
+				endpoint.NewEndpointWithTTL("api.team-a.example.com", endpoint.RecordTypeA, 60, "1.2.3.5").
+					WithLabel(endpoint.ResourceLabelKey, "service/team-a/api"),
+			},
+		},
+		{
+			name: "service inherits all the namespace hostnames",
+			objects: []runtime.Object{
+				newNamespace("team-a", map[string]string{annotations.HostnameKey: "a.example.com,a.example.org"}),
+				newLoadBalancerService("team-a", "web", "1.2.3.4", nil),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("web.a.example.com", endpoint.RecordTypeA, "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "service/team-a/web"),
+				endpoint.NewEndpoint("web.a.example.org", endpoint.RecordTypeA, "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "service/team-a/web"),
+			},
+		},
+		{
+			name: "service hostname annotation takes priority",
+			objects: []runtime.Object{
+				newNamespace("team-a", map[string]string{annotations.HostnameKey: "team-a.example.com"}),
+				newLoadBalancerService("team-a", "web", "1.2.3.4", map[string]string{annotations.HostnameKey: "www.example.com"}),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "service/team-a/web"),
+			},
+		},
+		{
+			name: "namespace without hostname annotation",
+			objects: []runtime.Object{
+				newNamespace("team-a", nil),
+				newLoadBalancerService("team-a", "web", "1.2.3.4", nil),
+				newLoadBalancerService("team-a", "api", "1.2.3.5", map[string]string{annotations.HostnameKey: "api.example.com"}),
+			},
+			expected: []*endpoint.Endpoint{},
+		},
+		{
+			name: "only the services of annotated namespaces",
+			objects: []runtime.Object{
+				newNamespace("team-a", map[string]string{annotations.HostnameKey: "team-a.example.com"}),
+				newNamespace("team-b", nil),
+				newLoadBalancerService("team-a", "web", "1.2.3.4", nil),
+				newLoadBalancerService("team-b", "web", "1.2.3.5", nil),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("web.team-a.example.com", endpoint.RecordTypeA, "1.2.3.4").
+					WithLabel(endpoint.ResourceLabelKey, "service/team-a/web"),
+			},
+		},
+		{
+			name:      "single namespace",
+			namespace: "team-b",
+			objects: []runtime.Object{
+				newNamespace("team-a", map[string]string{annotations.HostnameKey: "team-a.example.com"}),
+				newNamespace("team-b", map[string]string{annotations.HostnameKey: "team-b.example.com"}),
+				newLoadBalancerService("team-a", "web", "1.2.3.4", nil),
+				newLoadBalancerService("team-b", "web", "1.2.3.5", nil),
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("web.team-b.example.com", endpoint.RecordTypeA, "1.2.3.5").
+					WithLabel(endpoint.ResourceLabelKey, "service/team-b/web"),
+			},
+		},
+		{
+			name: "service without targets",
+			objects: []runtime.Object{
+				newNamespace("team-a", map[string]string{annotations.HostnameKey: "team-a.example.com"}),
+				&v1.Service{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "internal"},
+					Spec:       v1.ServiceSpec{Type: v1.ServiceTypeClusterIP, ClusterIP: "10.0.0.1"},
+				},
+			},
+			expected: []*endpoint.Endpoint{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := fakeKube.NewClientset(tt.objects...)
+
+			src, err := NewNamespaceSource(context.Background(), client, tt.namespace, "", false, false, false, []string{}, labels.Everything(), false, false, nil, false, informers.Options{})
+			require.NoError(t, err)
+
+			endpoints, err := src.Endpoints(context.Background())
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tt.expected)
+		})
+	}
+}
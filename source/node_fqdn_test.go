@@ -25,7 +25,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes/fake"
+
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/informers"
 )
 
 func TestNodeSourceNewNodeSourceWithFqdn(t *testing.T) {
@@ -64,6 +66,7 @@ func TestNodeSourceNewNodeSourceWithFqdn(t *testing.T) {
 				labels.Everything(),
 				true,
 				true,
+				informers.Options{},
 			)
 			if tt.expectError {
 				assert.Error(t, err)
@@ -105,7 +108,7 @@ func TestNodeSourceFqdnTemplatingExamples(t *testing.T) {
 			},
 		},
 		{
-			title: "templating contains namespace when node namespace is not a valid variable",
+			title: "templating skips hostname with empty label when node namespace is not a valid variable",
 			nodes: []*v1.Node{
 				{
 					ObjectMeta: metav1.ObjectMeta{
@@ -121,7 +124,6 @@ func TestNodeSourceFqdnTemplatingExamples(t *testing.T) {
 			fqdnTemplate: "{{.Name}}.domainA.com,{{ .Name }}.{{ .Namespace }}.example.tld",
 			expected: []*endpoint.Endpoint{
 				{DNSName: "node-name.domainA.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.1.176.1"}},
-				{DNSName: "node-name..example.tld", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.1.176.1"}},
 			},
 		},
 		{
@@ -310,6 +312,7 @@ func TestNodeSourceFqdnTemplatingExamples(t *testing.T) {
 				labels.Everything(),
 				true,
 				true,
+				informers.Options{},
 			)
 			require.NoError(t, err)
 
@@ -29,6 +29,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 
 	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/source/informers"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -95,6 +96,7 @@ func testNodeSourceNewNodeSource(t *testing.T) {
 				labels.Everything(),
 				true,
 				true,
+				informers.Options{},
 			)
 
 			if ti.expectError {
@@ -440,6 +442,7 @@ func testNodeSourceEndpoints(t *testing.T) {
 				labelSelector,
 				tc.exposeInternalIPv6,
 				tc.excludeUnschedulable,
+				informers.Options{},
 			)
 			require.NoError(t, err)
 
@@ -557,6 +560,7 @@ func testNodeEndpointsWithIPv6(t *testing.T) {
 			labelSelector,
 			tc.exposeInternalIPv6,
 			tc.excludeUnschedulable,
+			informers.Options{},
 		)
 		require.NoError(t, err)
 
@@ -604,6 +608,7 @@ func TestResourceLabelIsSetForEachNodeEndpoint(t *testing.T) {
 		labels.Everything(),
 		false,
 		true,
+		informers.Options{},
 	)
 	require.NoError(t, err)
 
@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"sort"
 	"text/template"
-	"time"
 
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/openshift/client-go/route/clientset/versioned"
@@ -66,6 +65,7 @@ func NewOcpRouteSource(
 	ignoreHostnameAnnotation bool,
 	labelSelector labels.Selector,
 	ocpRouterName string,
+	informerOpts informers.Options,
 ) (Source, error) {
 	tmpl, err := fqdn.ParseTemplate(fqdnTemplate)
 	if err != nil {
@@ -73,8 +73,7 @@ func NewOcpRouteSource(
 	}
 
 	// Use a shared informer to listen for add/update/delete of Routes in the specified namespace.
-	// Set resync period to 0, to prevent processing when nothing has changed.
-	informerFactory := extInformers.NewFilteredSharedInformerFactory(ocpClient, 0*time.Second, namespace, nil)
+	informerFactory := extInformers.NewFilteredSharedInformerFactory(ocpClient, informerOpts.ResyncPeriod, namespace, nil)
 	informer := informerFactory.Route().V1().Routes()
 
 	// Add default resource event handlers to properly initialize informer.
@@ -85,6 +84,7 @@ func NewOcpRouteSource(
 		},
 	)
 
+	informers.SetWatchBackoff(informerOpts.WatchBackoffMaxDelay, informer.Informer())
 	informerFactory.Start(ctx.Done())
 
 	// wait for the local cache to be populated.
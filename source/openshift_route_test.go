@@ -31,6 +31,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/source/informers"
 )
 
 type OCPRouteSuite struct {
@@ -53,6 +54,7 @@ func (suite *OCPRouteSuite) SetupTest() {
 		false,
 		labels.Everything(),
 		"",
+		informers.Options{},
 	)
 
 	suite.routeWithTargets = &routev1.Route{
@@ -152,6 +154,7 @@ func testOcpRouteSourceNewOcpRouteSource(t *testing.T) {
 				false,
 				labelSelector,
 				"",
+				informers.Options{},
 			)
 
 			if ti.expectError {
@@ -537,6 +540,7 @@ func testOcpRouteSourceEndpoints(t *testing.T) {
 				false,
 				labelSelector,
 				tc.ocpRouterName,
+				informers.Options{},
 			)
 			require.NoError(t, err)
 
@@ -67,6 +67,7 @@ type serviceSource struct {
 
 	ignoreHostnameAnnotation       bool
 	publishInternal                bool
+	includeClusterIP               bool
 	publishHostIP                  bool
 	alwaysPublishNotReadyAddresses bool
 	resolveLoadBalancerHostname    bool
@@ -76,6 +77,7 @@ type serviceSource struct {
 	podInformer                    coreinformers.PodInformer
 	nodeInformer                   coreinformers.NodeInformer
 	serviceTypeFilter              *serviceTypes
+	loadBalancerClassFilter        []string
 	exposeInternalIPv6             bool
 
 	// process Services with legacy annotations
@@ -83,15 +85,14 @@ type serviceSource struct {
 }
 
 // NewServiceSource creates a new serviceSource with the given config.
-func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, namespace, annotationFilter, fqdnTemplate string, combineFqdnAnnotation bool, compatibility string, publishInternal, publishHostIP, alwaysPublishNotReadyAddresses bool, serviceTypeFilter []string, ignoreHostnameAnnotation bool, labelSelector labels.Selector, resolveLoadBalancerHostname, listenEndpointEvents bool, exposeInternalIPv6 bool) (Source, error) {
+func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, namespace, annotationFilter, fqdnTemplate string, combineFqdnAnnotation bool, compatibility string, publishInternal, publishHostIP, alwaysPublishNotReadyAddresses bool, serviceTypeFilter []string, ignoreHostnameAnnotation bool, labelSelector labels.Selector, resolveLoadBalancerHostname, listenEndpointEvents bool, exposeInternalIPv6 bool, loadBalancerClassFilter []string, includeClusterIP bool, informerOpts informers.Options) (Source, error) {
 	tmpl, err := fqdn.ParseTemplate(fqdnTemplate)
 	if err != nil {
 		return nil, err
 	}
 
 	// Use shared informers to listen for add/update/delete of services/pods/nodes in the specified namespace.
-	// Set the resync period to 0 to prevent processing when nothing has changed
-	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(namespace))
+	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, informerOpts.ResyncPeriod, kubeinformers.WithNamespace(namespace))
 	serviceInformer := informerFactory.Core().V1().Services()
 	endpointsInformer := informerFactory.Core().V1().Endpoints()
 	podInformer := informerFactory.Core().V1().Pods()
@@ -123,6 +124,7 @@ func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, name
 		},
 	)
 
+	informers.SetWatchBackoff(informerOpts.WatchBackoffMaxDelay, serviceInformer.Informer(), endpointsInformer.Informer(), podInformer.Informer(), nodeInformer.Informer())
 	informerFactory.Start(ctx.Done())
 
 	// wait for the local cache to be populated.
@@ -145,6 +147,7 @@ func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, name
 		combineFQDNAnnotation:          combineFqdnAnnotation,
 		ignoreHostnameAnnotation:       ignoreHostnameAnnotation,
 		publishInternal:                publishInternal,
+		includeClusterIP:               includeClusterIP,
 		publishHostIP:                  publishHostIP,
 		alwaysPublishNotReadyAddresses: alwaysPublishNotReadyAddresses,
 		serviceInformer:                serviceInformer,
@@ -152,6 +155,7 @@ func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, name
 		podInformer:                    podInformer,
 		nodeInformer:                   nodeInformer,
 		serviceTypeFilter:              sTypesFilter,
+		loadBalancerClassFilter:        loadBalancerClassFilter,
 		labelSelector:                  labelSelector,
 		resolveLoadBalancerHostname:    resolveLoadBalancerHostname,
 		listenEndpointEvents:           listenEndpointEvents,
@@ -169,6 +173,8 @@ func (sc *serviceSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 	// filter on service types if at least one has been provided
 	services = sc.filterByServiceType(services)
 
+	services = sc.filterByLoadBalancerClass(services)
+
 	services, err = sc.filterByAnnotations(services)
 	if err != nil {
 		return nil, err
@@ -185,6 +191,11 @@ func (sc *serviceSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 			continue
 		}
 
+		if svc.Annotations[annotations.RequireReadyKey] == "true" && !sc.hasReadyEndpoints(svc) {
+			log.Debugf("Skipping service %s/%s because it has no ready endpoints", svc.Namespace, svc.Name)
+			continue
+		}
+
 		svcEndpoints := sc.endpoints(svc)
 
 		// process legacy annotations if no endpoints were returned and compatibility mode is enabled.
@@ -244,7 +255,8 @@ func (sc *serviceSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 				mergedEndpoints[lastMergedEndpoint].RecordType == endpoints[i].RecordType &&
 				mergedEndpoints[lastMergedEndpoint].RecordType != endpoint.RecordTypeCNAME && // It is against RFC-1034 for CNAME records to have multiple targets, so skip merging
 				mergedEndpoints[lastMergedEndpoint].SetIdentifier == endpoints[i].SetIdentifier &&
-				mergedEndpoints[lastMergedEndpoint].RecordTTL == endpoints[i].RecordTTL {
+				mergedEndpoints[lastMergedEndpoint].RecordTTL == endpoints[i].RecordTTL &&
+				sameWeight(mergedEndpoints[lastMergedEndpoint], endpoints[i]) { // services with different weights are published as separate weighted records
 				mergedEndpoints[lastMergedEndpoint].Targets = append(mergedEndpoints[lastMergedEndpoint].Targets, endpoints[i].Targets[0])
 			} else {
 				mergedEndpoints = append(mergedEndpoints, endpoints[i])
@@ -266,6 +278,27 @@ func (sc *serviceSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, err
 	return endpoints, nil
 }
 
+// hasReadyEndpoints returns true when the Endpoints object of the service has at least one ready address.
+func (sc *serviceSource) hasReadyEndpoints(svc *v1.Service) bool {
+	endpointsObject, err := sc.endpointsInformer.Lister().Endpoints(svc.Namespace).Get(svc.GetName())
+	if err != nil {
+		return false
+	}
+	for _, subset := range endpointsObject.Subsets {
+		if len(subset.Addresses) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// sameWeight returns true when both endpoints have the same weight annotation, or none.
+func sameWeight(a, b *endpoint.Endpoint) bool {
+	weightA, _ := a.GetProviderSpecificProperty(annotations.AWSWeightProperty)
+	weightB, _ := b.GetProviderSpecificProperty(annotations.AWSWeightProperty)
+	return weightA == weightB
+}
+
 // extractHeadlessEndpoints extracts endpoints from a headless service using the "Endpoints" Kubernetes API resource
 func (sc *serviceSource) extractHeadlessEndpoints(svc *v1.Service, hostname string, ttl endpoint.TTL) []*endpoint.Endpoint {
 	var endpoints []*endpoint.Endpoint
@@ -402,6 +435,7 @@ func (sc *serviceSource) endpointsFromTemplate(svc *v1.Service) ([]*endpoint.End
 	if err != nil {
 		return nil, err
 	}
+	hostnames = annotations.WildcardHostnames(svc.Annotations, hostnames)
 
 	providerSpecific, setIdentifier := annotations.ProviderSpecificAnnotations(svc.Annotations)
 
@@ -422,12 +456,12 @@ func (sc *serviceSource) endpoints(svc *v1.Service) []*endpoint.Endpoint {
 		var hostnameList []string
 		var internalHostnameList []string
 
-		hostnameList = annotations.HostnamesFromAnnotations(svc.Annotations)
+		hostnameList = annotations.WildcardHostnames(svc.Annotations, annotations.HostnamesFromAnnotations(svc.Annotations))
 		for _, hostname := range hostnameList {
 			endpoints = append(endpoints, sc.generateEndpoints(svc, hostname, providerSpecific, setIdentifier, false)...)
 		}
 
-		internalHostnameList = annotations.InternalHostnamesFromAnnotations(svc.Annotations)
+		internalHostnameList = annotations.WildcardHostnames(svc.Annotations, annotations.InternalHostnamesFromAnnotations(svc.Annotations))
 		for _, hostname := range internalHostnameList {
 			endpoints = append(endpoints, sc.generateEndpoints(svc, hostname, providerSpecific, setIdentifier, true)...)
 		}
@@ -474,6 +508,30 @@ func (sc *serviceSource) filterByServiceType(services []*v1.Service) []*v1.Servi
 	return result
 }
 
+// filterByLoadBalancerClass filters LoadBalancer services according to their spec.loadBalancerClass,
+// a service without class matches the empty class. Services of other types are not filtered.
+func (sc *serviceSource) filterByLoadBalancerClass(services []*v1.Service) []*v1.Service {
+	if len(sc.loadBalancerClassFilter) == 0 || len(services) == 0 {
+		return services
+	}
+	var result []*v1.Service
+	for _, service := range services {
+		if service.Spec.Type != v1.ServiceTypeLoadBalancer {
+			result = append(result, service)
+			continue
+		}
+		class := ""
+		if service.Spec.LoadBalancerClass != nil {
+			class = *service.Spec.LoadBalancerClass
+		}
+		if slices.Contains(sc.loadBalancerClassFilter, class) {
+			result = append(result, service)
+		}
+	}
+	log.Debugf("filtered %d services out of %d with load balancer class filter %q", len(result), len(services), sc.loadBalancerClassFilter)
+	return result
+}
+
 func (sc *serviceSource) setResourceLabel(service *v1.Service, endpoints []*endpoint.Endpoint) {
 	for _, ep := range endpoints {
 		ep.Labels[endpoint.ResourceLabelKey] = fmt.Sprintf("service/%s/%s", service.Namespace, service.Name)
@@ -493,15 +551,15 @@ func (sc *serviceSource) generateEndpoints(svc *v1.Service, hostname string, pro
 		switch svc.Spec.Type {
 		case v1.ServiceTypeLoadBalancer:
 			if useClusterIP {
-				targets = extractServiceIps(svc)
+				targets = extractServiceIps(svc, sc.includeClusterIP)
 			} else {
 				targets = extractLoadBalancerTargets(svc, sc.resolveLoadBalancerHostname)
 			}
 		case v1.ServiceTypeClusterIP:
 			if svc.Spec.ClusterIP == v1.ClusterIPNone {
 				endpoints = append(endpoints, sc.extractHeadlessEndpoints(svc, hostname, ttl)...)
-			} else if useClusterIP || sc.publishInternal {
-				targets = extractServiceIps(svc)
+			} else if useClusterIP || sc.publishInternal || sc.includeClusterIP {
+				targets = extractServiceIps(svc, sc.includeClusterIP)
 			}
 		case v1.ServiceTypeNodePort:
 			// add the nodeTargets and extract an SRV endpoint
@@ -527,11 +585,16 @@ func (sc *serviceSource) generateEndpoints(svc *v1.Service, hostname string, pro
 	return endpoints
 }
 
-func extractServiceIps(svc *v1.Service) endpoint.Targets {
+// extractServiceIps returns the cluster IP of the service, or all its cluster IPs when dualStack is set.
+func extractServiceIps(svc *v1.Service, dualStack bool) endpoint.Targets {
 	if svc.Spec.ClusterIP == v1.ClusterIPNone {
 		log.Debugf("Unable to associate %s headless service with a Cluster IP", svc.Name)
 		return endpoint.Targets{}
 	}
+	// dual-stack services have an IPv4 and an IPv6 cluster IP, the first one is spec.clusterIP
+	if dualStack && len(svc.Spec.ClusterIPs) > 0 {
+		return slices.Clone(endpoint.Targets(svc.Spec.ClusterIPs))
+	}
 	return endpoint.Targets{svc.Spec.ClusterIP}
 }
 
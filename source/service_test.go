@@ -23,6 +23,7 @@ import (
 	"net"
 	"sort"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -33,10 +34,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/testutils"
 	"sigs.k8s.io/external-dns/source/annotations"
+	"sigs.k8s.io/external-dns/source/informers"
 )
 
 type ServiceSuite struct {
@@ -86,6 +89,9 @@ func (suite *ServiceSuite) SetupTest() {
 		false,
 		false,
 		false,
+		[]string{},
+		false,
+		informers.Options{},
 	)
 	suite.NoError(err, "should initialize service source")
 }
@@ -169,6 +175,9 @@ func testServiceSourceNewServiceSource(t *testing.T) {
 				false,
 				false,
 				false,
+				[]string{},
+				false,
+				informers.Options{},
 			)
 
 			if ti.expectError {
@@ -1142,6 +1151,9 @@ func testServiceSourceEndpoints(t *testing.T) {
 				tc.resolveLoadBalancerHostname,
 				false,
 				false,
+				[]string{},
+				false,
+				informers.Options{},
 			)
 
 			require.NoError(t, err)
@@ -1358,6 +1370,9 @@ func testMultipleServicesEndpoints(t *testing.T) {
 				false,
 				false,
 				false,
+				[]string{},
+				false,
+				informers.Options{},
 			)
 			require.NoError(t, err)
 
@@ -1404,6 +1419,8 @@ func TestClusterIpServices(t *testing.T) {
 		labels                   map[string]string
 		annotations              map[string]string
 		clusterIP                string
+		clusterIPs               []string
+		includeClusterIP         bool
 		expected                 []*endpoint.Endpoint
 		expectError              bool
 		labelSelector            string
@@ -1602,6 +1619,63 @@ func TestClusterIpServices(t *testing.T) {
 			expected:      []*endpoint.Endpoint{},
 			labelSelector: "app=web-external",
 		},
+		{
+			title:        "dual-stack ClusterIp services return A and AAAA endpoints",
+			svcNamespace: "testing",
+			svcName:      "foo",
+			svcType:      v1.ServiceTypeClusterIP,
+			annotations: map[string]string{
+				hostnameAnnotationKey: "foo.example.org.",
+			},
+			clusterIP:        "1.2.3.4",
+			clusterIPs:       []string{"1.2.3.4", "2001:db8::1"},
+			includeClusterIP: true,
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+				{DNSName: "foo.example.org", RecordType: endpoint.RecordTypeAAAA, Targets: endpoint.Targets{"2001:db8::1"}},
+			},
+		},
+		{
+			title:        "dual-stack ClusterIp services return the primary Cluster IP without --include-cluster-ip",
+			svcNamespace: "testing",
+			svcName:      "foo",
+			svcType:      v1.ServiceTypeClusterIP,
+			annotations: map[string]string{
+				hostnameAnnotationKey: "foo.example.org.",
+			},
+			clusterIP:  "1.2.3.4",
+			clusterIPs: []string{"1.2.3.4", "2001:db8::1"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+		},
+		{
+			title:        "IPv6 ClusterIp services return an AAAA endpoint",
+			svcNamespace: "testing",
+			svcName:      "foo",
+			svcType:      v1.ServiceTypeClusterIP,
+			annotations: map[string]string{
+				hostnameAnnotationKey: "foo.example.org.",
+			},
+			clusterIP:        "2001:db8::1",
+			clusterIPs:       []string{"2001:db8::1"},
+			includeClusterIP: true,
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.example.org", RecordType: endpoint.RecordTypeAAAA, Targets: endpoint.Targets{"2001:db8::1"}},
+			},
+		},
+		{
+			title:        "ClusterIp services from the fqdn template return an endpoint with Cluster IP",
+			svcNamespace: "testing",
+			svcName:      "foo",
+			svcType:      v1.ServiceTypeClusterIP,
+			fqdnTemplate: "{{.Name}}.internal.example.org",
+			clusterIP:    "1.2.3.4",
+			clusterIPs:   []string{"1.2.3.4"},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.internal.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+		},
 		{
 			title:        "invalid hostname does not generate endpoints",
 			svcNamespace: "testing",
@@ -1624,8 +1698,9 @@ func TestClusterIpServices(t *testing.T) {
 			// Create a service to test against
 			service := &v1.Service{
 				Spec: v1.ServiceSpec{
-					Type:      tc.svcType,
-					ClusterIP: tc.clusterIP,
+					Type:       tc.svcType,
+					ClusterIP:  tc.clusterIP,
+					ClusterIPs: tc.clusterIPs,
 				},
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace:   tc.svcNamespace,
@@ -1663,6 +1738,9 @@ func TestClusterIpServices(t *testing.T) {
 				false,
 				false,
 				false,
+				[]string{},
+				tc.includeClusterIP,
+				informers.Options{},
 			)
 			require.NoError(t, err)
 
@@ -2385,6 +2463,9 @@ func TestServiceSourceNodePortServices(t *testing.T) {
 				false,
 				false,
 				tc.exposeInternalIPv6,
+				[]string{},
+				false,
+				informers.Options{},
 			)
 			require.NoError(t, err)
 
@@ -3181,6 +3262,9 @@ func TestHeadlessServices(t *testing.T) {
 				false,
 				false,
 				tc.exposeInternalIPv6,
+				[]string{},
+				false,
+				informers.Options{},
 			)
 			require.NoError(t, err)
 
@@ -3642,6 +3726,9 @@ func TestHeadlessServicesHostIP(t *testing.T) {
 				false,
 				false,
 				false,
+				[]string{},
+				false,
+				informers.Options{},
 			)
 			require.NoError(t, err)
 
@@ -3822,6 +3909,9 @@ func TestExternalServices(t *testing.T) {
 				false,
 				false,
 				false,
+				[]string{},
+				false,
+				informers.Options{},
 			)
 			require.NoError(t, err)
 
@@ -3879,6 +3969,9 @@ func BenchmarkServiceEndpoints(b *testing.B) {
 		false,
 		false,
 		false,
+		[]string{},
+		false,
+		informers.Options{},
 	)
 	require.NoError(b, err)
 
@@ -3908,6 +4001,9 @@ func TestNewServiceSourceWithServiceTypeFilters_Unsupported(t *testing.T) {
 		false,
 		false,
 		false,
+		[]string{},
+		false,
+		informers.Options{},
 	)
 	require.Errorf(t, err, "unsupported service type filter: \"UnknownType\". Supported types are: [\"ClusterIP\" \"NodePort\" \"LoadBalancer\" \"ExternalName\"]")
 	require.Nil(t, svc, "ServiceSource should be nil when an unsupported service type is provided")
@@ -4083,3 +4179,413 @@ func createTestServicesByType(namespace string, typeCounts map[v1.ServiceType]in
 	})
 	return services
 }
+
+func TestServiceSourceLoadBalancerClassFilter(t *testing.T) {
+	t.Parallel()
+
+	newService := func(name string, serviceType v1.ServiceType, class *string, ip string) *v1.Service {
+		return &v1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        name,
+				Annotations: map[string]string{hostnameAnnotationKey: name + ".example.org"},
+			},
+			Spec: v1.ServiceSpec{Type: serviceType, LoadBalancerClass: class, ClusterIP: ip},
+			Status: v1.ServiceStatus{
+				LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: ip}}},
+			},
+		}
+	}
+	services := []*v1.Service{
+		newService("aws", v1.ServiceTypeLoadBalancer, ptr.To("service.k8s.aws/nlb"), "1.1.1.1"),
+		newService("metallb", v1.ServiceTypeLoadBalancer, ptr.To("metallb.io/metallb"), "2.2.2.2"),
+		newService("default", v1.ServiceTypeLoadBalancer, nil, "3.3.3.3"),
+		newService("internal", v1.ServiceTypeClusterIP, nil, "10.0.0.1"),
+	}
+
+	aws := &endpoint.Endpoint{DNSName: "aws.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.1.1.1"}}
+	metallb := &endpoint.Endpoint{DNSName: "metallb.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"2.2.2.2"}}
+	noClass := &endpoint.Endpoint{DNSName: "default.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"3.3.3.3"}}
+	internal := &endpoint.Endpoint{DNSName: "internal.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}}
+
+	for _, tt := range []struct {
+		name     string
+		filter   []string
+		expected []*endpoint.Endpoint
+	}{
+		{
+			name:     "filter disabled",
+			expected: []*endpoint.Endpoint{aws, metallb, noClass, internal},
+		},
+		{
+			name:     "matching class is included and others excluded",
+			filter:   []string{"service.k8s.aws/nlb"},
+			expected: []*endpoint.Endpoint{aws, internal},
+		},
+		{
+			name:     "no class with empty filter is included",
+			filter:   []string{""},
+			expected: []*endpoint.Endpoint{noClass, internal},
+		},
+		{
+			name:     "multiple classes",
+			filter:   []string{"service.k8s.aws/nlb", "metallb.io/metallb", ""},
+			expected: []*endpoint.Endpoint{aws, metallb, noClass, internal},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			kubernetes := fake.NewClientset()
+			for _, svc := range services {
+				_, err := kubernetes.CoreV1().Services(svc.Namespace).Create(context.Background(), svc, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
+			client, err := NewServiceSource(context.TODO(), kubernetes, "", "", "", false, "", true, false, false, []string{}, false,
+				labels.Everything(), false, false, false, tt.filter, false, informers.Options{})
+			require.NoError(t, err)
+
+			endpoints, err := client.Endpoints(context.Background())
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tt.expected)
+		})
+	}
+}
+
+func TestServiceSourceIncludeClusterIP(t *testing.T) {
+	t.Parallel()
+
+	svc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "internal",
+		},
+		Spec: v1.ServiceSpec{
+			Type:       v1.ServiceTypeClusterIP,
+			ClusterIP:  "10.0.0.1",
+			ClusterIPs: []string{"10.0.0.1", "fd00::1"},
+		},
+	}
+
+	for _, tt := range []struct {
+		name             string
+		publishInternal  bool
+		includeClusterIP bool
+		expected         []*endpoint.Endpoint
+	}{
+		{
+			name:     "ClusterIP services are not published by default",
+			expected: []*endpoint.Endpoint{},
+		},
+		{
+			name:            "publish internal services publishes the primary cluster IP",
+			publishInternal: true,
+			expected: []*endpoint.Endpoint{
+				{DNSName: "internal.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}},
+			},
+		},
+		{
+			name:             "include cluster ip publishes all the cluster IPs",
+			includeClusterIP: true,
+			expected: []*endpoint.Endpoint{
+				{DNSName: "internal.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}},
+				{DNSName: "internal.example.org", RecordType: endpoint.RecordTypeAAAA, Targets: endpoint.Targets{"fd00::1"}},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			kubernetes := fake.NewClientset()
+			_, err := kubernetes.CoreV1().Services(svc.Namespace).Create(context.Background(), svc, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			client, err := NewServiceSource(context.TODO(), kubernetes, "", "", "{{.Name}}.example.org", false, "", tt.publishInternal, false, false, []string{}, false,
+				labels.Everything(), false, false, false, nil, tt.includeClusterIP, informers.Options{})
+			require.NoError(t, err)
+
+			endpoints, err := client.Endpoints(context.Background())
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tt.expected)
+		})
+	}
+}
+
+func TestServiceSourceWeightedServicesSharingHostname(t *testing.T) {
+	t.Parallel()
+
+	newService := func(name, ip, weight string) *v1.Service {
+		return &v1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				Annotations: map[string]string{
+					hostnameAnnotationKey:    "web.example.org",
+					annotations.AWSWeightKey: weight,
+				},
+			},
+			Spec: v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer},
+			Status: v1.ServiceStatus{
+				LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: ip}}},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name     string
+		services []*v1.Service
+		expected []*endpoint.Endpoint
+	}{
+		{
+			name:     "different weights are separate records",
+			services: []*v1.Service{newService("blue", "1.1.1.1", "10"), newService("green", "2.2.2.2", "90")},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "1.1.1.1").
+					WithProviderSpecific(annotations.AWSWeightProperty, "10").
+					WithLabel(endpoint.ResourceLabelKey, "service/default/blue"),
+				endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "2.2.2.2").
+					WithProviderSpecific(annotations.AWSWeightProperty, "90").
+					WithLabel(endpoint.ResourceLabelKey, "service/default/green"),
+			},
+		},
+		{
+			name:     "same weights are merged",
+			services: []*v1.Service{newService("blue", "1.1.1.1", "50"), newService("green", "2.2.2.2", "50")},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("web.example.org", endpoint.RecordTypeA, "1.1.1.1", "2.2.2.2").
+					WithProviderSpecific(annotations.AWSWeightProperty, "50").
+					WithLabel(endpoint.ResourceLabelKey, "service/default/blue"),
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			kubernetes := fake.NewClientset()
+			for _, svc := range tt.services {
+				_, err := kubernetes.CoreV1().Services(svc.Namespace).Create(context.Background(), svc, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
+			client, err := NewServiceSource(context.TODO(), kubernetes, "", "", "", false, "", false, false, false, []string{}, false,
+				labels.Everything(), false, false, false, nil, false, informers.Options{})
+			require.NoError(t, err)
+
+			endpoints, err := client.Endpoints(context.Background())
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tt.expected)
+		})
+	}
+}
+
+func TestServiceSourceRequireReady(t *testing.T) {
+	t.Parallel()
+
+	newService := func(name, ip string, requireReady bool) *v1.Service {
+		svc := &v1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        name,
+				Annotations: map[string]string{hostnameAnnotationKey: name + ".example.org"},
+			},
+			Spec: v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer},
+			Status: v1.ServiceStatus{
+				LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: ip}}},
+			},
+		}
+		if requireReady {
+			svc.Annotations[annotations.RequireReadyKey] = "true"
+		}
+		return svc
+	}
+	newEndpoints := func(name string, ready bool) *v1.Endpoints {
+		subset := v1.EndpointSubset{}
+		if ready {
+			subset.Addresses = []v1.EndpointAddress{{IP: "10.0.0.1"}}
+		} else {
+			subset.NotReadyAddresses = []v1.EndpointAddress{{IP: "10.0.0.1"}}
+		}
+		return &v1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Subsets:    []v1.EndpointSubset{subset},
+		}
+	}
+
+	for _, tt := range []struct {
+		name      string
+		service   *v1.Service
+		endpoints *v1.Endpoints
+		expected  []*endpoint.Endpoint
+	}{
+		{
+			name:      "annotation with ready endpoints is included",
+			service:   newService("ready", "1.1.1.1", true),
+			endpoints: newEndpoints("ready", true),
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("ready.example.org", endpoint.RecordTypeA, "1.1.1.1").
+					WithLabel(endpoint.ResourceLabelKey, "service/default/ready"),
+			},
+		},
+		{
+			name:      "annotation without ready endpoints is excluded",
+			service:   newService("not-ready", "2.2.2.2", true),
+			endpoints: newEndpoints("not-ready", false),
+			expected:  []*endpoint.Endpoint{},
+		},
+		{
+			name:     "annotation without endpoints object is excluded",
+			service:  newService("missing", "3.3.3.3", true),
+			expected: []*endpoint.Endpoint{},
+		},
+		{
+			name:      "no annotation without ready endpoints is included",
+			service:   newService("no-annotation", "4.4.4.4", false),
+			endpoints: newEndpoints("no-annotation", false),
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("no-annotation.example.org", endpoint.RecordTypeA, "4.4.4.4").
+					WithLabel(endpoint.ResourceLabelKey, "service/default/no-annotation"),
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			kubernetes := fake.NewClientset()
+			_, err := kubernetes.CoreV1().Services(tt.service.Namespace).Create(context.Background(), tt.service, metav1.CreateOptions{})
+			require.NoError(t, err)
+			if tt.endpoints != nil {
+				_, err = kubernetes.CoreV1().Endpoints(tt.endpoints.Namespace).Create(context.Background(), tt.endpoints, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
+			client, err := NewServiceSource(context.TODO(), kubernetes, "", "", "", false, "", false, false, false, []string{}, false,
+				labels.Everything(), false, false, false, nil, false, informers.Options{})
+			require.NoError(t, err)
+
+			endpoints, err := client.Endpoints(context.Background())
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tt.expected)
+		})
+	}
+}
+
+func TestServiceSourceWildcard(t *testing.T) {
+	t.Parallel()
+
+	svc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "apps",
+			Annotations: map[string]string{
+				hostnameAnnotationKey:         "apps.example.org,*.web.example.org,foo.*.example.org",
+				internalHostnameAnnotationKey: "apps.internal.example.org",
+				annotations.WildcardKey:       "true",
+			},
+		},
+		Spec: v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer, ClusterIP: "10.0.0.1"},
+		Status: v1.ServiceStatus{
+			LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: "1.2.3.4"}}},
+		},
+	}
+
+	kubernetes := fake.NewClientset()
+	_, err := kubernetes.CoreV1().Services(svc.Namespace).Create(context.Background(), svc, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	client, err := NewServiceSource(context.TODO(), kubernetes, "", "", "", false, "", false, false, false, []string{}, false,
+		labels.Everything(), false, false, false, nil, false, informers.Options{})
+	require.NoError(t, err)
+
+	endpoints, err := client.Endpoints(context.Background())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("*.apps.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "service/default/apps"),
+		endpoint.NewEndpoint("*.web.example.org", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "service/default/apps"),
+		endpoint.NewEndpoint("*.apps.internal.example.org", endpoint.RecordTypeA, "10.0.0.1").WithLabel(endpoint.ResourceLabelKey, "service/default/apps"),
+	})
+}
+
+func TestServiceSourceFqdnTemplateLabels(t *testing.T) {
+	t.Parallel()
+
+	newService := func(name string, labels map[string]string, ip string) *v1.Service {
+		return &v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Labels: labels},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer},
+			Status: v1.ServiceStatus{
+				LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: ip}}},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name     string
+		service  *v1.Service
+		expected []*endpoint.Endpoint
+	}{
+		{
+			name:    "label present",
+			service: newService("web", map[string]string{"team": "payments"}, "1.2.3.4"),
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("payments.web.example.com", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "service/default/web"),
+			},
+		},
+		{
+			name:     "label absent",
+			service:  newService("web", map[string]string{"app": "web"}, "1.2.3.4"),
+			expected: []*endpoint.Endpoint{},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			kubernetes := fake.NewClientset()
+			_, err := kubernetes.CoreV1().Services(tt.service.Namespace).Create(context.Background(), tt.service, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			client, err := NewServiceSource(context.TODO(), kubernetes, "", "", `{{ index .Labels "team" }}.{{ .Name }}.example.com`, false, "", false, false, false, []string{}, false,
+				labels.Everything(), false, false, false, nil, false, informers.Options{})
+			require.NoError(t, err)
+
+			endpoints, err := client.Endpoints(context.Background())
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tt.expected)
+		})
+	}
+
+	t.Run("template syntax error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewServiceSource(context.TODO(), fake.NewClientset(), "", "", `{{ index .Labels "team" }.example.com`, false, "", false, false, false, []string{}, false,
+			labels.Everything(), false, false, false, nil, false, informers.Options{})
+		require.Error(t, err)
+	})
+}
+
+func TestServiceSourceResync(t *testing.T) {
+	kubernetes := fake.NewClientset()
+	_, err := kubernetes.CoreV1().Services("default").Create(context.Background(), &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+		Spec:       v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer},
+		Status: v1.ServiceStatus{
+			LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: "1.2.3.4"}}},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	client, err := NewServiceSource(context.TODO(), kubernetes, "", "", "{{.Name}}.example.com", false, "", false, false, false, []string{}, false,
+		labels.Everything(), false, false, false, nil, false, informers.Options{ResyncPeriod: 50 * time.Millisecond})
+	require.NoError(t, err)
+
+	var events atomic.Int32
+	client.AddEventHandler(context.Background(), func() { events.Add(1) })
+	assert.Eventually(t, func() bool { return events.Load() >= 3 }, 5*time.Second, 10*time.Millisecond,
+		"the event handler should be called on each resync although the service is unchanged")
+
+	endpoints, err := client.Endpoints(context.Background())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("web.example.com", endpoint.RecordTypeA, "1.2.3.4").WithLabel(endpoint.ResourceLabelKey, "service/default/web"),
+	})
+}
@@ -50,6 +50,12 @@ type Source interface {
 	AddEventHandler(context.Context, func())
 }
 
+// SyncReporter is implemented by sources which report the result of a synchronization back to their objects.
+type SyncReporter interface {
+	// ReportSync is called after every synchronization, err is nil when it succeeded
+	ReportSync(ctx context.Context, err error)
+}
+
 type kubeObject interface {
 	runtime.Object
 	metav1.Object
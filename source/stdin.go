@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// stdinSource is an implementation of Source that provides endpoints read from a stream of
+// JSON objects, one per line, e.g. the standard input of a pipeline. The stream is read until
+// its end on the first call, the following calls return the same endpoints.
+type stdinSource struct {
+	reader io.Reader
+
+	once      sync.Once
+	endpoints []*endpoint.Endpoint
+	err       error
+}
+
+// NewStdinSource creates a new stdinSource reading the given stream.
+func NewStdinSource(reader io.Reader) (Source, error) {
+	return &stdinSource{reader: reader}, nil
+}
+
+func (ss *stdinSource) read() {
+	endpoints := []*endpoint.Endpoint{}
+	decoder := json.NewDecoder(ss.reader)
+	for {
+		ep := &endpoint.Endpoint{}
+		err := decoder.Decode(ep)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			ss.err = fmt.Errorf("stdin source: decoding endpoint %d: %w", len(endpoints), err)
+			return
+		}
+		if ep.DNSName == "" {
+			ss.err = fmt.Errorf("stdin source: the endpoint %d has no dnsName", len(endpoints))
+			return
+		}
+		if ep.Labels == nil {
+			ep.Labels = endpoint.NewLabels()
+		}
+		endpoints = append(endpoints, ep)
+	}
+	log.Debugf("Stdin source read %d endpoints", len(endpoints))
+	ss.endpoints = endpoints
+}
+
+// Endpoints returns the endpoints read from the stream.
+func (ss *stdinSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+	ss.once.Do(ss.read)
+	if ss.err != nil {
+		return nil, ss.err
+	}
+	copied := make([]*endpoint.Endpoint, 0, len(ss.endpoints))
+	for _, ep := range ss.endpoints {
+		copied = append(copied, ep.DeepCopy())
+	}
+	return copied, nil
+}
+
+func (ss *stdinSource) AddEventHandler(_ context.Context, _ func()) {
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestStdinSource(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []*endpoint.Endpoint
+		err      string
+	}{
+		{
+			name: "endpoints are read",
+			input: `{"dnsName":"foo.example.com","targets":["1.2.3.4"],"recordType":"A","recordTTL":300}
+{"dnsName":"bar.example.com","targets":["foo.example.com"],"recordType":"CNAME","labels":{"team":"dns"}}
+`,
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("foo.example.com", endpoint.RecordTypeA, 300, "1.2.3.4"),
+				endpoint.NewEndpoint("bar.example.com", endpoint.RecordTypeCNAME, "foo.example.com").WithLabel("team", "dns"),
+			},
+		},
+		{
+			name:     "empty input",
+			input:    "",
+			expected: []*endpoint.Endpoint{},
+		},
+		{
+			name:  "invalid line",
+			input: `{"dnsName":"foo.example.com","targets":["1.2.3.4"],"recordType":"A"}` + "\nnot json\n",
+			err:   "stdin source: decoding endpoint 1",
+		},
+		{
+			name:  "missing dnsName",
+			input: `{"targets":["1.2.3.4"],"recordType":"A"}`,
+			err:   "stdin source: the endpoint 0 has no dnsName",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, err := NewStdinSource(strings.NewReader(tt.input))
+			require.NoError(t, err)
+
+			endpoints, err := src.Endpoints(context.Background())
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tt.expected)
+		})
+	}
+}
+
+func TestStdinSourceReadsOnce(t *testing.T) {
+	src, err := NewStdinSource(strings.NewReader(`{"dnsName":"foo.example.com","targets":["1.2.3.4"],"recordType":"A"}`))
+	require.NoError(t, err)
+
+	for range 2 {
+		endpoints, err := src.Endpoints(context.Background())
+		require.NoError(t, err)
+		validateEndpoints(t, endpoints, []*endpoint.Endpoint{endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4")})
+		endpoints[0].Targets = endpoint.Targets{"5.6.7.8"}
+	}
+}
@@ -18,6 +18,7 @@ package source
 
 import (
 	"context"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"net/http"
@@ -36,9 +37,12 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/tools/record"
 	gateway "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/source/informers"
 )
 
 // ErrSourceNotFound is returned when a requested source doesn't exist.
@@ -56,6 +60,8 @@ type Config struct {
 	IgnoreNonHostNetworkPods       bool
 	IgnoreIngressTLSSpec           bool
 	IgnoreIngressRulesSpec         bool
+	InheritServiceAnnotations      bool
+	UpdateIngressStatus            bool
 	ListenEndpointEvents           bool
 	GatewayName                    string
 	GatewayNamespace               string
@@ -63,14 +69,21 @@ type Config struct {
 	Compatibility                  string
 	PodSourceDomain                string
 	PublishInternal                bool
+	IncludeClusterIP               bool
 	PublishHostIP                  bool
 	AlwaysPublishNotReadyAddresses bool
 	ConnectorServer                string
+	WebhookSourceURL               string
+	SourceFile                     string
+	FederationKubeConfigs          []string
+	FederationSource               string
 	CRDSourceAPIVersion            string
 	CRDSourceKind                  string
 	KubeConfig                     string
 	APIServerURL                   string
+	RestConfigOptions              RestConfigOptions
 	ServiceTypeFilter              []string
+	LoadBalancerClassFilter        []string
 	CFAPIEndpoint                  string
 	CFUsername                     string
 	CFPassword                     string
@@ -85,6 +98,11 @@ type Config struct {
 	TraefikDisableNew              bool
 	ExcludeUnschedulable           bool
 	ExposeInternalIPv6             bool
+	CRDSourceFinalizer             bool
+	ResyncPeriod                   time.Duration
+	WatchBackoffMaxDelay           time.Duration
+	// EventRecorder records the Kubernetes events of the sources, nil disables them
+	EventRecorder record.EventRecorder
 }
 
 func NewSourceConfig(cfg *externaldns.Config) *Config {
@@ -101,6 +119,8 @@ func NewSourceConfig(cfg *externaldns.Config) *Config {
 		IgnoreNonHostNetworkPods:       cfg.IgnoreNonHostNetworkPods,
 		IgnoreIngressTLSSpec:           cfg.IgnoreIngressTLSSpec,
 		IgnoreIngressRulesSpec:         cfg.IgnoreIngressRulesSpec,
+		InheritServiceAnnotations:      cfg.InheritServiceAnnotations,
+		UpdateIngressStatus:            cfg.UpdateIngressStatus,
 		ListenEndpointEvents:           cfg.ListenEndpointEvents,
 		GatewayName:                    cfg.GatewayName,
 		GatewayNamespace:               cfg.GatewayNamespace,
@@ -108,14 +128,21 @@ func NewSourceConfig(cfg *externaldns.Config) *Config {
 		Compatibility:                  cfg.Compatibility,
 		PodSourceDomain:                cfg.PodSourceDomain,
 		PublishInternal:                cfg.PublishInternal,
+		IncludeClusterIP:               cfg.IncludeClusterIP,
 		PublishHostIP:                  cfg.PublishHostIP,
 		AlwaysPublishNotReadyAddresses: cfg.AlwaysPublishNotReadyAddresses,
 		ConnectorServer:                cfg.ConnectorSourceServer,
+		WebhookSourceURL:               cfg.WebhookSourceURL,
+		SourceFile:                     cfg.SourceFile,
+		FederationKubeConfigs:          externaldns.SplitCommaSeparated([]string{cfg.FederationKubeConfig}, false),
+		FederationSource:               cfg.FederationSource,
 		CRDSourceAPIVersion:            cfg.CRDSourceAPIVersion,
 		CRDSourceKind:                  cfg.CRDSourceKind,
 		KubeConfig:                     cfg.KubeConfig,
 		APIServerURL:                   cfg.APIServerURL,
+		RestConfigOptions:              newRestConfigOptions(cfg),
 		ServiceTypeFilter:              cfg.ServiceTypeFilter,
+		LoadBalancerClassFilter:        externaldns.SplitCommaSeparated(cfg.LoadBalancerClassFilter, true),
 		CFAPIEndpoint:                  cfg.CFAPIEndpoint,
 		CFUsername:                     cfg.CFUsername,
 		CFPassword:                     cfg.CFPassword,
@@ -130,6 +157,28 @@ func NewSourceConfig(cfg *externaldns.Config) *Config {
 		TraefikDisableNew:              cfg.TraefikDisableNew,
 		ExcludeUnschedulable:           cfg.ExcludeUnschedulable,
 		ExposeInternalIPv6:             cfg.ExposeInternalIPV6,
+		ResyncPeriod:                   cfg.ResyncPeriod,
+		WatchBackoffMaxDelay:           cfg.WatchBackoffMaxDelay,
+		// The finalizer is only removed after a synchronization, which never happens in dry-run mode.
+		CRDSourceFinalizer: cfg.CRDSourceFinalizer && !cfg.DryRun,
+	}
+}
+
+// informerOptions returns the options of the informers of the sources.
+func (cfg *Config) informerOptions() informers.Options {
+	return informers.Options{
+		ResyncPeriod:         cfg.ResyncPeriod,
+		WatchBackoffMaxDelay: cfg.WatchBackoffMaxDelay,
+	}
+}
+
+func newRestConfigOptions(cfg *externaldns.Config) RestConfigOptions {
+	return RestConfigOptions{
+		SkipTLSVerify:     cfg.KubeSkipTLSVerify,
+		CAFile:            cfg.KubeCAFile,
+		Context:           cfg.KubeContext,
+		ImpersonateUser:   cfg.KubeImpersonateUser,
+		ImpersonateGroups: cfg.KubeImpersonateGroups,
 	}
 }
 
@@ -146,28 +195,29 @@ type ClientGenerator interface {
 // SingletonClientGenerator stores provider clients and guarantees that only one instance of client
 // will be generated
 type SingletonClientGenerator struct {
-	KubeConfig      string
-	APIServerURL    string
-	RequestTimeout  time.Duration
-	kubeClient      kubernetes.Interface
-	gatewayClient   gateway.Interface
-	istioClient     *istioclient.Clientset
-	cfClient        *cfclient.Client
-	dynKubeClient   dynamic.Interface
-	openshiftClient openshift.Interface
-	kubeOnce        sync.Once
-	gatewayOnce     sync.Once
-	istioOnce       sync.Once
-	cfOnce          sync.Once
-	dynCliOnce      sync.Once
-	openshiftOnce   sync.Once
+	KubeConfig        string
+	APIServerURL      string
+	RestConfigOptions RestConfigOptions
+	RequestTimeout    time.Duration
+	kubeClient        kubernetes.Interface
+	gatewayClient     gateway.Interface
+	istioClient       *istioclient.Clientset
+	cfClient          *cfclient.Client
+	dynKubeClient     dynamic.Interface
+	openshiftClient   openshift.Interface
+	kubeOnce          sync.Once
+	gatewayOnce       sync.Once
+	istioOnce         sync.Once
+	cfOnce            sync.Once
+	dynCliOnce        sync.Once
+	openshiftOnce     sync.Once
 }
 
 // KubeClient generates a kube client if it was not created before
 func (p *SingletonClientGenerator) KubeClient() (kubernetes.Interface, error) {
 	var err error
 	p.kubeOnce.Do(func() {
-		p.kubeClient, err = NewKubeClient(p.KubeConfig, p.APIServerURL, p.RequestTimeout)
+		p.kubeClient, err = NewKubeClient(p.KubeConfig, p.APIServerURL, p.RequestTimeout, p.RestConfigOptions)
 	})
 	return p.kubeClient, err
 }
@@ -176,13 +226,13 @@ func (p *SingletonClientGenerator) KubeClient() (kubernetes.Interface, error) {
 func (p *SingletonClientGenerator) GatewayClient() (gateway.Interface, error) {
 	var err error
 	p.gatewayOnce.Do(func() {
-		p.gatewayClient, err = newGatewayClient(p.KubeConfig, p.APIServerURL, p.RequestTimeout)
+		p.gatewayClient, err = newGatewayClient(p.KubeConfig, p.APIServerURL, p.RequestTimeout, p.RestConfigOptions)
 	})
 	return p.gatewayClient, err
 }
 
-func newGatewayClient(kubeConfig, apiServerURL string, requestTimeout time.Duration) (gateway.Interface, error) {
-	config, err := instrumentedRESTConfig(kubeConfig, apiServerURL, requestTimeout)
+func newGatewayClient(kubeConfig, apiServerURL string, requestTimeout time.Duration, opts RestConfigOptions) (gateway.Interface, error) {
+	config, err := instrumentedRESTConfig(kubeConfig, apiServerURL, requestTimeout, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -198,7 +248,7 @@ func newGatewayClient(kubeConfig, apiServerURL string, requestTimeout time.Durat
 func (p *SingletonClientGenerator) IstioClient() (istioclient.Interface, error) {
 	var err error
 	p.istioOnce.Do(func() {
-		p.istioClient, err = NewIstioClient(p.KubeConfig, p.APIServerURL)
+		p.istioClient, err = NewIstioClient(p.KubeConfig, p.APIServerURL, p.RestConfigOptions)
 	})
 	return p.istioClient, err
 }
@@ -231,7 +281,7 @@ func NewCFClient(cfAPIEndpoint string, cfUsername string, cfPassword string) (*c
 func (p *SingletonClientGenerator) DynamicKubernetesClient() (dynamic.Interface, error) {
 	var err error
 	p.dynCliOnce.Do(func() {
-		p.dynKubeClient, err = NewDynamicKubernetesClient(p.KubeConfig, p.APIServerURL, p.RequestTimeout)
+		p.dynKubeClient, err = NewDynamicKubernetesClient(p.KubeConfig, p.APIServerURL, p.RequestTimeout, p.RestConfigOptions)
 	})
 	return p.dynKubeClient, err
 }
@@ -240,7 +290,7 @@ func (p *SingletonClientGenerator) DynamicKubernetesClient() (dynamic.Interface,
 func (p *SingletonClientGenerator) OpenShiftClient() (openshift.Interface, error) {
 	var err error
 	p.openshiftOnce.Do(func() {
-		p.openshiftClient, err = NewOpenShiftClient(p.KubeConfig, p.APIServerURL, p.RequestTimeout)
+		p.openshiftClient, err = NewOpenShiftClient(p.KubeConfig, p.APIServerURL, p.RequestTimeout, p.RestConfigOptions)
 	})
 	return p.openshiftClient, err
 }
@@ -267,25 +317,31 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		if err != nil {
 			return nil, err
 		}
-		return NewNodeSource(ctx, client, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.LabelFilter, cfg.ExposeInternalIPv6, cfg.ExcludeUnschedulable)
+		return NewNodeSource(ctx, client, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.LabelFilter, cfg.ExposeInternalIPv6, cfg.ExcludeUnschedulable, cfg.informerOptions())
 	case "service":
 		client, err := p.KubeClient()
 		if err != nil {
 			return nil, err
 		}
-		return NewServiceSource(ctx, client, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.Compatibility, cfg.PublishInternal, cfg.PublishHostIP, cfg.AlwaysPublishNotReadyAddresses, cfg.ServiceTypeFilter, cfg.IgnoreHostnameAnnotation, cfg.LabelFilter, cfg.ResolveLoadBalancerHostname, cfg.ListenEndpointEvents, cfg.ExposeInternalIPv6)
+		return NewServiceSource(ctx, client, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.Compatibility, cfg.PublishInternal, cfg.PublishHostIP, cfg.AlwaysPublishNotReadyAddresses, cfg.ServiceTypeFilter, cfg.IgnoreHostnameAnnotation, cfg.LabelFilter, cfg.ResolveLoadBalancerHostname, cfg.ListenEndpointEvents, cfg.ExposeInternalIPv6, cfg.LoadBalancerClassFilter, cfg.IncludeClusterIP, cfg.informerOptions())
+	case "namespace":
+		client, err := p.KubeClient()
+		if err != nil {
+			return nil, err
+		}
+		return NewNamespaceSource(ctx, client, cfg.Namespace, cfg.AnnotationFilter, cfg.PublishInternal, cfg.PublishHostIP, cfg.AlwaysPublishNotReadyAddresses, cfg.ServiceTypeFilter, cfg.LabelFilter, cfg.ResolveLoadBalancerHostname, cfg.ExposeInternalIPv6, cfg.LoadBalancerClassFilter, cfg.IncludeClusterIP, cfg.informerOptions())
 	case "ingress":
 		client, err := p.KubeClient()
 		if err != nil {
 			return nil, err
 		}
-		return NewIngressSource(ctx, client, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation, cfg.IgnoreIngressTLSSpec, cfg.IgnoreIngressRulesSpec, cfg.LabelFilter, cfg.IngressClassNames)
+		return NewIngressSource(ctx, client, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation, cfg.IgnoreIngressTLSSpec, cfg.IgnoreIngressRulesSpec, cfg.LabelFilter, cfg.IngressClassNames, cfg.InheritServiceAnnotations, cfg.UpdateIngressStatus, cfg.informerOptions())
 	case "pod":
 		client, err := p.KubeClient()
 		if err != nil {
 			return nil, err
 		}
-		return NewPodSource(ctx, client, cfg.Namespace, cfg.Compatibility, cfg.IgnoreNonHostNetworkPods, cfg.PodSourceDomain)
+		return NewPodSource(ctx, client, cfg.Namespace, cfg.Compatibility, cfg.IgnoreNonHostNetworkPods, cfg.PodSourceDomain, cfg.informerOptions())
 	case "gateway-httproute":
 		return NewGatewayHTTPRouteSource(p, cfg)
 	case "gateway-grpcroute":
@@ -305,7 +361,7 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		if err != nil {
 			return nil, err
 		}
-		return NewIstioGatewaySource(ctx, kubernetesClient, istioClient, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation)
+		return NewIstioGatewaySource(ctx, kubernetesClient, istioClient, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation, cfg.informerOptions())
 	case "istio-virtualservice":
 		kubernetesClient, err := p.KubeClient()
 		if err != nil {
@@ -315,7 +371,7 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		if err != nil {
 			return nil, err
 		}
-		return NewIstioVirtualServiceSource(ctx, kubernetesClient, istioClient, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation)
+		return NewIstioVirtualServiceSource(ctx, kubernetesClient, istioClient, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation, cfg.informerOptions())
 	case "cloudfoundry":
 		cfClient, err := p.CloudFoundryClient(cfg.CFAPIEndpoint, cfg.CFUsername, cfg.CFPassword)
 		if err != nil {
@@ -331,13 +387,13 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		if err != nil {
 			return nil, err
 		}
-		return NewAmbassadorHostSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter, cfg.LabelFilter)
+		return NewAmbassadorHostSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter, cfg.LabelFilter, cfg.informerOptions())
 	case "contour-httpproxy":
 		dynamicClient, err := p.DynamicKubernetesClient()
 		if err != nil {
 			return nil, err
 		}
-		return NewContourHTTPProxySource(ctx, dynamicClient, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation)
+		return NewContourHTTPProxySource(ctx, dynamicClient, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation, cfg.informerOptions())
 	case "gloo-proxy":
 		kubernetesClient, err := p.KubeClient()
 		if err != nil {
@@ -357,32 +413,65 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		if err != nil {
 			return nil, err
 		}
-		return NewTraefikSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter, cfg.IgnoreHostnameAnnotation, cfg.TraefikDisableLegacy, cfg.TraefikDisableNew)
+		return NewTraefikSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter, cfg.IgnoreHostnameAnnotation, cfg.TraefikDisableLegacy, cfg.TraefikDisableNew, cfg.informerOptions())
 	case "openshift-route":
 		ocpClient, err := p.OpenShiftClient()
 		if err != nil {
 			return nil, err
 		}
-		return NewOcpRouteSource(ctx, ocpClient, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation, cfg.LabelFilter, cfg.OCPRouterName)
+		return NewOcpRouteSource(ctx, ocpClient, cfg.Namespace, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.CombineFQDNAndAnnotation, cfg.IgnoreHostnameAnnotation, cfg.LabelFilter, cfg.OCPRouterName, cfg.informerOptions())
 	case "fake":
 		return NewFakeSource(cfg.FQDNTemplate)
 	case "connector":
 		return NewConnectorSource(cfg.ConnectorServer)
+	case "webhook":
+		return NewWebhookSource(cfg.WebhookSourceURL, cfg.RequestTimeout)
+	case "file":
+		return NewFileSource(ctx, cfg.SourceFile)
+	case "stdin":
+		return NewStdinSource(os.Stdin)
+	case "federation":
+		clusters := make(map[string]ClientGenerator, len(cfg.FederationKubeConfigs))
+		for _, kubeConfig := range cfg.FederationKubeConfigs {
+			clusters[kubeConfig] = &SingletonClientGenerator{
+				KubeConfig:     kubeConfig,
+				RequestTimeout: cfg.RequestTimeout,
+			}
+		}
+		return NewFederationSource(ctx, cfg.FederationSource, clusters, cfg)
 	case "crd":
 		client, err := p.KubeClient()
 		if err != nil {
 			return nil, err
 		}
-		crdClient, scheme, err := NewCRDClientForAPIVersionKind(client, cfg.KubeConfig, cfg.APIServerURL, cfg.CRDSourceAPIVersion, cfg.CRDSourceKind)
+		crdClient, scheme, err := NewCRDClientForAPIVersionKind(client, cfg.KubeConfig, cfg.APIServerURL, cfg.RestConfigOptions, cfg.CRDSourceAPIVersion, cfg.CRDSourceKind)
+		if err != nil {
+			return nil, err
+		}
+		return NewCRDSource(crdClient, cfg.Namespace, cfg.CRDSourceKind, cfg.AnnotationFilter, cfg.LabelFilter, scheme, cfg.UpdateEvents, cfg.CRDSourceFinalizer, cfg.FQDNTemplate, cfg.EventRecorder, cfg.informerOptions())
+	case "argocd-application":
+		dynamicClient, err := p.DynamicKubernetesClient()
+		if err != nil {
+			return nil, err
+		}
+		return NewArgoCDApplicationSource(ctx, dynamicClient, cfg.Namespace, cfg.AnnotationFilter, cfg.LabelFilter, cfg.IgnoreHostnameAnnotation, cfg.informerOptions())
+	case "flux-helmrelease":
+		dynamicClient, err := p.DynamicKubernetesClient()
+		if err != nil {
+			return nil, err
+		}
+		return NewFluxHelmReleaseSource(ctx, dynamicClient, cfg.Namespace, cfg.AnnotationFilter, cfg.LabelFilter, cfg.IgnoreHostnameAnnotation, cfg.informerOptions())
+	case "dnsrecord":
+		dynamicClient, err := p.DynamicKubernetesClient()
 		if err != nil {
 			return nil, err
 		}
-		return NewCRDSource(crdClient, cfg.Namespace, cfg.CRDSourceKind, cfg.AnnotationFilter, cfg.LabelFilter, scheme, cfg.UpdateEvents)
+		return NewDNSRecordSource(ctx, dynamicClient, cfg.Namespace, cfg.AnnotationFilter, cfg.LabelFilter, cfg.informerOptions())
 	case "skipper-routegroup":
 		apiServerURL := cfg.APIServerURL
 		tokenPath := ""
 		token := ""
-		restConfig, err := GetRestConfig(cfg.KubeConfig, cfg.APIServerURL)
+		restConfig, err := GetRestConfig(cfg.KubeConfig, cfg.APIServerURL, cfg.RestConfigOptions)
 		if err == nil {
 			apiServerURL = restConfig.Host
 			tokenPath = restConfig.BearerTokenFile
@@ -398,7 +487,7 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		if err != nil {
 			return nil, err
 		}
-		return NewKongTCPIngressSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter, cfg.IgnoreHostnameAnnotation)
+		return NewKongTCPIngressSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter, cfg.IgnoreHostnameAnnotation, cfg.informerOptions())
 	case "f5-virtualserver":
 		kubernetesClient, err := p.KubeClient()
 		if err != nil {
@@ -408,7 +497,7 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		if err != nil {
 			return nil, err
 		}
-		return NewF5VirtualServerSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter)
+		return NewF5VirtualServerSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter, cfg.informerOptions())
 	case "f5-transportserver":
 		kubernetesClient, err := p.KubeClient()
 		if err != nil {
@@ -418,14 +507,14 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		if err != nil {
 			return nil, err
 		}
-		return NewF5TransportServerSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter)
+		return NewF5TransportServerSource(ctx, dynamicClient, kubernetesClient, cfg.Namespace, cfg.AnnotationFilter, cfg.informerOptions())
 	}
 
 	return nil, ErrSourceNotFound
 }
 
-func instrumentedRESTConfig(kubeConfig, apiServerURL string, requestTimeout time.Duration) (*rest.Config, error) {
-	config, err := GetRestConfig(kubeConfig, apiServerURL)
+func instrumentedRESTConfig(kubeConfig, apiServerURL string, requestTimeout time.Duration, opts RestConfigOptions) (*rest.Config, error) {
+	config, err := GetRestConfig(kubeConfig, apiServerURL, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -441,9 +530,60 @@ func instrumentedRESTConfig(kubeConfig, apiServerURL string, requestTimeout time
 	return config, nil
 }
 
+// RestConfigOptions holds the options of the connection to the Kubernetes API server
+// which are not part of the kubeconfig.
+type RestConfigOptions struct {
+	// SkipTLSVerify disables the verification of the API server certificate
+	SkipTLSVerify bool
+	// CAFile is a PEM bundle of CAs trusted in addition to the one of the kubeconfig or service account
+	CAFile string
+	// Context is the kubeconfig context to use instead of the current one
+	Context string
+	// ImpersonateUser is the user, or service account, the API requests are made as
+	ImpersonateUser string
+	// ImpersonateGroups are the groups the API requests are made as, along with ImpersonateUser
+	ImpersonateGroups []string
+}
+
+// apply sets the TLS options on the rest config.
+func (o RestConfigOptions) apply(config *rest.Config) error {
+	if o.CAFile != "" {
+		ca, err := os.ReadFile(o.CAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read the Kubernetes CA file: %w", err)
+		}
+		if !x509.NewCertPool().AppendCertsFromPEM(ca) {
+			return fmt.Errorf("no PEM encoded certificate found in the Kubernetes CA file %s", o.CAFile)
+		}
+		caData := config.TLSClientConfig.CAData
+		if len(caData) == 0 && config.TLSClientConfig.CAFile != "" {
+			if caData, err = os.ReadFile(config.TLSClientConfig.CAFile); err != nil {
+				return fmt.Errorf("failed to read the Kubernetes CA file: %w", err)
+			}
+		}
+		config.TLSClientConfig.CAFile = ""
+		config.TLSClientConfig.CAData = append(append(caData, '\n'), ca...)
+	}
+	if o.SkipTLSVerify {
+		log.Warn("The certificate of the Kubernetes API server is not verified")
+		// client-go refuses root certificates together with the insecure flag
+		config.TLSClientConfig.Insecure = true
+		config.TLSClientConfig.CAFile = ""
+		config.TLSClientConfig.CAData = nil
+	}
+	if o.ImpersonateUser != "" {
+		log.Infof("Impersonating the user %s and the groups %v in the requests to the Kubernetes API server", o.ImpersonateUser, o.ImpersonateGroups)
+		config.Impersonate = rest.ImpersonationConfig{
+			UserName: o.ImpersonateUser,
+			Groups:   o.ImpersonateGroups,
+		}
+	}
+	return nil
+}
+
 // GetRestConfig returns the rest clients config to get automatically
 // data if you run inside a cluster or by passing flags.
-func GetRestConfig(kubeConfig, apiServerURL string) (*rest.Config, error) {
+func GetRestConfig(kubeConfig, apiServerURL string, opts RestConfigOptions) (*rest.Config, error) {
 	if kubeConfig == "" {
 		if _, err := os.Stat(clientcmd.RecommendedHomeFile); err == nil {
 			kubeConfig = clientcmd.RecommendedHomeFile
@@ -457,16 +597,25 @@ func GetRestConfig(kubeConfig, apiServerURL string) (*rest.Config, error) {
 		config *rest.Config
 		err    error
 	)
-	if kubeConfig == "" {
+	switch {
+	case kubeConfig == "" && opts.Context != "":
+		return nil, fmt.Errorf("no kubeconfig found to select the context %s from", opts.Context)
+	case kubeConfig == "":
 		log.Infof("Using inCluster-config based on serviceaccount-token")
 		config, err = rest.InClusterConfig()
-	} else {
+	default:
 		log.Infof("Using kubeConfig")
-		config, err = clientcmd.BuildConfigFromFlags(apiServerURL, kubeConfig)
+		config, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+			&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeConfig},
+			&clientcmd.ConfigOverrides{CurrentContext: opts.Context, ClusterInfo: clientcmdapi.Cluster{Server: apiServerURL}},
+		).ClientConfig()
 	}
 	if err != nil {
 		return nil, err
 	}
+	if err := opts.apply(config); err != nil {
+		return nil, err
+	}
 
 	return config, nil
 }
@@ -474,9 +623,9 @@ func GetRestConfig(kubeConfig, apiServerURL string) (*rest.Config, error) {
 // NewKubeClient returns a new Kubernetes client object. It takes a Config and
 // uses APIServerURL and KubeConfig attributes to connect to the cluster. If
 // KubeConfig isn't provided it defaults to using the recommended default.
-func NewKubeClient(kubeConfig, apiServerURL string, requestTimeout time.Duration) (*kubernetes.Clientset, error) {
+func NewKubeClient(kubeConfig, apiServerURL string, requestTimeout time.Duration, opts RestConfigOptions) (*kubernetes.Clientset, error) {
 	log.Infof("Instantiating new Kubernetes client")
-	config, err := instrumentedRESTConfig(kubeConfig, apiServerURL, requestTimeout)
+	config, err := instrumentedRESTConfig(kubeConfig, apiServerURL, requestTimeout, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -496,14 +645,8 @@ func NewKubeClient(kubeConfig, apiServerURL string, requestTimeout time.Duration
 // wrappers) to the client's config at this level. Furthermore, the Istio client
 // constructor does not expose the ability to override the Kubernetes API server endpoint,
 // so the apiServerURL config attribute has no effect.
-func NewIstioClient(kubeConfig string, apiServerURL string) (*istioclient.Clientset, error) {
-	if kubeConfig == "" {
-		if _, err := os.Stat(clientcmd.RecommendedHomeFile); err == nil {
-			kubeConfig = clientcmd.RecommendedHomeFile
-		}
-	}
-
-	restCfg, err := clientcmd.BuildConfigFromFlags(apiServerURL, kubeConfig)
+func NewIstioClient(kubeConfig string, apiServerURL string, opts RestConfigOptions) (*istioclient.Clientset, error) {
+	restCfg, err := GetRestConfig(kubeConfig, apiServerURL, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -519,8 +662,8 @@ func NewIstioClient(kubeConfig string, apiServerURL string) (*istioclient.Client
 // NewDynamicKubernetesClient returns a new Dynamic Kubernetes client object. It takes a Config and
 // uses APIServerURL and KubeConfig attributes to connect to the cluster. If
 // KubeConfig isn't provided it defaults to using the recommended default.
-func NewDynamicKubernetesClient(kubeConfig, apiServerURL string, requestTimeout time.Duration) (dynamic.Interface, error) {
-	config, err := instrumentedRESTConfig(kubeConfig, apiServerURL, requestTimeout)
+func NewDynamicKubernetesClient(kubeConfig, apiServerURL string, requestTimeout time.Duration, opts RestConfigOptions) (dynamic.Interface, error) {
+	config, err := instrumentedRESTConfig(kubeConfig, apiServerURL, requestTimeout, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -535,8 +678,8 @@ func NewDynamicKubernetesClient(kubeConfig, apiServerURL string, requestTimeout
 // NewOpenShiftClient returns a new Openshift client object. It takes a Config and
 // uses APIServerURL and KubeConfig attributes to connect to the cluster. If
 // KubeConfig isn't provided it defaults to using the recommended default.
-func NewOpenShiftClient(kubeConfig, apiServerURL string, requestTimeout time.Duration) (*openshift.Clientset, error) {
-	config, err := instrumentedRESTConfig(kubeConfig, apiServerURL, requestTimeout)
+func NewOpenShiftClient(kubeConfig, apiServerURL string, requestTimeout time.Duration, opts RestConfigOptions) (*openshift.Clientset, error) {
+	config, err := instrumentedRESTConfig(kubeConfig, apiServerURL, requestTimeout, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -18,12 +18,27 @@ package source
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
 	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	cfclient "github.com/cloudfoundry-community/go-cfclient"
 	openshift "github.com/openshift/client-go/route/clientset/versioned"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	istioclient "istio.io/client-go/pkg/clientset/versioned"
 	istiofake "istio.io/client-go/pkg/clientset/versioned/fake"
@@ -33,7 +48,10 @@ import (
 	fakeDynamic "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes"
 	fakeKube "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
 	gateway "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
 )
 
 type MockClientGenerator struct {
@@ -239,3 +257,198 @@ func (suite *ByNamesTestSuite) TestDynamicKubernetesClientFails() {
 func TestByNames(t *testing.T) {
 	suite.Run(t, new(ByNamesTestSuite))
 }
+
+func TestNewSourceConfigPublishInternal(t *testing.T) {
+	for _, tt := range []struct {
+		name             string
+		publishInternal  bool
+		includeClusterIP bool
+	}{
+		{name: "disabled"},
+		{name: "publish internal services", publishInternal: true},
+		{name: "include cluster ip", includeClusterIP: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewSourceConfig(&externaldns.Config{PublishInternal: tt.publishInternal, IncludeClusterIP: tt.includeClusterIP})
+			assert.Equal(t, tt.publishInternal, cfg.PublishInternal)
+			assert.Equal(t, tt.includeClusterIP, cfg.IncludeClusterIP)
+		})
+	}
+}
+
+func TestNewSourceConfigSplitsLists(t *testing.T) {
+	cfg := NewSourceConfig(&externaldns.Config{FederationKubeConfig: "/a, /b,", LoadBalancerClassFilter: []string{"aws,", "metallb"}})
+	assert.Equal(t, []string{"/a", "/b"}, cfg.FederationKubeConfigs)
+	// an empty class matches the services without class
+	assert.Equal(t, []string{"aws", "", "metallb"}, cfg.LoadBalancerClassFilter)
+}
+
+// newTestCA returns a self-signed CA certificate and its PEM encoding.
+func newTestCA(t *testing.T, name string) (*x509.Certificate, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// writeTestKubeConfig writes a kubeconfig trusting the given CA for the API server.
+func writeTestKubeConfig(t *testing.T, ca []byte) string {
+	kubeConfig := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(kubeConfig, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: cluster
+  cluster:
+    server: https://127.0.0.1:6443
+    certificate-authority-data: `+base64.StdEncoding.EncodeToString(ca)+`
+contexts:
+- name: context
+  context:
+    cluster: cluster
+    user: user
+current-context: context
+users:
+- name: user
+  user:
+    token: token
+`), 0o600))
+	return kubeConfig
+}
+
+func TestGetRestConfigCAFile(t *testing.T) {
+	kubeConfigCA, kubeConfigCAPEM := newTestCA(t, "kubeconfig-ca")
+	privateCA, privateCAPEM := newTestCA(t, "private-ca")
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, privateCAPEM, 0o600))
+
+	config, err := GetRestConfig(writeTestKubeConfig(t, kubeConfigCAPEM), "", RestConfigOptions{CAFile: caFile})
+	require.NoError(t, err)
+
+	tlsConfig, err := rest.TLSConfigFor(config)
+	require.NoError(t, err)
+	require.NotNil(t, tlsConfig.RootCAs)
+	assert.False(t, tlsConfig.InsecureSkipVerify)
+	for _, ca := range []*x509.Certificate{privateCA, kubeConfigCA} {
+		_, err := ca.Verify(x509.VerifyOptions{Roots: tlsConfig.RootCAs})
+		assert.NoError(t, err, "%s must be in the root CA pool", ca.Subject.CommonName)
+	}
+}
+
+func TestGetRestConfigInvalidCAFile(t *testing.T) {
+	_, kubeConfigCAPEM := newTestCA(t, "kubeconfig-ca")
+	kubeConfig := writeTestKubeConfig(t, kubeConfigCAPEM)
+
+	_, err := GetRestConfig(kubeConfig, "", RestConfigOptions{CAFile: filepath.Join(t.TempDir(), "missing.crt")})
+	assert.ErrorContains(t, err, "failed to read the Kubernetes CA file")
+
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, []byte("not a certificate"), 0o600))
+	_, err = GetRestConfig(kubeConfig, "", RestConfigOptions{CAFile: caFile})
+	assert.ErrorContains(t, err, "no PEM encoded certificate found in the Kubernetes CA file")
+}
+
+func TestGetRestConfigSkipTLSVerify(t *testing.T) {
+	_, kubeConfigCAPEM := newTestCA(t, "kubeconfig-ca")
+
+	config, err := GetRestConfig(writeTestKubeConfig(t, kubeConfigCAPEM), "", RestConfigOptions{SkipTLSVerify: true})
+	require.NoError(t, err)
+
+	tlsConfig, err := rest.TLSConfigFor(config)
+	require.NoError(t, err)
+	assert.True(t, tlsConfig.InsecureSkipVerify)
+	assert.Nil(t, tlsConfig.RootCAs)
+}
+
+func TestGetRestConfigContext(t *testing.T) {
+	kubeConfig := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(kubeConfig, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: first
+  cluster:
+    server: https://first.example.com:6443
+- name: second
+  cluster:
+    server: https://second.example.com:6443
+contexts:
+- name: first
+  context:
+    cluster: first
+    user: first
+- name: second
+  context:
+    cluster: second
+    user: second
+current-context: first
+users:
+- name: first
+  user:
+    token: first-token
+- name: second
+  user:
+    token: second-token
+`), 0o600))
+
+	config, err := GetRestConfig(kubeConfig, "", RestConfigOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "https://first.example.com:6443", config.Host)
+	assert.Equal(t, "first-token", config.BearerToken)
+
+	config, err = GetRestConfig(kubeConfig, "", RestConfigOptions{Context: "second"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://second.example.com:6443", config.Host)
+	assert.Equal(t, "second-token", config.BearerToken)
+
+	// the API server URL still takes precedence over the cluster of the context
+	config, err = GetRestConfig(kubeConfig, "https://api.example.com", RestConfigOptions{Context: "second"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.example.com", config.Host)
+	assert.Equal(t, "second-token", config.BearerToken)
+
+	_, err = GetRestConfig(kubeConfig, "", RestConfigOptions{Context: "missing"})
+	assert.ErrorContains(t, err, `context "missing" does not exist`)
+}
+
+func TestGetRestConfigImpersonation(t *testing.T) {
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"major":"1","minor":"33","gitVersion":"v1.33.0"}`))
+	}))
+	defer server.Close()
+
+	_, ca := newTestCA(t, "kubeconfig-ca")
+	config, err := GetRestConfig(writeTestKubeConfig(t, ca), server.URL, RestConfigOptions{
+		ImpersonateUser:   "system:serviceaccount:default:external-dns",
+		ImpersonateGroups: []string{"system:serviceaccounts", "dns-admins"},
+	})
+	require.NoError(t, err)
+
+	client, err := kubernetes.NewForConfig(config)
+	require.NoError(t, err)
+	_, err = client.Discovery().ServerVersion()
+	require.NoError(t, err)
+
+	assert.Equal(t, "system:serviceaccount:default:external-dns", headers.Get("Impersonate-User"))
+	assert.Equal(t, []string{"system:serviceaccounts", "dns-admins"}, headers.Values("Impersonate-Group"))
+}
+
+func TestGetRestConfigWithoutImpersonation(t *testing.T) {
+	_, ca := newTestCA(t, "kubeconfig-ca")
+	config, err := GetRestConfig(writeTestKubeConfig(t, ca), "", RestConfigOptions{ImpersonateGroups: []string{"ignored"}})
+	require.NoError(t, err)
+	assert.Empty(t, config.Impersonate)
+}
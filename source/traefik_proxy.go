@@ -95,10 +95,9 @@ type traefikSource struct {
 	unstructuredConverter      *unstructuredConverter
 }
 
-func NewTraefikSource(ctx context.Context, dynamicKubeClient dynamic.Interface, kubeClient kubernetes.Interface, namespace string, annotationFilter string, ignoreHostnameAnnotation bool, disableLegacy bool, disableNew bool) (Source, error) {
+func NewTraefikSource(ctx context.Context, dynamicKubeClient dynamic.Interface, kubeClient kubernetes.Interface, namespace string, annotationFilter string, ignoreHostnameAnnotation bool, disableLegacy bool, disableNew bool, informerOpts informers.Options) (Source, error) {
 	// Use shared informer to listen for add/update/delete of Host in the specified namespace.
-	// Set resync period to 0, to prevent processing when nothing has changed.
-	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, 0, namespace, nil)
+	informerFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicKubeClient, informerOpts.ResyncPeriod, namespace, nil)
 	var ingressRouteInformer, ingressRouteTcpInformer, ingressRouteUdpInformer kubeinformers.GenericInformer
 	var oldIngressRouteInformer, oldIngressRouteTcpInformer, oldIngressRouteUdpInformer kubeinformers.GenericInformer
 
@@ -122,6 +121,7 @@ func NewTraefikSource(ctx context.Context, dynamicKubeClient dynamic.Interface,
 				AddFunc: func(obj interface{}) {},
 			},
 		)
+		informers.SetWatchBackoff(informerOpts.WatchBackoffMaxDelay, ingressRouteInformer.Informer(), ingressRouteTcpInformer.Informer(), ingressRouteUdpInformer.Informer())
 	}
 	if !disableLegacy {
 		oldIngressRouteInformer = informerFactory.ForResource(oldIngressrouteGVR)
@@ -142,6 +142,7 @@ func NewTraefikSource(ctx context.Context, dynamicKubeClient dynamic.Interface,
 				AddFunc: func(obj interface{}) {},
 			},
 		)
+		informers.SetWatchBackoff(informerOpts.WatchBackoffMaxDelay, oldIngressRouteInformer.Informer(), oldIngressRouteTcpInformer.Informer(), oldIngressRouteUdpInformer.Informer())
 	}
 
 	informerFactory.Start((ctx.Done()))
@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// transformWebhookSource is a Source that passes the endpoints of its wrapped source
+// through a webhook, which can filter, annotate or rewrite them.
+type transformWebhookSource struct {
+	source Source
+	url    string
+	client *http.Client
+}
+
+// NewTransformWebhookSource creates a new transformWebhookSource wrapping the provided Source.
+// The endpoints are POSTed to the url as a JSON array and replaced by the array of the response.
+func NewTransformWebhookSource(source Source, url string, timeout time.Duration) Source {
+	return &transformWebhookSource{
+		source: source,
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Endpoints collects endpoints from its wrapped source and returns them as transformed by the webhook.
+// When the webhook times out the endpoints are returned unchanged, any other failure aborts the synchronization.
+func (ts *transformWebhookSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := ts.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	transformed, err := ts.transform(ctx, endpoints)
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			log.Warnf("Endpoint transform webhook timed out, using the endpoints unchanged: %v", err)
+			return endpoints, nil
+		}
+		return nil, fmt.Errorf("endpoint transform webhook: %w", err)
+	}
+	return transformed, nil
+}
+
+func (ts *transformWebhookSource) transform(ctx context.Context, endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	if endpoints == nil {
+		endpoints = []*endpoint.Endpoint{}
+	}
+	body, err := json.Marshal(endpoints)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ts.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := ts.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	var result []*endpoint.Endpoint
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	log.Debugf("Endpoint transform webhook returned %d of %d endpoints", len(result), len(endpoints))
+	return result, nil
+}
+
+func (ts *transformWebhookSource) AddEventHandler(ctx context.Context, handler func()) {
+	ts.source.AddEventHandler(ctx, handler)
+}
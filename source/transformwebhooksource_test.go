@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+)
+
+func TestTransformWebhookSource(t *testing.T) {
+	endpoints := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("internal.example.com", endpoint.RecordTypeA, "10.0.0.1"),
+	}
+
+	tests := []struct {
+		name     string
+		handler  http.HandlerFunc
+		expected []*endpoint.Endpoint
+		err      string
+	}{
+		{
+			name: "endpoints are replaced by the response",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+				var received []*endpoint.Endpoint
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+				require.Len(t, received, 2)
+
+				// drop the internal record and rewrite the target of the other one
+				received[0].Targets = endpoint.Targets{"5.6.7.8"}
+				received[0].WithLabel("team", "dns")
+				_ = json.NewEncoder(w).Encode(received[:1])
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "5.6.7.8").WithLabel("team", "dns"),
+			},
+		},
+		{
+			name: "endpoints pass through on timeout",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(200 * time.Millisecond)
+				_, _ = w.Write([]byte("[]"))
+			},
+			expected: endpoints,
+		},
+		{
+			name: "error response aborts the sync",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "transformation failed", http.StatusInternalServerError)
+			},
+			err: "endpoint transform webhook: unexpected status 500: transformation failed",
+		},
+		{
+			name: "invalid response aborts the sync",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("{"))
+			},
+			err: "endpoint transform webhook: decoding response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			src := NewTransformWebhookSource(NewEchoSource(endpoints), server.URL, 100*time.Millisecond)
+			result, err := src.Endpoints(context.Background())
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestTransformWebhookSourceSourceError(t *testing.T) {
+	mockSource := new(testutils.MockSource)
+	mockSource.On("Endpoints").Return([]*endpoint.Endpoint{}, errors.New("source failed"))
+	src := NewTransformWebhookSource(mockSource, "http://127.0.0.1:0", time.Second)
+
+	_, err := src.Endpoints(context.Background())
+	assert.EqualError(t, err, "source failed")
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// webhookSource is an implementation of Source that provides endpoints by querying
+// a remote HTTP server, which returns them as a JSON array.
+type webhookSource struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSource creates a new webhookSource querying the given url.
+func NewWebhookSource(url string, timeout time.Duration) (Source, error) {
+	if url == "" {
+		return nil, errors.New("webhook source requires --webhook-source-url")
+	}
+	return &webhookSource{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Endpoints returns the endpoints returned by the webhook.
+func (ws *webhookSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ws.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := ws.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("webhook source: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("webhook source: unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	endpoints := []*endpoint.Endpoint{}
+	if err := json.NewDecoder(resp.Body).Decode(&endpoints); err != nil {
+		return nil, fmt.Errorf("webhook source: decoding response: %w", err)
+	}
+	log.Debugf("Webhook source returned %d endpoints", len(endpoints))
+
+	// a null response is handled as an empty array, and null elements are skipped
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for i, ep := range endpoints {
+		if ep == nil {
+			log.Warnf("Webhook source: skipping the null endpoint at index %d", i)
+			continue
+		}
+		if ep.Labels == nil {
+			ep.Labels = endpoint.NewLabels()
+		}
+		result = append(result, ep)
+	}
+	return result, nil
+}
+
+func (ws *webhookSource) AddEventHandler(ctx context.Context, handler func()) {
+}
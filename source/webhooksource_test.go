@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestWebhookSource(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  http.HandlerFunc
+		expected []*endpoint.Endpoint
+		err      string
+	}{
+		{
+			name: "endpoints are returned",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Accept"))
+				_, _ = w.Write([]byte(`[{"dnsName":"foo.example.com","targets":["1.2.3.4"],"recordType":"A","recordTTL":300},` +
+					`{"dnsName":"bar.example.com","targets":["foo.example.com"],"recordType":"CNAME","labels":{"team":"dns"}}]`))
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("foo.example.com", endpoint.RecordTypeA, 300, "1.2.3.4"),
+				endpoint.NewEndpoint("bar.example.com", endpoint.RecordTypeCNAME, "foo.example.com").WithLabel("team", "dns"),
+			},
+		},
+		{
+			name: "empty response",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("[]"))
+			},
+			expected: []*endpoint.Endpoint{},
+		},
+		{
+			name: "null response",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("null"))
+			},
+			expected: []*endpoint.Endpoint{},
+		},
+		{
+			name: "null endpoints are skipped",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`[null,{"dnsName":"foo.example.com","targets":["1.2.3.4"],"recordType":"A"},null]`))
+			},
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+			},
+		},
+		{
+			name: "timeout",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(200 * time.Millisecond)
+				_, _ = w.Write([]byte("[]"))
+			},
+			err: "Client.Timeout exceeded",
+		},
+		{
+			name: "server error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "inventory unavailable", http.StatusServiceUnavailable)
+			},
+			err: "webhook source: unexpected status 503: inventory unavailable",
+		},
+		{
+			name: "not found",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.NotFound(w, r)
+			},
+			err: "webhook source: unexpected status 404: 404 page not found",
+		},
+		{
+			name: "invalid response",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"dnsName":"foo.example.com"}`))
+			},
+			err: "webhook source: decoding response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			src, err := NewWebhookSource(server.URL, 100*time.Millisecond)
+			require.NoError(t, err)
+
+			endpoints, err := src.Endpoints(context.Background())
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				assert.Nil(t, endpoints)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, endpoints)
+		})
+	}
+}
+
+func TestNewWebhookSourceRequiresURL(t *testing.T) {
+	_, err := NewWebhookSource("", time.Second)
+	assert.Error(t, err)
+}